@@ -36,7 +36,8 @@ type Addenda02 struct {
 	// or codes that the merchant needs to identify the particular transaction or customer.
 	ReferenceInformationOne string `json:"referenceInformationOne,omitempty"`
 	// ReferenceInformationTwo  may be used for additional reference numbers, identification numbers,
-	// or codes that the merchant needs to identify the particular transaction or customer.
+	// or codes that the merchant needs to identify the particular transaction or customer. Card networks
+	// commonly carry their network identification code here.
 	ReferenceInformationTwo string `json:"referenceInformationTwo,omitempty"`
 	// TerminalIdentificationCode identifies an Electronic terminal with a unique code that allows
 	// a terminal owner and/or switching network to identify the terminal at which an Entry originated.
@@ -65,6 +66,8 @@ type Addenda02 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // NewAddenda02 returns a new Addenda02 with default values for none exported fields
@@ -180,6 +183,40 @@ func (addenda02 *Addenda02) Validate() error {
 	if err := addenda02.isAlphanumeric(addenda02.TerminalState); err != nil {
 		return fieldError("TerminalState", err, addenda02.TerminalState)
 	}
+	return addenda02.validateTerminal()
+}
+
+// validateTerminal applies the card-present rules for POS, MTE and SHR entries that ACH Operators
+// enforce on the terminal fields: values must fit their positions without truncation, the
+// TerminalIdentificationCode must be uppercase and not all zeros, and the network identification
+// carried in ReferenceInformationTwo must be uppercase.
+func (addenda02 *Addenda02) validateTerminal() error {
+	fields := []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"ReferenceInformationOne", addenda02.ReferenceInformationOne, 7},
+		{"ReferenceInformationTwo", addenda02.ReferenceInformationTwo, 3},
+		{"TerminalIdentificationCode", addenda02.TerminalIdentificationCode, 6},
+		{"TransactionSerialNumber", addenda02.TransactionSerialNumber, 6},
+		{"TransactionDate", addenda02.TransactionDate, 4},
+		{"AuthorizationCodeOrExpireDate", addenda02.AuthorizationCodeOrExpireDate, 6},
+		{"TerminalLocation", addenda02.TerminalLocation, 27},
+		{"TerminalCity", addenda02.TerminalCity, 15},
+		{"TerminalState", addenda02.TerminalState, 2},
+	}
+	for _, f := range fields {
+		if utf8.RuneCountInString(f.value) > f.max {
+			return fieldError(f.name, ErrFieldTooLong, f.value)
+		}
+	}
+	if addenda02.isUpperAlphanumeric(addenda02.TerminalIdentificationCode) != nil || strings.Trim(addenda02.TerminalIdentificationCode, "0") == "" {
+		return fieldError("TerminalIdentificationCode", ErrAddenda02TerminalIdentificationCode, addenda02.TerminalIdentificationCode)
+	}
+	if addenda02.isUpperAlphanumeric(addenda02.ReferenceInformationTwo) != nil || strings.Contains(addenda02.ReferenceInformationTwo, " ") {
+		return fieldError("ReferenceInformationTwo", ErrAddenda02NetworkIdentification, addenda02.ReferenceInformationTwo)
+	}
 	return nil
 }
 
@@ -222,7 +259,7 @@ func (addenda02 *Addenda02) ReferenceInformationOneField() string {
 
 // ReferenceInformationTwoField returns a space padded ReferenceInformationTwo string
 func (addenda02 *Addenda02) ReferenceInformationTwoField() string {
-	return addenda02.alphaField(addenda02.ReferenceInformationOne, 3)
+	return addenda02.alphaField(addenda02.ReferenceInformationTwo, 3)
 }
 
 // TerminalIdentificationCodeField returns a space padded TerminalIdentificationCode string
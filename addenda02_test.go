@@ -661,3 +661,57 @@ func TestAddenda02RuneCountInString(t *testing.T) {
 		t.Error("Parsed with an invalid RuneCountInString not equal to 94")
 	}
 }
+
+func TestAddenda02__validateTerminal(t *testing.T) {
+	addenda02 := mockAddenda02()
+	addenda02.TerminalLocation = "Target Store 0049 at 321 East Market Street"
+	if err := addenda02.Validate(); !base.Match(err, ErrFieldTooLong) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	addenda02 = mockAddenda02()
+	addenda02.TerminalCity = "SOUTH PHILADELPHIA"
+	if err := addenda02.Validate(); !base.Match(err, ErrFieldTooLong) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	addenda02 = mockAddenda02()
+	addenda02.TerminalIdentificationCode = "TERMINAL02"
+	if err := addenda02.Validate(); !base.Match(err, ErrFieldTooLong) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	for _, code := range []string{"000000", "term02"} {
+		addenda02 = mockAddenda02()
+		addenda02.TerminalIdentificationCode = code
+		if err := addenda02.Validate(); !base.Match(err, ErrAddenda02TerminalIdentificationCode) {
+			t.Errorf("%s: %T: %s", code, err, err)
+		}
+	}
+
+	for _, code := range []string{"ab", "A B"} {
+		addenda02 = mockAddenda02()
+		addenda02.ReferenceInformationTwo = code
+		if err := addenda02.Validate(); !base.Match(err, ErrAddenda02NetworkIdentification) {
+			t.Errorf("%s: %T: %s", code, err, err)
+		}
+	}
+
+	// network identification is optional
+	addenda02 = mockAddenda02()
+	addenda02.ReferenceInformationTwo = ""
+	if err := addenda02.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+func TestAddenda02__ReferenceInformationTwoField(t *testing.T) {
+	addenda02 := mockAddenda02()
+	addenda02.ReferenceInformationTwo = "VS"
+	if v := addenda02.ReferenceInformationTwoField(); v != "VS " {
+		t.Errorf("got %q", v)
+	}
+	if v := addenda02.String(); v[10:13] != "VS " {
+		t.Errorf("got %q", v[10:13])
+	}
+}
@@ -17,10 +17,7 @@
 
 package ach
 
-import (
-	"strings"
-	"unicode/utf8"
-)
+import "unicode/utf8"
 
 // Addenda05 is a Addendumer addenda which provides business transaction information for Addenda Type
 // Code 05 in a machine readable format. It is usually formatted according to ANSI, ASC, X12 Standard.
@@ -29,24 +26,26 @@ type Addenda05 struct {
 	// ID is a client defined string used as a reference to this record.
 	ID string `json:"id"`
 	// RecordType defines the type of record in the block. entryAddenda05 Pos 7
-	recordType string
+	recordType string `ach:"1-1,alpha"`
 	// TypeCode Addenda05 types code '05'
-	TypeCode string `json:"typeCode"`
+	TypeCode string `json:"typeCode" ach:"2-3,alpha"`
 	// PaymentRelatedInformation
-	PaymentRelatedInformation string `json:"paymentRelatedInformation"`
+	PaymentRelatedInformation string `json:"paymentRelatedInformation" ach:"4-83,alpha"`
 	// SequenceNumber is consecutively assigned to each Addenda05 Record following
 	// an Entry Detail Record. The first addenda05 sequence number must always
 	// be a "1".
-	SequenceNumber int `json:"sequenceNumber,omitempty"`
+	SequenceNumber int `json:"sequenceNumber,omitempty" ach:"84-87,numeric"`
 	// EntryDetailSequenceNumber contains the ascending sequence number section of the Entry
 	// Detail or Corporate Entry Detail Record's trace number This number is
 	// the same as the last seven digits of the trace number of the related
 	// Entry Detail Record or Corporate Entry Detail Record.
-	EntryDetailSequenceNumber int `json:"entryDetailSequenceNumber,omitempty"`
+	EntryDetailSequenceNumber int `json:"entryDetailSequenceNumber,omitempty" ach:"88-94,numeric"`
 	// validator is composed for data validation
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // NewAddenda05 returns a new Addenda05 with default values for none exported fields
@@ -64,30 +63,7 @@ func (addenda05 *Addenda05) Parse(record string) {
 	if utf8.RuneCountInString(record) != 94 {
 		return
 	}
-
-	// 1-1 Always "7"
-	addenda05.recordType = "7"
-	// 2-3 Always 05
-	addenda05.TypeCode = record[1:3]
-	// 4-83 Based on the information entered (04-83) 80 alphanumeric
-	addenda05.PaymentRelatedInformation = strings.TrimSpace(record[3:83])
-	// 84-87 SequenceNumber is consecutively assigned to each Addenda05 Record following
-	// an Entry Detail Record
-	addenda05.SequenceNumber = addenda05.parseNumField(record[83:87])
-	// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
-	addenda05.EntryDetailSequenceNumber = addenda05.parseNumField(record[87:94])
-}
-
-// String writes the Addenda05 struct to a 94 character string.
-func (addenda05 *Addenda05) String() string {
-	var buf strings.Builder
-	buf.Grow(94)
-	buf.WriteString(addenda05.recordType)
-	buf.WriteString(addenda05.TypeCode)
-	buf.WriteString(addenda05.PaymentRelatedInformationField())
-	buf.WriteString(addenda05.SequenceNumberField())
-	buf.WriteString(addenda05.EntryDetailSequenceNumberField())
-	return buf.String()
+	addenda05.parseFields(record)
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -60,6 +60,8 @@ type Addenda10 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // NewAddenda10 returns a new Addenda10 with default values for none exported fields
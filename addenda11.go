@@ -51,6 +51,8 @@ type Addenda11 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // NewAddenda11 returns a new Addenda11 with default values for none exported fields
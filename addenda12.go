@@ -56,6 +56,8 @@ type Addenda12 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // NewAddenda12 returns a new Addenda12 with default values for none exported fields
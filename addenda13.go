@@ -72,6 +72,8 @@ type Addenda13 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // NewAddenda13 returns a new Addenda13 with default values for none exported fields
@@ -68,6 +68,8 @@ type Addenda14 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // NewAddenda14 returns a new Addenda14 with default values for none exported fields
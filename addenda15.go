@@ -52,6 +52,8 @@ type Addenda15 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // NewAddenda15 returns a new Addenda15 with default values for none exported fields
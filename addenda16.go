@@ -55,6 +55,8 @@ type Addenda16 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // NewAddenda16 returns a new Addenda16 with default values for none exported fields
@@ -51,6 +51,8 @@ type Addenda17 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // NewAddenda17 returns a new Addenda17 with default values for none exported fields
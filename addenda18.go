@@ -20,6 +20,8 @@ package ach
 import (
 	"strings"
 	"unicode/utf8"
+
+	"github.com/moov-io/ach/internal/iso3166"
 )
 
 // Addenda18 is an addenda which provides business transaction information for Addenda Type
@@ -69,6 +71,8 @@ type Addenda18 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // NewAddenda18 returns a new Addenda18 with default values for none exported fields
@@ -149,12 +153,18 @@ func (addenda18 *Addenda18) Validate() error {
 	if err := addenda18.isAlphanumeric(addenda18.ForeignCorrespondentBankIDNumberQualifier); err != nil {
 		return fieldError("ForeignCorrespondentBankIDNumberQualifier", err, addenda18.ForeignCorrespondentBankIDNumberQualifier)
 	}
+	if err := addenda18.isIDNumberQualifier(addenda18.ForeignCorrespondentBankIDNumberQualifier); err != nil {
+		return fieldError("ForeignCorrespondentBankIDNumberQualifier", err, addenda18.ForeignCorrespondentBankIDNumberQualifier)
+	}
 	if err := addenda18.isAlphanumeric(addenda18.ForeignCorrespondentBankIDNumber); err != nil {
 		return fieldError("ForeignCorrespondentBankIDNumber", err, addenda18.ForeignCorrespondentBankIDNumber)
 	}
 	if err := addenda18.isAlphanumeric(addenda18.ForeignCorrespondentBankBranchCountryCode); err != nil {
 		return fieldError("ForeignCorrespondentBankBranchCountryCode", err, addenda18.ForeignCorrespondentBankBranchCountryCode)
 	}
+	if !iso3166.Valid(addenda18.ForeignCorrespondentBankBranchCountryCode) {
+		return fieldError("ForeignCorrespondentBankBranchCountryCode", ErrValidISO3166, addenda18.ForeignCorrespondentBankBranchCountryCode)
+	}
 	return nil
 }
 
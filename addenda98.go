@@ -19,6 +19,7 @@ package ach
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode/utf8"
@@ -30,38 +31,42 @@ type Addenda98 struct {
 	// ID is a client defined string used as a reference to this record.
 	ID string `json:"id"`
 	// RecordType defines the type of record in the block. entryAddendaPos 7
-	recordType string
+	recordType string `ach:"1-1,alpha"`
 	// TypeCode Addenda types code '98'
-	TypeCode string `json:"typeCode"`
+	TypeCode string `json:"typeCode" ach:"2-3,alpha"`
 	// ChangeCode field contains a standard code used by an ACH Operator or RDFI to describe the reason for a change Entry.
 	// Must exist in changeCodeDict
-	ChangeCode string `json:"changeCode"`
+	ChangeCode string `json:"changeCode" ach:"4-6,alpha"`
 	// OriginalTrace This field contains the Trace Number as originally included on the forward Entry or Prenotification.
 	// The RDFI must include the Original Entry Trace Number in the Addenda Record of an Entry being returned to an ODFI,
 	// in the Addenda Record of an 98, within an Acknowledgment Entry, or with an RDFI request for a copy of an authorization.
-	OriginalTrace string `json:"originalTrace"`
+	OriginalTrace string `json:"originalTrace" ach:"7-21,string"`
 	// OriginalDFI field contains the Receiving DFI Identification (addenda.RDFIIdentification) as originally included on the forward Entry or Prenotification that the RDFI is returning or correcting.
-	OriginalDFI string `json:"originalDFI"`
+	OriginalDFI string `json:"originalDFI" ach:"28-35,string"`
 	// CorrectedData
-	CorrectedData string `json:"correctedData"`
+	CorrectedData string `json:"correctedData" ach:"36-64,alpha"`
 	// TraceNumber matches the Entry Detail Trace Number of the entry being returned.
 	//
 	// Use TraceNumberField() for a properly formatted string representation.
-	TraceNumber string `json:"traceNumber,omitempty"`
+	TraceNumber string `json:"traceNumber,omitempty" ach:"80-94,string"`
 
 	// validator is composed for data validation
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 var (
-	changeCodeDict = map[string]*ChangeCode{}
+	changeCodeDict        = map[string]*ChangeCode{}
+	refusedChangeCodeDict = map[string]*ChangeCode{}
 )
 
 func init() {
 	// populate the changeCode map with lookup values
 	changeCodeDict = makeChangeCodeDict()
+	refusedChangeCodeDict = makeRefusedChangeCodeDict()
 }
 
 // ChangeCode holds a change Code, Reason/Title, and Description
@@ -72,6 +77,12 @@ type ChangeCode struct {
 	Description string `json:"description"`
 }
 
+// clone returns a copy of the ChangeCode so callers can't modify the shared table of change codes
+func (code *ChangeCode) clone() *ChangeCode {
+	out := *code
+	return &out
+}
+
 // NewAddenda98 returns an reference to an instantiated Addenda98 with default values
 func NewAddenda98() *Addenda98 {
 	addenda98 := &Addenda98{
@@ -88,37 +99,7 @@ func (addenda98 *Addenda98) Parse(record string) {
 	if utf8.RuneCountInString(record) != 94 {
 		return
 	}
-
-	// 1-1 Always "7"
-	addenda98.recordType = "7"
-	// 2-3 Always "98"
-	addenda98.TypeCode = record[1:3]
-	// 4-6
-	addenda98.ChangeCode = record[3:6]
-	// 7-21
-	addenda98.OriginalTrace = strings.TrimSpace(record[6:21])
-	// 28-35
-	addenda98.OriginalDFI = addenda98.parseStringField(record[27:35])
-	// 36-64
-	addenda98.CorrectedData = strings.TrimSpace(record[35:64])
-	// 80-94
-	addenda98.TraceNumber = strings.TrimSpace(record[79:94])
-}
-
-// String writes the Addenda98 struct to a 94 character string
-func (addenda98 *Addenda98) String() string {
-	var buf strings.Builder
-	buf.Grow(94)
-	buf.WriteString(addenda98.recordType)
-	buf.WriteString(addenda98.TypeCode)
-	buf.WriteString(addenda98.ChangeCode)
-	buf.WriteString(addenda98.OriginalTraceField())
-	buf.WriteString("      ") // 6 char reserved field
-	buf.WriteString(addenda98.OriginalDFIField())
-	buf.WriteString(addenda98.CorrectedDataField())
-	buf.WriteString("               ") // 15 char reserved field
-	buf.WriteString(addenda98.TraceNumberField())
-	return buf.String()
+	addenda98.parseFields(record)
 }
 
 // Validate verifies NACHA rules for Addenda98
@@ -144,10 +125,68 @@ func (addenda98 *Addenda98) Validate() error {
 	if addenda98.CorrectedData == "" {
 		return fieldError("CorrectedData", ErrAddenda98CorrectedData, addenda98.CorrectedData)
 	}
+	if err := addenda98.validateCorrectedData(); err != nil {
+		return fieldError("CorrectedData", err, addenda98.CorrectedData)
+	}
 
 	return nil
 }
 
+// validateCorrectedData verifies CorrectedData is formatted the way ChangeCode requires,
+// for example a nine digit routing number for C02 or an account number and transaction code for C06.
+func (addenda98 *Addenda98) validateCorrectedData() error {
+	data := strings.TrimSpace(addenda98.CorrectedData)
+	if err := addenda98.isAlphanumeric(data); err != nil {
+		return err
+	}
+	account := func(v string) bool {
+		return v != "" && utf8.RuneCountInString(v) <= 17
+	}
+	routing := func(v string) bool {
+		return len(v) == 9 && CheckRoutingNumber(v) == nil
+	}
+	transactionCode := func(v string) bool {
+		n, err := strconv.Atoi(v)
+		return err == nil && len(v) == 2 && addenda98.isTransactionCode(n) == nil
+	}
+	maxLength := func(v string, n int) bool {
+		return utf8.RuneCountInString(v) <= n
+	}
+
+	ok := true
+	parts := strings.Fields(data)
+	switch addenda98.ChangeCode {
+	case "C01": // Incorrect DFI Account Number
+		ok = len(parts) == 1 && account(data)
+	case "C02": // Incorrect Routing Number
+		ok = routing(data)
+	case "C03": // Incorrect Routing Number and Incorrect DFI Account Number
+		ok = len(parts) == 2 && routing(parts[0]) && account(parts[1])
+	case "C04", "C09": // Incorrect Individual Name or Individual Identification Number
+		ok = maxLength(data, 22)
+	case "C05": // Incorrect Transaction Code
+		ok = transactionCode(data)
+	case "C06": // Incorrect DFI Account Number and Incorrect Transaction Code
+		ok = len(parts) == 2 && account(parts[0]) && transactionCode(parts[1])
+	case "C07": // Incorrect Routing Number, Incorrect DFI Account Number, and Incorrect Transaction Code
+		ok = len(data) > 9 && routing(data[:9])
+		if ok {
+			parts = strings.Fields(data[9:])
+			ok = len(parts) == 2 && account(parts[0]) && transactionCode(parts[1])
+		}
+	case "C10": // Incorrect Company Name
+		ok = maxLength(data, 16)
+	case "C11": // Incorrect Company Identification
+		ok = maxLength(data, 10)
+	case "C12": // Incorrect Company Name and Company Identification
+		ok = len(data) > 16 && strings.TrimSpace(data[:16]) != "" && maxLength(strings.TrimSpace(data[16:]), 10)
+	}
+	if !ok {
+		return ErrAddenda98CorrectedData
+	}
+	return nil
+}
+
 // OriginalTraceField returns a zero padded OriginalTrace string
 func (addenda98 *Addenda98) OriginalTraceField() string {
 	return addenda98.stringField(addenda98.OriginalTrace, 15)
@@ -171,7 +210,7 @@ func (addenda98 *Addenda98) TraceNumberField() string {
 func (addenda98 *Addenda98) ChangeCodeField() *ChangeCode {
 	code, ok := changeCodeDict[addenda98.ChangeCode]
 	if ok {
-		return code
+		return code.clone()
 	}
 	return nil
 }
@@ -180,11 +219,47 @@ func (addenda98 *Addenda98) ChangeCodeField() *ChangeCode {
 // the provided NACHA change code.
 func LookupChangeCode(code string) *ChangeCode {
 	if code, exists := changeCodeDict[strings.ToUpper(code)]; exists {
-		return code
+		return code.clone()
 	}
 	return nil
 }
 
+// LookupRefusedChangeCode will return a struct representing the reason and description for
+// the provided NACHA refused Notification of Change code (C61-C69).
+func LookupRefusedChangeCode(code string) *ChangeCode {
+	if code, exists := refusedChangeCodeDict[strings.ToUpper(code)]; exists {
+		return code.clone()
+	}
+	return nil
+}
+
+// IsRefusedChangeCode returns true for codes an ODFI uses to refuse a Notification of Change (C61-C69).
+func IsRefusedChangeCode(code string) bool {
+	return LookupRefusedChangeCode(code) != nil
+}
+
+// ChangeCodes returns every NACHA change code sorted by code.
+func ChangeCodes() []*ChangeCode {
+	return sortedChangeCodes(changeCodeDict)
+}
+
+// RefusedChangeCodes returns every NACHA refused Notification of Change code sorted by code.
+//
+// Refused Notifications of Change use a different addenda layout than Addenda98, so these
+// codes are not accepted as an Addenda98 ChangeCode.
+func RefusedChangeCodes() []*ChangeCode {
+	return sortedChangeCodes(refusedChangeCodeDict)
+}
+
+func sortedChangeCodes(dict map[string]*ChangeCode) []*ChangeCode {
+	out := make([]*ChangeCode, 0, len(dict))
+	for _, code := range dict {
+		out = append(out, code.clone())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
+
 func makeChangeCodeDict() map[string]*ChangeCode {
 	dict := make(map[string]*ChangeCode)
 
@@ -196,10 +271,33 @@ func makeChangeCodeDict() map[string]*ChangeCode {
 		{"C05", "Incorrect payment code", "Entry posted to demand account should contain savings payment codes or vice versa"},
 		{"C06", "Incorrect bank account number and transit code", "Bank account number must be changed and payment code should indicate posting to another account type (demand/savings)"},
 		{"C07", "Incorrect transit/routing number, bank account number and payment code", "Changes required in three fields indicated"},
+		{"C08", "Incorrect receiving DFI identification", "Receiving DFI identification of an IAT entry is incorrect"},
 		{"C09", "Incorrect individual ID number", "Individual's ID number is incorrect"},
 		{"C10", "Incorrect company name", "Company name is no longer valid and should be changed."},
 		{"C11", "Incorrect company identification", "Company ID is no longer valid and should be changed"},
 		{"C12", "Incorrect company name and company ID", "Both the company name and company id are no longer valid and must be changed"},
+		{"C13", "Addenda format error", "Entry was processed but information in the addenda record was unclear or formatted incorrectly"},
+	}
+	// populate the map
+	for i := range codes {
+		dict[codes[i].Code] = &codes[i]
+	}
+	return dict
+}
+
+func makeRefusedChangeCodeDict() map[string]*ChangeCode {
+	dict := make(map[string]*ChangeCode)
+
+	codes := []ChangeCode{
+		{"C61", "Misrouted notification of change", "Notification of Change was sent to the wrong ODFI"},
+		{"C62", "Incorrect trace number", "Original entry trace number is not valid"},
+		{"C63", "Incorrect company identification number", "Company identification does not match the original entry"},
+		{"C64", "Incorrect individual identification number", "Individual identification does not match the original entry"},
+		{"C65", "Incorrectly formatted corrected data", "Corrected data is not formatted for the change code"},
+		{"C66", "Incorrect discretionary data", "Discretionary data does not match the original entry"},
+		{"C67", "Routing number not from original entry detail record", "Routing number does not match the original entry"},
+		{"C68", "DFI account number not from original entry detail record", "DFI account number does not match the original entry"},
+		{"C69", "Incorrect transaction code", "Transaction code does not match the original entry"},
 	}
 	// populate the map
 	for i := range codes {
@@ -451,3 +451,83 @@ func TestCorrectedData__WriteCorrectionData(t *testing.T) {
 		t.Errorf("C09 got %q (length=%d)", v, len(v))
 	}
 }
+
+func TestAddenda98__validateCorrectedData(t *testing.T) {
+	cases := []struct {
+		code, data string
+		valid      bool
+	}{
+		{"C01", "1918171614", true},
+		{"C01", "123456789012345678", false},
+		{"C01", "1234 5678", false},
+		{"C02", "987654320", true},
+		{"C02", "987654321", false},
+		{"C02", "98765432", false},
+		{"C03", "987654320   123456", true},
+		{"C03", "987654320", false},
+		{"C04", "Jane Doe", true},
+		{"C04", "Jane Doe with a really long name", false},
+		{"C05", "22", true},
+		{"C05", "99", false},
+		{"C05", "checking", false},
+		{"C06", "123456789                22", true},
+		{"C06", "123456789", false},
+		{"C07", "9876543201242415    22", true},
+		{"C07", "987654320  12345  22", true},
+		{"C07", "987654321  12345  22", false},
+		{"C07", "987654320 1234 1234 1234", false},
+		{"C08", "987654320", true},
+		{"C09", "21345678", true},
+		{"C10", "ACME One Corp", true},
+		{"C10", "ACME One Corporation", false},
+		{"C11", "1918171614", true},
+		{"C11", "12345678901", false},
+		{"C12", "ACME One Corp   1918171614", true},
+		{"C12", "ACME One Corp", false},
+		{"C13", "addenda unreadable", true},
+	}
+	for i := range cases {
+		addenda98 := mockAddenda98()
+		addenda98.ChangeCode = cases[i].code
+		addenda98.CorrectedData = cases[i].data
+		err := addenda98.Validate()
+		if cases[i].valid && err != nil {
+			t.Errorf("%s %q: %v", cases[i].code, cases[i].data, err)
+		}
+		if !cases[i].valid && !base.Match(err, ErrAddenda98CorrectedData) {
+			t.Errorf("%s %q: expected ErrAddenda98CorrectedData, got %v", cases[i].code, cases[i].data, err)
+		}
+	}
+}
+
+func TestAddenda98__ChangeCodes(t *testing.T) {
+	codes := ChangeCodes()
+	if len(codes) != 13 {
+		t.Fatalf("got %d change codes", len(codes))
+	}
+	if codes[0].Code != "C01" || codes[len(codes)-1].Code != "C13" {
+		t.Errorf("unexpected ordering: %s ... %s", codes[0].Code, codes[len(codes)-1].Code)
+	}
+
+	refused := RefusedChangeCodes()
+	if len(refused) != 9 || refused[0].Code != "C61" || refused[8].Code != "C69" {
+		t.Errorf("unexpected refused codes: %d", len(refused))
+	}
+	if !IsRefusedChangeCode("c65") || IsRefusedChangeCode("C01") {
+		t.Error("unexpected IsRefusedChangeCode")
+	}
+	if LookupChangeCode("C63") != nil {
+		t.Error("refused code found as a change code")
+	}
+	if code := LookupRefusedChangeCode("C63"); code == nil || code.Reason != "Incorrect company identification number" {
+		t.Errorf("unexpected refused code: %v", code)
+	}
+}
+
+func TestAddenda98__LookupChangeCodeCopy(t *testing.T) {
+	code := LookupChangeCode("C01")
+	code.Reason = "changed"
+	if code := LookupChangeCode("C01"); code.Reason == "changed" {
+		t.Errorf("lookup returned shared ChangeCode: %#v", code)
+	}
+}
@@ -18,6 +18,7 @@
 package ach
 
 import (
+	"sort"
 	"strings"
 	"unicode/utf8"
 )
@@ -69,15 +70,55 @@ type Addenda99 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
-// ReturnCode holds a return Code, Reason/Title, and Description
+// ReturnCode holds a return Code, Reason/Title, and Description along with the SEC codes it
+// can be used with and how long after settlement an entry can be returned with it.
 //
 // Table of return codes exists in Part 4.2 of the NACHA corporate rules and guidelines
 type ReturnCode struct {
 	Code        string `json:"code"`
 	Reason      string `json:"reason"`
 	Description string `json:"description"`
+	// SECCodes are the StandardEntryClassCodes of entries which can be returned with this code.
+	// Codes which can be used with any SEC code leave this empty.
+	SECCodes []string `json:"secCodes,omitempty"`
+	// Timeframe is how long the return has to reach the ODFI's ACH operator
+	Timeframe ReturnTimeframe `json:"timeframe"`
+}
+
+// clone returns a copy of the ReturnCode so callers can't modify the shared table of return codes
+func (code *ReturnCode) clone() *ReturnCode {
+	out := *code
+	if code.SECCodes != nil {
+		out.SECCodes = make([]string, len(code.SECCodes))
+		copy(out.SECCodes, code.SECCodes)
+	}
+	return &out
+}
+
+// ReturnTimeframe is how long after the Settlement Date of an entry it can be returned. Dishonored and
+// contested dishonored returns count from the Settlement Date of the return they respond to.
+type ReturnTimeframe struct {
+	// Days is zero when the return isn't limited by the rules, such as returns agreed to by the ODFI
+	Days int `json:"days"`
+	// BankingDays is true when Days counts banking days rather than calendar days
+	BankingDays bool `json:"bankingDays"`
+}
+
+// AllowsSECCode returns true if entries of the StandardEntryClassCode can be returned with the ReturnCode
+func (code *ReturnCode) AllowsSECCode(secCode string) bool {
+	if len(code.SECCodes) == 0 {
+		return true
+	}
+	for i := range code.SECCodes {
+		if code.SECCodes[i] == secCode {
+			return true
+		}
+	}
+	return false
 }
 
 // NewAddenda99 returns a new Addenda99 with default values for none exported fields
@@ -206,7 +247,7 @@ func (Addenda99 *Addenda99) TraceNumberField() string {
 func (Addenda99 *Addenda99) ReturnCodeField() *ReturnCode {
 	code, ok := returnCodeDict[Addenda99.ReturnCode]
 	if ok {
-		return code
+		return code.clone()
 	}
 	return nil
 }
@@ -215,15 +256,70 @@ func (Addenda99 *Addenda99) ReturnCodeField() *ReturnCode {
 // the provided NACHA return code.
 func LookupReturnCode(code string) *ReturnCode {
 	if code, exists := returnCodeDict[strings.ToUpper(code)]; exists {
-		return code
+		return code.clone()
 	}
 	return nil
 }
 
+// ReturnCodes returns every NACHA return code ordered by Code
+func ReturnCodes() []*ReturnCode {
+	out := make([]*ReturnCode, 0, len(returnCodeDict))
+	for _, code := range returnCodeDict {
+		out = append(out, code.clone())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
+
+// ReturnCodesForSECCode returns the return codes, ordered by Code, which entries of the StandardEntryClassCode can be returned with
+func ReturnCodesForSECCode(secCode string) []*ReturnCode {
+	var out []*ReturnCode
+	for _, code := range ReturnCodes() {
+		if code.AllowsSECCode(strings.ToUpper(secCode)) {
+			out = append(out, code)
+		}
+	}
+	return out
+}
+
+var (
+	// returnCodeSECCodes limits return codes to the SEC codes they can be used with
+	returnCodeSECCodes = map[string][]string{
+		"R05": {CCD, CTX},
+		"R11": {TRC, TRX},
+		"R29": {CCD, CTX},
+		"R30": {TRC, TRX},
+		"R31": {CCD, CTX},
+		"R33": {XCK},
+		"R35": {CIE},
+		"R37": {ARC, BOC, POP},
+		"R38": {ARC, BOC},
+		"R39": {ARC, BOC, POP},
+		"R40": {ENR}, "R41": {ENR}, "R42": {ENR}, "R43": {ENR}, "R44": {ENR}, "R45": {ENR}, "R46": {ENR}, "R47": {ENR},
+		"R50": {RCK}, "R51": {RCK}, "R52": {RCK}, "R53": {RCK},
+		"R80": {IAT}, "R81": {IAT}, "R82": {IAT}, "R83": {IAT}, "R84": {IAT},
+	}
+
+	// returnCodeTimeframes are the return codes which aren't due within two banking days
+	returnCodeTimeframes = map[string]ReturnTimeframe{
+		// unauthorized entries
+		"R05": {Days: 60}, "R07": {Days: 60}, "R10": {Days: 60},
+		// source documents and items
+		"R33": {Days: 60}, "R37": {Days: 60}, "R38": {Days: 60}, "R51": {Days: 60}, "R52": {Days: 60}, "R53": {Days: 60},
+		// returns requested or agreed to by the ODFI, or at a Federal Government Agency's discretion
+		"R06": {}, "R31": {}, "R40": {},
+		// dishonored returns
+		"R61": {Days: 5, BankingDays: true}, "R62": {Days: 5, BankingDays: true}, "R67": {Days: 5, BankingDays: true},
+		"R68": {Days: 5, BankingDays: true}, "R69": {Days: 5, BankingDays: true}, "R70": {Days: 5, BankingDays: true},
+	}
+)
+
 func makeReturnCodeDict() map[string]*ReturnCode {
 	dict := make(map[string]*ReturnCode)
 
-	codes := []ReturnCode{
+	codes := []struct {
+		Code, Reason, Description string
+	}{
 		// Return Reason Codes for RDFIs
 		{"R01", "Insufficient Funds", "Available balance is not sufficient to cover the dollar value of the debit entry"},
 		{"R02", "Account Closed", "Previously active account has been closed by customer or RDFI"},
@@ -281,6 +377,7 @@ func makeReturnCodeDict() map[string]*ReturnCode {
 		{"R53", "Item and RCK Entry Presented for Payment (Adjustment Entry)", "Both the RCK entry and check have been presented forpayment. RDFI must obtain a Written Statement and return the entry within 60 days following Settlement Date"},
 		// Return Codes to be used by the ODFI for dishonored return entries
 		{"R61", "Misrouted Return", "The financial institution preparing the Return Entry (the RDFI of the original Entry) has placed the incorrect Routing Number in the Receiving DFI Identification field."},
+		{"R62", "Return of Erroneous or Reversing Debit", "The Originator's/ODFI's use of the reversing debit entry was for a reason other than one permitted by these Rules."},
 		{"R67", "Duplicate Return", "The ODFI has received more than one Return for the same Entry."},
 		{"R68", "Untimely Return", "The Return Entry has not been sent within the time frame established by these Rules."},
 		{"R69", "Field Error(s)", "One or more of the field requirements are incorrect."},
@@ -292,6 +389,7 @@ func makeReturnCodeDict() map[string]*ReturnCode {
 		{"R74", "Corrected Return", "The RDFI is correcting a previous Return Entry that was dishonored using Return Reason Code R69 (Field Error(s)) because it contained incomplete or incorrect information."},
 		{"R75", "Return Not a Duplicate", "The Return Entry was not a duplicate of an Entry previously returned by the RDFI."},
 		{"R76", "No Errors Found", "The original Return Entry did not contain the errors indicated by the ODFI in the dishonored Return Entry."},
+		{"R77", "Non-Acceptance of R62 Dishonored Return", "The RDFI returned both the Erroneous Entry and the related Reversing Entry, or the funds relating to the R62 dishonored Return are not recoverable from the Receiver."},
 		//Return Codes to be used by Gateways for the return of international payments
 		{"R80", "IAT Entry Coding Error", "The IAT Entry is being returned due to one or more of the following conditions: Invalid DFI/Bank Branch Country Code, invalid DFI/Bank Identification Number Qualifier, invalid Foreign Exchange Indicator, invalid ISO Originating Currency Code, invalid ISO Destination Currency Code, invalid ISO Destination Country Code, invalid Transaction Type Code"},
 		{"R81", "Non-Participant in IAT Program", "The IAT Entry is being returned because the Gateway does not have an agreement with either the ODFI or the Gateway's customer to transmit Outbound IAT Entries."},
@@ -302,7 +400,17 @@ func makeReturnCodeDict() map[string]*ReturnCode {
 	}
 	// populate the map
 	for i := range codes {
-		dict[codes[i].Code] = &codes[i]
+		timeframe, ok := returnCodeTimeframes[codes[i].Code]
+		if !ok {
+			timeframe = ReturnTimeframe{Days: 2, BankingDays: true}
+		}
+		dict[codes[i].Code] = &ReturnCode{
+			Code:        codes[i].Code,
+			Reason:      codes[i].Reason,
+			Description: codes[i].Description,
+			SECCodes:    returnCodeSECCodes[codes[i].Code],
+			Timeframe:   timeframe,
+		}
 	}
 	return dict
 }
@@ -0,0 +1,243 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Addenda99Contested is the Addenda99 record of a contested dishonored return. An RDFI contests a dishonored
+// return it received (for example the original return was timely) by sending it back to the ODFI which dishonored it.
+//
+// See Appendix Four: Return Entries in the NACHA Corporate Rules
+type Addenda99Contested struct {
+	// ID is a client defined string used as a reference to this record.
+	ID string `json:"id"`
+	// RecordType defines the type of record in the block. entryAddendaPos 7
+	recordType string
+	// TypeCode Addenda types code '99'
+	TypeCode string `json:"typeCode"`
+	// ContestedReturnCode is the reason the RDFI is contesting the dishonored return (R71-R76)
+	ContestedReturnCode string `json:"contestedReturnCode"`
+	// OriginalEntryTraceNumber is the TraceNumber of the forward entry which was returned
+	OriginalEntryTraceNumber string `json:"originalEntryTraceNumber"`
+	// DateOriginalEntryReturned is the date (YYMMDD) the return entry was sent
+	DateOriginalEntryReturned string `json:"dateOriginalEntryReturned"`
+	// OriginalReceivingDFIIdentification is the RDFIIdentification of the forward entry which was returned
+	OriginalReceivingDFIIdentification string `json:"originalReceivingDFIIdentification"`
+	// OriginalSettlementDate is the Julian day the forward entry settled on
+	OriginalSettlementDate string `json:"originalSettlementDate"`
+	// ReturnTraceNumber is the TraceNumber of the return entry which was dishonored
+	ReturnTraceNumber string `json:"returnTraceNumber"`
+	// ReturnSettlementDate is the Julian day the return entry settled on
+	ReturnSettlementDate string `json:"returnSettlementDate"`
+	// ReturnReasonCode is the two digit reason code of the return entry, without its leading 'R'
+	ReturnReasonCode string `json:"returnReasonCode"`
+	// DishonoredReturnTraceNumber is the TraceNumber of the dishonored return being contested
+	DishonoredReturnTraceNumber string `json:"dishonoredReturnTraceNumber"`
+	// DishonoredReturnSettlementDate is the Julian day the dishonored return settled on
+	DishonoredReturnSettlementDate string `json:"dishonoredReturnSettlementDate"`
+	// DishonoredReturnReasonCode is the two digit reason code of the dishonored return, without its leading 'R'
+	DishonoredReturnReasonCode string `json:"dishonoredReturnReasonCode"`
+	// TraceNumber matches the Entry Detail Trace Number of the contested dishonored return entry.
+	//
+	// Use TraceNumberField() for a properly formatted string representation.
+	TraceNumber string `json:"traceNumber,omitempty"`
+
+	// validator is composed for data validation
+	validator
+	// converters is composed for ACH to GoLang Converters
+	converters
+	// rawLine is the line the record was read from
+	rawLine
+}
+
+// IsContestedReturnCode returns true if code is used by an RDFI to contest a dishonored return
+func IsContestedReturnCode(code string) bool {
+	switch code {
+	case "R71", "R72", "R73", "R74", "R75", "R76", "R77":
+		return true
+	}
+	return false
+}
+
+// NewAddenda99Contested returns a new Addenda99Contested with default values for none exported fields
+func NewAddenda99Contested() *Addenda99Contested {
+	return &Addenda99Contested{
+		recordType: "7",
+		TypeCode:   "99",
+	}
+}
+
+// Parse takes the input record string and parses the Addenda99Contested values
+//
+// Parse provides no guarantee about all fields being filled in. Callers should make a Validate() call to confirm successful parsing and data validity.
+func (Addenda99 *Addenda99Contested) Parse(record string) {
+	if utf8.RuneCountInString(record) != 94 {
+		return
+	}
+
+	// 1-1 Always "7"
+	Addenda99.recordType = "7"
+	// 2-3 Defines the specific explanation and format for the addenda information contained in the same record
+	Addenda99.TypeCode = record[1:3]
+	// 4-6
+	Addenda99.ContestedReturnCode = record[3:6]
+	// 7-21
+	Addenda99.OriginalEntryTraceNumber = strings.TrimSpace(record[6:21])
+	// 22-27
+	Addenda99.DateOriginalEntryReturned = Addenda99.validateSimpleDate(record[21:27])
+	// 28-35
+	Addenda99.OriginalReceivingDFIIdentification = Addenda99.parseStringField(record[27:35])
+	// 36-38
+	Addenda99.OriginalSettlementDate = strings.TrimSpace(record[35:38])
+	// 39-53
+	Addenda99.ReturnTraceNumber = strings.TrimSpace(record[38:53])
+	// 54-56
+	Addenda99.ReturnSettlementDate = strings.TrimSpace(record[53:56])
+	// 57-58
+	Addenda99.ReturnReasonCode = strings.TrimSpace(record[56:58])
+	// 59-73
+	Addenda99.DishonoredReturnTraceNumber = strings.TrimSpace(record[58:73])
+	// 74-76
+	Addenda99.DishonoredReturnSettlementDate = strings.TrimSpace(record[73:76])
+	// 77-78
+	Addenda99.DishonoredReturnReasonCode = strings.TrimSpace(record[76:78])
+	// 79 reserved
+	// 80-94
+	Addenda99.TraceNumber = strings.TrimSpace(record[79:94])
+}
+
+// String writes the Addenda99Contested struct to a 94 character string
+func (Addenda99 *Addenda99Contested) String() string {
+	var buf strings.Builder
+	buf.Grow(94)
+	buf.WriteString(Addenda99.recordType)
+	buf.WriteString(Addenda99.TypeCode)
+	buf.WriteString(Addenda99.ContestedReturnCode)
+	buf.WriteString(Addenda99.OriginalEntryTraceNumberField())
+	buf.WriteString(Addenda99.DateOriginalEntryReturnedField())
+	buf.WriteString(Addenda99.OriginalReceivingDFIIdentificationField())
+	buf.WriteString(Addenda99.settlementDateField(Addenda99.OriginalSettlementDate))
+	buf.WriteString(Addenda99.ReturnTraceNumberField())
+	buf.WriteString(Addenda99.settlementDateField(Addenda99.ReturnSettlementDate))
+	buf.WriteString(Addenda99.stringField(Addenda99.ReturnReasonCode, 2))
+	buf.WriteString(Addenda99.DishonoredReturnTraceNumberField())
+	buf.WriteString(Addenda99.settlementDateField(Addenda99.DishonoredReturnSettlementDate))
+	buf.WriteString(Addenda99.stringField(Addenda99.DishonoredReturnReasonCode, 2))
+	buf.WriteString(Addenda99.alphaField("", 1))
+	buf.WriteString(Addenda99.TraceNumberField())
+	return buf.String()
+}
+
+// Validate verifies NACHA rules for Addenda99Contested
+func (Addenda99 *Addenda99Contested) Validate() error {
+	if Addenda99.recordType != "7" {
+		return fieldError("recordType", NewErrRecordType(7), Addenda99.recordType)
+	}
+	if Addenda99.TypeCode == "" {
+		return fieldError("TypeCode", ErrConstructor, Addenda99.TypeCode)
+	}
+	if Addenda99.TypeCode != "99" {
+		return fieldError("TypeCode", ErrAddendaTypeCode, Addenda99.TypeCode)
+	}
+	if !IsContestedReturnCode(Addenda99.ContestedReturnCode) {
+		return fieldError("ContestedReturnCode", ErrAddenda99ContestedReturnCode, Addenda99.ContestedReturnCode)
+	}
+	return nil
+}
+
+// OriginalEntryTraceNumberField returns a zero padded OriginalEntryTraceNumber string
+func (Addenda99 *Addenda99Contested) OriginalEntryTraceNumberField() string {
+	return Addenda99.stringField(Addenda99.OriginalEntryTraceNumber, 15)
+}
+
+// DateOriginalEntryReturnedField returns a space padded DateOriginalEntryReturned string
+func (Addenda99 *Addenda99Contested) DateOriginalEntryReturnedField() string {
+	if Addenda99.DateOriginalEntryReturned == "" {
+		return Addenda99.alphaField("", 6)
+	}
+	return Addenda99.formatSimpleDate(Addenda99.DateOriginalEntryReturned)
+}
+
+// OriginalReceivingDFIIdentificationField returns a zero padded OriginalReceivingDFIIdentification string
+func (Addenda99 *Addenda99Contested) OriginalReceivingDFIIdentificationField() string {
+	return Addenda99.stringField(Addenda99.OriginalReceivingDFIIdentification, 8)
+}
+
+// ReturnTraceNumberField returns a zero padded ReturnTraceNumber string
+func (Addenda99 *Addenda99Contested) ReturnTraceNumberField() string {
+	return Addenda99.stringField(Addenda99.ReturnTraceNumber, 15)
+}
+
+// DishonoredReturnTraceNumberField returns a zero padded DishonoredReturnTraceNumber string
+func (Addenda99 *Addenda99Contested) DishonoredReturnTraceNumberField() string {
+	return Addenda99.stringField(Addenda99.DishonoredReturnTraceNumber, 15)
+}
+
+// TraceNumberField returns a zero padded TraceNumber string
+func (Addenda99 *Addenda99Contested) TraceNumberField() string {
+	return Addenda99.stringField(Addenda99.TraceNumber, 15)
+}
+
+// ContestDishonoredReturn creates a contested dishonored return of a dishonored return received by the RDFI.
+// dishonoredSettlementDate is the SettlementDate of the batch the dishonored return was received in and code
+// is the contested dishonored return reason (R71-R76).
+//
+// The contested dishonored return is sent to the ODFI which dishonored the return. DateOriginalEntryReturned and
+// OriginalSettlementDate are not carried on the dishonored return, so they should be set on the entry's
+// Addenda99Contested from the RDFI's records. The entry has no TraceNumber, so one is assigned when it's added
+// to a batch and created.
+func ContestDishonoredReturn(received *EntryDetail, dishonoredSettlementDate string, code string) (*EntryDetail, error) {
+	if received == nil || received.Addenda99Dishonored == nil {
+		return nil, fieldError("Addenda99Dishonored", ErrFieldInclusion)
+	}
+	if !IsContestedReturnCode(code) {
+		return nil, fieldError("ContestedReturnCode", ErrAddenda99ContestedReturnCode, code)
+	}
+	dishonored := received.Addenda99Dishonored
+
+	entry := NewEntryDetail()
+	entry.TransactionCode = received.TransactionCode
+	odfi := received.TraceNumberField()[:8] // the ODFI which sent the dishonored return
+	entry.RDFIIdentification = odfi
+	entry.CheckDigit = strconv.Itoa(entry.CalculateCheckDigit(odfi))
+	entry.DFIAccountNumber = received.DFIAccountNumber
+	entry.Amount = received.Amount
+	entry.IdentificationNumber = received.IdentificationNumber
+	entry.IndividualName = received.IndividualName
+	entry.DiscretionaryData = received.DiscretionaryData
+	entry.AddendaRecordIndicator = 1
+	entry.Category = CategoryDishonoredReturnContested
+
+	addenda := NewAddenda99Contested()
+	addenda.ContestedReturnCode = code
+	addenda.OriginalEntryTraceNumber = dishonored.OriginalEntryTraceNumber
+	addenda.OriginalReceivingDFIIdentification = dishonored.OriginalReceivingDFIIdentification
+	addenda.ReturnTraceNumber = dishonored.ReturnTraceNumber
+	addenda.ReturnSettlementDate = dishonored.ReturnSettlementDate
+	addenda.ReturnReasonCode = dishonored.ReturnReasonCode
+	addenda.DishonoredReturnTraceNumber = received.TraceNumber
+	addenda.DishonoredReturnSettlementDate = dishonoredSettlementDate
+	addenda.DishonoredReturnReasonCode = returnReasonCode(dishonored.DishonoredReturnReasonCode)
+	entry.Addenda99Contested = addenda
+
+	return entry, nil
+}
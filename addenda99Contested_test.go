@@ -0,0 +1,130 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base"
+)
+
+func TestAddenda99Contested__ParseString(t *testing.T) {
+	line := "799R73121042880000001191001231380102742313801000000012710112104288000000227268 231380100000002"
+	addenda99 := NewAddenda99Contested()
+	addenda99.Parse(line)
+
+	if addenda99.ContestedReturnCode != "R73" || addenda99.OriginalEntryTraceNumber != "121042880000001" ||
+		addenda99.DateOriginalEntryReturned != "191001" || addenda99.OriginalReceivingDFIIdentification != "23138010" ||
+		addenda99.OriginalSettlementDate != "274" || addenda99.ReturnTraceNumber != "231380100000001" ||
+		addenda99.ReturnSettlementDate != "271" || addenda99.ReturnReasonCode != "01" ||
+		addenda99.DishonoredReturnTraceNumber != "121042880000002" || addenda99.DishonoredReturnSettlementDate != "272" ||
+		addenda99.DishonoredReturnReasonCode != "68" || addenda99.TraceNumber != "231380100000002" {
+		t.Errorf("unexpected parse: %#v", addenda99)
+	}
+	if v := addenda99.String(); v != line {
+		t.Errorf("\n got %q\nwant %q", v, line)
+	}
+	if err := addenda99.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	addenda99.ContestedReturnCode = "R68"
+	if err := addenda99.Validate(); !base.Match(err, ErrAddenda99ContestedReturnCode) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+func TestIsContestedReturnCode(t *testing.T) {
+	for _, code := range []string{"R71", "R72", "R73", "R74", "R75", "R76", "R77"} {
+		if !IsContestedReturnCode(code) {
+			t.Errorf("%s should be a contested return code", code)
+		}
+	}
+	for _, code := range []string{"R01", "R62", "R70", "R78"} {
+		if IsContestedReturnCode(code) {
+			t.Errorf("%s isn't a contested return code", code)
+		}
+	}
+}
+
+func TestContestDishonoredReturn(t *testing.T) {
+	dishonored, err := DishonorReturn(mockReturnEntry(), "271", "R68")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dishonored.SetTraceNumber("12104288", 2) // assigned by the ODFI's batch
+
+	entry, err := ContestDishonoredReturn(dishonored, "272", "R73")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.RDFIIdentification != "12104288" || entry.CheckDigit != "2" {
+		t.Errorf("RDFIIdentification=%s CheckDigit=%s", entry.RDFIIdentification, entry.CheckDigit)
+	}
+	if entry.Category != CategoryDishonoredReturnContested {
+		t.Errorf("Category=%s", entry.Category)
+	}
+	addenda99 := entry.Addenda99Contested
+	if addenda99.OriginalEntryTraceNumber != "121042880000001" || addenda99.ReturnTraceNumber != "231380100000001" ||
+		addenda99.ReturnReasonCode != "01" || addenda99.DishonoredReturnTraceNumber != "121042880000002" ||
+		addenda99.DishonoredReturnSettlementDate != "272" || addenda99.DishonoredReturnReasonCode != "68" {
+		t.Errorf("unexpected addenda: %#v", addenda99)
+	}
+	addenda99.DateOriginalEntryReturned = "191001"
+	addenda99.OriginalSettlementDate = "270"
+
+	// batch and write the contested dishonored return, then read it back
+	bh := mockBatchPPDHeader()
+	bh.ServiceClassCode = DebitsOnly
+	bh.ODFIIdentification = "23138010"
+	batch := NewBatchPPD(bh)
+	batch.AddEntry(entry)
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatal(err)
+	}
+	read, err := NewReader(strings.NewReader(buf.String())).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	readEntry := read.Batches[0].GetEntries()[0]
+	if readEntry.Category != CategoryDishonoredReturnContested {
+		t.Errorf("Category=%s", readEntry.Category)
+	}
+	if v := readEntry.Addenda99Contested; v == nil || v.String() != addenda99.String() {
+		t.Errorf("unexpected addenda: %#v", v)
+	}
+
+	if _, err := ContestDishonoredReturn(mockReturnEntry(), "272", "R73"); !base.Match(err, ErrFieldInclusion) {
+		t.Errorf("%T: %s", err, err)
+	}
+	if _, err := ContestDishonoredReturn(dishonored, "272", "R68"); !base.Match(err, ErrAddenda99ContestedReturnCode) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
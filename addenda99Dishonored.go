@@ -0,0 +1,240 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Addenda99Dishonored is the Addenda99 record of a dishonored return. An ODFI dishonors a return entry it
+// received (for example the return was untimely or misrouted) by sending it back to the RDFI which returned it.
+//
+// See Appendix Four: Return Entries in the NACHA Corporate Rules
+type Addenda99Dishonored struct {
+	// ID is a client defined string used as a reference to this record.
+	ID string `json:"id"`
+	// RecordType defines the type of record in the block. entryAddendaPos 7
+	recordType string
+	// TypeCode Addenda types code '99'
+	TypeCode string `json:"typeCode"`
+	// DishonoredReturnReasonCode is the reason the ODFI is dishonoring the return (R61, R67-R70)
+	DishonoredReturnReasonCode string `json:"dishonoredReturnReasonCode"`
+	// OriginalEntryTraceNumber is the TraceNumber of the forward entry which was returned
+	OriginalEntryTraceNumber string `json:"originalEntryTraceNumber"`
+	// OriginalReceivingDFIIdentification is the RDFIIdentification of the forward entry which was returned
+	OriginalReceivingDFIIdentification string `json:"originalReceivingDFIIdentification"`
+	// ReturnTraceNumber is the TraceNumber of the return entry being dishonored
+	ReturnTraceNumber string `json:"returnTraceNumber"`
+	// ReturnSettlementDate is the Julian day the return entry settled on
+	ReturnSettlementDate string `json:"returnSettlementDate"`
+	// ReturnReasonCode is the two digit reason code of the return entry, without its leading 'R'
+	ReturnReasonCode string `json:"returnReasonCode"`
+	// AddendaInformation
+	AddendaInformation string `json:"addendaInformation,omitempty"`
+	// TraceNumber matches the Entry Detail Trace Number of the dishonored return entry.
+	//
+	// Use TraceNumberField() for a properly formatted string representation.
+	TraceNumber string `json:"traceNumber,omitempty"`
+
+	// validator is composed for data validation
+	validator
+	// converters is composed for ACH to GoLang Converters
+	converters
+	// rawLine is the line the record was read from
+	rawLine
+}
+
+// IsDishonoredReturnCode returns true if code is used by an ODFI to dishonor a return entry
+func IsDishonoredReturnCode(code string) bool {
+	switch code {
+	case "R61", "R62", "R67", "R68", "R69", "R70":
+		return true
+	}
+	return false
+}
+
+// NewAddenda99Dishonored returns a new Addenda99Dishonored with default values for none exported fields
+func NewAddenda99Dishonored() *Addenda99Dishonored {
+	return &Addenda99Dishonored{
+		recordType: "7",
+		TypeCode:   "99",
+	}
+}
+
+// Parse takes the input record string and parses the Addenda99Dishonored values
+//
+// Parse provides no guarantee about all fields being filled in. Callers should make a Validate() call to confirm successful parsing and data validity.
+func (Addenda99 *Addenda99Dishonored) Parse(record string) {
+	if utf8.RuneCountInString(record) != 94 {
+		return
+	}
+
+	// 1-1 Always "7"
+	Addenda99.recordType = "7"
+	// 2-3 Defines the specific explanation and format for the addenda information contained in the same record
+	Addenda99.TypeCode = record[1:3]
+	// 4-6
+	Addenda99.DishonoredReturnReasonCode = record[3:6]
+	// 7-21
+	Addenda99.OriginalEntryTraceNumber = strings.TrimSpace(record[6:21])
+	// 22-27 reserved
+	// 28-35
+	Addenda99.OriginalReceivingDFIIdentification = Addenda99.parseStringField(record[27:35])
+	// 36-38 reserved
+	// 39-53
+	Addenda99.ReturnTraceNumber = strings.TrimSpace(record[38:53])
+	// 54-56
+	Addenda99.ReturnSettlementDate = strings.TrimSpace(record[53:56])
+	// 57-58
+	Addenda99.ReturnReasonCode = strings.TrimSpace(record[56:58])
+	// 59-79
+	Addenda99.AddendaInformation = strings.TrimSpace(record[58:79])
+	// 80-94
+	Addenda99.TraceNumber = strings.TrimSpace(record[79:94])
+}
+
+// String writes the Addenda99Dishonored struct to a 94 character string
+func (Addenda99 *Addenda99Dishonored) String() string {
+	var buf strings.Builder
+	buf.Grow(94)
+	buf.WriteString(Addenda99.recordType)
+	buf.WriteString(Addenda99.TypeCode)
+	buf.WriteString(Addenda99.DishonoredReturnReasonCode)
+	buf.WriteString(Addenda99.OriginalEntryTraceNumberField())
+	buf.WriteString(Addenda99.alphaField("", 6))
+	buf.WriteString(Addenda99.OriginalReceivingDFIIdentificationField())
+	buf.WriteString(Addenda99.alphaField("", 3))
+	buf.WriteString(Addenda99.ReturnTraceNumberField())
+	buf.WriteString(Addenda99.ReturnSettlementDateField())
+	buf.WriteString(Addenda99.ReturnReasonCodeField())
+	buf.WriteString(Addenda99.AddendaInformationField())
+	buf.WriteString(Addenda99.TraceNumberField())
+	return buf.String()
+}
+
+// Validate verifies NACHA rules for Addenda99Dishonored
+func (Addenda99 *Addenda99Dishonored) Validate() error {
+	if Addenda99.recordType != "7" {
+		return fieldError("recordType", NewErrRecordType(7), Addenda99.recordType)
+	}
+	if Addenda99.TypeCode == "" {
+		return fieldError("TypeCode", ErrConstructor, Addenda99.TypeCode)
+	}
+	if Addenda99.TypeCode != "99" {
+		return fieldError("TypeCode", ErrAddendaTypeCode, Addenda99.TypeCode)
+	}
+	if !IsDishonoredReturnCode(Addenda99.DishonoredReturnReasonCode) {
+		return fieldError("DishonoredReturnReasonCode", ErrAddenda99DishonoredReturnCode, Addenda99.DishonoredReturnReasonCode)
+	}
+	if err := Addenda99.isAlphanumeric(Addenda99.AddendaInformation); err != nil {
+		return fieldError("AddendaInformation", err, Addenda99.AddendaInformation)
+	}
+	return nil
+}
+
+// OriginalEntryTraceNumberField returns a zero padded OriginalEntryTraceNumber string
+func (Addenda99 *Addenda99Dishonored) OriginalEntryTraceNumberField() string {
+	return Addenda99.stringField(Addenda99.OriginalEntryTraceNumber, 15)
+}
+
+// OriginalReceivingDFIIdentificationField returns a zero padded OriginalReceivingDFIIdentification string
+func (Addenda99 *Addenda99Dishonored) OriginalReceivingDFIIdentificationField() string {
+	return Addenda99.stringField(Addenda99.OriginalReceivingDFIIdentification, 8)
+}
+
+// ReturnTraceNumberField returns a zero padded ReturnTraceNumber string
+func (Addenda99 *Addenda99Dishonored) ReturnTraceNumberField() string {
+	return Addenda99.stringField(Addenda99.ReturnTraceNumber, 15)
+}
+
+// ReturnSettlementDateField returns a zero padded ReturnSettlementDate string, or spaces when it's not set
+func (Addenda99 *Addenda99Dishonored) ReturnSettlementDateField() string {
+	return Addenda99.settlementDateField(Addenda99.ReturnSettlementDate)
+}
+
+// ReturnReasonCodeField returns a zero padded ReturnReasonCode string
+func (Addenda99 *Addenda99Dishonored) ReturnReasonCodeField() string {
+	return Addenda99.stringField(Addenda99.ReturnReasonCode, 2)
+}
+
+// AddendaInformationField returns a space padded AddendaInformation string
+func (Addenda99 *Addenda99Dishonored) AddendaInformationField() string {
+	return Addenda99.alphaField(Addenda99.AddendaInformation, 21)
+}
+
+// TraceNumberField returns a zero padded TraceNumber string
+func (Addenda99 *Addenda99Dishonored) TraceNumberField() string {
+	return Addenda99.stringField(Addenda99.TraceNumber, 15)
+}
+
+// settlementDateField returns a zero padded Julian settlement date, or spaces as the ACH operator
+// fills in settlement dates
+func (c *converters) settlementDateField(s string) string {
+	if s == "" {
+		return c.alphaField("", 3)
+	}
+	return c.stringField(s, 3)
+}
+
+// returnReasonCode returns the two digits of a return code, such as "01" for R01
+func returnReasonCode(code string) string {
+	if len(code) == 3 && strings.HasPrefix(strings.ToUpper(code), "R") {
+		return code[1:]
+	}
+	return code
+}
+
+// DishonorReturn creates a dishonored return of a return entry received by the ODFI. returnSettlementDate is the
+// SettlementDate of the batch the return was received in and code is the dishonored return reason (R61, R67-R70).
+//
+// The dishonored return is sent to the RDFI of the original forward entry. It has no TraceNumber, so one is
+// assigned when it's added to a batch and created.
+func DishonorReturn(received *EntryDetail, returnSettlementDate string, code string) (*EntryDetail, error) {
+	if received == nil || received.Addenda99 == nil {
+		return nil, fieldError("Addenda99", ErrFieldInclusion)
+	}
+	if !IsDishonoredReturnCode(code) {
+		return nil, fieldError("DishonoredReturnReasonCode", ErrAddenda99DishonoredReturnCode, code)
+	}
+
+	entry := NewEntryDetail()
+	entry.TransactionCode = received.TransactionCode
+	rdfi := entry.stringField(received.Addenda99.OriginalDFI, 8)
+	entry.RDFIIdentification = rdfi
+	entry.CheckDigit = strconv.Itoa(entry.CalculateCheckDigit(rdfi))
+	entry.DFIAccountNumber = received.DFIAccountNumber
+	entry.Amount = received.Amount
+	entry.IdentificationNumber = received.IdentificationNumber
+	entry.IndividualName = received.IndividualName
+	entry.DiscretionaryData = received.DiscretionaryData
+	entry.AddendaRecordIndicator = 1
+	entry.Category = CategoryDishonoredReturn
+
+	addenda := NewAddenda99Dishonored()
+	addenda.DishonoredReturnReasonCode = code
+	addenda.OriginalEntryTraceNumber = received.Addenda99.OriginalTrace
+	addenda.OriginalReceivingDFIIdentification = rdfi
+	addenda.ReturnTraceNumber = received.TraceNumber
+	addenda.ReturnSettlementDate = returnSettlementDate
+	addenda.ReturnReasonCode = returnReasonCode(received.Addenda99.ReturnCode)
+	entry.Addenda99Dishonored = addenda
+
+	return entry, nil
+}
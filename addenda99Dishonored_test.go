@@ -0,0 +1,204 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base"
+)
+
+func mockAddenda99Dishonored() *Addenda99Dishonored {
+	addenda99 := NewAddenda99Dishonored()
+	addenda99.DishonoredReturnReasonCode = "R68"
+	addenda99.OriginalEntryTraceNumber = "121042880000001"
+	addenda99.OriginalReceivingDFIIdentification = "23138010"
+	addenda99.ReturnTraceNumber = "231380100000001"
+	addenda99.ReturnSettlementDate = "271"
+	addenda99.ReturnReasonCode = "01"
+	addenda99.AddendaInformation = "Untimely Return"
+	addenda99.TraceNumber = "121042880000002"
+	return addenda99
+}
+
+func TestAddenda99Dishonored__ParseString(t *testing.T) {
+	line := "799R68121042880000001      23138010   23138010000000127101Untimely Return      121042880000002"
+	addenda99 := NewAddenda99Dishonored()
+	addenda99.Parse(line)
+
+	expected := mockAddenda99Dishonored()
+	if addenda99.DishonoredReturnReasonCode != expected.DishonoredReturnReasonCode ||
+		addenda99.OriginalEntryTraceNumber != expected.OriginalEntryTraceNumber ||
+		addenda99.OriginalReceivingDFIIdentification != expected.OriginalReceivingDFIIdentification ||
+		addenda99.ReturnTraceNumber != expected.ReturnTraceNumber ||
+		addenda99.ReturnSettlementDate != expected.ReturnSettlementDate ||
+		addenda99.ReturnReasonCode != expected.ReturnReasonCode ||
+		addenda99.AddendaInformation != expected.AddendaInformation ||
+		addenda99.TraceNumber != expected.TraceNumber {
+		t.Errorf("unexpected parse: %#v", addenda99)
+	}
+	if v := addenda99.String(); v != line {
+		t.Errorf("\n got %q\nwant %q", v, line)
+	}
+	if err := addenda99.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	// settlement dates are filled in by the ACH operator
+	addenda99.ReturnSettlementDate = ""
+	if v := addenda99.String(); len(v) != 94 || v[53:56] != "   " {
+		t.Errorf("unexpected record: %q", v)
+	}
+}
+
+func TestIsDishonoredReturnCode(t *testing.T) {
+	for _, code := range []string{"R61", "R62", "R67", "R68", "R69", "R70"} {
+		if !IsDishonoredReturnCode(code) {
+			t.Errorf("%s should be a dishonored return code", code)
+		}
+	}
+	for _, code := range []string{"R01", "R60", "R63", "R71", "R77"} {
+		if IsDishonoredReturnCode(code) {
+			t.Errorf("%s isn't a dishonored return code", code)
+		}
+	}
+}
+
+func TestAddenda99Dishonored__Validate(t *testing.T) {
+	addenda99 := mockAddenda99Dishonored()
+	addenda99.DishonoredReturnReasonCode = "R01"
+	if err := addenda99.Validate(); !base.Match(err, ErrAddenda99DishonoredReturnCode) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	addenda99 = mockAddenda99Dishonored()
+	addenda99.TypeCode = "98"
+	if err := addenda99.Validate(); !base.Match(err, ErrAddendaTypeCode) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	addenda99 = mockAddenda99Dishonored()
+	addenda99.AddendaInformation = "®"
+	if err := addenda99.Validate(); !base.Match(err, ErrNonAlphanumeric) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// mockReturnEntry is a return received by ODFI 12104288 from RDFI 23138010
+func mockReturnEntry() *EntryDetail {
+	entry := NewEntryDetail()
+	entry.TransactionCode = CheckingReturnNOCDebit
+	entry.SetRDFI("121042882")
+	entry.DFIAccountNumber = "744-5678-99"
+	entry.Amount = 25000
+	entry.IndividualName = "Wade Arnold"
+	entry.TraceNumber = "231380100000001"
+	entry.AddendaRecordIndicator = 1
+	entry.Category = CategoryReturn
+
+	addenda99 := NewAddenda99()
+	addenda99.ReturnCode = "R01"
+	addenda99.OriginalTrace = "121042880000001"
+	addenda99.OriginalDFI = "23138010"
+	entry.Addenda99 = addenda99
+	return entry
+}
+
+func TestDishonorReturn(t *testing.T) {
+	entry, err := DishonorReturn(mockReturnEntry(), "271", "R68")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.RDFIIdentification != "23138010" || entry.CheckDigit != "4" {
+		t.Errorf("RDFIIdentification=%s CheckDigit=%s", entry.RDFIIdentification, entry.CheckDigit)
+	}
+	if entry.Category != CategoryDishonoredReturn || entry.Amount != 25000 {
+		t.Errorf("Category=%s Amount=%d", entry.Category, entry.Amount)
+	}
+	addenda99 := entry.Addenda99Dishonored
+	if addenda99.OriginalEntryTraceNumber != "121042880000001" || addenda99.ReturnTraceNumber != "231380100000001" ||
+		addenda99.ReturnSettlementDate != "271" || addenda99.ReturnReasonCode != "01" {
+		t.Errorf("unexpected addenda: %#v", addenda99)
+	}
+
+	// batch and write the dishonored return, then read it back
+	bh := mockBatchPPDHeader()
+	bh.ServiceClassCode = DebitsOnly
+	batch := NewBatchPPD(bh)
+	batch.AddEntry(entry)
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if addenda99.TraceNumber != entry.TraceNumber {
+		t.Errorf("addenda TraceNumber=%s entry TraceNumber=%s", addenda99.TraceNumber, entry.TraceNumber)
+	}
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatal(err)
+	}
+	read, err := NewReader(strings.NewReader(buf.String())).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	readEntry := read.Batches[0].GetEntries()[0]
+	if readEntry.Category != CategoryDishonoredReturn || readEntry.Addenda99 != nil {
+		t.Errorf("Category=%s Addenda99=%v", readEntry.Category, readEntry.Addenda99)
+	}
+	if v := readEntry.Addenda99Dishonored; v == nil || v.String() != addenda99.String() {
+		t.Errorf("unexpected addenda: %#v", v)
+	}
+
+	// dishonoring requires a return
+	if _, err := DishonorReturn(NewEntryDetail(), "271", "R68"); !base.Match(err, ErrFieldInclusion) {
+		t.Errorf("%T: %s", err, err)
+	}
+	if _, err := DishonorReturn(mockReturnEntry(), "271", "R01"); !base.Match(err, ErrAddenda99DishonoredReturnCode) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+func TestBatch__dishonoredReturnAddenda(t *testing.T) {
+	entry, err := DishonorReturn(mockReturnEntry(), "271", "R68")
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry.Category = CategoryForward
+
+	bh := mockBatchPPDHeader()
+	bh.ServiceClassCode = DebitsOnly
+	batch := NewBatchPPD(bh)
+	batch.AddEntry(entry)
+	if err := batch.Create(); !base.Match(err, ErrBatchAddendaCategory) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	entry.Category = CategoryDishonoredReturn
+	entry.Addenda99Dishonored = nil
+	entry.AddendaRecordIndicator = 0
+	if err := batch.Create(); !base.Match(err, ErrFieldInclusion) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
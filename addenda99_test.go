@@ -87,6 +87,68 @@ func TestAddenda99__LookupReturnCode(t *testing.T) {
 	}
 }
 
+func TestAddenda99__LookupReturnCodeCopy(t *testing.T) {
+	code := LookupReturnCode("R05")
+	code.Reason = "changed"
+	code.SECCodes[0] = "ZZZ"
+
+	if code := LookupReturnCode("R05"); code.Reason == "changed" || code.SECCodes[0] == "ZZZ" {
+		t.Errorf("lookup returned shared ReturnCode: %#v", code)
+	}
+	codes := ReturnCodes()
+	codes[0].Reason = "changed"
+	if code := LookupReturnCode(codes[0].Code); code.Reason == "changed" {
+		t.Errorf("ReturnCodes returned shared ReturnCode: %#v", code)
+	}
+}
+
+func TestAddenda99__ReturnCodes(t *testing.T) {
+	codes := ReturnCodes()
+	if len(codes) != len(returnCodeDict) || codes[0].Code != "R01" {
+		t.Fatalf("got %d codes starting with %s", len(codes), codes[0].Code)
+	}
+	for i := 1; i < len(codes); i++ {
+		if codes[i-1].Code >= codes[i].Code {
+			t.Errorf("%s is out of order", codes[i].Code)
+		}
+	}
+
+	if code := LookupReturnCode("R01"); code.Timeframe != (ReturnTimeframe{Days: 2, BankingDays: true}) || !code.AllowsSECCode(PPD) {
+		t.Errorf("unexpected R01: %#v", code)
+	}
+	if code := LookupReturnCode("R10"); code.Timeframe != (ReturnTimeframe{Days: 60}) {
+		t.Errorf("unexpected R10 timeframe: %#v", code.Timeframe)
+	}
+	if code := LookupReturnCode("R68"); code.Timeframe != (ReturnTimeframe{Days: 5, BankingDays: true}) {
+		t.Errorf("unexpected R68 timeframe: %#v", code.Timeframe)
+	}
+	// every dishonored and contested return code is in the catalog
+	for _, v := range []string{"R61", "R62", "R67", "R68", "R69", "R70", "R71", "R72", "R73", "R74", "R75", "R76", "R77"} {
+		code := LookupReturnCode(v)
+		if code == nil {
+			t.Errorf("missing %s", v)
+			continue
+		}
+		if IsDishonoredReturnCode(v) && code.Timeframe != (ReturnTimeframe{Days: 5, BankingDays: true}) {
+			t.Errorf("unexpected %s timeframe: %#v", v, code.Timeframe)
+		}
+	}
+	if code := LookupReturnCode("R05"); code.AllowsSECCode(PPD) || !code.AllowsSECCode(CCD) {
+		t.Errorf("unexpected R05 SEC codes: %v", code.SECCodes)
+	}
+
+	// ENR only codes are not used with PPD entries
+	for _, code := range ReturnCodesForSECCode("ppd") {
+		if code.Code == "R41" {
+			t.Error("R41 is only for ENR entries")
+		}
+	}
+	enr := ReturnCodesForSECCode(ENR)
+	if len(enr) >= len(codes) {
+		t.Errorf("got %d ENR codes", len(enr))
+	}
+}
+
 func TestAddenda99Parse(t *testing.T) {
 	testAddenda99Parse(t)
 }
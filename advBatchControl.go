@@ -18,9 +18,7 @@
 package ach
 
 import (
-	"fmt"
 	"strconv"
-	"strings"
 	"unicode/utf8"
 )
 
@@ -30,36 +28,38 @@ type ADVBatchControl struct {
 	// ID is a client defined string used as a reference to this record.
 	ID string `json:"id"`
 	// RecordType defines the type of record in the block.
-	recordType string
+	recordType string `ach:"1-1,alpha"`
 	// This should be the same as BatchHeader ServiceClassCode for ADV: AutomatedAccountingAdvices.
-	ServiceClassCode int `json:"serviceClassCode"`
+	ServiceClassCode int `json:"serviceClassCode" ach:"2-4,numeric"`
 	// EntryAddendaCount is a tally of each Entry Detail Record and each Addenda
 	// Record processed, within either the batch or file as appropriate.
-	EntryAddendaCount int `json:"entryAddendaCount"`
+	EntryAddendaCount int `json:"entryAddendaCount" ach:"5-10,numeric"`
 	// validate the Receiving DFI Identification in each Entry Detail Record is hashed
 	// to provide a check against inadvertent alteration of data contents due
 	// to hardware failure or program error
 	//
 	// In this context the Entry Hash is the sum of the corresponding fields in the
 	// Entry Detail Records on the file.
-	EntryHash int `json:"entryHash"`
+	EntryHash int `json:"entryHash" ach:"11-20,numeric"`
 	// TotalDebitEntryDollarAmount Contains accumulated Entry debit totals within the batch.
-	TotalDebitEntryDollarAmount int `json:"totalDebit"`
+	TotalDebitEntryDollarAmount int `json:"totalDebit" ach:"21-40,numeric"`
 	// TotalCreditEntryDollarAmount Contains accumulated Entry credit totals within the batch.
-	TotalCreditEntryDollarAmount int `json:"totalCredit"`
+	TotalCreditEntryDollarAmount int `json:"totalCredit" ach:"41-60,numeric"`
 	// ACHOperatorData is an alphanumeric code used to identify an ACH Operator
-	ACHOperatorData string `json:"achOperatorData"`
+	ACHOperatorData string `json:"achOperatorData" ach:"61-79,alpha"`
 	// ODFIIdentification the routing number is used to identify the DFI originating entries within a given branch.
-	ODFIIdentification string `json:"ODFIIdentification"`
+	ODFIIdentification string `json:"ODFIIdentification" ach:"80-87,string"`
 	// BatchNumber this number is assigned in ascending sequence to each batch by the ODFI
 	// or its Sending Point in a given file of entries. Since the batch number
 	// in the Batch Header Record and the Batch Control Record is the same,
 	// the ascending sequence number should be assigned by batch and not by record.
-	BatchNumber int `json:"batchNumber"`
+	BatchNumber int `json:"batchNumber" ach:"88-94,numeric"`
 	// validator is composed for data validation
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // Parse takes the input record string and parses the EntryDetail values
@@ -67,26 +67,7 @@ func (bc *ADVBatchControl) Parse(record string) {
 	if utf8.RuneCountInString(record) != 94 {
 		return
 	}
-
-	// 1-1 Always "8"
-	bc.recordType = "8"
-	// 2-4 This is the same as the "Service code" field in previous Batch Header Record
-	bc.ServiceClassCode = bc.parseNumField(record[1:4])
-	// 5-10 Total number of Entry Detail Record in the batch
-	bc.EntryAddendaCount = bc.parseNumField(record[4:10])
-	// 11-20 Total of all positions 4-11 on each Entry Detail Record in the batch. This is essentially the sum of all the RDFI routing numbers in the batch.
-	// If the sum exceeds 10 digits (because you have lots of Entry Detail Records), lop off the most significant digits of the sum until there are only 10
-	bc.EntryHash = bc.parseNumField(record[10:20])
-	// 21-32 Number of cents of debit entries within the batch
-	bc.TotalDebitEntryDollarAmount = bc.parseNumField(record[20:40])
-	// 33-44 Number of cents of credit entries within the batch
-	bc.TotalCreditEntryDollarAmount = bc.parseNumField(record[40:60])
-	// 45-54 ACH Operator Data
-	bc.ACHOperatorData = strings.TrimSpace(record[60:79])
-	// 80-87 This is the same as the "ODFI identification" field in previous Batch Header Record
-	bc.ODFIIdentification = bc.parseStringField(record[79:87])
-	// 88-94 This is the same as the "Batch number" field in previous Batch Header Record
-	bc.BatchNumber = bc.parseNumField(record[87:94])
+	bc.parseFields(record)
 }
 
 // NewADVBatchControl returns a new ADVBatchControl with default values for none exported fields
@@ -99,22 +80,6 @@ func NewADVBatchControl() *ADVBatchControl {
 	}
 }
 
-// String writes the ADVBatchControl struct to a 94 character string.
-func (bc *ADVBatchControl) String() string {
-	var buf strings.Builder
-	buf.Grow(94)
-	buf.WriteString(bc.recordType)
-	buf.WriteString(fmt.Sprintf("%v", bc.ServiceClassCode))
-	buf.WriteString(bc.EntryAddendaCountField())
-	buf.WriteString(bc.EntryHashField())
-	buf.WriteString(bc.TotalDebitEntryDollarAmountField())
-	buf.WriteString(bc.TotalCreditEntryDollarAmountField())
-	buf.WriteString(bc.ACHOperatorDataField())
-	buf.WriteString(bc.ODFIIdentificationField())
-	buf.WriteString(bc.BatchNumberField())
-	return buf.String()
-}
-
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (bc *ADVBatchControl) Validate() error {
@@ -169,6 +134,16 @@ func (bc *ADVBatchControl) TotalCreditEntryDollarAmountField() string {
 	return bc.numericField(bc.TotalCreditEntryDollarAmount, 20)
 }
 
+// TotalDebitAmount returns TotalDebitEntryDollarAmount as an Amount
+func (bc *ADVBatchControl) TotalDebitAmount() Amount {
+	return Amount(bc.TotalDebitEntryDollarAmount)
+}
+
+// TotalCreditAmount returns TotalCreditEntryDollarAmount as an Amount
+func (bc *ADVBatchControl) TotalCreditAmount() Amount {
+	return Amount(bc.TotalCreditEntryDollarAmount)
+}
+
 // ACHOperatorDataField get the ACHOperatorData right padded
 func (bc *ADVBatchControl) ACHOperatorDataField() string {
 	return bc.alphaField(bc.ACHOperatorData, 19)
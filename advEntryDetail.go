@@ -18,7 +18,6 @@
 package ach
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 	"unicode/utf8"
@@ -86,6 +85,8 @@ type ADVEntryDetail struct {
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 const (
@@ -167,7 +168,7 @@ func (ed *ADVEntryDetail) String() string {
 	var buf strings.Builder
 	buf.Grow(94)
 	buf.WriteString(ed.recordType)
-	buf.WriteString(fmt.Sprintf("%v", ed.TransactionCode))
+	buf.WriteString(strconv.Itoa(ed.TransactionCode))
 	buf.WriteString(ed.RDFIIdentificationField())
 	buf.WriteString(ed.CheckDigit)
 	buf.WriteString(ed.DFIAccountNumberField())
@@ -177,7 +178,7 @@ func (ed *ADVEntryDetail) String() string {
 	buf.WriteString(ed.ACHOperatorDataField())
 	buf.WriteString(ed.IndividualNameField())
 	buf.WriteString(ed.DiscretionaryDataField())
-	buf.WriteString(fmt.Sprintf("%v", ed.AddendaRecordIndicator))
+	buf.WriteString(strconv.Itoa(ed.AddendaRecordIndicator))
 	buf.WriteString(ed.ACHOperatorRoutingNumberField())
 	buf.WriteString(ed.JulianDateDayField())
 	buf.WriteString(ed.SequenceNumberField())
@@ -278,6 +279,16 @@ func (ed *ADVEntryDetail) AmountField() string {
 	return ed.numericField(ed.Amount, 12)
 }
 
+// GetAmount returns the Amount of the entry
+func (ed *ADVEntryDetail) GetAmount() Amount {
+	return Amount(ed.Amount)
+}
+
+// SetAmount sets the Amount of the entry
+func (ed *ADVEntryDetail) SetAmount(a Amount) {
+	ed.Amount = int(a)
+}
+
 // AdviceRoutingNumberField gets the AdviceRoutingNumber with zero padding
 func (ed *ADVEntryDetail) AdviceRoutingNumberField() string {
 	return ed.stringField(ed.AdviceRoutingNumber, 9)
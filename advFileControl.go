@@ -17,10 +17,7 @@
 
 package ach
 
-import (
-	"strings"
-	"unicode/utf8"
-)
+import "unicode/utf8"
 
 // ADVFileControl record contains entry counts, dollar totals and hash
 // totals accumulated from each batchADV control record in the file.
@@ -28,32 +25,34 @@ type ADVFileControl struct {
 	// ID is a client defined string used as a reference to this record.
 	ID string `json:"id"`
 	// RecordType defines the type of record in the block. fileControlPos 9
-	recordType string
+	recordType string `ach:"1-1,alpha"`
 
 	// BatchCount total number of batches (i.e., '5' records) in the file
-	BatchCount int `json:"batchCount"`
+	BatchCount int `json:"batchCount" ach:"2-7,numeric"`
 
 	// BlockCount total number of records in the file (include all headers and trailer) divided
 	// by 10 (This number must be evenly divisible by 10. If not, additional records consisting of all 9's are added to the file after the initial '9' record to fill out the block 10.)
-	BlockCount int `json:"blockCount,omitempty"`
+	BlockCount int `json:"blockCount,omitempty" ach:"8-13,numeric"`
 
 	// EntryAddendaCount total detail and addenda records in the file
-	EntryAddendaCount int `json:"entryAddendaCount"`
+	EntryAddendaCount int `json:"entryAddendaCount" ach:"14-21,numeric"`
 
 	// EntryHash calculated in the same manner as the batch has total but includes total from entire file
-	EntryHash int `json:"entryHash"`
+	EntryHash int `json:"entryHash" ach:"22-31,numeric"`
 
 	// TotalDebitEntryDollarAmountInFile contains accumulated Batch debit totals within the file.
-	TotalDebitEntryDollarAmountInFile int `json:"totalDebit"`
+	TotalDebitEntryDollarAmountInFile int `json:"totalDebit" ach:"32-51,numeric"`
 
 	// TotalCreditEntryDollarAmountInFile contains accumulated Batch credit totals within the file.
-	TotalCreditEntryDollarAmountInFile int `json:"totalCredit"`
+	TotalCreditEntryDollarAmountInFile int `json:"totalCredit" ach:"52-71,numeric"`
 	// Reserved should be blank.
-	reserved string
+	reserved string `ach:"72-94,blank"`
 	// validator is composed for data validation
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // Parse takes the input record string and parses the FileControl values
@@ -63,24 +62,7 @@ func (fc *ADVFileControl) Parse(record string) {
 	if utf8.RuneCountInString(record) < 71 {
 		return
 	}
-
-	// 1-1 Always "9"
-	fc.recordType = "9"
-	// 2-7 The total number of Batch Header Record in the file. For example: "000003
-	fc.BatchCount = fc.parseNumField(record[1:7])
-	// 8-13 e total number of blocks on the file, including the File Header and File Control records. One block is 10 lines, so it's effectively the number of lines in the file divided by 10.
-	fc.BlockCount = fc.parseNumField(record[7:13])
-	// 14-21 Total number of Entry Detail Record in the file
-	fc.EntryAddendaCount = fc.parseNumField(record[13:21])
-	// 22-31 Total of all positions 4-11 on each Entry Detail Record in the file. This is essentially the sum of all the RDFI routing numbers in the file.
-	// If the sum exceeds 10 digits (because you have lots of Entry Detail Records), lop off the most significant digits of the sum until there are only 10
-	fc.EntryHash = fc.parseNumField(record[21:31])
-	// 32-51 Number of cents of debit entries within the file
-	fc.TotalDebitEntryDollarAmountInFile = fc.parseNumField(record[31:51])
-	// 52-71 Number of cents of credit entries within the file
-	fc.TotalCreditEntryDollarAmountInFile = fc.parseNumField(record[51:71])
-	// 72-94 Reserved Always blank (just fill with spaces)
-	fc.reserved = "                       "
+	fc.parseFields(record)
 }
 
 // NewADVFileControl returns a new ADVFileControl with default values for none exported fields
@@ -91,21 +73,6 @@ func NewADVFileControl() ADVFileControl {
 	}
 }
 
-// String writes the ADVFileControl struct to a 94 character string.
-func (fc *ADVFileControl) String() string {
-	var buf strings.Builder
-	buf.Grow(94)
-	buf.WriteString(fc.recordType)
-	buf.WriteString(fc.BatchCountField())
-	buf.WriteString(fc.BlockCountField())
-	buf.WriteString(fc.EntryAddendaCountField())
-	buf.WriteString(fc.EntryHashField())
-	buf.WriteString(fc.TotalDebitEntryDollarAmountInFileField())
-	buf.WriteString(fc.TotalCreditEntryDollarAmountInFileField())
-	buf.WriteString(fc.reserved)
-	return buf.String()
-}
-
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (fc *ADVFileControl) Validate() error {
@@ -168,3 +135,13 @@ func (fc *ADVFileControl) TotalDebitEntryDollarAmountInFileField() string {
 func (fc *ADVFileControl) TotalCreditEntryDollarAmountInFileField() string {
 	return fc.numericField(fc.TotalCreditEntryDollarAmountInFile, 20)
 }
+
+// TotalDebitAmount returns TotalDebitEntryDollarAmountInFile as an Amount
+func (fc *ADVFileControl) TotalDebitAmount() Amount {
+	return Amount(fc.TotalDebitEntryDollarAmountInFile)
+}
+
+// TotalCreditAmount returns TotalCreditEntryDollarAmountInFile as an Amount
+func (fc *ADVFileControl) TotalCreditAmount() Amount {
+	return Amount(fc.TotalCreditEntryDollarAmountInFile)
+}
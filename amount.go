@@ -0,0 +1,132 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Amount is an amount of money in cents. Use it instead of a float64 of dollars, which can't hold
+// most amounts of cents exactly, e.g. ParseAmount("19.99") rather than int(19.99 * 100).
+//
+// An Amount is written to JSON as a number of cents, like the Amount fields of records. See DollarAmount
+// to write strings of dollars instead.
+type Amount int64
+
+// MaxEntryAmount is the largest Amount which fits in the Amount field of an EntryDetail or IATEntryDetail
+const MaxEntryAmount Amount = 9999999999
+
+// ParseAmount reads an amount of dollars with at most two decimal places, e.g. "1234.56", "$1,234.56" or "-0.5"
+func ParseAmount(s string) (Amount, error) {
+	v := strings.TrimSpace(s)
+	negative := strings.HasPrefix(v, "-")
+	if negative {
+		v = v[1:]
+	}
+	v = strings.Replace(strings.TrimPrefix(v, "$"), ",", "", -1)
+
+	dollars, cents := v, ""
+	if i := strings.Index(v, "."); i >= 0 {
+		dollars, cents = v[:i], v[i+1:]
+	}
+	if (dollars == "" && cents == "") || len(cents) > 2 || !isDigits(dollars) || !isDigits(cents) {
+		return 0, fmt.Errorf("%q %w", s, ErrInvalidAmount)
+	}
+	cents += strings.Repeat("0", 2-len(cents))
+
+	n, err := strconv.ParseInt(dollars+cents, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q %w", s, ErrInvalidAmount)
+	}
+	if negative {
+		n = -n
+	}
+	return Amount(n), nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Cents returns a as a number of cents
+func (a Amount) Cents() int64 {
+	return int64(a)
+}
+
+// String returns a as dollars with two decimal places, e.g. "1234.56"
+func (a Amount) String() string {
+	sign, n := "", int64(a)
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, n/100, n%100)
+}
+
+// MarshalJSON writes a as a number of cents
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(a), 10)), nil
+}
+
+// UnmarshalJSON reads a number of cents or a string of dollars (see ParseAmount)
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		v, err := ParseAmount(s)
+		if err != nil {
+			return err
+		}
+		*a = v
+		return nil
+	}
+	n, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%s %w", data, ErrInvalidAmount)
+	}
+	*a = Amount(n)
+	return nil
+}
+
+// DollarAmount is an Amount which is written to JSON as a string of dollars, e.g. "1234.56"
+type DollarAmount Amount
+
+// String returns d as dollars with two decimal places
+func (d DollarAmount) String() string {
+	return Amount(d).String()
+}
+
+// MarshalJSON writes d as a string of dollars
+func (d DollarAmount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Amount(d).String())
+}
+
+// UnmarshalJSON reads a string of dollars or a number of cents
+func (d *DollarAmount) UnmarshalJSON(data []byte) error {
+	return (*Amount)(d).UnmarshalJSON(data)
+}
@@ -0,0 +1,125 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestAmount__Parse(t *testing.T) {
+	cases := map[string]Amount{
+		"1234.56":   123456,
+		"$1,234.56": 123456,
+		"19.99":     1999,
+		"0.5":       50,
+		".05":       5,
+		"12":        1200,
+		"12.":       1200,
+		" -0.05 ":   -5,
+	}
+	for in, want := range cases {
+		got, err := ParseAmount(in)
+		if err != nil {
+			t.Errorf("%q: %v", in, err)
+		}
+		if got != want {
+			t.Errorf("%q: got %d, want %d", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"", ".", "1.234", "1e3", "12.3.4", "abc", "--1", "99999999999999999999"} {
+		if _, err := ParseAmount(in); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("%q: expected ErrInvalidAmount, got %v", in, err)
+		}
+	}
+}
+
+func TestAmount__String(t *testing.T) {
+	cases := map[Amount]string{
+		0:      "0.00",
+		5:      "0.05",
+		123456: "1234.56",
+		-150:   "-1.50",
+	}
+	for in, want := range cases {
+		if got := in.String(); got != want {
+			t.Errorf("%d: got %q, want %q", in, got, want)
+		}
+	}
+	if n := Amount(1999).Cents(); n != 1999 {
+		t.Errorf("Cents()=%d", n)
+	}
+}
+
+func TestAmount__JSON(t *testing.T) {
+	var v struct {
+		Amount  Amount       `json:"amount"`
+		Dollars DollarAmount `json:"dollars"`
+	}
+	if err := json.Unmarshal([]byte(`{"amount": "12.34", "dollars": 1999}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Amount != 1234 || v.Dollars != 1999 {
+		t.Errorf("amount=%d dollars=%d", v.Amount, v.Dollars)
+	}
+
+	bs, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(bs); s != `{"amount":1234,"dollars":"19.99"}` {
+		t.Errorf("unexpected JSON: %s", s)
+	}
+
+	for _, in := range []string{`{"amount": 12.5}`, `{"amount": "1.234"}`, `{"dollars": true}`} {
+		if err := json.Unmarshal([]byte(in), &v); err == nil {
+			t.Errorf("%s: expected error", in)
+		}
+	}
+}
+
+func TestAmount__accessors(t *testing.T) {
+	ed := mockPPDEntryDetail()
+	ed.SetAmount(1999)
+	if ed.Amount != 1999 || ed.GetAmount() != 1999 {
+		t.Errorf("Amount=%d", ed.Amount)
+	}
+
+	ed.SetAmount(MaxEntryAmount + 1)
+	if err := ed.Validate(); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("expected ErrInvalidAmount, got %v", err)
+	}
+	iatEd := mockIATEntryDetail()
+	iatEd.SetAmount(MaxEntryAmount + 1)
+	if err := iatEd.Validate(); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("expected ErrInvalidAmount, got %v", err)
+	}
+
+	bc := NewBatchControl()
+	bc.TotalDebitEntryDollarAmount, bc.TotalCreditEntryDollarAmount = 100, 250
+	if bc.TotalDebitAmount() != 100 || bc.TotalCreditAmount() != 250 {
+		t.Errorf("debit=%s credit=%s", bc.TotalDebitAmount(), bc.TotalCreditAmount())
+	}
+	fc := NewFileControl()
+	fc.TotalDebitEntryDollarAmountInFile, fc.TotalCreditEntryDollarAmountInFile = 100, 250
+	if fc.TotalDebitAmount() != 100 || fc.TotalCreditAmount() != 250 {
+		t.Errorf("debit=%s credit=%s", fc.TotalDebitAmount(), fc.TotalCreditAmount())
+	}
+}
@@ -0,0 +1,62 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"time"
+
+	"github.com/moov-io/base"
+)
+
+// IsBankingDay returns true if the calendar day of t (in its location) is a day the Federal Reserve
+// processes ACH payments. Weekends and Federal Reserve holidays are not banking days.
+func IsBankingDay(t time.Time) bool {
+	return base.NewTime(calendarDay(t)).IsBankingDay()
+}
+
+// NextBankingDay returns the first banking day after t, or t's day when sameDay is true and t
+// is a banking day. The returned time is midnight in t's location and can be used as an
+// EffectiveEntryDate.
+func NextBankingDay(t time.Time, sameDay bool) time.Time {
+	day := startOfDay(t)
+	if sameDay && IsBankingDay(day) {
+		return day
+	}
+	next := base.NewTime(calendarDay(day)).AddBankingDay(1).Time
+	return time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// BankingDaysBetween returns the number of banking days after start up to and including end.
+// Zero is returned when end is on or before start.
+func BankingDaysBetween(start, end time.Time) int {
+	start, end = startOfDay(start), startOfDay(end)
+
+	days := 0
+	for day := start.AddDate(0, 0, 1); !day.After(end); day = day.AddDate(0, 0, 1) {
+		if IsBankingDay(day) {
+			days++
+		}
+	}
+	return days
+}
+
+// calendarDay returns noon UTC of t's year, month and day so base.Time (which operates in UTC)
+// checks the same calendar day regardless of t's location.
+func calendarDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, time.UTC)
+}
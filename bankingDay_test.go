@@ -0,0 +1,91 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsBankingDay(t *testing.T) {
+	cases := map[string]bool{
+		"2019-06-25": true,  // Tuesday
+		"2019-06-29": false, // Saturday
+		"2019-06-30": false, // Sunday
+		"2019-07-04": false, // Independence Day
+		"2019-12-25": false, // Christmas Day
+		"2019-11-11": false, // Veterans Day
+	}
+	for day, expected := range cases {
+		when, _ := time.Parse("2006-01-02", day)
+		if IsBankingDay(when) != expected {
+			t.Errorf("%s: expected %v", day, expected)
+		}
+	}
+
+	// 23:00 Eastern is the next day in UTC
+	loc := easternLocation()
+	if !IsBankingDay(time.Date(2019, time.July, 3, 23, 0, 0, 0, loc)) {
+		t.Error("expected July 3rd to be a banking day")
+	}
+}
+
+func TestNextBankingDay(t *testing.T) {
+	loc := easternLocation()
+	tuesday := time.Date(2019, time.June, 25, 15, 30, 0, 0, loc)
+
+	if day := NextBankingDay(tuesday, true); !day.Equal(time.Date(2019, time.June, 25, 0, 0, 0, 0, loc)) {
+		t.Errorf("got %v", day)
+	}
+	if day := NextBankingDay(tuesday, false); !day.Equal(time.Date(2019, time.June, 26, 0, 0, 0, 0, loc)) {
+		t.Errorf("got %v", day)
+	}
+
+	// Friday to Monday
+	friday := time.Date(2019, time.June, 28, 10, 0, 0, 0, loc)
+	if day := NextBankingDay(friday, false); !day.Equal(time.Date(2019, time.July, 1, 0, 0, 0, 0, loc)) {
+		t.Errorf("got %v", day)
+	}
+	// Saturday, even with sameDay, is Monday
+	saturday := time.Date(2019, time.June, 29, 10, 0, 0, 0, loc)
+	if day := NextBankingDay(saturday, true); !day.Equal(time.Date(2019, time.July, 1, 0, 0, 0, 0, loc)) {
+		t.Errorf("got %v", day)
+	}
+	// skip Independence Day
+	july3 := time.Date(2019, time.July, 3, 10, 0, 0, 0, loc)
+	if day := NextBankingDay(july3, false); !day.Equal(time.Date(2019, time.July, 5, 0, 0, 0, 0, loc)) {
+		t.Errorf("got %v", day)
+	}
+}
+
+func TestBankingDaysBetween(t *testing.T) {
+	friday := time.Date(2019, time.June, 28, 10, 0, 0, 0, time.UTC)
+	if n := BankingDaysBetween(friday, friday); n != 0 {
+		t.Errorf("got %d", n)
+	}
+	if n := BankingDaysBetween(friday, friday.AddDate(0, 0, 3)); n != 1 {
+		t.Errorf("got %d", n)
+	}
+	// Monday July 1st through Friday July 5th, skipping Independence Day
+	if n := BankingDaysBetween(friday, friday.AddDate(0, 0, 7)); n != 4 {
+		t.Errorf("got %d", n)
+	}
+	if n := BankingDaysBetween(friday, friday.AddDate(0, 0, -7)); n != 0 {
+		t.Errorf("got %d", n)
+	}
+}
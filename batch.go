@@ -36,6 +36,9 @@ type Batch struct {
 	ADVEntries []*ADVEntryDetail `json:"advEntryDetails,omitempty"`
 	ADVControl *ADVBatchControl  `json:"advBatchControl,omitempty"`
 
+	// Comments are free form notes kept with the batch's JSON. They're never written into a NACHA file.
+	Comments []string `json:"comments,omitempty"`
+
 	// offset holds the information to build an EntryDetail record which
 	// balances the batch by debiting or crediting the sum of amounts in the batch.
 	offset *Offset `json:"offset"`
@@ -46,6 +49,9 @@ type Batch struct {
 	converters
 
 	validateOpts *ValidateOpts
+
+	// traceNumbers assigns TraceNumbers to entries in build() when set
+	traceNumbers TraceNumberGenerator
 }
 
 const (
@@ -149,6 +155,35 @@ func (batch *Batch) UnmarshalJSON(p []byte) error {
 	return nil
 }
 
+// BatchFromJSON reads a Batch from JSON and returns it as the Batcher of its SEC code.
+// The batch isn't built, call Create() on it to tabulate and validate it.
+func BatchFromJSON(bs []byte) (Batcher, error) {
+	if len(bs) == 0 {
+		return nil, errors.New("no JSON data provided")
+	}
+	var batch Batch
+	if err := json.Unmarshal(bs, &batch); err != nil {
+		return nil, fmt.Errorf("problem reading Batch: %v", err)
+	}
+	if batch.Header == nil {
+		return nil, errors.New("no BatchHeader provided")
+	}
+	batch.setRecordTypesFromJSON()
+	return ConvertBatchType(batch), nil
+}
+
+// setRecordTypesFromJSON fills in the record types of a batch read from JSON, which
+// are inferred from the JSON field names rather than included
+func (batch *Batch) setRecordTypesFromJSON() {
+	batch.Header.recordType = batchHeaderPos
+	for _, e := range batch.Entries {
+		setEntryRecordType(e)
+	}
+	for _, e := range batch.ADVEntries {
+		setADVEntryRecordType(e)
+	}
+}
+
 // NewBatch takes a BatchHeader and returns a matching SEC code batch type that is a batcher. Returns an error if the SEC code is not supported.
 func NewBatch(bh *BatchHeader) (Batcher, error) {
 	if bh == nil {
@@ -203,6 +238,12 @@ func NewBatch(bh *BatchHeader) (Batcher, error) {
 	case XCK:
 		return NewBatchXCK(bh), nil
 	default:
+		if factory, ok := registeredBatchType(bh.StandardEntryClassCode); ok {
+			batch := Batch{}
+			batch.SetControl(NewBatchControl())
+			batch.SetHeader(bh)
+			return factory(batch), nil
+		}
 	}
 	return nil, NewErrFileUnknownSEC(bh.StandardEntryClassCode)
 }
@@ -255,6 +296,9 @@ func ConvertBatchType(b Batch) Batcher {
 	case XCK:
 		return &BatchXCK{b}
 	default:
+		if factory, ok := registeredBatchType(b.Header.StandardEntryClassCode); ok {
+			return factory(b)
+		}
 		return &b
 	}
 }
@@ -285,6 +329,32 @@ func (batch *Batch) SetValidation(opts *ValidateOpts) {
 	batch.validateOpts = opts
 }
 
+// strictBatch returns a Batcher sharing the records of b which validates with opts instead of
+// any ValidateOpts set on b, so a batch's own overrides can't relax StrictNACHA.
+func strictBatch(b Batcher, opts *ValidateOpts) Batcher {
+	batch := Batch{
+		Header:       b.GetHeader(),
+		Entries:      b.GetEntries(),
+		Control:      b.GetControl(),
+		ADVEntries:   b.GetADVEntries(),
+		ADVControl:   b.GetADVControl(),
+		category:     b.Category(),
+		validateOpts: opts,
+	}
+	batch.SetID(b.ID())
+	return ConvertBatchType(batch)
+}
+
+// SetTraceNumberGenerator has Create() assign TraceNumbers to entries from gen rather than
+// numbering each batch's entries from 1. Entries which already have a TraceNumber from the
+// batch's ODFI keep it.
+func (batch *Batch) SetTraceNumberGenerator(gen TraceNumberGenerator) {
+	if batch == nil {
+		return
+	}
+	batch.traceNumbers = gen
+}
+
 // verify checks basic valid NACHA batch rules. Assumes properly parsed records. This does not mean it is a valid batch as validity is tied to each batch type
 func (batch *Batch) verify() error {
 	// No entries in batch
@@ -362,6 +432,82 @@ func (batch *Batch) verify() error {
 	return nil
 }
 
+// defaultMaxAddendaPerEntry is the NACHA maximum number of Addenda05 records allowed on a
+// forward entry for each StandardEntryClassCode. SEC codes which are not listed are not limited
+// by this check.
+var defaultMaxAddendaPerEntry = map[string]int{
+	ACK: 1,
+	ARC: 0,
+	ATX: 9999,
+	BOC: 0,
+	CCD: 1,
+	CIE: 1,
+	CTX: 9999,
+	DNE: 1,
+	ENR: 9999,
+	MTE: 0,
+	POP: 0,
+	POS: 0,
+	PPD: 1,
+	RCK: 0,
+	SHR: 0,
+	TEL: 0,
+	TRC: 0,
+	TRX: 9999,
+	WEB: 1,
+	XCK: 0,
+}
+
+// DefaultMaxAddendaPerEntry returns the NACHA maximum number of Addenda05 records allowed on a
+// forward entry for the StandardEntryClassCode and if the SEC code is limited at all. Limits can
+// be overridden per File or Batch with ValidateOpts.MaxAddendaPerEntry.
+func DefaultMaxAddendaPerEntry(secCode string) (int, bool) {
+	max, ok := defaultMaxAddendaPerEntry[secCode]
+	return max, ok
+}
+
+// maxAddendaPerEntry returns the maximum number of Addenda05 records allowed on a forward entry
+// in this batch and if a limit applies for the batch's StandardEntryClassCode.
+func (batch *Batch) maxAddendaPerEntry() (int, bool) {
+	if batch.Header == nil {
+		return 0, false
+	}
+	sec := batch.Header.StandardEntryClassCode
+	if batch.validateOpts != nil {
+		if max, ok := batch.validateOpts.MaxAddendaPerEntry[sec]; ok {
+			return max, true
+		}
+	}
+	return DefaultMaxAddendaPerEntry(sec)
+}
+
+// isAddendaCount verifies a forward entry does not have more Addenda05 records than allowed
+// for the batch's StandardEntryClassCode
+func (batch *Batch) isAddendaCount(entry *EntryDetail) error {
+	if entry.Category != CategoryForward {
+		return nil
+	}
+	if max, ok := batch.maxAddendaPerEntry(); ok && len(entry.Addenda05) > max {
+		return batch.Error("AddendaCount", NewErrBatchAddendaCount(len(entry.Addenda05), max))
+	}
+	return nil
+}
+
+// AddAddenda05 appends an Addenda05 to an EntryDetail of the Batch and sets its AddendaRecordIndicator.
+// An error is returned if the entry would have more Addenda05 records than allowed for the batch's
+// StandardEntryClassCode.
+func (batch *Batch) AddAddenda05(entry *EntryDetail, addenda05 *Addenda05) error {
+	if entry == nil || addenda05 == nil {
+		return nil
+	}
+	if max, ok := batch.maxAddendaPerEntry(); ok && len(entry.Addenda05)+1 > max {
+		return batch.Error("AddendaCount", NewErrBatchAddendaCount(len(entry.Addenda05)+1, max))
+	}
+	entry.AddendaRecordIndicator = 1
+	entry.AddAddenda05(addenda05)
+	return nil
+}
+
 // Build creates valid batch by building sequence numbers and batch batch control. An error is returned if
 // the batch being built has invalid records.
 func (batch *Batch) build() error {
@@ -377,6 +523,9 @@ func (batch *Batch) build() error {
 	seq := 1
 
 	if !batch.IsADV() {
+		if batch.validateOpts != nil && batch.validateOpts.CorrectServiceClassCode {
+			batch.Header.ServiceClassCode = batch.calculateServiceClassCode()
+		}
 		for i, entry := range batch.Entries {
 			entryCount += 1 + entry.addendaCount()
 
@@ -393,10 +542,24 @@ func (batch *Batch) build() error {
 			// Add a sequenced TraceNumber if one is not already set. Have to keep original trance number Return and NOC entries
 			if currentTraceNumberODFI != batchHeaderODFI {
 				if batch.validateOpts == nil || !batch.validateOpts.BypassOriginValidation {
-					entry.SetTraceNumber(batch.Header.ODFIIdentification, seq)
+					if batch.traceNumbers != nil {
+						traceNumber, err := batch.traceNumbers.Next(batch.Header.ODFIIdentification)
+						if err != nil {
+							return batch.Error("TraceNumber", err)
+						}
+						entry.TraceNumber = traceNumber
+					} else {
+						entry.SetTraceNumber(batch.Header.ODFIIdentification, seq)
+					}
 				}
 			}
 			seq++
+			if entry.Addenda99Dishonored != nil && entry.Addenda99Dishonored.TraceNumber == "" {
+				entry.Addenda99Dishonored.TraceNumber = entry.TraceNumber
+			}
+			if entry.Addenda99Contested != nil && entry.Addenda99Contested.TraceNumber == "" {
+				entry.Addenda99Contested.TraceNumber = entry.TraceNumber
+			}
 			addendaSeq := 1
 			for _, a := range entry.Addenda05 {
 				// sequences don't exist in NOC or Return addenda
@@ -446,6 +609,11 @@ func (batch *Batch) build() error {
 	return batch.upsertOffsets()
 }
 
+// validation returns the ValidateOpts set on the batch, nil when none are
+func (batch *Batch) validation() *ValidateOpts {
+	return batch.validateOpts
+}
+
 // SetHeader appends an BatchHeader to the Batch
 func (batch *Batch) SetHeader(batchHeader *BatchHeader) {
 	batch.Header = batchHeader
@@ -565,6 +733,16 @@ func (batch *Batch) isFieldInclusion() error {
 					return err
 				}
 			}
+			if entry.Addenda99Dishonored != nil {
+				if err := entry.Addenda99Dishonored.Validate(); err != nil {
+					return err
+				}
+			}
+			if entry.Addenda99Contested != nil {
+				if err := entry.Addenda99Contested.Validate(); err != nil {
+					return err
+				}
+			}
 
 		}
 		return batch.Control.Validate()
@@ -667,6 +845,26 @@ func (batch *Batch) calculateBatchAmounts() (credit int, debit int) {
 	return credit, debit
 }
 
+// calculateServiceClassCode returns the ServiceClassCode which describes the entries of the batch.
+func (batch *Batch) calculateServiceClassCode() int {
+	credits, debits := false, false
+	for _, entry := range batch.Entries {
+		switch entry.CreditOrDebit() {
+		case "C":
+			credits = true
+		case "D":
+			debits = true
+		}
+	}
+	switch {
+	case credits && !debits:
+		return CreditsOnly
+	case debits && !credits:
+		return DebitsOnly
+	}
+	return MixedDebitsAndCredits
+}
+
 func (batch *Batch) calculateADVBatchAmounts() (credit int, debit int) {
 	for _, entry := range batch.ADVEntries {
 		if entry.TransactionCode == CreditForDebitsOriginated ||
@@ -813,7 +1011,7 @@ func (batch *Batch) isAddendaSequence() error {
 				return batch.Error("AddendaRecordIndicator", ErrBatchAddendaIndicator)
 			}
 		}
-		if entry.Addenda99 != nil {
+		if entry.Addenda99 != nil || entry.Addenda99Dishonored != nil || entry.Addenda99Contested != nil {
 			if entry.AddendaRecordIndicator != 1 {
 				return batch.Error("AddendaRecordIndicator", ErrBatchAddendaIndicator)
 			}
@@ -907,10 +1105,10 @@ func (batch *Batch) addendaFieldInclusionForward(entry *EntryDetail) error {
 			return batch.Error("Addenda98", ErrBatchAddendaCategory, entry.Category)
 		}
 	}
-	if entry.Addenda99 != nil {
-		return batch.Error("Addenda99", ErrBatchAddendaCategory, entry.Category)
+	if err := batch.excludeReturnAddenda(entry); err != nil {
+		return err
 	}
-	return nil
+	return batch.isAddendaCount(entry)
 }
 
 // addendaFieldInclusionNOC verifies Addenda* Field Inclusion for entry.Category NOC
@@ -926,9 +1124,20 @@ func (batch *Batch) addendaFieldInclusionNOC(entry *EntryDetail) error {
 			return batch.Error("Addenda98", ErrFieldInclusion)
 		}
 	}
+	return batch.excludeReturnAddenda(entry)
+}
+
+// excludeReturnAddenda verifies an entry which isn't a return has no Addenda99 records
+func (batch *Batch) excludeReturnAddenda(entry *EntryDetail) error {
 	if entry.Addenda99 != nil {
 		return batch.Error("Addenda99", ErrBatchAddendaCategory, entry.Category)
 	}
+	if entry.Addenda99Dishonored != nil {
+		return batch.Error("Addenda99Dishonored", ErrBatchAddendaCategory, entry.Category)
+	}
+	if entry.Addenda99Contested != nil {
+		return batch.Error("Addenda99Contested", ErrBatchAddendaCategory, entry.Category)
+	}
 	return nil
 }
 
@@ -943,8 +1152,20 @@ func (batch *Batch) addendaFieldInclusionReturn(entry *EntryDetail) error {
 	if entry.Addenda98 != nil {
 		return batch.Error("Addenda98", ErrBatchAddendaCategory, entry.Category)
 	}
-	if entry.Addenda99 == nil {
-		return batch.Error("Addenda99", ErrFieldInclusion)
+	switch entry.Category {
+	case CategoryDishonoredReturn:
+		// dishonored returns built before Addenda99Dishonored existed carry an Addenda99
+		if entry.Addenda99Dishonored == nil && entry.Addenda99 == nil {
+			return batch.Error("Addenda99Dishonored", ErrFieldInclusion)
+		}
+	case CategoryDishonoredReturnContested:
+		if entry.Addenda99Contested == nil && entry.Addenda99 == nil {
+			return batch.Error("Addenda99Contested", ErrFieldInclusion)
+		}
+	default:
+		if entry.Addenda99 == nil {
+			return batch.Error("Addenda99", ErrFieldInclusion)
+		}
 	}
 	return nil
 }
@@ -1075,7 +1296,7 @@ func (b *Batch) upsertOffsets() error {
 			}
 			// remove the EntryDetail
 			b.Control.EntryAddendaCount -= 1
-			b.Entries = append(b.Entries[:i], b.Entries[i+i:]...)
+			b.Entries = append(b.Entries[:i], b.Entries[i+1:]...)
 			i--
 		}
 	}
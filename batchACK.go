@@ -51,9 +51,6 @@ func (batch *BatchACK) Validate() error {
 		if entry.Amount > 0 {
 			return batch.Error("Amount", ErrBatchAmountNonZero, entry.Amount)
 		}
-		if len(entry.Addenda05) > 1 {
-			return batch.Error("AddendaCount", NewErrBatchAddendaCount(len(entry.Addenda05), 1))
-		}
 		switch entry.TransactionCode {
 		case CheckingZeroDollarRemittanceCredit, SavingsZeroDollarRemittanceCredit:
 		default:
@@ -515,7 +515,7 @@ func TestBatchATXAmount(t *testing.T) {
 	bh.OriginatorStatusCode = 2
 
 	entry := NewEntryDetail()
-	entry.TransactionCode = CheckingPrenoteCredit
+	entry.TransactionCode = CheckingZeroDollarRemittanceCredit
 	entry.SetRDFI("121042882")
 	entry.DFIAccountNumber = "744-5678-99"
 	entry.Amount = 25000
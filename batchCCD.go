@@ -45,14 +45,18 @@ func (batch *BatchCCD) Validate() error {
 	}
 
 	for _, entry := range batch.Entries {
-		// CCD can have up to one Addenda05 record,
-		if len(entry.Addenda05) > 1 {
-			return batch.Error("AddendaCount", NewErrBatchAddendaCount(len(entry.Addenda05), 1))
-		}
 		// Verify the TransactionCode is valid for a ServiceClassCode
 		if err := batch.ValidTranCodeForServiceClassCode(entry); err != nil {
 			return err
 		}
+		// Zero dollar entries only carry remittance data
+		if err := batch.isZeroDollarRemittance(entry); err != nil {
+			return err
+		}
+		// Health care claim payments carry the reassociation trace of their 835
+		if err := batch.isHealthCareClaimPayment(entry); err != nil {
+			return err
+		}
 		// Verify Addenda* FieldInclusion based on entry.Category and batchHeader.StandardEntryClassCode
 		if err := batch.addendaFieldInclusion(entry); err != nil {
 			return err
@@ -67,6 +67,9 @@ func (batch *BatchCTX) Validate() error {
 		if len(entry.Addenda05) != addendaRecords {
 			return batch.Error("AddendaCount", NewErrBatchExpectedAddendaCount(len(entry.Addenda05), addendaRecords))
 		}
+		if entry.AddendaRecordIndicator == 1 && entry.addendaCount() == 0 {
+			return batch.Error("AddendaRecordIndicator", ErrBatchAddendaIndicatorNoAddenda)
+		}
 
 		switch entry.TransactionCode {
 		case CheckingPrenoteCredit, CheckingPrenoteDebit, SavingsPrenoteCredit, SavingsReturnNOCDebit, GLPrenoteCredit,
@@ -78,6 +81,10 @@ func (batch *BatchCTX) Validate() error {
 		if err := batch.ValidTranCodeForServiceClassCode(entry); err != nil {
 			return err
 		}
+		// Zero dollar entries only carry remittance data
+		if err := batch.isZeroDollarRemittance(entry); err != nil {
+			return err
+		}
 		// Verify Addenda* FieldInclusion based on entry.Category and batchHeader.StandardEntryClassCode
 		if err := batch.addendaFieldInclusion(entry); err != nil {
 			return err
@@ -450,7 +450,7 @@ func testBatchCTXPrenoteAddendaRecords(t testing.TB) {
 	entry.TransactionCode = CheckingPrenoteCredit
 	entry.SetRDFI("231380104")
 	entry.DFIAccountNumber = "744-5678-99"
-	entry.Amount = 25000
+	entry.Amount = 0
 	entry.IdentificationNumber = "45689033"
 	entry.SetCATXAddendaRecords(1)
 	entry.SetCATXReceivingCompany("Receiver Company")
@@ -18,9 +18,7 @@
 package ach
 
 import (
-	"fmt"
 	"strconv"
-	"strings"
 	"unicode/utf8"
 )
 
@@ -30,27 +28,27 @@ type BatchControl struct {
 	// ID is a client defined string used as a reference to this record.
 	ID string `json:"id"`
 	// RecordType defines the type of record in the block.
-	recordType string
+	recordType string `ach:"1-1,alpha"`
 	// ServiceClassCode ACH Mixed Debits and Credits '200'
 	// ACH Credits Only '220'
 	// ACH Debits Only '225'
 	// Constants: MixedCreditsAnDebits (220), CReditsOnly 9220), DebitsOnly (225)
 	// Same as 'ServiceClassCode' in BatchHeaderRecord
-	ServiceClassCode int `json:"serviceClassCode"`
+	ServiceClassCode int `json:"serviceClassCode" ach:"2-4,numeric"`
 	// EntryAddendaCount is a tally of each Entry Detail Record and each Addenda
 	// Record processed, within either the batch or file as appropriate.
-	EntryAddendaCount int `json:"entryAddendaCount"`
+	EntryAddendaCount int `json:"entryAddendaCount" ach:"5-10,numeric"`
 	// validate the Receiving DFI Identification in each Entry Detail Record is hashed
 	// to provide a check against inadvertent alteration of data contents due
 	// to hardware failure or program error
 	//
 	// In this context the Entry Hash is the sum of the corresponding fields in the
 	// Entry Detail Records on the file.
-	EntryHash int `json:"entryHash"`
+	EntryHash int `json:"entryHash" ach:"11-20,numeric"`
 	// TotalDebitEntryDollarAmount Contains accumulated Entry debit totals within the batch.
-	TotalDebitEntryDollarAmount int `json:"totalDebit"`
+	TotalDebitEntryDollarAmount int `json:"totalDebit" ach:"21-32,numeric"`
 	// TotalCreditEntryDollarAmount Contains accumulated Entry credit totals within the batch.
-	TotalCreditEntryDollarAmount int `json:"totalCredit"`
+	TotalCreditEntryDollarAmount int `json:"totalCredit" ach:"33-44,numeric"`
 	// CompanyIdentification is an alphanumeric code used to identify an Originator
 	// The Company Identification Field must be included on all
 	// prenotification records and on each entry initiated pursuant to such
@@ -62,27 +60,29 @@ type BatchControl struct {
 	// IRS Employer Identification Number (EIN) "1"
 	// Data Universal Numbering Systems (DUNS) "3"
 	// User Assigned Number "9"
-	CompanyIdentification string `json:"companyIdentification"`
+	CompanyIdentification string `json:"companyIdentification" ach:"45-54,alpha"`
 	// MessageAuthenticationCode the MAC is an eight character code derived from a special key used in
 	// conjunction with the DES algorithm. The purpose of the MAC is to
 	// validate the authenticity of ACH entries. The DES algorithm and key
 	// message standards must be in accordance with standards adopted by the
 	// American National Standards Institute. The remaining eleven characters
 	// of this field are blank.
-	MessageAuthenticationCode string `json:"messageAuthentication,omitempty"`
+	MessageAuthenticationCode string `json:"messageAuthentication,omitempty" ach:"55-73,alpha"`
 	// Reserved for the future - Blank, 6 characters long
-	reserved string
+	reserved string `ach:"74-79,blank"`
 	// ODFIIdentification the routing number is used to identify the DFI originating entries within a given branch.
-	ODFIIdentification string `json:"ODFIIdentification"`
+	ODFIIdentification string `json:"ODFIIdentification" ach:"80-87,string"`
 	// BatchNumber this number is assigned in ascending sequence to each batch by the ODFI
 	// or its Sending Point in a given file of entries. Since the batch number
 	// in the Batch Header Record and the Batch Control Record is the same,
 	// the ascending sequence number should be assigned by batch and not by record.
-	BatchNumber int `json:"batchNumber"`
+	BatchNumber int `json:"batchNumber" ach:"88-94,numeric"`
 	// validator is composed for data validation
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // Parse takes the input record string and parses the EntryDetail values
@@ -92,30 +92,7 @@ func (bc *BatchControl) Parse(record string) {
 	if utf8.RuneCountInString(record) != 94 {
 		return
 	}
-
-	// 1-1 Always "8"
-	bc.recordType = "8"
-	// 2-4 This is the same as the "Service code" field in previous Batch Header Record
-	bc.ServiceClassCode = bc.parseNumField(record[1:4])
-	// 5-10 Total number of Entry Detail Record in the batch
-	bc.EntryAddendaCount = bc.parseNumField(record[4:10])
-	// 11-20 Total of all positions 4-11 on each Entry Detail Record in the batch. This is essentially the sum of all the RDFI routing numbers in the batch.
-	// If the sum exceeds 10 digits (because you have lots of Entry Detail Records), lop off the most significant digits of the sum until there are only 10
-	bc.EntryHash = bc.parseNumField(record[10:20])
-	// 21-32 Number of cents of debit entries within the batch
-	bc.TotalDebitEntryDollarAmount = bc.parseNumField(record[20:32])
-	// 33-44 Number of cents of credit entries within the batch
-	bc.TotalCreditEntryDollarAmount = bc.parseNumField(record[32:44])
-	// 45-54 This is the same as the "Company identification" field in previous Batch Header Record
-	bc.CompanyIdentification = strings.TrimSpace(record[44:54])
-	// 55-73 Seems to always be blank
-	bc.MessageAuthenticationCode = strings.TrimSpace(record[54:73])
-	// 74-79 Always blank (just fill with spaces)
-	bc.reserved = "      "
-	// 80-87 This is the same as the "ODFI identification" field in previous Batch Header Record
-	bc.ODFIIdentification = bc.parseStringField(record[79:87])
-	// 88-94 This is the same as the "Batch number" field in previous Batch Header Record
-	bc.BatchNumber = bc.parseNumField(record[87:94])
+	bc.parseFields(record)
 }
 
 // NewBatchControl returns a new BatchControl with default values for none exported fields
@@ -128,24 +105,6 @@ func NewBatchControl() *BatchControl {
 	}
 }
 
-// String writes the BatchControl struct to a 94 character string.
-func (bc *BatchControl) String() string {
-	var buf strings.Builder
-	buf.Grow(94)
-	buf.WriteString(bc.recordType)
-	buf.WriteString(fmt.Sprintf("%v", bc.ServiceClassCode))
-	buf.WriteString(bc.EntryAddendaCountField())
-	buf.WriteString(bc.EntryHashField())
-	buf.WriteString(bc.TotalDebitEntryDollarAmountField())
-	buf.WriteString(bc.TotalCreditEntryDollarAmountField())
-	buf.WriteString(bc.CompanyIdentificationField())
-	buf.WriteString(bc.MessageAuthenticationCodeField())
-	buf.WriteString("      ")
-	buf.WriteString(bc.ODFIIdentificationField())
-	buf.WriteString(bc.BatchNumberField())
-	return buf.String()
-}
-
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (bc *BatchControl) Validate() error {
@@ -205,6 +164,16 @@ func (bc *BatchControl) TotalCreditEntryDollarAmountField() string {
 	return bc.numericField(bc.TotalCreditEntryDollarAmount, 12)
 }
 
+// TotalDebitAmount returns TotalDebitEntryDollarAmount as an Amount
+func (bc *BatchControl) TotalDebitAmount() Amount {
+	return Amount(bc.TotalDebitEntryDollarAmount)
+}
+
+// TotalCreditAmount returns TotalCreditEntryDollarAmount as an Amount
+func (bc *BatchControl) TotalCreditAmount() Amount {
+	return Amount(bc.TotalCreditEntryDollarAmount)
+}
+
 // CompanyIdentificationField get the CompanyIdentification right padded
 func (bc *BatchControl) CompanyIdentificationField() string {
 	return bc.alphaField(bc.CompanyIdentification, 10)
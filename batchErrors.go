@@ -29,6 +29,8 @@ var (
 	ErrBatchADVCount = errors.New("there can be a maximum of 9999 ADV Sequence Numbers (ADV Entry Detail Records)")
 	// ErrBatchAddendaIndicator is the error given when the addenda indicator is incorrectly set
 	ErrBatchAddendaIndicator = errors.New("is 0 but found addenda record(s)")
+	// ErrBatchAddendaIndicatorNoAddenda is the error given when the addenda indicator is set without any addenda records
+	ErrBatchAddendaIndicatorNoAddenda = errors.New("is 1 but found no addenda records")
 	// ErrBatchOriginatorDNE is the error given when a non-government agency tries to originate a DNE
 	ErrBatchOriginatorDNE = errors.New("only government agencies (originator status code 2) can originate a DNE")
 	// ErrBatchInvalidCardTransactionType is the error given when a card transaction type is invalid
@@ -37,6 +39,14 @@ var (
 	ErrBatchDebitOnly = errors.New("this batch type does not allow credit transaction codes")
 	// ErrBatchCheckSerialNumber is the error given when a batch requires check serial numbers, but it is missing
 	ErrBatchCheckSerialNumber = errors.New("this batch type requires entries to have Check Serial Numbers")
+	// ErrBatchPOPIdentificationNumber is the error given when a POP entry's check serial number, terminal city and state don't fit its IdentificationNumber
+	ErrBatchPOPIdentificationNumber = errors.New("POP check serial number, terminal city and terminal state must fit in 15 characters")
+	// ErrBatchPOPTerminalCity is the error given when a POP entry is missing the abbreviated city of its terminal
+	ErrBatchPOPTerminalCity = errors.New("POP entries require a terminal city")
+	// ErrBatchAddendaNotAllowed is the error given when an entry of a batch type without addenda has its addenda record indicator set
+	ErrBatchAddendaNotAllowed = errors.New("this batch type does not allow addenda records")
+	// ErrBatchIndividualName is the error given when a batch requires the receiver's name, but it is missing
+	ErrBatchIndividualName = errors.New("this batch type requires entries to have an Individual Name")
 	// ErrBatchSECType is the error given when the batch's header has the wrong SEC for its type
 	ErrBatchSECType = errors.New("header SEC does not match this batch's type")
 	// ErrBatchServiceClassCode is the error given when the batch's header has the wrong SCC for its type
@@ -55,6 +65,14 @@ var (
 	ErrBatchCompanyEntryDescriptionREDEPCHECK = errors.New("this batch type requires that the Company Entry Description is REDEPCHECK")
 	// ErrBatchAddendaCategory is the error given when the addenda isn't allowed for the batch's type and category
 	ErrBatchAddendaCategory = errors.New("this batch type does not allow this addenda for category")
+	// ErrBatchTypeRegistered is the error given when a batch type is registered for a SEC code which already has one
+	ErrBatchTypeRegistered = errors.New("a batch type is already registered for this SEC code")
+	// ErrBatchCompanyNotAllowed is the error given when a Policy doesn't allow the batch's company to originate
+	ErrBatchCompanyNotAllowed = errors.New("company is not allowed to originate by policy")
+	// ErrBatchSECCodeNotAllowed is the error given when a Policy doesn't allow the batch's company to originate its SEC code
+	ErrBatchSECCodeNotAllowed = errors.New("SEC code is not allowed for this company by policy")
+	// ErrBatchDescriptionNotAllowed is the error given when a Policy doesn't allow the batch's Company Entry Description
+	ErrBatchDescriptionNotAllowed = errors.New("company entry description is not allowed for this company by policy")
 )
 
 // BatchError is an Error that describes batch validation issues
@@ -301,6 +319,27 @@ func (e ErrBatchExpectedAddendaCount) Error() string {
 	return e.Message
 }
 
+// ErrBatchAddendaSequence is the error given when addenda sequence numbers are not consecutively
+// assigned starting at 1
+type ErrBatchAddendaSequence struct {
+	Message  string
+	Found    int
+	Expected int
+}
+
+// NewErrBatchAddendaSequence creates a new error of the ErrBatchAddendaSequence type
+func NewErrBatchAddendaSequence(found, expected int) ErrBatchAddendaSequence {
+	return ErrBatchAddendaSequence{
+		Message:  fmt.Sprintf("addenda sequence number %v found where %v is expected", found, expected),
+		Found:    found,
+		Expected: expected,
+	}
+}
+
+func (e ErrBatchAddendaSequence) Error() string {
+	return e.Message
+}
+
 // ErrBatchServiceClassTranCode is the error given when the transaction code is not valid for the batch's service class
 type ErrBatchServiceClassTranCode struct {
 	Message          string
@@ -18,7 +18,6 @@
 package ach
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -122,6 +121,8 @@ type BatchHeader struct {
 
 	// converters is composed for ACH to golang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 const (
@@ -196,7 +197,7 @@ func (bh *BatchHeader) String() string {
 	var buf strings.Builder
 	buf.Grow(94)
 	buf.WriteString(bh.recordType)
-	buf.WriteString(fmt.Sprintf("%v", bh.ServiceClassCode))
+	buf.WriteString(strconv.Itoa(bh.ServiceClassCode))
 	buf.WriteString(bh.CompanyNameField())
 	buf.WriteString(bh.CompanyDiscretionaryDataField())
 	buf.WriteString(bh.CompanyIdentificationField())
@@ -205,7 +206,7 @@ func (bh *BatchHeader) String() string {
 	buf.WriteString(bh.CompanyDescriptiveDateField())
 	buf.WriteString(bh.EffectiveEntryDateField())
 	buf.WriteString(bh.settlementDateField())
-	buf.WriteString(fmt.Sprintf("%v", bh.OriginatorStatusCode))
+	buf.WriteString(strconv.Itoa(bh.OriginatorStatusCode))
 	buf.WriteString(bh.ODFIIdentificationField())
 	buf.WriteString(bh.BatchNumberField())
 	return buf.String()
@@ -325,6 +326,28 @@ func (bh *BatchHeader) settlementDateField() string {
 	return bh.alphaField(bh.settlementDate, 3)
 }
 
+// LiftEffectiveEntryDate parses EffectiveEntryDate as midnight UTC. Use EffectiveEntryDay to compare it
+// with dates in other timezones, as midnight UTC is the day before in the Americas.
 func (bh *BatchHeader) LiftEffectiveEntryDate() (time.Time, error) {
 	return time.Parse("060102", bh.EffectiveEntryDate) // YYMMDD
 }
+
+// EffectiveEntryDay returns EffectiveEntryDate as a Date, which has no time or location
+func (bh *BatchHeader) EffectiveEntryDay() (Date, error) {
+	t, err := time.Parse("060102", bh.EffectiveEntryDate) // YYMMDD
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// SetEffectiveEntryDay sets EffectiveEntryDate to d, unlike SetEffectiveEntryDate it isn't moved to a banking day
+func (bh *BatchHeader) SetEffectiveEntryDay(d Date) {
+	bh.EffectiveEntryDate = d.Time().Format("060102") // YYMMDD
+}
+
+// SetEffectiveEntryDate sets EffectiveEntryDate to the next banking day after t. If sameDay is true and t
+// is a banking day then t's day is used instead. See NextBankingDay
+func (bh *BatchHeader) SetEffectiveEntryDate(t time.Time, sameDay bool) {
+	bh.EffectiveEntryDate = NextBankingDay(t, sameDay).Format("060102") // YYMMDD
+}
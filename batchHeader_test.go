@@ -20,6 +20,7 @@ package ach
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/moov-io/base"
 )
@@ -563,3 +564,35 @@ func TestBatchHeader__LiftEffectiveEntryDate(t *testing.T) {
 		t.Error("expected error")
 	}
 }
+
+func TestBatchHeader__EffectiveEntryDay(t *testing.T) {
+	bh := mockBatchHeader()
+	bh.EffectiveEntryDate = "190730"
+	if d, err := bh.EffectiveEntryDay(); err != nil || d != (Date{Year: 2019, Month: time.July, Day: 30}) {
+		t.Errorf("d=%v error=%v", d, err)
+	}
+	bh.EffectiveEntryDate = "2019-07-30"
+	if _, err := bh.EffectiveEntryDay(); err == nil {
+		t.Error("expected error")
+	}
+
+	// a Saturday isn't moved to a banking day
+	bh.SetEffectiveEntryDay(Date{Year: 2019, Month: time.June, Day: 29})
+	if bh.EffectiveEntryDate != "190629" {
+		t.Errorf("EffectiveEntryDate=%s", bh.EffectiveEntryDate)
+	}
+}
+
+func TestBatchHeader__SetEffectiveEntryDate(t *testing.T) {
+	bh := mockBatchHeader()
+
+	friday := time.Date(2019, time.June, 28, 10, 0, 0, 0, time.UTC)
+	bh.SetEffectiveEntryDate(friday, true)
+	if bh.EffectiveEntryDate != "190628" {
+		t.Errorf("EffectiveEntryDate=%s", bh.EffectiveEntryDate)
+	}
+	bh.SetEffectiveEntryDate(friday, false)
+	if bh.EffectiveEntryDate != "190701" {
+		t.Errorf("EffectiveEntryDate=%s", bh.EffectiveEntryDate)
+	}
+}
@@ -17,6 +17,10 @@
 
 package ach
 
+import (
+	"github.com/moov-io/ach/internal/usabbrev"
+)
+
 // BatchPOP holds the BatchHeader and BatchControl and all EntryDetail for POP Entries.
 //
 // Point-of-Purchase. A check presented in-person to a merchant for purchase is presented
@@ -75,8 +79,8 @@ func (batch *BatchPOP) Validate() error {
 			return batch.Error("Amount", NewErrBatchAmount(entry.Amount, 2500000))
 		}
 		// CheckSerialNumber, Terminal City, Terminal State underlying IdentificationNumber, must be defined
-		if entry.IdentificationNumber == "" {
-			return batch.Error("CheckSerialNumber", ErrBatchCheckSerialNumber)
+		if err := batch.validatePOPIdentification(entry); err != nil {
+			return err
 		}
 		// Verify the TransactionCode is valid for a ServiceClassCode
 		if err := batch.ValidTranCodeForServiceClassCode(entry); err != nil {
@@ -86,6 +90,28 @@ func (batch *BatchPOP) Validate() error {
 		if err := batch.addendaFieldInclusion(entry); err != nil {
 			return err
 		}
+		// POP entries have no addenda
+		if entry.Category == CategoryForward && entry.AddendaRecordIndicator != 0 {
+			return batch.Error("AddendaRecordIndicator", ErrBatchAddendaNotAllowed, entry.AddendaRecordIndicator)
+		}
+	}
+	return nil
+}
+
+// validatePOPIdentification checks the check serial number (characters 1-9), terminal city (10-13)
+// and terminal state (14-15) of a POP entry's IdentificationNumber
+func (batch *BatchPOP) validatePOPIdentification(entry *EntryDetail) error {
+	if len(entry.IdentificationNumber) > 15 {
+		return batch.Error("IdentificationNumber", ErrBatchPOPIdentificationNumber, entry.IdentificationNumber)
+	}
+	if entry.POPCheckSerialNumberField() == "" {
+		return batch.Error("CheckSerialNumber", ErrBatchCheckSerialNumber)
+	}
+	if city := entry.POPTerminalCityField(); city == "" {
+		return batch.Error("TerminalCity", ErrBatchPOPTerminalCity, city)
+	}
+	if state := entry.POPTerminalStateField(); !usabbrev.Valid(state) {
+		return batch.Error("TerminalState", ErrValidState, state)
 	}
 	return nil
 }
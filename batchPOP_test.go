@@ -474,3 +474,39 @@ func TestBatchPOPAddendum99(t *testing.T) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
+
+// TestBatchPOPIdentificationNumber validates the check serial number, terminal city and state of POP entries
+func TestBatchPOPIdentificationNumber(t *testing.T) {
+	cases := map[string]error{
+		"         PHILPA":  ErrBatchCheckSerialNumber,
+		"123456789    PA":  ErrBatchPOPTerminalCity,
+		"123456789PHIL":    ErrValidState,
+		"123456789PHILZZ":  ErrValidState,
+		"123456789PHILPA1": ErrBatchPOPIdentificationNumber,
+	}
+	for identification, expected := range cases {
+		mockBatch := NewBatchPOP(mockBatchPOPHeader())
+		mockBatch.AddEntry(mockPOPEntryDetail())
+		mockBatch.GetEntries()[0].IdentificationNumber = identification
+		if err := mockBatch.Create(); !base.Match(err, expected) {
+			t.Errorf("%q: %T: %s", identification, err, err)
+		}
+	}
+
+	// short identification numbers are read without panicking
+	entry := mockPOPEntryDetail()
+	entry.IdentificationNumber = "123"
+	if entry.POPCheckSerialNumberField() != "123" || entry.POPTerminalCityField() != "" || entry.POPTerminalStateField() != "" {
+		t.Errorf("unexpected fields: %q", entry.IdentificationNumber)
+	}
+}
+
+// TestBatchPOPAddendaRecordIndicator validates POP entries can't claim to have addenda
+func TestBatchPOPAddendaRecordIndicator(t *testing.T) {
+	mockBatch := NewBatchPOP(mockBatchPOPHeader())
+	mockBatch.AddEntry(mockPOPEntryDetail())
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
+	if err := mockBatch.Create(); !base.Match(err, ErrBatchAddendaNotAllowed) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
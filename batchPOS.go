@@ -39,6 +39,27 @@ type BatchPOS struct {
 	Batch
 }
 
+const (
+	// Card Transaction Type codes carried in EntryDetail.DiscretionaryData of POS and SHR entries
+
+	// CardTransactionPurchase is a purchase of goods or services
+	CardTransactionPurchase = "01"
+	// CardTransactionCash is a cash withdrawal
+	CardTransactionCash = "02"
+	// CardTransactionReturnReversal reverses a prior return
+	CardTransactionReturnReversal = "03"
+	// CardTransactionPurchaseReversal reverses a prior purchase
+	CardTransactionPurchaseReversal = "11"
+	// CardTransactionCashReversal reverses a prior cash withdrawal
+	CardTransactionCashReversal = "12"
+	// CardTransactionReturn is a return of goods
+	CardTransactionReturn = "13"
+	// CardTransactionAdjustment is an adjustment to a prior transaction
+	CardTransactionAdjustment = "21"
+	// CardTransactionMiscellaneous is any other card transaction
+	CardTransactionMiscellaneous = "99"
+)
+
 // NewBatchPOS returns a *BatchPOS
 func NewBatchPOS(bh *BatchHeader) *BatchPOS {
 	batch := new(BatchPOS)
@@ -46,14 +46,10 @@ func (batch *BatchPPD) Validate() error {
 	}
 
 	for _, entry := range batch.Entries {
-		// PPD can have up to one Addenda05 record
-		if len(entry.Addenda05) > 1 {
-			return batch.Error("AddendaCount", NewErrBatchAddendaCount(len(entry.Addenda05), 1))
-		}
 		// Verify the TransactionCode is valid for a ServiceClassCode
-		//if err := batch.ValidTranCodeForServiceClassCode(entry); err != nil {
-		//	return err
-		//}
+		if err := batch.ValidTranCodeForServiceClassCode(entry); err != nil {
+			return err
+		}
 		// Verify Addenda* FieldInclusion based on entry.Category and batchHeader.StandardEntryClassCode
 		if err := batch.addendaFieldInclusion(entry); err != nil {
 			return err
@@ -17,6 +17,12 @@
 
 package ach
 
+import (
+	"strings"
+
+	"github.com/moov-io/base"
+)
+
 // BatchRCK holds the BatchHeader and BatchControl and all EntryDetail for RCK Entries.
 //
 // Represented Check Entries (RCK). A physical check that was presented but returned because of
@@ -64,13 +70,8 @@ func (batch *BatchRCK) Validate() error {
 		if entry.CreditOrDebit() != "D" {
 			return batch.Error("TransactionCode", ErrBatchDebitOnly, entry.TransactionCode)
 		}
-		// // Amount must be 2,500 or less
-		if entry.Amount > 250000 {
-			return batch.Error("Amount", NewErrBatchAmount(entry.Amount, 250000))
-		}
-		// CheckSerialNumber underlying IdentificationNumber, must be defined
-		if entry.IdentificationNumber == "" {
-			return batch.Error("CheckSerialNumber", ErrBatchCheckSerialNumber)
+		if err := batch.validateRCKEntry(entry); err != nil {
+			return err
 		}
 		// Verify the TransactionCode is valid for a ServiceClassCode
 		if err := batch.ValidTranCodeForServiceClassCode(entry); err != nil {
@@ -80,10 +81,98 @@ func (batch *BatchRCK) Validate() error {
 		if err := batch.addendaFieldInclusion(entry); err != nil {
 			return err
 		}
+		// RCK entries have no addenda
+		if entry.Category == CategoryForward && entry.AddendaRecordIndicator != 0 {
+			return batch.Error("AddendaRecordIndicator", ErrBatchAddendaNotAllowed, entry.AddendaRecordIndicator)
+		}
 	}
 	return nil
 }
 
+// rckAmountLimit is the largest Amount of an RCK entry, which must be for less than $2,500
+const rckAmountLimit = 249999
+
+// validateRCKEntry checks an entry represents an eligible item: a check drawn on a consumer's checking or savings
+// account for less than $2,500 with its serial number and the name of its writer
+func (batch *BatchRCK) validateRCKEntry(entry *EntryDetail) error {
+	if entry.Category != "" && entry.Category != CategoryForward {
+		return nil
+	}
+	// Checks are drawn on checking and savings accounts, prenotes aren't allowed
+	switch entry.TransactionCode {
+	case CheckingDebit, SavingsDebit:
+	default:
+		return batch.Error("TransactionCode", ErrBatchTransactionCode, entry.TransactionCode)
+	}
+	if entry.Amount <= 0 {
+		return batch.Error("Amount", ErrBatchAmountZero, entry.Amount)
+	}
+	if entry.Amount > rckAmountLimit {
+		return batch.Error("Amount", NewErrBatchAmount(entry.Amount, rckAmountLimit))
+	}
+	// CheckSerialNumber underlying IdentificationNumber, must be defined
+	if strings.TrimSpace(entry.IdentificationNumber) == "" {
+		return batch.Error("CheckSerialNumber", ErrBatchCheckSerialNumber)
+	}
+	if strings.TrimSpace(entry.IndividualName) == "" {
+		return batch.Error("IndividualName", ErrBatchIndividualName)
+	}
+	return nil
+}
+
+// RCKCheck is a returned check, as read from its MICR line, to represent with NewRCKEntry
+type RCKCheck struct {
+	// RoutingNumber is the routing number of the bank the check is drawn on
+	RoutingNumber string
+	// AccountNumber is the check writer's account number
+	AccountNumber string
+	// Savings is true when AccountNumber is a savings account instead of a checking account
+	Savings bool
+	// SerialNumber is the check's serial number, at most 15 characters
+	SerialNumber string
+	// Amount of the check, which must be less than $2,500
+	Amount Amount
+	// Name is the name of the check writer
+	Name string
+}
+
+// NewRCKEntry returns a debit entry representing check in a BatchRCK, after checking it's eligible.
+// Its TraceNumber is left to be set, e.g. with SetTraceNumber.
+func NewRCKEntry(check RCKCheck) (*EntryDetail, error) {
+	var errs base.ErrorList
+	if err := CheckRoutingNumber(check.RoutingNumber); err != nil {
+		errs.Add(fieldError("RoutingNumber", err, check.RoutingNumber))
+	}
+	if strings.TrimSpace(check.AccountNumber) == "" || len(check.AccountNumber) > 17 {
+		errs.Add(fieldError("AccountNumber", ErrFieldRequired, check.AccountNumber))
+	}
+	if serial := strings.TrimSpace(check.SerialNumber); serial == "" || len(serial) > 15 {
+		errs.Add(fieldError("SerialNumber", ErrBatchCheckSerialNumber, check.SerialNumber))
+	}
+	if check.Amount <= 0 || check.Amount > rckAmountLimit {
+		errs.Add(fieldError("Amount", NewErrBatchAmount(int(check.Amount), rckAmountLimit), check.Amount.String()))
+	}
+	if strings.TrimSpace(check.Name) == "" {
+		errs.Add(fieldError("Name", ErrBatchIndividualName, check.Name))
+	}
+	if !errs.Empty() {
+		return nil, errs
+	}
+
+	entry := NewEntryDetail()
+	entry.TransactionCode = CheckingDebit
+	if check.Savings {
+		entry.TransactionCode = SavingsDebit
+	}
+	entry.SetRDFI(check.RoutingNumber)
+	entry.DFIAccountNumber = check.AccountNumber
+	entry.SetAmount(check.Amount)
+	entry.SetCheckSerialNumber(strings.TrimSpace(check.SerialNumber))
+	entry.IndividualName = check.Name
+	entry.Category = CategoryForward
+	return entry, nil
+}
+
 // Create will tabulate and assemble an ACH batch into a valid state. This includes
 // setting any posting dates, sequence numbers, counts, and sums.
 //
@@ -444,3 +444,64 @@ func TestBatchRCKAddendum99(t *testing.T) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
+
+// TestBatchRCKEligibility validates RCK entries are eligible items
+func TestBatchRCKEligibility(t *testing.T) {
+	cases := map[string]struct {
+		modify   func(entry *EntryDetail)
+		expected error
+	}{
+		"limit":       {func(entry *EntryDetail) { entry.Amount = 250000 }, NewErrBatchAmount(250000, 249999)},
+		"zero":        {func(entry *EntryDetail) { entry.Amount = 0 }, ErrBatchAmountZero},
+		"prenote":     {func(entry *EntryDetail) { entry.TransactionCode, entry.Amount = CheckingPrenoteDebit, 0 }, ErrBatchTransactionCode},
+		"loan":        {func(entry *EntryDetail) { entry.TransactionCode = GLDebit }, ErrBatchTransactionCode},
+		"serial":      {func(entry *EntryDetail) { entry.IdentificationNumber = "   " }, ErrBatchCheckSerialNumber},
+		"name":        {func(entry *EntryDetail) { entry.IndividualName = "   " }, ErrBatchIndividualName},
+		"indicator":   {func(entry *EntryDetail) { entry.AddendaRecordIndicator = 1 }, ErrBatchAddendaNotAllowed},
+		"addenda05":   {func(entry *EntryDetail) { entry.AddAddenda05(mockAddenda05()); entry.AddendaRecordIndicator = 1 }, ErrBatchAddendaCategory},
+		"under limit": {func(entry *EntryDetail) { entry.Amount = 249999 }, nil},
+		"savings":     {func(entry *EntryDetail) { entry.TransactionCode = SavingsDebit }, nil},
+	}
+	for name, c := range cases {
+		mockBatch := NewBatchRCK(mockBatchRCKHeader())
+		mockBatch.AddEntry(mockRCKEntryDetail())
+		c.modify(mockBatch.GetEntries()[0])
+		if err := mockBatch.Create(); !base.Match(err, c.expected) {
+			t.Errorf("%s: %T: %s", name, err, err)
+		}
+	}
+}
+
+// TestNewRCKEntry validates building an RCK entry from a check
+func TestNewRCKEntry(t *testing.T) {
+	check := RCKCheck{
+		RoutingNumber: "231380104",
+		AccountNumber: "744-5678-99",
+		SerialNumber:  "123123123",
+		Amount:        2400,
+		Name:          "Wade Arnold",
+	}
+	entry, err := NewRCKEntry(check)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.TransactionCode != CheckingDebit || entry.RDFIIdentification != "23138010" || entry.CheckDigit != "4" || entry.CheckSerialNumberField() != "123123123      " {
+		t.Errorf("unexpected entry: %#v", entry)
+	}
+	entry.SetTraceNumber(mockBatchRCKHeader().ODFIIdentification, 1)
+	mockBatch := NewBatchRCK(mockBatchRCKHeader())
+	mockBatch.AddEntry(entry)
+	if err := mockBatch.Create(); err != nil {
+		t.Error(err)
+	}
+
+	check.Savings = true
+	if entry, err := NewRCKEntry(check); err != nil || entry.TransactionCode != SavingsDebit {
+		t.Errorf("unexpected entry: %v", err)
+	}
+
+	_, err = NewRCKEntry(RCKCheck{RoutingNumber: "231380105", SerialNumber: "1234567890123456", Amount: 250000})
+	if errs, ok := err.(base.ErrorList); !ok || len(errs) != 5 {
+		t.Errorf("%T: %v", err, err)
+	}
+}
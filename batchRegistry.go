@@ -0,0 +1,98 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"sync"
+)
+
+// BatchFactory returns a Batcher for a registered StandardEntryClassCode. The Batch passed in
+// already holds the BatchHeader (and any entries or controls read from a file) and is typically
+// embedded in the returned type, the same way BatchPPD and other NACHA batch types embed Batch.
+type BatchFactory func(batch Batch) Batcher
+
+var (
+	batchTypesMu sync.RWMutex
+	batchTypes   = make(map[string]BatchFactory)
+)
+
+// RegisterBatchType adds support for a custom (private-label or not yet supported)
+// StandardEntryClassCode. Once registered, NewBatch, ConvertBatchType, Reader and
+// FileFromJSON create batches of the code with factory and BatchHeader.Validate accepts it.
+//
+// The SEC code must be three uppercase letters or digits and can not replace a NACHA
+// defined code or another registered code. RegisterBatchType is safe for concurrent use,
+// but is typically called from an init function.
+func RegisterBatchType(secCode string, factory BatchFactory) error {
+	if factory == nil {
+		return fmt.Errorf("%s: nil BatchFactory", secCode)
+	}
+	if !isBatchTypeCode(secCode) {
+		return fieldError("StandardEntryClassCode", ErrSECCode, secCode)
+	}
+	if isNACHASECCode(secCode) {
+		return fmt.Errorf("%s: %w", secCode, ErrBatchTypeRegistered)
+	}
+
+	batchTypesMu.Lock()
+	defer batchTypesMu.Unlock()
+
+	if _, exists := batchTypes[secCode]; exists {
+		return fmt.Errorf("%s: %w", secCode, ErrBatchTypeRegistered)
+	}
+	batchTypes[secCode] = factory
+	return nil
+}
+
+// registeredBatchType returns the BatchFactory for a custom StandardEntryClassCode
+func registeredBatchType(secCode string) (BatchFactory, bool) {
+	batchTypesMu.RLock()
+	defer batchTypesMu.RUnlock()
+
+	factory, ok := batchTypes[secCode]
+	return factory, ok
+}
+
+// isBatchTypeCode returns true if secCode is three uppercase letters or digits
+func isBatchTypeCode(secCode string) bool {
+	if len(secCode) != 3 {
+		return false
+	}
+	for i := 0; i < len(secCode); i++ {
+		c := secCode[i]
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Build assigns sequence and trace numbers and computes the BatchControl of the batch.
+// It's intended for the Create method of Batcher implementations registered with
+// RegisterBatchType, which should call their Validate method afterwards.
+func (batch *Batch) Build() error {
+	return batch.build()
+}
+
+// Verify checks the NACHA rules common to every batch type, such as matching header and
+// control fields, totals and addenda. Batcher implementations registered with RegisterBatchType
+// should call it before their own SEC code specific rules.
+func (batch *Batch) Verify() error {
+	return batch.verify()
+}
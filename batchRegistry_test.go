@@ -0,0 +1,163 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base"
+)
+
+// batchICT is an example intra-company batch type which only allows credits
+type batchICT struct {
+	Batch
+}
+
+func (batch *batchICT) Create() error {
+	if err := batch.Build(); err != nil {
+		return err
+	}
+	return batch.Validate()
+}
+
+func (batch *batchICT) Validate() error {
+	if err := batch.Verify(); err != nil {
+		return err
+	}
+	for _, entry := range batch.Entries {
+		if entry.CreditOrDebit() != "C" {
+			return batch.Error("TransactionCode", ErrBatchDebitOnly, entry.TransactionCode)
+		}
+	}
+	return nil
+}
+
+// registerBatchICT registers batchICT for the rest of the test only, so other tests
+// run with the NACHA batch types alone
+func registerBatchICT(t *testing.T) {
+	t.Helper()
+
+	if err := RegisterBatchType("ICT", func(b Batch) Batcher { return &batchICT{b} }); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		batchTypesMu.Lock()
+		defer batchTypesMu.Unlock()
+		delete(batchTypes, "ICT")
+	})
+}
+
+func mockBatchICT(t *testing.T) Batcher {
+	t.Helper()
+
+	bh := mockBatchPPDHeader()
+	bh.StandardEntryClassCode = "ICT"
+	batch, err := NewBatch(bh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch.AddEntry(mockPPDEntryDetail())
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+	return batch
+}
+
+func TestRegisterBatchType(t *testing.T) {
+	registerBatchICT(t)
+
+	batch := mockBatchICT(t)
+	if _, ok := batch.(*batchICT); !ok {
+		t.Fatalf("unexpected batch: %T", batch)
+	}
+	if err := batch.GetHeader().Validate(); err != nil {
+		t.Error(err)
+	}
+
+	// rules of the custom type are applied
+	batch.GetEntries()[0].TransactionCode = CheckingDebit
+	if err := batch.Create(); !base.Match(err, ErrBatchDebitOnly) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
+
+func TestRegisterBatchTypeErrors(t *testing.T) {
+	registerBatchICT(t)
+	factory := func(b Batch) Batcher { return &batchICT{b} }
+
+	if err := RegisterBatchType("ICT", factory); !errors.Is(err, ErrBatchTypeRegistered) {
+		t.Errorf("%T: %v", err, err)
+	}
+	if err := RegisterBatchType(PPD, factory); !errors.Is(err, ErrBatchTypeRegistered) {
+		t.Errorf("%T: %v", err, err)
+	}
+	if err := RegisterBatchType("ict", factory); !base.Match(err, ErrSECCode) {
+		t.Errorf("%T: %v", err, err)
+	}
+	if err := RegisterBatchType("ICTX", factory); !base.Match(err, ErrSECCode) {
+		t.Errorf("%T: %v", err, err)
+	}
+	if err := RegisterBatchType("ABC", nil); err == nil {
+		t.Error("expected error")
+	}
+
+	bh := mockBatchPPDHeader()
+	bh.StandardEntryClassCode = "ABC"
+	if _, err := NewBatch(bh); !base.Match(err, NewErrFileUnknownSEC("ABC")) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
+
+func TestRegisterBatchTypeReadWrite(t *testing.T) {
+	registerBatchICT(t)
+
+	file := NewFile()
+	file.SetHeader(mockFileHeader())
+	file.AddBatch(mockBatchICT(t))
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatal(err)
+	}
+	read, err := NewReader(strings.NewReader(buf.String())).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := read.Batches[0].(*batchICT); !ok {
+		t.Errorf("unexpected batch: %T", read.Batches[0])
+	}
+
+	bs, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON, err := FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fromJSON.Batches[0].(*batchICT); !ok {
+		t.Errorf("unexpected batch: %T", fromJSON.Batches[0])
+	}
+}
@@ -44,10 +44,6 @@ func (batch *BatchWEB) Validate() error {
 	}
 
 	for _, entry := range batch.Entries {
-		// WEB can have up to one Addenda05 record
-		if len(entry.Addenda05) > 1 {
-			return batch.Error("AddendaCount", NewErrBatchAddendaCount(len(entry.Addenda05), 1))
-		}
 		// Verify the TransactionCode is valid for a ServiceClassCode
 		if err := batch.ValidTranCodeForServiceClassCode(entry); err != nil {
 			return err
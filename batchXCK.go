@@ -17,9 +17,17 @@
 
 package ach
 
+import (
+	"strings"
+)
+
 // BatchXCK holds the BatchHeader and BatchControl and all EntryDetail for XCK Entries.
 //
 // Destroyed Check Entry identifies a debit entry initiated for a XCK eligible items.
+//
+// XCK entries carry the Check Serial Number in the underlying IdentificationNumber (positions 40-54)
+// and the Process Control Field and Item Research Number in the underlying IndividualName
+// (positions 55-60 and 61-76). Forward XCK entries cannot have addenda records.
 type BatchXCK struct {
 	Batch
 }
@@ -62,11 +70,15 @@ func (batch *BatchXCK) Validate() error {
 		if entry.Amount > 250000 {
 			return batch.Error("Amount", NewErrBatchAmount(entry.Amount, 250000))
 		}
-		// ProcessControlField underlying IdentificationNumber, must be defined
+		// CheckSerialNumber underlying IdentificationNumber, must be defined
+		if strings.TrimSpace(entry.IdentificationNumber) == "" {
+			return batch.Error("CheckSerialNumber", ErrBatchCheckSerialNumber)
+		}
+		// ProcessControlField underlying IndividualName, must be defined
 		if entry.ProcessControlField() == "" {
 			return batch.Error("ProcessControlField", ErrFieldRequired)
 		}
-		// ItemResearchNumber underlying IdentificationNumber, must be defined
+		// ItemResearchNumber underlying IndividualName, must be defined
 		if entry.ItemResearchNumber() == "" {
 			return batch.Error("ItemResearchNumber", ErrFieldRequired)
 		}
@@ -74,6 +86,10 @@ func (batch *BatchXCK) Validate() error {
 		if err := batch.ValidTranCodeForServiceClassCode(entry); err != nil {
 			return err
 		}
+		// Forward XCK entries cannot have addenda records
+		if entry.Category == CategoryForward && entry.AddendaRecordIndicator != 0 {
+			return batch.Error("AddendaRecordIndicator", ErrBatchAddendaCategory, entry.Category)
+		}
 		// Verify Addenda* FieldInclusion based on entry.Category and batchHeader.StandardEntryClassCode
 		if err := batch.addendaFieldInclusion(entry); err != nil {
 			return err
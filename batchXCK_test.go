@@ -253,14 +253,13 @@ func BenchmarkBatchXCKAutomatedAccountingAdvices(b *testing.B) {
 	}
 }
 
-// testBatchXCKCheckSerialNumber validates BatchXCK CheckSerialNumber is not mandatory
+// testBatchXCKCheckSerialNumber validates BatchXCK CheckSerialNumber is mandatory
 func testBatchXCKCheckSerialNumber(t testing.TB) {
 	mockBatch := mockBatchXCK()
 	// modify CheckSerialNumber / IdentificationNumber to nothing
 	mockBatch.GetEntries()[0].SetCheckSerialNumber("")
 	err := mockBatch.Validate()
-	// no error expected
-	if !base.Match(err, nil) {
+	if !base.Match(err, ErrBatchCheckSerialNumber) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
@@ -423,3 +422,33 @@ func TestBatchXCKAmount(t *testing.T) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
+
+// TestBatchXCKCheckSerialNumberBlank validates a space padded CheckSerialNumber is rejected
+func TestBatchXCKCheckSerialNumberBlank(t *testing.T) {
+	mockBatch := mockBatchXCK()
+	mockBatch.GetEntries()[0].SetCheckSerialNumber("               ")
+	err := mockBatch.Validate()
+	if !base.Match(err, ErrBatchCheckSerialNumber) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestBatchXCKAddendaRecordIndicator validates a forward XCK entry cannot indicate addenda records
+func TestBatchXCKAddendaRecordIndicator(t *testing.T) {
+	mockBatch := mockBatchXCK()
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
+	err := mockBatch.Validate()
+	if !base.Match(err, ErrBatchAddendaCategory) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestBatchXCKShortIndividualName validates a short IndividualName does not panic
+func TestBatchXCKShortIndividualName(t *testing.T) {
+	mockBatch := mockBatchXCK()
+	mockBatch.GetEntries()[0].IndividualName = "CHECK1"
+	err := mockBatch.Validate()
+	if !base.Match(err, ErrFieldRequired) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
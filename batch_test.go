@@ -250,6 +250,7 @@ func testBatchDNEMismatch(t testing.TB) {
 	ed := mockBatch.GetEntries()[0]
 	ed.AddAddenda05(mockAddenda05())
 	ed.AddAddenda05(mockAddenda05())
+	ed.Amount = 0
 	mockBatch.build()
 
 	mockBatch.GetHeader().OriginatorStatusCode = 1
@@ -1350,6 +1351,32 @@ func TestBatch__upsertOffsetIdempotent(t *testing.T) {
 	}
 }
 
+func TestBatch__upsertOffsetsManyEntries(t *testing.T) {
+	b := NewBatchPPD(mockBatchPPDHeader())
+	b.Header.ServiceClassCode = MixedDebitsAndCredits
+	for i := 1; i <= 3; i++ {
+		entry := mockPPDEntryDetail()
+		entry.TransactionCode = CheckingCredit
+		entry.SetTraceNumber(b.Header.ODFIIdentification, i)
+		b.AddEntry(entry)
+	}
+	b.WithOffset(&Offset{
+		RoutingNumber: "121042882",
+		AccountNumber: "123456789",
+		AccountType:   OffsetChecking,
+	})
+
+	// the offset entry is removed from the end of the batch before it's added again
+	for i := 0; i < 2; i++ {
+		if err := b.Create(); err != nil {
+			t.Fatal(err)
+		}
+		if len(b.Entries) != 4 || b.Entries[3].IndividualName != "OFFSET" || b.Entries[3].Amount != 3*b.Entries[0].Amount {
+			t.Fatalf("unexpected entries: %#v", b.Entries)
+		}
+	}
+}
+
 func TestBatch__upsertOffsetsErr(t *testing.T) {
 	f := mockFilePPD()
 	b, ok := f.Batches[0].(*BatchPPD)
@@ -1422,3 +1449,115 @@ func TestBatch__isTraceNumberODFI(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+func TestBatch__MaxAddendaPerEntry(t *testing.T) {
+	mockBatch := NewBatchPPD(mockBatchPPDHeader())
+	entry := mockPPDEntryDetail()
+	mockBatch.AddEntry(entry)
+
+	if err := mockBatch.AddAddenda05(entry, mockAddenda05()); err != nil {
+		t.Fatal(err)
+	}
+	if entry.AddendaRecordIndicator != 1 {
+		t.Errorf("unexpected AddendaRecordIndicator: %d", entry.AddendaRecordIndicator)
+	}
+	if err := mockBatch.AddAddenda05(entry, mockAddenda05()); !base.Match(err, NewErrBatchAddendaCount(2, 1)) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// add the second addenda directly and expect Validate to reject it
+	entry.AddAddenda05(mockAddenda05())
+	if err := mockBatch.Create(); !base.Match(err, NewErrBatchAddendaCount(2, 1)) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// raise the limit for PPD
+	mockBatch.SetValidation(&ValidateOpts{
+		MaxAddendaPerEntry: map[string]int{PPD: 2},
+	})
+	if err := mockBatch.Create(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mockBatch.AddAddenda05(entry, mockAddenda05()); !base.Match(err, NewErrBatchAddendaCount(3, 2)) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+func TestBatch__DefaultMaxAddendaPerEntry(t *testing.T) {
+	if n, ok := DefaultMaxAddendaPerEntry(PPD); !ok || n != 1 {
+		t.Errorf("PPD: n=%d ok=%v", n, ok)
+	}
+	if n, ok := DefaultMaxAddendaPerEntry(XCK); !ok || n != 0 {
+		t.Errorf("XCK: n=%d ok=%v", n, ok)
+	}
+	if _, ok := DefaultMaxAddendaPerEntry("ZZZ"); ok {
+		t.Error("expected unknown SEC code")
+	}
+}
+
+func TestBatch__MaxAddendaPerEntryZero(t *testing.T) {
+	mockBatch := NewBatchXCK(mockBatchXCKHeader())
+	entry := mockXCKEntryDetail()
+	mockBatch.AddEntry(entry)
+
+	if err := mockBatch.AddAddenda05(entry, mockAddenda05()); !base.Match(err, NewErrBatchAddendaCount(1, 0)) {
+		t.Errorf("%T: %s", err, err)
+	}
+	if len(entry.Addenda05) != 0 || entry.AddendaRecordIndicator != 0 {
+		t.Errorf("unexpected addenda: %#v", entry.Addenda05)
+	}
+}
+
+func TestBatch__CorrectServiceClassCode(t *testing.T) {
+	mockBatch := mockBatchPPD()
+	mockBatch.GetHeader().ServiceClassCode = DebitsOnly
+	if err := mockBatch.Create(); !base.Match(err, NewErrBatchServiceClassTranCode(DebitsOnly, CheckingCredit)) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	mockBatch.SetValidation(&ValidateOpts{CorrectServiceClassCode: true})
+	if err := mockBatch.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if mockBatch.GetHeader().ServiceClassCode != CreditsOnly || mockBatch.GetControl().ServiceClassCode != CreditsOnly {
+		t.Errorf("header=%d control=%d", mockBatch.GetHeader().ServiceClassCode, mockBatch.GetControl().ServiceClassCode)
+	}
+
+	// add a debit
+	entry := mockPPDEntryDetail()
+	entry.TransactionCode = CheckingDebit
+	entry.SetTraceNumber(mockBatch.GetHeader().ODFIIdentification, 2)
+	mockBatch.AddEntry(entry)
+	if err := mockBatch.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if mockBatch.GetHeader().ServiceClassCode != MixedDebitsAndCredits {
+		t.Errorf("ServiceClassCode=%d", mockBatch.GetHeader().ServiceClassCode)
+	}
+}
+
+func TestBatchFromJSON(t *testing.T) {
+	bs, err := json.Marshal(mockBatchPPD())
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch, err := BatchFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := batch.(*BatchPPD); !ok {
+		t.Fatalf("got %T", batch)
+	}
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, body := range []string{"", "[]", `{"batchHeader": null}`} {
+		if _, err := BatchFromJSON([]byte(body)); err == nil {
+			t.Errorf("expected error for %q", body)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Checksum holds values computed from the records of a File or batch which downstream consumers can
+// use to verify integrity without rendering the NACHA formatted file themselves.
+type Checksum struct {
+	// ID is the ID of the File or batch
+	ID string `json:"id,omitempty"`
+	// EntryHash is the sum of RDFI routing numbers of each entry truncated to 10 digits
+	EntryHash int `json:"entryHash"`
+	// TotalDebit is the sum of debit entry amounts
+	TotalDebit int `json:"totalDebit"`
+	// TotalCredit is the sum of credit entry amounts
+	TotalCredit int `json:"totalCredit"`
+	// SHA256 is the hex encoded SHA-256 digest of the NACHA formatted records
+	SHA256 string `json:"sha256"`
+}
+
+// FileChecksums holds the Checksum of a File along with the Checksum of each of its batches.
+type FileChecksums struct {
+	File       Checksum   `json:"file"`
+	Batches    []Checksum `json:"batches"`
+	IATBatches []Checksum `json:"iatBatches,omitempty"`
+}
+
+// Checksums computes the entry hash, totals and SHA-256 digest of the File and each of its batches.
+// Values are computed from the File's records rather than read from its control records, so they can be
+// compared against both.
+func (f *File) Checksums() (*FileChecksums, error) {
+	out := &FileChecksums{
+		File: Checksum{ID: f.ID},
+	}
+	for _, batch := range f.Batches {
+		sum := batchChecksum(batch)
+		digest, err := checksumSHA256(&File{Header: f.Header, Batches: []Batcher{batch}}, (*Writer).writeBatch)
+		if err != nil {
+			return nil, err
+		}
+		sum.SHA256 = digest
+		out.Batches = append(out.Batches, sum)
+		out.File.add(sum)
+	}
+	for i := range f.IATBatches {
+		iatBatch := &f.IATBatches[i]
+		sum := iatBatchChecksum(iatBatch)
+		digest, err := checksumSHA256(&File{Header: f.Header, IATBatches: []IATBatch{*iatBatch}}, (*Writer).writeIATBatch)
+		if err != nil {
+			return nil, err
+		}
+		sum.SHA256 = digest
+		out.IATBatches = append(out.IATBatches, sum)
+		out.File.add(sum)
+	}
+
+	out.File.EntryHash = f.truncateEntryHash(out.File.EntryHash)
+
+	digest, err := checksumSHA256(f, (*Writer).write)
+	if err != nil {
+		return nil, err
+	}
+	out.File.SHA256 = digest
+
+	return out, nil
+}
+
+// batchChecksum computes the entry hash and totals of a batch from its entries
+func batchChecksum(batch Batcher) Checksum {
+	b := &Batch{
+		Header:     batch.GetHeader(),
+		Entries:    batch.GetEntries(),
+		ADVEntries: batch.GetADVEntries(),
+	}
+	sum := Checksum{
+		ID:        batch.ID(),
+		EntryHash: b.calculateEntryHash(),
+	}
+	if b.IsADV() {
+		sum.TotalCredit, sum.TotalDebit = b.calculateADVBatchAmounts()
+	} else {
+		sum.TotalCredit, sum.TotalDebit = b.calculateBatchAmounts()
+	}
+	return sum
+}
+
+// iatBatchChecksum computes the entry hash and totals of an IATBatch from its entries
+func iatBatchChecksum(iatBatch *IATBatch) Checksum {
+	hash, _ := strconv.Atoi(iatBatch.calculateEntryHash())
+	sum := Checksum{
+		ID:        iatBatch.ID,
+		EntryHash: hash,
+	}
+	sum.TotalCredit, sum.TotalDebit = iatBatch.calculateBatchAmounts()
+	return sum
+}
+
+// truncateEntryHash keeps the last 10 digits of an entry hash like FileControl
+func (f *File) truncateEntryHash(hash int) int {
+	out, _ := strconv.Atoi(f.Control.numericField(hash, 10))
+	return out
+}
+
+func (c *Checksum) add(other Checksum) {
+	c.EntryHash += other.EntryHash
+	c.TotalDebit += other.TotalDebit
+	c.TotalCredit += other.TotalCredit
+}
+
+// checksumSHA256 renders file with fn and returns the hex encoded SHA-256 digest
+func checksumSHA256(file *File, fn func(*Writer, *File) error) (string, error) {
+	h := sha256.New()
+	w := NewWriter(h)
+	if err := fn(w, file); err != nil {
+		return "", err
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
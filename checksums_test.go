@@ -0,0 +1,120 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFile__Checksums(t *testing.T) {
+	file := mockFilePPD()
+
+	sums, err := file.Checksums()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sums.File.ID != file.ID {
+		t.Errorf("ID=%s", sums.File.ID)
+	}
+	if sums.File.EntryHash != file.Control.EntryHash {
+		t.Errorf("EntryHash=%d expected %d", sums.File.EntryHash, file.Control.EntryHash)
+	}
+	if sums.File.TotalCredit != file.Control.TotalCreditEntryDollarAmountInFile || sums.File.TotalDebit != file.Control.TotalDebitEntryDollarAmountInFile {
+		t.Errorf("TotalCredit=%d TotalDebit=%d", sums.File.TotalCredit, sums.File.TotalDebit)
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatal(err)
+	}
+	if expected := sha256.Sum256(buf.Bytes()); sums.File.SHA256 != hex.EncodeToString(expected[:]) {
+		t.Errorf("SHA256=%s", sums.File.SHA256)
+	}
+
+	// batch
+	if len(sums.Batches) != 1 {
+		t.Fatalf("unexpected batches: %#v", sums.Batches)
+	}
+	bc := file.Batches[0].GetControl()
+	if sums.Batches[0].EntryHash != bc.EntryHash || sums.Batches[0].TotalCredit != bc.TotalCreditEntryDollarAmount {
+		t.Errorf("unexpected batch checksum: %#v", sums.Batches[0])
+	}
+	// the batch is rendered on lines 2 through 4 (header, entry, control)
+	lines := strings.SplitAfter(buf.String(), "\n")
+	if expected := sha256.Sum256([]byte(strings.Join(lines[1:4], ""))); sums.Batches[0].SHA256 != hex.EncodeToString(expected[:]) {
+		t.Errorf("SHA256=%s", sums.Batches[0].SHA256)
+	}
+}
+
+func TestFile__ChecksumsChanged(t *testing.T) {
+	file := mockFilePPD()
+	before, err := file.Checksums()
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Batches[0].GetEntries()[0].Amount++
+	after, err := file.Checksums()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.File.SHA256 == after.File.SHA256 || before.Batches[0].SHA256 == after.Batches[0].SHA256 {
+		t.Error("expected SHA256 to change")
+	}
+	if after.File.TotalCredit != before.File.TotalCredit+1 {
+		t.Errorf("TotalCredit=%d", after.File.TotalCredit)
+	}
+}
+
+func TestFile__ChecksumsIAT(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "20180716-IAT-A17-A18.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sums, err := file.Checksums()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sums.Batches) != 0 || len(sums.IATBatches) != len(file.IATBatches) {
+		t.Fatalf("Batches=%d IATBatches=%d", len(sums.Batches), len(sums.IATBatches))
+	}
+	if sums.File.EntryHash != file.Control.EntryHash {
+		t.Errorf("EntryHash=%d expected %d", sums.File.EntryHash, file.Control.EntryHash)
+	}
+	if sums.File.TotalDebit != file.Control.TotalDebitEntryDollarAmountInFile {
+		t.Errorf("TotalDebit=%d", sums.File.TotalDebit)
+	}
+}
+
+func TestFile__ChecksumsADV(t *testing.T) {
+	file := mockFileADV()
+	sums, err := file.Checksums()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sums.File.EntryHash != file.ADVControl.EntryHash {
+		t.Errorf("EntryHash=%d expected %d", sums.File.EntryHash, file.ADVControl.EntryHash)
+	}
+	if sums.File.TotalDebit != file.ADVControl.TotalDebitEntryDollarAmountInFile {
+		t.Errorf("TotalDebit=%d", sums.File.TotalDebit)
+	}
+}
@@ -0,0 +1,552 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/server"
+	"github.com/moov-io/base"
+	"gopkg.in/yaml.v2"
+)
+
+// Config holds every setting of the server. It's read from the JSON or YAML file given with -config
+// or ACH_CONFIG_FILE, then flags set on the command line and environment variables override it.
+type Config struct {
+	HTTP       HTTPConfig       `json:"http"`
+	Logging    LoggingConfig    `json:"logging"`
+	Storage    StorageConfig    `json:"storage"`
+	Auth       AuthFileConfig   `json:"auth"`
+	Events     EventsConfig     `json:"events"`
+	Audit      AuditConfig      `json:"audit"`
+	Approvals  ApprovalsConfig  `json:"approvals"`
+	Validation ValidationConfig `json:"validation"`
+	Cutoffs    CutoffsConfig    `json:"cutoffs"`
+	// Policy is checked each time a file is validated, see ach.Policy
+	Policy   ach.Policy     `json:"policy"`
+	Exposure ExposureConfig `json:"exposure"`
+	// Offsets are the settlement accounts batches are balanced against when files are built,
+	// keyed by Company Identification, see server.WithOffsets
+	Offsets   map[string]ach.Offset `json:"offsets"`
+	Schedules SchedulesConfig       `json:"schedules"`
+}
+
+type HTTPConfig struct {
+	BindAddress      string `json:"bindAddress"`
+	AdminBindAddress string `json:"adminBindAddress"`
+
+	// CertFile and KeyFile serve HTTPS when both are set
+	CertFile string `json:"certFile"`
+	KeyFile  string `json:"keyFile"`
+
+	MaxBodySize int64   `json:"maxBodySize"`
+	RateLimit   float64 `json:"rateLimit"`
+	RateBurst   int     `json:"rateBurst"`
+
+	ShutdownDelay   Duration `json:"shutdownDelay"`
+	ShutdownTimeout Duration `json:"shutdownTimeout"`
+}
+
+type LoggingConfig struct {
+	Format string `json:"format"`
+	Level  string `json:"level"`
+}
+
+type StorageConfig struct {
+	// Backend is where files are stored, only "memory" is supported
+	Backend string   `json:"backend"`
+	TTL     Duration `json:"ttl"`
+	// PurgeAfter is how long deleted files can be restored before they're removed for good
+	PurgeAfter Duration `json:"purgeAfter"`
+
+	EncryptionKey     string `json:"encryptionKey"`
+	EncryptionKeyFile string `json:"encryptionKeyFile"`
+}
+
+type AuthFileConfig struct {
+	// APIKeys are formatted as server.ParseAPIKeys reads them
+	APIKeys string `json:"apiKeys"`
+
+	JWTSecret        string `json:"jwtSecret"`
+	JWTPublicKeyFile string `json:"jwtPublicKeyFile"`
+	JWKSURL          string `json:"jwksURL"`
+	JWTIssuer        string `json:"jwtIssuer"`
+	JWTAudience      string `json:"jwtAudience"`
+	// JWTAllowMissingExpiry accepts JWTs without an "exp" claim, which never expire
+	JWTAllowMissingExpiry bool `json:"jwtAllowMissingExpiry"`
+
+	// RequireRoles limits each route to callers with the roles allowed on it, see server.RolesConfig
+	RequireRoles bool `json:"requireRoles"`
+	// APIKeyRoles are formatted as server.ParseAPIKeyRoles reads them
+	APIKeyRoles   string `json:"apiKeyRoles"`
+	JWTRolesClaim string `json:"jwtRolesClaim"`
+	// RouteRoles overrides the roles allowed on routes, keyed by method and path template
+	RouteRoles map[string][]server.Role `json:"routeRoles"`
+}
+
+type EventsConfig struct {
+	NATSURL  string `json:"natsURL"`
+	Topic    string `json:"topic"`
+	Format   string `json:"format"`
+	SpoolDir string `json:"spoolDir"`
+}
+
+type AuditConfig struct {
+	// File is where the audit log of changes to files is appended, it's kept in memory when empty
+	File string `json:"file"`
+}
+
+type ApprovalsConfig struct {
+	// Enabled requires files be submitted and approved by two different users before their contents can be read,
+	// see server.WithApprovals
+	Enabled bool `json:"enabled"`
+}
+
+type ValidationConfig struct {
+	Strict        bool     `json:"strict"`
+	SweepInterval Duration `json:"sweepInterval"`
+	// RDFIDirectory is the filepath of FedACH participants (FedACHdir.txt or a .csv file) RDFIs are checked against
+	RDFIDirectory string `json:"rdfiDirectory"`
+	// ScreeningURL is a screening service IAT entries are checked with, see screening.HTTPScreener
+	ScreeningURL string `json:"screeningURL"`
+}
+
+type CutoffsConfig struct {
+	// Timezone is an IANA name (e.g. America/New_York) Times are in, UTC when empty
+	Timezone string `json:"timezone"`
+	// Times are formatted as 15:04
+	Times []string `json:"times"`
+}
+
+type SchedulesConfig struct {
+	// Interval is how often recurring payments due are added to the day's file, see server.WithSchedules.
+	// Schedules are disabled when it's zero.
+	Interval Duration `json:"interval"`
+	// The FileHeader of files of recurring payments
+	ImmediateOrigin          string `json:"immediateOrigin"`
+	ImmediateOriginName      string `json:"immediateOriginName"`
+	ImmediateDestination     string `json:"immediateDestination"`
+	ImmediateDestinationName string `json:"immediateDestinationName"`
+}
+
+type ExposureConfig struct {
+	// Window is how long a validated file counts towards Limits
+	Window Duration `json:"window"`
+	// Limits are keyed by Company Identification, see server.WithExposureLimits
+	Limits map[string]server.ExposureLimit `json:"limits"`
+}
+
+// Duration is a time.Duration written in JSON as a string, e.g. "30s"
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("duration must be a string like \"30s\": %v", err)
+	}
+	dur, err := time.ParseDuration(v)
+	if err != nil {
+		return err
+	}
+	d.Duration = dur
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Duration.String())
+}
+
+// defaultConfig returns the settings used when nothing overrides them
+func defaultConfig() *Config {
+	cfg := &Config{}
+	cfg.HTTP.BindAddress = *httpAddr
+	cfg.HTTP.AdminBindAddress = *adminAddr
+	cfg.HTTP.ShutdownTimeout.Duration = 30 * time.Second
+	cfg.Logging.Format = "plain"
+	cfg.Logging.Level = "info"
+	cfg.Storage.Backend = "memory"
+	cfg.Storage.PurgeAfter.Duration = 24 * time.Hour
+	return cfg
+}
+
+// loadConfig reads the config file at path, or ACH_CONFIG_FILE, and overrides it with the flags set
+// on the command line and then environment variables. The returned Config is never nil, so logging
+// can be setup to report errors, which include every invalid setting.
+func loadConfig(path string, getenv func(string) string) (*Config, error) {
+	cfg := defaultConfig()
+	if v := getenv("ACH_CONFIG_FILE"); v != "" {
+		path = v
+	}
+	if path != "" {
+		if err := readConfigFile(cfg, path); err != nil {
+			return cfg, err
+		}
+	}
+	applyFlags(cfg)
+
+	var errs base.ErrorList
+	if err := applyEnv(cfg, getenv); err != nil {
+		errs = append(errs, err.(base.ErrorList)...)
+	}
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err.(base.ErrorList)...)
+	}
+	if errs.Empty() {
+		return cfg, nil
+	}
+	return cfg, errs
+}
+
+// applyFlags overrides cfg with the flags set on the command line
+func applyFlags(cfg *Config) {
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "http.addr":
+			cfg.HTTP.BindAddress = *httpAddr
+		case "admin.addr":
+			cfg.HTTP.AdminBindAddress = *adminAddr
+		case "log.format":
+			cfg.Logging.Format = *flagLogFormat
+		case "log.level":
+			cfg.Logging.Level = *flagLogLevel
+		case "validate.strict":
+			cfg.Validation.Strict = *flagStrict
+		}
+	})
+}
+
+// readConfigFile overrides cfg with the settings in the file at path, which is read as YAML when it has
+// a .yml or .yaml extension and as JSON otherwise. Unknown settings are an error.
+func readConfigFile(cfg *Config, path string) error {
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("problem reading config file: %v", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		if bs, err = yamlToJSON(bs); err != nil {
+			return fmt.Errorf("problem parsing config file %s: %v", path, err)
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(cfg); err != nil {
+		return fmt.Errorf("problem parsing config file %s: %v", path, err)
+	}
+	return nil
+}
+
+// yamlToJSON converts a YAML document to JSON, so YAML config files use the same keys and
+// decoding (e.g. of Duration) as JSON files
+func yamlToJSON(bs []byte) ([]byte, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(bs, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonValue(v))
+}
+
+// jsonValue replaces the maps yaml.v2 decodes, keyed by interface{}, with maps keyed by string
+func jsonValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = jsonValue(value)
+		}
+		return m
+	case []interface{}:
+		for i := range v {
+			v[i] = jsonValue(v[i])
+		}
+	}
+	return v
+}
+
+// applyEnv overrides cfg with the environment variables getenv returns
+func applyEnv(cfg *Config, getenv func(string) string) error {
+	var errs base.ErrorList
+	str := func(name string, dst *string) {
+		if v := getenv(name); v != "" {
+			*dst = v
+		}
+	}
+	dur := func(name string, dst *Duration) {
+		if v := getenv(name); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				errs.Add(fmt.Errorf("%s: %v", name, err))
+				return
+			}
+			dst.Duration = d
+		}
+	}
+	num := func(name string, parse func(string) error) {
+		if v := getenv(name); v != "" {
+			if err := parse(v); err != nil {
+				errs.Add(fmt.Errorf("%s: %v", name, err))
+			}
+		}
+	}
+
+	str("HTTP_BIND_ADDRESS", &cfg.HTTP.BindAddress)
+	str("HTTP_ADMIN_BIND_ADDRESS", &cfg.HTTP.AdminBindAddress)
+	str("HTTPS_CERT_FILE", &cfg.HTTP.CertFile)
+	str("HTTPS_KEY_FILE", &cfg.HTTP.KeyFile)
+	num("ACH_HTTP_MAX_BODY_SIZE", func(v string) (err error) {
+		cfg.HTTP.MaxBodySize, err = strconv.ParseInt(v, 10, 64)
+		return
+	})
+	num("ACH_HTTP_RATE_LIMIT", func(v string) (err error) {
+		cfg.HTTP.RateLimit, err = strconv.ParseFloat(v, 64)
+		return
+	})
+	num("ACH_HTTP_RATE_BURST", func(v string) (err error) {
+		cfg.HTTP.RateBurst, err = strconv.Atoi(v)
+		return
+	})
+	dur("HTTP_SHUTDOWN_DELAY", &cfg.HTTP.ShutdownDelay)
+	dur("HTTP_SHUTDOWN_TIMEOUT", &cfg.HTTP.ShutdownTimeout)
+
+	str("LOG_FORMAT", &cfg.Logging.Format)
+	str("LOG_LEVEL", &cfg.Logging.Level)
+
+	str("ACH_STORAGE_BACKEND", &cfg.Storage.Backend)
+	dur("ACH_FILE_TTL", &cfg.Storage.TTL)
+	dur("ACH_FILE_PURGE_AFTER", &cfg.Storage.PurgeAfter)
+	str("ACH_STORAGE_ENCRYPTION_KEY", &cfg.Storage.EncryptionKey)
+	str("ACH_STORAGE_ENCRYPTION_KEY_FILE", &cfg.Storage.EncryptionKeyFile)
+
+	str("ACH_AUTH_API_KEYS", &cfg.Auth.APIKeys)
+	str("ACH_AUTH_JWT_SECRET", &cfg.Auth.JWTSecret)
+	str("ACH_AUTH_JWT_PUBLIC_KEY_FILE", &cfg.Auth.JWTPublicKeyFile)
+	str("ACH_AUTH_JWKS_URL", &cfg.Auth.JWKSURL)
+	str("ACH_AUTH_JWT_ISSUER", &cfg.Auth.JWTIssuer)
+	str("ACH_AUTH_JWT_AUDIENCE", &cfg.Auth.JWTAudience)
+	num("ACH_AUTH_JWT_ALLOW_MISSING_EXPIRY", func(v string) (err error) {
+		cfg.Auth.JWTAllowMissingExpiry, err = strconv.ParseBool(v)
+		return
+	})
+	num("ACH_AUTH_REQUIRE_ROLES", func(v string) (err error) {
+		cfg.Auth.RequireRoles, err = strconv.ParseBool(v)
+		return
+	})
+	str("ACH_AUTH_API_KEY_ROLES", &cfg.Auth.APIKeyRoles)
+	str("ACH_AUTH_JWT_ROLES_CLAIM", &cfg.Auth.JWTRolesClaim)
+
+	str("ACH_EVENTS_NATS_URL", &cfg.Events.NATSURL)
+	str("ACH_EVENTS_TOPIC", &cfg.Events.Topic)
+	str("ACH_EVENTS_FORMAT", &cfg.Events.Format)
+	str("ACH_EVENTS_SPOOL_DIR", &cfg.Events.SpoolDir)
+
+	str("ACH_AUDIT_LOG_FILE", &cfg.Audit.File)
+
+	num("ACH_APPROVALS_ENABLED", func(v string) (err error) {
+		cfg.Approvals.Enabled, err = strconv.ParseBool(v)
+		return
+	})
+
+	num("ACH_VALIDATE_STRICT", func(v string) (err error) {
+		cfg.Validation.Strict, err = strconv.ParseBool(v)
+		return
+	})
+	dur("ACH_VALIDATION_SWEEP_INTERVAL", &cfg.Validation.SweepInterval)
+	str("ACH_RDFI_DIRECTORY", &cfg.Validation.RDFIDirectory)
+	str("ACH_SCREENING_URL", &cfg.Validation.ScreeningURL)
+
+	dur("ACH_SCHEDULES_INTERVAL", &cfg.Schedules.Interval)
+
+	str("ACH_CUTOFF_TIMEZONE", &cfg.Cutoffs.Timezone)
+	if v := getenv("ACH_CUTOFF_TIMES"); v != "" {
+		cfg.Cutoffs.Times = strings.Split(v, ",")
+	}
+
+	if errs.Empty() {
+		return nil
+	}
+	return errs
+}
+
+// Validate checks every setting of cfg, returning all of the problems found
+func (cfg *Config) Validate() error {
+	var errs base.ErrorList
+
+	if cfg.HTTP.BindAddress == "" {
+		errs.Add(errors.New("http.bindAddress is required"))
+	}
+	if (cfg.HTTP.CertFile == "") != (cfg.HTTP.KeyFile == "") {
+		errs.Add(errors.New("http.certFile and http.keyFile must be set together"))
+	}
+	if cfg.HTTP.MaxBodySize < 0 {
+		errs.Add(errors.New("http.maxBodySize can't be negative"))
+	}
+	if cfg.HTTP.RateLimit < 0 || cfg.HTTP.RateBurst < 0 {
+		errs.Add(errors.New("http.rateLimit and http.rateBurst can't be negative"))
+	}
+	if cfg.HTTP.ShutdownDelay.Duration < 0 || cfg.HTTP.ShutdownTimeout.Duration < 0 {
+		errs.Add(errors.New("http.shutdownDelay and http.shutdownTimeout can't be negative"))
+	}
+
+	switch cfg.Logging.Format {
+	case "json", "plain":
+	default:
+		errs.Add(fmt.Errorf("unknown logging.format %q", cfg.Logging.Format))
+	}
+	switch strings.ToLower(cfg.Logging.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		errs.Add(fmt.Errorf("unknown logging.level %q", cfg.Logging.Level))
+	}
+
+	if cfg.Storage.Backend != "memory" {
+		errs.Add(fmt.Errorf("unknown storage.backend %q", cfg.Storage.Backend))
+	}
+	if cfg.Storage.TTL.Duration < 0 {
+		errs.Add(errors.New("storage.ttl can't be negative"))
+	}
+	if cfg.Storage.PurgeAfter.Duration < 0 {
+		errs.Add(errors.New("storage.purgeAfter can't be negative"))
+	}
+	if cfg.Storage.EncryptionKey != "" && cfg.Storage.EncryptionKeyFile != "" {
+		errs.Add(errors.New("only one of storage.encryptionKey and storage.encryptionKeyFile can be set"))
+	}
+	if cfg.Storage.EncryptionKey != "" {
+		if _, err := server.ParseEncryptionKey(cfg.Storage.EncryptionKey); err != nil {
+			errs.Add(fmt.Errorf("storage.encryptionKey: %v", err))
+		}
+	}
+
+	if cfg.Auth.APIKeys != "" {
+		if _, err := server.ParseAPIKeys(cfg.Auth.APIKeys); err != nil {
+			errs.Add(fmt.Errorf("auth.apiKeys: %v", err))
+		}
+	}
+	if cfg.Auth.APIKeyRoles != "" {
+		if _, err := server.ParseAPIKeyRoles(cfg.Auth.APIKeyRoles); err != nil {
+			errs.Add(fmt.Errorf("auth.apiKeyRoles: %v", err))
+		}
+	}
+	if a := cfg.Auth; cfg.Approvals.Enabled && a.APIKeys == "" && a.JWTSecret == "" && a.JWTPublicKeyFile == "" && a.JWKSURL == "" {
+		errs.Add(errors.New("approvals.enabled requires auth.apiKeys or JWT settings, files are approved by authenticated callers"))
+	}
+	for route, roles := range cfg.Auth.RouteRoles {
+		for _, role := range roles {
+			switch role {
+			case server.RolePreparer, server.RoleApprover, server.RoleAdmin:
+			default:
+				errs.Add(fmt.Errorf("auth.routeRoles: unknown role %q for %s", role, route))
+			}
+		}
+	}
+
+	switch cfg.Events.Format {
+	case "", "json", "proto":
+	default:
+		errs.Add(fmt.Errorf("unknown events.format %q", cfg.Events.Format))
+	}
+
+	if cfg.Validation.SweepInterval.Duration < 0 {
+		errs.Add(errors.New("validation.sweepInterval can't be negative"))
+	}
+
+	if cfg.Schedules.Interval.Duration < 0 {
+		errs.Add(errors.New("schedules.interval can't be negative"))
+	}
+	if cfg.Schedules.Interval.Duration > 0 {
+		if strings.TrimSpace(cfg.Schedules.ImmediateOrigin) == "" {
+			errs.Add(errors.New("schedules.immediateOrigin is required with schedules.interval"))
+		}
+		if err := ach.CheckRoutingNumber(strings.TrimSpace(cfg.Schedules.ImmediateDestination)); err != nil {
+			errs.Add(fmt.Errorf("schedules.immediateDestination: %v", err))
+		}
+	}
+
+	if _, err := time.LoadLocation(cfg.Cutoffs.Timezone); err != nil {
+		errs.Add(fmt.Errorf("cutoffs.timezone: %v", err))
+	}
+	for _, v := range cfg.Cutoffs.Times {
+		if _, err := server.ParseCutoffTime(strings.TrimSpace(v)); err != nil {
+			errs.Add(fmt.Errorf("cutoffs.times: %v", err))
+		}
+	}
+
+	if err := cfg.Policy.Validate(); err != nil {
+		errs.Add(fmt.Errorf("policy: %v", err))
+	}
+
+	if len(cfg.Exposure.Limits) > 0 && cfg.Exposure.Window.Duration <= 0 {
+		errs.Add(errors.New("exposure.window is required with exposure.limits"))
+	}
+	for company, limit := range cfg.Exposure.Limits {
+		if limit.Debit < 0 || limit.Credit < 0 {
+			errs.Add(fmt.Errorf("exposure.limits: %s can't have a negative limit", company))
+		}
+	}
+
+	for company, off := range cfg.Offsets {
+		if err := ach.CheckRoutingNumber(off.RoutingNumber); err != nil {
+			errs.Add(fmt.Errorf("offsets: %s routingNumber: %v", company, err))
+		}
+		if strings.TrimSpace(off.AccountNumber) == "" {
+			errs.Add(fmt.Errorf("offsets: %s is missing its accountNumber", company))
+		}
+		switch off.AccountType {
+		case ach.OffsetChecking, ach.OffsetSavings:
+		default:
+			errs.Add(fmt.Errorf("offsets: %s has unknown accountType %q", company, off.AccountType))
+		}
+	}
+
+	if errs.Empty() {
+		return nil
+	}
+	return errs
+}
+
+// cutoffTimes returns the ServiceOption for cfg's cutoff times, which have been validated
+func (cfg *Config) cutoffTimes() server.ServiceOption {
+	loc, _ := time.LoadLocation(cfg.Cutoffs.Timezone)
+	var cutoffs []time.Duration
+	for _, v := range cfg.Cutoffs.Times {
+		d, _ := server.ParseCutoffTime(strings.TrimSpace(v))
+		cutoffs = append(cutoffs, d)
+	}
+	return server.WithCutoffTimes(loc, cutoffs...)
+}
+
+// schedulesFileHeader returns the FileHeader of files of recurring payments
+func (cfg *Config) schedulesFileHeader() ach.FileHeader {
+	fh := ach.NewFileHeader()
+	fh.ImmediateOrigin = cfg.Schedules.ImmediateOrigin
+	fh.ImmediateOriginName = cfg.Schedules.ImmediateOriginName
+	fh.ImmediateDestination = cfg.Schedules.ImmediateDestination
+	fh.ImmediateDestinationName = cfg.Schedules.ImmediateDestinationName
+	return fh
+}
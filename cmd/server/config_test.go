@@ -0,0 +1,252 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moov-io/ach"
+)
+
+func writeConfigFile(t *testing.T, dir, contents string) string {
+	t.Helper()
+
+	fd, err := ioutil.TempFile(dir, "config-*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd.Close()
+
+	path := fd.Name()
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func envFrom(env map[string]string) func(string) string {
+	return func(name string) string { return env[name] }
+}
+
+func TestConfig__defaults(t *testing.T) {
+	cfg, err := loadConfig("", envFrom(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.HTTP.BindAddress != *httpAddr || cfg.Storage.Backend != "memory" || cfg.Logging.Format != "plain" {
+		t.Errorf("unexpected config: %#v", cfg)
+	}
+	if cfg.HTTP.ShutdownTimeout.Duration != 30*time.Second {
+		t.Errorf("ShutdownTimeout=%v", cfg.HTTP.ShutdownTimeout)
+	}
+	if cfg.Storage.PurgeAfter.Duration != 24*time.Hour {
+		t.Errorf("PurgeAfter=%v", cfg.Storage.PurgeAfter)
+	}
+}
+
+func TestConfig__file(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ach-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := writeConfigFile(t, dir, `{
+  "http": {"bindAddress": ":8888", "rateLimit": 10, "shutdownDelay": "5s"},
+  "logging": {"format": "json", "level": "warn"},
+  "storage": {"ttl": "240m"},
+  "auth": {"apiKeys": "key1:read;key2"},
+  "validation": {"strict": true, "sweepInterval": "1h"},
+  "cutoffs": {"timezone": "America/New_York", "times": ["10:30", "16:00"]}
+}`)
+
+	// environment variables override the file
+	cfg, err := loadConfig(path, envFrom(map[string]string{
+		"HTTP_BIND_ADDRESS":      ":9999",
+		"LOG_LEVEL":              "debug",
+		"ACH_RDFI_DIRECTORY":     "FedACHdir.txt",
+		"ACH_SCREENING_URL":      "http://localhost:8084/screen",
+		"ACH_AUDIT_LOG_FILE":     "audit.log",
+		"ACH_FILE_PURGE_AFTER":   "72h",
+		"ACH_AUTH_REQUIRE_ROLES": "true",
+		"ACH_AUTH_API_KEY_ROLES": "key1:approver",
+		"ACH_APPROVALS_ENABLED":  "true",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.HTTP.BindAddress != ":9999" || cfg.Logging.Level != "debug" || cfg.Logging.Format != "json" {
+		t.Errorf("unexpected config: %#v", cfg)
+	}
+	if cfg.HTTP.RateLimit != 10 || cfg.HTTP.ShutdownDelay.Duration != 5*time.Second || cfg.Storage.TTL.Duration != 240*time.Minute {
+		t.Errorf("unexpected config: %#v", cfg)
+	}
+	if !cfg.Validation.Strict || cfg.Validation.SweepInterval.Duration != time.Hour || cfg.Validation.RDFIDirectory != "FedACHdir.txt" || cfg.Validation.ScreeningURL == "" || len(cfg.Cutoffs.Times) != 2 {
+		t.Errorf("unexpected config: %#v", cfg)
+	}
+	if cfg.Audit.File != "audit.log" {
+		t.Errorf("unexpected audit: %#v", cfg.Audit)
+	}
+	if cfg.Storage.PurgeAfter.Duration != 72*time.Hour {
+		t.Errorf("PurgeAfter=%v", cfg.Storage.PurgeAfter)
+	}
+	if !cfg.Auth.RequireRoles || cfg.Auth.APIKeyRoles != "key1:approver" || !cfg.Approvals.Enabled {
+		t.Errorf("unexpected auth: %#v", cfg.Auth)
+	}
+	if cfg.cutoffTimes() == nil {
+		t.Error("expected cutoff times")
+	}
+
+	// ACH_CONFIG_FILE is read without -config
+	cfg, err = loadConfig("", envFrom(map[string]string{"ACH_CONFIG_FILE": path}))
+	if err != nil || cfg.HTTP.BindAddress != ":8888" {
+		t.Errorf("bindAddress=%s error=%v", cfg.HTTP.BindAddress, err)
+	}
+}
+
+func TestConfig__yaml(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ach-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.yaml")
+	contents := `http:
+  bindAddress: ":8888"
+  shutdownDelay: 5s
+logging:
+  format: json
+storage:
+  ttl: 240m
+cutoffs:
+  timezone: America/New_York
+  times: ["10:30", "16:00"]
+exposure:
+  window: 24h
+  limits:
+    121042882:
+      debit: 5000000
+`
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadConfig(path, envFrom(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.HTTP.BindAddress != ":8888" || cfg.HTTP.ShutdownDelay.Duration != 5*time.Second || cfg.Logging.Format != "json" {
+		t.Errorf("unexpected config: %#v", cfg)
+	}
+	if cfg.Storage.TTL.Duration != 240*time.Minute || len(cfg.Cutoffs.Times) != 2 {
+		t.Errorf("unexpected config: %#v", cfg)
+	}
+	if limit := cfg.Exposure.Limits["121042882"]; cfg.Exposure.Window.Duration != 24*time.Hour || limit.Debit != 5000000 {
+		t.Errorf("unexpected exposure: %#v", cfg.Exposure)
+	}
+
+	// .yml is read as YAML too, with unknown settings rejected as in JSON files
+	path = filepath.Join(dir, "config.yml")
+	if err := ioutil.WriteFile(path, []byte("http:\n  bindAdress: \":8888\"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadConfig(path, envFrom(nil)); err == nil || !strings.Contains(err.Error(), "bindAdress") {
+		t.Errorf("expected error for an unknown setting: %v", err)
+	}
+	if err := ioutil.WriteFile(path, []byte("http: [\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadConfig(path, envFrom(nil)); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
+
+func TestConfig__example(t *testing.T) {
+	cfg, err := loadConfig(filepath.Join("..", "..", "documentation", "config.json"), envFrom(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Policy.Companies) != 1 || cfg.Policy.Companies[0].DailyLimit != 25000000 {
+		t.Errorf("unexpected policy: %#v", cfg.Policy)
+	}
+	if limit := cfg.Exposure.Limits["121042882"]; cfg.Exposure.Window.Duration != 24*time.Hour || limit.Debit != 5000000 || limit.Credit != 50000000 {
+		t.Errorf("unexpected exposure: %#v", cfg.Exposure)
+	}
+	if off := cfg.Offsets["121042882"]; off.AccountType != ach.OffsetChecking || off.AccountNumber != "123456789" {
+		t.Errorf("unexpected offsets: %#v", cfg.Offsets)
+	}
+	if fh := cfg.schedulesFileHeader(); cfg.Schedules.Interval.Duration != 15*time.Minute || fh.ImmediateDestination != "231380104" {
+		t.Errorf("unexpected schedules: %#v", cfg.Schedules)
+	}
+}
+
+func TestConfig__invalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ach-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := loadConfig(filepath.Join("missing", "config.json"), envFrom(nil)); err == nil {
+		t.Error("expected error for a missing file")
+	}
+	if _, err := loadConfig(writeConfigFile(t, dir, `{"htpp": {}}`), envFrom(nil)); err == nil || !strings.Contains(err.Error(), "unknown field") {
+		t.Errorf("expected error for unknown settings: %v", err)
+	}
+	if _, err := loadConfig(writeConfigFile(t, dir, `{"storage": {"ttl": 60}}`), envFrom(nil)); err == nil {
+		t.Error("expected error for a numeric duration")
+	}
+
+	// every invalid setting is reported
+	_, err = loadConfig(writeConfigFile(t, dir, `{
+  "http": {"certFile": "cert.pem"},
+  "logging": {"format": "xml"},
+  "storage": {"backend": "postgres"},
+  "auth": {"apiKeyRoles": "key1", "routeRoles": {"POST /files/{id}/build": ["owner"]}},
+  "approvals": {"enabled": true},
+  "cutoffs": {"timezone": "Mars/Olympus", "times": ["4pm"]},
+  "policy": {"secCodes": ["XYZ"]},
+  "exposure": {"limits": {"121042882": {"credit": 100}}},
+  "offsets": {"121042882": {"routingNumber": "12104288", "accountType": "money market"}},
+  "schedules": {"interval": "1h", "immediateDestination": "231380105"}
+}`), envFrom(nil))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{"http.certFile", "logging.format", "storage.backend", "auth.apiKeyRoles", "auth.routeRoles", "approvals.enabled", "cutoffs.timezone", "cutoffs.times", "policy", "exposure.window", "offsets: 121042882 routingNumber", "accountNumber", "accountType", "schedules.immediateOrigin", "schedules.immediateDestination"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing %s in: %v", want, err)
+		}
+	}
+
+	// malformed environment variables aren't ignored
+	_, err = loadConfig("", envFrom(map[string]string{"ACH_FILE_TTL": "forever", "ACH_HTTP_RATE_LIMIT": "fast", "LOG_FORMAT": "xml"}))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{"ACH_FILE_TTL", "ACH_HTTP_RATE_LIMIT", "logging.format"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing %s in: %v", want, err)
+		}
+	}
+}
@@ -19,21 +19,27 @@ package main
 
 import (
 	"context"
+	"crypto/rsa"
 	"crypto/tls"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/routing"
+	"github.com/moov-io/ach/screening"
 	"github.com/moov-io/ach/server"
 	"github.com/moov-io/base/admin"
 	"github.com/moov-io/base/http/bind"
 
 	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
 )
 
 var (
@@ -41,6 +47,11 @@ var (
 	adminAddr = flag.String("admin.addr", bind.Admin("ach"), "Admin HTTP listen address")
 
 	flagLogFormat = flag.String("log.format", "", "Format for log lines (Options: json, plain")
+	flagLogLevel  = flag.String("log.level", "", "Lowest level of log lines written (Options: debug, info, warn, error)")
+
+	flagStrict = flag.Bool("validate.strict", false, "Validate every file with ach.StrictNACHA(), ignoring per-request validation options")
+
+	flagConfig = flag.String("config", "", "Filepath of a JSON or YAML config file, flags and environment variables override its settings")
 
 	logger log.Logger
 
@@ -50,34 +61,177 @@ var (
 
 func main() {
 	flag.Parse()
+	cfg, cfgErr := loadConfig(*flagConfig, os.Getenv)
 
 	// Setup logging, default to stdout
-	if v := os.Getenv("LOG_FORMAT"); v != "" {
-		*flagLogFormat = v
-	}
-	if *flagLogFormat == "json" {
+	if cfg.Logging.Format == "json" {
 		logger = log.NewJSONLogger(os.Stdout)
 	} else {
 		logger = log.NewLogfmtLogger(os.Stdout)
 	}
+	logger = level.NewFilter(logger, logLevel(cfg.Logging.Level))
 	logger = log.With(logger, "ts", log.DefaultTimestampUTC)
 	logger = log.With(logger, "caller", log.DefaultCaller)
-	logger.Log("startup", fmt.Sprintf("Starting ach server version %s", ach.Version))
+	level.Info(logger).Log("component", "startup", "msg", fmt.Sprintf("Starting ach server version %s", ach.Version))
+	if cfgErr != nil {
+		level.Error(logger).Log("component", "startup", "msg", "invalid config", "error", cfgErr)
+		os.Exit(1)
+	}
 
 	// Setup underlying ach service
-	var achFileTTL time.Duration
-	if v := os.Getenv("ACH_FILE_TTL"); v != "" {
-		dur, err := time.ParseDuration(v)
-		if err == nil {
-			achFileTTL = dur
-			logger.Log("main", fmt.Sprintf("Using %v as ach.File TTL", achFileTTL))
+	achFileTTL := cfg.Storage.TTL.Duration
+	if achFileTTL > 0 {
+		level.Info(logger).Log("component", "main", "msg", fmt.Sprintf("Using %v as ach.File TTL", achFileTTL))
+	}
+	var r server.Repository
+
+	// Encrypt stored files when a key is provided, either directly or as a file written by a KMS or secrets manager
+	encryptionKey := cfg.Storage.EncryptionKey
+	if path := cfg.Storage.EncryptionKeyFile; path != "" {
+		bs, err := ioutil.ReadFile(path)
+		if err != nil {
+			level.Error(logger).Log("component", "main", "msg", fmt.Sprintf("problem reading storage encryption key file: %v", err))
+			os.Exit(1)
+		}
+		encryptionKey = string(bs)
+	}
+	var key []byte
+	if encryptionKey != "" {
+		var err error
+		if key, err = server.ParseEncryptionKey(encryptionKey); err != nil {
+			level.Error(logger).Log("component", "main", "msg", fmt.Sprintf("problem with storage encryption key: %v", err))
+			os.Exit(1)
+		}
+		if r, err = server.NewRepositoryEncrypted(key, achFileTTL, logger); err != nil {
+			level.Error(logger).Log("component", "main", "msg", fmt.Sprintf("problem setting up encrypted storage: %v", err))
+			os.Exit(1)
+		}
+		level.Info(logger).Log("component", "main", "msg", "Encrypting stored files with AES-GCM")
+	} else {
+		r = server.NewRepositoryInMemory(achFileTTL, logger)
+	}
+	var serviceOpts []server.ServiceOption
+
+	// Publish file events to NATS
+	if v := cfg.Events.NATSURL; v != "" {
+		sender, err := server.NewNATSSender(v)
+		if err != nil {
+			level.Error(logger).Log("component", "main", "msg", fmt.Sprintf("problem with events NATS URL: %v", err))
+			os.Exit(1)
+		}
+		events, err := server.NewEventPublisher(sender, server.EventConfig{
+			Topic:    cfg.Events.Topic,
+			Format:   cfg.Events.Format,
+			SpoolDir: cfg.Events.SpoolDir,
+			SpoolKey: key, // spooled events carry files, so keep them as encrypted as storage
+		}, logger)
+		if err != nil {
+			level.Error(logger).Log("component", "main", "msg", fmt.Sprintf("problem setting up event publishing: %v", err))
+			os.Exit(1)
+		}
+		defer events.Close()
+		level.Info(logger).Log("component", "main", "msg", fmt.Sprintf("Publishing file events to NATS at %s", v))
+		r = server.NewEventRepository(r, events)
+		serviceOpts = append(serviceOpts, server.WithEventPublisher(events))
+	}
+	// Record who changes files and when
+	audit := server.NewAuditLogInMemory()
+	if path := cfg.Audit.File; path != "" {
+		var err error
+		if audit, err = server.NewAuditLogFile(path); err != nil {
+			level.Error(logger).Log("component", "main", "msg", fmt.Sprintf("problem opening audit log: %v", err))
+			os.Exit(1)
 		}
+		level.Info(logger).Log("component", "main", "msg", fmt.Sprintf("Appending the audit log to %s", path))
+	}
+	r = server.NewAuditRepository(r, audit, logger)
+	serviceOpts = append(serviceOpts, server.WithAuditLog(audit))
+
+	if cfg.Approvals.Enabled {
+		level.Info(logger).Log("component", "main", "msg", "Requiring files be approved by a second user before they're read")
+		serviceOpts = append(serviceOpts, server.WithApprovals(server.NewApprovalRepositoryInMemory()))
+	}
+
+	// Cache rendered file contents, this wraps every other Repository so all changes invalidate it.
+	// The cache holds plaintext, so encrypted files are rendered on every request instead.
+	if key == nil {
+		r = server.NewContentsCacheRepository(r)
+	}
+	if cfg.Validation.Strict {
+		level.Info(logger).Log("component", "main", "msg", "Validating files with strict NACHA rules")
+		serviceOpts = append(serviceOpts, server.WithStrictNACHA())
+	}
+	if path := cfg.Validation.RDFIDirectory; path != "" {
+		dir, err := routing.ReadDirectoryFile(path)
+		if err != nil {
+			level.Error(logger).Log("component", "main", "msg", fmt.Sprintf("problem reading RDFI directory: %v", err))
+			os.Exit(1)
+		}
+		level.Info(logger).Log("component", "main", "msg", fmt.Sprintf("Checking RDFIs against %d participants from %s", len(dir), path))
+		serviceOpts = append(serviceOpts, server.WithRDFIDirectory(dir))
+	}
+	if u := cfg.Validation.ScreeningURL; u != "" {
+		level.Info(logger).Log("component", "main", "msg", fmt.Sprintf("Screening IAT entries with %s", u))
+		serviceOpts = append(serviceOpts, server.WithIATScreener(screening.NewHTTPScreener(u)))
+	}
+	if p := cfg.Policy; len(p.Companies) > 0 || len(p.SECCodes) > 0 || len(p.Descriptions) > 0 {
+		level.Info(logger).Log("component", "main", "msg", fmt.Sprintf("Checking files against a policy for %d companies", len(p.Companies)))
+		serviceOpts = append(serviceOpts, server.WithPolicy(&cfg.Policy))
+	}
+	if e := cfg.Exposure; len(e.Limits) > 0 {
+		level.Info(logger).Log("component", "main", "msg", fmt.Sprintf("Limiting the exposure of %d companies every %v", len(e.Limits), e.Window))
+		serviceOpts = append(serviceOpts, server.WithExposureLimits(e.Window.Duration, e.Limits))
+	}
+	if len(cfg.Offsets) > 0 {
+		level.Info(logger).Log("component", "main", "msg", fmt.Sprintf("Balancing the batches of %d companies with offset entries", len(cfg.Offsets)))
+		serviceOpts = append(serviceOpts, server.WithOffsets(cfg.Offsets))
+	}
+	if len(cfg.Cutoffs.Times) > 0 {
+		level.Info(logger).Log("component", "main", "msg", fmt.Sprintf("Submitting files at %s", strings.Join(cfg.Cutoffs.Times, ", ")), "timezone", cfg.Cutoffs.Timezone)
+		serviceOpts = append(serviceOpts, cfg.cutoffTimes())
+	}
+	if interval := cfg.Schedules.Interval.Duration; interval > 0 {
+		level.Info(logger).Log("component", "main", "msg", fmt.Sprintf("Adding recurring payments to the day's file every %v", interval))
+		serviceOpts = append(serviceOpts, server.WithSchedules(server.NewScheduleRepositoryInMemory(), cfg.schedulesFileHeader()))
+	}
+	svc = server.NewService(r, serviceOpts...)
+
+	// Deleted files can be restored until they're purged
+	level.Info(logger).Log("component", "main", "msg", fmt.Sprintf("Purging deleted files after %v", cfg.Storage.PurgeAfter.Duration))
+	stopPurge := server.StartPurge(r, cfg.Storage.PurgeAfter.Duration, logger)
+	defer stopPurge()
+
+	// Periodically re-validate stored files
+	if interval := cfg.Validation.SweepInterval.Duration; interval > 0 {
+		level.Info(logger).Log("component", "main", "msg", fmt.Sprintf("Validating stored files every %v", interval))
+		stopSweep := server.StartValidationSweep(svc, interval, logger)
+		defer stopSweep()
+	}
+	if interval := cfg.Schedules.Interval.Duration; interval > 0 {
+		stopScheduler := server.StartScheduler(svc, interval, logger)
+		defer stopScheduler()
 	}
-	r := server.NewRepositoryInMemory(achFileTTL, logger)
-	svc = server.NewService(r)
 
 	// Create HTTP server
-	handler = server.MakeHTTPHandler(svc, r, log.With(logger, "component", "HTTP"))
+	var handlerOpts []server.HandlerOption
+	if auth := authConfig(cfg.Auth); auth != nil {
+		level.Info(logger).Log("component", "main", "msg", "Requiring authentication for HTTP requests")
+		if auth.Roles != nil {
+			level.Info(logger).Log("component", "main", "msg", "Limiting routes to the roles of callers")
+		}
+		handlerOpts = append(handlerOpts, server.WithAuth(auth))
+	}
+	if n := cfg.HTTP.MaxBodySize; n > 0 {
+		level.Info(logger).Log("component", "main", "msg", fmt.Sprintf("Limiting HTTP request bodies to %d bytes", n))
+		handlerOpts = append(handlerOpts, server.WithMaxBodySize(n))
+	}
+	if rate := cfg.HTTP.RateLimit; rate > 0 {
+		level.Info(logger).Log("component", "main", "msg", fmt.Sprintf("Limiting each client to %v HTTP requests per second", rate))
+		handlerOpts = append(handlerOpts, server.WithRateLimit(rate, cfg.HTTP.RateBurst))
+	}
+	health := server.NewHealth()
+	handlerOpts = append(handlerOpts, server.WithHealth(health))
+	handler = server.MakeHTTPHandler(svc, r, logger, handlerOpts...)
 
 	// Listen for application termination.
 	errs := make(chan error)
@@ -91,13 +245,8 @@ func main() {
 	writTimeout, _ := time.ParseDuration("30s")
 	idleTimeout, _ := time.ParseDuration("60s")
 
-	// Check to see if our -http.addr flag has been overridden
-	if v := os.Getenv("HTTP_BIND_ADDRESS"); v != "" {
-		*httpAddr = v
-	}
-
 	serve := &http.Server{
-		Addr:    *httpAddr,
+		Addr:    cfg.HTTP.BindAddress,
 		Handler: handler,
 		TLSConfig: &tls.Config{
 			InsecureSkipVerify:       false,
@@ -109,24 +258,33 @@ func main() {
 		IdleTimeout:  idleTimeout,
 	}
 	shutdownServer := func() {
-		if err := serve.Shutdown(context.TODO()); err != nil {
-			logger.Log("shutdown", err)
+		// Fail readiness checks first so load balancers stop sending new requests, then
+		// wait for in-flight requests to finish.
+		health.Drain()
+		if delay := cfg.HTTP.ShutdownDelay.Duration; delay > 0 {
+			level.Info(logger).Log("component", "shutdown", "msg", fmt.Sprintf("waiting %v before draining HTTP requests", delay))
+			time.Sleep(delay)
+		}
+		timeout := cfg.HTTP.ShutdownTimeout.Duration
+		if timeout <= 0 {
+			timeout = 30 * time.Second
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		if err := serve.Shutdown(ctx); err != nil {
+			level.Error(logger).Log("component", "shutdown", "error", err)
 		}
-	}
-
-	// Check to see if our -admin.addr flag has been overridden
-	if v := os.Getenv("HTTP_ADMIN_BIND_ADDRESS"); v != "" {
-		*adminAddr = v
 	}
 
 	// Admin server (metrics and debugging)
-	adminServer := admin.NewServer(*adminAddr)
+	adminServer := admin.NewServer(cfg.HTTP.AdminBindAddress)
 	adminServer.AddVersionHandler(ach.Version) // Setup 'GET /version'
+	adminServer.AddReadinessCheck("http", health.Ready)
 	go func() {
-		logger.Log("admin", fmt.Sprintf("listening on %s", adminServer.BindAddr()))
-		if err := adminServer.Listen(); err != nil {
+		level.Info(logger).Log("component", "admin", "msg", fmt.Sprintf("listening on %s", adminServer.BindAddr()))
+		if err := adminServer.Listen(); err != nil && err != http.ErrServerClosed {
 			err = fmt.Errorf("problem starting admin http: %v", err)
-			logger.Log("admin", err)
+			level.Error(logger).Log("component", "admin", "error", err)
 			errs <- err
 		}
 	}()
@@ -134,23 +292,96 @@ func main() {
 
 	// Start main HTTP server
 	go func() {
-		if certFile, keyFile := os.Getenv("HTTPS_CERT_FILE"), os.Getenv("HTTPS_KEY_FILE"); certFile != "" && keyFile != "" {
-			logger.Log("startup", fmt.Sprintf("binding to %s for secure HTTP server", *httpAddr))
-			if err := serve.ListenAndServeTLS(certFile, keyFile); err != nil {
+		if certFile, keyFile := cfg.HTTP.CertFile, cfg.HTTP.KeyFile; certFile != "" && keyFile != "" {
+			level.Info(logger).Log("component", "startup", "msg", fmt.Sprintf("binding to %s for secure HTTP server", serve.Addr))
+			if err := serve.ListenAndServeTLS(certFile, keyFile); err != nil && err != http.ErrServerClosed {
 				errs <- err
-				logger.Log("exit", err)
+				level.Error(logger).Log("component", "exit", "error", err)
 			}
 		} else {
-			logger.Log("startup", fmt.Sprintf("binding to %s for HTTP server", *httpAddr))
-			if err := serve.ListenAndServe(); err != nil {
+			level.Info(logger).Log("component", "startup", "msg", fmt.Sprintf("binding to %s for HTTP server", serve.Addr))
+			if err := serve.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 				errs <- err
-				logger.Log("exit", err)
+				level.Error(logger).Log("component", "exit", "error", err)
 			}
 		}
 	}()
 
 	if err := <-errs; err != nil {
+		level.Info(logger).Log("component", "shutdown", "msg", fmt.Sprintf("shutting down: %v", err))
 		shutdownServer()
-		logger.Log("exit", err)
 	}
 }
+
+// logLevel returns the level.Option allowing lines at name and above, defaulting to info
+func logLevel(name string) level.Option {
+	switch strings.ToLower(name) {
+	case "debug":
+		return level.AllowDebug()
+	case "warn":
+		return level.AllowWarn()
+	case "error":
+		return level.AllowError()
+	default:
+		return level.AllowInfo()
+	}
+}
+
+// authConfig reads the API keys and JWT settings callers authenticate with, nil when none are set
+func authConfig(auth AuthFileConfig) *server.AuthConfig {
+	var cfg server.AuthConfig
+	if v := auth.APIKeys; v != "" {
+		keys, err := server.ParseAPIKeys(v)
+		if err != nil {
+			level.Error(logger).Log("component", "main", "msg", fmt.Sprintf("problem with auth API keys: %v", err))
+			os.Exit(1)
+		}
+		cfg.APIKeys = keys
+	}
+
+	jwt := &server.JWTConfig{
+		Secret:   []byte(auth.JWTSecret),
+		JWKSURL:  auth.JWKSURL,
+		Issuer:   auth.JWTIssuer,
+		Audience: auth.JWTAudience,
+
+		AllowMissingExpiry: auth.JWTAllowMissingExpiry,
+	}
+	if path := auth.JWTPublicKeyFile; path != "" {
+		bs, err := ioutil.ReadFile(path)
+		if err != nil {
+			level.Error(logger).Log("component", "main", "msg", fmt.Sprintf("problem reading JWT public key file: %v", err))
+			os.Exit(1)
+		}
+		key, err := server.ParseRSAPublicKey(bs)
+		if err != nil {
+			level.Error(logger).Log("component", "main", "msg", fmt.Sprintf("problem with JWT public key file: %v", err))
+			os.Exit(1)
+		}
+		jwt.PublicKeys = map[string]*rsa.PublicKey{"": key}
+	}
+	if len(jwt.Secret) > 0 || jwt.PublicKeys != nil || jwt.JWKSURL != "" {
+		cfg.JWT = jwt
+	}
+
+	if cfg.APIKeys == nil && cfg.JWT == nil {
+		return nil
+	}
+
+	if auth.RequireRoles {
+		roles := &server.RolesConfig{
+			Claim:  auth.JWTRolesClaim,
+			Routes: auth.RouteRoles,
+		}
+		if v := auth.APIKeyRoles; v != "" {
+			keys, err := server.ParseAPIKeyRoles(v)
+			if err != nil {
+				level.Error(logger).Log("component", "main", "msg", fmt.Sprintf("problem with auth API key roles: %v", err))
+				os.Exit(1)
+			}
+			roles.APIKeys = keys
+		}
+		cfg.Roles = roles
+	}
+	return &cfg
+}
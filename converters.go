@@ -25,6 +25,20 @@ import (
 // converters handles golang to ACH type Converters
 type converters struct{}
 
+// spaces and zeros are sliced to pad fields without building the padding for each field
+var (
+	spaces = strings.Repeat(" ", RecordLength)
+	zeros  = strings.Repeat("0", RecordLength)
+)
+
+// padding returns n characters of pad, which is spaces or zeros
+func padding(pad string, n uint) string {
+	if n > uint(len(pad)) {
+		return strings.Repeat(pad[:1], int(n))
+	}
+	return pad[:n]
+}
+
 func (c *converters) parseNumField(r string) (s int) {
 	s, _ = strconv.Atoi(strings.TrimSpace(r))
 	return s
@@ -57,8 +71,7 @@ func (c *converters) alphaField(s string, max uint) string {
 	if ln > max {
 		return s[:max]
 	}
-	s += strings.Repeat(" ", int(max-ln))
-	return s
+	return s + padding(spaces, max-ln)
 }
 
 // numericField right-justified, unsigned, and zero filled
@@ -68,8 +81,7 @@ func (c *converters) numericField(n int, max uint) string {
 	if ln > max {
 		return s[ln-max:]
 	}
-	s = strings.Repeat("0", int(max-ln)) + s
-	return s
+	return padding(zeros, max-ln) + s
 }
 
 // stringField slices to max length and zero filled
@@ -78,6 +90,5 @@ func (c *converters) stringField(s string, max uint) string {
 	if ln > max {
 		return s[:max]
 	}
-	s = strings.Repeat("0", int(max-ln)) + s
-	return s
+	return padding(zeros, max-ln) + s
 }
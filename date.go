@@ -0,0 +1,86 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date is a calendar day without a time or location. EffectiveEntryDates are Dates so they're
+// the same day for clients and servers in different timezones.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the Date of t in t's location
+func DateOf(t time.Time) Date {
+	return Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+}
+
+// Today returns the current Date in Eastern time, where the Federal Reserve operates the ACH Network
+func Today() Date {
+	return DateOf(time.Now().In(easternLocation()))
+}
+
+// ParseDate reads a Date formatted as YYYY-MM-DD or YYMMDD, as found in ACH files
+func ParseDate(v string) (Date, error) {
+	for _, layout := range []string{"2006-01-02", "060102"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return DateOf(t), nil
+		}
+	}
+	return Date{}, fmt.Errorf("invalid date %q: expected YYYY-MM-DD or YYMMDD", v)
+}
+
+// Time returns midnight UTC of the Date
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// IsZero returns true for the zero Date
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// Before returns true if d is an earlier day than other
+func (d Date) Before(other Date) bool {
+	return d.Time().Before(other.Time())
+}
+
+// String returns the Date in YYYY-MM-DD format
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// MarshalText encodes the Date as YYYY-MM-DD, so it's a string rather than an object in JSON
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText reads a Date formatted as YYYY-MM-DD or YYMMDD
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
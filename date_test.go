@@ -0,0 +1,83 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	expected := Date{Year: 2019, Month: time.October, Day: 4}
+	for _, v := range []string{"2019-10-04", "191004"} {
+		if d, err := ParseDate(v); err != nil || d != expected {
+			t.Errorf("%s: d=%v error=%v", v, d, err)
+		}
+	}
+	for _, v := range []string{"", "10/04/2019", "2019-10-04T00:00:00Z", "191304"} {
+		if _, err := ParseDate(v); err == nil {
+			t.Errorf("%q: expected error", v)
+		}
+	}
+}
+
+func TestDate__JSON(t *testing.T) {
+	var out struct {
+		Date Date         `json:"date"`
+		Days map[Date]int `json:"days"`
+	}
+	out.Date = Date{Year: 2019, Month: time.October, Day: 4}
+	out.Days = map[Date]int{out.Date: 1}
+	bs, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := string(bs); v != `{"date":"2019-10-04","days":{"2019-10-04":1}}` {
+		t.Errorf("got %s", v)
+	}
+
+	var in struct {
+		Date Date `json:"date"`
+	}
+	if err := json.Unmarshal([]byte(`{"date":"191005"}`), &in); err != nil || in.Date.String() != "2019-10-05" {
+		t.Errorf("date=%v error=%v", in.Date, err)
+	}
+	if err := json.Unmarshal([]byte(`{"date":"2019-10-05T23:00:00-07:00"}`), &in); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestDate__compare(t *testing.T) {
+	friday, monday := Date{Year: 2019, Month: time.October, Day: 4}, Date{Year: 2019, Month: time.October, Day: 7}
+	if !friday.Before(monday) || monday.Before(friday) || friday.Before(friday) {
+		t.Error("unexpected order")
+	}
+	if friday.IsZero() || !(Date{}).IsZero() {
+		t.Error("unexpected zero")
+	}
+
+	// late evening in the Americas is already tomorrow in UTC, but not in Eastern time
+	evening := time.Date(2019, time.October, 4, 21, 0, 0, 0, easternLocation())
+	if DateOf(evening) != friday || DateOf(evening.UTC()) == friday {
+		t.Errorf("unexpected dates: %v", DateOf(evening.UTC()))
+	}
+	if today := Today(); today.IsZero() {
+		t.Error("expected a Date")
+	}
+}
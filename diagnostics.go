@@ -0,0 +1,95 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diagnostic is a non-fatal issue found while reading a file. They describe data which
+// was ignored when parsing, such as characters in reserved columns, and don't make
+// a file invalid.
+type Diagnostic struct {
+	// Line is the line number of the record
+	Line int `json:"line"`
+	// Record is the name of the record, e.g. FileControl or Addenda10
+	Record string `json:"record"`
+	// Field is the name of the ignored field, if any
+	Field string `json:"field,omitempty"`
+	// Message describes the ignored data
+	Message string `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	if d.Field == "" {
+		return fmt.Sprintf("line:%d record:%s %s", d.Line, d.Record, d.Message)
+	}
+	return fmt.Sprintf("line:%d record:%s field:%s %s", d.Line, d.Record, d.Field, d.Message)
+}
+
+// addendaReservedColumns are the reserved columns, as zero-based [start, end) offsets,
+// of each addenda type which has them.
+var addendaReservedColumns = map[string][][2]int{
+	"10": {{81, 87}},
+	"11": {{73, 87}},
+	"12": {{73, 87}},
+	"13": {{77, 87}},
+	"14": {{77, 87}},
+	"15": {{53, 87}},
+	"16": {{73, 87}},
+	"18": {{77, 83}},
+	"98": {{21, 27}, {64, 79}},
+}
+
+// Diagnostics returns the non-fatal issues found while reading the file, such as
+// data in reserved columns or unknown addenda records which were ignored.
+func (r *Reader) Diagnostics() []Diagnostic {
+	return r.diagnostics
+}
+
+func (r *Reader) addDiagnostic(record, field, format string, args ...interface{}) {
+	r.diagnostics = append(r.diagnostics, Diagnostic{
+		Line:    r.lineNum,
+		Record:  record,
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// checkReserved records a Diagnostic when the current line has data in columns [start, end)
+func (r *Reader) checkReserved(record string, start, end int) {
+	if v := r.line[start:end]; strings.TrimSpace(v) != "" {
+		r.addDiagnostic(record, "reserved", "columns %d-%d are reserved and should be blank, ignored %q", start+1, end, v)
+	}
+}
+
+// checkAddendaReserved checks the reserved columns of the current addenda line
+func (r *Reader) checkAddendaReserved() {
+	typeCode := r.line[1:3]
+	for _, columns := range addendaReservedColumns[typeCode] {
+		r.checkReserved("Addenda"+typeCode, columns[0], columns[1])
+	}
+}
+
+// checkPadding records a Diagnostic when a block padding line contains anything other than 9's
+func (r *Reader) checkPadding() {
+	if strings.Trim(r.line, "9") != "" {
+		r.addDiagnostic("FileControl", "", "block padding should only contain 9's, ignored %q", r.line)
+	}
+}
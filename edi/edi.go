@@ -0,0 +1,151 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package edi translates the payment related information of CTX and CCD+ entries to and from
+// ASC X12 EDI. CTX entries carry an 820 payment order/remittance advice across their Addenda05
+// records and CCD+ health care claim payments carry the TRN reassociation trace of the 835 sent
+// to the payee separately.
+//
+// Segments are made of elements, e.g. "RMR*IV*12345**1000\", where the first element is the
+// segment's ID. NACHA recommends "*" between elements and "\" after each segment, which are
+// the DefaultDelimiters written by this package. When reading, the delimiters of an ISA
+// interchange header are used, otherwise the character after the first segment ID separates
+// elements and the last character ends segments.
+package edi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrEmpty is given when there are no segments to read
+	ErrEmpty = errors.New("no EDI segments")
+	// ErrDelimiters is given when the element separator or segment terminator can't be found
+	ErrDelimiters = errors.New("unable to find EDI delimiters")
+)
+
+// Delimiters are the characters which separate the elements and end the segments of EDI
+type Delimiters struct {
+	Element byte
+	Segment byte
+}
+
+// DefaultDelimiters are the delimiters NACHA recommends for payment related information
+var DefaultDelimiters = Delimiters{Element: '*', Segment: '\\'}
+
+// isaLength is the length of an ISA interchange header, which has fixed width elements
+const isaLength = 106
+
+// Segment is a segment of EDI, e.g. BPR or RMR, along with its elements
+type Segment struct {
+	ID       string
+	Elements []string
+}
+
+// NewSegment returns a segment with its elements, trailing empty elements are left out
+func NewSegment(id string, elements ...string) Segment {
+	for len(elements) > 0 && elements[len(elements)-1] == "" {
+		elements = elements[:len(elements)-1]
+	}
+	return Segment{ID: id, Elements: elements}
+}
+
+// Element returns the n'th element of the segment, counting from 1 as EDI does (e.g. RMR02),
+// or "" when the segment doesn't have it
+func (s Segment) Element(n int) string {
+	if n < 1 || n > len(s.Elements) {
+		return ""
+	}
+	return s.Elements[n-1]
+}
+
+// Format returns the segment written with d, ending with its segment terminator
+func (s Segment) Format(d Delimiters) string {
+	var buf strings.Builder
+	buf.WriteString(s.ID)
+	for _, e := range s.Elements {
+		buf.WriteByte(d.Element)
+		buf.WriteString(e)
+	}
+	buf.WriteByte(d.Segment)
+	return buf.String()
+}
+
+// ParseSegments reads the segments of data, such as the PaymentRelatedInformation of an entry, and
+// returns them with the delimiters they were written with. Spaces padding the end of data or an
+// Addenda05, and line breaks between segments, are ignored.
+func ParseSegments(data string) ([]Segment, Delimiters, error) {
+	data = strings.TrimRight(data, " ")
+	if data == "" {
+		return nil, Delimiters{}, ErrEmpty
+	}
+	d, err := findDelimiters(data)
+	if err != nil {
+		return nil, d, err
+	}
+	var out []Segment
+	for _, raw := range strings.Split(data, string(d.Segment)) {
+		raw = strings.TrimRight(strings.TrimLeft(raw, " \r\n"), "\r\n")
+		if raw == "" {
+			continue
+		}
+		elements := strings.Split(raw, string(d.Element))
+		out = append(out, Segment{ID: elements[0], Elements: elements[1:]})
+	}
+	if len(out) == 0 {
+		return nil, d, ErrEmpty
+	}
+	return out, d, nil
+}
+
+// findDelimiters returns the delimiters of data, read from its ISA header when it has one
+func findDelimiters(data string) (Delimiters, error) {
+	if strings.HasPrefix(data, "ISA") {
+		if len(data) < isaLength {
+			return Delimiters{}, fmt.Errorf("ISA segment of %d characters: %w", len(data), ErrDelimiters)
+		}
+		return Delimiters{Element: data[3], Segment: data[isaLength-1]}, nil
+	}
+	i := 0
+	for i < len(data) && isIDCharacter(data[i]) {
+		i++
+	}
+	if i == 0 || i >= len(data)-1 {
+		return Delimiters{}, ErrDelimiters
+	}
+	d := Delimiters{Element: data[i], Segment: data[len(data)-1]}
+	if d.Element == d.Segment || isIDCharacter(d.Segment) {
+		return Delimiters{}, ErrDelimiters
+	}
+	return d, nil
+}
+
+// isIDCharacter returns true for the upper case letters and digits segment IDs are made of
+func isIDCharacter(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// FormatSegments writes segments with d
+func FormatSegments(segments []Segment, d Delimiters) string {
+	var buf strings.Builder
+	for _, s := range segments {
+		buf.WriteString(s.Format(d))
+	}
+	return buf.String()
+}
@@ -0,0 +1,70 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package edi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSegments(t *testing.T) {
+	segments, d, err := ParseSegments("RMR*IV*12345**1000\\REF*PO*98765\\  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != DefaultDelimiters || len(segments) != 2 {
+		t.Fatalf("unexpected segments: %#v %#v", d, segments)
+	}
+	if s := segments[0]; s.ID != "RMR" || s.Element(1) != "IV" || s.Element(3) != "" || s.Element(4) != "1000" || s.Element(5) != "" {
+		t.Errorf("unexpected segment: %#v", s)
+	}
+
+	// other delimiters, including those of an ISA header, and line breaks
+	segments, d, err = ParseSegments("RMR|IV|12345~\nREF|PO|98765~")
+	if err != nil || d.Element != '|' || d.Segment != '~' || len(segments) != 2 || segments[1].ID != "REF" {
+		t.Errorf("unexpected segments: %#v %#v %v", d, segments, err)
+	}
+	isa := "ISA^00^          ^00^          ^ZZ^SENDER         ^ZZ^RECEIVER       ^210101^1200^U^00401^000000001^0^P^>~"
+	segments, d, err = ParseSegments(isa + "ST^820^0001~SE^2^0001~")
+	if err != nil || d.Element != '^' || d.Segment != '~' || len(segments) != 3 {
+		t.Errorf("unexpected segments: %#v %#v %v", d, segments, err)
+	}
+
+	for _, data := range []string{"", "   ", "RMR", "RMR*IV", "ISA*00*"} {
+		if _, _, err := ParseSegments(data); err == nil {
+			t.Errorf("%q: expected error", data)
+		}
+	}
+}
+
+func TestFormatSegments(t *testing.T) {
+	segments := []Segment{NewSegment("RMR", "IV", "12345", "", "1000", "", ""), NewSegment("REF", "PO", "98765")}
+	if v := FormatSegments(segments, DefaultDelimiters); v != "RMR*IV*12345**1000\\REF*PO*98765\\" {
+		t.Errorf("got %q", v)
+	}
+	if v := FormatSegments(segments, Delimiters{Element: '|', Segment: '~'}); v != "RMR|IV|12345||1000~REF|PO|98765~" {
+		t.Errorf("got %q", v)
+	}
+}
+
+func TestCheckElements(t *testing.T) {
+	err := checkElements([]Segment{NewSegment("N1", "PE", "A*B")}, DefaultDelimiters)
+	if !errors.Is(err, ErrDelimiterInElement) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
@@ -0,0 +1,306 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package edi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/ach"
+)
+
+var (
+	// ErrTransactionSet is given when EDI isn't a single transaction set of the expected kind
+	ErrTransactionSet = errors.New("unexpected EDI transaction set")
+	// ErrSegmentCount is given when the SE segment of a transaction set doesn't count its segments
+	ErrSegmentCount = errors.New("EDI transaction set segment count mismatch")
+	// ErrDelimiterInElement is given when an element to write contains one of the delimiters
+	ErrDelimiterInElement = errors.New("EDI element contains a delimiter")
+)
+
+// Payment820 is an 820 payment order/remittance advice transaction set, as carried by the Addenda05
+// records of a CTX entry. Segments other than ST, BPR, TRN, N1, RMR, REF and SE are skipped when
+// reading, along with the ISA, GS, GE and IEA segments of an interchange envelope.
+type Payment820 struct {
+	// ControlNumber is the ST02 transaction set control number, e.g. "0001"
+	ControlNumber string `json:"controlNumber"`
+	// Payment is the BPR segment
+	Payment PaymentOrder `json:"payment"`
+	// Trace is the TRN segment, which is optional
+	Trace *Trace `json:"trace,omitempty"`
+	// Parties are the N1 segments, e.g. the payer (PR) and payee (PE)
+	Parties []Party `json:"parties,omitempty"`
+	// Remittances are the RMR segments with the REF segments following each of them
+	Remittances []Remittance `json:"remittances,omitempty"`
+}
+
+// PaymentOrder is the BPR segment of an 820
+type PaymentOrder struct {
+	// TransactionHandlingCode is BPR01, e.g. C for a payment with its remittance or I for remittance only
+	TransactionHandlingCode string `json:"transactionHandlingCode"`
+	// Amount is BPR02
+	Amount ach.Amount `json:"amount"`
+	// CreditDebitFlag is BPR03, C or D
+	CreditDebitFlag string `json:"creditDebitFlag"`
+	// PaymentMethod is BPR04, e.g. ACH
+	PaymentMethod string `json:"paymentMethod"`
+	// PaymentFormat is BPR05, e.g. CTX or CCP
+	PaymentFormat string `json:"paymentFormat,omitempty"`
+	// OriginatingRoutingNumber and OriginatingAccountNumber are BPR07 and BPR09, written with
+	// the ABA (01) and demand deposit account (DA) qualifiers
+	OriginatingRoutingNumber string `json:"originatingRoutingNumber,omitempty"`
+	OriginatingAccountNumber string `json:"originatingAccountNumber,omitempty"`
+	// ReceivingRoutingNumber and ReceivingAccountNumber are BPR13 and BPR15
+	ReceivingRoutingNumber string `json:"receivingRoutingNumber,omitempty"`
+	ReceivingAccountNumber string `json:"receivingAccountNumber,omitempty"`
+	// EffectiveDate is BPR16, CCYYMMDD
+	EffectiveDate string `json:"effectiveDate,omitempty"`
+}
+
+// Party is an N1 segment naming a party to the payment
+type Party struct {
+	// EntityCode is N101, e.g. PR for the payer or PE for the payee
+	EntityCode string `json:"entityCode"`
+	// Name is N102
+	Name string `json:"name"`
+	// IDQualifier and ID are N103 and N104, e.g. 1 and a DUNS number
+	IDQualifier string `json:"idQualifier,omitempty"`
+	ID          string `json:"id,omitempty"`
+}
+
+// Remittance is an RMR segment, which says what an amount of the payment is for
+type Remittance struct {
+	// Qualifier is RMR01, e.g. IV for an invoice number
+	Qualifier string `json:"qualifier"`
+	// ReferenceID is RMR02, e.g. the invoice number
+	ReferenceID string `json:"referenceID"`
+	// PaymentActionCode is RMR03
+	PaymentActionCode string `json:"paymentActionCode,omitempty"`
+	// Paid is RMR04
+	Paid ach.Amount `json:"paid"`
+	// Invoiced and Discount are RMR05 and RMR06, zero amounts aren't written
+	Invoiced ach.Amount `json:"invoiced,omitempty"`
+	Discount ach.Amount `json:"discount,omitempty"`
+	// References are the REF segments following the RMR
+	References []Reference `json:"references,omitempty"`
+}
+
+// Reference is a REF segment
+type Reference struct {
+	// Qualifier is REF01, e.g. PO for a purchase order number
+	Qualifier string `json:"qualifier"`
+	// ID is REF02
+	ID string `json:"id"`
+}
+
+// Parse820 reads an 820 transaction set, such as the PaymentRelatedInformation of a CTX entry
+func Parse820(data string) (*Payment820, error) {
+	segments, _, err := ParseSegments(data)
+	if err != nil {
+		return nil, err
+	}
+	segments, err = transactionSet(segments, "820")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Payment820{ControlNumber: segments[0].Element(2)}
+	for _, s := range segments[1 : len(segments)-1] {
+		switch s.ID {
+		case "BPR":
+			p.Payment = PaymentOrder{
+				TransactionHandlingCode:  s.Element(1),
+				CreditDebitFlag:          s.Element(3),
+				PaymentMethod:            s.Element(4),
+				PaymentFormat:            s.Element(5),
+				OriginatingRoutingNumber: s.Element(7),
+				OriginatingAccountNumber: s.Element(9),
+				ReceivingRoutingNumber:   s.Element(13),
+				ReceivingAccountNumber:   s.Element(15),
+				EffectiveDate:            s.Element(16),
+			}
+			if p.Payment.Amount, err = parseAmount(s, 2); err != nil {
+				return nil, err
+			}
+		case "TRN":
+			t := parseTrace(s)
+			p.Trace = &t
+		case "N1":
+			p.Parties = append(p.Parties, Party{
+				EntityCode:  s.Element(1),
+				Name:        s.Element(2),
+				IDQualifier: s.Element(3),
+				ID:          s.Element(4),
+			})
+		case "RMR":
+			r := Remittance{
+				Qualifier:         s.Element(1),
+				ReferenceID:       s.Element(2),
+				PaymentActionCode: s.Element(3),
+			}
+			if r.Paid, err = parseAmount(s, 4); err != nil {
+				return nil, err
+			}
+			if r.Invoiced, err = parseAmount(s, 5); err != nil {
+				return nil, err
+			}
+			if r.Discount, err = parseAmount(s, 6); err != nil {
+				return nil, err
+			}
+			p.Remittances = append(p.Remittances, r)
+		case "REF":
+			if n := len(p.Remittances); n > 0 {
+				p.Remittances[n-1].References = append(p.Remittances[n-1].References, Reference{
+					Qualifier: s.Element(1),
+					ID:        s.Element(2),
+				})
+			}
+		}
+	}
+	return p, nil
+}
+
+// transactionSet returns the ST through SE segments of the single transaction set in segments, skipping
+// the segments of an interchange envelope, after checking its kind (ST01) and segment count (SE01)
+func transactionSet(segments []Segment, kind string) ([]Segment, error) {
+	var out []Segment
+	for _, s := range segments {
+		switch s.ID {
+		case "ISA", "GS", "GE", "IEA":
+			continue
+		case "ST":
+			if len(out) > 0 {
+				return nil, fmt.Errorf("more than one transaction set: %w", ErrTransactionSet)
+			}
+		}
+		out = append(out, s)
+	}
+	if len(out) < 2 || out[0].ID != "ST" || out[len(out)-1].ID != "SE" {
+		return nil, fmt.Errorf("missing ST or SE segment: %w", ErrTransactionSet)
+	}
+	if v := out[0].Element(1); v != kind {
+		return nil, fmt.Errorf("%q transaction set, expected %s: %w", v, kind, ErrTransactionSet)
+	}
+	se := out[len(out)-1]
+	if n, err := strconv.Atoi(se.Element(1)); err != nil || n != len(out) {
+		return nil, fmt.Errorf("SE01 of %q for %d segments: %w", se.Element(1), len(out), ErrSegmentCount)
+	}
+	if se.Element(2) != out[0].Element(2) {
+		return nil, fmt.Errorf("SE02 %q doesn't match ST02 %q: %w", se.Element(2), out[0].Element(2), ErrTransactionSet)
+	}
+	return out, nil
+}
+
+// parseAmount reads the n'th element of s as a decimal amount of dollars, zero when it's empty
+func parseAmount(s Segment, n int) (ach.Amount, error) {
+	v := s.Element(n)
+	if v == "" {
+		return 0, nil
+	}
+	if strings.ContainsAny(v, "$,") {
+		return 0, fmt.Errorf("%s%02d %q %w", s.ID, n, v, ach.ErrInvalidAmount)
+	}
+	amount, err := ach.ParseAmount(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s%02d: %w", s.ID, n, err)
+	}
+	return amount, nil
+}
+
+// formatAmount writes a for an amount element, "" when it's zero and omitted
+func formatAmount(a ach.Amount, omitted bool) string {
+	if a == 0 && omitted {
+		return ""
+	}
+	return a.String()
+}
+
+// Segments returns the ST through SE segments of the 820
+func (p *Payment820) Segments() []Segment {
+	segments := []Segment{NewSegment("ST", "820", p.ControlNumber)}
+
+	bpr := p.Payment
+	segments = append(segments, NewSegment("BPR",
+		bpr.TransactionHandlingCode, formatAmount(bpr.Amount, false), bpr.CreditDebitFlag, bpr.PaymentMethod, bpr.PaymentFormat,
+		qualifier("01", bpr.OriginatingRoutingNumber), bpr.OriginatingRoutingNumber,
+		qualifier("DA", bpr.OriginatingAccountNumber), bpr.OriginatingAccountNumber,
+		"", "", // BPR10 and BPR11, the originating company identifier and supplemental code
+		qualifier("01", bpr.ReceivingRoutingNumber), bpr.ReceivingRoutingNumber,
+		qualifier("DA", bpr.ReceivingAccountNumber), bpr.ReceivingAccountNumber,
+		bpr.EffectiveDate,
+	))
+	if p.Trace != nil {
+		segments = append(segments, p.Trace.Segment())
+	}
+	for _, party := range p.Parties {
+		segments = append(segments, NewSegment("N1", party.EntityCode, party.Name, party.IDQualifier, party.ID))
+	}
+	for _, r := range p.Remittances {
+		segments = append(segments, NewSegment("RMR",
+			r.Qualifier, r.ReferenceID, r.PaymentActionCode,
+			formatAmount(r.Paid, false), formatAmount(r.Invoiced, true), formatAmount(r.Discount, true),
+		))
+		for _, ref := range r.References {
+			segments = append(segments, NewSegment("REF", ref.Qualifier, ref.ID))
+		}
+	}
+	se := NewSegment("SE", strconv.Itoa(len(segments)+1), p.ControlNumber)
+	return append(segments, se)
+}
+
+// qualifier returns q when v is set
+func qualifier(q, v string) string {
+	if v == "" {
+		return ""
+	}
+	return q
+}
+
+// String returns the 820 written with the DefaultDelimiters
+func (p *Payment820) String() string {
+	return FormatSegments(p.Segments(), DefaultDelimiters)
+}
+
+// Read820 reads the 820 in the Addenda05 records of a CTX entry
+func Read820(entry *ach.EntryDetail) (*Payment820, error) {
+	return Parse820(entry.PaymentRelatedInformation())
+}
+
+// WriteCTX820 replaces the Addenda05 records of a CTX entry with p, setting the entry's
+// addenda record indicator and number of addenda records
+func WriteCTX820(entry *ach.EntryDetail, p *Payment820) error {
+	segments := p.Segments()
+	if err := checkElements(segments, DefaultDelimiters); err != nil {
+		return err
+	}
+	return entry.SetCTXPaymentRelatedInformation(FormatSegments(segments, DefaultDelimiters))
+}
+
+// checkElements returns an error when the elements of segments contain one of the delimiters
+func checkElements(segments []Segment, d Delimiters) error {
+	for _, s := range segments {
+		for i, e := range s.Elements {
+			if strings.IndexByte(e, d.Element) >= 0 || strings.IndexByte(e, d.Segment) >= 0 {
+				return fmt.Errorf("%s%02d %q: %w", s.ID, i+1, e, ErrDelimiterInElement)
+			}
+		}
+	}
+	return nil
+}
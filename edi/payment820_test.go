@@ -0,0 +1,153 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package edi
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/ach"
+)
+
+func mock820() *Payment820 {
+	return &Payment820{
+		ControlNumber: "0001",
+		Payment: PaymentOrder{
+			TransactionHandlingCode:  "C",
+			Amount:                   125000,
+			CreditDebitFlag:          "C",
+			PaymentMethod:            "ACH",
+			PaymentFormat:            "CTX",
+			OriginatingRoutingNumber: "121042882",
+			OriginatingAccountNumber: "123456789",
+			ReceivingRoutingNumber:   "231380104",
+			ReceivingAccountNumber:   "744567899",
+			EffectiveDate:            "20210101",
+		},
+		Trace: &Trace{TraceType: "1", ReferenceID: "0001", OriginatorID: "1234567890"},
+		Parties: []Party{
+			{EntityCode: "PR", Name: "Payer Company"},
+			{EntityCode: "PE", Name: "Receiver Company", IDQualifier: "1", ID: "123456789"},
+		},
+		Remittances: []Remittance{
+			{Qualifier: "IV", ReferenceID: "INV-1", Paid: 100000, Invoiced: 102000, Discount: 2000, References: []Reference{{Qualifier: "PO", ID: "PO-1"}}},
+			{Qualifier: "IV", ReferenceID: "INV-2", Paid: 25000},
+		},
+	}
+}
+
+func TestPayment820(t *testing.T) {
+	p := mock820()
+	expected := "ST*820*0001\\" +
+		"BPR*C*1250.00*C*ACH*CTX*01*121042882*DA*123456789***01*231380104*DA*744567899*20210101\\" +
+		"TRN*1*0001*1234567890\\" +
+		"N1*PR*Payer Company\\" +
+		"N1*PE*Receiver Company*1*123456789\\" +
+		"RMR*IV*INV-1**1000.00*1020.00*20.00\\" +
+		"REF*PO*PO-1\\" +
+		"RMR*IV*INV-2**250.00\\" +
+		"SE*9*0001\\"
+	if v := p.String(); v != expected {
+		t.Fatalf("got\n%s\nexpected\n%s", v, expected)
+	}
+
+	read, err := Parse820(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, p) {
+		t.Errorf("got %#v", read)
+	}
+
+	// interchange envelopes and other segments are skipped
+	isa := "ISA*00*          *00*          *ZZ*SENDER         *ZZ*RECEIVER       *210101*1200*U*00401*000000001*0*P*>\\"
+	data := isa + "GS*RA*SENDER*RECEIVER*20210101*1200*1*X*004010\\" +
+		"ST*820*0002\\BPR*I*10.5*C*ACH\\DTM*097*20210101\\RMR*IV*1**10.5\\SE*5*0002\\GE*1*1\\IEA*1*000000001\\"
+	read, err = Parse820(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.ControlNumber != "0002" || read.Payment.Amount != 1050 || read.Trace != nil || len(read.Remittances) != 1 || read.Remittances[0].Paid != 1050 {
+		t.Errorf("got %#v", read)
+	}
+}
+
+func TestParse820Errors(t *testing.T) {
+	cases := map[string]error{
+		"ST*835*0001\\SE*2*0001\\":                         ErrTransactionSet,
+		"BPR*C*1*C*ACH\\":                                  ErrTransactionSet,
+		"ST*820*0001\\SE*3*0001\\":                         ErrSegmentCount,
+		"ST*820*0001\\SE*2*0002\\":                         ErrTransactionSet,
+		"ST*820*0001\\SE*2*0001\\ST*820*0002\\SE*2*0002\\": ErrTransactionSet,
+		"ST*820*0001\\BPR*C*1,000*C*ACH\\SE*3*0001\\":      ach.ErrInvalidAmount,
+		"ST*820*0001\\RMR*IV*1**1.234\\SE*3*0001\\":        ach.ErrInvalidAmount,
+		"": ErrEmpty,
+	}
+	for data, expected := range cases {
+		if _, err := Parse820(data); !errors.Is(err, expected) {
+			t.Errorf("%q: %v", data, err)
+		}
+	}
+}
+
+func TestWriteCTX820(t *testing.T) {
+	entry := ach.NewEntryDetail()
+	entry.TransactionCode = ach.CheckingCredit
+	entry.SetRDFI("231380104")
+	entry.DFIAccountNumber = "744-5678-99"
+	entry.Amount = 125000
+	entry.SetCATXAddendaRecords(0)
+	entry.SetCATXReceivingCompany("Receiver Company")
+	entry.SetTraceNumber("12104288", 1)
+
+	p := mock820()
+	if err := WriteCTX820(entry, p); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(entry.Addenda05); n != 4 || entry.CATXAddendaRecordsField() != "0004" || entry.AddendaRecordIndicator != 1 {
+		t.Errorf("unexpected entry: %d addenda %#v", n, entry)
+	}
+	read, err := Read820(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, p) {
+		t.Errorf("got %#v", read)
+	}
+
+	bh := ach.NewBatchHeader()
+	bh.ServiceClassCode = ach.CreditsOnly
+	bh.CompanyName = "Payer Company"
+	bh.CompanyIdentification = "121042882"
+	bh.StandardEntryClassCode = ach.CTX
+	bh.CompanyEntryDescription = "ACH CTX"
+	bh.EffectiveEntryDate = "210101"
+	bh.ODFIIdentification = "12104288"
+	batch := ach.NewBatchCTX(bh)
+	batch.AddEntry(entry)
+	if err := batch.Create(); err != nil {
+		t.Error(err)
+	}
+
+	p.Parties[0].Name = "Payer*Company"
+	if err := WriteCTX820(entry, p); !errors.Is(err, ErrDelimiterInElement) || !strings.Contains(err.Error(), "N102") {
+		t.Errorf("%T: %v", err, err)
+	}
+}
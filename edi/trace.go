@@ -0,0 +1,93 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package edi
+
+import (
+	"fmt"
+
+	"github.com/moov-io/ach"
+)
+
+// maxAddenda05Length is how many characters of payment related information fit in one Addenda05
+const maxAddenda05Length = 80
+
+// Trace is a TRN trace segment. The Addenda05 of a CCD+ health care claim payment carries the TRN of
+// the 835 sent to the payee, so the payee can reassociate the payment with its remittance advice.
+type Trace struct {
+	// TraceType is TRN01, 1 for a current transaction trace number
+	TraceType string `json:"traceType"`
+	// ReferenceID is TRN02, the check or EFT trace number of the 835
+	ReferenceID string `json:"referenceID"`
+	// OriginatorID is TRN03, for an 835 a 1 followed by the payer's federal tax identification number
+	OriginatorID string `json:"originatorID,omitempty"`
+	// SupplementalID is TRN04, which identifies a subdivision of the payer
+	SupplementalID string `json:"supplementalID,omitempty"`
+}
+
+func parseTrace(s Segment) Trace {
+	return Trace{
+		TraceType:      s.Element(1),
+		ReferenceID:    s.Element(2),
+		OriginatorID:   s.Element(3),
+		SupplementalID: s.Element(4),
+	}
+}
+
+// Segment returns the TRN segment of t
+func (t Trace) Segment() Segment {
+	return NewSegment("TRN", t.TraceType, t.ReferenceID, t.OriginatorID, t.SupplementalID)
+}
+
+// String returns the TRN segment of t written with the DefaultDelimiters, e.g. "TRN*1*12345*1512345678\"
+func (t Trace) String() string {
+	return t.Segment().Format(DefaultDelimiters)
+}
+
+// ParseTrace reads a TRN segment, such as the PaymentRelatedInformation of a CCD+ health care claim payment
+func ParseTrace(data string) (*Trace, error) {
+	segments, _, err := ParseSegments(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) != 1 || segments[0].ID != "TRN" {
+		return nil, fmt.Errorf("expected a single TRN segment: %w", ErrTransactionSet)
+	}
+	t := parseTrace(segments[0])
+	return &t, nil
+}
+
+// ReadReassociation reads the 835 reassociation trace in the Addenda05 of a CCD+ entry
+func ReadReassociation(entry *ach.EntryDetail) (*Trace, error) {
+	return ParseTrace(entry.PaymentRelatedInformation())
+}
+
+// WriteReassociation replaces the Addenda05 records of a CCD+ entry with a single Addenda05 carrying t,
+// which has to fit in its 80 characters. ach.EntryDetail.SetReassociationTrace writes the same Addenda05
+// after checking the payer's tax identification number.
+func WriteReassociation(entry *ach.EntryDetail, t Trace) error {
+	segment := t.Segment()
+	if err := checkElements([]Segment{segment}, DefaultDelimiters); err != nil {
+		return err
+	}
+	info := segment.Format(DefaultDelimiters)
+	if len(info) > maxAddenda05Length {
+		return fmt.Errorf("TRN segment of %d characters is longer than an Addenda05's %d", len(info), maxAddenda05Length)
+	}
+	entry.SetPaymentRelatedInformation(info)
+	return nil
+}
@@ -0,0 +1,68 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package edi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/moov-io/ach"
+)
+
+func TestTrace(t *testing.T) {
+	trace := Trace{TraceType: "1", ReferenceID: "12345", OriginatorID: "1512345678"}
+	if v := trace.String(); v != "TRN*1*12345*1512345678\\" {
+		t.Errorf("got %q", v)
+	}
+	read, err := ParseTrace("TRN*1*12345*1512345678*ABC\\")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.ReferenceID != "12345" || read.OriginatorID != "1512345678" || read.SupplementalID != "ABC" {
+		t.Errorf("got %#v", read)
+	}
+	for _, data := range []string{"RMR*IV*1\\", "TRN*1*1\\TRN*1*2\\"} {
+		if _, err := ParseTrace(data); !errors.Is(err, ErrTransactionSet) {
+			t.Errorf("%q: %v", data, err)
+		}
+	}
+}
+
+func TestWriteReassociation(t *testing.T) {
+	entry := ach.NewEntryDetail()
+	entry.SetTraceNumber("12104288", 7)
+	trace := Trace{TraceType: "1", ReferenceID: "12345", OriginatorID: "1512345678"}
+	if err := WriteReassociation(entry, trace); err != nil {
+		t.Fatal(err)
+	}
+	if len(entry.Addenda05) != 1 || entry.AddendaRecordIndicator != 1 || entry.Addenda05[0].EntryDetailSequenceNumber != 7 {
+		t.Errorf("unexpected entry: %#v", entry)
+	}
+	read, err := ReadReassociation(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *read != trace {
+		t.Errorf("got %#v", read)
+	}
+
+	trace.SupplementalID = "123456789012345678901234567890123456789012345678901234567890"
+	if err := WriteReassociation(entry, trace); err == nil {
+		t.Error("expected error")
+	}
+}
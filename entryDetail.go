@@ -18,6 +18,8 @@
 package ach
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -82,12 +84,18 @@ type EntryDetail struct {
 	Addenda98 *Addenda98 `json:"addenda98,omitempty"`
 	// Addenda99 for use with Returns
 	Addenda99 *Addenda99 `json:"addenda99,omitempty"`
+	// Addenda99Dishonored for use with Dishonored Returns
+	Addenda99Dishonored *Addenda99Dishonored `json:"addenda99Dishonored,omitempty"`
+	// Addenda99Contested for use with Contested Dishonored Returns
+	Addenda99Contested *Addenda99Contested `json:"addenda99Contested,omitempty"`
 	// Category defines if the entry is a Forward, Return, or NOC
 	Category string `json:"category,omitempty"`
 	// validator is composed for data validation
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 const (
@@ -188,6 +196,19 @@ func NewEntryDetail() *EntryDetail {
 	return entry
 }
 
+// EntryDetailFromJSON reads an EntryDetail and its addenda from JSON, filling in their record types.
+func EntryDetailFromJSON(bs []byte) (*EntryDetail, error) {
+	if len(bs) == 0 {
+		return nil, errors.New("no JSON data provided")
+	}
+	entry := NewEntryDetail()
+	if err := json.Unmarshal(bs, entry); err != nil {
+		return nil, fmt.Errorf("problem reading EntryDetail: %v", err)
+	}
+	setEntryRecordType(entry)
+	return entry, nil
+}
+
 // Parse takes the input record string and parses the EntryDetail values
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate() call to confirm successful parsing and data validity.
@@ -228,7 +249,7 @@ func (ed *EntryDetail) String() string {
 	var buf strings.Builder
 	buf.Grow(94)
 	buf.WriteString(ed.recordType)
-	buf.WriteString(fmt.Sprintf("%v", ed.TransactionCode))
+	buf.WriteString(strconv.Itoa(ed.TransactionCode))
 	buf.WriteString(ed.RDFIIdentificationField())
 	buf.WriteString(ed.CheckDigit)
 	buf.WriteString(ed.DFIAccountNumberField())
@@ -236,7 +257,7 @@ func (ed *EntryDetail) String() string {
 	buf.WriteString(ed.IdentificationNumberField())
 	buf.WriteString(ed.IndividualNameField())
 	buf.WriteString(ed.DiscretionaryDataField())
-	buf.WriteString(fmt.Sprintf("%v", ed.AddendaRecordIndicator))
+	buf.WriteString(strconv.Itoa(ed.AddendaRecordIndicator))
 	buf.WriteString(ed.TraceNumberField())
 	return buf.String()
 }
@@ -259,6 +280,12 @@ func (ed *EntryDetail) Validate() error {
 	if ed.Amount < 0 {
 		return fieldError("Amount", ErrNegativeAmount, ed.Amount)
 	}
+	if Amount(ed.Amount) > MaxEntryAmount {
+		return fieldError("Amount", ErrInvalidAmount, ed.Amount)
+	}
+	if ed.IsPrenote() && ed.Amount != 0 {
+		return fieldError("Amount", ErrPrenoteAmount, ed.Amount)
+	}
 	if err := ed.isAlphanumeric(ed.IdentificationNumber); err != nil {
 		return fieldError("IdentificationNumber", err, ed.IdentificationNumber)
 	}
@@ -334,18 +361,28 @@ func (ed *EntryDetail) AmountField() string {
 	return ed.numericField(ed.Amount, 10)
 }
 
+// GetAmount returns the Amount of the entry
+func (ed *EntryDetail) GetAmount() Amount {
+	return Amount(ed.Amount)
+}
+
+// SetAmount sets the Amount of the entry
+func (ed *EntryDetail) SetAmount(a Amount) {
+	ed.Amount = int(a)
+}
+
 // IdentificationNumberField returns a space padded string of IdentificationNumber
 func (ed *EntryDetail) IdentificationNumberField() string {
 	return ed.alphaField(ed.IdentificationNumber, 15)
 }
 
-// CheckSerialNumberField is used in RCK, ARC, BOC files but returns
+// CheckSerialNumberField is used in RCK, ARC, BOC, XCK files but returns
 // a space padded string of the underlying IdentificationNumber field
 func (ed *EntryDetail) CheckSerialNumberField() string {
 	return ed.alphaField(ed.IdentificationNumber, 15)
 }
 
-// SetCheckSerialNumber setter for RCK, ARC, BOC CheckSerialNumber
+// SetCheckSerialNumber setter for RCK, ARC, BOC, XCK CheckSerialNumber
 // which is underlying IdentificationNumber
 func (ed *EntryDetail) SetCheckSerialNumber(s string) {
 	ed.IdentificationNumber = s
@@ -372,19 +409,19 @@ func (ed *EntryDetail) SetPOPTerminalState(s string) {
 // POPCheckSerialNumberField is used in POP, characters 1-9 of underlying BatchPOP
 // CheckSerialNumber / IdentificationNumber
 func (ed *EntryDetail) POPCheckSerialNumberField() string {
-	return ed.parseStringField(ed.IdentificationNumber[0:9])
+	return ed.parseStringField(ed.alphaField(ed.IdentificationNumber, 15)[0:9])
 }
 
 // POPTerminalCityField is used in POP, characters 10-13 of underlying BatchPOP
 // CheckSerialNumber / IdentificationNumber
 func (ed *EntryDetail) POPTerminalCityField() string {
-	return ed.parseStringField(ed.IdentificationNumber[9:13])
+	return ed.parseStringField(ed.alphaField(ed.IdentificationNumber, 15)[9:13])
 }
 
 // POPTerminalStateField is used in POP, characters 14-15 of underlying BatchPOP
 // CheckSerialNumber / IdentificationNumber
 func (ed *EntryDetail) POPTerminalStateField() string {
-	return ed.parseStringField(ed.IdentificationNumber[13:15])
+	return ed.parseStringField(ed.alphaField(ed.IdentificationNumber, 15)[13:15])
 }
 
 // SetSHRCardExpirationDate format MMYY is used in SHR, characters 1-4 of underlying
@@ -496,12 +533,12 @@ func (ed *EntryDetail) SetPaymentType(t string) {
 	}
 }
 
-// SetProcessControlField setter for TRC Process Control Field characters 1-6 of underlying IndividualName
+// SetProcessControlField setter for TRC and XCK Process Control Field characters 1-6 of underlying IndividualName
 func (ed *EntryDetail) SetProcessControlField(s string) {
 	ed.IndividualName = ed.alphaField(s, 6)
 }
 
-// SetItemResearchNumber setter for TRC Item Research Number characters 7-22 of underlying IndividualName
+// SetItemResearchNumber setter for TRC and XCK Item Research Number characters 7-22 of underlying IndividualName
 func (ed *EntryDetail) SetItemResearchNumber(s string) {
 	ed.IndividualName = ed.IndividualName + ed.alphaField(s, 16)
 }
@@ -511,14 +548,14 @@ func (ed *EntryDetail) SetItemTypeIndicator(s string) {
 	ed.DiscretionaryData = ed.alphaField(s, 2)
 }
 
-// ProcessControlField getter for TRC Process Control Field characters 1-6 of underlying IndividualName
+// ProcessControlField getter for TRC and XCK Process Control Field characters 1-6 of underlying IndividualName
 func (ed *EntryDetail) ProcessControlField() string {
-	return ed.parseStringField(ed.IndividualName[0:6])
+	return ed.parseStringField(ed.alphaField(ed.IndividualName, 22)[0:6])
 }
 
-// ItemResearchNumber getter for TRC Item Research Number characters 7-22 of underlying IndividualName
+// ItemResearchNumber getter for TRC and XCK Item Research Number characters 7-22 of underlying IndividualName
 func (ed *EntryDetail) ItemResearchNumber() string {
-	return ed.parseStringField(ed.IndividualName[6:22])
+	return ed.parseStringField(ed.alphaField(ed.IndividualName, 22)[6:22])
 }
 
 // ItemTypeIndicator getter for TRC Item Type Indicator which is underlying Discretionary Data
@@ -570,5 +607,11 @@ func (ed *EntryDetail) addendaCount() (n int) {
 	if ed.Addenda99 != nil {
 		n += 1
 	}
+	if ed.Addenda99Dishonored != nil {
+		n += 1
+	}
+	if ed.Addenda99Contested != nil {
+		n += 1
+	}
 	return n
 }
@@ -737,3 +737,32 @@ func TestEntryDetail__ParseNOC(t *testing.T) {
 		t.Errorf("EntryDetail.Category=%s\n  %#v", entries[0].Category, entries[0])
 	}
 }
+
+func TestEntryDetailFromJSON(t *testing.T) {
+	ed := mockEntryDetail()
+	ed.AddAddenda05(mockAddenda05())
+	ed.AddendaRecordIndicator = 1
+	bs, err := json.Marshal(ed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := EntryDetailFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entry.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if len(entry.Addenda05) != 1 {
+		t.Fatalf("got %d Addenda05", len(entry.Addenda05))
+	}
+	if err := entry.Addenda05[0].Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, body := range []string{"", "[]"} {
+		if _, err := EntryDetailFromJSON([]byte(body)); err == nil {
+			t.Errorf("expected error for %q", body)
+		}
+	}
+}
@@ -72,7 +72,7 @@ func Example_iatReadMixedCreditDebit() {
 
 	// Output:
 	// SEC Code: IAT
-	// Debit Entry: 6271210428820007             0000100000123456789                              1231380100000001
+	// Debit Entry: 6271210428820009             0000100000123456789                              1231380100000001
 	// Addenda10: 710ANN000000000000100000928383-23938          BEK Enterprises                          0000001
 	// Addenda11: 711BEK Solutions                      15 West Place Street                             0000001
 	// Addenda12: 712JacobsTown*PA\                     US*19305\                                        0000001
@@ -83,7 +83,7 @@ func Example_iatReadMixedCreditDebit() {
 	// Addenda17: 717This is an international payment                                                00010000001
 	// Addenda18: 718Bank of France                     01456456456987987                   FR       00010000001
 	// Total File Debit Amount: 100000
-	// Credit Entry: 6221210428820007             0000100000123456789                              1231380100000002
+	// Credit Entry: 6221210428820009             0000100000123456789                              1231380100000002
 	// Addenda10: 710ANN000000000000100000928383-23938          ADCAF Enterprises                        0000002
 	// Addenda11: 711ADCAF Solutions                    15 West Place Street                             0000002
 	// Addenda12: 712JacobsTown*PA\                     US*19305\                                        0000002
@@ -0,0 +1,79 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"strings"
+	"time"
+)
+
+// Totals are the debit and credit amounts, in cents, of a number of entries
+type Totals struct {
+	Debit   int `json:"debit"`
+	Credit  int `json:"credit"`
+	Entries int `json:"entries"`
+}
+
+// Net returns the credit amount less the debit amount
+func (t Totals) Net() int {
+	return t.Credit - t.Debit
+}
+
+func (t *Totals) add(other Totals) {
+	t.Debit += other.Debit
+	t.Credit += other.Credit
+	t.Entries += other.Entries
+}
+
+// ExposureByDate totals the debit and credit amounts of every batch across files by the date the batch
+// settles on. Batches settle on their EffectiveEntryDate, or the next banking day after it when it's a weekend
+// or holiday. Batches without a valid EffectiveEntryDate (such as ENR batches) are not included.
+func ExposureByDate(files []*File) map[Date]Totals {
+	out := make(map[Date]Totals)
+	add := func(effectiveEntryDate string, sum Checksum, entries int) {
+		date, ok := effectiveSettlementDate(effectiveEntryDate)
+		if !ok {
+			return
+		}
+		totals := out[date]
+		totals.add(Totals{Debit: sum.TotalDebit, Credit: sum.TotalCredit, Entries: entries})
+		out[date] = totals
+	}
+	for _, f := range files {
+		if f == nil {
+			continue
+		}
+		for _, batch := range f.Batches {
+			add(batch.GetHeader().EffectiveEntryDate, batchChecksum(batch), len(batch.GetEntries())+len(batch.GetADVEntries()))
+		}
+		for i := range f.IATBatches {
+			iatBatch := &f.IATBatches[i]
+			add(iatBatch.GetHeader().EffectiveEntryDate, iatBatchChecksum(iatBatch), len(iatBatch.GetEntries()))
+		}
+	}
+	return out
+}
+
+// effectiveSettlementDate returns the banking day a YYMMDD EffectiveEntryDate settles on
+func effectiveSettlementDate(effectiveEntryDate string) (Date, bool) {
+	t, err := time.Parse("060102", strings.TrimSpace(effectiveEntryDate))
+	if err != nil {
+		return Date{}, false
+	}
+	return DateOf(NextBankingDay(t, true)), true
+}
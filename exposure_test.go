@@ -0,0 +1,65 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExposureByDate(t *testing.T) {
+	credits := mockFilePPD() // 100000000 credit
+	credits.Batches[0].GetHeader().EffectiveEntryDate = "191004"
+
+	debits := mockFilePPD()
+	bh := debits.Batches[0].GetHeader()
+	bh.EffectiveEntryDate = "191005" // Saturday, settles on Monday
+	entry := debits.Batches[0].GetEntries()[0]
+	entry.TransactionCode = CheckingDebit
+	entry.Amount = 2500
+
+	// ENR batches have no EffectiveEntryDate
+	enr := mockFilePPD()
+	enr.Batches[0].GetHeader().EffectiveEntryDate = ""
+
+	exposure := ExposureByDate([]*File{credits, debits, enr, nil})
+	if len(exposure) != 2 {
+		t.Fatalf("unexpected exposure: %#v", exposure)
+	}
+
+	friday := Date{Year: 2019, Month: time.October, Day: 4}
+	if totals := exposure[friday]; totals.Credit != 100000000 || totals.Debit != 0 || totals.Entries != 1 {
+		t.Errorf("%v: %#v", friday, totals)
+	}
+	monday := DateOf(time.Date(2019, time.October, 7, 12, 0, 0, 0, time.UTC))
+	if totals := exposure[monday]; totals.Debit != 2500 || totals.Net() != -2500 {
+		t.Errorf("%v: %#v", monday, totals)
+	}
+	if v := monday.String(); v != "2019-10-07" {
+		t.Errorf("Date.String()=%s", v)
+	}
+	if !monday.Time().Equal(time.Date(2019, time.October, 7, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Date.Time()=%v", monday.Time())
+	}
+
+	// the same date accumulates across files
+	exposure = ExposureByDate([]*File{credits, credits})
+	if totals := exposure[friday]; totals.Credit != 200000000 || totals.Entries != 2 {
+		t.Errorf("%v: %#v", friday, totals)
+	}
+}
@@ -61,21 +61,33 @@ var (
 	ErrValidISO3166 = errors.New("is an invalid ISO 3166-1-alpha-2 code")
 	// ErrValidISO4217 is the error given when a field has an invalid ISO 4217 code
 	ErrValidISO4217 = errors.New("is an invalid ISO 4217 code")
+	// ErrFieldTooLong is the error given when a value would be truncated to fit its field
+	ErrFieldTooLong = errors.New("is longer than the field allows")
 
 	// EntryDetail errors
 
 	// ErrNegativeAmount is the error given when an Amount value is negaitve, which is
 	// against NACHA rules and guidelines.
 	ErrNegativeAmount = errors.New("amounts cannot be negative")
+	// ErrInvalidAmount is the error given when an amount can't be read or is too large for its field
+	ErrInvalidAmount = errors.New("is an invalid amount")
 
 	// Addenda errors
 
+	// ErrAddenda02TerminalIdentificationCode is given when a terminal identification code isn't uppercase alphanumeric or is all zeros
+	ErrAddenda02TerminalIdentificationCode = errors.New("is an invalid Terminal Identification Code")
+	// ErrAddenda02NetworkIdentification is given when the network identification in ReferenceInformationTwo isn't uppercase alphanumeric
+	ErrAddenda02NetworkIdentification = errors.New("is an invalid Network Identification Code")
 	// ErrAddenda98ChangeCode is given when there's an invalid addenda change code
 	ErrAddenda98ChangeCode = errors.New("found is not a valid addenda Change Code")
 	// ErrAddenda98CorrectedData is given when the corrected data does not corespond to the change code
 	ErrAddenda98CorrectedData = errors.New("must contain the corrected information corresponding to the Change Code")
 	// ErrAddenda99ReturnCode is given when there's an invalid return code
 	ErrAddenda99ReturnCode = errors.New("found is not a valid return code")
+	// ErrAddenda99DishonoredReturnCode is given when there's an invalid dishonored return code
+	ErrAddenda99DishonoredReturnCode = errors.New("found is not a valid dishonored return code")
+	// ErrAddenda99ContestedReturnCode is given when there's an invalid contested dishonored return code
+	ErrAddenda99ContestedReturnCode = errors.New("found is not a valid contested dishonored return code")
 	// ErrBatchCORAddenda is given when an entry in a COR batch does not have an addenda98
 	ErrBatchCORAddenda = errors.New("one Addenda98 record is required for each entry in SEC Type COR")
 
@@ -100,6 +112,14 @@ var (
 	ErrIDNumberQualifier = errors.New("is an invalid Identification Number Qualifier")
 	// ErrIATBatchAddendaIndicator is given when there's an invalid addenda record for an IAT batch
 	ErrIATBatchAddendaIndicator = errors.New("is invalid for addenda record(s) found")
+	// ErrForeignExchangeReference is given when the foreign exchange reference doesn't match its reference indicator
+	ErrForeignExchangeReference = errors.New("is invalid for the Foreign Exchange Reference Indicator")
+	// ErrForeignExchangeRate is given when a foreign exchange rate reference isn't a positive decimal number
+	ErrForeignExchangeRate = errors.New("is an invalid Foreign Exchange Rate")
+	// ErrOFACScreeningIndicator is given when there's an invalid OFAC screening indicator
+	ErrOFACScreeningIndicator = errors.New("is an invalid OFAC Screening Indicator")
+	// ErrDateFormat is given when a date or time can not be converted between its NACHA and ISO 8601 formats
+	ErrDateFormat = errors.New("is an invalid date or time")
 )
 
 // FieldError is returned for errors at a field level in a record
@@ -19,12 +19,18 @@ package ach
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/moov-io/ach/routing"
+	"github.com/moov-io/ach/screening"
 	"github.com/moov-io/base"
 )
 
@@ -68,6 +74,13 @@ type File struct {
 	// ReturnEntries is a slice of references to file.Batches that contain return entries
 	ReturnEntries []Batcher `json:"ReturnEntries"`
 
+	// TrailerRecords are proprietary records found after the FileControl. They're only
+	// read when Reader.AllowTrailerRecords is called and written when Writer.WriteTrailerRecords is set.
+	TrailerRecords []string `json:"trailerRecords,omitempty"`
+
+	// Comments are free form notes kept with the File's JSON. They're never written into a NACHA file.
+	Comments []string `json:"comments,omitempty"`
+
 	validateOpts *ValidateOpts
 }
 
@@ -80,7 +93,9 @@ func NewFile() *File {
 }
 
 type file struct {
-	ID string `json:"id"`
+	ID             string   `json:"id"`
+	TrailerRecords []string `json:"trailerRecords"`
+	Comments       []string `json:"comments"`
 }
 
 type fileHeader struct {
@@ -105,9 +120,21 @@ type advFileControl struct {
 // Date and Time fields in formats: RFC 3339 and ISO 8601 will be parsed and rewritten
 // as their YYMMDD (year, month, day) or hhmm (hour, minute) formats.
 func FileFromJSON(bs []byte) (*File, error) {
+	return FileFromJSONWith(bs, nil)
+}
+
+// FileFromJSONWith reads a File from JSON like FileFromJSON with opts changing how it's read.
+// A nil opts is the same as calling FileFromJSON.
+//
+// With opts.Draft set the JSON is read as a workspace, see JSONOpts.
+func FileFromJSONWith(bs []byte, opts *JSONOpts) (*File, error) {
 	if len(bs) == 0 {
 		return nil, errors.New("no JSON data provided")
 	}
+	draft := opts != nil && opts.Draft
+	if draft {
+		bs = stripJSONComments(bs)
+	}
 
 	// read file root level
 	var f file
@@ -116,6 +143,8 @@ func FileFromJSON(bs []byte) (*File, error) {
 		return nil, fmt.Errorf("problem reading File: %v", err)
 	}
 	file.ID = f.ID
+	file.TrailerRecords = f.TrailerRecords
+	file.Comments = f.Comments
 
 	// Read FileHeader
 	header := fileHeader{
@@ -127,7 +156,7 @@ func FileFromJSON(bs []byte) (*File, error) {
 	file.Header = header.Header
 
 	// Build resulting file
-	if err := file.setBatchesFromJSON(bs); err != nil {
+	if err := file.setBatchesFromJSON(bs, draft); err != nil {
 		return nil, err
 	}
 
@@ -154,19 +183,143 @@ func FileFromJSON(bs []byte) (*File, error) {
 		file.ADVControl = advControl.ADVControl
 	}
 
+	return file.finishJSON(draft)
+}
+
+// finishJSON counts the batches of a File read from JSON and, unless it's a draft, builds and validates it
+func (f *File) finishJSON(draft bool) (*File, error) {
+	if !f.IsADV() {
+		f.Control.BatchCount = len(f.Batches)
+	} else {
+		f.ADVControl.BatchCount = len(f.Batches)
+	}
+
+	if draft {
+		// drafts are built and validated by Finalize once they're complete
+		return f, nil
+	}
+	if err := f.Create(); err != nil {
+		return f, err
+	}
+	if err := f.Validate(); err != nil {
+		return f, err
+	}
+	return f, nil
+}
+
+// FileFromJSONReader reads a File from JSON like FileFromJSON, but decodes r as it's read so the
+// JSON is never held in memory all at once. Only one batch is buffered at a time.
+func FileFromJSONReader(r io.Reader) (*File, error) {
+	file := NewFile()
+	control, advControl := NewFileControl(), NewADVFileControl()
+	var batches []*Batch
+	var iatBatches []IATBatch
+
+	dec := json.NewDecoder(r)
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		if err == io.EOF {
+			return nil, errors.New("no JSON data provided")
+		}
+		return nil, fmt.Errorf("problem reading File: %v", err)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("problem reading File: %v", err)
+		}
+		key, _ := tok.(string)
+		switch {
+		case strings.EqualFold(key, "id"):
+			err = dec.Decode(&file.ID)
+		case strings.EqualFold(key, "trailerRecords"):
+			err = dec.Decode(&file.TrailerRecords)
+		case strings.EqualFold(key, "comments"):
+			err = dec.Decode(&file.Comments)
+		case strings.EqualFold(key, "fileHeader"):
+			if err := dec.Decode(&file.Header); err != nil {
+				return nil, fmt.Errorf("problem reading FileHeader: %v", err)
+			}
+		case strings.EqualFold(key, "batches"):
+			batches = nil
+			err = decodeJSONArray(dec, func() error {
+				var batch *Batch
+				if err := dec.Decode(&batch); err != nil {
+					return err
+				}
+				batches = append(batches, batch)
+				return nil
+			})
+		case strings.EqualFold(key, "iatBatches"):
+			iatBatches = nil
+			err = decodeJSONArray(dec, func() error {
+				var iatBatch IATBatch
+				if err := dec.Decode(&iatBatch); err != nil {
+					return err
+				}
+				iatBatches = append(iatBatches, iatBatch)
+				return nil
+			})
+		case strings.EqualFold(key, "fileControl"):
+			if err := dec.Decode(&control); err != nil {
+				return nil, fmt.Errorf("problem reading FileControl: %v", err)
+			}
+		case strings.EqualFold(key, "advFileControl"):
+			if err := dec.Decode(&advControl); err != nil {
+				return nil, fmt.Errorf("problem reading ADVFileControl: %v", err)
+			}
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("problem reading File: %v", err)
+		}
+	}
+	if err := expectJSONDelim(dec, '}'); err != nil {
+		return nil, fmt.Errorf("problem reading File: %v", err)
+	}
+
+	if err := file.addBatchesFromJSON(batches, iatBatches, false); err != nil {
+		return nil, err
+	}
+	file.overwriteDateTimeFields()
 	if !file.IsADV() {
-		file.Control.BatchCount = len(file.Batches)
+		file.Control = control
 	} else {
-		file.ADVControl.BatchCount = len(file.Batches)
+		file.ADVControl = advControl
 	}
+	return file.finishJSON(false)
+}
 
-	if err := file.Create(); err != nil {
-		return file, err
+func expectJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
 	}
-	if err := file.Validate(); err != nil {
-		return file, err
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("expected %v but found %v", delim, tok)
 	}
-	return file, nil
+	return nil
+}
+
+// decodeJSONArray calls decode for each element of the JSON array dec is at. A null array has no elements.
+func decodeJSONArray(dec *json.Decoder, decode func() error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("expected [ but found %v", tok)
+	}
+	for dec.More() {
+		if err := decode(); err != nil {
+			return err
+		}
+	}
+	return expectJSONDelim(dec, ']')
 }
 
 // UnmarshalJSON returns error json struct tag unmarshal is deprecated, use ach.FileFromJSON instead
@@ -200,6 +353,14 @@ func setEntryRecordType(e *EntryDetail) {
 		e.Addenda99.recordType = "7"
 		e.Addenda99.TypeCode = "99"
 	}
+	if e.Addenda99Dishonored != nil {
+		e.Addenda99Dishonored.recordType = "7"
+		e.Addenda99Dishonored.TypeCode = "99"
+	}
+	if e.Addenda99Contested != nil {
+		e.Addenda99Contested.recordType = "7"
+		e.Addenda99Contested.TypeCode = "99"
+	}
 }
 
 func setADVEntryRecordType(e *ADVEntryDetail) {
@@ -262,13 +423,22 @@ func setIATEntryRecordType(e *IATEntryDetail) {
 //
 // We have to break this out as Batcher is an interface (and can't be read by Go's
 // json struct tag decoding).
-func (f *File) setBatchesFromJSON(bs []byte) error {
+func (f *File) setBatchesFromJSON(bs []byte, draft bool) error {
 	var batches batchesJSON
 	var iatBatches iatBatchesJSON
 
 	if err := json.Unmarshal(bs, &batches); err != nil {
 		return err
 	}
+	if err := json.Unmarshal(bs, &iatBatches); err != nil {
+		return err
+	}
+	return f.addBatchesFromJSON(batches.Batches, iatBatches.IATBatches, draft)
+}
+
+// addBatchesFromJSON converts batches read from JSON to their Batcher and adds them to the File.
+// Unless draft is set each batch is built.
+func (f *File) addBatchesFromJSON(batches []*Batch, iatBatches []IATBatch, draft bool) error {
 	// Clear out any nil batches
 	for i := range f.Batches {
 		if f.Batches[i] == nil {
@@ -276,47 +446,35 @@ func (f *File) setBatchesFromJSON(bs []byte) error {
 		}
 	}
 	// Add new batches to file
-	for i := range batches.Batches {
-		if batches.Batches[i] == nil {
+	for i := range batches {
+		if batches[i] == nil {
 			continue
 		}
-		batch := *batches.Batches[i]
-		batch.Header.recordType = batchHeaderPos
-
-		for _, e := range batch.Entries {
-			// these values need to be inferred from the json field names
-			setEntryRecordType(e)
-		}
-		for _, e := range batch.ADVEntries {
-			setADVEntryRecordType(e)
-		}
+		batch := *batches[i]
+		batch.setRecordTypesFromJSON()
 
-		if err := batch.build(); err != nil {
-			return batch.Error("Invalid Batch", err, batch.Header.ID)
+		if !draft {
+			if err := batch.build(); err != nil {
+				return batch.Error("Invalid Batch", err, batch.Header.ID)
+			}
 		}
 
 		// Attach a batch with the correct type
 		f.Batches = append(f.Batches, ConvertBatchType(batch))
 	}
 
-	if err := json.Unmarshal(bs, &iatBatches); err != nil {
-		return err
-	}
-
 	// Add new iatBatches to file
-	for i := range iatBatches.IATBatches {
-		if len(iatBatches.IATBatches) == 0 {
-			continue
-		}
-
-		iatBatch := iatBatches.IATBatches[i]
+	for i := range iatBatches {
+		iatBatch := iatBatches[i]
 		iatBatch.Header.recordType = "5"
 		for _, e := range iatBatch.Entries {
 			setIATEntryRecordType(e)
 		}
 
-		if err := iatBatch.build(); err != nil {
-			return iatBatch.Error("from JSON", err)
+		if !draft {
+			if err := iatBatch.build(); err != nil {
+				return iatBatch.Error("from JSON", err)
+			}
 		}
 		f.IATBatches = append(f.IATBatches, iatBatch)
 	}
@@ -343,9 +501,7 @@ func (f *File) overwriteDateTimeFields() {
 		if t, err := datetimeParse(strings.TrimPrefix(header.CompanyDescriptiveDate, "SD")); err == nil {
 			header.CompanyDescriptiveDate = "SD" + t.Format("1504")
 		}
-		if t, err := datetimeParse(header.EffectiveEntryDate); err == nil {
-			header.EffectiveEntryDate = t.Format("060102")
-		}
+		header.EffectiveEntryDate = jsonEffectiveEntryDate(header.EffectiveEntryDate)
 		f.Batches[i].SetHeader(header)
 	}
 
@@ -353,12 +509,23 @@ func (f *File) overwriteDateTimeFields() {
 
 	// IAT Batches
 	for i := range f.IATBatches {
-		if t, err := datetimeParse(f.IATBatches[i].Header.EffectiveEntryDate); err == nil {
-			f.IATBatches[i].Header.EffectiveEntryDate = t.Format("060102")
-		}
+		f.IATBatches[i].Header.EffectiveEntryDate = jsonEffectiveEntryDate(f.IATBatches[i].Header.EffectiveEntryDate)
 	}
 }
 
+// jsonEffectiveEntryDate returns the YYMMDD of an EffectiveEntryDate read from JSON as a date (YYYY-MM-DD) or
+// timestamp. Timestamps keep the day of their own UTC offset so the server's timezone never changes the day,
+// but clients should send dates as JavaScript's toISOString() is the next day in UTC for evenings in the Americas.
+func jsonEffectiveEntryDate(v string) string {
+	if d, err := time.Parse("2006-01-02", v); err == nil {
+		return d.Format("060102")
+	}
+	if t, err := datetimeParse(v); err == nil {
+		return t.Format("060102")
+	}
+	return v
+}
+
 var datetimeformats = []string{
 	"2006-01-02T15:04:05.999Z", // Default javascript (new Date).toISOString()
 	"2006-01-02T15:04:05Z",     // ISO 8601 without milliseconds
@@ -511,6 +678,11 @@ func (f *File) Validate() error {
 	return f.ValidateWith(f.validateOpts)
 }
 
+// ValidateContext is Validate, but stops between batches and returns ctx's error once ctx is done.
+func (f *File) ValidateContext(ctx context.Context) error {
+	return f.ValidateWithContext(ctx, f.validateOpts)
+}
+
 // SetValidation stores ValidateOpts on the Batch which are to be used to override
 // the default NACHA validation rules.
 func (f *File) SetValidation(opts *ValidateOpts) {
@@ -535,6 +707,61 @@ type ValidateOpts struct {
 	// This also allows for custom TraceNumbers which aren't prefixed with
 	// a routing number as required by the NACHA specification.
 	BypassOriginValidation bool `json:"bypassOriginValidation"`
+
+	// MaxAddendaPerEntry overrides the maximum number of Addenda05 records allowed on
+	// a forward entry, keyed by StandardEntryClassCode. SEC codes which are not present
+	// use the NACHA defaults returned by DefaultMaxAddendaPerEntry.
+	MaxAddendaPerEntry map[string]int `json:"maxAddendaPerEntry,omitempty"`
+
+	// CorrectServiceClassCode can be set to have a batch's Create() set its ServiceClassCode
+	// from its entries (CreditsOnly, DebitsOnly or MixedDebitsAndCredits) rather than
+	// returning an error when the entries don't match.
+	CorrectServiceClassCode bool `json:"correctServiceClassCode,omitempty"`
+
+	// RequireUniqueTraceNumbers can be set to reject files where two entries, in any of
+	// the file's batches, share a TraceNumber.
+	RequireUniqueTraceNumbers bool `json:"requireUniqueTraceNumbers,omitempty"`
+
+	// SameDayEntryLimit overrides the maximum Amount (in cents) of an entry which
+	// IsEligibleSameDay accepts. Zero uses the limit of RulesVersion.
+	SameDayEntryLimit int `json:"sameDayEntryLimit,omitempty"`
+
+	// RulesVersion selects the NACHA rules the file is checked against, such as the Same Day
+	// entry limit, RulesCurrent when empty. Use RulesEffectiveDate to check each batch against
+	// the rules in force on its EffectiveEntryDate.
+	RulesVersion RulesVersion `json:"rulesVersion,omitempty"`
+
+	// AccountValidator can be set to reject WEB debits to accounts which haven't been validated when
+	// RulesVersion requires it, see File.ValidateWEBDebitAccounts. It isn't read from JSON.
+	AccountValidator AccountValidator `json:"-"`
+
+	// RDFIDirectory can be set to reject entries whose RDFI isn't a participant of the directory or
+	// doesn't receive the SEC code of their batch, see File.ValidateRDFIs. It isn't read from JSON.
+	RDFIDirectory routing.Directory `json:"-"`
+
+	// IATScreener can be set to reject IAT entries with a receiver, originator or financial institution
+	// it blocks, see File.ScreenIATEntries. It isn't read from JSON.
+	IATScreener screening.Screener `json:"-"`
+
+	// Concurrency is how many of a file's batches are validated at once, which speeds up validating
+	// files with thousands of batches. Zero or one validates them one at a time. The error of the first
+	// invalid batch is returned either way. It isn't read from JSON.
+	Concurrency int `json:"-"`
+
+	// strict is set by StrictNACHA to validate batches with these options instead of their own
+	strict bool
+}
+
+// StrictNACHA returns ValidateOpts for validating at the maximum strictness of the NACHA rules.
+// Every bypass and lenient option is off and every optional check is enabled, so the result
+// can be used to assert conformance regardless of the options a File was created with. Any
+// ValidateOpts set on the File's batches are ignored as well.
+func StrictNACHA() *ValidateOpts {
+	return &ValidateOpts{
+		RequireABAOrigin:          true,
+		RequireUniqueTraceNumbers: true,
+		strict:                    true,
+	}
 }
 
 // ValidateWith performs NACHA format rule checks on each record according to their specification
@@ -542,9 +769,18 @@ type ValidateOpts struct {
 //
 // The first error encountered is returned and stops the parsing.
 func (f *File) ValidateWith(opts *ValidateOpts) error {
+	return f.ValidateWithContext(context.Background(), opts)
+}
+
+// ValidateWithContext is ValidateWith, but stops between batches and returns ctx's error once ctx is done.
+// ctx is also passed to the RDFIDirectory and IATScreener of opts.
+func (f *File) ValidateWithContext(ctx context.Context, opts *ValidateOpts) error {
 	if opts == nil {
 		opts = &ValidateOpts{}
 	}
+	if err := opts.RulesVersion.validate(); err != nil {
+		return fieldError("RulesVersion", err, opts.RulesVersion)
+	}
 
 	if err := f.Header.ValidateWith(opts); err != nil {
 		return err
@@ -556,10 +792,8 @@ func (f *File) ValidateWith(opts *ValidateOpts) error {
 			return NewErrFileCalculatedControlEquality("BatchCount", len(f.Batches), f.Control.BatchCount)
 		}
 
-		for _, b := range f.Batches {
-			if err := b.Validate(); err != nil {
-				return err
-			}
+		if err := f.validateBatches(ctx, opts); err != nil {
+			return err
 		}
 
 		if err := f.Control.Validate(); err != nil {
@@ -571,6 +805,26 @@ func (f *File) ValidateWith(opts *ValidateOpts) error {
 		if err := f.isFileAmount(false); err != nil {
 			return err
 		}
+		if opts.RequireUniqueTraceNumbers {
+			if err := f.isTraceNumberUnique(); err != nil {
+				return err
+			}
+		}
+		if opts.RDFIDirectory != nil {
+			if err := f.ValidateRDFIs(ctx, opts.RDFIDirectory); err != nil {
+				return err
+			}
+		}
+		if opts.IATScreener != nil {
+			if err := f.ScreenIATEntries(ctx, opts.IATScreener); err != nil {
+				return err
+			}
+		}
+		if opts.AccountValidator != nil {
+			if err := f.ValidateWEBDebitAccounts(ctx, opts.AccountValidator, opts.RulesVersion); err != nil {
+				return err
+			}
+		}
 		return f.isEntryHash(false)
 	}
 
@@ -589,9 +843,74 @@ func (f *File) ValidateWith(opts *ValidateOpts) error {
 	if err := f.isFileAmount(true); err != nil {
 		return err
 	}
+	if opts.RDFIDirectory != nil {
+		if err := f.ValidateRDFIs(ctx, opts.RDFIDirectory); err != nil {
+			return err
+		}
+	}
 	return f.isEntryHash(true)
 }
 
+// validateBatches validates f's batches, opts.Concurrency at a time, and returns the error of the first
+// invalid batch. Batches after an invalid batch which haven't started are skipped.
+func (f *File) validateBatches(ctx context.Context, opts *ValidateOpts) error {
+	validate := func(b Batcher) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if opts.strict {
+			b = strictBatch(b, opts)
+		}
+		return b.Validate()
+	}
+
+	workers := opts.Concurrency
+	if workers > len(f.Batches) {
+		workers = len(f.Batches)
+	}
+	if workers <= 1 {
+		for _, b := range f.Batches {
+			if err := validate(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, len(f.Batches))
+	next := int64(-1)                     // the last batch handed to a worker
+	firstInvalid := int64(len(f.Batches)) // the first batch with an error
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				// batches are handed out in order, so those before an invalid one are all validated
+				i := atomic.AddInt64(&next, 1)
+				if i >= atomic.LoadInt64(&firstInvalid) {
+					return
+				}
+				if errs[i] = validate(f.Batches[i]); errs[i] == nil {
+					continue
+				}
+				for {
+					first := atomic.LoadInt64(&firstInvalid)
+					if i >= first || atomic.CompareAndSwapInt64(&firstInvalid, first, i) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if i := atomic.LoadInt64(&firstInvalid); i < int64(len(f.Batches)) {
+		return errs[i]
+	}
+	return nil
+}
+
 // isEntryAddendaCount is prepared by hashing the RDFI's 8-digit Routing Number in each entry.
 // The Entry Hash provides a check against inadvertent alteration of data
 func (f *File) isEntryAddendaCount(IsADV bool) error {
@@ -0,0 +1,289 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/moov-io/base"
+)
+
+// FileBuilder creates a File with chained calls, e.g.
+//
+//	file, err := ach.NewFileBuilder().
+//	    WithHeader(fh).
+//	    AddPPDBatch(bh).AddEntry(entry1).AddEntry(entry2).
+//	    AddCCDBatch(bh2).AddEntry(entry3).
+//	    Build()
+//
+// Nothing is created or validated until Build, which returns every error found. Entries without
+// a TraceNumber are given one from the ODFI of their batch, unique across the file.
+type FileBuilder struct {
+	header       *FileHeader
+	validateOpts *ValidateOpts
+	batches      []*BatchBuilder
+	iatBatches   []*IATBatchBuilder
+	errs         base.ErrorList
+}
+
+// NewFileBuilder returns a FileBuilder for an empty file
+func NewFileBuilder() *FileBuilder {
+	return &FileBuilder{}
+}
+
+// WithHeader sets the FileHeader of the file
+func (b *FileBuilder) WithHeader(fh FileHeader) *FileBuilder {
+	b.header = &fh
+	return b
+}
+
+// WithValidation overrides the default NACHA rules for the file and each of its batches
+func (b *FileBuilder) WithValidation(opts *ValidateOpts) *FileBuilder {
+	b.validateOpts = opts
+	return b
+}
+
+// AddBatch starts a batch with bh, whose StandardEntryClassCode picks the kind of batch
+func (b *FileBuilder) AddBatch(bh *BatchHeader) *BatchBuilder {
+	batch := &BatchBuilder{file: b, header: bh, n: len(b.batches) + 1}
+	if bh == nil {
+		b.errs.Add(fmt.Errorf("batch #%d: missing BatchHeader", batch.n))
+	}
+	b.batches = append(b.batches, batch)
+	return batch
+}
+
+// AddPPDBatch starts a PPD batch with bh
+func (b *FileBuilder) AddPPDBatch(bh *BatchHeader) *BatchBuilder {
+	return b.addBatch(PPD, bh)
+}
+
+// AddCCDBatch starts a CCD batch with bh
+func (b *FileBuilder) AddCCDBatch(bh *BatchHeader) *BatchBuilder {
+	return b.addBatch(CCD, bh)
+}
+
+// AddWEBBatch starts a WEB batch with bh
+func (b *FileBuilder) AddWEBBatch(bh *BatchHeader) *BatchBuilder {
+	return b.addBatch(WEB, bh)
+}
+
+// AddTELBatch starts a TEL batch with bh
+func (b *FileBuilder) AddTELBatch(bh *BatchHeader) *BatchBuilder {
+	return b.addBatch(TEL, bh)
+}
+
+// addBatch starts a batch with bh after setting its StandardEntryClassCode
+func (b *FileBuilder) addBatch(sec string, bh *BatchHeader) *BatchBuilder {
+	if bh != nil {
+		bh.StandardEntryClassCode = sec
+	}
+	return b.AddBatch(bh)
+}
+
+// AddIATBatch starts an IAT batch with bh
+func (b *FileBuilder) AddIATBatch(bh *IATBatchHeader) *IATBatchBuilder {
+	batch := &IATBatchBuilder{file: b, header: bh, n: len(b.iatBatches) + 1}
+	if bh == nil {
+		b.errs.Add(fmt.Errorf("IAT batch #%d: missing IATBatchHeader", batch.n))
+	}
+	b.iatBatches = append(b.iatBatches, batch)
+	return batch
+}
+
+// Build creates each batch and then the file, returning all of the errors found. Batches are
+// numbered in the order they were added, with IAT batches after the others.
+func (b *FileBuilder) Build() (*File, error) {
+	errs := append(base.ErrorList(nil), b.errs...)
+	if b.header == nil {
+		errs.Add(errors.New("missing FileHeader"))
+	}
+	if len(b.batches) == 0 && len(b.iatBatches) == 0 {
+		errs.Add(ErrFileNoBatches)
+	}
+	if !errs.Empty() {
+		return nil, errs
+	}
+
+	file := NewFile()
+	file.SetHeader(*b.header)
+	file.SetValidation(b.validateOpts)
+
+	traceNumbers := NewTraceNumberGenerator(nil)
+	for i, bb := range b.batches {
+		batch, err := bb.create(traceNumbers, b.validateOpts)
+		if err != nil {
+			errs.Add(fmt.Errorf("batch #%d: %v", i+1, err))
+			continue
+		}
+		file.AddBatch(batch)
+	}
+	for i, bb := range b.iatBatches {
+		iatBatch, err := bb.create(traceNumbers)
+		if err != nil {
+			errs.Add(fmt.Errorf("IAT batch #%d: %v", i+1, err))
+			continue
+		}
+		file.AddIATBatch(*iatBatch)
+	}
+	if !errs.Empty() {
+		return nil, errs
+	}
+
+	if err := file.Create(); err != nil {
+		return nil, err
+	}
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// BatchBuilder collects the entries of one batch of a FileBuilder
+type BatchBuilder struct {
+	file       *FileBuilder
+	n          int
+	header     *BatchHeader
+	entries    []*EntryDetail
+	advEntries []*ADVEntryDetail
+}
+
+// AddEntry adds ed to the batch
+func (bb *BatchBuilder) AddEntry(ed *EntryDetail) *BatchBuilder {
+	if ed == nil {
+		bb.file.errs.Add(fmt.Errorf("batch #%d: nil EntryDetail", bb.n))
+		return bb
+	}
+	bb.entries = append(bb.entries, ed)
+	return bb
+}
+
+// AddADVEntry adds ed to an ADV batch
+func (bb *BatchBuilder) AddADVEntry(ed *ADVEntryDetail) *BatchBuilder {
+	if ed == nil {
+		bb.file.errs.Add(fmt.Errorf("batch #%d: nil ADVEntryDetail", bb.n))
+		return bb
+	}
+	bb.advEntries = append(bb.advEntries, ed)
+	return bb
+}
+
+// AddBatch finishes this batch and starts another, see FileBuilder.AddBatch
+func (bb *BatchBuilder) AddBatch(bh *BatchHeader) *BatchBuilder {
+	return bb.file.AddBatch(bh)
+}
+
+// AddPPDBatch finishes this batch and starts a PPD batch
+func (bb *BatchBuilder) AddPPDBatch(bh *BatchHeader) *BatchBuilder {
+	return bb.file.AddPPDBatch(bh)
+}
+
+// AddCCDBatch finishes this batch and starts a CCD batch
+func (bb *BatchBuilder) AddCCDBatch(bh *BatchHeader) *BatchBuilder {
+	return bb.file.AddCCDBatch(bh)
+}
+
+// AddWEBBatch finishes this batch and starts a WEB batch
+func (bb *BatchBuilder) AddWEBBatch(bh *BatchHeader) *BatchBuilder {
+	return bb.file.AddWEBBatch(bh)
+}
+
+// AddTELBatch finishes this batch and starts a TEL batch
+func (bb *BatchBuilder) AddTELBatch(bh *BatchHeader) *BatchBuilder {
+	return bb.file.AddTELBatch(bh)
+}
+
+// AddIATBatch finishes this batch and starts an IAT batch
+func (bb *BatchBuilder) AddIATBatch(bh *IATBatchHeader) *IATBatchBuilder {
+	return bb.file.AddIATBatch(bh)
+}
+
+// Done returns the FileBuilder this batch belongs to
+func (bb *BatchBuilder) Done() *FileBuilder {
+	return bb.file
+}
+
+// Build builds the file this batch belongs to, see FileBuilder.Build
+func (bb *BatchBuilder) Build() (*File, error) {
+	return bb.file.Build()
+}
+
+func (bb *BatchBuilder) create(traceNumbers TraceNumberGenerator, opts *ValidateOpts) (Batcher, error) {
+	batch, err := NewBatch(bb.header)
+	if err != nil {
+		return nil, err
+	}
+	batch.SetValidation(opts)
+	setTraceNumberGenerator(batch, traceNumbers)
+	for _, ed := range bb.entries {
+		batch.AddEntry(ed)
+	}
+	for _, ed := range bb.advEntries {
+		batch.AddADVEntry(ed)
+	}
+	if err := batch.Create(); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// IATBatchBuilder collects the entries of one IAT batch of a FileBuilder
+type IATBatchBuilder struct {
+	file    *FileBuilder
+	n       int
+	header  *IATBatchHeader
+	entries []*IATEntryDetail
+}
+
+// AddEntry adds ed to the batch, see IATBuilder for creating an IATEntryDetail with its addenda records
+func (bb *IATBatchBuilder) AddEntry(ed *IATEntryDetail) *IATBatchBuilder {
+	if ed == nil {
+		bb.file.errs.Add(fmt.Errorf("IAT batch #%d: nil IATEntryDetail", bb.n))
+		return bb
+	}
+	bb.entries = append(bb.entries, ed)
+	return bb
+}
+
+// AddIATBatch finishes this batch and starts another IAT batch
+func (bb *IATBatchBuilder) AddIATBatch(bh *IATBatchHeader) *IATBatchBuilder {
+	return bb.file.AddIATBatch(bh)
+}
+
+// Done returns the FileBuilder this batch belongs to
+func (bb *IATBatchBuilder) Done() *FileBuilder {
+	return bb.file
+}
+
+// Build builds the file this batch belongs to, see FileBuilder.Build
+func (bb *IATBatchBuilder) Build() (*File, error) {
+	return bb.file.Build()
+}
+
+func (bb *IATBatchBuilder) create(traceNumbers TraceNumberGenerator) (*IATBatch, error) {
+	iatBatch := NewIATBatch(bb.header)
+	iatBatch.SetTraceNumberGenerator(traceNumbers)
+	for _, ed := range bb.entries {
+		iatBatch.AddEntry(ed)
+	}
+	if err := iatBatch.Create(); err != nil {
+		return nil, err
+	}
+	return &iatBatch, nil
+}
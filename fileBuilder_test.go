@@ -0,0 +1,160 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base"
+)
+
+func mockBuilderIATEntryDetail() *IATEntryDetail {
+	ed := mockIATEntryDetail()
+	ed.TraceNumber = ""
+	ed.Addenda10 = mockAddenda10()
+	ed.Addenda11 = mockAddenda11()
+	ed.Addenda12 = mockAddenda12()
+	ed.Addenda13 = mockAddenda13()
+	ed.Addenda14 = mockAddenda14()
+	ed.Addenda15 = mockAddenda15()
+	ed.Addenda16 = mockAddenda16()
+	return ed
+}
+
+func TestFileBuilder(t *testing.T) {
+	ppd1, ppd2, ccd := mockPPDEntryDetail(), mockPPDEntryDetail(), mockCCDEntryDetail()
+	ppd1.TraceNumber, ppd2.TraceNumber, ccd.TraceNumber = "", "", ""
+
+	file, err := NewFileBuilder().
+		WithHeader(mockFileHeader()).
+		AddPPDBatch(mockBatchPPDHeader()).AddEntry(ppd1).AddEntry(ppd2).
+		AddCCDBatch(mockBatchCCDHeader()).AddEntry(ccd).
+		AddIATBatch(mockIATBatchHeaderFF()).AddEntry(mockBuilderIATEntryDetail()).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(file.Batches); n != 2 {
+		t.Fatalf("got %d batches", n)
+	}
+	if n := len(file.IATBatches); n != 1 {
+		t.Fatalf("got %d IAT batches", n)
+	}
+	if sec := file.Batches[1].GetHeader().StandardEntryClassCode; sec != CCD {
+		t.Errorf("StandardEntryClassCode=%s", sec)
+	}
+	if n := file.Control.EntryAddendaCount; n != 11 {
+		t.Errorf("EntryAddendaCount=%d", n)
+	}
+
+	// trace numbers are assigned and unique across the file
+	seen := make(map[string]bool)
+	file.ForEachEntry(func(_ Batcher, ed *EntryDetail) error {
+		if ed.TraceNumber == "" || seen[ed.TraceNumber] {
+			t.Errorf("unexpected TraceNumber %q", ed.TraceNumber)
+		}
+		seen[ed.TraceNumber] = true
+		return nil
+	})
+	if tn := file.IATBatches[0].Entries[0].TraceNumber; tn == "" || seen[tn] {
+		t.Errorf("unexpected IAT TraceNumber %q", tn)
+	}
+}
+
+func TestFileBuilder__Done(t *testing.T) {
+	b := NewFileBuilder()
+	b.AddPPDBatch(mockBatchPPDHeader()).AddEntry(mockPPDEntryDetail()).
+		Done().WithHeader(mockFileHeader())
+
+	file, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(file.Batches[0].GetEntries()); n != 1 {
+		t.Errorf("got %d entries", n)
+	}
+}
+
+func TestFileBuilder__Errors(t *testing.T) {
+	_, err := NewFileBuilder().Build()
+	errs, ok := err.(base.ErrorList)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("%T: %v", err, err)
+	}
+	if !base.Has(errs, ErrFileNoBatches) {
+		t.Errorf("expected ErrFileNoBatches: %v", errs)
+	}
+
+	// errors from every batch are returned
+	bh := mockBatchPPDHeader()
+	bh.ServiceClassCode = 0
+	_, err = NewFileBuilder().
+		WithHeader(mockFileHeader()).
+		AddPPDBatch(bh).AddEntry(mockPPDEntryDetail()).
+		AddCCDBatch(mockBatchCCDHeader()).
+		AddBatch(nil).
+		Build()
+	errs, ok = err.(base.ErrorList)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("%T: %v", err, err)
+	}
+	if !strings.Contains(errs[0].Error(), "batch #3") {
+		t.Errorf("unexpected error: %v", errs[0])
+	}
+
+	_, err = NewFileBuilder().
+		WithHeader(mockFileHeader()).
+		AddPPDBatch(bh).AddEntry(mockPPDEntryDetail()).
+		AddCCDBatch(mockBatchCCDHeader()).
+		Build()
+	errs, ok = err.(base.ErrorList)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("%T: %v", err, err)
+	}
+	if !strings.HasPrefix(errs[0].Error(), "batch #1") || !strings.HasPrefix(errs[1].Error(), "batch #2") {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	// the file is validated
+	fh := mockFileHeader()
+	fh.ImmediateOrigin = ""
+	if _, err := NewFileBuilder().WithHeader(fh).AddPPDBatch(mockBatchPPDHeader()).AddEntry(mockPPDEntryDetail()).Build(); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestFileBuilder__WithValidation(t *testing.T) {
+	build := func(opts *ValidateOpts) error {
+		ed1, ed2 := mockPPDEntryDetail(), mockPPDEntryDetail()
+		ed2.TraceNumber = ed1.TraceNumber
+		_, err := NewFileBuilder().
+			WithHeader(mockFileHeader()).
+			WithValidation(opts).
+			AddPPDBatch(mockBatchPPDHeader()).AddEntry(ed1).
+			AddPPDBatch(mockBatchPPDHeader()).AddEntry(ed2).
+			Build()
+		return err
+	}
+	if err := build(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := build(&ValidateOpts{RequireUniqueTraceNumbers: true}); err == nil {
+		t.Error("expected duplicate TraceNumber error")
+	}
+}
@@ -45,6 +45,8 @@ var (
 	ErrFileIATSEC = errors.New("IAT Standard Entry Class Code should use iatBatch")
 	// ErrFileNoBatches is the error given if a file has no batches
 	ErrFileNoBatches = errors.New("must have []*Batches or []*IATBatches to be built")
+	// ErrFileDailyLimit is the error given if a company's entries for an effective date exceed its daily limit in a Policy
+	ErrFileDailyLimit = errors.New("company exceeds its daily limit")
 )
 
 // RecordWrongLengthErr is the error given when a record is the wrong length
@@ -122,3 +124,21 @@ func NewErrFileCalculatedControlEquality(field string, calculated, control int)
 func (e ErrFileCalculatedControlEquality) Error() string {
 	return e.Message
 }
+
+// ErrFileDuplicateTraceNumber is the error given when two entries in a file share a TraceNumber
+type ErrFileDuplicateTraceNumber struct {
+	Message     string
+	TraceNumber string
+}
+
+// NewErrFileDuplicateTraceNumber creates a new error of the ErrFileDuplicateTraceNumber type
+func NewErrFileDuplicateTraceNumber(traceNumber string) ErrFileDuplicateTraceNumber {
+	return ErrFileDuplicateTraceNumber{
+		Message:     fmt.Sprintf("TraceNumber %s is used by more than one entry", traceNumber),
+		TraceNumber: traceNumber,
+	}
+}
+
+func (e ErrFileDuplicateTraceNumber) Error() string {
+	return e.Message
+}
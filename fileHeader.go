@@ -18,6 +18,7 @@
 package ach
 
 import (
+	"errors"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -100,6 +101,8 @@ type FileHeader struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 
 	validateOpts *ValidateOpts
 }
@@ -351,3 +354,24 @@ func (fh *FileHeader) ImmediateOriginNameField() string {
 func (fh *FileHeader) ReferenceCodeField() string {
 	return fh.alphaField(fh.ReferenceCode, 8)
 }
+
+// ErrFileIDModifierExhausted is the error given when no FileIDModifier follows Z through 9
+var ErrFileIDModifierExhausted = errors.New("no FileIDModifier after 9")
+
+// fileIDModifiers are the FileIDModifiers in the order they're used on a day
+const fileIDModifiers = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// NextFileIDModifier returns the FileIDModifier after m (A through Z, then 0 through 9) for the next file
+// from the same origin to the same destination on a day. A is returned when m isn't a FileIDModifier.
+func NextFileIDModifier(m string) (string, error) {
+	for i := 0; i < len(fileIDModifiers); i++ {
+		if fileIDModifiers[i:i+1] != m {
+			continue
+		}
+		if i+1 == len(fileIDModifiers) {
+			return "", ErrFileIDModifierExhausted
+		}
+		return fileIDModifiers[i+1 : i+2], nil
+	}
+	return fileIDModifiers[:1], nil
+}
@@ -744,3 +744,15 @@ func TestFileHeader__SetValidation(t *testing.T) {
 	fh.SetValidation(nil)
 	fh.SetValidation(&ValidateOpts{})
 }
+
+func TestFileHeader__NextFileIDModifier(t *testing.T) {
+	cases := map[string]string{"A": "B", "Z": "0", "8": "9", "": "A", "a": "A"}
+	for m, expected := range cases {
+		if next, err := NextFileIDModifier(m); err != nil || next != expected {
+			t.Errorf("%q: got %q (%v)", m, next, err)
+		}
+	}
+	if _, err := NextFileIDModifier("9"); err != ErrFileIDModifierExhausted {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
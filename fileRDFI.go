@@ -0,0 +1,77 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"context"
+	"errors"
+
+	"github.com/moov-io/ach/routing"
+	"github.com/moov-io/base"
+)
+
+// ValidateRDFIs checks the RDFI of every entry in f is a participant of dir which receives the
+// StandardEntryClassCode of the entry's batch. Every rejected entry is returned as a BatchError
+// wrapping routing.ErrNotParticipant or routing.ErrSECCodeNotReceived. Errors from dir other than
+// routing.ErrNotParticipant are returned as soon as they happen.
+//
+// Set ValidateOpts.RDFIDirectory to have ValidateWith call ValidateRDFIs.
+func (f *File) ValidateRDFIs(ctx context.Context, dir routing.Directory) error {
+	participants := make(map[string]*routing.Participant)
+	var errs base.ErrorList
+
+	check := func(batchError func(string, error, ...interface{}) error, sec, rdfi string) error {
+		p, seen := participants[rdfi]
+		if !seen {
+			// invalid routing numbers can't be participants
+			if n, err := routing.Parse(rdfi); err == nil {
+				p, err = dir.Lookup(ctx, n)
+				if err != nil && !errors.Is(err, routing.ErrNotParticipant) {
+					return err
+				}
+			}
+			participants[rdfi] = p
+		}
+		if p == nil {
+			errs.Add(batchError("RDFIIdentification", routing.ErrNotParticipant, rdfi))
+			return nil
+		}
+		if err := p.Receives(sec); err != nil {
+			errs.Add(batchError("RDFIIdentification", err, rdfi))
+		}
+		return nil
+	}
+	err := f.Visit(FileVisitor{
+		Entry: func(b Batcher, e *EntryDetail) error {
+			return check(b.Error, b.GetHeader().StandardEntryClassCode, e.RDFIIdentificationField()+e.CheckDigit)
+		},
+		ADVEntry: func(b Batcher, e *ADVEntryDetail) error {
+			return check(b.Error, b.GetHeader().StandardEntryClassCode, e.RDFIIdentificationField()+e.CheckDigit)
+		},
+		IATEntry: func(b *IATBatch, e *IATEntryDetail) error {
+			return check(b.Error, IAT, e.RDFIIdentificationField()+e.CheckDigit)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if errs.Empty() {
+		return nil
+	}
+	return errs
+}
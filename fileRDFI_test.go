@@ -0,0 +1,99 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/moov-io/ach/routing"
+	"github.com/moov-io/base"
+)
+
+type errDirectory struct{}
+
+func (errDirectory) Lookup(_ context.Context, _ routing.Number) (*routing.Participant, error) {
+	return nil, errors.New("directory unavailable")
+}
+
+func mockRDFIFile(t *testing.T) *File {
+	t.Helper()
+
+	ppd, ccd := mockPPDEntryDetail(), mockCCDEntryDetail()
+	ppd.SetRDFI("231380104")
+	ccd.SetRDFI("231380104")
+	file, err := NewFileBuilder().
+		WithHeader(mockFileHeader()).
+		AddPPDBatch(mockBatchPPDHeader()).AddEntry(ppd).
+		AddCCDBatch(mockBatchCCDHeader()).AddEntry(ccd).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestFile__ValidateRDFIs(t *testing.T) {
+	ctx := context.Background()
+	file := mockRDFIFile(t)
+
+	dir := routing.StaticDirectory{
+		"231380104": {RoutingNumber: "231380104"},
+	}
+	if err := file.ValidateRDFIs(ctx, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	// the participant doesn't receive CCD entries
+	dir["231380104"] = routing.Participant{RoutingNumber: "231380104", SECCodes: []string{PPD}}
+	err := file.ValidateRDFIs(ctx, dir)
+	errs, ok := err.(base.ErrorList)
+	if !ok || len(errs) != 1 || !base.Has(errs, routing.ErrSECCodeNotReceived) {
+		t.Fatalf("%T: %v", err, err)
+	}
+	if be, ok := errs[0].(*BatchError); !ok || be.BatchType != CCD || be.FieldName != "RDFIIdentification" {
+		t.Errorf("unexpected error: %#v", errs[0])
+	}
+
+	// every entry for a non-participant is rejected
+	err = file.ValidateRDFIs(ctx, routing.StaticDirectory{})
+	if errs, ok := err.(base.ErrorList); !ok || len(errs) != 2 || !base.Has(errs, routing.ErrNotParticipant) {
+		t.Errorf("%T: %v", err, err)
+	}
+
+	if err := file.ValidateRDFIs(ctx, errDirectory{}); err == nil || err.Error() != "directory unavailable" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFile__ValidateOptsRDFIDirectory(t *testing.T) {
+	file := mockRDFIFile(t)
+	if err := file.ValidateWith(&ValidateOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.ValidateWith(&ValidateOpts{RDFIDirectory: routing.StaticDirectory{}}); !base.Has(err, routing.ErrNotParticipant) {
+		t.Errorf("%T: %v", err, err)
+	}
+
+	// ADV entries are checked too
+	adv := mockFileADV()
+	if err := adv.ValidateWith(&ValidateOpts{RDFIDirectory: routing.StaticDirectory{}}); !base.Has(err, routing.ErrNotParticipant) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
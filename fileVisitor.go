@@ -0,0 +1,106 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+// FileVisitor has a func for each kind of entry in a File, any of which can be nil.
+// File.Visit calls them in file order so consumers don't need their own loops over
+// Batches and IATBatches.
+type FileVisitor struct {
+	// Entry is called with each EntryDetail of File.Batches
+	Entry func(b Batcher, e *EntryDetail) error
+	// ADVEntry is called with each ADVEntryDetail of File.Batches
+	ADVEntry func(b Batcher, e *ADVEntryDetail) error
+	// IATEntry is called with each IATEntryDetail of File.IATBatches
+	IATEntry func(b *IATBatch, e *IATEntryDetail) error
+}
+
+// Visit calls v's funcs with every entry of f's batches and then its IAT batches, stopping
+// at and returning the first error one returns.
+func (f *File) Visit(v FileVisitor) error {
+	err := f.ForEachBatch(func(b Batcher) error {
+		if v.Entry != nil {
+			for _, e := range b.GetEntries() {
+				if err := v.Entry(b, e); err != nil {
+					return err
+				}
+			}
+		}
+		if v.ADVEntry != nil {
+			for _, e := range b.GetADVEntries() {
+				if err := v.ADVEntry(b, e); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil || v.IATEntry == nil {
+		return err
+	}
+	return f.ForEachIATBatch(func(b *IATBatch) error {
+		for _, e := range b.GetEntries() {
+			if err := v.IATEntry(b, e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ForEachBatch calls fn with each batch in f.Batches, stopping at and returning the first error fn returns.
+// IAT batches are visited with ForEachIATBatch.
+func (f *File) ForEachBatch(fn func(b Batcher) error) error {
+	for _, b := range f.Batches {
+		if b == nil {
+			continue
+		}
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForEachIATBatch calls fn with each batch in f.IATBatches, stopping at and returning the first error fn returns.
+func (f *File) ForEachIATBatch(fn func(b *IATBatch) error) error {
+	for i := range f.IATBatches {
+		if err := fn(&f.IATBatches[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForEachEntry calls fn with each EntryDetail in f.Batches and the batch it's in, stopping at and
+// returning the first error fn returns. ADV and IAT entries are visited with ForEachADVEntry and
+// ForEachIATEntry, or all of them at once with Visit.
+func (f *File) ForEachEntry(fn func(b Batcher, e *EntryDetail) error) error {
+	return f.Visit(FileVisitor{Entry: fn})
+}
+
+// ForEachADVEntry calls fn with each ADVEntryDetail in f.Batches and the batch it's in, stopping at
+// and returning the first error fn returns.
+func (f *File) ForEachADVEntry(fn func(b Batcher, e *ADVEntryDetail) error) error {
+	return f.Visit(FileVisitor{ADVEntry: fn})
+}
+
+// ForEachIATEntry calls fn with each IATEntryDetail in f.IATBatches and the batch it's in, stopping
+// at and returning the first error fn returns.
+func (f *File) ForEachIATEntry(fn func(b *IATBatch, e *IATEntryDetail) error) error {
+	return f.Visit(FileVisitor{IATEntry: fn})
+}
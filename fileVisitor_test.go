@@ -0,0 +1,134 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// mixedTestFile returns a File with PPD, ADV and IAT batches
+func mixedTestFile(t *testing.T) *File {
+	t.Helper()
+
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	adv, err := readACHFilepath(filepath.Join("test", "testdata", "flattenADVBatchesMultipleBatchHeaders.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	iat, err := readACHFilepath(filepath.Join("test", "testdata", "iat-mixedDebitCredit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Batches = append(file.Batches, adv.Batches...)
+	file.IATBatches = append(file.IATBatches, iat.IATBatches...)
+	return file
+}
+
+func TestFile__Visit(t *testing.T) {
+	file := mixedTestFile(t)
+
+	var entries, advEntries, iatEntries int
+	for _, b := range file.Batches {
+		entries += len(b.GetEntries())
+		advEntries += len(b.GetADVEntries())
+	}
+	for _, b := range file.IATBatches {
+		iatEntries += len(b.GetEntries())
+	}
+	if entries == 0 || advEntries == 0 || iatEntries == 0 {
+		t.Fatalf("entries=%d advEntries=%d iatEntries=%d", entries, advEntries, iatEntries)
+	}
+
+	var order []string
+	var visited [3]int
+	err := file.Visit(FileVisitor{
+		Entry: func(b Batcher, e *EntryDetail) error {
+			if b.GetHeader().StandardEntryClassCode != PPD {
+				t.Errorf("unexpected batch: %s", b.GetHeader().StandardEntryClassCode)
+			}
+			visited[0]++
+			order = append(order, "entry")
+			return nil
+		},
+		ADVEntry: func(b Batcher, e *ADVEntryDetail) error {
+			visited[1]++
+			order = append(order, "adv")
+			return nil
+		},
+		IATEntry: func(b *IATBatch, e *IATEntryDetail) error {
+			if b.GetHeader() == nil {
+				t.Error("missing IAT batch header")
+			}
+			visited[2]++
+			order = append(order, "iat")
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if visited != [3]int{entries, advEntries, iatEntries} {
+		t.Errorf("visited=%v", visited)
+	}
+	if order[0] != "entry" || order[len(order)-1] != "iat" {
+		t.Errorf("unexpected order: %v", order)
+	}
+
+	// a visitor without funcs does nothing
+	if err := file.Visit(FileVisitor{}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFile__ForEach(t *testing.T) {
+	file := mixedTestFile(t)
+
+	var batches, iatBatches, entries, advEntries, iatEntries int
+	file.ForEachBatch(func(Batcher) error { batches++; return nil })
+	file.ForEachIATBatch(func(*IATBatch) error { iatBatches++; return nil })
+	file.ForEachEntry(func(Batcher, *EntryDetail) error { entries++; return nil })
+	file.ForEachADVEntry(func(Batcher, *ADVEntryDetail) error { advEntries++; return nil })
+	file.ForEachIATEntry(func(*IATBatch, *IATEntryDetail) error { iatEntries++; return nil })
+
+	if batches != len(file.Batches) || iatBatches != len(file.IATBatches) {
+		t.Errorf("batches=%d iatBatches=%d", batches, iatBatches)
+	}
+	if entries == 0 || advEntries == 0 || iatEntries == 0 {
+		t.Errorf("entries=%d advEntries=%d iatEntries=%d", entries, advEntries, iatEntries)
+	}
+
+	// the first error stops visiting
+	stop := errors.New("stop")
+	calls := 0
+	err := file.ForEachEntry(func(Batcher, *EntryDetail) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("calls=%d error=%v", calls, err)
+	}
+	calls = 0
+	if err := file.ForEachIATBatch(func(*IATBatch) error { calls++; return stop }); err != stop || calls != 1 {
+		t.Errorf("calls=%d error=%v", calls, err)
+	}
+}
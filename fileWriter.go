@@ -0,0 +1,148 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrFileWriterClosed is returned when writing to a FileWriter after Close
+var ErrFileWriterClosed = errors.New("file writer is closed")
+
+// FileWriter writes a NACHA file one batch at a time, so files too large to hold in memory can be
+// generated. Each batch is built and written when it's added, its BatchNumber is set in order and
+// the totals of the FileControl are kept as batches are written. Close writes the FileControl.
+//
+// Entries without a TraceNumber are numbered in sequence across the file. ADV batches, whose files
+// have their own FileControl, aren't supported.
+type FileWriter struct {
+	w      *Writer
+	header FileHeader
+
+	traceNumbers TraceNumberGenerator
+	control      FileControl
+	records      int // written so far, for the BlockCount
+	closed       bool
+}
+
+// NewFileWriter validates header, writes it to w as customized by opts and returns a FileWriter
+// to write the file's batches.
+func NewFileWriter(w io.Writer, header FileHeader, opts WriterOpts) (*FileWriter, error) {
+	if err := header.Validate(); err != nil {
+		return nil, err
+	}
+	fw := &FileWriter{
+		w:            NewWriterWithOpts(w, opts),
+		header:       header,
+		traceNumbers: NewTraceNumberGenerator(nil),
+		control:      NewFileControl(),
+	}
+	if err := fw.w.writeFileHeader(header); err != nil {
+		return nil, err
+	}
+	return fw, nil
+}
+
+// WriteBatch builds batch, with the next BatchNumber, and writes it. The batch can be discarded afterwards.
+func (fw *FileWriter) WriteBatch(batch Batcher) error {
+	if fw.closed {
+		return ErrFileWriterClosed
+	}
+	if batch.GetHeader().StandardEntryClassCode == ADV {
+		return batch.Error("StandardEntryClassCode", ErrSECCode, ADV)
+	}
+	batch.GetHeader().BatchNumber = fw.control.BatchCount + 1
+	setTraceNumberGenerator(batch, fw.traceNumbers)
+	err := batch.Create()
+	setTraceNumberGenerator(batch, nil)
+	if err != nil {
+		return err
+	}
+	if err := fw.w.writeBatcher(batch, false); err != nil {
+		return err
+	}
+	fw.add(batch.GetControl())
+	return nil
+}
+
+// WriteIATBatch builds iatBatch, with the next BatchNumber, and writes it. The batch can be discarded afterwards.
+func (fw *FileWriter) WriteIATBatch(iatBatch *IATBatch) error {
+	if fw.closed {
+		return ErrFileWriterClosed
+	}
+	iatBatch.GetHeader().BatchNumber = fw.control.BatchCount + 1
+	iatBatch.SetTraceNumberGenerator(fw.traceNumbers)
+	err := iatBatch.Create()
+	iatBatch.SetTraceNumberGenerator(nil)
+	if err != nil {
+		return err
+	}
+	if err := fw.w.writeIATBatcher(iatBatch); err != nil {
+		return err
+	}
+	fw.add(iatBatch.GetControl())
+	return nil
+}
+
+// add sums the totals of a written batch into the FileControl
+func (fw *FileWriter) add(bc *BatchControl) {
+	fw.control.BatchCount++
+	fw.control.EntryAddendaCount += bc.EntryAddendaCount
+	fw.control.EntryHash += bc.EntryHash
+	fw.control.TotalDebitEntryDollarAmountInFile += bc.TotalDebitEntryDollarAmount
+	fw.control.TotalCreditEntryDollarAmountInFile += bc.TotalCreditEntryDollarAmount
+	// the batch header, control, entries and addenda
+	fw.records += 2 + bc.EntryAddendaCount
+}
+
+// Control returns the FileControl of the batches written so far
+func (fw *FileWriter) Control() FileControl {
+	fc := fw.control
+	// add 2 for the FileHeader and FileControl
+	records := fw.records + 2
+	fc.BlockCount = records / 10
+	if records%10 != 0 {
+		fc.BlockCount++
+	}
+	return fc
+}
+
+// Close writes the FileControl and block padding, and flushes the file to the underlying io.Writer.
+// It doesn't close the io.Writer.
+func (fw *FileWriter) Close() error {
+	if fw.closed {
+		return ErrFileWriterClosed
+	}
+	fw.closed = true
+	if fw.control.BatchCount == 0 {
+		return ErrFileNoBatches
+	}
+	fc := fw.Control()
+	if err := fc.Validate(); err != nil {
+		return err
+	}
+	if err := fw.w.writeLine(fc.String()); err != nil {
+		return err
+	}
+	fw.w.lineNum++
+	if err := fw.w.writeBlockPadding(); err != nil {
+		return err
+	}
+	return fw.w.Flush()
+}
@@ -0,0 +1,94 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/moov-io/base"
+)
+
+func TestFileWriter(t *testing.T) {
+	opts := WriterOpts{LineEnding: "\r\n"}
+
+	// the same file built in memory
+	file := NewFile().SetHeader(mockFileHeader())
+	for i := 0; i < 3; i++ {
+		file.AddBatch(mockBatchPPD())
+	}
+	iatBatch := mockIATBatch(t)
+	file.AddIATBatch(iatBatch)
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+	var expected bytes.Buffer
+	if err := NewWriterWithOpts(&expected, opts).Write(file); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	fw, err := NewFileWriter(&buf, mockFileHeader(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := fw.WriteBatch(mockBatchPPD()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	iatBatch = mockIATBatch(t)
+	if err := fw.WriteIATBatch(&iatBatch); err != nil {
+		t.Fatal(err)
+	}
+	if fc := fw.Control(); fc != file.Control {
+		t.Errorf("FileControl:\n%#v\n%#v", fc, file.Control)
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != expected.String() {
+		t.Errorf("files differ:\n%s\n%s", buf.String(), expected.String())
+	}
+
+	if err := fw.WriteBatch(mockBatchPPD()); err != ErrFileWriterClosed {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFileWriter__errors(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := NewFileWriter(&buf, FileHeader{}, WriterOpts{}); err == nil {
+		t.Error("expected error")
+	}
+
+	fw, err := NewFileWriter(&buf, mockFileHeader(), WriterOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.WriteBatch(mockBatchADV()); !base.Match(err, ErrSECCode) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := fw.WriteBatch(NewBatchPPD(mockBatchPPDHeader())); !base.Match(err, ErrBatchNoEntries) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := fw.Close(); !errors.Is(err, ErrFileNoBatches) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
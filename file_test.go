@@ -18,6 +18,8 @@
 package ach
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -634,6 +636,21 @@ func TestFile__datetimeParse(t *testing.T) {
 	}
 }
 
+func TestFile__jsonEffectiveEntryDate(t *testing.T) {
+	cases := map[string]string{
+		"2019-09-20":                "190920",
+		"190920":                    "190920",
+		"2019-09-20T23:30:00-07:00": "190920", // the client's day, not UTC's
+		"2019-09-21T06:30:00.000Z":  "190921",
+		"":                          "",
+	}
+	for v, expected := range cases {
+		if got := jsonEffectiveEntryDate(v); got != expected {
+			t.Errorf("%q: got %q", v, got)
+		}
+	}
+}
+
 func TestFileADV__Success(t *testing.T) {
 	fh := mockFileHeader()
 	bh := mockBatchADVHeader()
@@ -1895,3 +1912,189 @@ func TestFile__SetValidation(t *testing.T) {
 	file.SetValidation(nil)
 	file.SetValidation(&ValidateOpts{})
 }
+
+func TestFile__ValidateWithContext(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := file.ValidateContext(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := file.ValidateContext(ctx); err != context.Canceled {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := file.ValidateWithContext(ctx, &ValidateOpts{}); err != context.Canceled {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFile__FileFromJSONReader(t *testing.T) {
+	paths := []string{
+		"adv-valid.json",
+		"iat-debit.json",
+		"ppd-mixedDebitCredit-valid.json",
+		"ppd-no-control-blobs-valid.json",
+		"ppd-valid.json",
+		"rfc3339.json",
+	}
+	for _, name := range paths {
+		bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := FileFromJSON(bs)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		file, err := FileFromJSONReader(bytes.NewReader(bs))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		want, _ := json.Marshal(expected)
+		got, _ := json.Marshal(file)
+		if !bytes.Equal(want, got) {
+			t.Errorf("%s: files differ\n want: %s\n  got: %s", name, want, got)
+		}
+	}
+
+	// errors
+	for _, name := range []string{"ppd-invalid.json", "ppd-noBatches.json"} {
+		bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := FileFromJSON(bs); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+		if _, err := FileFromJSONReader(bytes.NewReader(bs)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	for _, body := range []string{"", "[]", `{"batches": {}}`, `{"fileHeader": "x"}`, `{"id": "foo"`} {
+		if _, err := FileFromJSONReader(strings.NewReader(body)); err == nil {
+			t.Errorf("expected error for %q", body)
+		}
+	}
+}
+
+func BenchmarkFileFromJSON(b *testing.B) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "ppd-mixedDebitCredit-valid.json"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := FileFromJSON(bs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFileFromJSONReader(b *testing.B) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "ppd-mixedDebitCredit-valid.json"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := FileFromJSONReader(bytes.NewReader(bs)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// mockFileBatches returns a built file with n PPD batches
+func mockFileBatches(t testing.TB, n int) *File {
+	file := NewFile().SetHeader(mockFileHeader())
+	for i := 0; i < n; i++ {
+		batch := NewBatchPPD(mockBatchPPDHeader())
+		for j := 0; j < 10; j++ {
+			entry := mockPPDEntryDetail()
+			entry.SetTraceNumber(batch.Header.ODFIIdentification, j+1)
+			batch.AddEntry(entry)
+		}
+		if err := batch.Create(); err != nil {
+			t.Fatal(err)
+		}
+		file.AddBatch(batch)
+	}
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestFile__ValidateConcurrency(t *testing.T) {
+	file := mockFileBatches(t, 50)
+	opts := &ValidateOpts{Concurrency: 8}
+	if err := file.ValidateWith(opts); err != nil {
+		t.Fatal(err)
+	}
+
+	// the first invalid batch's error is returned
+	file.Batches[30].GetControl().EntryHash++
+	file.Batches[10].GetEntries()[0].Amount++
+	expected := file.ValidateWith(nil)
+	if expected == nil {
+		t.Fatal("expected error")
+	}
+	for i := 0; i < 10; i++ {
+		if err := file.ValidateWith(opts); err == nil || err.Error() != expected.Error() {
+			t.Fatalf("got %v, expected %v", err, expected)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := file.ValidateWithContext(ctx, opts); err != context.Canceled {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func BenchmarkFile__ValidateConcurrency(b *testing.B) {
+	file := mockFileBatches(b, 5000)
+	for _, workers := range []int{1, 2, 4, 8} {
+		opts := &ValidateOpts{Concurrency: workers}
+		b.Run(strconv.Itoa(workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := file.ValidateWith(opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFile__Create(b *testing.B) {
+	file := mockFileBatches(b, 1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, batch := range file.Batches {
+			if err := batch.Create(); err != nil {
+				b.Fatal(err)
+			}
+		}
+		if err := file.Create(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFile__Validate(b *testing.B) {
+	file := mockFileBatches(b, 1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := file.Validate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
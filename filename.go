@@ -0,0 +1,179 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrFilenameTemplate is the error given when a filename template can not be parsed
+	ErrFilenameTemplate = errors.New("invalid filename template")
+)
+
+// FilenameTemplate renders the names of ACH files from a pattern of {variable} placeholders, such as
+// "{origin}_{date}_{cutoff}_{modifier}.ach", as ODFI's often require their own naming convention.
+//
+// The following variables are supported:
+//
+//	{origin}       FileHeader ImmediateOrigin without padding
+//	{destination}  FileHeader ImmediateDestination without padding
+//	{modifier}     FileHeader FileIDModifier
+//	{id}           File ID
+//	{date}         Date of the render in YYYYMMDD format
+//	{time}         Time of the render in HHMM format
+//	{cutoff}       Cutoff in HHMM format
+//	{counter}      Number of files rendered for {date}, starting at 1. {counter:N} pads the value to N digits.
+type FilenameTemplate struct {
+	// Location is the time zone {date}, {time} and {cutoff} are rendered in. UTC is used when nil.
+	Location *time.Location
+
+	// Cutoff is the time after midnight of the processing window files are destined for.
+	// For example 16*time.Hour + 15*time.Minute renders {cutoff} as 1615.
+	Cutoff time.Duration
+
+	pattern string
+	parts   []filenamePart
+
+	mu sync.Mutex
+	// counters are the files rendered for each date (YYYYMMDD), earlier dates are dropped as later ones are rendered
+	counters map[string]int
+}
+
+// filenamePart is either literal text or a variable (with an optional width) of a FilenameTemplate
+type filenamePart struct {
+	text     string
+	variable string
+	width    int
+}
+
+// NewFilenameTemplate parses pattern and returns a FilenameTemplate. An error is returned if pattern
+// contains unknown variables or unbalanced braces.
+func NewFilenameTemplate(pattern string) (*FilenameTemplate, error) {
+	tmpl := &FilenameTemplate{
+		pattern:  pattern,
+		counters: make(map[string]int),
+	}
+	for remaining := pattern; remaining != ""; {
+		open := strings.IndexAny(remaining, "{}")
+		if open < 0 {
+			tmpl.parts = append(tmpl.parts, filenamePart{text: remaining})
+			break
+		}
+		if remaining[open] == '}' {
+			return nil, fmt.Errorf("%w: unexpected } in %q", ErrFilenameTemplate, pattern)
+		}
+		if open > 0 {
+			tmpl.parts = append(tmpl.parts, filenamePart{text: remaining[:open]})
+		}
+		end := strings.IndexByte(remaining[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unclosed { in %q", ErrFilenameTemplate, pattern)
+		}
+		part, err := parseFilenameVariable(remaining[open+1 : open+end])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrFilenameTemplate, err)
+		}
+		tmpl.parts = append(tmpl.parts, part)
+		remaining = remaining[open+end+1:]
+	}
+	return tmpl, nil
+}
+
+func parseFilenameVariable(v string) (filenamePart, error) {
+	name, width := v, ""
+	if idx := strings.IndexByte(v, ':'); idx >= 0 {
+		name, width = v[:idx], v[idx+1:]
+	}
+	switch name {
+	case "origin", "destination", "modifier", "id", "date", "time", "cutoff":
+		if width != "" {
+			return filenamePart{}, fmt.Errorf("{%s} does not accept a width", name)
+		}
+		return filenamePart{variable: name}, nil
+	case "counter":
+		part := filenamePart{variable: name}
+		if width != "" {
+			n, err := strconv.Atoi(width)
+			if err != nil || n <= 0 {
+				return filenamePart{}, fmt.Errorf("invalid {counter} width %q", width)
+			}
+			part.width = n
+		}
+		return part, nil
+	}
+	return filenamePart{}, fmt.Errorf("unknown variable {%s}", v)
+}
+
+// String returns the pattern FilenameTemplate was created from
+func (tmpl *FilenameTemplate) String() string {
+	return tmpl.pattern
+}
+
+// Render returns the filename for file at the given time. Each call increments {counter} for the rendered date.
+func (tmpl *FilenameTemplate) Render(file *File, when time.Time) string {
+	if tmpl.Location != nil {
+		when = when.In(tmpl.Location)
+	} else {
+		when = when.UTC()
+	}
+	date := when.Format("20060102")
+
+	tmpl.mu.Lock()
+	if _, ok := tmpl.counters[date]; !ok {
+		// only the counters of the current date are needed again, so earlier ones don't pile up
+		for d := range tmpl.counters {
+			if d < date {
+				delete(tmpl.counters, d)
+			}
+		}
+	}
+	tmpl.counters[date]++
+	counter := tmpl.counters[date]
+	tmpl.mu.Unlock()
+
+	var buf strings.Builder
+	for _, part := range tmpl.parts {
+		switch part.variable {
+		case "":
+			buf.WriteString(part.text)
+		case "origin":
+			buf.WriteString(strings.TrimSpace(file.Header.ImmediateOrigin))
+		case "destination":
+			buf.WriteString(strings.TrimSpace(file.Header.ImmediateDestination))
+		case "modifier":
+			buf.WriteString(file.Header.FileIDModifier)
+		case "id":
+			buf.WriteString(file.ID)
+		case "date":
+			buf.WriteString(date)
+		case "time":
+			buf.WriteString(when.Format("1504"))
+		case "cutoff":
+			buf.WriteString(fmt.Sprintf("%02d%02d", int(tmpl.Cutoff.Hours()), int(tmpl.Cutoff.Minutes())%60))
+		case "counter":
+			buf.WriteString(fmt.Sprintf("%0*d", part.width, counter))
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,96 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moov-io/base"
+)
+
+func TestFilenameTemplate(t *testing.T) {
+	tmpl, err := NewFilenameTemplate("{origin}_{date}_{cutoff}_{modifier}.ach")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl.Cutoff = 16*time.Hour + 15*time.Minute
+
+	file := mockFilePPD()
+	when := time.Date(2019, time.June, 25, 10, 30, 0, 0, time.UTC)
+	if name := tmpl.Render(file, when); name != "121042882_20190625_1615_A.ach" {
+		t.Errorf("got %s", name)
+	}
+	if tmpl.String() != "{origin}_{date}_{cutoff}_{modifier}.ach" {
+		t.Errorf("got %s", tmpl)
+	}
+}
+
+func TestFilenameTemplate__Counter(t *testing.T) {
+	tmpl, err := NewFilenameTemplate("ACH-{destination}-{counter:3}-{counter}.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := mockFilePPD()
+	when := time.Date(2019, time.June, 25, 10, 30, 0, 0, time.UTC)
+
+	if name := tmpl.Render(file, when); name != "ACH-231380104-001-1.txt" {
+		t.Errorf("got %s", name)
+	}
+	if name := tmpl.Render(file, when); name != "ACH-231380104-002-2.txt" {
+		t.Errorf("got %s", name)
+	}
+	// counters reset each day
+	if name := tmpl.Render(file, when.AddDate(0, 0, 1)); name != "ACH-231380104-001-1.txt" {
+		t.Errorf("got %s", name)
+	}
+	// and only the current day's counter is kept
+	if len(tmpl.counters) != 1 || tmpl.counters["20190626"] != 1 {
+		t.Errorf("unexpected counters: %v", tmpl.counters)
+	}
+}
+
+func TestFilenameTemplate__Location(t *testing.T) {
+	tmpl, err := NewFilenameTemplate("{id}-{date}-{time}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl.Location = time.FixedZone("EST", -5*60*60)
+
+	file := mockFilePPD()
+	when := time.Date(2019, time.June, 26, 3, 0, 0, 0, time.UTC)
+	if name := tmpl.Render(file, when); name != "fileId-20190625-2200" {
+		t.Errorf("got %s", name)
+	}
+}
+
+func TestFilenameTemplate__Invalid(t *testing.T) {
+	patterns := []string{
+		"{origin",
+		"origin}",
+		"{unknown}.ach",
+		"{date:3}",
+		"{counter:0}",
+		"{counter:a}",
+	}
+	for i := range patterns {
+		if _, err := NewFilenameTemplate(patterns[i]); !base.Match(err, ErrFilenameTemplate) {
+			t.Errorf("%s: %v", patterns[i], err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Fingerprint returns a hex encoded SHA-256 digest over the ImmediateOrigin, FileCreationDate,
+// FileCreationTime, entry hash and debit and credit totals of the File. Two submissions of the same
+// entries from the same origin share a Fingerprint even when their IDs or batch numbering differ,
+// which can be used to catch duplicate files before they are sent to an ODFI.
+//
+// The entry hash and totals are computed from the File's entries rather than read from its FileControl.
+func (f *File) Fingerprint() string {
+	var sum Checksum
+	for _, batch := range f.Batches {
+		sum.add(batchChecksum(batch))
+	}
+	for i := range f.IATBatches {
+		sum.add(iatBatchChecksum(&f.IATBatches[i]))
+	}
+	sum.EntryHash = f.truncateEntryHash(sum.EntryHash)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%s|%d|%d|%d",
+		f.Header.ImmediateOriginField(), f.Header.FileCreationDateField(), f.Header.FileCreationTimeField(),
+		sum.EntryHash, sum.TotalDebit, sum.TotalCredit)
+	return hex.EncodeToString(h.Sum(nil))
+}
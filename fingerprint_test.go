@@ -0,0 +1,49 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"testing"
+)
+
+func TestFile__Fingerprint(t *testing.T) {
+	file := mockFilePPD()
+	fingerprint := file.Fingerprint()
+	if len(fingerprint) != 64 {
+		t.Fatalf("unexpected fingerprint: %q", fingerprint)
+	}
+
+	// the ID and control records don't change the fingerprint
+	other := mockFilePPD()
+	other.ID = "other"
+	other.Control = NewFileControl()
+	if v := other.Fingerprint(); v != fingerprint {
+		t.Errorf("fingerprint changed: %s", v)
+	}
+
+	// entries and the header do
+	other.Batches[0].GetEntries()[0].Amount += 1
+	if v := other.Fingerprint(); v == fingerprint {
+		t.Error("expected a different fingerprint after changing an amount")
+	}
+	other = mockFilePPD()
+	other.Header.FileCreationTime = "0101"
+	if v := other.Fingerprint(); v == fingerprint {
+		t.Error("expected a different fingerprint after changing FileCreationTime")
+	}
+}
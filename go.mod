@@ -3,9 +3,15 @@ module github.com/moov-io/ach
 require (
 	github.com/Pallinder/go-randomdata v1.2.0
 	github.com/go-kit/kit v0.9.0
+	github.com/go-logr/logr v1.2.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/golang/protobuf v1.3.2
 	github.com/gorilla/mux v1.7.4
 	github.com/moov-io/base v0.11.0
 	github.com/prometheus/client_golang v1.4.1
+	go.opentelemetry.io/otel v1.4.1
+	go.opentelemetry.io/otel/trace v1.4.1
+	gopkg.in/yaml.v2 v2.4.0
 	golang.org/x/crypto v0.0.0-20200117160349-530e935923ad // indirect
 )
 
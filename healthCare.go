@@ -0,0 +1,126 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// HealthCareClaimPayment is the CompanyEntryDescription NACHA requires of CCD health care EFT
+// entries, which pay a health care provider's claims
+const HealthCareClaimPayment = "HCCLAIMPMT"
+
+var (
+	// ErrHealthCareEntryDescription is the error given when a CCD entry carries a reassociation trace
+	// without its batch's CompanyEntryDescription being HCCLAIMPMT
+	ErrHealthCareEntryDescription = errors.New("health care claim payments must have a company entry description of " + HealthCareClaimPayment)
+	// ErrHealthCareReassociation is the error given when a health care claim payment doesn't have a single
+	// Addenda05 with a TRN reassociation trace
+	ErrHealthCareReassociation = errors.New("health care claim payments require a single addenda with a TRN reassociation trace")
+	// ErrHealthCareCredit is the error given when a health care claim payment isn't a credit
+	ErrHealthCareCredit = errors.New("health care claim payments must be credits")
+)
+
+// reassociationTracePrefix starts the Addenda05 of a health care claim payment: a TRN segment with a
+// trace type of 1 (current transaction trace numbers)
+const reassociationTracePrefix = "TRN*1*"
+
+// IsHealthCareClaimPayment returns true when the batch's CompanyEntryDescription is HCCLAIMPMT,
+// compared with NormalizeCompanyEntryDescription
+func (bh *BatchHeader) IsHealthCareClaimPayment() bool {
+	return NormalizeCompanyEntryDescription(bh.CompanyEntryDescription) == HealthCareClaimPayment
+}
+
+// FormatReassociationTrace returns the TRN segment of an X12 835 health care claim payment/advice written
+// for the Addenda05 of its CCD+ payment, e.g. "TRN*1*12345*1512345678\". traceNumber is the check or
+// EFT trace number of the 835 (TRN02), payerTIN the payer's nine digit federal tax identification number
+// (TRN03 is a 1 followed by it) and supplementalCode the optional originating company supplemental code
+// identifying a subdivision of the payer (TRN04).
+func FormatReassociationTrace(traceNumber, payerTIN, supplementalCode string) (string, error) {
+	if traceNumber == "" || strings.ContainsAny(traceNumber, `*\`) {
+		return "", fieldError("TraceNumber", ErrHealthCareReassociation, traceNumber)
+	}
+	if len(payerTIN) != 9 || !isDigits(payerTIN) {
+		return "", fieldError("PayerTIN", ErrHealthCareReassociation, payerTIN)
+	}
+	if strings.ContainsAny(supplementalCode, `*\`) {
+		return "", fieldError("SupplementalCode", ErrHealthCareReassociation, supplementalCode)
+	}
+	trace := reassociationTracePrefix + traceNumber + "*1" + payerTIN
+	if supplementalCode != "" {
+		trace += "*" + supplementalCode
+	}
+	trace += `\`
+	if len(trace) > paymentRelatedInformationLength {
+		return "", fieldError("PaymentRelatedInformation", fmt.Errorf("%d characters: %w", len(trace), ErrHealthCareReassociation), trace)
+	}
+	return trace, nil
+}
+
+// SetReassociationTrace replaces the entry's Addenda05 records with a single Addenda05 of the reassociation
+// trace of the 835 it pays, see FormatReassociationTrace. The 835 itself is sent to the provider separately.
+func (ed *EntryDetail) SetReassociationTrace(traceNumber, payerTIN, supplementalCode string) error {
+	trace, err := FormatReassociationTrace(traceNumber, payerTIN, supplementalCode)
+	if err != nil {
+		return err
+	}
+	ed.SetPaymentRelatedInformation(trace)
+	return nil
+}
+
+// hasReassociationTrace returns true when the entry's first Addenda05 starts a TRN reassociation trace
+func (ed *EntryDetail) hasReassociationTrace() bool {
+	return len(ed.Addenda05) > 0 && strings.HasPrefix(ed.Addenda05[0].PaymentRelatedInformation, reassociationTracePrefix)
+}
+
+// validReassociationTrace returns true when info is a reassociation trace as written by FormatReassociationTrace
+func validReassociationTrace(info string) bool {
+	info = strings.TrimSpace(info)
+	if !strings.HasPrefix(info, reassociationTracePrefix) || !strings.HasSuffix(info, `\`) {
+		return false
+	}
+	elements := strings.Split(strings.TrimSuffix(info, `\`), "*")
+	if len(elements) < 4 || len(elements) > 5 || elements[2] == "" || strings.Contains(info[:len(info)-1], `\`) {
+		return false
+	}
+	payer := elements[3]
+	return len(payer) == 10 && payer[0] == '1' && isDigits(payer)
+}
+
+// isHealthCareClaimPayment checks the forward entries of HCCLAIMPMT batches are credits with a single Addenda05
+// of a reassociation trace, and that entries with a reassociation trace are in HCCLAIMPMT batches
+func (batch *Batch) isHealthCareClaimPayment(entry *EntryDetail) error {
+	if entry.Category != "" && entry.Category != CategoryForward {
+		return nil
+	}
+	if !batch.Header.IsHealthCareClaimPayment() {
+		if entry.hasReassociationTrace() {
+			return batch.Error("CompanyEntryDescription", ErrHealthCareEntryDescription, batch.Header.CompanyEntryDescription)
+		}
+		return nil
+	}
+	if entry.CreditOrDebit() != "C" {
+		return batch.Error("TransactionCode", ErrHealthCareCredit, entry.TransactionCode)
+	}
+	if len(entry.Addenda05) != 1 || !validReassociationTrace(entry.Addenda05[0].PaymentRelatedInformation) {
+		return batch.Error("Addenda05", ErrHealthCareReassociation, entry.TraceNumber)
+	}
+	return nil
+}
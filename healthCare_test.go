@@ -0,0 +1,110 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base"
+)
+
+// mockHealthCareBatch creates a CCD batch with a health care claim payment
+func mockHealthCareBatch(t *testing.T) *BatchCCD {
+	t.Helper()
+
+	bh := mockBatchCCDHeader()
+	bh.ServiceClassCode = CreditsOnly
+	bh.CompanyEntryDescription = HealthCareClaimPayment
+	entry := mockCCDEntryDetail()
+	entry.TransactionCode = CheckingCredit
+	if err := entry.SetReassociationTrace("12345", "512345678", ""); err != nil {
+		t.Fatal(err)
+	}
+	batch := NewBatchCCD(bh)
+	batch.AddEntry(entry)
+	return batch
+}
+
+func TestFormatReassociationTrace(t *testing.T) {
+	trace, err := FormatReassociationTrace("12345", "512345678", "")
+	if err != nil || trace != `TRN*1*12345*1512345678\` {
+		t.Errorf("got %q: %v", trace, err)
+	}
+	trace, err = FormatReassociationTrace("12345", "512345678", "DIV1")
+	if err != nil || trace != `TRN*1*12345*1512345678*DIV1\` {
+		t.Errorf("got %q: %v", trace, err)
+	}
+
+	cases := [][3]string{
+		{"", "512345678", ""},
+		{"123*45", "512345678", ""},
+		{"12345", "51234567", ""},
+		{"12345", "51234567A", ""},
+		{"12345", "512345678", `DIV\1`},
+		{strings.Repeat("1", 60), "512345678", "DIV1"},
+	}
+	for _, c := range cases {
+		if _, err := FormatReassociationTrace(c[0], c[1], c[2]); !base.Match(err, ErrHealthCareReassociation) {
+			t.Errorf("%q: %v", c, err)
+		}
+	}
+}
+
+func TestBatchCCD__HealthCareClaimPayment(t *testing.T) {
+	batch := mockHealthCareBatch(t)
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if addenda05 := batch.Entries[0].Addenda05; len(addenda05) != 1 || batch.Entries[0].AddendaRecordIndicator != 1 {
+		t.Errorf("unexpected addenda: %#v", addenda05)
+	}
+
+	// the description is compared with NormalizeCompanyEntryDescription
+	batch.Header.CompanyEntryDescription = "hcclaimpmt "
+	if err := batch.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBatchCCD__HealthCareClaimPaymentErrors(t *testing.T) {
+	batch := mockHealthCareBatch(t)
+	batch.Header.CompanyEntryDescription = "Vndr Pay"
+	if err := batch.Create(); !base.Match(err, ErrHealthCareEntryDescription) {
+		t.Errorf("%T: %v", err, err)
+	}
+
+	batch = mockHealthCareBatch(t)
+	batch.Header.ServiceClassCode = MixedDebitsAndCredits
+	batch.Entries[0].TransactionCode = CheckingDebit
+	if err := batch.Create(); !base.Match(err, ErrHealthCareCredit) {
+		t.Errorf("%T: %v", err, err)
+	}
+
+	for _, info := range []string{"", "RMR*IV*1\\", `TRN*1*12345*512345678\`, `TRN*1**1512345678\`, `TRN*1*12345*1512345678`} {
+		batch = mockHealthCareBatch(t)
+		batch.Entries[0].Addenda05[0].PaymentRelatedInformation = info
+		if info == "" {
+			batch.Entries[0].Addenda05 = nil
+			batch.Entries[0].AddendaRecordIndicator = 0
+		}
+		if err := batch.Create(); !base.Match(err, ErrHealthCareReassociation) {
+			t.Errorf("%q: %T: %v", info, err, err)
+		}
+	}
+}
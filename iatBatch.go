@@ -38,10 +38,23 @@ type IATBatch struct {
 	Entries []*IATEntryDetail `json:"IATEntryDetails,omitempty"`
 	Control *BatchControl     `json:"batchControl,omitempty"`
 
+	// Comments are free form notes kept with the batch's JSON. They're never written into a NACHA file.
+	Comments []string `json:"comments,omitempty"`
+
 	// category defines if the entry is a Forward, Return, or NOC
 	category string
 	// Converters is composed for ACH to GoLang Converters
 	converters
+
+	// traceNumbers assigns TraceNumbers to entries in build() when set
+	traceNumbers TraceNumberGenerator
+}
+
+// SetTraceNumberGenerator has Create() assign TraceNumbers to entries from gen rather than
+// numbering each batch's entries from 1. Entries which already have a TraceNumber from the
+// batch's ODFI keep it.
+func (iatBatch *IATBatch) SetTraceNumberGenerator(gen TraceNumberGenerator) {
+	iatBatch.traceNumbers = gen
 }
 
 // NewIATBatch takes a BatchHeader and returns a matching SEC code batch type that is a batcher. Returns an error if the SEC code is not supported.
@@ -168,7 +181,15 @@ func (iatBatch *IATBatch) build() error {
 
 		// Add a sequenced TraceNumber if one is not already set.
 		if currentTraceNumberODFI != batchHeaderODFI {
-			iatBatch.Entries[i].SetTraceNumber(iatBatch.Header.ODFIIdentification, seq)
+			if iatBatch.traceNumbers != nil {
+				traceNumber, err := iatBatch.traceNumbers.Next(iatBatch.Header.ODFIIdentification)
+				if err != nil {
+					return iatBatch.Error("TraceNumber", err)
+				}
+				iatBatch.Entries[i].TraceNumber = traceNumber
+			} else {
+				iatBatch.Entries[i].SetTraceNumber(iatBatch.Header.ODFIIdentification, seq)
+			}
 		}
 
 		if entry.Category != CategoryNOC {
@@ -197,6 +218,10 @@ func (iatBatch *IATBatch) build() error {
 			addenda18.EntryDetailSequenceNumber = iatBatch.parseNumField(iatBatch.Entries[i].TraceNumberField()[8:])
 			addenda18Seq++
 		}
+
+		if entry.Addenda98 == nil && entry.Addenda99 == nil {
+			entry.AddendaRecords = 7 + len(entry.Addenda17) + len(entry.Addenda18)
+		}
 	}
 
 	// build a BatchControl record
@@ -461,7 +486,7 @@ func (iatBatch *IATBatch) isAddendaSequence() error {
 		lastAddenda17Seq := -1
 		lastAddenda18Seq := -1
 
-		for _, addenda17 := range entry.Addenda17 {
+		for i, addenda17 := range entry.Addenda17 {
 			if addenda17.SequenceNumber < lastAddenda17Seq {
 				return iatBatch.Error("SequenceNumber", NewErrBatchAscending(lastAddenda17Seq, addenda17.SequenceNumber))
 			}
@@ -470,9 +495,13 @@ func (iatBatch *IATBatch) isAddendaSequence() error {
 			if !(addenda17.EntryDetailSequenceNumberField() == entry.TraceNumberField()[8:]) {
 				return iatBatch.Error("TraceNumber", NewErrBatchAddendaTraceNumber(addenda17.EntryDetailSequenceNumberField(), entryTN))
 			}
+			// Addenda17 records are consecutively numbered starting at 1
+			if addenda17.SequenceNumber != i+1 {
+				return iatBatch.Error("SequenceNumber", NewErrBatchAddendaSequence(addenda17.SequenceNumber, i+1))
+			}
 		}
 
-		for _, addenda18 := range entry.Addenda18 {
+		for i, addenda18 := range entry.Addenda18 {
 			if addenda18.SequenceNumber < lastAddenda18Seq {
 				return iatBatch.Error("SequenceNumber", NewErrBatchAscending(lastAddenda18Seq, addenda18.SequenceNumber))
 			}
@@ -481,6 +510,10 @@ func (iatBatch *IATBatch) isAddendaSequence() error {
 			if !(addenda18.EntryDetailSequenceNumberField() == entry.TraceNumberField()[8:]) {
 				return iatBatch.Error("TraceNumber", NewErrBatchAddendaTraceNumber(addenda18.EntryDetailSequenceNumberField(), entryTN))
 			}
+			// Addenda18 records are consecutively numbered starting at 1
+			if addenda18.SequenceNumber != i+1 {
+				return iatBatch.Error("SequenceNumber", NewErrBatchAddendaSequence(addenda18.SequenceNumber, i+1))
+			}
 		}
 	}
 	return nil
@@ -564,6 +597,14 @@ func (iatBatch *IATBatch) Validate() error {
 		if len(entry.Addenda18) > 5 {
 			return iatBatch.Error("Addenda18", NewErrBatchAddendaCount(len(entry.Addenda18), 5))
 		}
+		// AddendaRecords on forward entries must count the mandatory Addenda10-16 records
+		// and any Addenda17 and Addenda18 records
+		if entry.Addenda98 == nil && entry.Addenda99 == nil {
+			addendaRecords := 7 + len(entry.Addenda17) + len(entry.Addenda18)
+			if entry.AddendaRecords != addendaRecords {
+				return iatBatch.Error("AddendaRecords", NewErrBatchExpectedAddendaCount(addendaRecords, entry.AddendaRecords))
+			}
+		}
 		if iatBatch.Header.ServiceClassCode == AutomatedAccountingAdvices {
 			return iatBatch.Error("ServiceClassCode", ErrBatchServiceClassCode, iatBatch.Header.ServiceClassCode)
 		}
@@ -18,7 +18,6 @@
 package ach
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 	"unicode/utf8"
@@ -170,11 +169,31 @@ type IATBatchHeader struct {
 
 	// converters is composed for ACH to golang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 const (
 	// IATCOR is the valid value for IATBatchHeader.IATIndicator for IAT Notification Of Changr
 	IATCOR = "IATCOR"
+
+	// IATBatchHeader.ForeignExchangeIndicator
+
+	// ForeignExchangeFixedToVariable (FV) is originated in a fixed amount and received in a variable amount
+	ForeignExchangeFixedToVariable = "FV"
+	// ForeignExchangeVariableToFixed (VF) is originated in a variable amount and received in a fixed amount
+	ForeignExchangeVariableToFixed = "VF"
+	// ForeignExchangeFixedToFixed (FF) is originated and received in the same fixed amount and currency
+	ForeignExchangeFixedToFixed = "FF"
+
+	// IATBatchHeader.ForeignExchangeReferenceIndicator
+
+	// ForeignExchangeRate indicates the ForeignExchangeReference holds the exchange rate
+	ForeignExchangeRate = 1
+	// ForeignExchangeReferenceNumber indicates the ForeignExchangeReference holds a reference number
+	ForeignExchangeReferenceNumber = 2
+	// ForeignExchangeSpaceFilled indicates the ForeignExchangeReference is space filled
+	ForeignExchangeSpaceFilled = 3
 )
 
 // NewIATBatchHeader returns a new BatchHeader with default values for non exported fields
@@ -252,7 +271,7 @@ func (iatBh *IATBatchHeader) String() string {
 	var buf strings.Builder
 	buf.Grow(94)
 	buf.WriteString(iatBh.recordType)
-	buf.WriteString(fmt.Sprintf("%v", iatBh.ServiceClassCode))
+	buf.WriteString(strconv.Itoa(iatBh.ServiceClassCode))
 	buf.WriteString(iatBh.IATIndicatorField())
 	buf.WriteString(iatBh.ForeignExchangeIndicatorField())
 	buf.WriteString(iatBh.ForeignExchangeReferenceIndicatorField())
@@ -265,7 +284,7 @@ func (iatBh *IATBatchHeader) String() string {
 	buf.WriteString(iatBh.ISODestinationCurrencyCodeField())
 	buf.WriteString(iatBh.EffectiveEntryDateField())
 	buf.WriteString(iatBh.settlementDateField())
-	buf.WriteString(fmt.Sprintf("%v", iatBh.OriginatorStatusCode))
+	buf.WriteString(strconv.Itoa(iatBh.OriginatorStatusCode))
 	buf.WriteString(iatBh.ODFIIdentificationField())
 	buf.WriteString(iatBh.BatchNumberField())
 	return buf.String()
@@ -289,6 +308,9 @@ func (iatBh *IATBatchHeader) Validate() error {
 	if err := iatBh.isForeignExchangeReferenceIndicator(iatBh.ForeignExchangeReferenceIndicator); err != nil {
 		return fieldError("ForeignExchangeReferenceIndicator", err, strconv.Itoa(iatBh.ForeignExchangeReferenceIndicator))
 	}
+	if err := iatBh.isForeignExchangeReference(); err != nil {
+		return err
+	}
 	if !iso3166.Valid(iatBh.ISODestinationCountryCode) {
 		return fieldError("ISODestinationCountryCode", ErrValidISO3166, iatBh.ISODestinationCountryCode)
 	}
@@ -310,6 +332,80 @@ func (iatBh *IATBatchHeader) Validate() error {
 	return nil
 }
 
+// isForeignExchangeReference verifies the ForeignExchangeIndicator, ForeignExchangeReferenceIndicator
+// and ForeignExchangeReference agree with each other.
+//
+// Fixed-to-Fixed entries have no currency conversion so the reference is space filled (3),
+// Variable-to-Fixed entries must carry the rate or reference number used (1 or 2), and the
+// ForeignExchangeReference must only be space filled when the reference indicator is 3.
+func (iatBh *IATBatchHeader) isForeignExchangeReference() error {
+	switch iatBh.ForeignExchangeIndicator {
+	case ForeignExchangeFixedToFixed:
+		if iatBh.ForeignExchangeReferenceIndicator != ForeignExchangeSpaceFilled {
+			return fieldError("ForeignExchangeReferenceIndicator", ErrForeignExchangeReferenceIndicator, strconv.Itoa(iatBh.ForeignExchangeReferenceIndicator))
+		}
+	case ForeignExchangeVariableToFixed:
+		if iatBh.ForeignExchangeReferenceIndicator == ForeignExchangeSpaceFilled {
+			return fieldError("ForeignExchangeReferenceIndicator", ErrForeignExchangeReferenceIndicator, strconv.Itoa(iatBh.ForeignExchangeReferenceIndicator))
+		}
+	}
+	reference := strings.TrimSpace(iatBh.ForeignExchangeReference)
+	if iatBh.ForeignExchangeReferenceIndicator == ForeignExchangeSpaceFilled && reference != "" {
+		return fieldError("ForeignExchangeReference", ErrForeignExchangeReference, iatBh.ForeignExchangeReference)
+	}
+	if iatBh.ForeignExchangeReferenceIndicator != ForeignExchangeSpaceFilled && reference == "" {
+		return fieldError("ForeignExchangeReference", ErrForeignExchangeReference, iatBh.ForeignExchangeReference)
+	}
+	if iatBh.ForeignExchangeReferenceIndicator == ForeignExchangeRate {
+		if err := iatBh.isForeignExchangeRate(reference); err != nil {
+			return fieldError("ForeignExchangeReference", err, iatBh.ForeignExchangeReference)
+		}
+	}
+	return nil
+}
+
+// SetForeignExchangeRate sets ForeignExchangeIndicator and records rate as the
+// ForeignExchangeReference with a ForeignExchangeReferenceIndicator of ForeignExchangeRate.
+//
+// The header is left unchanged if indicator and rate don't form a valid combination.
+func (iatBh *IATBatchHeader) SetForeignExchangeRate(indicator string, rate string) error {
+	return iatBh.setForeignExchange(indicator, ForeignExchangeRate, rate)
+}
+
+// SetForeignExchangeReferenceNumber sets ForeignExchangeIndicator and records reference as the
+// ForeignExchangeReference with a ForeignExchangeReferenceIndicator of ForeignExchangeReferenceNumber.
+//
+// The header is left unchanged if indicator and reference don't form a valid combination.
+func (iatBh *IATBatchHeader) SetForeignExchangeReferenceNumber(indicator string, reference string) error {
+	return iatBh.setForeignExchange(indicator, ForeignExchangeReferenceNumber, reference)
+}
+
+// SetFixedToFixed marks the batch as Fixed-to-Fixed, which has no currency conversion
+// and so a space filled ForeignExchangeReference.
+func (iatBh *IATBatchHeader) SetFixedToFixed() {
+	iatBh.ForeignExchangeIndicator = ForeignExchangeFixedToFixed
+	iatBh.ForeignExchangeReferenceIndicator = ForeignExchangeSpaceFilled
+	iatBh.ForeignExchangeReference = ""
+}
+
+func (iatBh *IATBatchHeader) setForeignExchange(indicator string, referenceIndicator int, reference string) error {
+	if err := iatBh.isForeignExchangeIndicator(indicator); err != nil {
+		return fieldError("ForeignExchangeIndicator", err, indicator)
+	}
+	candidate := &IATBatchHeader{
+		ForeignExchangeIndicator:          indicator,
+		ForeignExchangeReferenceIndicator: referenceIndicator,
+		ForeignExchangeReference:          strings.TrimSpace(reference),
+	}
+	if err := candidate.isForeignExchangeReference(); err != nil {
+		return err
+	}
+	iatBh.ForeignExchangeIndicator = candidate.ForeignExchangeIndicator
+	iatBh.ForeignExchangeReferenceIndicator = candidate.ForeignExchangeReferenceIndicator
+	iatBh.ForeignExchangeReference = candidate.ForeignExchangeReference
+	return nil
+}
+
 // fieldInclusion validate mandatory fields are not default values. If fields are
 // invalid the ACH transfer will be returned.
 func (iatBh *IATBatchHeader) fieldInclusion() error {
@@ -792,3 +792,100 @@ func BenchmarkIATBHODFIIdentification(b *testing.B) {
 		testIATBHODFIIdentification(b)
 	}
 }
+
+// TestIATBHForeignExchangeReference validates the ForeignExchangeIndicator cross field rules
+func TestIATBHForeignExchangeReference(t *testing.T) {
+	bh := mockIATBatchHeaderFF()
+	bh.ForeignExchangeReferenceIndicator = 1
+	if err := bh.Validate(); !base.Match(err, ErrForeignExchangeReferenceIndicator) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	bh = mockIATBatchHeaderFF()
+	bh.ForeignExchangeReference = "123456"
+	if err := bh.Validate(); !base.Match(err, ErrForeignExchangeReference) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	bh = mockIATBatchHeaderFF()
+	bh.ForeignExchangeIndicator = "VF"
+	if err := bh.Validate(); !base.Match(err, ErrForeignExchangeReferenceIndicator) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	bh.ForeignExchangeReferenceIndicator = 1
+	if err := bh.Validate(); !base.Match(err, ErrForeignExchangeReference) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	bh.ForeignExchangeReference = "1.3542"
+	if err := bh.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestIATBHForeignExchangeRate validates a ForeignExchangeReference holding a rate is numeric
+func TestIATBHForeignExchangeRate(t *testing.T) {
+	bh := mockIATBatchHeaderFF()
+	bh.ForeignExchangeIndicator = ForeignExchangeFixedToVariable
+	bh.ForeignExchangeReferenceIndicator = ForeignExchangeRate
+	for _, rate := range []string{"ABC123", "1.2.3", "0.000", "-1.5"} {
+		bh.ForeignExchangeReference = rate
+		if err := bh.Validate(); !base.Match(err, ErrForeignExchangeRate) {
+			t.Errorf("rate %q: %T: %s", rate, err, err)
+		}
+	}
+
+	// reference numbers are free form
+	bh.ForeignExchangeReferenceIndicator = ForeignExchangeReferenceNumber
+	bh.ForeignExchangeReference = "ABC123"
+	if err := bh.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestIATBHSetForeignExchange validates the foreign exchange setters
+func TestIATBHSetForeignExchange(t *testing.T) {
+	bh := mockIATBatchHeaderFF()
+	if err := bh.SetForeignExchangeRate(ForeignExchangeVariableToFixed, "1.3542"); err != nil {
+		t.Fatal(err)
+	}
+	if bh.ForeignExchangeIndicator != "VF" || bh.ForeignExchangeReferenceIndicator != 1 || bh.ForeignExchangeReference != "1.3542" {
+		t.Errorf("unexpected foreign exchange: %s %d %q", bh.ForeignExchangeIndicator, bh.ForeignExchangeReferenceIndicator, bh.ForeignExchangeReference)
+	}
+	if err := bh.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// invalid combinations leave the header unchanged
+	if err := bh.SetForeignExchangeRate(ForeignExchangeFixedToFixed, "1.3542"); !base.Match(err, ErrForeignExchangeReferenceIndicator) {
+		t.Errorf("%T: %s", err, err)
+	}
+	if err := bh.SetForeignExchangeRate(ForeignExchangeFixedToVariable, "rate"); !base.Match(err, ErrForeignExchangeRate) {
+		t.Errorf("%T: %s", err, err)
+	}
+	if err := bh.SetForeignExchangeReferenceNumber("XY", "REF-1"); !base.Match(err, ErrForeignExchangeIndicator) {
+		t.Errorf("%T: %s", err, err)
+	}
+	if err := bh.SetForeignExchangeReferenceNumber(ForeignExchangeFixedToVariable, " "); !base.Match(err, ErrForeignExchangeReference) {
+		t.Errorf("%T: %s", err, err)
+	}
+	if bh.ForeignExchangeIndicator != "VF" || bh.ForeignExchangeReference != "1.3542" {
+		t.Errorf("header changed: %s %q", bh.ForeignExchangeIndicator, bh.ForeignExchangeReference)
+	}
+
+	if err := bh.SetForeignExchangeReferenceNumber(ForeignExchangeFixedToVariable, "REF-1"); err != nil {
+		t.Fatal(err)
+	}
+	if v := bh.ForeignExchangeReferenceField(); v != "REF-1          " {
+		t.Errorf("got %q", v)
+	}
+
+	bh.SetFixedToFixed()
+	if err := bh.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if v := bh.ForeignExchangeReferenceField(); v != "               " {
+		t.Errorf("got %q", v)
+	}
+}
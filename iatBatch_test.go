@@ -1938,3 +1938,36 @@ func TestParseRuneCountIATBatchHeader(t *testing.T) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
+
+// TestIATBatchAddenda18SequenceGap validates Addenda18 records are consecutively numbered
+func TestIATBatchAddenda18SequenceGap(t *testing.T) {
+	mockBatch := mockIATBatch(t)
+	mockBatch.Entries[0].AddAddenda18(mockAddenda18())
+	mockBatch.Entries[0].AddAddenda18(mockAddenda18B())
+	if err := mockBatch.build(); err != nil {
+		t.Fatal(err)
+	}
+	if mockBatch.Entries[0].AddendaRecords != 9 {
+		t.Errorf("unexpected AddendaRecords: %d", mockBatch.Entries[0].AddendaRecords)
+	}
+
+	mockBatch.Entries[0].Addenda18[1].SequenceNumber = 3
+	err := mockBatch.Validate()
+	if !base.Match(err, NewErrBatchAddendaSequence(3, 2)) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestIATBatchAddendaRecords validates AddendaRecords counts all addenda records on the entry
+func TestIATBatchAddendaRecords(t *testing.T) {
+	mockBatch := mockIATBatch(t)
+	mockBatch.Entries[0].AddAddenda17(mockAddenda17())
+	if err := mockBatch.build(); err != nil {
+		t.Fatal(err)
+	}
+	mockBatch.Entries[0].AddendaRecords = 7
+	err := mockBatch.Validate()
+	if !base.Match(err, NewErrBatchExpectedAddendaCount(8, 7)) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
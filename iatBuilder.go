@@ -0,0 +1,238 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// IATParty is the name and address of an IAT Originator or Receiver
+type IATParty struct {
+	// Name of the company or individual
+	Name string `json:"name"`
+	// IdentificationNumber is the Receiver's ID, it's only used for Receivers (Addenda15)
+	IdentificationNumber string `json:"identificationNumber,omitempty"`
+	// StreetAddress of the company or individual
+	StreetAddress string `json:"streetAddress"`
+	// City of the company or individual
+	City string `json:"city"`
+	// StateProvince of the company or individual
+	StateProvince string `json:"stateProvince"`
+	// Country is the ISO 3166 country code of the company or individual
+	Country string `json:"country"`
+	// PostalCode of the company or individual
+	PostalCode string `json:"postalCode"`
+}
+
+// IATBank identifies the ODFI or RDFI of an IAT entry
+type IATBank struct {
+	// Name of the financial institution
+	Name string `json:"name"`
+	// IDNumberQualifier is 01 (National Clearing System), 02 (BIC Code) or 03 (IBAN Code)
+	IDNumberQualifier string `json:"IDNumberQualifier"`
+	// Identification is the bank identification number in the format of IDNumberQualifier
+	Identification string `json:"identification"`
+	// BranchCountryCode is the ISO 3166 country code of the branch
+	BranchCountryCode string `json:"branchCountryCode"`
+}
+
+// IATRemittance is the payment information of an IAT entry
+type IATRemittance struct {
+	// TransactionTypeCode describes the payment, e.g. ANN, BUS, SAL (see Addenda10)
+	TransactionTypeCode string `json:"transactionTypeCode"`
+	// ForeignPaymentAmount is the amount in the originating currency
+	ForeignPaymentAmount int `json:"foreignPaymentAmount"`
+	// ForeignTraceNumber is the trace number assigned in the originating country
+	ForeignTraceNumber string `json:"foreignTraceNumber,omitempty"`
+	// PaymentRelatedInformation are written as Addenda17 records, up to two lines of 80 characters
+	PaymentRelatedInformation []string `json:"paymentRelatedInformation,omitempty"`
+}
+
+// IATBuilder creates an IATEntryDetail along with its mandatory Addenda10-16 records
+// from sender, receiver, bank and remittance details.
+//
+// Addenda sequence numbers, AddendaRecords and the packing of city, state, country and
+// postal codes into Addenda12 and Addenda16 are handled by Build.
+type IATBuilder struct {
+	// TransactionCode of the entry, e.g. CheckingCredit
+	TransactionCode int `json:"transactionCode"`
+	// Amount in the destination currency
+	Amount int `json:"amount"`
+	// RDFIRoutingNumber is the 9 digit routing number of the RDFI or Gateway receiving the entry
+	RDFIRoutingNumber string `json:"RDFIRoutingNumber"`
+	// DFIAccountNumber is the Receiver's account number
+	DFIAccountNumber string `json:"DFIAccountNumber"`
+	// OFACScreeningIndicator of the entry, see IATEntryDetail
+	OFACScreeningIndicator string `json:"OFACScreeningIndicator,omitempty"`
+	// SecondaryOFACScreeningIndicator of the entry, see IATEntryDetail
+	SecondaryOFACScreeningIndicator string `json:"SecondaryOFACScreeningIndicator,omitempty"`
+
+	Originator IATParty      `json:"originator"`
+	Receiver   IATParty      `json:"receiver"`
+	ODFI       IATBank       `json:"ODFI"`
+	RDFI       IATBank       `json:"RDFI"`
+	Remittance IATRemittance `json:"remittance"`
+}
+
+// Build returns an IATEntryDetail with a TraceNumber made from ODFIIdentification and seq
+// and all mandatory addenda records. The entry and each addenda record are validated.
+func (b *IATBuilder) Build(ODFIIdentification string, seq int) (*IATEntryDetail, error) {
+	if len(b.Remittance.PaymentRelatedInformation) > 2 {
+		return nil, fieldError("PaymentRelatedInformation", NewErrBatchAddendaCount(len(b.Remittance.PaymentRelatedInformation), 2), "")
+	}
+
+	ed := NewIATEntryDetail()
+	ed.TransactionCode = b.TransactionCode
+	ed.SetRDFI(b.RDFIRoutingNumber)
+	ed.DFIAccountNumber = b.DFIAccountNumber
+	ed.Amount = b.Amount
+	ed.OFACScreeningIndicator = b.OFACScreeningIndicator
+	ed.SecondaryOFACScreeningIndicator = b.SecondaryOFACScreeningIndicator
+	ed.SetTraceNumber(ODFIIdentification, seq)
+
+	// Addenda records carry the last seven digits of the entry's TraceNumber
+	entrySeq, err := strconv.Atoi(ed.TraceNumber[8:])
+	if err != nil {
+		return nil, fieldError("TraceNumber", err, ed.TraceNumber)
+	}
+
+	addenda10 := NewAddenda10()
+	addenda10.TransactionTypeCode = b.Remittance.TransactionTypeCode
+	addenda10.ForeignPaymentAmount = b.Remittance.ForeignPaymentAmount
+	addenda10.ForeignTraceNumber = b.Remittance.ForeignTraceNumber
+	addenda10.Name = b.Receiver.Name
+	addenda10.EntryDetailSequenceNumber = entrySeq
+	ed.Addenda10 = addenda10
+
+	addenda11 := NewAddenda11()
+	addenda11.OriginatorName = b.Originator.Name
+	addenda11.OriginatorStreetAddress = b.Originator.StreetAddress
+	addenda11.EntryDetailSequenceNumber = entrySeq
+	ed.Addenda11 = addenda11
+
+	addenda12 := NewAddenda12()
+	addenda12.OriginatorCityStateProvince = packIATField(b.Originator.City, b.Originator.StateProvince)
+	addenda12.OriginatorCountryPostalCode = packIATField(b.Originator.Country, b.Originator.PostalCode)
+	addenda12.EntryDetailSequenceNumber = entrySeq
+	ed.Addenda12 = addenda12
+
+	addenda13 := NewAddenda13()
+	addenda13.ODFIName = b.ODFI.Name
+	addenda13.ODFIIDNumberQualifier = b.ODFI.IDNumberQualifier
+	addenda13.ODFIIdentification = b.ODFI.Identification
+	addenda13.ODFIBranchCountryCode = b.ODFI.BranchCountryCode
+	addenda13.EntryDetailSequenceNumber = entrySeq
+	ed.Addenda13 = addenda13
+
+	addenda14 := NewAddenda14()
+	addenda14.RDFIName = b.RDFI.Name
+	addenda14.RDFIIDNumberQualifier = b.RDFI.IDNumberQualifier
+	addenda14.RDFIIdentification = b.RDFI.Identification
+	addenda14.RDFIBranchCountryCode = b.RDFI.BranchCountryCode
+	addenda14.EntryDetailSequenceNumber = entrySeq
+	ed.Addenda14 = addenda14
+
+	addenda15 := NewAddenda15()
+	addenda15.ReceiverIDNumber = b.Receiver.IdentificationNumber
+	addenda15.ReceiverStreetAddress = b.Receiver.StreetAddress
+	addenda15.EntryDetailSequenceNumber = entrySeq
+	ed.Addenda15 = addenda15
+
+	addenda16 := NewAddenda16()
+	addenda16.ReceiverCityStateProvince = packIATField(b.Receiver.City, b.Receiver.StateProvince)
+	addenda16.ReceiverCountryPostalCode = packIATField(b.Receiver.Country, b.Receiver.PostalCode)
+	addenda16.EntryDetailSequenceNumber = entrySeq
+	ed.Addenda16 = addenda16
+
+	for i, info := range b.Remittance.PaymentRelatedInformation {
+		addenda17 := NewAddenda17()
+		addenda17.PaymentRelatedInformation = info
+		addenda17.SequenceNumber = i + 1
+		addenda17.EntryDetailSequenceNumber = entrySeq
+		ed.AddAddenda17(addenda17)
+	}
+	ed.AddendaRecords = 7 + len(ed.Addenda17)
+
+	if err := b.checkLengths(ed); err != nil {
+		return nil, err
+	}
+	if err := validateIATBuilderEntry(ed); err != nil {
+		return nil, err
+	}
+	return ed, nil
+}
+
+// packIATField joins two values with an asterisk and ends them with a backslash,
+// e.g. "Philadelphia*PA\" as required by Addenda12 and Addenda16.
+func packIATField(first, second string) string {
+	return fmt.Sprintf("%s*%s\\", first, second)
+}
+
+// checkLengths returns an error for values which would otherwise be truncated when written
+func (b *IATBuilder) checkLengths(ed *IATEntryDetail) error {
+	fields := []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"DFIAccountNumber", ed.DFIAccountNumber, 35},
+		{"ForeignTraceNumber", ed.Addenda10.ForeignTraceNumber, 22},
+		{"Name", ed.Addenda10.Name, 35},
+		{"OriginatorName", ed.Addenda11.OriginatorName, 35},
+		{"OriginatorStreetAddress", ed.Addenda11.OriginatorStreetAddress, 35},
+		{"OriginatorCityStateProvince", ed.Addenda12.OriginatorCityStateProvince, 35},
+		{"OriginatorCountryPostalCode", ed.Addenda12.OriginatorCountryPostalCode, 35},
+		{"ODFIName", ed.Addenda13.ODFIName, 35},
+		{"ODFIIdentification", ed.Addenda13.ODFIIdentification, 34},
+		{"RDFIName", ed.Addenda14.RDFIName, 35},
+		{"RDFIIdentification", ed.Addenda14.RDFIIdentification, 34},
+		{"ReceiverIDNumber", ed.Addenda15.ReceiverIDNumber, 15},
+		{"ReceiverStreetAddress", ed.Addenda15.ReceiverStreetAddress, 35},
+		{"ReceiverCityStateProvince", ed.Addenda16.ReceiverCityStateProvince, 35},
+		{"ReceiverCountryPostalCode", ed.Addenda16.ReceiverCountryPostalCode, 35},
+	}
+	for _, f := range fields {
+		if utf8.RuneCountInString(f.value) > f.max {
+			return fieldError(f.name, ErrFieldTooLong, f.value)
+		}
+	}
+	for _, addenda17 := range ed.Addenda17 {
+		if utf8.RuneCountInString(addenda17.PaymentRelatedInformation) > 80 {
+			return fieldError("PaymentRelatedInformation", ErrFieldTooLong, addenda17.PaymentRelatedInformation)
+		}
+	}
+	return nil
+}
+
+// validateIATBuilderEntry validates an IATEntryDetail and each of its addenda records
+func validateIATBuilderEntry(ed *IATEntryDetail) error {
+	validators := []interface {
+		Validate() error
+	}{ed, ed.Addenda10, ed.Addenda11, ed.Addenda12, ed.Addenda13, ed.Addenda14, ed.Addenda15, ed.Addenda16}
+	for _, addenda17 := range ed.Addenda17 {
+		validators = append(validators, addenda17)
+	}
+	for _, v := range validators {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base"
+)
+
+func mockIATBuilder() *IATBuilder {
+	return &IATBuilder{
+		TransactionCode:   CheckingCredit,
+		Amount:            100000,
+		RDFIRoutingNumber: "121042882",
+		DFIAccountNumber:  "123456789",
+		Originator: IATParty{
+			Name:          "BEK Solutions",
+			StreetAddress: "15 West Place Street",
+			City:          "JacobsTown",
+			StateProvince: "PA",
+			Country:       "US",
+			PostalCode:    "19305",
+		},
+		Receiver: IATParty{
+			Name:                 "BEK Enterprises",
+			IdentificationNumber: "987465493213987",
+			StreetAddress:        "2121 Front Street",
+			City:                 "LetterTown",
+			StateProvince:        "AB",
+			Country:              "CA",
+			PostalCode:           "80014",
+		},
+		ODFI: IATBank{
+			Name:              "Wells Fargo",
+			IDNumberQualifier: "01",
+			Identification:    "231380104",
+			BranchCountryCode: "US",
+		},
+		RDFI: IATBank{
+			Name:              "Citadel Bank",
+			IDNumberQualifier: "01",
+			Identification:    "121042882",
+			BranchCountryCode: "CA",
+		},
+		Remittance: IATRemittance{
+			TransactionTypeCode:       "ANN",
+			ForeignPaymentAmount:      100000,
+			ForeignTraceNumber:        "928383-23938",
+			PaymentRelatedInformation: []string{"This is an international payment"},
+		},
+	}
+}
+
+func TestIATBuilder__Build(t *testing.T) {
+	bh := mockIATBatchHeaderFF()
+	ed, err := mockIATBuilder().Build(bh.ODFIIdentification, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ed.TraceNumber != "231380100000012" {
+		t.Errorf("TraceNumber=%s", ed.TraceNumber)
+	}
+	if ed.AddendaRecords != 8 {
+		t.Errorf("AddendaRecords=%d", ed.AddendaRecords)
+	}
+	if v := ed.Addenda12.OriginatorCityStateProvince; v != `JacobsTown*PA\` {
+		t.Errorf("OriginatorCityStateProvince=%s", v)
+	}
+	if v := ed.Addenda16.ReceiverCountryPostalCode; v != `CA*80014\` {
+		t.Errorf("ReceiverCountryPostalCode=%s", v)
+	}
+	if ed.Addenda10.EntryDetailSequenceNumber != 12 || ed.Addenda16.EntryDetailSequenceNumber != 12 {
+		t.Errorf("Addenda10=%d Addenda16=%d", ed.Addenda10.EntryDetailSequenceNumber, ed.Addenda16.EntryDetailSequenceNumber)
+	}
+	if n := len(ed.Addenda17); n != 1 || ed.Addenda17[0].SequenceNumber != 1 || ed.Addenda17[0].EntryDetailSequenceNumber != 12 {
+		t.Errorf("unexpected Addenda17: %#v", ed.Addenda17)
+	}
+
+	// the entry is ready to be added to a batch
+	batch := NewIATBatch(bh)
+	batch.AddEntry(ed)
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIATBuilder__Errors(t *testing.T) {
+	builder := mockIATBuilder()
+	builder.Receiver.City = strings.Repeat("A", 35)
+	if _, err := builder.Build("23138010", 1); !base.Match(err, ErrFieldTooLong) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	builder = mockIATBuilder()
+	builder.Remittance.PaymentRelatedInformation = []string{"one", "two", "three"}
+	if _, err := builder.Build("23138010", 1); !base.Match(err, NewErrBatchAddendaCount(3, 2)) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	builder = mockIATBuilder()
+	builder.Receiver.Name = ""
+	if _, err := builder.Build("23138010", 1); !base.Match(err, ErrConstructor) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
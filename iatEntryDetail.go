@@ -18,7 +18,6 @@
 package ach
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 	"unicode/utf8"
@@ -59,9 +58,10 @@ type IATEntryDetail struct {
 	DFIAccountNumber string `json:"DFIAccountNumber"`
 	// reservedTwo - Leave blank
 	reservedTwo string
-	// OFACScreeningIndicator - Leave blank
+	// OFACScreeningIndicator - Leave blank, set by the Gateway Operator to 0 (no hit) or 1 (potential hit)
 	OFACScreeningIndicator string `json:"OFACScreeningIndicator"`
-	// SecondaryOFACScreeningIndicator - Leave blank
+	// SecondaryOFACScreeningIndicator - Leave blank, set by the Gateway Operator to 0 (no hit) or 1 (potential hit)
+	// and only after the OFACScreeningIndicator is set
 	SecondaryOFACScreeningIndicator string `json:"SecondaryOFACScreeningIndicator"`
 	// AddendaRecordIndicator indicates the existence of an Addenda Record.
 	// A value of "1" indicates that one or more addenda records follow,
@@ -133,6 +133,8 @@ type IATEntryDetail struct {
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// rawLine is the line the record was read from
+	rawLine
 }
 
 // NewIATEntryDetail returns a new IATEntryDetail with default values for non exported fields
@@ -172,9 +174,9 @@ func (iatEd *IATEntryDetail) Parse(record string) {
 	// 75-76 reserved2 Leave blank
 	iatEd.reservedTwo = "  "
 	// 77 OFACScreeningIndicator
-	iatEd.OFACScreeningIndicator = " "
+	iatEd.OFACScreeningIndicator = record[76:77]
 	// 78-78 Secondary SecondaryOFACScreeningIndicator
-	iatEd.SecondaryOFACScreeningIndicator = " "
+	iatEd.SecondaryOFACScreeningIndicator = record[77:78]
 	// 79-79 1 if addenda exists 0 if it does not
 	//iatEd.AddendaRecordIndicator = 1
 	iatEd.AddendaRecordIndicator = iatEd.parseNumField(record[78:79])
@@ -188,7 +190,7 @@ func (iatEd *IATEntryDetail) String() string {
 	var buf strings.Builder
 	buf.Grow(94)
 	buf.WriteString(iatEd.recordType)
-	buf.WriteString(fmt.Sprintf("%v", iatEd.TransactionCode))
+	buf.WriteString(strconv.Itoa(iatEd.TransactionCode))
 	buf.WriteString(iatEd.RDFIIdentificationField())
 	buf.WriteString(iatEd.CheckDigit)
 	buf.WriteString(iatEd.AddendaRecordsField())
@@ -198,7 +200,7 @@ func (iatEd *IATEntryDetail) String() string {
 	buf.WriteString(iatEd.reservedTwoField())
 	buf.WriteString(iatEd.OFACScreeningIndicatorField())
 	buf.WriteString(iatEd.SecondaryOFACScreeningIndicatorField())
-	buf.WriteString(fmt.Sprintf("%v", iatEd.AddendaRecordIndicator))
+	buf.WriteString(strconv.Itoa(iatEd.AddendaRecordIndicator))
 	buf.WriteString(iatEd.TraceNumberField())
 	return buf.String()
 }
@@ -218,6 +220,22 @@ func (iatEd *IATEntryDetail) Validate() error {
 	if err := iatEd.isAlphanumeric(iatEd.DFIAccountNumber); err != nil {
 		return fieldError("DFIAccountNumber", err, iatEd.DFIAccountNumber)
 	}
+	if Amount(iatEd.Amount) > MaxEntryAmount {
+		return fieldError("Amount", ErrInvalidAmount, iatEd.Amount)
+	}
+	if isPrenoteTransactionCode(iatEd.TransactionCode) && iatEd.Amount != 0 {
+		return fieldError("Amount", ErrPrenoteAmount, iatEd.Amount)
+	}
+	if err := iatEd.isOFACScreeningIndicator(iatEd.OFACScreeningIndicator); err != nil {
+		return fieldError("OFACScreeningIndicator", err, iatEd.OFACScreeningIndicator)
+	}
+	if err := iatEd.isOFACScreeningIndicator(iatEd.SecondaryOFACScreeningIndicator); err != nil {
+		return fieldError("SecondaryOFACScreeningIndicator", err, iatEd.SecondaryOFACScreeningIndicator)
+	}
+	// The secondary screening can only be indicated once the Gateway Operator screening is indicated
+	if strings.TrimSpace(iatEd.SecondaryOFACScreeningIndicator) != "" && strings.TrimSpace(iatEd.OFACScreeningIndicator) == "" {
+		return fieldError("SecondaryOFACScreeningIndicator", ErrOFACScreeningIndicator, iatEd.SecondaryOFACScreeningIndicator)
+	}
 	// CheckDigit calculations
 	calculated := iatEd.CalculateCheckDigit(iatEd.RDFIIdentificationField())
 
@@ -290,6 +308,16 @@ func (iatEd *IATEntryDetail) AmountField() string {
 	return iatEd.numericField(iatEd.Amount, 10)
 }
 
+// GetAmount returns the Amount of the entry
+func (iatEd *IATEntryDetail) GetAmount() Amount {
+	return Amount(iatEd.Amount)
+}
+
+// SetAmount sets the Amount of the entry
+func (iatEd *IATEntryDetail) SetAmount(a Amount) {
+	iatEd.Amount = int(a)
+}
+
 // DFIAccountNumberField gets the DFIAccountNumber with space padding
 func (iatEd *IATEntryDetail) DFIAccountNumberField() string {
 	return iatEd.alphaField(iatEd.DFIAccountNumber, 35)
@@ -479,3 +479,36 @@ func BenchmarkIATEDAddendaRecordIndicator(b *testing.B) {
 		testIATEDAddendaRecordIndicator(b)
 	}
 }
+
+// TestIATEDOFACScreeningIndicator validates the OFAC screening indicators
+func TestIATEDOFACScreeningIndicator(t *testing.T) {
+	entry := mockIATEntryDetail()
+	entry.OFACScreeningIndicator = "2"
+	if err := entry.Validate(); !base.Match(err, ErrOFACScreeningIndicator) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	entry = mockIATEntryDetail()
+	entry.SecondaryOFACScreeningIndicator = "1"
+	if err := entry.Validate(); !base.Match(err, ErrOFACScreeningIndicator) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	entry.OFACScreeningIndicator = "0"
+	if err := entry.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestIATEDParseOFACScreeningIndicator validates the OFAC screening indicators are read
+func TestIATEDParseOFACScreeningIndicator(t *testing.T) {
+	var line = "6221210428820007             000010000012345678901234567890123456789012345  011231380100000001"
+	entry := NewIATEntryDetail()
+	entry.Parse(line)
+	if entry.OFACScreeningIndicator != "0" || entry.SecondaryOFACScreeningIndicator != "1" {
+		t.Errorf("unexpected OFAC screening indicators: %q %q", entry.OFACScreeningIndicator, entry.SecondaryOFACScreeningIndicator)
+	}
+	if v := entry.String(); v != line {
+		t.Errorf("got %q", v)
+	}
+}
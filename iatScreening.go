@@ -0,0 +1,121 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/moov-io/ach/screening"
+	"github.com/moov-io/base"
+)
+
+// ScreeningParties returns the receiver, originator, ODFI, RDFI and foreign correspondent banks
+// of the entry from its addenda records for sanctions screening.
+func (iatEd *IATEntryDetail) ScreeningParties() []screening.Party {
+	var parties []screening.Party
+	add := func(role screening.Role, name, id, country string) {
+		if name = strings.TrimSpace(name); name != "" {
+			parties = append(parties, screening.Party{
+				Role:        role,
+				Name:        name,
+				IDNumber:    strings.TrimSpace(id),
+				CountryCode: strings.TrimSpace(country),
+				TraceNumber: iatEd.TraceNumber,
+			})
+		}
+	}
+	// country codes are followed by a * and the postal code
+	country := func(countryPostalCode string) string {
+		return strings.SplitN(countryPostalCode, "*", 2)[0]
+	}
+
+	if a := iatEd.Addenda10; a != nil {
+		var id, cc string
+		if iatEd.Addenda15 != nil {
+			id = iatEd.Addenda15.ReceiverIDNumber
+		}
+		if iatEd.Addenda16 != nil {
+			cc = country(iatEd.Addenda16.ReceiverCountryPostalCode)
+		}
+		add(screening.Receiver, a.Name, id, cc)
+	}
+	if a := iatEd.Addenda11; a != nil {
+		var cc string
+		if iatEd.Addenda12 != nil {
+			cc = country(iatEd.Addenda12.OriginatorCountryPostalCode)
+		}
+		add(screening.Originator, a.OriginatorName, "", cc)
+	}
+	if a := iatEd.Addenda13; a != nil {
+		add(screening.ODFI, a.ODFIName, a.ODFIIdentification, a.ODFIBranchCountryCode)
+	}
+	if a := iatEd.Addenda14; a != nil {
+		add(screening.RDFI, a.RDFIName, a.RDFIIdentification, a.RDFIBranchCountryCode)
+	}
+	for _, a := range iatEd.Addenda18 {
+		if a != nil {
+			add(screening.ForeignCorrespondentBank, a.ForeignCorrespondentBankName, a.ForeignCorrespondentBankIDNumber, a.ForeignCorrespondentBankBranchCountryCode)
+		}
+	}
+	return parties
+}
+
+// Screen checks the parties of each entry in the batch with s, see IATEntryDetail.ScreeningParties.
+// Every blocked entry is returned as a BatchError wrapping screening.ErrMatch, and other errors from
+// s are returned as soon as they happen so entries aren't sent unscreened.
+func (iatBatch *IATBatch) Screen(ctx context.Context, s screening.Screener) error {
+	var errs base.ErrorList
+	if err := iatBatch.screen(ctx, s, &errs); err != nil {
+		return err
+	}
+	if errs.Empty() {
+		return nil
+	}
+	return errs
+}
+
+func (iatBatch *IATBatch) screen(ctx context.Context, s screening.Screener, errs *base.ErrorList) error {
+	for _, entry := range iatBatch.Entries {
+		err := s.Screen(ctx, entry.ScreeningParties())
+		switch {
+		case errors.Is(err, screening.ErrMatch):
+			errs.Add(iatBatch.Error("TraceNumber", err, entry.TraceNumber))
+		case err != nil:
+			return err
+		}
+	}
+	return nil
+}
+
+// ScreenIATEntries checks the parties of every IAT entry in f with s, see IATBatch.Screen.
+//
+// Set ValidateOpts.IATScreener to have ValidateWith call ScreenIATEntries.
+func (f *File) ScreenIATEntries(ctx context.Context, s screening.Screener) error {
+	var errs base.ErrorList
+	for i := range f.IATBatches {
+		if err := f.IATBatches[i].screen(ctx, s, &errs); err != nil {
+			return err
+		}
+	}
+	if errs.Empty() {
+		return nil
+	}
+	return errs
+}
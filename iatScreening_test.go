@@ -0,0 +1,143 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/moov-io/ach/screening"
+	"github.com/moov-io/base"
+)
+
+// nameScreener blocks parties with a name in its map and fails with err when it's set
+type nameScreener struct {
+	blocked map[string]bool
+	err     error
+	calls   int
+}
+
+func (s *nameScreener) Screen(_ context.Context, parties []screening.Party) error {
+	s.calls++
+	if s.err != nil {
+		return s.err
+	}
+	var matches []screening.Match
+	for _, p := range parties {
+		if s.blocked[p.Name] {
+			matches = append(matches, screening.Match{Party: p})
+		}
+	}
+	if len(matches) > 0 {
+		return &screening.MatchError{Matches: matches}
+	}
+	return nil
+}
+
+func mockScreeningIATFile(t *testing.T) *File {
+	t.Helper()
+
+	ed := mockBuilderIATEntryDetail()
+	ed.AddAddenda18(mockAddenda18())
+	file, err := NewFileBuilder().
+		WithHeader(mockFileHeader()).
+		AddIATBatch(mockIATBatchHeaderFF()).AddEntry(ed).AddEntry(mockBuilderIATEntryDetail()).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestIATEntryDetail__ScreeningParties(t *testing.T) {
+	ed := mockBuilderIATEntryDetail()
+	ed.TraceNumber = "231380100000001"
+	ed.AddAddenda18(mockAddenda18())
+
+	parties := ed.ScreeningParties()
+	if len(parties) != 5 {
+		t.Fatalf("got %d parties: %#v", len(parties), parties)
+	}
+	roles := []screening.Role{screening.Receiver, screening.Originator, screening.ODFI, screening.RDFI, screening.ForeignCorrespondentBank}
+	for i, role := range roles {
+		if parties[i].Role != role || parties[i].TraceNumber != ed.TraceNumber {
+			t.Errorf("party %d: %#v", i, parties[i])
+		}
+	}
+	if p := parties[0]; p.Name != "BEK Enterprises" || p.CountryCode == "" {
+		t.Errorf("unexpected receiver: %#v", p)
+	}
+	if p := parties[1]; p.Name != "BEK Solutions" || p.CountryCode != "US" {
+		t.Errorf("unexpected originator: %#v", p)
+	}
+	if p := parties[4]; p.Name != "Bank of Germany" || p.IDNumber != "987987987654654" || p.CountryCode != "DE" {
+		t.Errorf("unexpected foreign correspondent bank: %#v", p)
+	}
+
+	// missing addenda records are skipped
+	if parties := NewIATEntryDetail().ScreeningParties(); len(parties) != 0 {
+		t.Errorf("got %d parties", len(parties))
+	}
+}
+
+func TestFile__ScreenIATEntries(t *testing.T) {
+	ctx := context.Background()
+	file := mockScreeningIATFile(t)
+
+	s := &nameScreener{}
+	if err := file.ScreenIATEntries(ctx, s); err != nil {
+		t.Fatal(err)
+	}
+	if s.calls != 2 {
+		t.Errorf("screened %d entries", s.calls)
+	}
+	if err := file.ScreenIATEntries(ctx, screening.NopScreener{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// only the first entry has a foreign correspondent bank
+	s = &nameScreener{blocked: map[string]bool{"Bank of Germany": true}}
+	err := file.ScreenIATEntries(ctx, s)
+	errs, ok := err.(base.ErrorList)
+	if !ok || len(errs) != 1 || !base.Has(errs, screening.ErrMatch) {
+		t.Fatalf("%T: %v", err, err)
+	}
+	if be, ok := errs[0].(*BatchError); !ok || be.FieldName != "TraceNumber" || be.FieldValue != file.IATBatches[0].Entries[0].TraceNumber {
+		t.Errorf("unexpected error: %#v", errs[0])
+	}
+
+	s = &nameScreener{err: errors.New("screening unavailable")}
+	if err := file.ScreenIATEntries(ctx, s); err == nil || err.Error() != "screening unavailable" || s.calls != 1 {
+		t.Errorf("unexpected error after %d calls: %v", s.calls, err)
+	}
+}
+
+func TestFile__ValidateOptsIATScreener(t *testing.T) {
+	file := mockScreeningIATFile(t)
+	s := &nameScreener{blocked: map[string]bool{"BEK Enterprises": true}}
+	if err := file.ValidateWith(&ValidateOpts{IATScreener: s}); !base.Has(err, screening.ErrMatch) {
+		t.Errorf("%T: %v", err, err)
+	}
+	if err := file.IATBatches[0].Screen(context.Background(), s); !base.Has(err, screening.ErrMatch) {
+		t.Errorf("%T: %v", err, err)
+	}
+	if err := file.ValidateWith(&ValidateOpts{IATScreener: screening.NopScreener{}}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,3176 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: ach.proto
+
+package achpb
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type File struct {
+	Id                   string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Header               *FileHeader     `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	Batches              []*Batch        `protobuf:"bytes,3,rep,name=batches,proto3" json:"batches,omitempty"`
+	IatBatches           []*IATBatch     `protobuf:"bytes,4,rep,name=iat_batches,json=iatBatches,proto3" json:"iat_batches,omitempty"`
+	Control              *FileControl    `protobuf:"bytes,5,opt,name=control,proto3" json:"control,omitempty"`
+	AdvControl           *ADVFileControl `protobuf:"bytes,6,opt,name=adv_control,json=advControl,proto3" json:"adv_control,omitempty"`
+	TrailerRecords       []string        `protobuf:"bytes,7,rep,name=trailer_records,json=trailerRecords,proto3" json:"trailer_records,omitempty"`
+	Comments             []string        `protobuf:"bytes,8,rep,name=comments,proto3" json:"comments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *File) Reset()         { *m = File{} }
+func (m *File) String() string { return proto.CompactTextString(m) }
+func (*File) ProtoMessage()    {}
+func (*File) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{0}
+}
+
+func (m *File) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_File.Unmarshal(m, b)
+}
+func (m *File) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_File.Marshal(b, m, deterministic)
+}
+func (m *File) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_File.Merge(m, src)
+}
+func (m *File) XXX_Size() int {
+	return xxx_messageInfo_File.Size(m)
+}
+func (m *File) XXX_DiscardUnknown() {
+	xxx_messageInfo_File.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_File proto.InternalMessageInfo
+
+func (m *File) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *File) GetHeader() *FileHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *File) GetBatches() []*Batch {
+	if m != nil {
+		return m.Batches
+	}
+	return nil
+}
+
+func (m *File) GetIatBatches() []*IATBatch {
+	if m != nil {
+		return m.IatBatches
+	}
+	return nil
+}
+
+func (m *File) GetControl() *FileControl {
+	if m != nil {
+		return m.Control
+	}
+	return nil
+}
+
+func (m *File) GetAdvControl() *ADVFileControl {
+	if m != nil {
+		return m.AdvControl
+	}
+	return nil
+}
+
+func (m *File) GetTrailerRecords() []string {
+	if m != nil {
+		return m.TrailerRecords
+	}
+	return nil
+}
+
+func (m *File) GetComments() []string {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+type Batch struct {
+	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Header               *BatchHeader      `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	Entries              []*EntryDetail    `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`
+	Control              *BatchControl     `protobuf:"bytes,4,opt,name=control,proto3" json:"control,omitempty"`
+	AdvEntries           []*ADVEntryDetail `protobuf:"bytes,5,rep,name=adv_entries,json=advEntries,proto3" json:"adv_entries,omitempty"`
+	AdvControl           *ADVBatchControl  `protobuf:"bytes,6,opt,name=adv_control,json=advControl,proto3" json:"adv_control,omitempty"`
+	Comments             []string          `protobuf:"bytes,7,rep,name=comments,proto3" json:"comments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Batch) Reset()         { *m = Batch{} }
+func (m *Batch) String() string { return proto.CompactTextString(m) }
+func (*Batch) ProtoMessage()    {}
+func (*Batch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{1}
+}
+
+func (m *Batch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Batch.Unmarshal(m, b)
+}
+func (m *Batch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Batch.Marshal(b, m, deterministic)
+}
+func (m *Batch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Batch.Merge(m, src)
+}
+func (m *Batch) XXX_Size() int {
+	return xxx_messageInfo_Batch.Size(m)
+}
+func (m *Batch) XXX_DiscardUnknown() {
+	xxx_messageInfo_Batch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Batch proto.InternalMessageInfo
+
+func (m *Batch) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Batch) GetHeader() *BatchHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *Batch) GetEntries() []*EntryDetail {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *Batch) GetControl() *BatchControl {
+	if m != nil {
+		return m.Control
+	}
+	return nil
+}
+
+func (m *Batch) GetAdvEntries() []*ADVEntryDetail {
+	if m != nil {
+		return m.AdvEntries
+	}
+	return nil
+}
+
+func (m *Batch) GetAdvControl() *ADVBatchControl {
+	if m != nil {
+		return m.AdvControl
+	}
+	return nil
+}
+
+func (m *Batch) GetComments() []string {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+type IATBatch struct {
+	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Header               *IATBatchHeader   `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	Entries              []*IATEntryDetail `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`
+	Control              *BatchControl     `protobuf:"bytes,4,opt,name=control,proto3" json:"control,omitempty"`
+	Comments             []string          `protobuf:"bytes,5,rep,name=comments,proto3" json:"comments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *IATBatch) Reset()         { *m = IATBatch{} }
+func (m *IATBatch) String() string { return proto.CompactTextString(m) }
+func (*IATBatch) ProtoMessage()    {}
+func (*IATBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{2}
+}
+
+func (m *IATBatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IATBatch.Unmarshal(m, b)
+}
+func (m *IATBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IATBatch.Marshal(b, m, deterministic)
+}
+func (m *IATBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IATBatch.Merge(m, src)
+}
+func (m *IATBatch) XXX_Size() int {
+	return xxx_messageInfo_IATBatch.Size(m)
+}
+func (m *IATBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_IATBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IATBatch proto.InternalMessageInfo
+
+func (m *IATBatch) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *IATBatch) GetHeader() *IATBatchHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *IATBatch) GetEntries() []*IATEntryDetail {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *IATBatch) GetControl() *BatchControl {
+	if m != nil {
+		return m.Control
+	}
+	return nil
+}
+
+func (m *IATBatch) GetComments() []string {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+type FileHeader struct {
+	Id                       string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ImmediateDestination     string   `protobuf:"bytes,2,opt,name=immediate_destination,json=immediateDestination,proto3" json:"immediate_destination,omitempty"`
+	ImmediateOrigin          string   `protobuf:"bytes,3,opt,name=immediate_origin,json=immediateOrigin,proto3" json:"immediate_origin,omitempty"`
+	FileCreationDate         string   `protobuf:"bytes,4,opt,name=file_creation_date,json=fileCreationDate,proto3" json:"file_creation_date,omitempty"`
+	FileCreationTime         string   `protobuf:"bytes,5,opt,name=file_creation_time,json=fileCreationTime,proto3" json:"file_creation_time,omitempty"`
+	FileIdModifier           string   `protobuf:"bytes,6,opt,name=file_id_modifier,json=fileIdModifier,proto3" json:"file_id_modifier,omitempty"`
+	ImmediateDestinationName string   `protobuf:"bytes,7,opt,name=immediate_destination_name,json=immediateDestinationName,proto3" json:"immediate_destination_name,omitempty"`
+	ImmediateOriginName      string   `protobuf:"bytes,8,opt,name=immediate_origin_name,json=immediateOriginName,proto3" json:"immediate_origin_name,omitempty"`
+	ReferenceCode            string   `protobuf:"bytes,9,opt,name=reference_code,json=referenceCode,proto3" json:"reference_code,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{} `json:"-"`
+	XXX_unrecognized         []byte   `json:"-"`
+	XXX_sizecache            int32    `json:"-"`
+}
+
+func (m *FileHeader) Reset()         { *m = FileHeader{} }
+func (m *FileHeader) String() string { return proto.CompactTextString(m) }
+func (*FileHeader) ProtoMessage()    {}
+func (*FileHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{3}
+}
+
+func (m *FileHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileHeader.Unmarshal(m, b)
+}
+func (m *FileHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileHeader.Marshal(b, m, deterministic)
+}
+func (m *FileHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileHeader.Merge(m, src)
+}
+func (m *FileHeader) XXX_Size() int {
+	return xxx_messageInfo_FileHeader.Size(m)
+}
+func (m *FileHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileHeader proto.InternalMessageInfo
+
+func (m *FileHeader) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *FileHeader) GetImmediateDestination() string {
+	if m != nil {
+		return m.ImmediateDestination
+	}
+	return ""
+}
+
+func (m *FileHeader) GetImmediateOrigin() string {
+	if m != nil {
+		return m.ImmediateOrigin
+	}
+	return ""
+}
+
+func (m *FileHeader) GetFileCreationDate() string {
+	if m != nil {
+		return m.FileCreationDate
+	}
+	return ""
+}
+
+func (m *FileHeader) GetFileCreationTime() string {
+	if m != nil {
+		return m.FileCreationTime
+	}
+	return ""
+}
+
+func (m *FileHeader) GetFileIdModifier() string {
+	if m != nil {
+		return m.FileIdModifier
+	}
+	return ""
+}
+
+func (m *FileHeader) GetImmediateDestinationName() string {
+	if m != nil {
+		return m.ImmediateDestinationName
+	}
+	return ""
+}
+
+func (m *FileHeader) GetImmediateOriginName() string {
+	if m != nil {
+		return m.ImmediateOriginName
+	}
+	return ""
+}
+
+func (m *FileHeader) GetReferenceCode() string {
+	if m != nil {
+		return m.ReferenceCode
+	}
+	return ""
+}
+
+type FileControl struct {
+	Id                                 string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BatchCount                         int64    `protobuf:"varint,2,opt,name=batch_count,json=batchCount,proto3" json:"batch_count,omitempty"`
+	BlockCount                         int64    `protobuf:"varint,3,opt,name=block_count,json=blockCount,proto3" json:"block_count,omitempty"`
+	EntryAddendaCount                  int64    `protobuf:"varint,4,opt,name=entry_addenda_count,json=entryAddendaCount,proto3" json:"entry_addenda_count,omitempty"`
+	EntryHash                          int64    `protobuf:"varint,5,opt,name=entry_hash,json=entryHash,proto3" json:"entry_hash,omitempty"`
+	TotalDebitEntryDollarAmountInFile  int64    `protobuf:"varint,6,opt,name=total_debit_entry_dollar_amount_in_file,json=totalDebitEntryDollarAmountInFile,proto3" json:"total_debit_entry_dollar_amount_in_file,omitempty"`
+	TotalCreditEntryDollarAmountInFile int64    `protobuf:"varint,7,opt,name=total_credit_entry_dollar_amount_in_file,json=totalCreditEntryDollarAmountInFile,proto3" json:"total_credit_entry_dollar_amount_in_file,omitempty"`
+	XXX_NoUnkeyedLiteral               struct{} `json:"-"`
+	XXX_unrecognized                   []byte   `json:"-"`
+	XXX_sizecache                      int32    `json:"-"`
+}
+
+func (m *FileControl) Reset()         { *m = FileControl{} }
+func (m *FileControl) String() string { return proto.CompactTextString(m) }
+func (*FileControl) ProtoMessage()    {}
+func (*FileControl) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{4}
+}
+
+func (m *FileControl) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileControl.Unmarshal(m, b)
+}
+func (m *FileControl) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileControl.Marshal(b, m, deterministic)
+}
+func (m *FileControl) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileControl.Merge(m, src)
+}
+func (m *FileControl) XXX_Size() int {
+	return xxx_messageInfo_FileControl.Size(m)
+}
+func (m *FileControl) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileControl.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileControl proto.InternalMessageInfo
+
+func (m *FileControl) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *FileControl) GetBatchCount() int64 {
+	if m != nil {
+		return m.BatchCount
+	}
+	return 0
+}
+
+func (m *FileControl) GetBlockCount() int64 {
+	if m != nil {
+		return m.BlockCount
+	}
+	return 0
+}
+
+func (m *FileControl) GetEntryAddendaCount() int64 {
+	if m != nil {
+		return m.EntryAddendaCount
+	}
+	return 0
+}
+
+func (m *FileControl) GetEntryHash() int64 {
+	if m != nil {
+		return m.EntryHash
+	}
+	return 0
+}
+
+func (m *FileControl) GetTotalDebitEntryDollarAmountInFile() int64 {
+	if m != nil {
+		return m.TotalDebitEntryDollarAmountInFile
+	}
+	return 0
+}
+
+func (m *FileControl) GetTotalCreditEntryDollarAmountInFile() int64 {
+	if m != nil {
+		return m.TotalCreditEntryDollarAmountInFile
+	}
+	return 0
+}
+
+type ADVFileControl struct {
+	Id                                 string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BatchCount                         int64    `protobuf:"varint,2,opt,name=batch_count,json=batchCount,proto3" json:"batch_count,omitempty"`
+	BlockCount                         int64    `protobuf:"varint,3,opt,name=block_count,json=blockCount,proto3" json:"block_count,omitempty"`
+	EntryAddendaCount                  int64    `protobuf:"varint,4,opt,name=entry_addenda_count,json=entryAddendaCount,proto3" json:"entry_addenda_count,omitempty"`
+	EntryHash                          int64    `protobuf:"varint,5,opt,name=entry_hash,json=entryHash,proto3" json:"entry_hash,omitempty"`
+	TotalDebitEntryDollarAmountInFile  int64    `protobuf:"varint,6,opt,name=total_debit_entry_dollar_amount_in_file,json=totalDebitEntryDollarAmountInFile,proto3" json:"total_debit_entry_dollar_amount_in_file,omitempty"`
+	TotalCreditEntryDollarAmountInFile int64    `protobuf:"varint,7,opt,name=total_credit_entry_dollar_amount_in_file,json=totalCreditEntryDollarAmountInFile,proto3" json:"total_credit_entry_dollar_amount_in_file,omitempty"`
+	XXX_NoUnkeyedLiteral               struct{} `json:"-"`
+	XXX_unrecognized                   []byte   `json:"-"`
+	XXX_sizecache                      int32    `json:"-"`
+}
+
+func (m *ADVFileControl) Reset()         { *m = ADVFileControl{} }
+func (m *ADVFileControl) String() string { return proto.CompactTextString(m) }
+func (*ADVFileControl) ProtoMessage()    {}
+func (*ADVFileControl) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{5}
+}
+
+func (m *ADVFileControl) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ADVFileControl.Unmarshal(m, b)
+}
+func (m *ADVFileControl) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ADVFileControl.Marshal(b, m, deterministic)
+}
+func (m *ADVFileControl) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ADVFileControl.Merge(m, src)
+}
+func (m *ADVFileControl) XXX_Size() int {
+	return xxx_messageInfo_ADVFileControl.Size(m)
+}
+func (m *ADVFileControl) XXX_DiscardUnknown() {
+	xxx_messageInfo_ADVFileControl.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ADVFileControl proto.InternalMessageInfo
+
+func (m *ADVFileControl) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ADVFileControl) GetBatchCount() int64 {
+	if m != nil {
+		return m.BatchCount
+	}
+	return 0
+}
+
+func (m *ADVFileControl) GetBlockCount() int64 {
+	if m != nil {
+		return m.BlockCount
+	}
+	return 0
+}
+
+func (m *ADVFileControl) GetEntryAddendaCount() int64 {
+	if m != nil {
+		return m.EntryAddendaCount
+	}
+	return 0
+}
+
+func (m *ADVFileControl) GetEntryHash() int64 {
+	if m != nil {
+		return m.EntryHash
+	}
+	return 0
+}
+
+func (m *ADVFileControl) GetTotalDebitEntryDollarAmountInFile() int64 {
+	if m != nil {
+		return m.TotalDebitEntryDollarAmountInFile
+	}
+	return 0
+}
+
+func (m *ADVFileControl) GetTotalCreditEntryDollarAmountInFile() int64 {
+	if m != nil {
+		return m.TotalCreditEntryDollarAmountInFile
+	}
+	return 0
+}
+
+type BatchHeader struct {
+	Id                       string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServiceClassCode         int64    `protobuf:"varint,2,opt,name=service_class_code,json=serviceClassCode,proto3" json:"service_class_code,omitempty"`
+	CompanyName              string   `protobuf:"bytes,3,opt,name=company_name,json=companyName,proto3" json:"company_name,omitempty"`
+	CompanyDiscretionaryData string   `protobuf:"bytes,4,opt,name=company_discretionary_data,json=companyDiscretionaryData,proto3" json:"company_discretionary_data,omitempty"`
+	CompanyIdentification    string   `protobuf:"bytes,5,opt,name=company_identification,json=companyIdentification,proto3" json:"company_identification,omitempty"`
+	StandardEntryClassCode   string   `protobuf:"bytes,6,opt,name=standard_entry_class_code,json=standardEntryClassCode,proto3" json:"standard_entry_class_code,omitempty"`
+	CompanyEntryDescription  string   `protobuf:"bytes,7,opt,name=company_entry_description,json=companyEntryDescription,proto3" json:"company_entry_description,omitempty"`
+	CompanyDescriptiveDate   string   `protobuf:"bytes,8,opt,name=company_descriptive_date,json=companyDescriptiveDate,proto3" json:"company_descriptive_date,omitempty"`
+	EffectiveEntryDate       string   `protobuf:"bytes,9,opt,name=effective_entry_date,json=effectiveEntryDate,proto3" json:"effective_entry_date,omitempty"`
+	OriginatorStatusCode     int64    `protobuf:"varint,10,opt,name=originator_status_code,json=originatorStatusCode,proto3" json:"originator_status_code,omitempty"`
+	OdfiIdentification       string   `protobuf:"bytes,11,opt,name=odfi_identification,json=odfiIdentification,proto3" json:"odfi_identification,omitempty"`
+	BatchNumber              int64    `protobuf:"varint,12,opt,name=batch_number,json=batchNumber,proto3" json:"batch_number,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{} `json:"-"`
+	XXX_unrecognized         []byte   `json:"-"`
+	XXX_sizecache            int32    `json:"-"`
+}
+
+func (m *BatchHeader) Reset()         { *m = BatchHeader{} }
+func (m *BatchHeader) String() string { return proto.CompactTextString(m) }
+func (*BatchHeader) ProtoMessage()    {}
+func (*BatchHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{6}
+}
+
+func (m *BatchHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchHeader.Unmarshal(m, b)
+}
+func (m *BatchHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchHeader.Marshal(b, m, deterministic)
+}
+func (m *BatchHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchHeader.Merge(m, src)
+}
+func (m *BatchHeader) XXX_Size() int {
+	return xxx_messageInfo_BatchHeader.Size(m)
+}
+func (m *BatchHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchHeader proto.InternalMessageInfo
+
+func (m *BatchHeader) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *BatchHeader) GetServiceClassCode() int64 {
+	if m != nil {
+		return m.ServiceClassCode
+	}
+	return 0
+}
+
+func (m *BatchHeader) GetCompanyName() string {
+	if m != nil {
+		return m.CompanyName
+	}
+	return ""
+}
+
+func (m *BatchHeader) GetCompanyDiscretionaryData() string {
+	if m != nil {
+		return m.CompanyDiscretionaryData
+	}
+	return ""
+}
+
+func (m *BatchHeader) GetCompanyIdentification() string {
+	if m != nil {
+		return m.CompanyIdentification
+	}
+	return ""
+}
+
+func (m *BatchHeader) GetStandardEntryClassCode() string {
+	if m != nil {
+		return m.StandardEntryClassCode
+	}
+	return ""
+}
+
+func (m *BatchHeader) GetCompanyEntryDescription() string {
+	if m != nil {
+		return m.CompanyEntryDescription
+	}
+	return ""
+}
+
+func (m *BatchHeader) GetCompanyDescriptiveDate() string {
+	if m != nil {
+		return m.CompanyDescriptiveDate
+	}
+	return ""
+}
+
+func (m *BatchHeader) GetEffectiveEntryDate() string {
+	if m != nil {
+		return m.EffectiveEntryDate
+	}
+	return ""
+}
+
+func (m *BatchHeader) GetOriginatorStatusCode() int64 {
+	if m != nil {
+		return m.OriginatorStatusCode
+	}
+	return 0
+}
+
+func (m *BatchHeader) GetOdfiIdentification() string {
+	if m != nil {
+		return m.OdfiIdentification
+	}
+	return ""
+}
+
+func (m *BatchHeader) GetBatchNumber() int64 {
+	if m != nil {
+		return m.BatchNumber
+	}
+	return 0
+}
+
+type BatchControl struct {
+	Id                           string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServiceClassCode             int64    `protobuf:"varint,2,opt,name=service_class_code,json=serviceClassCode,proto3" json:"service_class_code,omitempty"`
+	EntryAddendaCount            int64    `protobuf:"varint,3,opt,name=entry_addenda_count,json=entryAddendaCount,proto3" json:"entry_addenda_count,omitempty"`
+	EntryHash                    int64    `protobuf:"varint,4,opt,name=entry_hash,json=entryHash,proto3" json:"entry_hash,omitempty"`
+	TotalDebitEntryDollarAmount  int64    `protobuf:"varint,5,opt,name=total_debit_entry_dollar_amount,json=totalDebitEntryDollarAmount,proto3" json:"total_debit_entry_dollar_amount,omitempty"`
+	TotalCreditEntryDollarAmount int64    `protobuf:"varint,6,opt,name=total_credit_entry_dollar_amount,json=totalCreditEntryDollarAmount,proto3" json:"total_credit_entry_dollar_amount,omitempty"`
+	CompanyIdentification        string   `protobuf:"bytes,7,opt,name=company_identification,json=companyIdentification,proto3" json:"company_identification,omitempty"`
+	MessageAuthenticationCode    string   `protobuf:"bytes,8,opt,name=message_authentication_code,json=messageAuthenticationCode,proto3" json:"message_authentication_code,omitempty"`
+	OdfiIdentification           string   `protobuf:"bytes,9,opt,name=odfi_identification,json=odfiIdentification,proto3" json:"odfi_identification,omitempty"`
+	BatchNumber                  int64    `protobuf:"varint,10,opt,name=batch_number,json=batchNumber,proto3" json:"batch_number,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{} `json:"-"`
+	XXX_unrecognized             []byte   `json:"-"`
+	XXX_sizecache                int32    `json:"-"`
+}
+
+func (m *BatchControl) Reset()         { *m = BatchControl{} }
+func (m *BatchControl) String() string { return proto.CompactTextString(m) }
+func (*BatchControl) ProtoMessage()    {}
+func (*BatchControl) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{7}
+}
+
+func (m *BatchControl) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchControl.Unmarshal(m, b)
+}
+func (m *BatchControl) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchControl.Marshal(b, m, deterministic)
+}
+func (m *BatchControl) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchControl.Merge(m, src)
+}
+func (m *BatchControl) XXX_Size() int {
+	return xxx_messageInfo_BatchControl.Size(m)
+}
+func (m *BatchControl) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchControl.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchControl proto.InternalMessageInfo
+
+func (m *BatchControl) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *BatchControl) GetServiceClassCode() int64 {
+	if m != nil {
+		return m.ServiceClassCode
+	}
+	return 0
+}
+
+func (m *BatchControl) GetEntryAddendaCount() int64 {
+	if m != nil {
+		return m.EntryAddendaCount
+	}
+	return 0
+}
+
+func (m *BatchControl) GetEntryHash() int64 {
+	if m != nil {
+		return m.EntryHash
+	}
+	return 0
+}
+
+func (m *BatchControl) GetTotalDebitEntryDollarAmount() int64 {
+	if m != nil {
+		return m.TotalDebitEntryDollarAmount
+	}
+	return 0
+}
+
+func (m *BatchControl) GetTotalCreditEntryDollarAmount() int64 {
+	if m != nil {
+		return m.TotalCreditEntryDollarAmount
+	}
+	return 0
+}
+
+func (m *BatchControl) GetCompanyIdentification() string {
+	if m != nil {
+		return m.CompanyIdentification
+	}
+	return ""
+}
+
+func (m *BatchControl) GetMessageAuthenticationCode() string {
+	if m != nil {
+		return m.MessageAuthenticationCode
+	}
+	return ""
+}
+
+func (m *BatchControl) GetOdfiIdentification() string {
+	if m != nil {
+		return m.OdfiIdentification
+	}
+	return ""
+}
+
+func (m *BatchControl) GetBatchNumber() int64 {
+	if m != nil {
+		return m.BatchNumber
+	}
+	return 0
+}
+
+type ADVBatchControl struct {
+	Id                           string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServiceClassCode             int64    `protobuf:"varint,2,opt,name=service_class_code,json=serviceClassCode,proto3" json:"service_class_code,omitempty"`
+	EntryAddendaCount            int64    `protobuf:"varint,3,opt,name=entry_addenda_count,json=entryAddendaCount,proto3" json:"entry_addenda_count,omitempty"`
+	EntryHash                    int64    `protobuf:"varint,4,opt,name=entry_hash,json=entryHash,proto3" json:"entry_hash,omitempty"`
+	TotalDebitEntryDollarAmount  int64    `protobuf:"varint,5,opt,name=total_debit_entry_dollar_amount,json=totalDebitEntryDollarAmount,proto3" json:"total_debit_entry_dollar_amount,omitempty"`
+	TotalCreditEntryDollarAmount int64    `protobuf:"varint,6,opt,name=total_credit_entry_dollar_amount,json=totalCreditEntryDollarAmount,proto3" json:"total_credit_entry_dollar_amount,omitempty"`
+	AchOperatorData              string   `protobuf:"bytes,7,opt,name=ach_operator_data,json=achOperatorData,proto3" json:"ach_operator_data,omitempty"`
+	OdfiIdentification           string   `protobuf:"bytes,8,opt,name=odfi_identification,json=odfiIdentification,proto3" json:"odfi_identification,omitempty"`
+	BatchNumber                  int64    `protobuf:"varint,9,opt,name=batch_number,json=batchNumber,proto3" json:"batch_number,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{} `json:"-"`
+	XXX_unrecognized             []byte   `json:"-"`
+	XXX_sizecache                int32    `json:"-"`
+}
+
+func (m *ADVBatchControl) Reset()         { *m = ADVBatchControl{} }
+func (m *ADVBatchControl) String() string { return proto.CompactTextString(m) }
+func (*ADVBatchControl) ProtoMessage()    {}
+func (*ADVBatchControl) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{8}
+}
+
+func (m *ADVBatchControl) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ADVBatchControl.Unmarshal(m, b)
+}
+func (m *ADVBatchControl) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ADVBatchControl.Marshal(b, m, deterministic)
+}
+func (m *ADVBatchControl) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ADVBatchControl.Merge(m, src)
+}
+func (m *ADVBatchControl) XXX_Size() int {
+	return xxx_messageInfo_ADVBatchControl.Size(m)
+}
+func (m *ADVBatchControl) XXX_DiscardUnknown() {
+	xxx_messageInfo_ADVBatchControl.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ADVBatchControl proto.InternalMessageInfo
+
+func (m *ADVBatchControl) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ADVBatchControl) GetServiceClassCode() int64 {
+	if m != nil {
+		return m.ServiceClassCode
+	}
+	return 0
+}
+
+func (m *ADVBatchControl) GetEntryAddendaCount() int64 {
+	if m != nil {
+		return m.EntryAddendaCount
+	}
+	return 0
+}
+
+func (m *ADVBatchControl) GetEntryHash() int64 {
+	if m != nil {
+		return m.EntryHash
+	}
+	return 0
+}
+
+func (m *ADVBatchControl) GetTotalDebitEntryDollarAmount() int64 {
+	if m != nil {
+		return m.TotalDebitEntryDollarAmount
+	}
+	return 0
+}
+
+func (m *ADVBatchControl) GetTotalCreditEntryDollarAmount() int64 {
+	if m != nil {
+		return m.TotalCreditEntryDollarAmount
+	}
+	return 0
+}
+
+func (m *ADVBatchControl) GetAchOperatorData() string {
+	if m != nil {
+		return m.AchOperatorData
+	}
+	return ""
+}
+
+func (m *ADVBatchControl) GetOdfiIdentification() string {
+	if m != nil {
+		return m.OdfiIdentification
+	}
+	return ""
+}
+
+func (m *ADVBatchControl) GetBatchNumber() int64 {
+	if m != nil {
+		return m.BatchNumber
+	}
+	return 0
+}
+
+type EntryDetail struct {
+	Id                     string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TransactionCode        int64                `protobuf:"varint,2,opt,name=transaction_code,json=transactionCode,proto3" json:"transaction_code,omitempty"`
+	RdfiIdentification     string               `protobuf:"bytes,3,opt,name=rdfi_identification,json=rdfiIdentification,proto3" json:"rdfi_identification,omitempty"`
+	CheckDigit             string               `protobuf:"bytes,4,opt,name=check_digit,json=checkDigit,proto3" json:"check_digit,omitempty"`
+	DfiAccountNumber       string               `protobuf:"bytes,5,opt,name=dfi_account_number,json=dfiAccountNumber,proto3" json:"dfi_account_number,omitempty"`
+	Amount                 int64                `protobuf:"varint,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	IdentificationNumber   string               `protobuf:"bytes,7,opt,name=identification_number,json=identificationNumber,proto3" json:"identification_number,omitempty"`
+	IndividualName         string               `protobuf:"bytes,8,opt,name=individual_name,json=individualName,proto3" json:"individual_name,omitempty"`
+	DiscretionaryData      string               `protobuf:"bytes,9,opt,name=discretionary_data,json=discretionaryData,proto3" json:"discretionary_data,omitempty"`
+	AddendaRecordIndicator int64                `protobuf:"varint,10,opt,name=addenda_record_indicator,json=addendaRecordIndicator,proto3" json:"addenda_record_indicator,omitempty"`
+	TraceNumber            string               `protobuf:"bytes,11,opt,name=trace_number,json=traceNumber,proto3" json:"trace_number,omitempty"`
+	Addenda02              *Addenda02           `protobuf:"bytes,12,opt,name=addenda02,proto3" json:"addenda02,omitempty"`
+	Addenda05              []*Addenda05         `protobuf:"bytes,13,rep,name=addenda05,proto3" json:"addenda05,omitempty"`
+	Addenda98              *Addenda98           `protobuf:"bytes,14,opt,name=addenda98,proto3" json:"addenda98,omitempty"`
+	Addenda99              *Addenda99           `protobuf:"bytes,15,opt,name=addenda99,proto3" json:"addenda99,omitempty"`
+	Addenda99Dishonored    *Addenda99Dishonored `protobuf:"bytes,16,opt,name=addenda99_dishonored,json=addenda99Dishonored,proto3" json:"addenda99_dishonored,omitempty"`
+	Addenda99Contested     *Addenda99Contested  `protobuf:"bytes,17,opt,name=addenda99_contested,json=addenda99Contested,proto3" json:"addenda99_contested,omitempty"`
+	Category               string               `protobuf:"bytes,18,opt,name=category,proto3" json:"category,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}             `json:"-"`
+	XXX_unrecognized       []byte               `json:"-"`
+	XXX_sizecache          int32                `json:"-"`
+}
+
+func (m *EntryDetail) Reset()         { *m = EntryDetail{} }
+func (m *EntryDetail) String() string { return proto.CompactTextString(m) }
+func (*EntryDetail) ProtoMessage()    {}
+func (*EntryDetail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{9}
+}
+
+func (m *EntryDetail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EntryDetail.Unmarshal(m, b)
+}
+func (m *EntryDetail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EntryDetail.Marshal(b, m, deterministic)
+}
+func (m *EntryDetail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EntryDetail.Merge(m, src)
+}
+func (m *EntryDetail) XXX_Size() int {
+	return xxx_messageInfo_EntryDetail.Size(m)
+}
+func (m *EntryDetail) XXX_DiscardUnknown() {
+	xxx_messageInfo_EntryDetail.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EntryDetail proto.InternalMessageInfo
+
+func (m *EntryDetail) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *EntryDetail) GetTransactionCode() int64 {
+	if m != nil {
+		return m.TransactionCode
+	}
+	return 0
+}
+
+func (m *EntryDetail) GetRdfiIdentification() string {
+	if m != nil {
+		return m.RdfiIdentification
+	}
+	return ""
+}
+
+func (m *EntryDetail) GetCheckDigit() string {
+	if m != nil {
+		return m.CheckDigit
+	}
+	return ""
+}
+
+func (m *EntryDetail) GetDfiAccountNumber() string {
+	if m != nil {
+		return m.DfiAccountNumber
+	}
+	return ""
+}
+
+func (m *EntryDetail) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *EntryDetail) GetIdentificationNumber() string {
+	if m != nil {
+		return m.IdentificationNumber
+	}
+	return ""
+}
+
+func (m *EntryDetail) GetIndividualName() string {
+	if m != nil {
+		return m.IndividualName
+	}
+	return ""
+}
+
+func (m *EntryDetail) GetDiscretionaryData() string {
+	if m != nil {
+		return m.DiscretionaryData
+	}
+	return ""
+}
+
+func (m *EntryDetail) GetAddendaRecordIndicator() int64 {
+	if m != nil {
+		return m.AddendaRecordIndicator
+	}
+	return 0
+}
+
+func (m *EntryDetail) GetTraceNumber() string {
+	if m != nil {
+		return m.TraceNumber
+	}
+	return ""
+}
+
+func (m *EntryDetail) GetAddenda02() *Addenda02 {
+	if m != nil {
+		return m.Addenda02
+	}
+	return nil
+}
+
+func (m *EntryDetail) GetAddenda05() []*Addenda05 {
+	if m != nil {
+		return m.Addenda05
+	}
+	return nil
+}
+
+func (m *EntryDetail) GetAddenda98() *Addenda98 {
+	if m != nil {
+		return m.Addenda98
+	}
+	return nil
+}
+
+func (m *EntryDetail) GetAddenda99() *Addenda99 {
+	if m != nil {
+		return m.Addenda99
+	}
+	return nil
+}
+
+func (m *EntryDetail) GetAddenda99Dishonored() *Addenda99Dishonored {
+	if m != nil {
+		return m.Addenda99Dishonored
+	}
+	return nil
+}
+
+func (m *EntryDetail) GetAddenda99Contested() *Addenda99Contested {
+	if m != nil {
+		return m.Addenda99Contested
+	}
+	return nil
+}
+
+func (m *EntryDetail) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+type ADVEntryDetail struct {
+	Id                       string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TransactionCode          int64      `protobuf:"varint,2,opt,name=transaction_code,json=transactionCode,proto3" json:"transaction_code,omitempty"`
+	RdfiIdentification       string     `protobuf:"bytes,3,opt,name=rdfi_identification,json=rdfiIdentification,proto3" json:"rdfi_identification,omitempty"`
+	CheckDigit               string     `protobuf:"bytes,4,opt,name=check_digit,json=checkDigit,proto3" json:"check_digit,omitempty"`
+	DfiAccountNumber         string     `protobuf:"bytes,5,opt,name=dfi_account_number,json=dfiAccountNumber,proto3" json:"dfi_account_number,omitempty"`
+	Amount                   int64      `protobuf:"varint,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	AdviceRoutingNumber      string     `protobuf:"bytes,7,opt,name=advice_routing_number,json=adviceRoutingNumber,proto3" json:"advice_routing_number,omitempty"`
+	FileIdentification       string     `protobuf:"bytes,8,opt,name=file_identification,json=fileIdentification,proto3" json:"file_identification,omitempty"`
+	AchOperatorData          string     `protobuf:"bytes,9,opt,name=ach_operator_data,json=achOperatorData,proto3" json:"ach_operator_data,omitempty"`
+	IndividualName           string     `protobuf:"bytes,10,opt,name=individual_name,json=individualName,proto3" json:"individual_name,omitempty"`
+	DiscretionaryData        string     `protobuf:"bytes,11,opt,name=discretionary_data,json=discretionaryData,proto3" json:"discretionary_data,omitempty"`
+	AddendaRecordIndicator   int64      `protobuf:"varint,12,opt,name=addenda_record_indicator,json=addendaRecordIndicator,proto3" json:"addenda_record_indicator,omitempty"`
+	AchOperatorRoutingNumber string     `protobuf:"bytes,13,opt,name=ach_operator_routing_number,json=achOperatorRoutingNumber,proto3" json:"ach_operator_routing_number,omitempty"`
+	JulianDay                int64      `protobuf:"varint,14,opt,name=julian_day,json=julianDay,proto3" json:"julian_day,omitempty"`
+	SequenceNumber           int64      `protobuf:"varint,15,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+	Addenda99                *Addenda99 `protobuf:"bytes,16,opt,name=addenda99,proto3" json:"addenda99,omitempty"`
+	Category                 string     `protobuf:"bytes,17,opt,name=category,proto3" json:"category,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{}   `json:"-"`
+	XXX_unrecognized         []byte     `json:"-"`
+	XXX_sizecache            int32      `json:"-"`
+}
+
+func (m *ADVEntryDetail) Reset()         { *m = ADVEntryDetail{} }
+func (m *ADVEntryDetail) String() string { return proto.CompactTextString(m) }
+func (*ADVEntryDetail) ProtoMessage()    {}
+func (*ADVEntryDetail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{10}
+}
+
+func (m *ADVEntryDetail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ADVEntryDetail.Unmarshal(m, b)
+}
+func (m *ADVEntryDetail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ADVEntryDetail.Marshal(b, m, deterministic)
+}
+func (m *ADVEntryDetail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ADVEntryDetail.Merge(m, src)
+}
+func (m *ADVEntryDetail) XXX_Size() int {
+	return xxx_messageInfo_ADVEntryDetail.Size(m)
+}
+func (m *ADVEntryDetail) XXX_DiscardUnknown() {
+	xxx_messageInfo_ADVEntryDetail.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ADVEntryDetail proto.InternalMessageInfo
+
+func (m *ADVEntryDetail) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ADVEntryDetail) GetTransactionCode() int64 {
+	if m != nil {
+		return m.TransactionCode
+	}
+	return 0
+}
+
+func (m *ADVEntryDetail) GetRdfiIdentification() string {
+	if m != nil {
+		return m.RdfiIdentification
+	}
+	return ""
+}
+
+func (m *ADVEntryDetail) GetCheckDigit() string {
+	if m != nil {
+		return m.CheckDigit
+	}
+	return ""
+}
+
+func (m *ADVEntryDetail) GetDfiAccountNumber() string {
+	if m != nil {
+		return m.DfiAccountNumber
+	}
+	return ""
+}
+
+func (m *ADVEntryDetail) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ADVEntryDetail) GetAdviceRoutingNumber() string {
+	if m != nil {
+		return m.AdviceRoutingNumber
+	}
+	return ""
+}
+
+func (m *ADVEntryDetail) GetFileIdentification() string {
+	if m != nil {
+		return m.FileIdentification
+	}
+	return ""
+}
+
+func (m *ADVEntryDetail) GetAchOperatorData() string {
+	if m != nil {
+		return m.AchOperatorData
+	}
+	return ""
+}
+
+func (m *ADVEntryDetail) GetIndividualName() string {
+	if m != nil {
+		return m.IndividualName
+	}
+	return ""
+}
+
+func (m *ADVEntryDetail) GetDiscretionaryData() string {
+	if m != nil {
+		return m.DiscretionaryData
+	}
+	return ""
+}
+
+func (m *ADVEntryDetail) GetAddendaRecordIndicator() int64 {
+	if m != nil {
+		return m.AddendaRecordIndicator
+	}
+	return 0
+}
+
+func (m *ADVEntryDetail) GetAchOperatorRoutingNumber() string {
+	if m != nil {
+		return m.AchOperatorRoutingNumber
+	}
+	return ""
+}
+
+func (m *ADVEntryDetail) GetJulianDay() int64 {
+	if m != nil {
+		return m.JulianDay
+	}
+	return 0
+}
+
+func (m *ADVEntryDetail) GetSequenceNumber() int64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
+}
+
+func (m *ADVEntryDetail) GetAddenda99() *Addenda99 {
+	if m != nil {
+		return m.Addenda99
+	}
+	return nil
+}
+
+func (m *ADVEntryDetail) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+type Addenda02 struct {
+	Id                            string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode                      string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	ReferenceInformationOne       string   `protobuf:"bytes,3,opt,name=reference_information_one,json=referenceInformationOne,proto3" json:"reference_information_one,omitempty"`
+	ReferenceInformationTwo       string   `protobuf:"bytes,4,opt,name=reference_information_two,json=referenceInformationTwo,proto3" json:"reference_information_two,omitempty"`
+	TerminalIdentificationCode    string   `protobuf:"bytes,5,opt,name=terminal_identification_code,json=terminalIdentificationCode,proto3" json:"terminal_identification_code,omitempty"`
+	TransactionSerialNumber       string   `protobuf:"bytes,6,opt,name=transaction_serial_number,json=transactionSerialNumber,proto3" json:"transaction_serial_number,omitempty"`
+	TransactionDate               string   `protobuf:"bytes,7,opt,name=transaction_date,json=transactionDate,proto3" json:"transaction_date,omitempty"`
+	AuthorizationCodeOrExpireDate string   `protobuf:"bytes,8,opt,name=authorization_code_or_expire_date,json=authorizationCodeOrExpireDate,proto3" json:"authorization_code_or_expire_date,omitempty"`
+	TerminalLocation              string   `protobuf:"bytes,9,opt,name=terminal_location,json=terminalLocation,proto3" json:"terminal_location,omitempty"`
+	TerminalCity                  string   `protobuf:"bytes,10,opt,name=terminal_city,json=terminalCity,proto3" json:"terminal_city,omitempty"`
+	TerminalState                 string   `protobuf:"bytes,11,opt,name=terminal_state,json=terminalState,proto3" json:"terminal_state,omitempty"`
+	TraceNumber                   string   `protobuf:"bytes,12,opt,name=trace_number,json=traceNumber,proto3" json:"trace_number,omitempty"`
+	XXX_NoUnkeyedLiteral          struct{} `json:"-"`
+	XXX_unrecognized              []byte   `json:"-"`
+	XXX_sizecache                 int32    `json:"-"`
+}
+
+func (m *Addenda02) Reset()         { *m = Addenda02{} }
+func (m *Addenda02) String() string { return proto.CompactTextString(m) }
+func (*Addenda02) ProtoMessage()    {}
+func (*Addenda02) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{11}
+}
+
+func (m *Addenda02) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda02.Unmarshal(m, b)
+}
+func (m *Addenda02) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda02.Marshal(b, m, deterministic)
+}
+func (m *Addenda02) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda02.Merge(m, src)
+}
+func (m *Addenda02) XXX_Size() int {
+	return xxx_messageInfo_Addenda02.Size(m)
+}
+func (m *Addenda02) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda02.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda02 proto.InternalMessageInfo
+
+func (m *Addenda02) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda02) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda02) GetReferenceInformationOne() string {
+	if m != nil {
+		return m.ReferenceInformationOne
+	}
+	return ""
+}
+
+func (m *Addenda02) GetReferenceInformationTwo() string {
+	if m != nil {
+		return m.ReferenceInformationTwo
+	}
+	return ""
+}
+
+func (m *Addenda02) GetTerminalIdentificationCode() string {
+	if m != nil {
+		return m.TerminalIdentificationCode
+	}
+	return ""
+}
+
+func (m *Addenda02) GetTransactionSerialNumber() string {
+	if m != nil {
+		return m.TransactionSerialNumber
+	}
+	return ""
+}
+
+func (m *Addenda02) GetTransactionDate() string {
+	if m != nil {
+		return m.TransactionDate
+	}
+	return ""
+}
+
+func (m *Addenda02) GetAuthorizationCodeOrExpireDate() string {
+	if m != nil {
+		return m.AuthorizationCodeOrExpireDate
+	}
+	return ""
+}
+
+func (m *Addenda02) GetTerminalLocation() string {
+	if m != nil {
+		return m.TerminalLocation
+	}
+	return ""
+}
+
+func (m *Addenda02) GetTerminalCity() string {
+	if m != nil {
+		return m.TerminalCity
+	}
+	return ""
+}
+
+func (m *Addenda02) GetTerminalState() string {
+	if m != nil {
+		return m.TerminalState
+	}
+	return ""
+}
+
+func (m *Addenda02) GetTraceNumber() string {
+	if m != nil {
+		return m.TraceNumber
+	}
+	return ""
+}
+
+type Addenda05 struct {
+	Id                        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode                  string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	PaymentRelatedInformation string   `protobuf:"bytes,3,opt,name=payment_related_information,json=paymentRelatedInformation,proto3" json:"payment_related_information,omitempty"`
+	SequenceNumber            int64    `protobuf:"varint,4,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+	EntryDetailSequenceNumber int64    `protobuf:"varint,5,opt,name=entry_detail_sequence_number,json=entryDetailSequenceNumber,proto3" json:"entry_detail_sequence_number,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{} `json:"-"`
+	XXX_unrecognized          []byte   `json:"-"`
+	XXX_sizecache             int32    `json:"-"`
+}
+
+func (m *Addenda05) Reset()         { *m = Addenda05{} }
+func (m *Addenda05) String() string { return proto.CompactTextString(m) }
+func (*Addenda05) ProtoMessage()    {}
+func (*Addenda05) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{12}
+}
+
+func (m *Addenda05) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda05.Unmarshal(m, b)
+}
+func (m *Addenda05) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda05.Marshal(b, m, deterministic)
+}
+func (m *Addenda05) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda05.Merge(m, src)
+}
+func (m *Addenda05) XXX_Size() int {
+	return xxx_messageInfo_Addenda05.Size(m)
+}
+func (m *Addenda05) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda05.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda05 proto.InternalMessageInfo
+
+func (m *Addenda05) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda05) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda05) GetPaymentRelatedInformation() string {
+	if m != nil {
+		return m.PaymentRelatedInformation
+	}
+	return ""
+}
+
+func (m *Addenda05) GetSequenceNumber() int64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
+}
+
+func (m *Addenda05) GetEntryDetailSequenceNumber() int64 {
+	if m != nil {
+		return m.EntryDetailSequenceNumber
+	}
+	return 0
+}
+
+type Addenda98 struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode             string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	ChangeCode           string   `protobuf:"bytes,3,opt,name=change_code,json=changeCode,proto3" json:"change_code,omitempty"`
+	OriginalTrace        string   `protobuf:"bytes,4,opt,name=original_trace,json=originalTrace,proto3" json:"original_trace,omitempty"`
+	OriginalDfi          string   `protobuf:"bytes,5,opt,name=original_dfi,json=originalDfi,proto3" json:"original_dfi,omitempty"`
+	CorrectedData        string   `protobuf:"bytes,6,opt,name=corrected_data,json=correctedData,proto3" json:"corrected_data,omitempty"`
+	TraceNumber          string   `protobuf:"bytes,7,opt,name=trace_number,json=traceNumber,proto3" json:"trace_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Addenda98) Reset()         { *m = Addenda98{} }
+func (m *Addenda98) String() string { return proto.CompactTextString(m) }
+func (*Addenda98) ProtoMessage()    {}
+func (*Addenda98) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{13}
+}
+
+func (m *Addenda98) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda98.Unmarshal(m, b)
+}
+func (m *Addenda98) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda98.Marshal(b, m, deterministic)
+}
+func (m *Addenda98) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda98.Merge(m, src)
+}
+func (m *Addenda98) XXX_Size() int {
+	return xxx_messageInfo_Addenda98.Size(m)
+}
+func (m *Addenda98) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda98.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda98 proto.InternalMessageInfo
+
+func (m *Addenda98) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda98) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda98) GetChangeCode() string {
+	if m != nil {
+		return m.ChangeCode
+	}
+	return ""
+}
+
+func (m *Addenda98) GetOriginalTrace() string {
+	if m != nil {
+		return m.OriginalTrace
+	}
+	return ""
+}
+
+func (m *Addenda98) GetOriginalDfi() string {
+	if m != nil {
+		return m.OriginalDfi
+	}
+	return ""
+}
+
+func (m *Addenda98) GetCorrectedData() string {
+	if m != nil {
+		return m.CorrectedData
+	}
+	return ""
+}
+
+func (m *Addenda98) GetTraceNumber() string {
+	if m != nil {
+		return m.TraceNumber
+	}
+	return ""
+}
+
+type Addenda99 struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode             string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	ReturnCode           string   `protobuf:"bytes,3,opt,name=return_code,json=returnCode,proto3" json:"return_code,omitempty"`
+	OriginalTrace        string   `protobuf:"bytes,4,opt,name=original_trace,json=originalTrace,proto3" json:"original_trace,omitempty"`
+	DateOfDeath          string   `protobuf:"bytes,5,opt,name=date_of_death,json=dateOfDeath,proto3" json:"date_of_death,omitempty"`
+	OriginalDfi          string   `protobuf:"bytes,6,opt,name=original_dfi,json=originalDfi,proto3" json:"original_dfi,omitempty"`
+	AddendaInformation   string   `protobuf:"bytes,7,opt,name=addenda_information,json=addendaInformation,proto3" json:"addenda_information,omitempty"`
+	TraceNumber          string   `protobuf:"bytes,8,opt,name=trace_number,json=traceNumber,proto3" json:"trace_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Addenda99) Reset()         { *m = Addenda99{} }
+func (m *Addenda99) String() string { return proto.CompactTextString(m) }
+func (*Addenda99) ProtoMessage()    {}
+func (*Addenda99) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{14}
+}
+
+func (m *Addenda99) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda99.Unmarshal(m, b)
+}
+func (m *Addenda99) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda99.Marshal(b, m, deterministic)
+}
+func (m *Addenda99) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda99.Merge(m, src)
+}
+func (m *Addenda99) XXX_Size() int {
+	return xxx_messageInfo_Addenda99.Size(m)
+}
+func (m *Addenda99) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda99.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda99 proto.InternalMessageInfo
+
+func (m *Addenda99) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda99) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda99) GetReturnCode() string {
+	if m != nil {
+		return m.ReturnCode
+	}
+	return ""
+}
+
+func (m *Addenda99) GetOriginalTrace() string {
+	if m != nil {
+		return m.OriginalTrace
+	}
+	return ""
+}
+
+func (m *Addenda99) GetDateOfDeath() string {
+	if m != nil {
+		return m.DateOfDeath
+	}
+	return ""
+}
+
+func (m *Addenda99) GetOriginalDfi() string {
+	if m != nil {
+		return m.OriginalDfi
+	}
+	return ""
+}
+
+func (m *Addenda99) GetAddendaInformation() string {
+	if m != nil {
+		return m.AddendaInformation
+	}
+	return ""
+}
+
+func (m *Addenda99) GetTraceNumber() string {
+	if m != nil {
+		return m.TraceNumber
+	}
+	return ""
+}
+
+type Addenda99Dishonored struct {
+	Id                                 string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode                           string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	DishonoredReturnReasonCode         string   `protobuf:"bytes,3,opt,name=dishonored_return_reason_code,json=dishonoredReturnReasonCode,proto3" json:"dishonored_return_reason_code,omitempty"`
+	OriginalEntryTraceNumber           string   `protobuf:"bytes,4,opt,name=original_entry_trace_number,json=originalEntryTraceNumber,proto3" json:"original_entry_trace_number,omitempty"`
+	OriginalReceivingDfiIdentification string   `protobuf:"bytes,5,opt,name=original_receiving_dfi_identification,json=originalReceivingDfiIdentification,proto3" json:"original_receiving_dfi_identification,omitempty"`
+	ReturnTraceNumber                  string   `protobuf:"bytes,6,opt,name=return_trace_number,json=returnTraceNumber,proto3" json:"return_trace_number,omitempty"`
+	ReturnSettlementDate               string   `protobuf:"bytes,7,opt,name=return_settlement_date,json=returnSettlementDate,proto3" json:"return_settlement_date,omitempty"`
+	ReturnReasonCode                   string   `protobuf:"bytes,8,opt,name=return_reason_code,json=returnReasonCode,proto3" json:"return_reason_code,omitempty"`
+	AddendaInformation                 string   `protobuf:"bytes,9,opt,name=addenda_information,json=addendaInformation,proto3" json:"addenda_information,omitempty"`
+	TraceNumber                        string   `protobuf:"bytes,10,opt,name=trace_number,json=traceNumber,proto3" json:"trace_number,omitempty"`
+	XXX_NoUnkeyedLiteral               struct{} `json:"-"`
+	XXX_unrecognized                   []byte   `json:"-"`
+	XXX_sizecache                      int32    `json:"-"`
+}
+
+func (m *Addenda99Dishonored) Reset()         { *m = Addenda99Dishonored{} }
+func (m *Addenda99Dishonored) String() string { return proto.CompactTextString(m) }
+func (*Addenda99Dishonored) ProtoMessage()    {}
+func (*Addenda99Dishonored) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{15}
+}
+
+func (m *Addenda99Dishonored) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda99Dishonored.Unmarshal(m, b)
+}
+func (m *Addenda99Dishonored) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda99Dishonored.Marshal(b, m, deterministic)
+}
+func (m *Addenda99Dishonored) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda99Dishonored.Merge(m, src)
+}
+func (m *Addenda99Dishonored) XXX_Size() int {
+	return xxx_messageInfo_Addenda99Dishonored.Size(m)
+}
+func (m *Addenda99Dishonored) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda99Dishonored.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda99Dishonored proto.InternalMessageInfo
+
+func (m *Addenda99Dishonored) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda99Dishonored) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda99Dishonored) GetDishonoredReturnReasonCode() string {
+	if m != nil {
+		return m.DishonoredReturnReasonCode
+	}
+	return ""
+}
+
+func (m *Addenda99Dishonored) GetOriginalEntryTraceNumber() string {
+	if m != nil {
+		return m.OriginalEntryTraceNumber
+	}
+	return ""
+}
+
+func (m *Addenda99Dishonored) GetOriginalReceivingDfiIdentification() string {
+	if m != nil {
+		return m.OriginalReceivingDfiIdentification
+	}
+	return ""
+}
+
+func (m *Addenda99Dishonored) GetReturnTraceNumber() string {
+	if m != nil {
+		return m.ReturnTraceNumber
+	}
+	return ""
+}
+
+func (m *Addenda99Dishonored) GetReturnSettlementDate() string {
+	if m != nil {
+		return m.ReturnSettlementDate
+	}
+	return ""
+}
+
+func (m *Addenda99Dishonored) GetReturnReasonCode() string {
+	if m != nil {
+		return m.ReturnReasonCode
+	}
+	return ""
+}
+
+func (m *Addenda99Dishonored) GetAddendaInformation() string {
+	if m != nil {
+		return m.AddendaInformation
+	}
+	return ""
+}
+
+func (m *Addenda99Dishonored) GetTraceNumber() string {
+	if m != nil {
+		return m.TraceNumber
+	}
+	return ""
+}
+
+type Addenda99Contested struct {
+	Id                                 string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode                           string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	ContestedReturnCode                string   `protobuf:"bytes,3,opt,name=contested_return_code,json=contestedReturnCode,proto3" json:"contested_return_code,omitempty"`
+	OriginalEntryTraceNumber           string   `protobuf:"bytes,4,opt,name=original_entry_trace_number,json=originalEntryTraceNumber,proto3" json:"original_entry_trace_number,omitempty"`
+	DateOriginalEntryReturned          string   `protobuf:"bytes,5,opt,name=date_original_entry_returned,json=dateOriginalEntryReturned,proto3" json:"date_original_entry_returned,omitempty"`
+	OriginalReceivingDfiIdentification string   `protobuf:"bytes,6,opt,name=original_receiving_dfi_identification,json=originalReceivingDfiIdentification,proto3" json:"original_receiving_dfi_identification,omitempty"`
+	OriginalSettlementDate             string   `protobuf:"bytes,7,opt,name=original_settlement_date,json=originalSettlementDate,proto3" json:"original_settlement_date,omitempty"`
+	ReturnTraceNumber                  string   `protobuf:"bytes,8,opt,name=return_trace_number,json=returnTraceNumber,proto3" json:"return_trace_number,omitempty"`
+	ReturnSettlementDate               string   `protobuf:"bytes,9,opt,name=return_settlement_date,json=returnSettlementDate,proto3" json:"return_settlement_date,omitempty"`
+	ReturnReasonCode                   string   `protobuf:"bytes,10,opt,name=return_reason_code,json=returnReasonCode,proto3" json:"return_reason_code,omitempty"`
+	DishonoredReturnTraceNumber        string   `protobuf:"bytes,11,opt,name=dishonored_return_trace_number,json=dishonoredReturnTraceNumber,proto3" json:"dishonored_return_trace_number,omitempty"`
+	DishonoredReturnSettlementDate     string   `protobuf:"bytes,12,opt,name=dishonored_return_settlement_date,json=dishonoredReturnSettlementDate,proto3" json:"dishonored_return_settlement_date,omitempty"`
+	DishonoredReturnReasonCode         string   `protobuf:"bytes,13,opt,name=dishonored_return_reason_code,json=dishonoredReturnReasonCode,proto3" json:"dishonored_return_reason_code,omitempty"`
+	TraceNumber                        string   `protobuf:"bytes,14,opt,name=trace_number,json=traceNumber,proto3" json:"trace_number,omitempty"`
+	XXX_NoUnkeyedLiteral               struct{} `json:"-"`
+	XXX_unrecognized                   []byte   `json:"-"`
+	XXX_sizecache                      int32    `json:"-"`
+}
+
+func (m *Addenda99Contested) Reset()         { *m = Addenda99Contested{} }
+func (m *Addenda99Contested) String() string { return proto.CompactTextString(m) }
+func (*Addenda99Contested) ProtoMessage()    {}
+func (*Addenda99Contested) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{16}
+}
+
+func (m *Addenda99Contested) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda99Contested.Unmarshal(m, b)
+}
+func (m *Addenda99Contested) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda99Contested.Marshal(b, m, deterministic)
+}
+func (m *Addenda99Contested) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda99Contested.Merge(m, src)
+}
+func (m *Addenda99Contested) XXX_Size() int {
+	return xxx_messageInfo_Addenda99Contested.Size(m)
+}
+func (m *Addenda99Contested) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda99Contested.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda99Contested proto.InternalMessageInfo
+
+func (m *Addenda99Contested) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda99Contested) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda99Contested) GetContestedReturnCode() string {
+	if m != nil {
+		return m.ContestedReturnCode
+	}
+	return ""
+}
+
+func (m *Addenda99Contested) GetOriginalEntryTraceNumber() string {
+	if m != nil {
+		return m.OriginalEntryTraceNumber
+	}
+	return ""
+}
+
+func (m *Addenda99Contested) GetDateOriginalEntryReturned() string {
+	if m != nil {
+		return m.DateOriginalEntryReturned
+	}
+	return ""
+}
+
+func (m *Addenda99Contested) GetOriginalReceivingDfiIdentification() string {
+	if m != nil {
+		return m.OriginalReceivingDfiIdentification
+	}
+	return ""
+}
+
+func (m *Addenda99Contested) GetOriginalSettlementDate() string {
+	if m != nil {
+		return m.OriginalSettlementDate
+	}
+	return ""
+}
+
+func (m *Addenda99Contested) GetReturnTraceNumber() string {
+	if m != nil {
+		return m.ReturnTraceNumber
+	}
+	return ""
+}
+
+func (m *Addenda99Contested) GetReturnSettlementDate() string {
+	if m != nil {
+		return m.ReturnSettlementDate
+	}
+	return ""
+}
+
+func (m *Addenda99Contested) GetReturnReasonCode() string {
+	if m != nil {
+		return m.ReturnReasonCode
+	}
+	return ""
+}
+
+func (m *Addenda99Contested) GetDishonoredReturnTraceNumber() string {
+	if m != nil {
+		return m.DishonoredReturnTraceNumber
+	}
+	return ""
+}
+
+func (m *Addenda99Contested) GetDishonoredReturnSettlementDate() string {
+	if m != nil {
+		return m.DishonoredReturnSettlementDate
+	}
+	return ""
+}
+
+func (m *Addenda99Contested) GetDishonoredReturnReasonCode() string {
+	if m != nil {
+		return m.DishonoredReturnReasonCode
+	}
+	return ""
+}
+
+func (m *Addenda99Contested) GetTraceNumber() string {
+	if m != nil {
+		return m.TraceNumber
+	}
+	return ""
+}
+
+type IATBatchHeader struct {
+	Id                                string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServiceClassCode                  int64    `protobuf:"varint,2,opt,name=service_class_code,json=serviceClassCode,proto3" json:"service_class_code,omitempty"`
+	IatIndicator                      string   `protobuf:"bytes,3,opt,name=iat_indicator,json=iatIndicator,proto3" json:"iat_indicator,omitempty"`
+	ForeignExchangeIndicator          string   `protobuf:"bytes,4,opt,name=foreign_exchange_indicator,json=foreignExchangeIndicator,proto3" json:"foreign_exchange_indicator,omitempty"`
+	ForeignExchangeReferenceIndicator int64    `protobuf:"varint,5,opt,name=foreign_exchange_reference_indicator,json=foreignExchangeReferenceIndicator,proto3" json:"foreign_exchange_reference_indicator,omitempty"`
+	ForeignExchangeReference          string   `protobuf:"bytes,6,opt,name=foreign_exchange_reference,json=foreignExchangeReference,proto3" json:"foreign_exchange_reference,omitempty"`
+	IsoDestinationCountryCode         string   `protobuf:"bytes,7,opt,name=iso_destination_country_code,json=isoDestinationCountryCode,proto3" json:"iso_destination_country_code,omitempty"`
+	OriginatorIdentification          string   `protobuf:"bytes,8,opt,name=originator_identification,json=originatorIdentification,proto3" json:"originator_identification,omitempty"`
+	StandardEntryClassCode            string   `protobuf:"bytes,9,opt,name=standard_entry_class_code,json=standardEntryClassCode,proto3" json:"standard_entry_class_code,omitempty"`
+	CompanyEntryDescription           string   `protobuf:"bytes,10,opt,name=company_entry_description,json=companyEntryDescription,proto3" json:"company_entry_description,omitempty"`
+	IsoOriginatingCurrencyCode        string   `protobuf:"bytes,11,opt,name=iso_originating_currency_code,json=isoOriginatingCurrencyCode,proto3" json:"iso_originating_currency_code,omitempty"`
+	IsoDestinationCurrencyCode        string   `protobuf:"bytes,12,opt,name=iso_destination_currency_code,json=isoDestinationCurrencyCode,proto3" json:"iso_destination_currency_code,omitempty"`
+	EffectiveEntryDate                string   `protobuf:"bytes,13,opt,name=effective_entry_date,json=effectiveEntryDate,proto3" json:"effective_entry_date,omitempty"`
+	OriginatorStatusCode              int64    `protobuf:"varint,14,opt,name=originator_status_code,json=originatorStatusCode,proto3" json:"originator_status_code,omitempty"`
+	OdfiIdentification                string   `protobuf:"bytes,15,opt,name=odfi_identification,json=odfiIdentification,proto3" json:"odfi_identification,omitempty"`
+	BatchNumber                       int64    `protobuf:"varint,16,opt,name=batch_number,json=batchNumber,proto3" json:"batch_number,omitempty"`
+	XXX_NoUnkeyedLiteral              struct{} `json:"-"`
+	XXX_unrecognized                  []byte   `json:"-"`
+	XXX_sizecache                     int32    `json:"-"`
+}
+
+func (m *IATBatchHeader) Reset()         { *m = IATBatchHeader{} }
+func (m *IATBatchHeader) String() string { return proto.CompactTextString(m) }
+func (*IATBatchHeader) ProtoMessage()    {}
+func (*IATBatchHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{17}
+}
+
+func (m *IATBatchHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IATBatchHeader.Unmarshal(m, b)
+}
+func (m *IATBatchHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IATBatchHeader.Marshal(b, m, deterministic)
+}
+func (m *IATBatchHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IATBatchHeader.Merge(m, src)
+}
+func (m *IATBatchHeader) XXX_Size() int {
+	return xxx_messageInfo_IATBatchHeader.Size(m)
+}
+func (m *IATBatchHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_IATBatchHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IATBatchHeader proto.InternalMessageInfo
+
+func (m *IATBatchHeader) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *IATBatchHeader) GetServiceClassCode() int64 {
+	if m != nil {
+		return m.ServiceClassCode
+	}
+	return 0
+}
+
+func (m *IATBatchHeader) GetIatIndicator() string {
+	if m != nil {
+		return m.IatIndicator
+	}
+	return ""
+}
+
+func (m *IATBatchHeader) GetForeignExchangeIndicator() string {
+	if m != nil {
+		return m.ForeignExchangeIndicator
+	}
+	return ""
+}
+
+func (m *IATBatchHeader) GetForeignExchangeReferenceIndicator() int64 {
+	if m != nil {
+		return m.ForeignExchangeReferenceIndicator
+	}
+	return 0
+}
+
+func (m *IATBatchHeader) GetForeignExchangeReference() string {
+	if m != nil {
+		return m.ForeignExchangeReference
+	}
+	return ""
+}
+
+func (m *IATBatchHeader) GetIsoDestinationCountryCode() string {
+	if m != nil {
+		return m.IsoDestinationCountryCode
+	}
+	return ""
+}
+
+func (m *IATBatchHeader) GetOriginatorIdentification() string {
+	if m != nil {
+		return m.OriginatorIdentification
+	}
+	return ""
+}
+
+func (m *IATBatchHeader) GetStandardEntryClassCode() string {
+	if m != nil {
+		return m.StandardEntryClassCode
+	}
+	return ""
+}
+
+func (m *IATBatchHeader) GetCompanyEntryDescription() string {
+	if m != nil {
+		return m.CompanyEntryDescription
+	}
+	return ""
+}
+
+func (m *IATBatchHeader) GetIsoOriginatingCurrencyCode() string {
+	if m != nil {
+		return m.IsoOriginatingCurrencyCode
+	}
+	return ""
+}
+
+func (m *IATBatchHeader) GetIsoDestinationCurrencyCode() string {
+	if m != nil {
+		return m.IsoDestinationCurrencyCode
+	}
+	return ""
+}
+
+func (m *IATBatchHeader) GetEffectiveEntryDate() string {
+	if m != nil {
+		return m.EffectiveEntryDate
+	}
+	return ""
+}
+
+func (m *IATBatchHeader) GetOriginatorStatusCode() int64 {
+	if m != nil {
+		return m.OriginatorStatusCode
+	}
+	return 0
+}
+
+func (m *IATBatchHeader) GetOdfiIdentification() string {
+	if m != nil {
+		return m.OdfiIdentification
+	}
+	return ""
+}
+
+func (m *IATBatchHeader) GetBatchNumber() int64 {
+	if m != nil {
+		return m.BatchNumber
+	}
+	return 0
+}
+
+type IATEntryDetail struct {
+	Id                              string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TransactionCode                 int64        `protobuf:"varint,2,opt,name=transaction_code,json=transactionCode,proto3" json:"transaction_code,omitempty"`
+	RdfiIdentification              string       `protobuf:"bytes,3,opt,name=rdfi_identification,json=rdfiIdentification,proto3" json:"rdfi_identification,omitempty"`
+	CheckDigit                      string       `protobuf:"bytes,4,opt,name=check_digit,json=checkDigit,proto3" json:"check_digit,omitempty"`
+	AddendaRecords                  int64        `protobuf:"varint,5,opt,name=addenda_records,json=addendaRecords,proto3" json:"addenda_records,omitempty"`
+	Amount                          int64        `protobuf:"varint,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	DfiAccountNumber                string       `protobuf:"bytes,7,opt,name=dfi_account_number,json=dfiAccountNumber,proto3" json:"dfi_account_number,omitempty"`
+	OfacScreeningIndicator          string       `protobuf:"bytes,8,opt,name=ofac_screening_indicator,json=ofacScreeningIndicator,proto3" json:"ofac_screening_indicator,omitempty"`
+	SecondaryOfacScreeningIndicator string       `protobuf:"bytes,9,opt,name=secondary_ofac_screening_indicator,json=secondaryOfacScreeningIndicator,proto3" json:"secondary_ofac_screening_indicator,omitempty"`
+	AddendaRecordIndicator          int64        `protobuf:"varint,10,opt,name=addenda_record_indicator,json=addendaRecordIndicator,proto3" json:"addenda_record_indicator,omitempty"`
+	TraceNumber                     string       `protobuf:"bytes,11,opt,name=trace_number,json=traceNumber,proto3" json:"trace_number,omitempty"`
+	Addenda10                       *Addenda10   `protobuf:"bytes,12,opt,name=addenda10,proto3" json:"addenda10,omitempty"`
+	Addenda11                       *Addenda11   `protobuf:"bytes,13,opt,name=addenda11,proto3" json:"addenda11,omitempty"`
+	Addenda12                       *Addenda12   `protobuf:"bytes,14,opt,name=addenda12,proto3" json:"addenda12,omitempty"`
+	Addenda13                       *Addenda13   `protobuf:"bytes,15,opt,name=addenda13,proto3" json:"addenda13,omitempty"`
+	Addenda14                       *Addenda14   `protobuf:"bytes,16,opt,name=addenda14,proto3" json:"addenda14,omitempty"`
+	Addenda15                       *Addenda15   `protobuf:"bytes,17,opt,name=addenda15,proto3" json:"addenda15,omitempty"`
+	Addenda16                       *Addenda16   `protobuf:"bytes,18,opt,name=addenda16,proto3" json:"addenda16,omitempty"`
+	Addenda17                       []*Addenda17 `protobuf:"bytes,19,rep,name=addenda17,proto3" json:"addenda17,omitempty"`
+	Addenda18                       []*Addenda18 `protobuf:"bytes,20,rep,name=addenda18,proto3" json:"addenda18,omitempty"`
+	Addenda98                       *Addenda98   `protobuf:"bytes,21,opt,name=addenda98,proto3" json:"addenda98,omitempty"`
+	Addenda99                       *Addenda99   `protobuf:"bytes,22,opt,name=addenda99,proto3" json:"addenda99,omitempty"`
+	Category                        string       `protobuf:"bytes,23,opt,name=category,proto3" json:"category,omitempty"`
+	XXX_NoUnkeyedLiteral            struct{}     `json:"-"`
+	XXX_unrecognized                []byte       `json:"-"`
+	XXX_sizecache                   int32        `json:"-"`
+}
+
+func (m *IATEntryDetail) Reset()         { *m = IATEntryDetail{} }
+func (m *IATEntryDetail) String() string { return proto.CompactTextString(m) }
+func (*IATEntryDetail) ProtoMessage()    {}
+func (*IATEntryDetail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{18}
+}
+
+func (m *IATEntryDetail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IATEntryDetail.Unmarshal(m, b)
+}
+func (m *IATEntryDetail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IATEntryDetail.Marshal(b, m, deterministic)
+}
+func (m *IATEntryDetail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IATEntryDetail.Merge(m, src)
+}
+func (m *IATEntryDetail) XXX_Size() int {
+	return xxx_messageInfo_IATEntryDetail.Size(m)
+}
+func (m *IATEntryDetail) XXX_DiscardUnknown() {
+	xxx_messageInfo_IATEntryDetail.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IATEntryDetail proto.InternalMessageInfo
+
+func (m *IATEntryDetail) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *IATEntryDetail) GetTransactionCode() int64 {
+	if m != nil {
+		return m.TransactionCode
+	}
+	return 0
+}
+
+func (m *IATEntryDetail) GetRdfiIdentification() string {
+	if m != nil {
+		return m.RdfiIdentification
+	}
+	return ""
+}
+
+func (m *IATEntryDetail) GetCheckDigit() string {
+	if m != nil {
+		return m.CheckDigit
+	}
+	return ""
+}
+
+func (m *IATEntryDetail) GetAddendaRecords() int64 {
+	if m != nil {
+		return m.AddendaRecords
+	}
+	return 0
+}
+
+func (m *IATEntryDetail) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *IATEntryDetail) GetDfiAccountNumber() string {
+	if m != nil {
+		return m.DfiAccountNumber
+	}
+	return ""
+}
+
+func (m *IATEntryDetail) GetOfacScreeningIndicator() string {
+	if m != nil {
+		return m.OfacScreeningIndicator
+	}
+	return ""
+}
+
+func (m *IATEntryDetail) GetSecondaryOfacScreeningIndicator() string {
+	if m != nil {
+		return m.SecondaryOfacScreeningIndicator
+	}
+	return ""
+}
+
+func (m *IATEntryDetail) GetAddendaRecordIndicator() int64 {
+	if m != nil {
+		return m.AddendaRecordIndicator
+	}
+	return 0
+}
+
+func (m *IATEntryDetail) GetTraceNumber() string {
+	if m != nil {
+		return m.TraceNumber
+	}
+	return ""
+}
+
+func (m *IATEntryDetail) GetAddenda10() *Addenda10 {
+	if m != nil {
+		return m.Addenda10
+	}
+	return nil
+}
+
+func (m *IATEntryDetail) GetAddenda11() *Addenda11 {
+	if m != nil {
+		return m.Addenda11
+	}
+	return nil
+}
+
+func (m *IATEntryDetail) GetAddenda12() *Addenda12 {
+	if m != nil {
+		return m.Addenda12
+	}
+	return nil
+}
+
+func (m *IATEntryDetail) GetAddenda13() *Addenda13 {
+	if m != nil {
+		return m.Addenda13
+	}
+	return nil
+}
+
+func (m *IATEntryDetail) GetAddenda14() *Addenda14 {
+	if m != nil {
+		return m.Addenda14
+	}
+	return nil
+}
+
+func (m *IATEntryDetail) GetAddenda15() *Addenda15 {
+	if m != nil {
+		return m.Addenda15
+	}
+	return nil
+}
+
+func (m *IATEntryDetail) GetAddenda16() *Addenda16 {
+	if m != nil {
+		return m.Addenda16
+	}
+	return nil
+}
+
+func (m *IATEntryDetail) GetAddenda17() []*Addenda17 {
+	if m != nil {
+		return m.Addenda17
+	}
+	return nil
+}
+
+func (m *IATEntryDetail) GetAddenda18() []*Addenda18 {
+	if m != nil {
+		return m.Addenda18
+	}
+	return nil
+}
+
+func (m *IATEntryDetail) GetAddenda98() *Addenda98 {
+	if m != nil {
+		return m.Addenda98
+	}
+	return nil
+}
+
+func (m *IATEntryDetail) GetAddenda99() *Addenda99 {
+	if m != nil {
+		return m.Addenda99
+	}
+	return nil
+}
+
+func (m *IATEntryDetail) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+type Addenda10 struct {
+	Id                        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode                  string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	TransactionTypeCode       string   `protobuf:"bytes,3,opt,name=transaction_type_code,json=transactionTypeCode,proto3" json:"transaction_type_code,omitempty"`
+	ForeignPaymentAmount      int64    `protobuf:"varint,4,opt,name=foreign_payment_amount,json=foreignPaymentAmount,proto3" json:"foreign_payment_amount,omitempty"`
+	ForeignTraceNumber        string   `protobuf:"bytes,5,opt,name=foreign_trace_number,json=foreignTraceNumber,proto3" json:"foreign_trace_number,omitempty"`
+	Name                      string   `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	EntryDetailSequenceNumber int64    `protobuf:"varint,7,opt,name=entry_detail_sequence_number,json=entryDetailSequenceNumber,proto3" json:"entry_detail_sequence_number,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{} `json:"-"`
+	XXX_unrecognized          []byte   `json:"-"`
+	XXX_sizecache             int32    `json:"-"`
+}
+
+func (m *Addenda10) Reset()         { *m = Addenda10{} }
+func (m *Addenda10) String() string { return proto.CompactTextString(m) }
+func (*Addenda10) ProtoMessage()    {}
+func (*Addenda10) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{19}
+}
+
+func (m *Addenda10) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda10.Unmarshal(m, b)
+}
+func (m *Addenda10) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda10.Marshal(b, m, deterministic)
+}
+func (m *Addenda10) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda10.Merge(m, src)
+}
+func (m *Addenda10) XXX_Size() int {
+	return xxx_messageInfo_Addenda10.Size(m)
+}
+func (m *Addenda10) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda10.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda10 proto.InternalMessageInfo
+
+func (m *Addenda10) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda10) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda10) GetTransactionTypeCode() string {
+	if m != nil {
+		return m.TransactionTypeCode
+	}
+	return ""
+}
+
+func (m *Addenda10) GetForeignPaymentAmount() int64 {
+	if m != nil {
+		return m.ForeignPaymentAmount
+	}
+	return 0
+}
+
+func (m *Addenda10) GetForeignTraceNumber() string {
+	if m != nil {
+		return m.ForeignTraceNumber
+	}
+	return ""
+}
+
+func (m *Addenda10) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Addenda10) GetEntryDetailSequenceNumber() int64 {
+	if m != nil {
+		return m.EntryDetailSequenceNumber
+	}
+	return 0
+}
+
+type Addenda11 struct {
+	Id                        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode                  string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	OriginatorName            string   `protobuf:"bytes,3,opt,name=originator_name,json=originatorName,proto3" json:"originator_name,omitempty"`
+	OriginatorStreetAddress   string   `protobuf:"bytes,4,opt,name=originator_street_address,json=originatorStreetAddress,proto3" json:"originator_street_address,omitempty"`
+	EntryDetailSequenceNumber int64    `protobuf:"varint,5,opt,name=entry_detail_sequence_number,json=entryDetailSequenceNumber,proto3" json:"entry_detail_sequence_number,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{} `json:"-"`
+	XXX_unrecognized          []byte   `json:"-"`
+	XXX_sizecache             int32    `json:"-"`
+}
+
+func (m *Addenda11) Reset()         { *m = Addenda11{} }
+func (m *Addenda11) String() string { return proto.CompactTextString(m) }
+func (*Addenda11) ProtoMessage()    {}
+func (*Addenda11) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{20}
+}
+
+func (m *Addenda11) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda11.Unmarshal(m, b)
+}
+func (m *Addenda11) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda11.Marshal(b, m, deterministic)
+}
+func (m *Addenda11) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda11.Merge(m, src)
+}
+func (m *Addenda11) XXX_Size() int {
+	return xxx_messageInfo_Addenda11.Size(m)
+}
+func (m *Addenda11) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda11.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda11 proto.InternalMessageInfo
+
+func (m *Addenda11) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda11) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda11) GetOriginatorName() string {
+	if m != nil {
+		return m.OriginatorName
+	}
+	return ""
+}
+
+func (m *Addenda11) GetOriginatorStreetAddress() string {
+	if m != nil {
+		return m.OriginatorStreetAddress
+	}
+	return ""
+}
+
+func (m *Addenda11) GetEntryDetailSequenceNumber() int64 {
+	if m != nil {
+		return m.EntryDetailSequenceNumber
+	}
+	return 0
+}
+
+type Addenda12 struct {
+	Id                          string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode                    string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	OriginatorCityStateProvince string   `protobuf:"bytes,3,opt,name=originator_city_state_province,json=originatorCityStateProvince,proto3" json:"originator_city_state_province,omitempty"`
+	OriginatorCountryPostalCode string   `protobuf:"bytes,4,opt,name=originator_country_postal_code,json=originatorCountryPostalCode,proto3" json:"originator_country_postal_code,omitempty"`
+	EntryDetailSequenceNumber   int64    `protobuf:"varint,5,opt,name=entry_detail_sequence_number,json=entryDetailSequenceNumber,proto3" json:"entry_detail_sequence_number,omitempty"`
+	XXX_NoUnkeyedLiteral        struct{} `json:"-"`
+	XXX_unrecognized            []byte   `json:"-"`
+	XXX_sizecache               int32    `json:"-"`
+}
+
+func (m *Addenda12) Reset()         { *m = Addenda12{} }
+func (m *Addenda12) String() string { return proto.CompactTextString(m) }
+func (*Addenda12) ProtoMessage()    {}
+func (*Addenda12) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{21}
+}
+
+func (m *Addenda12) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda12.Unmarshal(m, b)
+}
+func (m *Addenda12) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda12.Marshal(b, m, deterministic)
+}
+func (m *Addenda12) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda12.Merge(m, src)
+}
+func (m *Addenda12) XXX_Size() int {
+	return xxx_messageInfo_Addenda12.Size(m)
+}
+func (m *Addenda12) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda12.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda12 proto.InternalMessageInfo
+
+func (m *Addenda12) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda12) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda12) GetOriginatorCityStateProvince() string {
+	if m != nil {
+		return m.OriginatorCityStateProvince
+	}
+	return ""
+}
+
+func (m *Addenda12) GetOriginatorCountryPostalCode() string {
+	if m != nil {
+		return m.OriginatorCountryPostalCode
+	}
+	return ""
+}
+
+func (m *Addenda12) GetEntryDetailSequenceNumber() int64 {
+	if m != nil {
+		return m.EntryDetailSequenceNumber
+	}
+	return 0
+}
+
+type Addenda13 struct {
+	Id                        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode                  string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	OdfiName                  string   `protobuf:"bytes,3,opt,name=odfi_name,json=odfiName,proto3" json:"odfi_name,omitempty"`
+	OdfiidNumberQualifier     string   `protobuf:"bytes,4,opt,name=odfiid_number_qualifier,json=odfiidNumberQualifier,proto3" json:"odfiid_number_qualifier,omitempty"`
+	OdfiIdentification        string   `protobuf:"bytes,5,opt,name=odfi_identification,json=odfiIdentification,proto3" json:"odfi_identification,omitempty"`
+	OdfiBranchCountryCode     string   `protobuf:"bytes,6,opt,name=odfi_branch_country_code,json=odfiBranchCountryCode,proto3" json:"odfi_branch_country_code,omitempty"`
+	EntryDetailSequenceNumber int64    `protobuf:"varint,7,opt,name=entry_detail_sequence_number,json=entryDetailSequenceNumber,proto3" json:"entry_detail_sequence_number,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{} `json:"-"`
+	XXX_unrecognized          []byte   `json:"-"`
+	XXX_sizecache             int32    `json:"-"`
+}
+
+func (m *Addenda13) Reset()         { *m = Addenda13{} }
+func (m *Addenda13) String() string { return proto.CompactTextString(m) }
+func (*Addenda13) ProtoMessage()    {}
+func (*Addenda13) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{22}
+}
+
+func (m *Addenda13) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda13.Unmarshal(m, b)
+}
+func (m *Addenda13) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda13.Marshal(b, m, deterministic)
+}
+func (m *Addenda13) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda13.Merge(m, src)
+}
+func (m *Addenda13) XXX_Size() int {
+	return xxx_messageInfo_Addenda13.Size(m)
+}
+func (m *Addenda13) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda13.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda13 proto.InternalMessageInfo
+
+func (m *Addenda13) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda13) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda13) GetOdfiName() string {
+	if m != nil {
+		return m.OdfiName
+	}
+	return ""
+}
+
+func (m *Addenda13) GetOdfiidNumberQualifier() string {
+	if m != nil {
+		return m.OdfiidNumberQualifier
+	}
+	return ""
+}
+
+func (m *Addenda13) GetOdfiIdentification() string {
+	if m != nil {
+		return m.OdfiIdentification
+	}
+	return ""
+}
+
+func (m *Addenda13) GetOdfiBranchCountryCode() string {
+	if m != nil {
+		return m.OdfiBranchCountryCode
+	}
+	return ""
+}
+
+func (m *Addenda13) GetEntryDetailSequenceNumber() int64 {
+	if m != nil {
+		return m.EntryDetailSequenceNumber
+	}
+	return 0
+}
+
+type Addenda14 struct {
+	Id                        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode                  string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	RdfiName                  string   `protobuf:"bytes,3,opt,name=rdfi_name,json=rdfiName,proto3" json:"rdfi_name,omitempty"`
+	RdfiidNumberQualifier     string   `protobuf:"bytes,4,opt,name=rdfiid_number_qualifier,json=rdfiidNumberQualifier,proto3" json:"rdfiid_number_qualifier,omitempty"`
+	RdfiIdentification        string   `protobuf:"bytes,5,opt,name=rdfi_identification,json=rdfiIdentification,proto3" json:"rdfi_identification,omitempty"`
+	RdfiBranchCountryCode     string   `protobuf:"bytes,6,opt,name=rdfi_branch_country_code,json=rdfiBranchCountryCode,proto3" json:"rdfi_branch_country_code,omitempty"`
+	EntryDetailSequenceNumber int64    `protobuf:"varint,7,opt,name=entry_detail_sequence_number,json=entryDetailSequenceNumber,proto3" json:"entry_detail_sequence_number,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{} `json:"-"`
+	XXX_unrecognized          []byte   `json:"-"`
+	XXX_sizecache             int32    `json:"-"`
+}
+
+func (m *Addenda14) Reset()         { *m = Addenda14{} }
+func (m *Addenda14) String() string { return proto.CompactTextString(m) }
+func (*Addenda14) ProtoMessage()    {}
+func (*Addenda14) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{23}
+}
+
+func (m *Addenda14) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda14.Unmarshal(m, b)
+}
+func (m *Addenda14) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda14.Marshal(b, m, deterministic)
+}
+func (m *Addenda14) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda14.Merge(m, src)
+}
+func (m *Addenda14) XXX_Size() int {
+	return xxx_messageInfo_Addenda14.Size(m)
+}
+func (m *Addenda14) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda14.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda14 proto.InternalMessageInfo
+
+func (m *Addenda14) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda14) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda14) GetRdfiName() string {
+	if m != nil {
+		return m.RdfiName
+	}
+	return ""
+}
+
+func (m *Addenda14) GetRdfiidNumberQualifier() string {
+	if m != nil {
+		return m.RdfiidNumberQualifier
+	}
+	return ""
+}
+
+func (m *Addenda14) GetRdfiIdentification() string {
+	if m != nil {
+		return m.RdfiIdentification
+	}
+	return ""
+}
+
+func (m *Addenda14) GetRdfiBranchCountryCode() string {
+	if m != nil {
+		return m.RdfiBranchCountryCode
+	}
+	return ""
+}
+
+func (m *Addenda14) GetEntryDetailSequenceNumber() int64 {
+	if m != nil {
+		return m.EntryDetailSequenceNumber
+	}
+	return 0
+}
+
+type Addenda15 struct {
+	Id                        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode                  string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	ReceiverIdNumber          string   `protobuf:"bytes,3,opt,name=receiver_id_number,json=receiverIdNumber,proto3" json:"receiver_id_number,omitempty"`
+	ReceiverStreetAddress     string   `protobuf:"bytes,4,opt,name=receiver_street_address,json=receiverStreetAddress,proto3" json:"receiver_street_address,omitempty"`
+	EntryDetailSequenceNumber int64    `protobuf:"varint,5,opt,name=entry_detail_sequence_number,json=entryDetailSequenceNumber,proto3" json:"entry_detail_sequence_number,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{} `json:"-"`
+	XXX_unrecognized          []byte   `json:"-"`
+	XXX_sizecache             int32    `json:"-"`
+}
+
+func (m *Addenda15) Reset()         { *m = Addenda15{} }
+func (m *Addenda15) String() string { return proto.CompactTextString(m) }
+func (*Addenda15) ProtoMessage()    {}
+func (*Addenda15) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{24}
+}
+
+func (m *Addenda15) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda15.Unmarshal(m, b)
+}
+func (m *Addenda15) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda15.Marshal(b, m, deterministic)
+}
+func (m *Addenda15) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda15.Merge(m, src)
+}
+func (m *Addenda15) XXX_Size() int {
+	return xxx_messageInfo_Addenda15.Size(m)
+}
+func (m *Addenda15) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda15.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda15 proto.InternalMessageInfo
+
+func (m *Addenda15) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda15) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda15) GetReceiverIdNumber() string {
+	if m != nil {
+		return m.ReceiverIdNumber
+	}
+	return ""
+}
+
+func (m *Addenda15) GetReceiverStreetAddress() string {
+	if m != nil {
+		return m.ReceiverStreetAddress
+	}
+	return ""
+}
+
+func (m *Addenda15) GetEntryDetailSequenceNumber() int64 {
+	if m != nil {
+		return m.EntryDetailSequenceNumber
+	}
+	return 0
+}
+
+type Addenda16 struct {
+	Id                        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode                  string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	ReceiverCityStateProvince string   `protobuf:"bytes,3,opt,name=receiver_city_state_province,json=receiverCityStateProvince,proto3" json:"receiver_city_state_province,omitempty"`
+	ReceiverCountryPostalCode string   `protobuf:"bytes,4,opt,name=receiver_country_postal_code,json=receiverCountryPostalCode,proto3" json:"receiver_country_postal_code,omitempty"`
+	EntryDetailSequenceNumber int64    `protobuf:"varint,5,opt,name=entry_detail_sequence_number,json=entryDetailSequenceNumber,proto3" json:"entry_detail_sequence_number,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{} `json:"-"`
+	XXX_unrecognized          []byte   `json:"-"`
+	XXX_sizecache             int32    `json:"-"`
+}
+
+func (m *Addenda16) Reset()         { *m = Addenda16{} }
+func (m *Addenda16) String() string { return proto.CompactTextString(m) }
+func (*Addenda16) ProtoMessage()    {}
+func (*Addenda16) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{25}
+}
+
+func (m *Addenda16) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda16.Unmarshal(m, b)
+}
+func (m *Addenda16) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda16.Marshal(b, m, deterministic)
+}
+func (m *Addenda16) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda16.Merge(m, src)
+}
+func (m *Addenda16) XXX_Size() int {
+	return xxx_messageInfo_Addenda16.Size(m)
+}
+func (m *Addenda16) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda16.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda16 proto.InternalMessageInfo
+
+func (m *Addenda16) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda16) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda16) GetReceiverCityStateProvince() string {
+	if m != nil {
+		return m.ReceiverCityStateProvince
+	}
+	return ""
+}
+
+func (m *Addenda16) GetReceiverCountryPostalCode() string {
+	if m != nil {
+		return m.ReceiverCountryPostalCode
+	}
+	return ""
+}
+
+func (m *Addenda16) GetEntryDetailSequenceNumber() int64 {
+	if m != nil {
+		return m.EntryDetailSequenceNumber
+	}
+	return 0
+}
+
+type Addenda17 struct {
+	Id                        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode                  string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	PaymentRelatedInformation string   `protobuf:"bytes,3,opt,name=payment_related_information,json=paymentRelatedInformation,proto3" json:"payment_related_information,omitempty"`
+	SequenceNumber            int64    `protobuf:"varint,4,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+	EntryDetailSequenceNumber int64    `protobuf:"varint,5,opt,name=entry_detail_sequence_number,json=entryDetailSequenceNumber,proto3" json:"entry_detail_sequence_number,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{} `json:"-"`
+	XXX_unrecognized          []byte   `json:"-"`
+	XXX_sizecache             int32    `json:"-"`
+}
+
+func (m *Addenda17) Reset()         { *m = Addenda17{} }
+func (m *Addenda17) String() string { return proto.CompactTextString(m) }
+func (*Addenda17) ProtoMessage()    {}
+func (*Addenda17) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{26}
+}
+
+func (m *Addenda17) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda17.Unmarshal(m, b)
+}
+func (m *Addenda17) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda17.Marshal(b, m, deterministic)
+}
+func (m *Addenda17) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda17.Merge(m, src)
+}
+func (m *Addenda17) XXX_Size() int {
+	return xxx_messageInfo_Addenda17.Size(m)
+}
+func (m *Addenda17) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda17.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda17 proto.InternalMessageInfo
+
+func (m *Addenda17) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda17) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda17) GetPaymentRelatedInformation() string {
+	if m != nil {
+		return m.PaymentRelatedInformation
+	}
+	return ""
+}
+
+func (m *Addenda17) GetSequenceNumber() int64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
+}
+
+func (m *Addenda17) GetEntryDetailSequenceNumber() int64 {
+	if m != nil {
+		return m.EntryDetailSequenceNumber
+	}
+	return 0
+}
+
+type Addenda18 struct {
+	Id                                        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeCode                                  string   `protobuf:"bytes,2,opt,name=type_code,json=typeCode,proto3" json:"type_code,omitempty"`
+	ForeignCorrespondentBankName              string   `protobuf:"bytes,3,opt,name=foreign_correspondent_bank_name,json=foreignCorrespondentBankName,proto3" json:"foreign_correspondent_bank_name,omitempty"`
+	ForeignCorrespondentBankIdNumberQualifier string   `protobuf:"bytes,4,opt,name=foreign_correspondent_bank_id_number_qualifier,json=foreignCorrespondentBankIdNumberQualifier,proto3" json:"foreign_correspondent_bank_id_number_qualifier,omitempty"`
+	ForeignCorrespondentBankIdNumber          string   `protobuf:"bytes,5,opt,name=foreign_correspondent_bank_id_number,json=foreignCorrespondentBankIdNumber,proto3" json:"foreign_correspondent_bank_id_number,omitempty"`
+	ForeignCorrespondentBankBranchCountryCode string   `protobuf:"bytes,6,opt,name=foreign_correspondent_bank_branch_country_code,json=foreignCorrespondentBankBranchCountryCode,proto3" json:"foreign_correspondent_bank_branch_country_code,omitempty"`
+	SequenceNumber                            int64    `protobuf:"varint,7,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+	EntryDetailSequenceNumber                 int64    `protobuf:"varint,8,opt,name=entry_detail_sequence_number,json=entryDetailSequenceNumber,proto3" json:"entry_detail_sequence_number,omitempty"`
+	XXX_NoUnkeyedLiteral                      struct{} `json:"-"`
+	XXX_unrecognized                          []byte   `json:"-"`
+	XXX_sizecache                             int32    `json:"-"`
+}
+
+func (m *Addenda18) Reset()         { *m = Addenda18{} }
+func (m *Addenda18) String() string { return proto.CompactTextString(m) }
+func (*Addenda18) ProtoMessage()    {}
+func (*Addenda18) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd0672b9de1f4bad, []int{27}
+}
+
+func (m *Addenda18) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Addenda18.Unmarshal(m, b)
+}
+func (m *Addenda18) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Addenda18.Marshal(b, m, deterministic)
+}
+func (m *Addenda18) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Addenda18.Merge(m, src)
+}
+func (m *Addenda18) XXX_Size() int {
+	return xxx_messageInfo_Addenda18.Size(m)
+}
+func (m *Addenda18) XXX_DiscardUnknown() {
+	xxx_messageInfo_Addenda18.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Addenda18 proto.InternalMessageInfo
+
+func (m *Addenda18) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Addenda18) GetTypeCode() string {
+	if m != nil {
+		return m.TypeCode
+	}
+	return ""
+}
+
+func (m *Addenda18) GetForeignCorrespondentBankName() string {
+	if m != nil {
+		return m.ForeignCorrespondentBankName
+	}
+	return ""
+}
+
+func (m *Addenda18) GetForeignCorrespondentBankIdNumberQualifier() string {
+	if m != nil {
+		return m.ForeignCorrespondentBankIdNumberQualifier
+	}
+	return ""
+}
+
+func (m *Addenda18) GetForeignCorrespondentBankIdNumber() string {
+	if m != nil {
+		return m.ForeignCorrespondentBankIdNumber
+	}
+	return ""
+}
+
+func (m *Addenda18) GetForeignCorrespondentBankBranchCountryCode() string {
+	if m != nil {
+		return m.ForeignCorrespondentBankBranchCountryCode
+	}
+	return ""
+}
+
+func (m *Addenda18) GetSequenceNumber() int64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
+}
+
+func (m *Addenda18) GetEntryDetailSequenceNumber() int64 {
+	if m != nil {
+		return m.EntryDetailSequenceNumber
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*File)(nil), "ach.v1.File")
+	proto.RegisterType((*Batch)(nil), "ach.v1.Batch")
+	proto.RegisterType((*IATBatch)(nil), "ach.v1.IATBatch")
+	proto.RegisterType((*FileHeader)(nil), "ach.v1.FileHeader")
+	proto.RegisterType((*FileControl)(nil), "ach.v1.FileControl")
+	proto.RegisterType((*ADVFileControl)(nil), "ach.v1.ADVFileControl")
+	proto.RegisterType((*BatchHeader)(nil), "ach.v1.BatchHeader")
+	proto.RegisterType((*BatchControl)(nil), "ach.v1.BatchControl")
+	proto.RegisterType((*ADVBatchControl)(nil), "ach.v1.ADVBatchControl")
+	proto.RegisterType((*EntryDetail)(nil), "ach.v1.EntryDetail")
+	proto.RegisterType((*ADVEntryDetail)(nil), "ach.v1.ADVEntryDetail")
+	proto.RegisterType((*Addenda02)(nil), "ach.v1.Addenda02")
+	proto.RegisterType((*Addenda05)(nil), "ach.v1.Addenda05")
+	proto.RegisterType((*Addenda98)(nil), "ach.v1.Addenda98")
+	proto.RegisterType((*Addenda99)(nil), "ach.v1.Addenda99")
+	proto.RegisterType((*Addenda99Dishonored)(nil), "ach.v1.Addenda99Dishonored")
+	proto.RegisterType((*Addenda99Contested)(nil), "ach.v1.Addenda99Contested")
+	proto.RegisterType((*IATBatchHeader)(nil), "ach.v1.IATBatchHeader")
+	proto.RegisterType((*IATEntryDetail)(nil), "ach.v1.IATEntryDetail")
+	proto.RegisterType((*Addenda10)(nil), "ach.v1.Addenda10")
+	proto.RegisterType((*Addenda11)(nil), "ach.v1.Addenda11")
+	proto.RegisterType((*Addenda12)(nil), "ach.v1.Addenda12")
+	proto.RegisterType((*Addenda13)(nil), "ach.v1.Addenda13")
+	proto.RegisterType((*Addenda14)(nil), "ach.v1.Addenda14")
+	proto.RegisterType((*Addenda15)(nil), "ach.v1.Addenda15")
+	proto.RegisterType((*Addenda16)(nil), "ach.v1.Addenda16")
+	proto.RegisterType((*Addenda17)(nil), "ach.v1.Addenda17")
+	proto.RegisterType((*Addenda18)(nil), "ach.v1.Addenda18")
+}
+
+func init() { proto.RegisterFile("ach.proto", fileDescriptor_fd0672b9de1f4bad) }
+
+var fileDescriptor_fd0672b9de1f4bad = []byte{
+	// 2971 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5b, 0x4b, 0x6f, 0x1c, 0xc7,
+	0xf1, 0x87, 0xb8, 0x7c, 0x6d, 0x2d, 0xb9, 0x24, 0x87, 0x0f, 0x0d, 0x49, 0xc9, 0xa2, 0x68, 0x1b,
+	0x92, 0x1f, 0xa2, 0xb4, 0x14, 0x25, 0x91, 0x7e, 0xfe, 0x29, 0xae, 0x0c, 0x13, 0xfe, 0x47, 0xb4,
+	0x47, 0x44, 0x0e, 0xb9, 0x0c, 0x9a, 0x33, 0xbd, 0xdc, 0x8e, 0x76, 0x67, 0xe8, 0x9e, 0x5e, 0xda,
+	0xcc, 0x35, 0xd7, 0x7c, 0x03, 0x7f, 0x80, 0x20, 0x40, 0x4e, 0x39, 0xe5, 0x96, 0x63, 0x02, 0xe4,
+	0x92, 0x63, 0x90, 0x00, 0x09, 0x90, 0x7b, 0x6e, 0x01, 0x72, 0xc8, 0xc1, 0x41, 0xbf, 0xa6, 0x7b,
+	0x5e, 0x24, 0x57, 0x52, 0x82, 0xc0, 0xf0, 0x45, 0xd8, 0xad, 0xfa, 0x55, 0x75, 0x57, 0x75, 0x55,
+	0x75, 0x75, 0x2d, 0x05, 0x75, 0x14, 0x74, 0x37, 0x4e, 0x68, 0xcc, 0x62, 0x67, 0x9c, 0x7f, 0x3c,
+	0x6d, 0xad, 0xff, 0x7e, 0x04, 0x46, 0x3f, 0x21, 0x3d, 0xec, 0x34, 0x61, 0x84, 0x84, 0xee, 0x95,
+	0xb5, 0x2b, 0xb7, 0xeb, 0xde, 0x08, 0x09, 0x9d, 0xb7, 0x61, 0xbc, 0x8b, 0x51, 0x88, 0xa9, 0x3b,
+	0xb2, 0x76, 0xe5, 0x76, 0x63, 0xd3, 0xd9, 0x90, 0x12, 0x1b, 0x1c, 0xfd, 0xa9, 0xe0, 0x78, 0x0a,
+	0xe1, 0xdc, 0x82, 0x89, 0x23, 0xc4, 0x82, 0x2e, 0x4e, 0xdc, 0xda, 0x5a, 0xed, 0x76, 0x63, 0x73,
+	0x5a, 0x83, 0x1f, 0x73, 0xb2, 0xa7, 0xb9, 0x4e, 0x0b, 0x1a, 0x04, 0x31, 0x5f, 0x83, 0x47, 0x05,
+	0x78, 0x56, 0x83, 0xf7, 0x77, 0x0f, 0x25, 0x1e, 0x08, 0x62, 0x8f, 0x95, 0xc8, 0x1d, 0x98, 0x08,
+	0xe2, 0x88, 0xd1, 0xb8, 0xe7, 0x8e, 0x89, 0x8d, 0xcc, 0xdb, 0x1b, 0xd9, 0x93, 0x2c, 0x4f, 0x63,
+	0x9c, 0x47, 0xd0, 0x40, 0xe1, 0xa9, 0xaf, 0x45, 0xc6, 0x85, 0xc8, 0x92, 0x16, 0xd9, 0x6d, 0xff,
+	0xd0, 0x96, 0x02, 0x14, 0x9e, 0xaa, 0xcf, 0xce, 0x2d, 0x98, 0x61, 0x14, 0x91, 0x1e, 0xa6, 0x3e,
+	0xc5, 0x41, 0x4c, 0xc3, 0xc4, 0x9d, 0x58, 0xab, 0xdd, 0xae, 0x7b, 0x4d, 0x45, 0xf6, 0x24, 0xd5,
+	0x59, 0x81, 0xc9, 0x20, 0xee, 0xf7, 0x71, 0xc4, 0x12, 0x77, 0x52, 0x20, 0xd2, 0xef, 0xeb, 0xbf,
+	0x1a, 0x81, 0x31, 0xb1, 0xf1, 0x82, 0x3b, 0xdf, 0xc9, 0xb9, 0x73, 0x3e, 0xe3, 0xa1, 0x9c, 0x3f,
+	0xef, 0xc0, 0x04, 0x8e, 0x18, 0x25, 0xa9, 0x3f, 0x53, 0xf4, 0x93, 0x88, 0xd1, 0xb3, 0x36, 0x66,
+	0x88, 0xf4, 0x3c, 0x8d, 0x71, 0x36, 0x8c, 0x8b, 0x46, 0x85, 0xf2, 0x85, 0x8c, 0xf2, 0x2a, 0x1f,
+	0xe9, 0x25, 0xc6, 0xd6, 0x6a, 0x39, 0x1f, 0xd9, 0xab, 0x70, 0x1f, 0x3d, 0x51, 0x0b, 0x6d, 0x97,
+	0x39, 0xf7, 0xaa, 0x25, 0x98, 0x59, 0xcf, 0xf6, 0xae, 0xed, 0xb4, 0x89, 0x9c, 0xd3, 0x7e, 0x77,
+	0x05, 0x26, 0xf5, 0xd1, 0x17, 0xfc, 0xb6, 0x91, 0xf3, 0xdb, 0x52, 0x3e, 0x58, 0x72, 0xae, 0xbb,
+	0x97, 0x77, 0x9d, 0x2d, 0xf0, 0x4a, 0xbc, 0x67, 0x9b, 0x32, 0x96, 0x33, 0xe5, 0x9b, 0x1a, 0x80,
+	0xc9, 0x8f, 0x82, 0x31, 0xf7, 0x61, 0x91, 0xf4, 0xfb, 0x38, 0x24, 0x88, 0x61, 0x3f, 0xc4, 0x09,
+	0x23, 0x11, 0x62, 0x24, 0x8e, 0x84, 0x6d, 0x75, 0x6f, 0x21, 0x65, 0xb6, 0x0d, 0xcf, 0x79, 0x0b,
+	0x66, 0x8d, 0x50, 0x4c, 0xc9, 0x31, 0x89, 0xdc, 0x9a, 0xc0, 0xcf, 0xa4, 0xf4, 0x03, 0x41, 0x76,
+	0xde, 0x05, 0xa7, 0x43, 0x7a, 0xd8, 0x0f, 0x28, 0x16, 0xb2, 0x7e, 0x88, 0x18, 0x16, 0x56, 0xd5,
+	0xbd, 0x59, 0xce, 0xd9, 0x53, 0x8c, 0x36, 0x62, 0xb8, 0x88, 0x66, 0xa4, 0x8f, 0xdd, 0xb1, 0x22,
+	0xfa, 0x90, 0xf4, 0xb1, 0x73, 0x1b, 0x04, 0xcd, 0x27, 0xa1, 0xdf, 0x8f, 0x43, 0xd2, 0x21, 0x98,
+	0x8a, 0x00, 0xa8, 0x7b, 0x4d, 0x4e, 0xdf, 0x0f, 0x7f, 0xa0, 0xa8, 0xce, 0x07, 0xb0, 0x52, 0x6a,
+	0xa5, 0x1f, 0xa1, 0x3e, 0x76, 0x27, 0x84, 0x8c, 0x5b, 0x66, 0xea, 0x53, 0xd4, 0xc7, 0xce, 0x26,
+	0x2c, 0xe6, 0xcd, 0x95, 0x82, 0x93, 0x42, 0x70, 0x3e, 0x67, 0xb3, 0x90, 0x79, 0x13, 0x9a, 0x14,
+	0x77, 0x30, 0xc5, 0x51, 0x80, 0xfd, 0x20, 0x0e, 0xb1, 0x5b, 0x17, 0xe0, 0xe9, 0x94, 0xba, 0x17,
+	0x87, 0x78, 0xfd, 0x8f, 0x23, 0xd0, 0xb0, 0xd2, 0xbf, 0x70, 0x3c, 0x37, 0xa0, 0x21, 0x2a, 0x93,
+	0x1f, 0xc4, 0x83, 0x88, 0x89, 0x43, 0xa9, 0x79, 0x70, 0x24, 0xa3, 0x60, 0x10, 0x31, 0x01, 0xe8,
+	0xc5, 0xc1, 0x73, 0x05, 0xa8, 0x29, 0x00, 0x27, 0x49, 0xc0, 0x06, 0xcc, 0xf3, 0xb0, 0x3a, 0xf3,
+	0x51, 0x18, 0xe2, 0x28, 0x44, 0x0a, 0x38, 0x2a, 0x80, 0x73, 0x82, 0xb5, 0x2b, 0x39, 0x12, 0x7f,
+	0x1d, 0x40, 0xe2, 0xbb, 0x28, 0xe9, 0x0a, 0xd7, 0xd7, 0xbc, 0xba, 0xa0, 0x7c, 0x8a, 0x92, 0xae,
+	0xe3, 0xc1, 0x2d, 0x16, 0x33, 0xd4, 0xf3, 0x43, 0x7c, 0x44, 0x98, 0x2f, 0xa1, 0x61, 0xdc, 0xeb,
+	0x21, 0xea, 0xa3, 0x3e, 0xd7, 0xe0, 0x93, 0xc8, 0xe7, 0xee, 0x17, 0x47, 0x51, 0xf3, 0x6e, 0x0a,
+	0x78, 0x9b, 0xa3, 0x65, 0xac, 0x0b, 0xec, 0xae, 0x80, 0xee, 0x47, 0xa2, 0xce, 0x1f, 0xc2, 0x6d,
+	0xa9, 0x33, 0xa0, 0x38, 0xbc, 0x40, 0xe9, 0x84, 0x50, 0xba, 0x2e, 0xf0, 0x7b, 0x02, 0x5e, 0xa1,
+	0x75, 0xfd, 0xcf, 0x23, 0xd0, 0xcc, 0x16, 0xd7, 0xef, 0xbd, 0xfb, 0xea, 0xbc, 0xfb, 0x87, 0x51,
+	0x68, 0x58, 0xc5, 0xae, 0xe0, 0xda, 0x77, 0xc1, 0x49, 0x30, 0x3d, 0x25, 0x3c, 0xfa, 0x7b, 0x28,
+	0x49, 0x64, 0x0e, 0x48, 0x0f, 0xcf, 0x2a, 0xce, 0x1e, 0x67, 0xf0, 0x34, 0x70, 0x6e, 0xc2, 0x54,
+	0x10, 0xf7, 0x4f, 0x50, 0x74, 0x26, 0x13, 0x4b, 0x16, 0x93, 0x86, 0xa2, 0x89, 0x84, 0xfa, 0x00,
+	0x56, 0x34, 0x24, 0x24, 0x49, 0x40, 0x31, 0x4f, 0x4f, 0xc4, 0x2d, 0x41, 0x0c, 0xa9, 0x82, 0xe2,
+	0x2a, 0x44, 0xdb, 0x06, 0xb4, 0x11, 0x43, 0xce, 0x03, 0x58, 0xd2, 0xd2, 0x24, 0xc4, 0x11, 0x23,
+	0x1d, 0x12, 0xc8, 0x3a, 0x27, 0x8b, 0xcb, 0xa2, 0xe2, 0xee, 0x67, 0x98, 0xce, 0x0e, 0x2c, 0x27,
+	0x0c, 0x45, 0x21, 0xa2, 0xa1, 0xf2, 0x9b, 0x65, 0x8c, 0x2c, 0x35, 0x4b, 0x1a, 0x20, 0x3c, 0x65,
+	0x4c, 0x7a, 0x0f, 0x96, 0xf5, 0x8a, 0xca, 0xe3, 0x38, 0x09, 0x28, 0x39, 0x11, 0x8b, 0xca, 0x8a,
+	0x73, 0x55, 0x01, 0xd4, 0x1d, 0x90, 0xb2, 0x9d, 0x6d, 0x70, 0x53, 0x5b, 0x35, 0xf9, 0x14, 0xcb,
+	0xd2, 0x29, 0x6b, 0x8e, 0xb6, 0xa6, 0x6d, 0xd8, 0xa2, 0x80, 0xde, 0x83, 0x05, 0xdc, 0xe9, 0xe0,
+	0x40, 0xe0, 0xd5, 0xba, 0x88, 0xe9, 0xe2, 0xe3, 0xa4, 0x3c, 0xb9, 0x24, 0x97, 0xd8, 0x82, 0x25,
+	0x59, 0xd2, 0x10, 0x8b, 0xa9, 0x9f, 0x30, 0xc4, 0x06, 0xca, 0x3e, 0x10, 0x87, 0xb5, 0x60, 0xb8,
+	0xcf, 0x04, 0x53, 0x58, 0x77, 0x17, 0xe6, 0xe3, 0xb0, 0x43, 0xf2, 0xce, 0x6c, 0xc8, 0x65, 0x38,
+	0x2b, 0xe7, 0xc9, 0x9b, 0x30, 0x25, 0x53, 0x2d, 0x1a, 0xf4, 0x8f, 0x30, 0x75, 0xa7, 0x84, 0x72,
+	0x99, 0x7e, 0x4f, 0x05, 0x69, 0xfd, 0x1f, 0x35, 0x98, 0xb2, 0xef, 0xb7, 0x97, 0x8c, 0xa9, 0x8a,
+	0xd4, 0xac, 0x5d, 0x2e, 0x35, 0x47, 0xf3, 0xa9, 0xd9, 0x86, 0x1b, 0x17, 0xa4, 0xa6, 0x4a, 0xe7,
+	0xd5, 0x73, 0x52, 0xd2, 0xf9, 0x04, 0xd6, 0x2e, 0x4a, 0x46, 0x95, 0xd9, 0xd7, 0xce, 0x4b, 0xc2,
+	0x73, 0xe2, 0x79, 0xe2, 0xbc, 0x78, 0xfe, 0x08, 0x56, 0xfb, 0x38, 0x49, 0xd0, 0x31, 0xf6, 0xd1,
+	0x80, 0x75, 0x39, 0x4f, 0x72, 0xa4, 0x2b, 0x65, 0x6c, 0x2d, 0x2b, 0xc8, 0x6e, 0x06, 0x71, 0xde,
+	0xb1, 0xd7, 0x2f, 0x7d, 0xec, 0x50, 0x3c, 0xf6, 0x5f, 0xd6, 0x60, 0x26, 0xd7, 0xa7, 0x7d, 0x7f,
+	0xf2, 0xd5, 0x27, 0xff, 0x36, 0xcc, 0xa1, 0xa0, 0xeb, 0xc7, 0x27, 0x98, 0x8a, 0x8c, 0x15, 0xe5,
+	0x4f, 0x1e, 0xfa, 0x0c, 0x0a, 0xba, 0x07, 0x8a, 0x2e, 0xaa, 0x5e, 0xc5, 0x71, 0x4d, 0x5e, 0xfa,
+	0xb8, 0xea, 0xc5, 0xe3, 0xfa, 0xed, 0x38, 0x34, 0xac, 0xa6, 0xb5, 0x70, 0x54, 0x6f, 0xc1, 0x2c,
+	0xa3, 0x28, 0x4a, 0x50, 0x60, 0xe2, 0x4a, 0x1e, 0xd4, 0x8c, 0x45, 0xd7, 0xd1, 0x44, 0x4b, 0xb6,
+	0x27, 0x8b, 0xbf, 0x43, 0x8b, 0xdb, 0xbb, 0x01, 0x8d, 0xa0, 0x8b, 0x83, 0xe7, 0x7e, 0x48, 0x8e,
+	0x09, 0x53, 0x45, 0x1f, 0x04, 0xa9, 0xcd, 0x29, 0x3c, 0x4e, 0xb8, 0x42, 0x14, 0x88, 0x13, 0xd7,
+	0x56, 0xa8, 0xfe, 0x31, 0xec, 0x90, 0x5d, 0xc9, 0x90, 0xa6, 0x38, 0x4b, 0x30, 0x9e, 0x71, 0xbc,
+	0xfa, 0x26, 0x7a, 0xe2, 0xcc, 0xc2, 0x5a, 0xd1, 0x84, 0xea, 0x89, 0x33, 0x4c, 0xa5, 0xec, 0x16,
+	0xcc, 0x90, 0x28, 0x24, 0xa7, 0x24, 0x1c, 0xa0, 0x9e, 0xdd, 0x1e, 0x36, 0x0d, 0x59, 0x5c, 0x64,
+	0x77, 0xc0, 0x29, 0xb9, 0xc0, 0x64, 0x0a, 0xcd, 0x85, 0x85, 0x9b, 0x6b, 0x1b, 0x5c, 0x1d, 0xc6,
+	0xf2, 0x11, 0xe8, 0x73, 0x7d, 0x01, 0x3f, 0x63, 0x95, 0x4d, 0x4b, 0x8a, 0x2f, 0x5f, 0x83, 0xfb,
+	0x9a, 0xcb, 0x0f, 0x93, 0x51, 0x14, 0x60, 0xbd, 0x7b, 0x59, 0x9c, 0x1b, 0x82, 0xa6, 0x36, 0x7d,
+	0x17, 0xea, 0x4a, 0xf8, 0xde, 0xa6, 0x28, 0xc9, 0x8d, 0xcd, 0xb9, 0xf4, 0xed, 0xa4, 0x19, 0x9e,
+	0xc1, 0xd8, 0x02, 0x0f, 0xdc, 0xe9, 0xb5, 0x5a, 0x99, 0xc0, 0x03, 0x23, 0xf0, 0xc0, 0x12, 0xd8,
+	0xd9, 0x76, 0x9b, 0xa5, 0x2b, 0xec, 0x6c, 0x7b, 0x06, 0x63, 0x0b, 0xec, 0xb8, 0x33, 0xe5, 0x02,
+	0x3b, 0x46, 0x60, 0xc7, 0x79, 0x0a, 0x0b, 0xe9, 0x17, 0xde, 0x1a, 0x74, 0xe3, 0x28, 0xa6, 0x38,
+	0x74, 0x67, 0x85, 0xec, 0x6a, 0x41, 0xb6, 0x9d, 0x42, 0xbc, 0x79, 0x54, 0x24, 0x3a, 0x9f, 0x81,
+	0x21, 0x8b, 0x77, 0x25, 0x4e, 0x18, 0x0e, 0xdd, 0x39, 0xa1, 0x6e, 0xa5, 0xa0, 0x6e, 0x4f, 0x23,
+	0x3c, 0x07, 0x15, 0x68, 0xe2, 0x65, 0x86, 0x18, 0x3e, 0x8e, 0xe9, 0x99, 0xeb, 0x08, 0xff, 0xa7,
+	0xdf, 0xd7, 0xff, 0x34, 0x26, 0x1a, 0xd4, 0xef, 0x78, 0x32, 0x6d, 0xc2, 0x22, 0x0a, 0x45, 0xe5,
+	0xa6, 0xf1, 0x80, 0x91, 0xe8, 0x38, 0x9b, 0x4c, 0xf3, 0x92, 0xe9, 0x49, 0x5e, 0x1a, 0x96, 0xf3,
+	0xea, 0x61, 0x57, 0x56, 0xb7, 0xe4, 0xdb, 0x2e, 0x63, 0x4b, 0x69, 0x51, 0xac, 0x97, 0x17, 0xc5,
+	0x92, 0x44, 0x85, 0x21, 0x12, 0xb5, 0xf1, 0x22, 0x89, 0x3a, 0x75, 0x6e, 0xa2, 0x7e, 0x08, 0xab,
+	0x99, 0xdd, 0xe7, 0x1c, 0x35, 0x2d, 0x7b, 0x5b, 0xcb, 0x8e, 0xac, 0xb7, 0xae, 0x03, 0xfc, 0x78,
+	0xd0, 0x23, 0x28, 0xf2, 0x43, 0x74, 0x26, 0x72, 0xac, 0xe6, 0xd5, 0x25, 0xa5, 0x8d, 0xce, 0xb8,
+	0xbd, 0x09, 0xfe, 0x72, 0x20, 0x1e, 0xa2, 0x4a, 0xe3, 0x8c, 0xc0, 0x34, 0x35, 0xb9, 0x50, 0x0c,
+	0x76, 0x76, 0xdc, 0xd9, 0x4b, 0x64, 0x9e, 0x1d, 0xdc, 0x73, 0xb9, 0xe0, 0xfe, 0xcd, 0x28, 0xd4,
+	0xd3, 0x0a, 0x52, 0x88, 0xeb, 0x55, 0xa8, 0xb3, 0xb3, 0x13, 0x6c, 0x02, 0xba, 0xee, 0x4d, 0x72,
+	0x82, 0xee, 0x9c, 0xcd, 0xd3, 0x99, 0x44, 0x9d, 0x98, 0xf6, 0x65, 0x15, 0x8e, 0x23, 0xfd, 0x32,
+	0xb8, 0x9a, 0x02, 0xf6, 0x0d, 0xff, 0x20, 0x3a, 0x47, 0x96, 0x7d, 0x15, 0xbb, 0xa3, 0xd5, 0xb2,
+	0x87, 0x5f, 0xc5, 0xce, 0xff, 0xc1, 0x35, 0x86, 0x69, 0x9f, 0x44, 0xa8, 0x97, 0x8b, 0x3c, 0xb9,
+	0x4f, 0x19, 0xf9, 0x2b, 0x1a, 0x93, 0x0d, 0x41, 0xbd, 0x73, 0x3b, 0x5d, 0x13, 0x4c, 0x09, 0x0f,
+	0x31, 0xe9, 0x74, 0xf9, 0x5c, 0xb8, 0x6a, 0x01, 0x9e, 0x09, 0xbe, 0xf2, 0x7e, 0x2e, 0xd5, 0x45,
+	0xd7, 0xae, 0xae, 0x75, 0x8b, 0x2e, 0x5a, 0xf6, 0x4f, 0xe1, 0x26, 0xef, 0xde, 0x62, 0x4a, 0x7e,
+	0x62, 0xb6, 0xe7, 0xc7, 0xd4, 0xc7, 0x5f, 0x9f, 0x10, 0x9a, 0x79, 0x27, 0x5c, 0xcf, 0x00, 0xf9,
+	0x26, 0x0f, 0xe8, 0x13, 0x81, 0x12, 0x9a, 0xde, 0x81, 0xb9, 0xd4, 0xe4, 0x5e, 0x9c, 0xe9, 0xe6,
+	0x66, 0x35, 0xe3, 0xff, 0x15, 0xdd, 0x79, 0x1d, 0xa6, 0x53, 0x70, 0x40, 0xd8, 0x99, 0x4a, 0x9b,
+	0x29, 0x4d, 0xdc, 0x23, 0xec, 0x8c, 0xcf, 0x3d, 0x52, 0x10, 0x7f, 0x4c, 0x60, 0x95, 0x30, 0xa9,
+	0x28, 0x7f, 0x44, 0xe0, 0xc2, 0xdd, 0x34, 0x55, 0xb8, 0x9b, 0xd6, 0xff, 0x76, 0xc5, 0x44, 0xd0,
+	0x83, 0xe1, 0x22, 0xe8, 0x23, 0x58, 0x3d, 0x41, 0x67, 0x7d, 0x1c, 0x31, 0x9f, 0xe2, 0x1e, 0x62,
+	0x38, 0xb4, 0x63, 0x41, 0xc5, 0xd0, 0xb2, 0x82, 0x78, 0x12, 0x61, 0x05, 0x43, 0x59, 0xca, 0x8c,
+	0x96, 0xa6, 0xcc, 0xc7, 0x70, 0x4d, 0x3f, 0xee, 0x78, 0xfd, 0xf6, 0xf3, 0x52, 0xb2, 0x2f, 0x5c,
+	0xc6, 0xa6, 0xc4, 0x3f, 0xcb, 0x28, 0x58, 0xff, 0xbb, 0x31, 0x72, 0x67, 0x7b, 0x38, 0x23, 0x45,
+	0xfd, 0x46, 0xd1, 0xb1, 0x62, 0xd7, 0x74, 0xfd, 0xe6, 0x24, 0x01, 0x78, 0x13, 0x9a, 0xea, 0xed,
+	0xd6, 0xf3, 0x85, 0x63, 0x55, 0x02, 0x4c, 0x6b, 0xea, 0x21, 0x27, 0xf2, 0xa3, 0x48, 0x61, 0x61,
+	0x87, 0xa8, 0x30, 0x6f, 0x68, 0x5a, 0xbb, 0x43, 0xb8, 0xa6, 0x20, 0xa6, 0x14, 0x07, 0xdc, 0x93,
+	0xa2, 0x0a, 0xca, 0x60, 0x9e, 0x4e, 0xa9, 0xa2, 0x02, 0xe6, 0x0f, 0x75, 0xa2, 0x78, 0xa8, 0xdf,
+	0x8c, 0x18, 0x7b, 0x77, 0x86, 0xb6, 0x97, 0x62, 0x36, 0xa0, 0x51, 0xc6, 0x5e, 0x49, 0x1a, 0xc6,
+	0xde, 0x75, 0x98, 0x0e, 0xc5, 0x20, 0xaf, 0xe3, 0x87, 0x18, 0xb1, 0xae, 0x36, 0x98, 0x13, 0x0f,
+	0x3a, 0x6d, 0x4e, 0x2a, 0xf8, 0x64, 0xbc, 0xe8, 0x93, 0xbb, 0x69, 0x9b, 0x90, 0x89, 0x2d, 0x69,
+	0xb3, 0x6e, 0x05, 0xec, 0xa0, 0xca, 0x7b, 0x67, 0xb2, 0xe8, 0x9d, 0x6f, 0x6b, 0x30, 0x5f, 0xd2,
+	0xa7, 0x0c, 0xe7, 0xa7, 0x5d, 0xb8, 0x6e, 0xba, 0x20, 0x5f, 0xb9, 0x8c, 0x62, 0x94, 0xc4, 0x19,
+	0xcf, 0xad, 0x18, 0x90, 0x27, 0x30, 0x9e, 0x80, 0x08, 0x15, 0x1f, 0xc2, 0x6a, 0x6a, 0xbe, 0x8c,
+	0xef, 0xcc, 0xce, 0xd5, 0xb0, 0x45, 0x43, 0x44, 0x03, 0x73, 0x68, 0xcc, 0x70, 0xbe, 0x80, 0x37,
+	0x53, 0x71, 0x8a, 0x03, 0x4c, 0x4e, 0xf9, 0x6d, 0x56, 0xd2, 0x9c, 0x48, 0xcf, 0xaf, 0x6b, 0xb0,
+	0xa7, 0xb1, 0xed, 0x42, 0xb3, 0xb2, 0x01, 0xf3, 0xca, 0x92, 0xcc, 0x4e, 0xe4, 0xb9, 0xcc, 0x49,
+	0x96, 0xbd, 0x85, 0x2d, 0x58, 0x52, 0xf8, 0x04, 0x33, 0xd6, 0xc3, 0xa2, 0x16, 0x58, 0x35, 0x75,
+	0x41, 0x72, 0x9f, 0xa5, 0x4c, 0x3d, 0x7e, 0x2e, 0xf1, 0x97, 0x3c, 0xa8, 0x59, 0x9a, 0xf7, 0x52,
+	0x45, 0x04, 0xd4, 0x2f, 0x1d, 0x01, 0x50, 0x8c, 0x80, 0x9f, 0x8d, 0x83, 0x53, 0x6c, 0x2d, 0x87,
+	0x0b, 0x80, 0x4d, 0x58, 0x4c, 0xdb, 0x56, 0xbf, 0x98, 0x32, 0xf3, 0x29, 0xd3, 0x33, 0xb9, 0xf3,
+	0x92, 0x27, 0xfe, 0x31, 0x5c, 0x0b, 0xcd, 0x70, 0x3c, 0xd5, 0x21, 0x17, 0xc7, 0xa1, 0x3a, 0xe8,
+	0xe5, 0x30, 0x9d, 0x91, 0x2b, 0x1d, 0x9e, 0x02, 0x5c, 0x3e, 0x64, 0xc6, 0x2f, 0x1d, 0x32, 0xdb,
+	0x90, 0xee, 0xb7, 0x22, 0x08, 0xf4, 0xe0, 0xab, 0x97, 0x0b, 0x83, 0x8a, 0x60, 0x9b, 0x1c, 0x3e,
+	0xd8, 0xea, 0x43, 0x07, 0x1b, 0x54, 0x04, 0xdb, 0x1e, 0xbc, 0x56, 0xcc, 0xea, 0x92, 0xe7, 0xdd,
+	0x6a, 0x3e, 0xad, 0xed, 0x8d, 0xee, 0xc3, 0xcd, 0xa2, 0x92, 0xfc, 0x9e, 0xe5, 0x55, 0xfc, 0x5a,
+	0x5e, 0x4f, 0x6e, 0xf7, 0x17, 0x56, 0x99, 0xe9, 0x0b, 0xab, 0x4c, 0x3e, 0x1d, 0x9a, 0xc5, 0x74,
+	0xf8, 0xe7, 0x38, 0x34, 0xb3, 0xbf, 0xaa, 0xbd, 0xe4, 0x68, 0xe8, 0x75, 0x98, 0x26, 0x88, 0x59,
+	0x9d, 0xb9, 0xcc, 0x89, 0x29, 0x82, 0x98, 0xe9, 0xc7, 0x3f, 0x80, 0x95, 0x4e, 0x4c, 0x31, 0x39,
+	0x8e, 0x7c, 0xfc, 0xb5, 0xba, 0x63, 0x8d, 0x84, 0xca, 0x05, 0x85, 0x78, 0xa2, 0x00, 0x46, 0xfa,
+	0x00, 0xde, 0x28, 0x48, 0xdb, 0x3d, 0xa9, 0xd6, 0x23, 0x7b, 0x83, 0x9b, 0x39, 0x3d, 0x9e, 0x69,
+	0x4e, 0xcf, 0xdb, 0x4e, 0xaa, 0xd0, 0x1d, 0x2f, 0xdd, 0x4e, 0xaa, 0x86, 0xa7, 0x26, 0x49, 0xe2,
+	0xcc, 0x8f, 0x5e, 0xe2, 0xd9, 0x46, 0xcf, 0xa4, 0xa7, 0x64, 0x2a, 0x2c, 0x93, 0x24, 0xb6, 0x7e,
+	0xf6, 0xda, 0x93, 0x08, 0xe1, 0xb2, 0xf7, 0x61, 0xd9, 0x1a, 0x10, 0x97, 0x3e, 0xc9, 0x5c, 0x03,
+	0x18, 0x66, 0x80, 0x5e, 0x7f, 0xf1, 0x01, 0x3a, 0x9c, 0x3f, 0x40, 0xdf, 0x85, 0xeb, 0xdc, 0x68,
+	0xbd, 0x2d, 0x5e, 0x4b, 0x82, 0x01, 0xe5, 0x0e, 0x51, 0x56, 0xcb, 0x64, 0x59, 0x21, 0x49, 0x7c,
+	0x60, 0x30, 0x7b, 0x0a, 0xa2, 0xaf, 0xd1, 0x82, 0xdf, 0x32, 0x2a, 0xa6, 0x52, 0x15, 0xb6, 0xe3,
+	0x6c, 0x15, 0x55, 0xc3, 0xf8, 0xe9, 0x17, 0x18, 0xc6, 0x37, 0x87, 0x1f, 0xc6, 0xcf, 0x5c, 0x7a,
+	0xcc, 0x37, 0x5b, 0x1c, 0xf3, 0xfd, 0x7c, 0x52, 0x64, 0xde, 0xff, 0xec, 0x70, 0xe2, 0x16, 0xcc,
+	0x64, 0x5f, 0xdb, 0x89, 0x4a, 0xa8, 0x66, 0xe6, 0x91, 0x9d, 0x54, 0xce, 0x25, 0xca, 0xa7, 0x1b,
+	0x13, 0x15, 0xd3, 0x0d, 0x7e, 0x99, 0x74, 0x50, 0xe0, 0xf3, 0x47, 0x3f, 0x8e, 0x78, 0x3c, 0x99,
+	0x44, 0x56, 0xbf, 0xc8, 0x70, 0xfe, 0x33, 0xcd, 0x36, 0xd9, 0xfb, 0x19, 0xac, 0x27, 0x38, 0x88,
+	0x79, 0x84, 0x9f, 0xf9, 0x95, 0x3a, 0x64, 0x2a, 0xdc, 0x48, 0x91, 0x07, 0xe5, 0xca, 0xfe, 0x4b,
+	0xc3, 0xc0, 0xd6, 0xbd, 0x8a, 0x61, 0x60, 0xeb, 0x9e, 0x67, 0x30, 0xb6, 0x40, 0xcb, 0x9d, 0x2e,
+	0x17, 0x68, 0x19, 0x81, 0x96, 0x2d, 0xb0, 0x59, 0x31, 0x0c, 0x6c, 0x99, 0x71, 0x63, 0xcb, 0x1e,
+	0x37, 0xb6, 0xee, 0x57, 0x0c, 0x03, 0x5b, 0xf7, 0x8d, 0xc0, 0x7d, 0x5b, 0x60, 0xab, 0x62, 0x86,
+	0xd1, 0xda, 0x32, 0x02, 0x5b, 0xb6, 0xc0, 0x03, 0x77, 0xae, 0x5c, 0xc0, 0x0c, 0x34, 0x5b, 0xf6,
+	0x40, 0xb3, 0xf5, 0xd0, 0x75, 0xca, 0x05, 0x1e, 0x1a, 0x81, 0x87, 0xb6, 0xc0, 0x23, 0x77, 0xbe,
+	0x74, 0x64, 0xda, 0x7a, 0x64, 0x04, 0x1e, 0xd9, 0x02, 0xdb, 0xee, 0x42, 0xb9, 0x80, 0x19, 0x99,
+	0xb6, 0xb6, 0xb3, 0x33, 0xd6, 0xc5, 0x61, 0x67, 0xac, 0x4b, 0x43, 0x4e, 0x7a, 0xae, 0xe6, 0x26,
+	0x3d, 0xbf, 0x30, 0x4f, 0xba, 0xd6, 0xbd, 0xa1, 0x3b, 0x55, 0xbb, 0x82, 0x18, 0xa0, 0xea, 0x54,
+	0x2d, 0xe6, 0xa1, 0x96, 0xd9, 0x82, 0x25, 0x7d, 0x1b, 0xea, 0x37, 0xbe, 0xca, 0x6f, 0xf9, 0x44,
+	0x5f, 0x50, 0xdc, 0xcf, 0x25, 0x53, 0xfd, 0x6a, 0x72, 0x0f, 0x34, 0x3d, 0xdb, 0x34, 0x8d, 0xa9,
+	0x91, 0xa2, 0xe4, 0xd9, 0xbd, 0x92, 0x03, 0xa3, 0x62, 0x36, 0x28, 0xef, 0x57, 0xf1, 0xf9, 0xc2,
+	0xe7, 0xfe, 0xc4, 0x45, 0xcf, 0xfd, 0xbf, 0x98, 0xe7, 0x7e, 0xab, 0x35, 0x9c, 0xaf, 0x6e, 0xc1,
+	0x8c, 0x75, 0x35, 0x58, 0xbf, 0x92, 0x37, 0x0d, 0x59, 0x8c, 0x2d, 0xdf, 0xcb, 0xdc, 0xd7, 0x09,
+	0xa3, 0x18, 0x33, 0x1f, 0x85, 0x21, 0xc5, 0x49, 0xa2, 0x47, 0x60, 0xf6, 0x35, 0xc2, 0xf9, 0xbb,
+	0x92, 0xfd, 0xf2, 0xf3, 0x8c, 0x9f, 0x5a, 0xc1, 0x30, 0xe4, 0xd8, 0x6f, 0x0f, 0x5e, 0xb3, 0xf6,
+	0xcd, 0x07, 0x4c, 0x72, 0x80, 0xe4, 0x9f, 0xd0, 0xf8, 0x94, 0x44, 0x81, 0xb6, 0x77, 0xd5, 0xa0,
+	0xf8, 0xc4, 0x49, 0xcc, 0x93, 0x3e, 0x57, 0x90, 0xbc, 0x12, 0xd5, 0xe8, 0x9c, 0xc4, 0x89, 0xf8,
+	0xe5, 0x8d, 0x2f, 0x3b, 0x5a, 0x50, 0x22, 0x41, 0x9f, 0x0b, 0x8c, 0xd8, 0xc9, 0x4b, 0x7b, 0xe1,
+	0xd7, 0x96, 0x17, 0xee, 0x0f, 0xe7, 0x85, 0x55, 0xa8, 0x8b, 0xbb, 0xdc, 0x3a, 0xe0, 0x49, 0x4e,
+	0x10, 0x47, 0xfb, 0x10, 0xae, 0xf2, 0xcf, 0x24, 0x54, 0x3b, 0xf1, 0xbf, 0x1c, 0xa0, 0x9e, 0xfc,
+	0xbb, 0x27, 0x69, 0xd6, 0xa2, 0x64, 0xcb, 0x6d, 0x7c, 0xa1, 0x99, 0x55, 0x0d, 0xc2, 0x58, 0x65,
+	0x83, 0xf0, 0x08, 0x5c, 0x21, 0x70, 0x44, 0x51, 0xa4, 0xff, 0x3c, 0x46, 0x37, 0x8c, 0xe3, 0x66,
+	0xa5, 0xc7, 0x82, 0x6d, 0x37, 0x8b, 0x2f, 0x9d, 0x21, 0xb6, 0xeb, 0xb6, 0x86, 0x76, 0x1d, 0xcd,
+	0xbb, 0x8e, 0x5a, 0xae, 0xa3, 0xe7, 0xbb, 0x8e, 0x56, 0xb9, 0x8e, 0x56, 0xbb, 0x8e, 0x96, 0xba,
+	0x8e, 0x5e, 0xe0, 0x3a, 0xfa, 0x9f, 0x71, 0xdd, 0x5f, 0xad, 0xe2, 0x32, 0xe4, 0xc0, 0x54, 0xbc,
+	0x45, 0xf9, 0x4b, 0x1a, 0x53, 0x3f, 0x75, 0x91, 0xf2, 0xe1, 0xac, 0xe6, 0xec, 0x2b, 0xe7, 0x08,
+	0x5f, 0x6a, 0x74, 0x69, 0x7d, 0x59, 0xd4, 0xec, 0x57, 0x5c, 0x5d, 0xfe, 0x65, 0x59, 0xf8, 0x70,
+	0x38, 0x0b, 0x3f, 0x86, 0x6b, 0xe9, 0x9e, 0xab, 0x6b, 0xcb, 0xb2, 0xc6, 0x14, 0x2b, 0x4b, 0x46,
+	0x41, 0x65, 0x5d, 0x31, 0x0a, 0x5e, 0x7d, 0x55, 0xb1, 0x06, 0xe2, 0xad, 0x47, 0xdf, 0xd5, 0x81,
+	0xf8, 0xb7, 0x35, 0x63, 0xe4, 0x90, 0x03, 0xf1, 0x27, 0x70, 0x43, 0xdf, 0xf1, 0x62, 0x2e, 0x9d,
+	0x9c, 0xc4, 0x11, 0xcf, 0x4d, 0xff, 0x08, 0x45, 0xcf, 0xed, 0xaa, 0x70, 0x4d, 0xc1, 0xf6, 0x6c,
+	0xd4, 0x63, 0x14, 0x3d, 0x17, 0x95, 0x02, 0xc1, 0xc6, 0x39, 0x6a, 0xaa, 0x0b, 0xc8, 0x5b, 0x55,
+	0x5a, 0xf7, 0x0b, 0x45, 0xe5, 0x29, 0xbc, 0x71, 0x99, 0x25, 0x54, 0x95, 0x59, 0xbb, 0x48, 0xf1,
+	0x05, 0x5b, 0xae, 0xae, 0x44, 0x95, 0x5b, 0x2e, 0x56, 0xa7, 0x92, 0x08, 0x98, 0x78, 0xa1, 0x08,
+	0x98, 0xbc, 0x20, 0x02, 0x1e, 0xdf, 0xf9, 0xd1, 0x3b, 0xc7, 0x84, 0x75, 0x07, 0x47, 0x1b, 0x41,
+	0xdc, 0xbf, 0xdb, 0x8f, 0xe3, 0xd3, 0x3b, 0x24, 0xbe, 0x8b, 0x82, 0xee, 0x5d, 0x12, 0x31, 0x4c,
+	0x23, 0xd4, 0xe3, 0x5f, 0x4e, 0x8e, 0xde, 0x17, 0xff, 0x1e, 0x8d, 0x8b, 0xff, 0x3c, 0x70, 0xff,
+	0xdf, 0x03, 0x00, 0xc9, 0x4c, 0xb9, 0x61, 0x49, 0x30, 0x00, 0x00,
+}
@@ -0,0 +1,543 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// JSONVersion2 identifies the versioned JSON representation of a File read and written
+// by FileFromJSONV2 and File.MarshalJSONV2.
+const JSONVersion2 = "v2"
+
+var (
+	// ErrJSONV2Unsupported is the error given when a File has records the v2 JSON format can not represent
+	ErrJSONV2Unsupported = errors.New("IAT, ADV and dishonored or contested return entries are not supported in the v2 JSON format")
+)
+
+const (
+	isoDateFormat = "2006-01-02"
+	isoTimeFormat = "15:04"
+)
+
+// FileV2 is the v2 JSON representation of a File. Unlike the legacy JSON, which follows the
+// Go structs, v2 field names are stable lowerCamel names, amounts are integers of cents and
+// dates are ISO 8601 (YYYY-MM-DD). Record type codes, addenda indicators and sequence numbers
+// are left out as they're computed when the File is built.
+//
+// Controls are only written. FileFromJSONV2 computes them with Create.
+type FileV2 struct {
+	Version string         `json:"version"`
+	ID      string         `json:"id,omitempty"`
+	Header  FileHeaderV2   `json:"header"`
+	Batches []*BatchV2     `json:"batches"`
+	Control *FileControlV2 `json:"control,omitempty"`
+}
+
+// FileHeaderV2 is the v2 JSON representation of a FileHeader
+type FileHeaderV2 struct {
+	ImmediateDestination     string `json:"immediateDestination"`
+	ImmediateDestinationName string `json:"immediateDestinationName,omitempty"`
+	ImmediateOrigin          string `json:"immediateOrigin"`
+	ImmediateOriginName      string `json:"immediateOriginName,omitempty"`
+	// CreationDate is YYYY-MM-DD
+	CreationDate string `json:"creationDate,omitempty"`
+	// CreationTime is HH:MM
+	CreationTime   string `json:"creationTime,omitempty"`
+	FileIDModifier string `json:"fileIDModifier,omitempty"`
+	ReferenceCode  string `json:"referenceCode,omitempty"`
+}
+
+// FileControlV2 is the v2 JSON representation of a FileControl
+type FileControlV2 struct {
+	BatchCount        int `json:"batchCount"`
+	BlockCount        int `json:"blockCount"`
+	EntryAddendaCount int `json:"entryAddendaCount"`
+	EntryHash         int `json:"entryHash"`
+	TotalDebit        int `json:"totalDebit"`
+	TotalCredit       int `json:"totalCredit"`
+}
+
+// BatchV2 is the v2 JSON representation of a Batch
+type BatchV2 struct {
+	ID      string          `json:"id,omitempty"`
+	Header  BatchHeaderV2   `json:"header"`
+	Entries []*EntryV2      `json:"entries"`
+	Control *BatchControlV2 `json:"control,omitempty"`
+}
+
+// BatchHeaderV2 is the v2 JSON representation of a BatchHeader
+type BatchHeaderV2 struct {
+	ServiceClassCode         int    `json:"serviceClassCode"`
+	CompanyName              string `json:"companyName"`
+	CompanyDiscretionaryData string `json:"companyDiscretionaryData,omitempty"`
+	CompanyIdentification    string `json:"companyIdentification"`
+	StandardEntryClassCode   string `json:"standardEntryClassCode"`
+	CompanyEntryDescription  string `json:"companyEntryDescription"`
+	CompanyDescriptiveDate   string `json:"companyDescriptiveDate,omitempty"`
+	// EffectiveEntryDate is YYYY-MM-DD
+	EffectiveEntryDate   string `json:"effectiveEntryDate,omitempty"`
+	OriginatorStatusCode int    `json:"originatorStatusCode"`
+	ODFIIdentification   string `json:"odfiIdentification"`
+	BatchNumber          int    `json:"batchNumber,omitempty"`
+}
+
+// BatchControlV2 is the v2 JSON representation of a BatchControl
+type BatchControlV2 struct {
+	EntryAddendaCount         int    `json:"entryAddendaCount"`
+	EntryHash                 int    `json:"entryHash"`
+	TotalDebit                int    `json:"totalDebit"`
+	TotalCredit               int    `json:"totalCredit"`
+	MessageAuthenticationCode string `json:"messageAuthenticationCode,omitempty"`
+}
+
+// EntryV2 is the v2 JSON representation of an EntryDetail and its addenda
+type EntryV2 struct {
+	ID                   string `json:"id,omitempty"`
+	TransactionCode      int    `json:"transactionCode"`
+	RDFIIdentification   string `json:"rdfiIdentification"`
+	CheckDigit           string `json:"checkDigit"`
+	DFIAccountNumber     string `json:"dfiAccountNumber"`
+	Amount               int    `json:"amount"`
+	IdentificationNumber string `json:"identificationNumber,omitempty"`
+	IndividualName       string `json:"individualName"`
+	DiscretionaryData    string `json:"discretionaryData,omitempty"`
+	TraceNumber          string `json:"traceNumber,omitempty"`
+	// Category is Forward, Return or NOC and defaults to Forward
+	Category string `json:"category,omitempty"`
+
+	// PaymentRelatedInformation holds one value for each Addenda05
+	PaymentRelatedInformation []string      `json:"paymentRelatedInformation,omitempty"`
+	Terminal                  *TerminalV2   `json:"terminal,omitempty"`
+	Return                    *ReturnV2     `json:"return,omitempty"`
+	Correction                *CorrectionV2 `json:"correction,omitempty"`
+}
+
+// TerminalV2 is the v2 JSON representation of an Addenda02
+type TerminalV2 struct {
+	ReferenceInformationOne    string `json:"referenceInformationOne,omitempty"`
+	ReferenceInformationTwo    string `json:"referenceInformationTwo,omitempty"`
+	TerminalIdentificationCode string `json:"terminalIdentificationCode"`
+	TransactionSerialNumber    string `json:"transactionSerialNumber"`
+	// TransactionDate is --MM-DD as the year isn't recorded
+	TransactionDate               string `json:"transactionDate"`
+	AuthorizationCodeOrExpireDate string `json:"authorizationCodeOrExpireDate,omitempty"`
+	TerminalLocation              string `json:"terminalLocation"`
+	TerminalCity                  string `json:"terminalCity"`
+	TerminalState                 string `json:"terminalState"`
+}
+
+// ReturnV2 is the v2 JSON representation of an Addenda99
+type ReturnV2 struct {
+	ReturnCode    string `json:"returnCode"`
+	OriginalTrace string `json:"originalTrace"`
+	// DateOfDeath is YYYY-MM-DD
+	DateOfDeath        string `json:"dateOfDeath,omitempty"`
+	OriginalDFI        string `json:"originalDFI"`
+	AddendaInformation string `json:"addendaInformation,omitempty"`
+}
+
+// CorrectionV2 is the v2 JSON representation of an Addenda98
+type CorrectionV2 struct {
+	ChangeCode    string `json:"changeCode"`
+	OriginalTrace string `json:"originalTrace"`
+	OriginalDFI   string `json:"originalDFI"`
+	CorrectedData string `json:"correctedData"`
+}
+
+// MarshalJSONV2 returns the File in the v2 JSON format. ErrJSONV2Unsupported is returned
+// for Files with IAT or ADV batches or dishonored or contested returns.
+func (f *File) MarshalJSONV2() ([]byte, error) {
+	out, err := f.ToV2()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(out)
+}
+
+// ToV2 returns the File in its v2 JSON representation. ErrJSONV2Unsupported is returned
+// for Files with IAT or ADV batches or dishonored or contested returns.
+func (f *File) ToV2() (*FileV2, error) {
+	if len(f.IATBatches) > 0 || f.IsADV() {
+		return nil, ErrJSONV2Unsupported
+	}
+	fh := f.Header
+	out := &FileV2{
+		Version: JSONVersion2,
+		ID:      f.ID,
+		Header: FileHeaderV2{
+			ImmediateDestination:     fh.ImmediateDestination,
+			ImmediateDestinationName: fh.ImmediateDestinationName,
+			ImmediateOrigin:          fh.ImmediateOrigin,
+			ImmediateOriginName:      fh.ImmediateOriginName,
+			FileIDModifier:           fh.FileIDModifier,
+			ReferenceCode:            fh.ReferenceCode,
+		},
+		Batches: make([]*BatchV2, 0, len(f.Batches)),
+		Control: &FileControlV2{
+			BatchCount:        f.Control.BatchCount,
+			BlockCount:        f.Control.BlockCount,
+			EntryAddendaCount: f.Control.EntryAddendaCount,
+			EntryHash:         f.Control.EntryHash,
+			TotalDebit:        f.Control.TotalDebitEntryDollarAmountInFile,
+			TotalCredit:       f.Control.TotalCreditEntryDollarAmountInFile,
+		},
+	}
+	var err error
+	if out.Header.CreationDate, err = toISO("060102", isoDateFormat, fh.FileCreationDate); err != nil {
+		return nil, fieldError("FileCreationDate", err, fh.FileCreationDate)
+	}
+	if out.Header.CreationTime, err = toISO("1504", isoTimeFormat, fh.FileCreationTime); err != nil {
+		return nil, fieldError("FileCreationTime", err, fh.FileCreationTime)
+	}
+
+	for _, batch := range f.Batches {
+		b, err := batchToV2(batch)
+		if err != nil {
+			return nil, err
+		}
+		out.Batches = append(out.Batches, b)
+	}
+	return out, nil
+}
+
+func batchToV2(batch Batcher) (*BatchV2, error) {
+	bh := batch.GetHeader()
+	out := &BatchV2{
+		ID: batch.ID(),
+		Header: BatchHeaderV2{
+			ServiceClassCode:         bh.ServiceClassCode,
+			CompanyName:              bh.CompanyName,
+			CompanyDiscretionaryData: bh.CompanyDiscretionaryData,
+			CompanyIdentification:    bh.CompanyIdentification,
+			StandardEntryClassCode:   bh.StandardEntryClassCode,
+			CompanyEntryDescription:  bh.CompanyEntryDescription,
+			CompanyDescriptiveDate:   bh.CompanyDescriptiveDate,
+			OriginatorStatusCode:     bh.OriginatorStatusCode,
+			ODFIIdentification:       bh.ODFIIdentification,
+			BatchNumber:              bh.BatchNumber,
+		},
+		Entries: make([]*EntryV2, 0, len(batch.GetEntries())),
+	}
+	var err error
+	if out.Header.EffectiveEntryDate, err = toISO("060102", isoDateFormat, bh.EffectiveEntryDate); err != nil {
+		return nil, batch.Error("EffectiveEntryDate", err, bh.EffectiveEntryDate)
+	}
+	if bc := batch.GetControl(); bc != nil {
+		out.Control = &BatchControlV2{
+			EntryAddendaCount:         bc.EntryAddendaCount,
+			EntryHash:                 bc.EntryHash,
+			TotalDebit:                bc.TotalDebitEntryDollarAmount,
+			TotalCredit:               bc.TotalCreditEntryDollarAmount,
+			MessageAuthenticationCode: bc.MessageAuthenticationCode,
+		}
+	}
+
+	for _, entry := range batch.GetEntries() {
+		e, err := entryToV2(entry)
+		if err != nil {
+			return nil, batch.Error("EntryDetail", err, entry.TraceNumber)
+		}
+		out.Entries = append(out.Entries, e)
+	}
+	return out, nil
+}
+
+func entryToV2(entry *EntryDetail) (*EntryV2, error) {
+	if entry.Addenda99Dishonored != nil || entry.Addenda99Contested != nil {
+		return nil, ErrJSONV2Unsupported
+	}
+	out := &EntryV2{
+		ID:                   entry.ID,
+		TransactionCode:      entry.TransactionCode,
+		RDFIIdentification:   entry.RDFIIdentification,
+		CheckDigit:           entry.CheckDigit,
+		DFIAccountNumber:     entry.DFIAccountNumber,
+		Amount:               entry.Amount,
+		IdentificationNumber: entry.IdentificationNumber,
+		IndividualName:       entry.IndividualName,
+		DiscretionaryData:    entry.DiscretionaryData,
+		TraceNumber:          entry.TraceNumber,
+		Category:             entry.Category,
+	}
+	for _, addenda05 := range entry.Addenda05 {
+		out.PaymentRelatedInformation = append(out.PaymentRelatedInformation, addenda05.PaymentRelatedInformation)
+	}
+	if a := entry.Addenda02; a != nil {
+		out.Terminal = &TerminalV2{
+			ReferenceInformationOne:       a.ReferenceInformationOne,
+			ReferenceInformationTwo:       a.ReferenceInformationTwo,
+			TerminalIdentificationCode:    a.TerminalIdentificationCode,
+			TransactionSerialNumber:       a.TransactionSerialNumber,
+			AuthorizationCodeOrExpireDate: a.AuthorizationCodeOrExpireDate,
+			TerminalLocation:              a.TerminalLocation,
+			TerminalCity:                  a.TerminalCity,
+			TerminalState:                 a.TerminalState,
+		}
+		var err error
+		if out.Terminal.TransactionDate, err = toISO("0102", "--01-02", a.TransactionDate); err != nil {
+			return nil, fieldError("TransactionDate", err, a.TransactionDate)
+		}
+	}
+	if a := entry.Addenda99; a != nil {
+		out.Return = &ReturnV2{
+			ReturnCode:         a.ReturnCode,
+			OriginalTrace:      a.OriginalTrace,
+			OriginalDFI:        a.OriginalDFI,
+			AddendaInformation: a.AddendaInformation,
+		}
+		var err error
+		if out.Return.DateOfDeath, err = toISO("060102", isoDateFormat, a.DateOfDeath); err != nil {
+			return nil, fieldError("DateOfDeath", err, a.DateOfDeath)
+		}
+	}
+	if a := entry.Addenda98; a != nil {
+		out.Correction = &CorrectionV2{
+			ChangeCode:    a.ChangeCode,
+			OriginalTrace: a.OriginalTrace,
+			OriginalDFI:   a.OriginalDFI,
+			CorrectedData: a.CorrectedData,
+		}
+	}
+	return out, nil
+}
+
+// FileFromJSONV2 reads a File in the v2 JSON format. Batches are created for their
+// StandardEntryClassCode and the File is built and validated with Finalize.
+func FileFromJSONV2(bs []byte) (*File, error) {
+	if len(bs) == 0 {
+		return nil, errors.New("no JSON data provided")
+	}
+	return FileFromJSONV2Reader(bytes.NewReader(bs))
+}
+
+// FileFromJSONV2Reader reads a File in the v2 JSON format like FileFromJSONV2, but decodes r as
+// it's read so the JSON is never held in memory all at once. Only one batch is buffered at a time.
+func FileFromJSONV2Reader(r io.Reader) (*File, error) {
+	file := NewFile()
+	var version string
+	var header FileHeaderV2
+	var batchErr error // a batch which can't be converted, rather than invalid JSON
+
+	dec := json.NewDecoder(r)
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		if err == io.EOF {
+			return nil, errors.New("no JSON data provided")
+		}
+		return nil, fmt.Errorf("problem reading File: %v", err)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("problem reading File: %v", err)
+		}
+		key, _ := tok.(string)
+		switch {
+		case strings.EqualFold(key, "version"):
+			if err := dec.Decode(&version); err != nil {
+				return nil, fmt.Errorf("problem reading File: %v", err)
+			}
+			if version != "" && version != JSONVersion2 {
+				return nil, fmt.Errorf("unexpected JSON version %q", version)
+			}
+		case strings.EqualFold(key, "id"):
+			err = dec.Decode(&file.ID)
+		case strings.EqualFold(key, "header"):
+			err = dec.Decode(&header)
+		case strings.EqualFold(key, "batches"):
+			file.Batches = nil
+			err = decodeJSONArray(dec, func() error {
+				var b *BatchV2
+				if err := dec.Decode(&b); err != nil || b == nil {
+					return err
+				}
+				batch, err := b.batch()
+				if err != nil {
+					batchErr = err
+					return err
+				}
+				file.AddBatch(batch)
+				return nil
+			})
+		default:
+			// controls are computed by Finalize
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if batchErr != nil {
+			return nil, batchErr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("problem reading File: %v", err)
+		}
+	}
+	if err := expectJSONDelim(dec, '}'); err != nil {
+		return nil, fmt.Errorf("problem reading File: %v", err)
+	}
+
+	fh := NewFileHeader()
+	fh.ImmediateDestination = header.ImmediateDestination
+	fh.ImmediateDestinationName = header.ImmediateDestinationName
+	fh.ImmediateOrigin = header.ImmediateOrigin
+	fh.ImmediateOriginName = header.ImmediateOriginName
+	fh.FileIDModifier = header.FileIDModifier
+	fh.ReferenceCode = header.ReferenceCode
+	var err error
+	if fh.FileCreationDate, err = fromISO(isoDateFormat, "060102", header.CreationDate); err != nil {
+		return nil, fieldError("CreationDate", err, header.CreationDate)
+	}
+	if fh.FileCreationTime, err = fromISO(isoTimeFormat, "1504", header.CreationTime); err != nil {
+		return nil, fieldError("CreationTime", err, header.CreationTime)
+	}
+	file.SetHeader(fh)
+
+	if err := file.Finalize(); err != nil {
+		return file, err
+	}
+	return file, nil
+}
+
+func (b *BatchV2) batch() (Batcher, error) {
+	bh := NewBatchHeader()
+	bh.ID = b.ID
+	bh.ServiceClassCode = b.Header.ServiceClassCode
+	bh.CompanyName = b.Header.CompanyName
+	bh.CompanyDiscretionaryData = b.Header.CompanyDiscretionaryData
+	bh.CompanyIdentification = b.Header.CompanyIdentification
+	bh.StandardEntryClassCode = b.Header.StandardEntryClassCode
+	bh.CompanyEntryDescription = b.Header.CompanyEntryDescription
+	bh.CompanyDescriptiveDate = b.Header.CompanyDescriptiveDate
+	bh.OriginatorStatusCode = b.Header.OriginatorStatusCode
+	bh.ODFIIdentification = b.Header.ODFIIdentification
+	bh.BatchNumber = b.Header.BatchNumber
+	var err error
+	if bh.EffectiveEntryDate, err = fromISO(isoDateFormat, "060102", b.Header.EffectiveEntryDate); err != nil {
+		return nil, fieldError("EffectiveEntryDate", err, b.Header.EffectiveEntryDate)
+	}
+
+	batch, err := NewBatch(bh)
+	if err != nil {
+		return nil, err
+	}
+	batch.SetID(b.ID)
+	for _, e := range b.Entries {
+		if e == nil {
+			continue
+		}
+		entry, err := e.entry()
+		if err != nil {
+			return nil, batch.Error("EntryDetail", err, e.TraceNumber)
+		}
+		batch.AddEntry(entry)
+	}
+	return batch, nil
+}
+
+func (e *EntryV2) entry() (*EntryDetail, error) {
+	entry := NewEntryDetail()
+	entry.ID = e.ID
+	entry.TransactionCode = e.TransactionCode
+	entry.RDFIIdentification = e.RDFIIdentification
+	entry.CheckDigit = e.CheckDigit
+	entry.DFIAccountNumber = e.DFIAccountNumber
+	entry.Amount = e.Amount
+	entry.IdentificationNumber = e.IdentificationNumber
+	entry.IndividualName = e.IndividualName
+	entry.DiscretionaryData = e.DiscretionaryData
+	entry.TraceNumber = e.TraceNumber
+	entry.Category = CategoryForward
+	if e.Category != "" {
+		entry.Category = e.Category
+	}
+
+	for _, info := range e.PaymentRelatedInformation {
+		addenda05 := NewAddenda05()
+		addenda05.PaymentRelatedInformation = info
+		entry.AddAddenda05(addenda05)
+	}
+	if t := e.Terminal; t != nil {
+		addenda02 := NewAddenda02()
+		addenda02.ReferenceInformationOne = t.ReferenceInformationOne
+		addenda02.ReferenceInformationTwo = t.ReferenceInformationTwo
+		addenda02.TerminalIdentificationCode = t.TerminalIdentificationCode
+		addenda02.TransactionSerialNumber = t.TransactionSerialNumber
+		addenda02.AuthorizationCodeOrExpireDate = t.AuthorizationCodeOrExpireDate
+		addenda02.TerminalLocation = t.TerminalLocation
+		addenda02.TerminalCity = t.TerminalCity
+		addenda02.TerminalState = t.TerminalState
+		var err error
+		if addenda02.TransactionDate, err = fromISO("--01-02", "0102", t.TransactionDate); err != nil {
+			return nil, fieldError("TransactionDate", err, t.TransactionDate)
+		}
+		entry.Addenda02 = addenda02
+	}
+	if r := e.Return; r != nil {
+		addenda99 := NewAddenda99()
+		addenda99.ReturnCode = r.ReturnCode
+		addenda99.OriginalTrace = r.OriginalTrace
+		addenda99.OriginalDFI = r.OriginalDFI
+		addenda99.AddendaInformation = r.AddendaInformation
+		var err error
+		if addenda99.DateOfDeath, err = fromISO(isoDateFormat, "060102", r.DateOfDeath); err != nil {
+			return nil, fieldError("DateOfDeath", err, r.DateOfDeath)
+		}
+		entry.Addenda99 = addenda99
+	}
+	if c := e.Correction; c != nil {
+		addenda98 := NewAddenda98()
+		addenda98.ChangeCode = c.ChangeCode
+		addenda98.OriginalTrace = c.OriginalTrace
+		addenda98.OriginalDFI = c.OriginalDFI
+		addenda98.CorrectedData = c.CorrectedData
+		entry.Addenda98 = addenda98
+	}
+	if len(entry.Addenda05) > 0 || entry.Addenda02 != nil || entry.Addenda98 != nil || entry.Addenda99 != nil {
+		entry.AddendaRecordIndicator = 1
+	}
+	return entry, nil
+}
+
+// toISO converts a NACHA date or time value from layout into iso. Blank values are kept blank.
+func toISO(layout, iso, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return "", ErrDateFormat
+	}
+	return t.Format(iso), nil
+}
+
+// fromISO converts an ISO 8601 date or time value into a NACHA layout. Blank values are kept blank.
+func fromISO(iso, layout, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	t, err := time.Parse(iso, value)
+	if err != nil {
+		return "", ErrDateFormat
+	}
+	return t.Format(layout), nil
+}
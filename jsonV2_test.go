@@ -0,0 +1,174 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/moov-io/base"
+)
+
+func TestFile__JSONV2(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "ppd-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Batches[0].GetEntries()[0].AddendaRecordIndicator = 1
+	file.Batches[0].GetEntries()[0].AddAddenda05(mockAddenda05())
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	bs, err = file.MarshalJSONV2()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(bs)
+	for _, expected := range []string{
+		`"version":"v2"`,
+		`"creationDate":"` + isoDate(t, file.Header.FileCreationDate) + `"`,
+		`"effectiveEntryDate":"` + isoDate(t, file.Batches[0].GetHeader().EffectiveEntryDate) + `"`,
+		`"odfiIdentification":"`,
+		`"amount":100000`,
+		`"paymentRelatedInformation":["`,
+		`"totalCredit":100000`,
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("missing %s in %s", expected, out)
+		}
+	}
+
+	read, err := FileFromJSONV2(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.ID != file.ID || read.Header.FileCreationDate != file.Header.FileCreationDate || read.Header.FileCreationTime != file.Header.FileCreationTime {
+		t.Errorf("unexpected FileHeader: %#v", read.Header)
+	}
+	if !read.Batches[0].Equal(file.Batches[0]) {
+		t.Errorf("batches differ:\n%#v\n%#v", read.Batches[0].GetEntries()[0], file.Batches[0].GetEntries()[0])
+	}
+	entry := read.Batches[0].GetEntries()[0]
+	if len(entry.Addenda05) != 1 || entry.Addenda05[0].PaymentRelatedInformation != mockAddenda05().PaymentRelatedInformation {
+		t.Errorf("unexpected Addenda05: %#v", entry.Addenda05)
+	}
+	if read.Control.TotalCreditEntryDollarAmountInFile != 100000 {
+		t.Errorf("unexpected FileControl: %#v", read.Control)
+	}
+}
+
+func TestFile__JSONV2Return(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "return-WEB.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, err := file.MarshalJSONV2()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var v2 FileV2
+	if err := json.Unmarshal(bs, &v2); err != nil {
+		t.Fatal(err)
+	}
+	ret := v2.Batches[0].Entries[0].Return
+	if ret == nil || ret.ReturnCode != file.Batches[0].GetEntries()[0].Addenda99.ReturnCode {
+		t.Fatalf("unexpected return: %#v", ret)
+	}
+
+	read, err := FileFromJSONV2(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read.ReturnEntries) != len(file.ReturnEntries) || read.Batches[0].GetEntries()[0].Addenda99.ReturnCode != ret.ReturnCode {
+		t.Errorf("unexpected returns: %#v", read.ReturnEntries)
+	}
+}
+
+func TestFile__JSONV2Reader(t *testing.T) {
+	file := readProtoTestFile(t, filepath.Join("test", "testdata", "ppd-valid.json"))
+	bs, err := file.MarshalJSONV2()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// read a byte at a time, with keys in a different order and null batches
+	var v2 map[string]json.RawMessage
+	if err := json.Unmarshal(bs, &v2); err != nil {
+		t.Fatal(err)
+	}
+	batches := strings.Replace(string(v2["batches"]), "[", "[null,", 1)
+	reordered := `{"Batches":` + batches + `,"header":` + string(v2["header"]) + `,"id":` + string(v2["id"]) + `,"version":"v2","extra":{"a":[1]}}`
+	read, err := FileFromJSONV2Reader(iotest.OneByteReader(strings.NewReader(reordered)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.ID != file.ID || len(read.Batches) != 1 || !read.Batches[0].Equal(file.Batches[0]) {
+		t.Errorf("unexpected File: %#v", read)
+	}
+
+	if _, err := FileFromJSONV2Reader(strings.NewReader("")); err == nil || !strings.Contains(err.Error(), "no JSON data") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := FileFromJSONV2Reader(strings.NewReader(`{"batches":[{"header":`)); err == nil || !strings.Contains(err.Error(), "problem reading File") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := FileFromJSONV2Reader(strings.NewReader(`{"batches":[{"header":{"effectiveEntryDate":"10/16/2026"}}]}`)); !base.Match(err, ErrDateFormat) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
+
+func TestFile__JSONV2Errors(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "iat-debit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.MarshalJSONV2(); !errors.Is(err, ErrJSONV2Unsupported) {
+		t.Errorf("%T: %v", err, err)
+	}
+
+	if _, err := FileFromJSONV2(nil); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := FileFromJSONV2([]byte(`{"version":"v3"}`)); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := FileFromJSONV2([]byte(`{"version":"v2","header":{"creationDate":"10/16/2026"}}`)); !base.Match(err, ErrDateFormat) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
+
+func isoDate(t *testing.T, yymmdd string) string {
+	t.Helper()
+
+	v, err := toISO("060102", isoDateFormat, yymmdd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
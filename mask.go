@@ -0,0 +1,150 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"strings"
+)
+
+// MaskOpts selects which personal data File.MaskSensitiveData redacts. The zero value redacts everything.
+type MaskOpts struct {
+	// KeepAccountNumbers leaves DFIAccountNumbers, and the account numbers of corrections, unmasked.
+	// Otherwise all but their last four characters are masked.
+	KeepAccountNumbers bool `json:"keepAccountNumbers"`
+
+	// KeepNames leaves the IndividualName of entries and the Receiver name of IAT entries unmasked
+	KeepNames bool `json:"keepNames"`
+
+	// KeepIdentificationNumbers leaves the IdentificationNumber of entries and the Receiver
+	// identification number of IAT entries unmasked
+	KeepIdentificationNumbers bool `json:"keepIdentificationNumbers"`
+
+	// KeepAddresses leaves the Receiver street, city and postal code of IAT entries unmasked
+	KeepAddresses bool `json:"keepAddresses"`
+}
+
+// maskChar replaces each redacted character
+const maskChar = "*"
+
+// maskedAccountDigits is how many trailing characters of an account number are left visible
+const maskedAccountDigits = 4
+
+// MaskSensitiveData returns a copy of the File with account numbers, names and identification
+// numbers of Receivers masked so it can be shared without exposing personal data. The File itself
+// isn't modified. Masked values are as long as the trimmed values they replace.
+//
+// nil opts redacts everything.
+func (f *File) MaskSensitiveData(opts *MaskOpts) (*File, error) {
+	if opts == nil {
+		opts = &MaskOpts{}
+	}
+	bs, err := f.MarshalProto()
+	if err != nil {
+		return nil, err
+	}
+	out, err := FileFromProto(bs)
+	if err != nil {
+		return nil, err
+	}
+	out.validateOpts = f.validateOpts
+
+	for _, batch := range out.Batches {
+		for _, entry := range batch.GetEntries() {
+			entry.mask(opts)
+		}
+		for _, entry := range batch.GetADVEntries() {
+			entry.mask(opts)
+		}
+	}
+	for i := range out.IATBatches {
+		for _, entry := range out.IATBatches[i].Entries {
+			entry.mask(opts)
+		}
+	}
+	return out, nil
+}
+
+func (ed *EntryDetail) mask(opts *MaskOpts) {
+	if !opts.KeepAccountNumbers {
+		ed.DFIAccountNumber = maskAccountNumber(ed.DFIAccountNumber)
+		if ed.Addenda98 != nil {
+			ed.Addenda98.maskAccountNumber()
+		}
+	}
+	if !opts.KeepNames {
+		ed.IndividualName = maskValue(ed.IndividualName)
+	}
+	if !opts.KeepIdentificationNumbers {
+		ed.IdentificationNumber = maskValue(ed.IdentificationNumber)
+	}
+}
+
+func (ed *ADVEntryDetail) mask(opts *MaskOpts) {
+	if !opts.KeepAccountNumbers {
+		ed.DFIAccountNumber = maskAccountNumber(ed.DFIAccountNumber)
+	}
+	if !opts.KeepNames {
+		ed.IndividualName = maskValue(ed.IndividualName)
+	}
+}
+
+func (ed *IATEntryDetail) mask(opts *MaskOpts) {
+	if !opts.KeepAccountNumbers {
+		ed.DFIAccountNumber = maskAccountNumber(ed.DFIAccountNumber)
+		if ed.Addenda98 != nil {
+			ed.Addenda98.maskAccountNumber()
+		}
+	}
+	if !opts.KeepNames && ed.Addenda10 != nil {
+		ed.Addenda10.Name = maskValue(ed.Addenda10.Name)
+	}
+	if !opts.KeepIdentificationNumbers && ed.Addenda15 != nil {
+		ed.Addenda15.ReceiverIDNumber = maskValue(ed.Addenda15.ReceiverIDNumber)
+	}
+	if !opts.KeepAddresses {
+		if ed.Addenda15 != nil {
+			ed.Addenda15.ReceiverStreetAddress = maskValue(ed.Addenda15.ReceiverStreetAddress)
+		}
+		if ed.Addenda16 != nil {
+			ed.Addenda16.ReceiverCityStateProvince = maskValue(ed.Addenda16.ReceiverCityStateProvince)
+			ed.Addenda16.ReceiverCountryPostalCode = maskValue(ed.Addenda16.ReceiverCountryPostalCode)
+		}
+	}
+}
+
+// maskAccountNumber masks the CorrectedData of change codes which carry an account number
+func (addenda98 *Addenda98) maskAccountNumber() {
+	switch addenda98.ChangeCode {
+	case "C01", "C03", "C06":
+		addenda98.CorrectedData = maskAccountNumber(addenda98.CorrectedData)
+	}
+}
+
+// maskAccountNumber masks all but the last four characters of v. Shorter values are masked entirely.
+func maskAccountNumber(v string) string {
+	v = strings.TrimSpace(v)
+	if len(v) <= maskedAccountDigits {
+		return maskValue(v)
+	}
+	return strings.Repeat(maskChar, len(v)-maskedAccountDigits) + v[len(v)-maskedAccountDigits:]
+}
+
+// maskValue masks every character of v, ignoring surrounding spaces
+func maskValue(v string) string {
+	return strings.Repeat(maskChar, len(strings.TrimSpace(v)))
+}
@@ -0,0 +1,133 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFile__MaskSensitiveData(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := file.Batches[0].GetEntries()[0]
+	account, name := entry.DFIAccountNumber, entry.IndividualName
+	entry.IdentificationNumber = "ID123"
+
+	masked, err := file.MaskSensitiveData(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := masked.Batches[0].GetEntries()[0]
+	if got.DFIAccountNumber != "****5678" {
+		t.Errorf("DFIAccountNumber=%q", got.DFIAccountNumber)
+	}
+	if got.IndividualName != strings.Repeat("*", len(strings.TrimSpace(name))) {
+		t.Errorf("IndividualName=%q", got.IndividualName)
+	}
+	if got.IdentificationNumber != "*****" {
+		t.Errorf("IdentificationNumber=%q", got.IdentificationNumber)
+	}
+	if got.Amount != entry.Amount || got.TraceNumber != entry.TraceNumber {
+		t.Errorf("unexpected change to entry: %#v", got)
+	}
+
+	// the original File is untouched
+	if entry.DFIAccountNumber != account || entry.IndividualName != name || entry.IdentificationNumber != "ID123" {
+		t.Errorf("original entry was modified: %#v", entry)
+	}
+
+	// keep everything
+	masked, err = file.MaskSensitiveData(&MaskOpts{KeepAccountNumbers: true, KeepNames: true, KeepIdentificationNumbers: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = masked.Batches[0].GetEntries()[0]
+	if got.DFIAccountNumber != account || got.IndividualName != name || got.IdentificationNumber != "ID123" {
+		t.Errorf("unexpected masking: %#v", got)
+	}
+}
+
+func TestFile__MaskSensitiveDataIAT(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "iat-debit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	masked, err := file.MaskSensitiveData(&MaskOpts{KeepAddresses: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, got := file.IATBatches[0].Entries[0], masked.IATBatches[0].Entries[0]
+	if strings.Trim(got.Addenda10.Name, "*") != "" {
+		t.Errorf("Addenda10.Name=%q", got.Addenda10.Name)
+	}
+	if strings.Trim(got.Addenda15.ReceiverIDNumber, "*") != "" {
+		t.Errorf("Addenda15.ReceiverIDNumber=%q", got.Addenda15.ReceiverIDNumber)
+	}
+	if got.Addenda15.ReceiverStreetAddress != entry.Addenda15.ReceiverStreetAddress {
+		t.Errorf("Addenda15.ReceiverStreetAddress=%q", got.Addenda15.ReceiverStreetAddress)
+	}
+	if !strings.HasPrefix(got.DFIAccountNumber, "*") || entry.Addenda10.Name == got.Addenda10.Name {
+		t.Errorf("expected masked entry: %#v", got)
+	}
+}
+
+func TestMaskAccountNumber(t *testing.T) {
+	cases := map[string]string{
+		"":               "",
+		"123":            "***",
+		"1234":           "****",
+		"12345":          "*2345",
+		"123456789     ": "*****6789",
+	}
+	for in, expected := range cases {
+		if got := maskAccountNumber(in); got != expected {
+			t.Errorf("maskAccountNumber(%q)=%q expected %q", in, got, expected)
+		}
+	}
+}
+
+func TestFile__MaskSensitiveDataCOR(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "cor-example.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	masked, err := file.MaskSensitiveData(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for i, batch := range masked.Batches {
+		for j, entry := range batch.GetEntries() {
+			original := file.Batches[i].GetEntries()[j].Addenda98
+			if entry.Addenda98 == nil || original.ChangeCode != "C01" {
+				continue
+			}
+			found = true
+			if entry.Addenda98.CorrectedData != maskAccountNumber(original.CorrectedData) {
+				t.Errorf("CorrectedData=%q", entry.Addenda98.CorrectedData)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected a C01 correction")
+	}
+}
@@ -0,0 +1,157 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"time"
+
+	"github.com/moov-io/base"
+)
+
+// PaymentParams are the details of a single payment for NewCreditPayment and NewDebitPayment
+type PaymentParams struct {
+	// ODFIRoutingNumber is the routing number of the originating bank, used as the file's ImmediateOrigin
+	// and the batch's ODFIIdentification
+	ODFIRoutingNumber string
+	// ODFIName is the name of the originating bank
+	ODFIName string
+	// DestinationRoutingNumber is the file's ImmediateDestination, usually the ACH operator or the ODFI.
+	// It defaults to ODFIRoutingNumber
+	DestinationRoutingNumber string
+	// DestinationName is the name of the destination
+	DestinationName string
+
+	// CompanyName is the name of the originator shown to the receiver
+	CompanyName string
+	// CompanyIdentification identifies the originator, often its tax ID
+	CompanyIdentification string
+
+	// ReceiverName is the name of the individual or company receiving the payment
+	ReceiverName string
+	// ReceiverIdentification is an optional identification number of the receiver with the originator
+	ReceiverIdentification string
+	// ReceiverRoutingNumber is the routing number of the receiver's bank
+	ReceiverRoutingNumber string
+	// ReceiverAccountNumber is the receiver's account number
+	ReceiverAccountNumber string
+	// Savings is true when ReceiverAccountNumber is a savings account instead of a checking account
+	Savings bool
+
+	// Amount is the amount of the payment, see ParseAmount
+	Amount Amount
+	// Description is the CompanyEntryDescription shown to the receiver, e.g. PAYROLL
+	Description string
+	// SECCode is the StandardEntryClassCode of the payment: PPD (default), CCD, WEB or TEL, which is only for debits
+	SECCode string
+	// EffectiveDate is the date the payment should settle, defaulting to the next banking day
+	EffectiveDate time.Time
+}
+
+// NewCreditPayment returns a file which pushes params.Amount to the receiver's account
+func NewCreditPayment(params PaymentParams) (*File, error) {
+	return newPayment(params, true)
+}
+
+// NewDebitPayment returns a file which pulls params.Amount from the receiver's account
+func NewDebitPayment(params PaymentParams) (*File, error) {
+	return newPayment(params, false)
+}
+
+func newPayment(params PaymentParams, credit bool) (*File, error) {
+	sec := params.SECCode
+	if sec == "" {
+		sec = PPD
+	}
+
+	var errs base.ErrorList
+	switch sec {
+	case PPD, CCD, WEB:
+	case TEL:
+		if credit {
+			errs.Add(fieldError("SECCode", ErrBatchDebitOnly, sec))
+		}
+	default:
+		errs.Add(fieldError("SECCode", ErrSECCode, sec))
+	}
+	if err := CheckRoutingNumber(params.ODFIRoutingNumber); err != nil {
+		errs.Add(fieldError("ODFIRoutingNumber", err, params.ODFIRoutingNumber))
+	}
+	if err := CheckRoutingNumber(params.ReceiverRoutingNumber); err != nil {
+		errs.Add(fieldError("ReceiverRoutingNumber", err, params.ReceiverRoutingNumber))
+	}
+	if params.Amount <= 0 || params.Amount > MaxEntryAmount {
+		errs.Add(fieldError("Amount", ErrInvalidAmount, params.Amount.String()))
+	}
+	if !errs.Empty() {
+		return nil, errs
+	}
+
+	now := time.Now()
+
+	fh := NewFileHeader()
+	fh.ImmediateOrigin = params.ODFIRoutingNumber
+	fh.ImmediateOriginName = params.ODFIName
+	fh.ImmediateDestination = params.DestinationRoutingNumber
+	fh.ImmediateDestinationName = params.DestinationName
+	if fh.ImmediateDestination == "" {
+		fh.ImmediateDestination = params.ODFIRoutingNumber
+		fh.ImmediateDestinationName = params.ODFIName
+	}
+	fh.FileCreationDate = now.Format("060102")
+	fh.FileCreationTime = now.Format("1504")
+
+	bh := NewBatchHeader()
+	bh.CompanyName = params.CompanyName
+	bh.CompanyIdentification = params.CompanyIdentification
+	bh.CompanyEntryDescription = params.Description
+	// the ODFI is identified by the first 8 digits of its routing number, without the check digit
+	bh.ODFIIdentification = params.ODFIRoutingNumber[:8]
+	if params.EffectiveDate.IsZero() {
+		bh.SetEffectiveEntryDate(now, false)
+	} else {
+		bh.EffectiveEntryDate = params.EffectiveDate.Format("060102")
+	}
+
+	ed := NewEntryDetail()
+	switch {
+	case credit && params.Savings:
+		ed.TransactionCode = SavingsCredit
+	case credit:
+		ed.TransactionCode = CheckingCredit
+	case params.Savings:
+		ed.TransactionCode = SavingsDebit
+	default:
+		ed.TransactionCode = CheckingDebit
+	}
+	if credit {
+		bh.ServiceClassCode = CreditsOnly
+	} else {
+		bh.ServiceClassCode = DebitsOnly
+	}
+	ed.SetRDFI(params.ReceiverRoutingNumber)
+	ed.DFIAccountNumber = params.ReceiverAccountNumber
+	ed.SetAmount(params.Amount)
+	ed.IdentificationNumber = params.ReceiverIdentification
+	ed.IndividualName = params.ReceiverName
+	if sec == WEB || sec == TEL {
+		ed.SetPaymentType("S")
+	}
+
+	bh.StandardEntryClassCode = sec
+	return NewFileBuilder().WithHeader(fh).AddBatch(bh).AddEntry(ed).Build()
+}
@@ -0,0 +1,89 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"strings"
+)
+
+// paymentRelatedInformationLength is how many characters of payment related information an Addenda05 holds
+const paymentRelatedInformationLength = 80
+
+// maxCTXAddendaRecords is the most Addenda05 records a CTX entry may have
+const maxCTXAddendaRecords = 9999
+
+// SetPaymentRelatedInformation replaces the entry's Addenda05 records with info, such as the segments of an
+// EDI 820 transaction set, split into 80 character PaymentRelatedInformation. The records are numbered from 1
+// and AddendaRecordIndicator is set when there's at least one. CTX entries should use
+// SetCTXPaymentRelatedInformation to also have their number of addenda records set.
+func (ed *EntryDetail) SetPaymentRelatedInformation(info string) {
+	ed.Addenda05 = nil
+	for i := 0; i < len(info); i += paymentRelatedInformationLength {
+		end := i + paymentRelatedInformationLength
+		if end > len(info) {
+			end = len(info)
+		}
+		addenda05 := NewAddenda05()
+		addenda05.PaymentRelatedInformation = info[i:end]
+		addenda05.SequenceNumber = len(ed.Addenda05) + 1
+		addenda05.EntryDetailSequenceNumber = ed.addendaEntryDetailSequenceNumber()
+		ed.AddAddenda05(addenda05)
+	}
+	ed.AddendaRecordIndicator = 0
+	if len(ed.Addenda05) > 0 {
+		ed.AddendaRecordIndicator = 1
+	}
+}
+
+// SetCTXPaymentRelatedInformation is SetPaymentRelatedInformation for CTX entries, which also sets the entry's
+// number of addenda records (see CATXAddendaRecordsField) while keeping its receiving company. A CTX entry
+// can have at most 9999 addenda records, longer info returns an error.
+func (ed *EntryDetail) SetCTXPaymentRelatedInformation(info string) error {
+	records := (len(info) + paymentRelatedInformationLength - 1) / paymentRelatedInformationLength
+	if records > maxCTXAddendaRecords {
+		return fieldError("PaymentRelatedInformation", NewErrBatchAddendaCount(records, maxCTXAddendaRecords), records)
+	}
+	ed.SetPaymentRelatedInformation(info)
+	if len(ed.IndividualName) >= 4 {
+		ed.IndividualName = ed.numericField(records, 4) + ed.IndividualName[4:]
+	} else {
+		ed.SetCATXAddendaRecords(records)
+	}
+	return nil
+}
+
+// PaymentRelatedInformation joins the PaymentRelatedInformation of the entry's Addenda05 records in the order
+// they're written. Every record but the last is padded to 80 characters as it is in a file, so information
+// split by SetPaymentRelatedInformation is returned as it was set.
+func (ed *EntryDetail) PaymentRelatedInformation() string {
+	var buf strings.Builder
+	for i, addenda05 := range ed.Addenda05 {
+		if i < len(ed.Addenda05)-1 {
+			buf.WriteString(addenda05.PaymentRelatedInformationField())
+		} else {
+			buf.WriteString(addenda05.PaymentRelatedInformation)
+		}
+	}
+	return buf.String()
+}
+
+// addendaEntryDetailSequenceNumber returns the last seven digits of the entry's TraceNumber, zero without one
+func (ed *EntryDetail) addendaEntryDetailSequenceNumber() int {
+	trace := ed.TraceNumberField()
+	return ed.parseNumField(trace[len(trace)-7:])
+}
@@ -0,0 +1,104 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base"
+)
+
+func TestEntryDetail__SetCTXPaymentRelatedInformation(t *testing.T) {
+	info := "ISA*00*          *00*          *ZZ*SENDER         *ZZ*RECEIVER       *210101*1200*U*00401*000000001*0*P*>\\" +
+		"ST*820*0001\\BPR*C*1000*C*ACH*CTX\\RMR*IV*12345**1000\\SE*4*0001\\"
+
+	batch := NewBatchCTX(mockBatchCTXHeader())
+	entry := mockCTXEntryDetail()
+	entry.AddendaRecordIndicator = 0
+	entry.Addenda05 = nil
+	if err := entry.SetCTXPaymentRelatedInformation(info); err != nil {
+		t.Fatal(err)
+	}
+	if len(entry.Addenda05) != 3 || entry.AddendaRecordIndicator != 1 {
+		t.Fatalf("unexpected addenda: %#v", entry.Addenda05)
+	}
+	for i, addenda05 := range entry.Addenda05 {
+		if addenda05.SequenceNumber != i+1 || addenda05.EntryDetailSequenceNumber != 1 {
+			t.Errorf("unexpected addenda: %#v", addenda05)
+		}
+	}
+	if entry.CATXAddendaRecordsField() != "0003" || entry.CATXReceivingCompanyField() != mockCTXEntryDetail().CATXReceivingCompanyField() {
+		t.Errorf("unexpected IndividualName: %q", entry.IndividualName)
+	}
+	if v := entry.PaymentRelatedInformation(); v != info {
+		t.Errorf("got %q", v)
+	}
+
+	batch.AddEntry(entry)
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	// info is split the same way after the entry is written and read
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+	var buf strings.Builder
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatal(err)
+	}
+	read, err := NewReader(strings.NewReader(buf.String())).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := read.Batches[0].GetEntries()[0].PaymentRelatedInformation(); v != info {
+		t.Errorf("got %q", v)
+	}
+
+	// clearing the information removes the addenda
+	entry.SetPaymentRelatedInformation("")
+	if len(entry.Addenda05) != 0 || entry.AddendaRecordIndicator != 0 || entry.PaymentRelatedInformation() != "" {
+		t.Errorf("unexpected entry: %#v", entry)
+	}
+}
+
+func TestEntryDetail__SetCTXPaymentRelatedInformationErrors(t *testing.T) {
+	entry := mockCTXEntryDetail()
+	entry.AddAddenda05(mockAddenda05())
+	if err := entry.SetCTXPaymentRelatedInformation(strings.Repeat("A", 80*10000)); !base.Match(err, NewErrBatchAddendaCount(10000, 9999)) {
+		t.Errorf("%T: %v", err, err)
+	}
+	if len(entry.Addenda05) != 1 {
+		t.Errorf("addenda were replaced: %d", len(entry.Addenda05))
+	}
+}
+
+func TestBatchCTX__addendaIndicatorWithoutAddenda(t *testing.T) {
+	batch := NewBatchCTX(mockBatchCTXHeader())
+	entry := mockCTXEntryDetail()
+	entry.Addenda05 = nil
+	entry.SetCATXAddendaRecords(0)
+	entry.AddendaRecordIndicator = 1
+	batch.AddEntry(entry)
+	if err := batch.Create(); !base.Match(err, ErrBatchAddendaIndicatorNoAddenda) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
@@ -0,0 +1,130 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base"
+)
+
+func mockPaymentParams() PaymentParams {
+	return PaymentParams{
+		ODFIRoutingNumber:     "121042882",
+		ODFIName:              "My Bank Name",
+		CompanyName:           "My Company",
+		CompanyIdentification: "121042882",
+		ReceiverName:          "Jane Doe",
+		ReceiverRoutingNumber: "231380104",
+		ReceiverAccountNumber: "123456789",
+		Amount:                12500,
+		Description:           "PAYROLL",
+	}
+}
+
+func TestPayment__credit(t *testing.T) {
+	file, err := NewCreditPayment(mockPaymentParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Header.ImmediateDestination != "121042882" {
+		t.Errorf("ImmediateDestination=%s", file.Header.ImmediateDestination)
+	}
+	bh := file.Batches[0].GetHeader()
+	if bh.StandardEntryClassCode != PPD || bh.ServiceClassCode != CreditsOnly {
+		t.Errorf("StandardEntryClassCode=%s ServiceClassCode=%d", bh.StandardEntryClassCode, bh.ServiceClassCode)
+	}
+	if bh.ODFIIdentification != "12104288" {
+		t.Errorf("ODFIIdentification=%s", bh.ODFIIdentification)
+	}
+	ed := file.Batches[0].GetEntries()[0]
+	if ed.TransactionCode != CheckingCredit || ed.Amount != 12500 || ed.TraceNumber == "" {
+		t.Errorf("unexpected entry: %#v", ed)
+	}
+	if file.Control.TotalCreditEntryDollarAmountInFile != 12500 {
+		t.Errorf("TotalCreditEntryDollarAmountInFile=%d", file.Control.TotalCreditEntryDollarAmountInFile)
+	}
+
+	// the file can be written and read back
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewReader(&buf).Read(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPayment__debit(t *testing.T) {
+	params := mockPaymentParams()
+	params.Savings = true
+	params.SECCode = WEB
+	params.DestinationRoutingNumber = "031300012"
+	params.EffectiveDate = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+	file, err := NewDebitPayment(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Header.ImmediateDestination != "031300012" {
+		t.Errorf("ImmediateDestination=%s", file.Header.ImmediateDestination)
+	}
+	bh := file.Batches[0].GetHeader()
+	if bh.StandardEntryClassCode != WEB || bh.ServiceClassCode != DebitsOnly || bh.EffectiveEntryDate != "261019" {
+		t.Errorf("unexpected batch header: %#v", bh)
+	}
+	ed := file.Batches[0].GetEntries()[0]
+	if ed.TransactionCode != SavingsDebit || ed.DiscretionaryData != "S" {
+		t.Errorf("TransactionCode=%d DiscretionaryData=%q", ed.TransactionCode, ed.DiscretionaryData)
+	}
+}
+
+func TestPayment__errors(t *testing.T) {
+	params := mockPaymentParams()
+	params.SECCode = "ARC"
+	params.Amount = 0
+	_, err := NewCreditPayment(params)
+	if errs, ok := err.(base.ErrorList); !ok || len(errs) != 2 {
+		t.Fatalf("%T: %v", err, err)
+	}
+
+	// TEL is only for debits
+	params = mockPaymentParams()
+	params.SECCode = TEL
+	_, err = NewCreditPayment(params)
+	if errs, ok := err.(base.ErrorList); !ok || len(errs) != 1 || !base.Has(err, ErrBatchDebitOnly) {
+		t.Errorf("%T: %v", err, err)
+	}
+	if _, err := NewDebitPayment(params); err != nil {
+		t.Error(err)
+	}
+
+	params = mockPaymentParams()
+	params.ReceiverRoutingNumber = "123"
+	if _, err := NewCreditPayment(params); err == nil {
+		t.Error("expected error")
+	}
+
+	params = mockPaymentParams()
+	params.ODFIRoutingNumber = "1210"
+	if _, err := NewCreditPayment(params); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,209 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moov-io/base"
+)
+
+// Policy is the rules an organization, such as an ODFI, has for the batches it accepts on top of
+// the NACHA rules: which companies may originate, the SEC codes and Company Entry Descriptions they
+// may use and how much they may send each day. The zero value accepts every batch.
+//
+// Descriptions are compared after NormalizeCompanyEntryDescription.
+type Policy struct {
+	// Companies lists the companies allowed to originate, by CompanyIdentification (or OriginatorIdentification
+	// for IAT batches). Any company is allowed when empty.
+	Companies []CompanyPolicy `json:"companies,omitempty"`
+	// SECCodes are the Standard Entry Class Codes companies without their own may originate, any when empty
+	SECCodes []string `json:"secCodes,omitempty"`
+	// Descriptions are the Company Entry Descriptions companies without their own may use, any when empty
+	Descriptions []string `json:"descriptions,omitempty"`
+}
+
+// CompanyPolicy is the rules for one company of a Policy
+type CompanyPolicy struct {
+	CompanyIdentification string `json:"companyIdentification"`
+	// SECCodes are the Standard Entry Class Codes the company may originate, the Policy's when empty
+	SECCodes []string `json:"secCodes,omitempty"`
+	// Descriptions are the Company Entry Descriptions the company may use, the Policy's when empty
+	Descriptions []string `json:"descriptions,omitempty"`
+	// DailyLimit is the largest total of debits and credits the company may send for each effective
+	// entry date, with no limit when zero
+	DailyLimit Amount `json:"dailyLimit,omitempty"`
+}
+
+// NormalizeCompanyEntryDescription returns s in uppercase with surrounding spaces removed and spaces
+// between words collapsed, e.g. " payroll  Jan " is "PAYROLL JAN".
+func NormalizeCompanyEntryDescription(s string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(s), " "))
+}
+
+// Validate checks the Policy's SEC codes are known and its companies are listed once
+func (p *Policy) Validate() error {
+	var errs base.ErrorList
+	checkSECCodes := func(codes []string) {
+		for _, code := range codes {
+			if _, registered := registeredBatchType(code); !isNACHASECCode(code) && !registered {
+				errs.Add(fieldError("SECCodes", ErrSECCode, code))
+			}
+		}
+	}
+	checkSECCodes(p.SECCodes)
+
+	seen := make(map[string]bool)
+	for _, c := range p.Companies {
+		if c.CompanyIdentification == "" {
+			errs.Add(fieldError("CompanyIdentification", ErrConstructor, c.CompanyIdentification))
+		} else if seen[c.CompanyIdentification] {
+			errs.Add(fmt.Errorf("company %s is listed more than once", c.CompanyIdentification))
+		}
+		seen[c.CompanyIdentification] = true
+		checkSECCodes(c.SECCodes)
+		if c.DailyLimit < 0 {
+			errs.Add(fieldError("DailyLimit", ErrNegativeAmount, c.DailyLimit.String()))
+		}
+	}
+	if errs.Empty() {
+		return nil
+	}
+	return errs
+}
+
+// policyBatch is the part of a batch or IAT batch a Policy checks
+type policyBatch struct {
+	company, sec, description, effectiveDate string
+	total                                    Amount
+	err                                      func(string, error, ...interface{}) error
+}
+
+func policyBatches(f *File) []policyBatch {
+	var batches []policyBatch
+	f.ForEachBatch(func(b Batcher) error {
+		bh, bc := b.GetHeader(), b.GetControl()
+		var total Amount
+		if bc != nil {
+			total = bc.TotalDebitAmount() + bc.TotalCreditAmount()
+		}
+		batches = append(batches, policyBatch{
+			company:       strings.TrimSpace(bh.CompanyIdentification),
+			sec:           bh.StandardEntryClassCode,
+			description:   bh.CompanyEntryDescription,
+			effectiveDate: bh.EffectiveEntryDate,
+			total:         total,
+			err:           b.Error,
+		})
+		return nil
+	})
+	f.ForEachIATBatch(func(b *IATBatch) error {
+		var total Amount
+		if bc := b.GetControl(); bc != nil {
+			total = bc.TotalDebitAmount() + bc.TotalCreditAmount()
+		}
+		batches = append(batches, policyBatch{
+			company:       strings.TrimSpace(b.Header.OriginatorIdentification),
+			sec:           IAT,
+			description:   b.Header.CompanyEntryDescription,
+			effectiveDate: b.Header.EffectiveEntryDate,
+			total:         total,
+			err:           b.Error,
+		})
+		return nil
+	})
+	return batches
+}
+
+// Check returns every batch of f the Policy doesn't allow, as a BatchError wrapping ErrBatchCompanyNotAllowed,
+// ErrBatchSECCodeNotAllowed or ErrBatchDescriptionNotAllowed, and every company whose batches for an effective
+// entry date exceed its DailyLimit, wrapping ErrFileDailyLimit. Batches of others, such as files already sent
+// today, count towards daily limits but aren't otherwise checked.
+func (p *Policy) Check(f *File, others ...*File) error {
+	companies := make(map[string]*CompanyPolicy)
+	for i := range p.Companies {
+		companies[p.Companies[i].CompanyIdentification] = &p.Companies[i]
+	}
+
+	type day struct{ company, date string }
+	totals := make(map[day]Amount)
+	for _, other := range others {
+		for _, b := range policyBatches(other) {
+			totals[day{b.company, b.effectiveDate}] += b.total
+		}
+	}
+
+	var errs base.ErrorList
+	var days []day // with a DailyLimit, in the order f has them
+	checked := make(map[day]bool)
+	for _, b := range policyBatches(f) {
+		secCodes, descriptions := p.SECCodes, p.Descriptions
+		c, ok := companies[b.company]
+		switch {
+		case ok:
+			if len(c.SECCodes) > 0 {
+				secCodes = c.SECCodes
+			}
+			if len(c.Descriptions) > 0 {
+				descriptions = c.Descriptions
+			}
+		case len(companies) > 0:
+			errs.Add(b.err("CompanyIdentification", ErrBatchCompanyNotAllowed, b.company))
+			continue
+		}
+		if !policyAllows(secCodes, b.sec, strings.ToUpper) {
+			errs.Add(b.err("StandardEntryClassCode", ErrBatchSECCodeNotAllowed, b.sec))
+		}
+		if !policyAllows(descriptions, b.description, NormalizeCompanyEntryDescription) {
+			errs.Add(b.err("CompanyEntryDescription", ErrBatchDescriptionNotAllowed, b.description))
+		}
+
+		if ok && c.DailyLimit > 0 {
+			d := day{b.company, b.effectiveDate}
+			if !checked[d] {
+				checked[d] = true
+				days = append(days, d)
+			}
+			totals[d] += b.total
+		}
+	}
+	for _, d := range days {
+		if limit := companies[d.company].DailyLimit; totals[d] > limit {
+			errs.Add(fmt.Errorf("company %s on %s: %w (%s is over %s)", d.company, d.date, ErrFileDailyLimit, totals[d], limit))
+		}
+	}
+	if errs.Empty() {
+		return nil
+	}
+	return errs
+}
+
+// policyAllows returns true if allowed is empty or has v, comparing both after normalize
+func policyAllows(allowed []string, v string, normalize func(string) string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	v = normalize(v)
+	for _, a := range allowed {
+		if normalize(a) == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base"
+)
+
+// mockPolicyFile returns a file with a PPD batch of 1,000,000.00 and a CCD batch of 50,000.00 from company
+// 121042882 for the same effective entry date
+func mockPolicyFile(t *testing.T) *File {
+	t.Helper()
+
+	bh := mockBatchCCDHeader()
+	bh.CompanyIdentification = "121042882"
+	bh.CompanyEntryDescription = "vendor  pay"
+	bh.EffectiveEntryDate = mockBatchPPDHeader().EffectiveEntryDate
+	file, err := NewFileBuilder().
+		WithHeader(mockFileHeader()).
+		AddPPDBatch(mockBatchPPDHeader()).AddEntry(mockPPDEntryDetail()).
+		AddCCDBatch(bh).AddEntry(mockCCDEntryDetail()).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestNormalizeCompanyEntryDescription(t *testing.T) {
+	cases := map[string]string{
+		"PAYROLL":         "PAYROLL",
+		" payroll ":       "PAYROLL",
+		"Vendor   Pay":    "VENDOR PAY",
+		"":                "",
+		"\tRETURN  FEE\n": "RETURN FEE",
+	}
+	for in, want := range cases {
+		if got := NormalizeCompanyEntryDescription(in); got != want {
+			t.Errorf("%q: got %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestPolicy__Check(t *testing.T) {
+	file := mockPolicyFile(t)
+
+	var p Policy
+	if err := p.Check(file); err != nil {
+		t.Fatalf("zero Policy: %v", err)
+	}
+
+	p = Policy{
+		Companies: []CompanyPolicy{{
+			CompanyIdentification: "121042882",
+			SECCodes:              []string{"ppd", CCD},
+			Descriptions:          []string{"PAYROLL", "Vendor Pay"},
+			DailyLimit:            105000000,
+		}},
+	}
+	if err := p.Check(file); err != nil {
+		t.Fatal(err)
+	}
+
+	// the company only has its own SEC codes and descriptions
+	p.SECCodes = []string{WEB}
+	p.Descriptions = []string{"PAYMENT"}
+	if err := p.Check(file); err != nil {
+		t.Fatal(err)
+	}
+	p.Companies[0].SECCodes = []string{PPD}
+	p.Companies[0].Descriptions = []string{"PAYROLL"}
+	err := p.Check(file)
+	errs, ok := err.(base.ErrorList)
+	if !ok || len(errs) != 2 || !base.Has(errs, ErrBatchSECCodeNotAllowed) || !base.Has(errs, ErrBatchDescriptionNotAllowed) {
+		t.Fatalf("%T: %v", err, err)
+	}
+	if be, ok := errs[0].(*BatchError); !ok || be.BatchType != CCD || be.FieldName != "StandardEntryClassCode" {
+		t.Errorf("unexpected error: %#v", errs[0])
+	}
+
+	// companies without their own use the Policy's
+	p.Companies[0].SECCodes, p.Companies[0].Descriptions = nil, nil
+	if err := p.Check(file); !base.Has(err, ErrBatchSECCodeNotAllowed) || !base.Has(err, ErrBatchDescriptionNotAllowed) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
+
+func TestPolicy__companies(t *testing.T) {
+	file := mockPolicyFile(t)
+	p := Policy{Companies: []CompanyPolicy{{CompanyIdentification: "987654321"}}}
+	err := p.Check(file)
+	errs, ok := err.(base.ErrorList)
+	if !ok || len(errs) != 2 || !base.Has(errs, ErrBatchCompanyNotAllowed) {
+		t.Fatalf("%T: %v", err, err)
+	}
+}
+
+func TestPolicy__DailyLimit(t *testing.T) {
+	file := mockPolicyFile(t)
+	p := Policy{Companies: []CompanyPolicy{{CompanyIdentification: "121042882", DailyLimit: 100000000}}}
+
+	err := p.Check(file)
+	errs, ok := err.(base.ErrorList)
+	if !ok || len(errs) != 1 || !base.Has(errs, ErrFileDailyLimit) {
+		t.Fatalf("%T: %v", err, err)
+	}
+	if !strings.Contains(errs[0].Error(), "1050000.00 is over 1000000.00") {
+		t.Errorf("unexpected error: %v", errs[0])
+	}
+
+	// other files count towards the limit
+	p.Companies[0].DailyLimit = 105000000
+	if err := p.Check(file); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Check(file, mockPolicyFile(t)); !base.Has(err, ErrFileDailyLimit) {
+		t.Errorf("%T: %v", err, err)
+	}
+
+	// each effective entry date has its own limit
+	other := mockPolicyFile(t)
+	for _, b := range other.Batches {
+		b.GetHeader().EffectiveEntryDate = "190816"
+	}
+	if err := p.Check(file, other); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPolicy__Validate(t *testing.T) {
+	var p Policy
+	if err := json.Unmarshal([]byte(`{
+  "companies": [{"companyIdentification": "121042882", "secCodes": ["PPD"], "dailyLimit": "2,500.00"}],
+  "secCodes": ["CCD", "WEB"]
+}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if p.Companies[0].DailyLimit != 250000 {
+		t.Errorf("DailyLimit=%d", p.Companies[0].DailyLimit)
+	}
+
+	p = Policy{
+		Companies: []CompanyPolicy{
+			{CompanyIdentification: "121042882", SECCodes: []string{"XYZ"}},
+			{CompanyIdentification: "121042882", DailyLimit: -1},
+			{},
+		},
+		SECCodes: []string{"ABC"},
+	}
+	err := p.Validate()
+	if errs, ok := err.(base.ErrorList); !ok || len(errs) != 5 {
+		t.Errorf("%T: %v", err, err)
+	}
+}
@@ -0,0 +1,133 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+)
+
+var (
+	// ErrPrenoteAmount is the error given when a prenotification has a non-zero Amount
+	ErrPrenoteAmount = errors.New("prenotes must have a zero amount")
+	// ErrPrenoteTransactionCode is the error given when an entry's TransactionCode has no prenotification
+	ErrPrenoteTransactionCode = errors.New("transaction code has no prenote")
+	// ErrPrenoteSECCode is the error given when prenotes are created for a batch whose SEC code doesn't allow them
+	ErrPrenoteSECCode = errors.New("SEC code does not allow prenotes")
+)
+
+// prenoteTransactionCodes maps the TransactionCode of live entries to that of their prenotification
+var prenoteTransactionCodes = map[int]int{
+	CheckingCredit: CheckingPrenoteCredit,
+	CheckingDebit:  CheckingPrenoteDebit,
+	SavingsCredit:  SavingsPrenoteCredit,
+	SavingsDebit:   SavingsPrenoteDebit,
+	GLCredit:       GLPrenoteCredit,
+	GLDebit:        GLPrenoteDebit,
+	LoanCredit:     LoanPrenoteCredit,
+}
+
+// prenoteSECCodes are the StandardEntryClassCodes CreatePrenotes creates prenotes for. Check conversion,
+// point of sale and non-monetary SEC codes have no prenotes and CTX batches don't accept prenote entries.
+var prenoteSECCodes = map[string]bool{
+	CCD: true,
+	CIE: true,
+	PPD: true,
+	TEL: true,
+	WEB: true,
+}
+
+// isPrenoteTransactionCode returns true if code is the TransactionCode of a prenotification
+func isPrenoteTransactionCode(code int) bool {
+	switch code {
+	case CheckingPrenoteCredit, CheckingPrenoteDebit, SavingsPrenoteCredit, SavingsPrenoteDebit,
+		GLPrenoteCredit, GLPrenoteDebit, LoanPrenoteCredit:
+		return true
+	}
+	return false
+}
+
+// PrenoteTransactionCode returns the TransactionCode of a prenotification for an entry with code.
+// Prenote codes are returned as is and ErrPrenoteTransactionCode is returned for codes without a prenote.
+func PrenoteTransactionCode(code int) (int, error) {
+	if isPrenoteTransactionCode(code) {
+		return code, nil
+	}
+	if prenote, ok := prenoteTransactionCodes[code]; ok {
+		return prenote, nil
+	}
+	return 0, ErrPrenoteTransactionCode
+}
+
+// IsPrenote returns true if the entry is a prenotification, which verifies the receiver's account
+// and carries no amount
+func (ed *EntryDetail) IsPrenote() bool {
+	return isPrenoteTransactionCode(ed.TransactionCode)
+}
+
+// CreatePrenotes returns a new batch with the batch's header and a prenotification for each of its entries,
+// sent ahead of live entries to verify the receivers' accounts. Prenotes have a zero Amount, the prenote
+// TransactionCode of their entry (see PrenoteTransactionCode) and keep the entry's Addenda05 records, other
+// addenda are left out. Only CCD, CIE, PPD, TEL and WEB batches can be prenoted, ErrPrenoteSECCode
+// is returned for other SEC codes.
+//
+// The returned batch has been built with Create().
+func (batch *Batch) CreatePrenotes() (Batcher, error) {
+	if !prenoteSECCodes[batch.Header.StandardEntryClassCode] {
+		return nil, batch.Error("StandardEntryClassCode", ErrPrenoteSECCode, batch.Header.StandardEntryClassCode)
+	}
+
+	bh := *batch.Header
+	bh.ID = ""
+	out, err := NewBatch(&bh)
+	if err != nil {
+		return nil, err
+	}
+	if batch.validateOpts != nil {
+		out.SetValidation(batch.validateOpts)
+	}
+	for _, entry := range batch.Entries {
+		code, err := PrenoteTransactionCode(entry.TransactionCode)
+		if err != nil {
+			return nil, batch.Error("TransactionCode", err, entry.TransactionCode)
+		}
+		prenote := *entry
+		prenote.ID = ""
+		prenote.TransactionCode = code
+		prenote.Amount = 0
+		prenote.Addenda02 = nil
+		prenote.Addenda98 = nil
+		prenote.Addenda99 = nil
+		prenote.Addenda99Dishonored = nil
+		prenote.Addenda99Contested = nil
+		prenote.Category = CategoryForward
+		prenote.Addenda05 = nil
+		for _, addenda05 := range entry.Addenda05 {
+			copied := *addenda05
+			prenote.AddAddenda05(&copied)
+		}
+		prenote.AddendaRecordIndicator = 0
+		if len(prenote.Addenda05) > 0 {
+			prenote.AddendaRecordIndicator = 1
+		}
+		out.AddEntry(&prenote)
+	}
+	if err := out.Create(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
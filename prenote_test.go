@@ -0,0 +1,124 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"testing"
+
+	"github.com/moov-io/base"
+)
+
+func TestPrenoteTransactionCode(t *testing.T) {
+	cases := map[int]int{
+		CheckingCredit:        CheckingPrenoteCredit,
+		CheckingDebit:         CheckingPrenoteDebit,
+		SavingsCredit:         SavingsPrenoteCredit,
+		SavingsDebit:          SavingsPrenoteDebit,
+		GLCredit:              GLPrenoteCredit,
+		GLDebit:               GLPrenoteDebit,
+		LoanCredit:            LoanPrenoteCredit,
+		CheckingPrenoteCredit: CheckingPrenoteCredit,
+	}
+	for code, expected := range cases {
+		if prenote, err := PrenoteTransactionCode(code); err != nil || prenote != expected {
+			t.Errorf("%d: got %d (%v) expected %d", code, prenote, err, expected)
+		}
+	}
+	for _, code := range []int{CheckingReturnNOCCredit, CheckingZeroDollarRemittanceCredit, LoanDebit} {
+		if _, err := PrenoteTransactionCode(code); err != ErrPrenoteTransactionCode {
+			t.Errorf("%d: unexpected error: %v", code, err)
+		}
+	}
+}
+
+func TestBatch__CreatePrenotes(t *testing.T) {
+	batch := NewBatchPPD(mockBatchPPDHeader2())
+	batch.AddEntry(mockPPDEntryDetail2())
+	debit := mockPPDEntryDetail2()
+	debit.TransactionCode = SavingsDebit
+	debit.SetTraceNumber(batch.Header.ODFIIdentification, 2)
+	debit.AddAddenda05(mockAddenda05())
+	debit.AddendaRecordIndicator = 1
+	batch.AddEntry(debit)
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	prenotes, err := batch.CreatePrenotes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := prenotes.(*BatchPPD); !ok {
+		t.Errorf("unexpected batch: %T", prenotes)
+	}
+	entries := prenotes.GetEntries()
+	if len(entries) != 2 || entries[0].TransactionCode != CheckingPrenoteCredit || entries[1].TransactionCode != SavingsPrenoteDebit {
+		t.Fatalf("unexpected entries: %#v", entries)
+	}
+	for _, entry := range entries {
+		if !entry.IsPrenote() || entry.Amount != 0 {
+			t.Errorf("unexpected prenote: %#v", entry)
+		}
+	}
+	if len(entries[1].Addenda05) != 1 || entries[1].AddendaRecordIndicator != 1 || entries[1].Addenda05[0] == debit.Addenda05[0] {
+		t.Errorf("unexpected addenda: %#v", entries[1].Addenda05)
+	}
+	if c := prenotes.GetControl(); c.TotalCreditEntryDollarAmount != 0 || c.TotalDebitEntryDollarAmount != 0 || c.EntryAddendaCount != 3 {
+		t.Errorf("unexpected control: %#v", c)
+	}
+
+	// the batch is left alone
+	if batch.Entries[0].TransactionCode != CheckingCredit || batch.Entries[0].Amount != 100000 {
+		t.Errorf("unexpected entry: %#v", batch.Entries[0])
+	}
+}
+
+func TestBatch__CreatePrenotesErrors(t *testing.T) {
+	for _, sec := range []string{ARC, CTX} {
+		bh := mockBatchPPDHeader()
+		bh.StandardEntryClassCode = sec
+		batch := &Batch{Header: bh}
+		if _, err := batch.CreatePrenotes(); !base.Match(err, ErrPrenoteSECCode) {
+			t.Errorf("%s: %T: %v", sec, err, err)
+		}
+	}
+
+	ppd := mockBatchPPD()
+	ppd.Entries[0].TransactionCode = LoanDebit
+	if _, err := ppd.CreatePrenotes(); !base.Match(err, ErrPrenoteTransactionCode) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
+
+func TestEntryDetail__prenoteAmount(t *testing.T) {
+	entry := mockPPDEntryDetail()
+	entry.TransactionCode = CheckingPrenoteCredit
+	if err := entry.Validate(); !base.Match(err, ErrPrenoteAmount) {
+		t.Errorf("%T: %v", err, err)
+	}
+	entry.Amount = 0
+	if err := entry.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	iatEntry := mockIATEntryDetail()
+	iatEntry.TransactionCode = SavingsPrenoteCredit
+	if err := iatEntry.Validate(); !base.Match(err, ErrPrenoteAmount) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
@@ -0,0 +1,329 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// MarshalProto returns the File encoded as the ach.v1.File protobuf message defined in ach.proto.
+// Every field of the File and its records is kept, so FileFromProto returns an identical File.
+func (f *File) MarshalProto() ([]byte, error) {
+	if f == nil {
+		return nil, errors.New("nil File")
+	}
+	for _, batch := range f.Batches {
+		if _, ok := batch.(interface{ asBatch() *Batch }); !ok {
+			return nil, fmt.Errorf("%T does not embed ach.Batch", batch)
+		}
+	}
+	var w protoWriter
+	f.marshalProto(&w)
+	return w.buf, nil
+}
+
+// FileFromProto reads a File encoded as the ach.v1.File protobuf message defined in ach.proto.
+// Batches are converted to the Batcher of their StandardEntryClassCode with ConvertBatchType.
+//
+// The File is returned as encoded, call Validate to check it against the NACHA rules.
+func FileFromProto(bs []byte) (*File, error) {
+	if len(bs) == 0 {
+		return nil, errors.New("no protobuf data provided")
+	}
+	f := NewFile()
+	if err := f.unmarshalProto(bs); err != nil {
+		return nil, fmt.Errorf("problem reading File: %v", err)
+	}
+	return f, nil
+}
+
+func (f *File) marshalProto(w *protoWriter) {
+	w.string(1, f.ID)
+	w.message(2, f.Header.marshalProto)
+	for _, batch := range f.Batches {
+		if b, ok := batch.(interface{ asBatch() *Batch }); ok {
+			w.message(3, b.asBatch().marshalProto)
+		}
+	}
+	for i := range f.IATBatches {
+		w.message(4, f.IATBatches[i].marshalProto)
+	}
+	w.message(5, f.Control.marshalProto)
+	w.message(6, f.ADVControl.marshalProto)
+	for _, record := range f.TrailerRecords {
+		w.repeatedString(7, record)
+	}
+	for _, comment := range f.Comments {
+		w.repeatedString(8, comment)
+	}
+}
+
+func (f *File) unmarshalProto(bs []byte) error {
+	return readProto(bs, func(field protoField) error {
+		switch field.num {
+		case 1:
+			f.ID = field.string()
+		case 2:
+			f.Header = NewFileHeader()
+			return f.Header.unmarshalProto(field.bytes)
+		case 3:
+			var batch Batch
+			if err := batch.unmarshalProto(field.bytes); err != nil {
+				return err
+			}
+			f.AddBatch(ConvertBatchType(batch))
+		case 4:
+			var iatBatch IATBatch
+			if err := iatBatch.unmarshalProto(field.bytes); err != nil {
+				return err
+			}
+			f.AddIATBatch(iatBatch)
+		case 5:
+			f.Control = NewFileControl()
+			return f.Control.unmarshalProto(field.bytes)
+		case 6:
+			f.ADVControl = NewADVFileControl()
+			return f.ADVControl.unmarshalProto(field.bytes)
+		case 7:
+			f.TrailerRecords = append(f.TrailerRecords, field.string())
+		case 8:
+			f.Comments = append(f.Comments, field.string())
+		}
+		return nil
+	})
+}
+
+// asBatch returns the Batch embedded in a Batcher implementation
+func (batch *Batch) asBatch() *Batch {
+	return batch
+}
+
+func (batch *Batch) marshalProto(w *protoWriter) {
+	w.string(1, batch.id)
+	if batch.Header != nil {
+		w.message(2, batch.Header.marshalProto)
+	}
+	for _, entry := range batch.Entries {
+		w.message(3, entry.marshalProto)
+	}
+	if batch.Control != nil {
+		w.message(4, batch.Control.marshalProto)
+	}
+	for _, entry := range batch.ADVEntries {
+		w.message(5, entry.marshalProto)
+	}
+	if batch.ADVControl != nil {
+		w.message(6, batch.ADVControl.marshalProto)
+	}
+	for _, comment := range batch.Comments {
+		w.repeatedString(7, comment)
+	}
+}
+
+func (batch *Batch) unmarshalProto(bs []byte) error {
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			batch.id = f.string()
+		case 2:
+			batch.Header = NewBatchHeader()
+			return batch.Header.unmarshalProto(f.bytes)
+		case 3:
+			entry := NewEntryDetail()
+			if err := entry.unmarshalProto(f.bytes); err != nil {
+				return err
+			}
+			batch.Entries = append(batch.Entries, entry)
+		case 4:
+			batch.Control = NewBatchControl()
+			return batch.Control.unmarshalProto(f.bytes)
+		case 5:
+			entry := NewADVEntryDetail()
+			if err := entry.unmarshalProto(f.bytes); err != nil {
+				return err
+			}
+			batch.ADVEntries = append(batch.ADVEntries, entry)
+		case 6:
+			batch.ADVControl = NewADVBatchControl()
+			return batch.ADVControl.unmarshalProto(f.bytes)
+		case 7:
+			batch.Comments = append(batch.Comments, f.string())
+		}
+		return nil
+	})
+}
+
+func (iatBatch *IATBatch) marshalProto(w *protoWriter) {
+	w.string(1, iatBatch.ID)
+	if iatBatch.Header != nil {
+		w.message(2, iatBatch.Header.marshalProto)
+	}
+	for _, entry := range iatBatch.Entries {
+		w.message(3, entry.marshalProto)
+	}
+	if iatBatch.Control != nil {
+		w.message(4, iatBatch.Control.marshalProto)
+	}
+	for _, comment := range iatBatch.Comments {
+		w.repeatedString(5, comment)
+	}
+}
+
+func (iatBatch *IATBatch) unmarshalProto(bs []byte) error {
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			iatBatch.ID = f.string()
+		case 2:
+			iatBatch.Header = NewIATBatchHeader()
+			return iatBatch.Header.unmarshalProto(f.bytes)
+		case 3:
+			entry := NewIATEntryDetail()
+			if err := entry.unmarshalProto(f.bytes); err != nil {
+				return err
+			}
+			iatBatch.Entries = append(iatBatch.Entries, entry)
+		case 4:
+			iatBatch.Control = NewBatchControl()
+			return iatBatch.Control.unmarshalProto(f.bytes)
+		case 5:
+			iatBatch.Comments = append(iatBatch.Comments, f.string())
+		}
+		return nil
+	})
+}
+
+// protobuf wire types used by ach.proto
+const (
+	protoVarint          = 0
+	protoFixed64         = 1
+	protoLengthDelimited = 2
+	protoFixed32         = 5
+)
+
+// protoWriter appends fields in the protobuf wire format. Like proto3, zero values are
+// left out, apart from messages which are always written so their presence is kept.
+type protoWriter struct {
+	buf []byte
+}
+
+func (w *protoWriter) tag(num, wireType int) {
+	w.varint(uint64(num<<3 | wireType))
+}
+
+func (w *protoWriter) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	w.buf = append(w.buf, b[:n]...)
+}
+
+func (w *protoWriter) bytes(num int, v []byte) {
+	w.tag(num, protoLengthDelimited)
+	w.varint(uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+func (w *protoWriter) string(num int, v string) {
+	if v != "" {
+		w.bytes(num, []byte(v))
+	}
+}
+
+// repeatedString writes v even when it's empty so the positions of repeated values are kept
+func (w *protoWriter) repeatedString(num int, v string) {
+	w.bytes(num, []byte(v))
+}
+
+func (w *protoWriter) int(num int, v int) {
+	if v != 0 {
+		w.tag(num, protoVarint)
+		w.varint(uint64(int64(v)))
+	}
+}
+
+func (w *protoWriter) message(num int, marshal func(*protoWriter)) {
+	var m protoWriter
+	marshal(&m)
+	w.bytes(num, m.buf)
+}
+
+// protoField is one field read from a protobuf message
+type protoField struct {
+	num      int
+	wireType int
+	varint   uint64
+	bytes    []byte
+}
+
+func (f protoField) string() string {
+	if f.wireType != protoLengthDelimited {
+		return ""
+	}
+	return string(f.bytes)
+}
+
+func (f protoField) int() int {
+	if f.wireType != protoVarint {
+		return 0
+	}
+	return int(int64(f.varint))
+}
+
+// readProto calls fn with each field of a protobuf message. Unknown fields are skipped by fn
+// so messages written by newer versions of ach.proto can be read.
+func readProto(bs []byte, fn func(protoField) error) error {
+	for len(bs) > 0 {
+		key, n := binary.Uvarint(bs)
+		if n <= 0 {
+			return errors.New("invalid protobuf field key")
+		}
+		bs = bs[n:]
+
+		f := protoField{num: int(key >> 3), wireType: int(key & 7)}
+		switch f.wireType {
+		case protoVarint:
+			if f.varint, n = binary.Uvarint(bs); n <= 0 {
+				return fmt.Errorf("invalid varint for field %d", f.num)
+			}
+			bs = bs[n:]
+		case protoFixed64, protoFixed32:
+			size := 8
+			if f.wireType == protoFixed32 {
+				size = 4
+			}
+			if len(bs) < size {
+				return fmt.Errorf("truncated field %d", f.num)
+			}
+			bs = bs[size:]
+		case protoLengthDelimited:
+			length, n := binary.Uvarint(bs)
+			if n <= 0 || uint64(len(bs)-n) < length {
+				return fmt.Errorf("truncated field %d", f.num)
+			}
+			f.bytes, bs = bs[n:n+int(length)], bs[n+int(length):]
+		default:
+			return fmt.Errorf("unsupported wire type %d for field %d", f.wireType, f.num)
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,1141 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+// protobuf encoding of the records of a File, field numbers match the messages in ach.proto
+
+func (fh *FileHeader) marshalProto(w *protoWriter) {
+	w.string(1, fh.ID)
+	w.string(2, fh.ImmediateDestination)
+	w.string(3, fh.ImmediateOrigin)
+	w.string(4, fh.FileCreationDate)
+	w.string(5, fh.FileCreationTime)
+	w.string(6, fh.FileIDModifier)
+	w.string(7, fh.ImmediateDestinationName)
+	w.string(8, fh.ImmediateOriginName)
+	w.string(9, fh.ReferenceCode)
+}
+
+func (fh *FileHeader) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewFileHeader
+	fh.FileIDModifier = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			fh.ID = f.string()
+		case 2:
+			fh.ImmediateDestination = f.string()
+		case 3:
+			fh.ImmediateOrigin = f.string()
+		case 4:
+			fh.FileCreationDate = f.string()
+		case 5:
+			fh.FileCreationTime = f.string()
+		case 6:
+			fh.FileIDModifier = f.string()
+		case 7:
+			fh.ImmediateDestinationName = f.string()
+		case 8:
+			fh.ImmediateOriginName = f.string()
+		case 9:
+			fh.ReferenceCode = f.string()
+		}
+		return nil
+	})
+}
+
+func (fc *FileControl) marshalProto(w *protoWriter) {
+	w.string(1, fc.ID)
+	w.int(2, fc.BatchCount)
+	w.int(3, fc.BlockCount)
+	w.int(4, fc.EntryAddendaCount)
+	w.int(5, fc.EntryHash)
+	w.int(6, fc.TotalDebitEntryDollarAmountInFile)
+	w.int(7, fc.TotalCreditEntryDollarAmountInFile)
+}
+
+func (fc *FileControl) unmarshalProto(bs []byte) error {
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			fc.ID = f.string()
+		case 2:
+			fc.BatchCount = f.int()
+		case 3:
+			fc.BlockCount = f.int()
+		case 4:
+			fc.EntryAddendaCount = f.int()
+		case 5:
+			fc.EntryHash = f.int()
+		case 6:
+			fc.TotalDebitEntryDollarAmountInFile = f.int()
+		case 7:
+			fc.TotalCreditEntryDollarAmountInFile = f.int()
+		}
+		return nil
+	})
+}
+
+func (fc *ADVFileControl) marshalProto(w *protoWriter) {
+	w.string(1, fc.ID)
+	w.int(2, fc.BatchCount)
+	w.int(3, fc.BlockCount)
+	w.int(4, fc.EntryAddendaCount)
+	w.int(5, fc.EntryHash)
+	w.int(6, fc.TotalDebitEntryDollarAmountInFile)
+	w.int(7, fc.TotalCreditEntryDollarAmountInFile)
+}
+
+func (fc *ADVFileControl) unmarshalProto(bs []byte) error {
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			fc.ID = f.string()
+		case 2:
+			fc.BatchCount = f.int()
+		case 3:
+			fc.BlockCount = f.int()
+		case 4:
+			fc.EntryAddendaCount = f.int()
+		case 5:
+			fc.EntryHash = f.int()
+		case 6:
+			fc.TotalDebitEntryDollarAmountInFile = f.int()
+		case 7:
+			fc.TotalCreditEntryDollarAmountInFile = f.int()
+		}
+		return nil
+	})
+}
+
+func (bh *BatchHeader) marshalProto(w *protoWriter) {
+	w.string(1, bh.ID)
+	w.int(2, bh.ServiceClassCode)
+	w.string(3, bh.CompanyName)
+	w.string(4, bh.CompanyDiscretionaryData)
+	w.string(5, bh.CompanyIdentification)
+	w.string(6, bh.StandardEntryClassCode)
+	w.string(7, bh.CompanyEntryDescription)
+	w.string(8, bh.CompanyDescriptiveDate)
+	w.string(9, bh.EffectiveEntryDate)
+	w.int(10, bh.OriginatorStatusCode)
+	w.string(11, bh.ODFIIdentification)
+	w.int(12, bh.BatchNumber)
+}
+
+func (bh *BatchHeader) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewBatchHeader
+	bh.OriginatorStatusCode, bh.BatchNumber = 0, 0
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			bh.ID = f.string()
+		case 2:
+			bh.ServiceClassCode = f.int()
+		case 3:
+			bh.CompanyName = f.string()
+		case 4:
+			bh.CompanyDiscretionaryData = f.string()
+		case 5:
+			bh.CompanyIdentification = f.string()
+		case 6:
+			bh.StandardEntryClassCode = f.string()
+		case 7:
+			bh.CompanyEntryDescription = f.string()
+		case 8:
+			bh.CompanyDescriptiveDate = f.string()
+		case 9:
+			bh.EffectiveEntryDate = f.string()
+		case 10:
+			bh.OriginatorStatusCode = f.int()
+		case 11:
+			bh.ODFIIdentification = f.string()
+		case 12:
+			bh.BatchNumber = f.int()
+		}
+		return nil
+	})
+}
+
+func (bc *BatchControl) marshalProto(w *protoWriter) {
+	w.string(1, bc.ID)
+	w.int(2, bc.ServiceClassCode)
+	w.int(3, bc.EntryAddendaCount)
+	w.int(4, bc.EntryHash)
+	w.int(5, bc.TotalDebitEntryDollarAmount)
+	w.int(6, bc.TotalCreditEntryDollarAmount)
+	w.string(7, bc.CompanyIdentification)
+	w.string(8, bc.MessageAuthenticationCode)
+	w.string(9, bc.ODFIIdentification)
+	w.int(10, bc.BatchNumber)
+}
+
+func (bc *BatchControl) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewBatchControl
+	bc.ServiceClassCode, bc.EntryHash, bc.BatchNumber = 0, 0, 0
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			bc.ID = f.string()
+		case 2:
+			bc.ServiceClassCode = f.int()
+		case 3:
+			bc.EntryAddendaCount = f.int()
+		case 4:
+			bc.EntryHash = f.int()
+		case 5:
+			bc.TotalDebitEntryDollarAmount = f.int()
+		case 6:
+			bc.TotalCreditEntryDollarAmount = f.int()
+		case 7:
+			bc.CompanyIdentification = f.string()
+		case 8:
+			bc.MessageAuthenticationCode = f.string()
+		case 9:
+			bc.ODFIIdentification = f.string()
+		case 10:
+			bc.BatchNumber = f.int()
+		}
+		return nil
+	})
+}
+
+func (bc *ADVBatchControl) marshalProto(w *protoWriter) {
+	w.string(1, bc.ID)
+	w.int(2, bc.ServiceClassCode)
+	w.int(3, bc.EntryAddendaCount)
+	w.int(4, bc.EntryHash)
+	w.int(5, bc.TotalDebitEntryDollarAmount)
+	w.int(6, bc.TotalCreditEntryDollarAmount)
+	w.string(7, bc.ACHOperatorData)
+	w.string(8, bc.ODFIIdentification)
+	w.int(9, bc.BatchNumber)
+}
+
+func (bc *ADVBatchControl) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewADVBatchControl
+	bc.ServiceClassCode, bc.EntryHash, bc.BatchNumber = 0, 0, 0
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			bc.ID = f.string()
+		case 2:
+			bc.ServiceClassCode = f.int()
+		case 3:
+			bc.EntryAddendaCount = f.int()
+		case 4:
+			bc.EntryHash = f.int()
+		case 5:
+			bc.TotalDebitEntryDollarAmount = f.int()
+		case 6:
+			bc.TotalCreditEntryDollarAmount = f.int()
+		case 7:
+			bc.ACHOperatorData = f.string()
+		case 8:
+			bc.ODFIIdentification = f.string()
+		case 9:
+			bc.BatchNumber = f.int()
+		}
+		return nil
+	})
+}
+
+func (ed *EntryDetail) marshalProto(w *protoWriter) {
+	w.string(1, ed.ID)
+	w.int(2, ed.TransactionCode)
+	w.string(3, ed.RDFIIdentification)
+	w.string(4, ed.CheckDigit)
+	w.string(5, ed.DFIAccountNumber)
+	w.int(6, ed.Amount)
+	w.string(7, ed.IdentificationNumber)
+	w.string(8, ed.IndividualName)
+	w.string(9, ed.DiscretionaryData)
+	w.int(10, ed.AddendaRecordIndicator)
+	w.string(11, ed.TraceNumber)
+	if ed.Addenda02 != nil {
+		w.message(12, ed.Addenda02.marshalProto)
+	}
+	for _, a := range ed.Addenda05 {
+		w.message(13, a.marshalProto)
+	}
+	if ed.Addenda98 != nil {
+		w.message(14, ed.Addenda98.marshalProto)
+	}
+	if ed.Addenda99 != nil {
+		w.message(15, ed.Addenda99.marshalProto)
+	}
+	if ed.Addenda99Dishonored != nil {
+		w.message(16, ed.Addenda99Dishonored.marshalProto)
+	}
+	if ed.Addenda99Contested != nil {
+		w.message(17, ed.Addenda99Contested.marshalProto)
+	}
+	w.string(18, ed.Category)
+}
+
+func (ed *EntryDetail) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewEntryDetail
+	ed.Category = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			ed.ID = f.string()
+		case 2:
+			ed.TransactionCode = f.int()
+		case 3:
+			ed.RDFIIdentification = f.string()
+		case 4:
+			ed.CheckDigit = f.string()
+		case 5:
+			ed.DFIAccountNumber = f.string()
+		case 6:
+			ed.Amount = f.int()
+		case 7:
+			ed.IdentificationNumber = f.string()
+		case 8:
+			ed.IndividualName = f.string()
+		case 9:
+			ed.DiscretionaryData = f.string()
+		case 10:
+			ed.AddendaRecordIndicator = f.int()
+		case 11:
+			ed.TraceNumber = f.string()
+		case 12:
+			ed.Addenda02 = NewAddenda02()
+			return ed.Addenda02.unmarshalProto(f.bytes)
+		case 13:
+			a := NewAddenda05()
+			if err := a.unmarshalProto(f.bytes); err != nil {
+				return err
+			}
+			ed.Addenda05 = append(ed.Addenda05, a)
+		case 14:
+			ed.Addenda98 = NewAddenda98()
+			return ed.Addenda98.unmarshalProto(f.bytes)
+		case 15:
+			ed.Addenda99 = NewAddenda99()
+			return ed.Addenda99.unmarshalProto(f.bytes)
+		case 16:
+			ed.Addenda99Dishonored = NewAddenda99Dishonored()
+			return ed.Addenda99Dishonored.unmarshalProto(f.bytes)
+		case 17:
+			ed.Addenda99Contested = NewAddenda99Contested()
+			return ed.Addenda99Contested.unmarshalProto(f.bytes)
+		case 18:
+			ed.Category = f.string()
+		}
+		return nil
+	})
+}
+
+func (ed *ADVEntryDetail) marshalProto(w *protoWriter) {
+	w.string(1, ed.ID)
+	w.int(2, ed.TransactionCode)
+	w.string(3, ed.RDFIIdentification)
+	w.string(4, ed.CheckDigit)
+	w.string(5, ed.DFIAccountNumber)
+	w.int(6, ed.Amount)
+	w.string(7, ed.AdviceRoutingNumber)
+	w.string(8, ed.FileIdentification)
+	w.string(9, ed.ACHOperatorData)
+	w.string(10, ed.IndividualName)
+	w.string(11, ed.DiscretionaryData)
+	w.int(12, ed.AddendaRecordIndicator)
+	w.string(13, ed.ACHOperatorRoutingNumber)
+	w.int(14, ed.JulianDay)
+	w.int(15, ed.SequenceNumber)
+	if ed.Addenda99 != nil {
+		w.message(16, ed.Addenda99.marshalProto)
+	}
+	w.string(17, ed.Category)
+}
+
+func (ed *ADVEntryDetail) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewADVEntryDetail
+	ed.Category = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			ed.ID = f.string()
+		case 2:
+			ed.TransactionCode = f.int()
+		case 3:
+			ed.RDFIIdentification = f.string()
+		case 4:
+			ed.CheckDigit = f.string()
+		case 5:
+			ed.DFIAccountNumber = f.string()
+		case 6:
+			ed.Amount = f.int()
+		case 7:
+			ed.AdviceRoutingNumber = f.string()
+		case 8:
+			ed.FileIdentification = f.string()
+		case 9:
+			ed.ACHOperatorData = f.string()
+		case 10:
+			ed.IndividualName = f.string()
+		case 11:
+			ed.DiscretionaryData = f.string()
+		case 12:
+			ed.AddendaRecordIndicator = f.int()
+		case 13:
+			ed.ACHOperatorRoutingNumber = f.string()
+		case 14:
+			ed.JulianDay = f.int()
+		case 15:
+			ed.SequenceNumber = f.int()
+		case 16:
+			ed.Addenda99 = NewAddenda99()
+			return ed.Addenda99.unmarshalProto(f.bytes)
+		case 17:
+			ed.Category = f.string()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda02) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.ReferenceInformationOne)
+	w.string(4, addenda.ReferenceInformationTwo)
+	w.string(5, addenda.TerminalIdentificationCode)
+	w.string(6, addenda.TransactionSerialNumber)
+	w.string(7, addenda.TransactionDate)
+	w.string(8, addenda.AuthorizationCodeOrExpireDate)
+	w.string(9, addenda.TerminalLocation)
+	w.string(10, addenda.TerminalCity)
+	w.string(11, addenda.TerminalState)
+	w.string(12, addenda.TraceNumber)
+}
+
+func (addenda *Addenda02) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda02
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.ReferenceInformationOne = f.string()
+		case 4:
+			addenda.ReferenceInformationTwo = f.string()
+		case 5:
+			addenda.TerminalIdentificationCode = f.string()
+		case 6:
+			addenda.TransactionSerialNumber = f.string()
+		case 7:
+			addenda.TransactionDate = f.string()
+		case 8:
+			addenda.AuthorizationCodeOrExpireDate = f.string()
+		case 9:
+			addenda.TerminalLocation = f.string()
+		case 10:
+			addenda.TerminalCity = f.string()
+		case 11:
+			addenda.TerminalState = f.string()
+		case 12:
+			addenda.TraceNumber = f.string()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda05) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.PaymentRelatedInformation)
+	w.int(4, addenda.SequenceNumber)
+	w.int(5, addenda.EntryDetailSequenceNumber)
+}
+
+func (addenda *Addenda05) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda05
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.PaymentRelatedInformation = f.string()
+		case 4:
+			addenda.SequenceNumber = f.int()
+		case 5:
+			addenda.EntryDetailSequenceNumber = f.int()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda98) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.ChangeCode)
+	w.string(4, addenda.OriginalTrace)
+	w.string(5, addenda.OriginalDFI)
+	w.string(6, addenda.CorrectedData)
+	w.string(7, addenda.TraceNumber)
+}
+
+func (addenda *Addenda98) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda98
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.ChangeCode = f.string()
+		case 4:
+			addenda.OriginalTrace = f.string()
+		case 5:
+			addenda.OriginalDFI = f.string()
+		case 6:
+			addenda.CorrectedData = f.string()
+		case 7:
+			addenda.TraceNumber = f.string()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda99) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.ReturnCode)
+	w.string(4, addenda.OriginalTrace)
+	w.string(5, addenda.DateOfDeath)
+	w.string(6, addenda.OriginalDFI)
+	w.string(7, addenda.AddendaInformation)
+	w.string(8, addenda.TraceNumber)
+}
+
+func (addenda *Addenda99) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda99
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.ReturnCode = f.string()
+		case 4:
+			addenda.OriginalTrace = f.string()
+		case 5:
+			addenda.DateOfDeath = f.string()
+		case 6:
+			addenda.OriginalDFI = f.string()
+		case 7:
+			addenda.AddendaInformation = f.string()
+		case 8:
+			addenda.TraceNumber = f.string()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda99Dishonored) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.DishonoredReturnReasonCode)
+	w.string(4, addenda.OriginalEntryTraceNumber)
+	w.string(5, addenda.OriginalReceivingDFIIdentification)
+	w.string(6, addenda.ReturnTraceNumber)
+	w.string(7, addenda.ReturnSettlementDate)
+	w.string(8, addenda.ReturnReasonCode)
+	w.string(9, addenda.AddendaInformation)
+	w.string(10, addenda.TraceNumber)
+}
+
+func (addenda *Addenda99Dishonored) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda99Dishonored
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.DishonoredReturnReasonCode = f.string()
+		case 4:
+			addenda.OriginalEntryTraceNumber = f.string()
+		case 5:
+			addenda.OriginalReceivingDFIIdentification = f.string()
+		case 6:
+			addenda.ReturnTraceNumber = f.string()
+		case 7:
+			addenda.ReturnSettlementDate = f.string()
+		case 8:
+			addenda.ReturnReasonCode = f.string()
+		case 9:
+			addenda.AddendaInformation = f.string()
+		case 10:
+			addenda.TraceNumber = f.string()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda99Contested) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.ContestedReturnCode)
+	w.string(4, addenda.OriginalEntryTraceNumber)
+	w.string(5, addenda.DateOriginalEntryReturned)
+	w.string(6, addenda.OriginalReceivingDFIIdentification)
+	w.string(7, addenda.OriginalSettlementDate)
+	w.string(8, addenda.ReturnTraceNumber)
+	w.string(9, addenda.ReturnSettlementDate)
+	w.string(10, addenda.ReturnReasonCode)
+	w.string(11, addenda.DishonoredReturnTraceNumber)
+	w.string(12, addenda.DishonoredReturnSettlementDate)
+	w.string(13, addenda.DishonoredReturnReasonCode)
+	w.string(14, addenda.TraceNumber)
+}
+
+func (addenda *Addenda99Contested) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda99Contested
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.ContestedReturnCode = f.string()
+		case 4:
+			addenda.OriginalEntryTraceNumber = f.string()
+		case 5:
+			addenda.DateOriginalEntryReturned = f.string()
+		case 6:
+			addenda.OriginalReceivingDFIIdentification = f.string()
+		case 7:
+			addenda.OriginalSettlementDate = f.string()
+		case 8:
+			addenda.ReturnTraceNumber = f.string()
+		case 9:
+			addenda.ReturnSettlementDate = f.string()
+		case 10:
+			addenda.ReturnReasonCode = f.string()
+		case 11:
+			addenda.DishonoredReturnTraceNumber = f.string()
+		case 12:
+			addenda.DishonoredReturnSettlementDate = f.string()
+		case 13:
+			addenda.DishonoredReturnReasonCode = f.string()
+		case 14:
+			addenda.TraceNumber = f.string()
+		}
+		return nil
+	})
+}
+
+func (iatBh *IATBatchHeader) marshalProto(w *protoWriter) {
+	w.string(1, iatBh.ID)
+	w.int(2, iatBh.ServiceClassCode)
+	w.string(3, iatBh.IATIndicator)
+	w.string(4, iatBh.ForeignExchangeIndicator)
+	w.int(5, iatBh.ForeignExchangeReferenceIndicator)
+	w.string(6, iatBh.ForeignExchangeReference)
+	w.string(7, iatBh.ISODestinationCountryCode)
+	w.string(8, iatBh.OriginatorIdentification)
+	w.string(9, iatBh.StandardEntryClassCode)
+	w.string(10, iatBh.CompanyEntryDescription)
+	w.string(11, iatBh.ISOOriginatingCurrencyCode)
+	w.string(12, iatBh.ISODestinationCurrencyCode)
+	w.string(13, iatBh.EffectiveEntryDate)
+	w.int(14, iatBh.OriginatorStatusCode)
+	w.string(15, iatBh.ODFIIdentification)
+	w.int(16, iatBh.BatchNumber)
+}
+
+func (iatBh *IATBatchHeader) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewIATBatchHeader
+	iatBh.OriginatorStatusCode, iatBh.BatchNumber = 0, 0
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			iatBh.ID = f.string()
+		case 2:
+			iatBh.ServiceClassCode = f.int()
+		case 3:
+			iatBh.IATIndicator = f.string()
+		case 4:
+			iatBh.ForeignExchangeIndicator = f.string()
+		case 5:
+			iatBh.ForeignExchangeReferenceIndicator = f.int()
+		case 6:
+			iatBh.ForeignExchangeReference = f.string()
+		case 7:
+			iatBh.ISODestinationCountryCode = f.string()
+		case 8:
+			iatBh.OriginatorIdentification = f.string()
+		case 9:
+			iatBh.StandardEntryClassCode = f.string()
+		case 10:
+			iatBh.CompanyEntryDescription = f.string()
+		case 11:
+			iatBh.ISOOriginatingCurrencyCode = f.string()
+		case 12:
+			iatBh.ISODestinationCurrencyCode = f.string()
+		case 13:
+			iatBh.EffectiveEntryDate = f.string()
+		case 14:
+			iatBh.OriginatorStatusCode = f.int()
+		case 15:
+			iatBh.ODFIIdentification = f.string()
+		case 16:
+			iatBh.BatchNumber = f.int()
+		}
+		return nil
+	})
+}
+
+func (ed *IATEntryDetail) marshalProto(w *protoWriter) {
+	w.string(1, ed.ID)
+	w.int(2, ed.TransactionCode)
+	w.string(3, ed.RDFIIdentification)
+	w.string(4, ed.CheckDigit)
+	w.int(5, ed.AddendaRecords)
+	w.int(6, ed.Amount)
+	w.string(7, ed.DFIAccountNumber)
+	w.string(8, ed.OFACScreeningIndicator)
+	w.string(9, ed.SecondaryOFACScreeningIndicator)
+	w.int(10, ed.AddendaRecordIndicator)
+	w.string(11, ed.TraceNumber)
+	if ed.Addenda10 != nil {
+		w.message(12, ed.Addenda10.marshalProto)
+	}
+	if ed.Addenda11 != nil {
+		w.message(13, ed.Addenda11.marshalProto)
+	}
+	if ed.Addenda12 != nil {
+		w.message(14, ed.Addenda12.marshalProto)
+	}
+	if ed.Addenda13 != nil {
+		w.message(15, ed.Addenda13.marshalProto)
+	}
+	if ed.Addenda14 != nil {
+		w.message(16, ed.Addenda14.marshalProto)
+	}
+	if ed.Addenda15 != nil {
+		w.message(17, ed.Addenda15.marshalProto)
+	}
+	if ed.Addenda16 != nil {
+		w.message(18, ed.Addenda16.marshalProto)
+	}
+	for _, a := range ed.Addenda17 {
+		w.message(19, a.marshalProto)
+	}
+	for _, a := range ed.Addenda18 {
+		w.message(20, a.marshalProto)
+	}
+	if ed.Addenda98 != nil {
+		w.message(21, ed.Addenda98.marshalProto)
+	}
+	if ed.Addenda99 != nil {
+		w.message(22, ed.Addenda99.marshalProto)
+	}
+	w.string(23, ed.Category)
+}
+
+func (ed *IATEntryDetail) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewIATEntryDetail
+	ed.AddendaRecordIndicator, ed.Category = 0, ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			ed.ID = f.string()
+		case 2:
+			ed.TransactionCode = f.int()
+		case 3:
+			ed.RDFIIdentification = f.string()
+		case 4:
+			ed.CheckDigit = f.string()
+		case 5:
+			ed.AddendaRecords = f.int()
+		case 6:
+			ed.Amount = f.int()
+		case 7:
+			ed.DFIAccountNumber = f.string()
+		case 8:
+			ed.OFACScreeningIndicator = f.string()
+		case 9:
+			ed.SecondaryOFACScreeningIndicator = f.string()
+		case 10:
+			ed.AddendaRecordIndicator = f.int()
+		case 11:
+			ed.TraceNumber = f.string()
+		case 12:
+			ed.Addenda10 = NewAddenda10()
+			return ed.Addenda10.unmarshalProto(f.bytes)
+		case 13:
+			ed.Addenda11 = NewAddenda11()
+			return ed.Addenda11.unmarshalProto(f.bytes)
+		case 14:
+			ed.Addenda12 = NewAddenda12()
+			return ed.Addenda12.unmarshalProto(f.bytes)
+		case 15:
+			ed.Addenda13 = NewAddenda13()
+			return ed.Addenda13.unmarshalProto(f.bytes)
+		case 16:
+			ed.Addenda14 = NewAddenda14()
+			return ed.Addenda14.unmarshalProto(f.bytes)
+		case 17:
+			ed.Addenda15 = NewAddenda15()
+			return ed.Addenda15.unmarshalProto(f.bytes)
+		case 18:
+			ed.Addenda16 = NewAddenda16()
+			return ed.Addenda16.unmarshalProto(f.bytes)
+		case 19:
+			a := NewAddenda17()
+			if err := a.unmarshalProto(f.bytes); err != nil {
+				return err
+			}
+			ed.Addenda17 = append(ed.Addenda17, a)
+		case 20:
+			a := NewAddenda18()
+			if err := a.unmarshalProto(f.bytes); err != nil {
+				return err
+			}
+			ed.Addenda18 = append(ed.Addenda18, a)
+		case 21:
+			ed.Addenda98 = NewAddenda98()
+			return ed.Addenda98.unmarshalProto(f.bytes)
+		case 22:
+			ed.Addenda99 = NewAddenda99()
+			return ed.Addenda99.unmarshalProto(f.bytes)
+		case 23:
+			ed.Category = f.string()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda10) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.TransactionTypeCode)
+	w.int(4, addenda.ForeignPaymentAmount)
+	w.string(5, addenda.ForeignTraceNumber)
+	w.string(6, addenda.Name)
+	w.int(7, addenda.EntryDetailSequenceNumber)
+}
+
+func (addenda *Addenda10) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda10
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.TransactionTypeCode = f.string()
+		case 4:
+			addenda.ForeignPaymentAmount = f.int()
+		case 5:
+			addenda.ForeignTraceNumber = f.string()
+		case 6:
+			addenda.Name = f.string()
+		case 7:
+			addenda.EntryDetailSequenceNumber = f.int()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda11) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.OriginatorName)
+	w.string(4, addenda.OriginatorStreetAddress)
+	w.int(5, addenda.EntryDetailSequenceNumber)
+}
+
+func (addenda *Addenda11) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda11
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.OriginatorName = f.string()
+		case 4:
+			addenda.OriginatorStreetAddress = f.string()
+		case 5:
+			addenda.EntryDetailSequenceNumber = f.int()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda12) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.OriginatorCityStateProvince)
+	w.string(4, addenda.OriginatorCountryPostalCode)
+	w.int(5, addenda.EntryDetailSequenceNumber)
+}
+
+func (addenda *Addenda12) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda12
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.OriginatorCityStateProvince = f.string()
+		case 4:
+			addenda.OriginatorCountryPostalCode = f.string()
+		case 5:
+			addenda.EntryDetailSequenceNumber = f.int()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda13) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.ODFIName)
+	w.string(4, addenda.ODFIIDNumberQualifier)
+	w.string(5, addenda.ODFIIdentification)
+	w.string(6, addenda.ODFIBranchCountryCode)
+	w.int(7, addenda.EntryDetailSequenceNumber)
+}
+
+func (addenda *Addenda13) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda13
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.ODFIName = f.string()
+		case 4:
+			addenda.ODFIIDNumberQualifier = f.string()
+		case 5:
+			addenda.ODFIIdentification = f.string()
+		case 6:
+			addenda.ODFIBranchCountryCode = f.string()
+		case 7:
+			addenda.EntryDetailSequenceNumber = f.int()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda14) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.RDFIName)
+	w.string(4, addenda.RDFIIDNumberQualifier)
+	w.string(5, addenda.RDFIIdentification)
+	w.string(6, addenda.RDFIBranchCountryCode)
+	w.int(7, addenda.EntryDetailSequenceNumber)
+}
+
+func (addenda *Addenda14) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda14
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.RDFIName = f.string()
+		case 4:
+			addenda.RDFIIDNumberQualifier = f.string()
+		case 5:
+			addenda.RDFIIdentification = f.string()
+		case 6:
+			addenda.RDFIBranchCountryCode = f.string()
+		case 7:
+			addenda.EntryDetailSequenceNumber = f.int()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda15) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.ReceiverIDNumber)
+	w.string(4, addenda.ReceiverStreetAddress)
+	w.int(5, addenda.EntryDetailSequenceNumber)
+}
+
+func (addenda *Addenda15) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda15
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.ReceiverIDNumber = f.string()
+		case 4:
+			addenda.ReceiverStreetAddress = f.string()
+		case 5:
+			addenda.EntryDetailSequenceNumber = f.int()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda16) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.ReceiverCityStateProvince)
+	w.string(4, addenda.ReceiverCountryPostalCode)
+	w.int(5, addenda.EntryDetailSequenceNumber)
+}
+
+func (addenda *Addenda16) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda16
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.ReceiverCityStateProvince = f.string()
+		case 4:
+			addenda.ReceiverCountryPostalCode = f.string()
+		case 5:
+			addenda.EntryDetailSequenceNumber = f.int()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda17) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.PaymentRelatedInformation)
+	w.int(4, addenda.SequenceNumber)
+	w.int(5, addenda.EntryDetailSequenceNumber)
+}
+
+func (addenda *Addenda17) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda17
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.PaymentRelatedInformation = f.string()
+		case 4:
+			addenda.SequenceNumber = f.int()
+		case 5:
+			addenda.EntryDetailSequenceNumber = f.int()
+		}
+		return nil
+	})
+}
+
+func (addenda *Addenda18) marshalProto(w *protoWriter) {
+	w.string(1, addenda.ID)
+	w.string(2, addenda.TypeCode)
+	w.string(3, addenda.ForeignCorrespondentBankName)
+	w.string(4, addenda.ForeignCorrespondentBankIDNumberQualifier)
+	w.string(5, addenda.ForeignCorrespondentBankIDNumber)
+	w.string(6, addenda.ForeignCorrespondentBankBranchCountryCode)
+	w.int(7, addenda.SequenceNumber)
+	w.int(8, addenda.EntryDetailSequenceNumber)
+}
+
+func (addenda *Addenda18) unmarshalProto(bs []byte) error {
+	// fields left out of the message are zero values rather than the defaults of NewAddenda18
+	addenda.TypeCode = ""
+
+	return readProto(bs, func(f protoField) error {
+		switch f.num {
+		case 1:
+			addenda.ID = f.string()
+		case 2:
+			addenda.TypeCode = f.string()
+		case 3:
+			addenda.ForeignCorrespondentBankName = f.string()
+		case 4:
+			addenda.ForeignCorrespondentBankIDNumberQualifier = f.string()
+		case 5:
+			addenda.ForeignCorrespondentBankIDNumber = f.string()
+		case 6:
+			addenda.ForeignCorrespondentBankBranchCountryCode = f.string()
+		case 7:
+			addenda.SequenceNumber = f.int()
+		case 8:
+			addenda.EntryDetailSequenceNumber = f.int()
+		}
+		return nil
+	})
+}
@@ -0,0 +1,218 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/ach/internal/achpb"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func TestFile__Proto(t *testing.T) {
+	paths := []string{
+		"ppd-debit.ach",
+		"return-WEB.ach",
+		"cor-example.ach",
+		"iat-debit.ach",
+		"20180716-IAT-A17-A18.ach",
+		"ppd-valid.json",
+		"adv-valid.json",
+	}
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			file := readProtoTestFile(t, filepath.Join("test", "testdata", path))
+			file.Comments = []string{"first", ""}
+
+			bs, err := file.MarshalProto()
+			if err != nil {
+				t.Fatal(err)
+			}
+			read, err := FileFromProto(bs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := read.Validate(); err != nil {
+				t.Error(err)
+			}
+
+			expected, _ := json.Marshal(file)
+			actual, _ := json.Marshal(read)
+			if !bytes.Equal(expected, actual) {
+				t.Errorf("files differ:\n%s\n%s", expected, actual)
+			}
+			for i := range file.Batches {
+				if file.Batches[i].ID() != read.Batches[i].ID() {
+					t.Errorf("batch #%d: ID %q != %q", i, file.Batches[i].ID(), read.Batches[i].ID())
+				}
+			}
+		})
+	}
+}
+
+// TestFile__ProtoConformance reads MarshalProto output with the protoc-gen-go types of ach.proto
+// and reads their encoding back with FileFromProto.
+func TestFile__ProtoConformance(t *testing.T) {
+	paths := []string{
+		"ppd-debit.ach",
+		"return-WEB.ach",
+		"20180716-IAT-A17-A18.ach",
+		"adv-valid.json",
+	}
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			file := readProtoTestFile(t, filepath.Join("test", "testdata", path))
+			file.Comments = []string{"first"}
+
+			bs, err := file.MarshalProto()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var pb achpb.File
+			if err := proto.Unmarshal(bs, &pb); err != nil {
+				t.Fatal(err)
+			}
+			if len(pb.XXX_unrecognized) > 0 {
+				t.Errorf("unrecognized fields: %x", pb.XXX_unrecognized)
+			}
+			if pb.Header.ImmediateOrigin != file.Header.ImmediateOrigin || pb.Header.FileCreationDate != file.Header.FileCreationDate {
+				t.Errorf("unexpected FileHeader: %v", pb.Header)
+			}
+			if int(pb.Control.EntryHash) != file.Control.EntryHash || pb.Comments[0] != "first" {
+				t.Errorf("unexpected File: %v", &pb)
+			}
+			if len(pb.Batches) != len(file.Batches) || len(pb.IatBatches) != len(file.IATBatches) {
+				t.Fatalf("got %d batches and %d IAT batches", len(pb.Batches), len(pb.IatBatches))
+			}
+			for i, batch := range file.Batches {
+				header := pb.Batches[i].Header
+				if header.StandardEntryClassCode != batch.GetHeader().StandardEntryClassCode || int(header.ServiceClassCode) != batch.GetHeader().ServiceClassCode {
+					t.Errorf("batch #%d: unexpected BatchHeader: %v", i, header)
+				}
+				entries := pb.Batches[i].Entries
+				for j, ed := range batch.GetEntries() {
+					if entries[j].TraceNumber != ed.TraceNumber || int(entries[j].Amount) != ed.Amount {
+						t.Errorf("batch #%d entry #%d: unexpected EntryDetail: %v", i, j, entries[j])
+					}
+					if ed.Addenda99 != nil && entries[j].Addenda99.ReturnCode != ed.Addenda99.ReturnCode {
+						t.Errorf("batch #%d entry #%d: unexpected Addenda99: %v", i, j, entries[j].Addenda99)
+					}
+				}
+				if len(pb.Batches[i].AdvEntries) != len(batch.GetADVEntries()) {
+					t.Errorf("batch #%d: got %d ADV entries", i, len(pb.Batches[i].AdvEntries))
+				}
+			}
+			for i, iatBatch := range file.IATBatches {
+				for j, ed := range iatBatch.Entries {
+					entry := pb.IatBatches[i].Entries[j]
+					if entry.TraceNumber != ed.TraceNumber || len(entry.Addenda17) != len(ed.Addenda17) || len(entry.Addenda18) != len(ed.Addenda18) {
+						t.Errorf("IAT batch #%d entry #%d: unexpected IATEntryDetail: %v", i, j, entry)
+					}
+				}
+			}
+
+			bs, err = proto.Marshal(&pb)
+			if err != nil {
+				t.Fatal(err)
+			}
+			read, err := FileFromProto(bs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected, _ := json.Marshal(file)
+			actual, _ := json.Marshal(read)
+			if !bytes.Equal(expected, actual) {
+				t.Errorf("files differ:\n%s\n%s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestFile__ProtoWire(t *testing.T) {
+	var w protoWriter
+	fh := FileHeader{ID: "a", FileIDModifier: "B"}
+	fh.marshalProto(&w)
+	if expected := []byte{0x0a, 0x01, 'a', 0x32, 0x01, 'B'}; !bytes.Equal(w.buf, expected) {
+		t.Errorf("got %x expected %x", w.buf, expected)
+	}
+
+	// negative values, unknown fields of every wire type and fields with the wrong wire type
+	w = protoWriter{}
+	w.int(2, -5)
+	w.int(99, 7)
+	w.buf = append(w.buf, 0x99, 0x06, 1, 2, 3, 4, 5, 6, 7, 8) // field 99, fixed64
+	w.buf = append(w.buf, 0x9d, 0x06, 1, 2, 3, 4)             // field 99, fixed32
+	w.int(1, 3)
+	var fc FileControl
+	if err := fc.unmarshalProto(w.buf); err != nil {
+		t.Fatal(err)
+	}
+	if fc.BatchCount != -5 || fc.ID != "" {
+		t.Errorf("unexpected FileControl: %#v", fc)
+	}
+
+	// zero values are kept rather than replaced by constructor defaults
+	bh := NewBatchHeader()
+	bh.OriginatorStatusCode = 0
+	w = protoWriter{}
+	bh.marshalProto(&w)
+	read := NewBatchHeader()
+	if err := read.unmarshalProto(w.buf); err != nil {
+		t.Fatal(err)
+	}
+	if read.OriginatorStatusCode != 0 || read.BatchNumber != 1 {
+		t.Errorf("unexpected BatchHeader: %#v", read)
+	}
+
+	if _, err := FileFromProto(nil); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := FileFromProto([]byte{0x12, 0x05, 0x0a}); err == nil || !strings.Contains(err.Error(), "truncated") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := FileFromProto([]byte{0x0b}); err == nil || !strings.Contains(err.Error(), "wire type") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func readProtoTestFile(t *testing.T, path string) *File {
+	t.Helper()
+
+	if strings.HasSuffix(path, ".json") {
+		bs, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file, err := FileFromJSON(bs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+	file, err := readACHFilepath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
@@ -0,0 +1,43 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+// rawLine holds the line a record was read from when the Reader preserves raw lines.
+type rawLine struct {
+	raw string
+}
+
+// Raw returns the line the record was read from, as it was received and before any changes
+// made by a lenient ReaderMode. It's empty unless the record was read by a Reader with
+// PreserveRawLines enabled.
+func (r *rawLine) Raw() string {
+	return r.raw
+}
+
+// PreserveRawLines keeps the line each record is read from, which is returned by the record's Raw()
+// method, so error reports and audit trails can show exactly what was received.
+func (r *Reader) PreserveRawLines() {
+	r.preserveRaw = true
+}
+
+// setRaw sets the line of record to the one currently being parsed
+func (r *Reader) setRaw(record *rawLine) {
+	if r.preserveRaw {
+		record.raw = r.raw
+	}
+}
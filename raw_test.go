@@ -0,0 +1,66 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReader__PreserveRawLines(t *testing.T) {
+	lines := readTestdataLines(t, "ppd-debit.ach")
+	lines[1] = lines[1][:50] + "ppd" + lines[1][53:]
+
+	r := NewReader(strings.NewReader(strings.Join(lines, "\n")))
+	r.SetMode(ReaderLenient)
+	r.PreserveRawLines()
+	f, err := r.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch := f.Batches[0]
+	if raw := batch.GetHeader().Raw(); raw != lines[1] {
+		t.Errorf("BatchHeader.Raw()=%q", raw)
+	}
+	if sec := batch.GetHeader().StandardEntryClassCode; sec != PPD {
+		t.Errorf("StandardEntryClassCode=%s", sec)
+	}
+	if f.Header.Raw() != lines[0] || batch.GetEntries()[0].Raw() != lines[2] || batch.GetControl().Raw() != lines[3] || f.Control.Raw() != lines[4] {
+		t.Errorf("unexpected raw lines in %#v", f)
+	}
+
+	// lines aren't kept by default
+	r = NewReader(strings.NewReader(strings.Join(lines, "\n")))
+	r.SetMode(ReaderLenient)
+	if f, err = r.Read(); err != nil || f.Header.Raw() != "" {
+		t.Errorf("FileHeader.Raw()=%q", f.Header.Raw())
+	}
+}
+
+func TestReader__PreserveRawLinesIAT(t *testing.T) {
+	r := NewReader(strings.NewReader(strings.Join(readTestdataLines(t, "iat-debit.ach"), "\n")))
+	r.PreserveRawLines()
+	f, err := r.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := f.IATBatches[0].Entries[0]
+	if entry.Raw() == "" || entry.Raw() != entry.String() || entry.Addenda10.Raw() != entry.Addenda10.String() {
+		t.Errorf("unexpected raw lines: %q %q", entry.Raw(), entry.Addenda10.Raw())
+	}
+}
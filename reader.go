@@ -19,6 +19,7 @@ package ach
 
 import (
 	"bufio"
+	"bytes"
 	"io"
 	"strings"
 
@@ -59,6 +60,20 @@ type Reader struct {
 
 	// errors holds each error encountered when attempting to parse the file
 	errors base.ErrorList
+
+	// diagnostics holds non-fatal issues found while parsing the file
+	diagnostics []Diagnostic
+
+	// allowTrailerRecords keeps records after the FileControl in File.TrailerRecords
+	allowTrailerRecords    bool
+	trailerRecordValidator TrailerRecordValidator
+
+	// mode is how records which don't follow the NACHA format are handled
+	mode ReaderMode
+
+	// preserveRaw keeps raw, the line of the record being parsed as received, on each record
+	preserveRaw bool
+	raw         string
 }
 
 // error returns a new ParseError based on err
@@ -88,11 +103,38 @@ func (r *Reader) addIATCurrentBatch(iatBatch IATBatch) {
 	r.IATCurrentBatch = iatBatch
 }
 
-// NewReader returns a new ACH Reader that reads from r.
+// NewReader returns a new ACH Reader that reads from r. Records can end with "\n", "\r\n" or "\r"
+// and the last block doesn't need to be padded with lines of 9's.
 func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(scanRecords)
 	return &Reader{
-		scanner: bufio.NewScanner(r),
+		scanner: scanner,
+	}
+}
+
+// scanRecords is a bufio.SplitFunc like bufio.ScanLines which also splits lines ending with a
+// lone "\r", as written by some older systems.
+func scanRecords(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		switch {
+		case data[i] == '\n':
+			return i + 1, data[:i], nil
+		case i+1 < len(data) && data[i+1] == '\n':
+			return i + 2, data[:i], nil
+		case i+1 < len(data) || atEOF:
+			return i + 1, data[:i], nil
+		}
+		// read more to find if "\r" is followed by "\n"
+		return 0, nil, nil
+	}
+	if atEOF {
+		return len(data), data, nil
 	}
+	return 0, nil, nil
 }
 
 // Read reads each line of the ACH file and defines which parser to use based on the first character
@@ -105,12 +147,19 @@ func (r *Reader) Read() (File, error) {
 	// read through the entire file
 	for r.scanner.Scan() {
 		line := r.scanner.Text()
+		r.raw = line
 		r.lineNum++
 		if r.lineNum > maxLines {
 			r.errors.Add(ErrFileTooLong)
 			break
 		}
 
+		if r.mode != ReaderStrict {
+			var ok bool
+			if line, ok = r.normalizeLine(line); !ok {
+				continue
+			}
+		}
 		lineLength := len(line)
 
 		switch {
@@ -119,14 +168,17 @@ func (r *Reader) Read() (File, error) {
 				r.errors.Add(err)
 			}
 		case lineLength != RecordLength:
-			r.errors.Add(r.parseError(NewRecordWrongLengthErr(lineLength)))
+			r.addError(r.parseError(NewRecordWrongLengthErr(lineLength)))
 		default:
 			r.line = line
 			if err := r.parseLine(); err != nil {
-				r.errors.Add(err)
+				r.addError(err)
 			}
 		}
 	}
+	if r.mode != ReaderStrict {
+		r.closeFile()
+	}
 	if (FileHeader{}) == r.File.Header {
 		// There must be at least one File Header
 		r.recordName = "FileHeader"
@@ -153,22 +205,24 @@ func (r *Reader) Read() (File, error) {
 }
 
 func (r *Reader) processFixedWidthFile(line *string) error {
-	// it should be safe to parse this byte by byte since ACH files are ascii only
-	record := ""
-	for i, c := range *line {
-		record = record + string(c)
-		if i > 0 && (i+1)%RecordLength == 0 {
-			r.line = record
-			if err := r.parseLine(); err != nil {
+	// it should be safe to slice this byte by byte since ACH files are ascii only
+	for start := 0; start+RecordLength <= len(*line); start += RecordLength {
+		r.line = (*line)[start : start+RecordLength]
+		r.raw = r.line
+		if err := r.parseLine(); err != nil {
+			if r.mode != ReaderRecover {
 				return err
 			}
-			record = ""
+			r.skipRecord(err)
 		}
 	}
 	return nil
 }
 
 func (r *Reader) parseLine() error {
+	if r.isTrailerRecord() {
+		return r.parseTrailerRecord()
+	}
 	switch r.line[:1] {
 	case fileHeaderPos:
 		if err := r.parseFileHeader(); err != nil {
@@ -188,11 +242,18 @@ func (r *Reader) parseLine() error {
 		}
 	case batchControlPos:
 		if err := r.parseBatchControl(); err != nil {
+			if r.mode == ReaderRecover {
+				// skip the batch, its entries can't be checked against the control
+				r.currentBatch, r.IATCurrentBatch = nil, IATBatch{}
+			}
 			return err
 		}
 		if r.currentBatch != nil {
 			if err := r.currentBatch.Validate(); err != nil {
 				r.recordName = "Batches"
+				if r.mode == ReaderRecover {
+					r.currentBatch = nil // skip the batch
+				}
 				return r.parseError(err)
 			}
 			r.File.AddBatch(r.currentBatch)
@@ -200,6 +261,9 @@ func (r *Reader) parseLine() error {
 		} else {
 			if err := r.IATCurrentBatch.Validate(); err != nil {
 				r.recordName = "Batches"
+				if r.mode == ReaderRecover {
+					r.IATCurrentBatch = IATBatch{} // skip the batch
+				}
 				return r.parseError(err)
 			}
 			r.File.AddIATBatch(r.IATCurrentBatch)
@@ -208,12 +272,14 @@ func (r *Reader) parseLine() error {
 	case fileControlPos:
 		if r.line[:2] == "99" {
 			// final blocking padding
+			r.checkPadding()
 			break
 		}
 		if err := r.parseFileControl(); err != nil {
 			return err
 		}
 	default:
+		r.recordName = ""
 		return NewErrUnknownRecordType(r.line[:1])
 	}
 	return nil
@@ -270,6 +336,7 @@ func (r *Reader) parseFileHeader() error {
 		return ErrFileHeader
 	}
 	r.File.Header.Parse(r.line)
+	r.setRaw(&r.File.Header.rawLine)
 
 	if err := r.File.Header.Validate(); err != nil {
 		return r.parseError(err)
@@ -288,6 +355,7 @@ func (r *Reader) parseBatchHeader() error {
 	// Ensure we have a valid batch header before building a batch.
 	bh := NewBatchHeader()
 	bh.Parse(r.line)
+	r.setRaw(&bh.rawLine)
 	if err := bh.Validate(); err != nil {
 		return r.parseError(err)
 	}
@@ -312,6 +380,7 @@ func (r *Reader) parseEntryDetail() error {
 	if r.currentBatch.GetHeader().StandardEntryClassCode != ADV {
 		ed := new(EntryDetail)
 		ed.Parse(r.line)
+		r.setRaw(&ed.rawLine)
 		if err := ed.Validate(); err != nil {
 			return r.parseError(err)
 		}
@@ -319,6 +388,7 @@ func (r *Reader) parseEntryDetail() error {
 	} else {
 		ed := new(ADVEntryDetail)
 		ed.Parse(r.line)
+		r.setRaw(&ed.rawLine)
 		if err := ed.Validate(); err != nil {
 			return r.parseError(err)
 		}
@@ -346,6 +416,7 @@ func (r *Reader) parseAddenda() error {
 			case "02":
 				addenda02 := NewAddenda02()
 				addenda02.Parse(r.line)
+				r.setRaw(&addenda02.rawLine)
 				if err := addenda02.Validate(); err != nil {
 					return r.parseError(err)
 				}
@@ -353,6 +424,7 @@ func (r *Reader) parseAddenda() error {
 			case "05":
 				addenda05 := NewAddenda05()
 				addenda05.Parse(r.line)
+				r.setRaw(&addenda05.rawLine)
 				if err := addenda05.Validate(); err != nil {
 					return r.parseError(err)
 				}
@@ -360,19 +432,46 @@ func (r *Reader) parseAddenda() error {
 			case "98":
 				addenda98 := NewAddenda98()
 				addenda98.Parse(r.line)
+				r.setRaw(&addenda98.rawLine)
 				if err := addenda98.Validate(); err != nil {
 					return r.parseError(err)
 				}
 				r.currentBatch.GetEntries()[entryIndex].Category = CategoryNOC
 				r.currentBatch.GetEntries()[entryIndex].Addenda98 = addenda98
+				r.checkAddendaReserved()
 			case "99":
-				addenda99 := NewAddenda99()
-				addenda99.Parse(r.line)
-				if err := addenda99.Validate(); err != nil {
-					return r.parseError(err)
+				// dishonored and contested dishonored returns share the type code with returns
+				switch code := r.line[3:6]; {
+				case IsDishonoredReturnCode(code):
+					addenda99 := NewAddenda99Dishonored()
+					addenda99.Parse(r.line)
+					r.setRaw(&addenda99.rawLine)
+					if err := addenda99.Validate(); err != nil {
+						return r.parseError(err)
+					}
+					r.currentBatch.GetEntries()[entryIndex].Category = CategoryDishonoredReturn
+					r.currentBatch.GetEntries()[entryIndex].Addenda99Dishonored = addenda99
+				case IsContestedReturnCode(code):
+					addenda99 := NewAddenda99Contested()
+					addenda99.Parse(r.line)
+					r.setRaw(&addenda99.rawLine)
+					if err := addenda99.Validate(); err != nil {
+						return r.parseError(err)
+					}
+					r.currentBatch.GetEntries()[entryIndex].Category = CategoryDishonoredReturnContested
+					r.currentBatch.GetEntries()[entryIndex].Addenda99Contested = addenda99
+				default:
+					addenda99 := NewAddenda99()
+					addenda99.Parse(r.line)
+					r.setRaw(&addenda99.rawLine)
+					if err := addenda99.Validate(); err != nil {
+						return r.parseError(err)
+					}
+					r.currentBatch.GetEntries()[entryIndex].Category = CategoryReturn
+					r.currentBatch.GetEntries()[entryIndex].Addenda99 = addenda99
 				}
-				r.currentBatch.GetEntries()[entryIndex].Category = CategoryReturn
-				r.currentBatch.GetEntries()[entryIndex].Addenda99 = addenda99
+			default:
+				r.addDiagnostic("Addenda", "TypeCode", "unknown addenda type %s was ignored", r.line[1:3])
 			}
 		} else {
 			return r.parseError(r.currentBatch.Error("AddendaRecordIndicator", ErrBatchAddendaIndicator))
@@ -398,6 +497,7 @@ func (r *Reader) parseADVAddenda() error {
 	}
 	addenda99 := NewAddenda99()
 	addenda99.Parse(r.line)
+	r.setRaw(&addenda99.rawLine)
 	if err := addenda99.Validate(); err != nil {
 		return r.parseError(err)
 	}
@@ -416,21 +516,25 @@ func (r *Reader) parseBatchControl() error {
 	if r.currentBatch != nil {
 		if r.currentBatch.GetHeader().StandardEntryClassCode == ADV {
 			r.currentBatch.GetADVControl().Parse(r.line)
+			r.setRaw(&r.currentBatch.GetADVControl().rawLine)
 			if err := r.currentBatch.GetADVControl().Validate(); err != nil {
 				return r.parseError(err)
 			}
 		} else {
 			r.currentBatch.GetControl().Parse(r.line)
+			r.setRaw(&r.currentBatch.GetControl().rawLine)
 			if err := r.currentBatch.GetControl().Validate(); err != nil {
 				return r.parseError(err)
 			}
+			r.checkReserved(r.recordName, 73, 79)
 		}
 	} else {
 		r.IATCurrentBatch.GetControl().Parse(r.line)
+		r.setRaw(&r.IATCurrentBatch.GetControl().rawLine)
 		if err := r.IATCurrentBatch.GetControl().Validate(); err != nil {
 			return r.parseError(err)
 		}
-
+		r.checkReserved(r.recordName, 73, 79)
 	}
 	return nil
 }
@@ -445,18 +549,22 @@ func (r *Reader) parseFileControl() error {
 			return ErrFileControl
 		}
 		r.File.Control.Parse(r.line)
+		r.setRaw(&r.File.Control.rawLine)
 		if err := r.File.Control.Validate(); err != nil {
 			return r.parseError(err)
 		}
+		r.checkReserved(r.recordName, 55, 94)
 	} else {
 		if (ADVFileControl{}) != r.File.ADVControl {
 			// Can be only one file control per file
 			return ErrFileControl
 		}
 		r.File.ADVControl.Parse(r.line)
+		r.setRaw(&r.File.ADVControl.rawLine)
 		if err := r.File.ADVControl.Validate(); err != nil {
 			return r.parseError(err)
 		}
+		r.checkReserved(r.recordName, 71, 94)
 	}
 	return nil
 }
@@ -474,6 +582,7 @@ func (r *Reader) parseIATBatchHeader() error {
 	// Ensure we have a valid IAT BatchHeader before building a batch.
 	bh := NewIATBatchHeader()
 	bh.Parse(r.line)
+	r.setRaw(&bh.rawLine)
 	if err := bh.Validate(); err != nil {
 		return r.parseError(err)
 	}
@@ -495,10 +604,12 @@ func (r *Reader) parseIATEntryDetail() error {
 
 	ed := new(IATEntryDetail)
 	ed.Parse(r.line)
+	r.setRaw(&ed.rawLine)
 	if err := ed.Validate(); err != nil {
 		return r.parseError(err)
 	}
 	r.IATCurrentBatch.AddEntry(ed)
+	r.checkReserved(r.recordName, 74, 76)
 	return nil
 }
 
@@ -517,6 +628,7 @@ func (r *Reader) parseIATAddenda() error {
 		if err != nil {
 			return r.parseError(err)
 		}
+		r.checkAddendaReserved()
 	} else {
 		return r.parseError(fieldError("AddendaRecordIndicator", ErrIATBatchAddendaIndicator))
 	}
@@ -543,6 +655,8 @@ func (r *Reader) switchIATAddenda(entryIndex int) error {
 		if err != nil {
 			return err
 		}
+	default:
+		r.addDiagnostic("Addenda", "TypeCode", "unknown addenda type %s was ignored", r.line[1:3])
 	}
 	return nil
 }
@@ -554,6 +668,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "10":
 		addenda10 := NewAddenda10()
 		addenda10.Parse(r.line)
+		r.setRaw(&addenda10.rawLine)
 		if err := addenda10.Validate(); err != nil {
 			return err
 		}
@@ -561,6 +676,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "11":
 		addenda11 := NewAddenda11()
 		addenda11.Parse(r.line)
+		r.setRaw(&addenda11.rawLine)
 		if err := addenda11.Validate(); err != nil {
 			return err
 		}
@@ -568,6 +684,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "12":
 		addenda12 := NewAddenda12()
 		addenda12.Parse(r.line)
+		r.setRaw(&addenda12.rawLine)
 		if err := addenda12.Validate(); err != nil {
 			return err
 		}
@@ -575,6 +692,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "13":
 		addenda13 := NewAddenda13()
 		addenda13.Parse(r.line)
+		r.setRaw(&addenda13.rawLine)
 		if err := addenda13.Validate(); err != nil {
 			return err
 		}
@@ -582,6 +700,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "14":
 		addenda14 := NewAddenda14()
 		addenda14.Parse(r.line)
+		r.setRaw(&addenda14.rawLine)
 		if err := addenda14.Validate(); err != nil {
 			return err
 		}
@@ -589,6 +708,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "15":
 		addenda15 := NewAddenda15()
 		addenda15.Parse(r.line)
+		r.setRaw(&addenda15.rawLine)
 		if err := addenda15.Validate(); err != nil {
 			return err
 		}
@@ -596,6 +716,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "16":
 		addenda16 := NewAddenda16()
 		addenda16.Parse(r.line)
+		r.setRaw(&addenda16.rawLine)
 		if err := addenda16.Validate(); err != nil {
 			return err
 		}
@@ -603,6 +724,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "17":
 		addenda17 := NewAddenda17()
 		addenda17.Parse(r.line)
+		r.setRaw(&addenda17.rawLine)
 		if err := addenda17.Validate(); err != nil {
 			return err
 		}
@@ -610,6 +732,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "18":
 		addenda18 := NewAddenda18()
 		addenda18.Parse(r.line)
+		r.setRaw(&addenda18.rawLine)
 		if err := addenda18.Validate(); err != nil {
 			return err
 		}
@@ -622,6 +745,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 func (r *Reader) nocIATAddenda(entryIndex int) error {
 	addenda98 := NewAddenda98()
 	addenda98.Parse(r.line)
+	r.setRaw(&addenda98.rawLine)
 	if err := addenda98.Validate(); err != nil {
 		return err
 	}
@@ -633,6 +757,7 @@ func (r *Reader) nocIATAddenda(entryIndex int) error {
 func (r *Reader) returnIATAddenda(entryIndex int) error {
 	addenda99 := NewAddenda99()
 	addenda99.Parse(r.line)
+	r.setRaw(&addenda99.rawLine)
 	if err := addenda99.Validate(); err != nil {
 		return err
 	}
@@ -0,0 +1,138 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/moov-io/base"
+)
+
+// ReaderMode is how a Reader handles records which don't follow the NACHA format
+type ReaderMode int
+
+const (
+	// ReaderStrict returns an error for each record which doesn't follow the NACHA format. It's the default.
+	ReaderStrict ReaderMode = iota
+
+	// ReaderLenient accepts benign quirks of files from legacy systems, each recorded as a Diagnostic:
+	// lines padded with spaces past 94 characters or shorter than 94 characters, blank lines, lowercase
+	// FileIDModifiers and SEC codes, and a missing last BatchControl or FileControl which are computed
+	// from the records read.
+	ReaderLenient
+
+	// ReaderRecover reads files as ReaderLenient does and skips records which can't be parsed, recording
+	// each as a Diagnostic, rather than returning an error. Batches which fail validation are skipped.
+	// A file without a FileHeader is still an error.
+	ReaderRecover
+)
+
+// SetMode sets how r handles records which don't follow the NACHA format, see ReaderMode.
+func (r *Reader) SetMode(mode ReaderMode) {
+	r.mode = mode
+}
+
+// normalizeLine fixes the benign quirks of line accepted by ReaderLenient. It returns false for
+// blank lines, which are skipped.
+func (r *Reader) normalizeLine(line string) (string, bool) {
+	if strings.TrimSpace(line) == "" {
+		r.addDiagnostic("", "", "blank line ignored")
+		return "", false
+	}
+	if n := len(line); n > RecordLength && strings.TrimSpace(line[RecordLength:]) == "" {
+		r.addDiagnostic("", "", "line is %d characters, ignored the trailing spaces", n)
+		line = line[:RecordLength]
+	} else if n < RecordLength {
+		r.addDiagnostic("", "", "line is %d characters, padded with spaces", n)
+		line += strings.Repeat(" ", RecordLength-n)
+	}
+	if len(line) != RecordLength {
+		return line, true
+	}
+	switch line[:1] {
+	case fileHeaderPos:
+		if modifier := line[33:34]; modifier != strings.ToUpper(modifier) {
+			r.addDiagnostic("FileHeader", "FileIDModifier", "lowercase %q read as uppercase", modifier)
+			line = line[:33] + strings.ToUpper(modifier) + line[34:]
+		}
+	case batchHeaderPos:
+		if sec := line[50:53]; sec != strings.ToUpper(sec) {
+			r.addDiagnostic("BatchHeader", "StandardEntryClassCode", "lowercase %q read as uppercase", sec)
+			line = line[:50] + strings.ToUpper(sec) + line[53:]
+		}
+	}
+	return line, true
+}
+
+// skipRecord records err, why the current record couldn't be parsed, as a Diagnostic for ReaderRecover
+func (r *Reader) skipRecord(err error) {
+	record := r.recordName
+	var parseErr *base.ParseError
+	if errors.As(err, &parseErr) {
+		record, err = parseErr.Record, parseErr.Err
+	}
+	r.addDiagnostic(record, "", "skipped: %v", err)
+}
+
+// addError keeps err for Read to return, or skips the record it's from with ReaderRecover
+func (r *Reader) addError(err error) {
+	if r.mode == ReaderRecover {
+		r.skipRecord(err)
+		return
+	}
+	r.errors.Add(err)
+}
+
+// closeFile computes the BatchControl of a batch still open at the end of the file, and the FileControl
+// when it's missing, as ReaderLenient accepts files without them
+func (r *Reader) closeFile() {
+	if r.currentBatch != nil {
+		r.recordName = "BatchControl"
+		r.addDiagnostic(r.recordName, "", "missing, computed from the batch's entries")
+		if err := r.currentBatch.Create(); err != nil {
+			r.addError(r.parseError(err))
+		} else {
+			r.File.AddBatch(r.currentBatch)
+		}
+		r.currentBatch = nil
+	}
+	if r.IATCurrentBatch.Header != nil {
+		r.recordName = "BatchControl"
+		r.addDiagnostic(r.recordName, "", "missing, computed from the batch's entries")
+		if err := r.IATCurrentBatch.Create(); err != nil {
+			r.addError(r.parseError(err))
+		} else {
+			r.File.AddIATBatch(r.IATCurrentBatch)
+		}
+		r.IATCurrentBatch = IATBatch{}
+	}
+
+	missing := (FileControl{}) == r.File.Control
+	if r.File.IsADV() {
+		missing = (ADVFileControl{}) == r.File.ADVControl
+	}
+	if !missing || (FileHeader{}) == r.File.Header {
+		return
+	}
+	r.recordName = "FileControl"
+	r.addDiagnostic(r.recordName, "", "missing, computed from the file's batches")
+	if err := r.File.Create(); err != nil {
+		r.errors.Add(r.parseError(err))
+	}
+}
@@ -0,0 +1,110 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readTestdataLines(t *testing.T, name string) []string {
+	t.Helper()
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSuffix(string(bs), "\n"), "\n")
+}
+
+func readMode(lines []string, mode ReaderMode) (*Reader, File, error) {
+	r := NewReader(strings.NewReader(strings.Join(lines, "\n")))
+	r.SetMode(mode)
+	f, err := r.Read()
+	return r, f, err
+}
+
+func TestReader__lenient(t *testing.T) {
+	lines := readTestdataLines(t, "ppd-debit.ach")
+	quirky := []string{
+		strings.TrimRight(lines[0], " "),                 // short FileHeader
+		lines[1][:50] + "ppd" + lines[1][53:] + "      ", // lowercase SEC code and padding
+		"",       // blank line
+		lines[2], // entry without its BatchControl, FileControl or block padding
+	}
+	quirky[0] = quirky[0][:33] + "a" + quirky[0][34:]
+
+	if _, _, err := readMode(quirky, ReaderStrict); err == nil {
+		t.Fatal("expected error")
+	}
+	r, f, err := readMode(quirky, ReaderLenient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Validate(); err != nil {
+		t.Error(err)
+	}
+	if f.Header.FileIDModifier != "A" || len(f.Batches) != 1 || f.Batches[0].GetHeader().StandardEntryClassCode != PPD {
+		t.Errorf("unexpected file: %#v", f)
+	}
+	if f.Control.EntryAddendaCount != 1 || f.Batches[0].GetControl().TotalDebitEntryDollarAmount != 100000000 {
+		t.Errorf("unexpected controls: %#v", f.Control)
+	}
+	if n := len(r.Diagnostics()); n != 7 {
+		t.Errorf("got %d diagnostics: %v", n, r.Diagnostics())
+	}
+
+	// lines padded with anything other than spaces are still invalid
+	quirky[3] += "extra"
+	if _, _, err := readMode(quirky, ReaderLenient); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestReader__recover(t *testing.T) {
+	lines := readTestdataLines(t, "flattenBatchesMultipleBatchHeaders.ach")
+	lines[2] = "6" + "99" + lines[2][3:] // invalid TransactionCode in the first batch
+	lines = append(lines[:1], append([]string{"X unknown record"}, lines[1:]...)...)
+
+	if _, _, err := readMode(lines, ReaderLenient); err == nil {
+		t.Fatal("expected error")
+	}
+	r, f, err := readMode(lines, ReaderRecover)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Batches) != 3 {
+		t.Errorf("got %d batches", len(f.Batches))
+	}
+	var skipped []string
+	for _, d := range r.Diagnostics() {
+		if strings.HasPrefix(d.Message, "skipped") {
+			skipped = append(skipped, d.String())
+		}
+	}
+	// the unknown record, entry, its addenda and batch
+	if len(skipped) != 4 {
+		t.Errorf("skipped: %v", skipped)
+	}
+
+	// files without a FileHeader can't be recovered
+	if _, _, err := readMode(lines[2:], ReaderRecover); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -1651,3 +1651,120 @@ func TestReader_AddendaParse(t *testing.T) {
 		}
 	}
 }
+
+func TestReader__Diagnostics(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(bs), "\n")
+	lines[4] = lines[4][:90] + "DATA"                   // FileControl reserved columns
+	lines[5] = lines[5][:93] + "X"                      // block padding
+	lines[3] = lines[3][:73] + "ABCDEF" + lines[3][79:] // BatchControl reserved columns
+
+	r := NewReader(strings.NewReader(strings.Join(lines, "\n")))
+	if _, err := r.Read(); err != nil {
+		t.Fatal(err)
+	}
+	diagnostics := r.Diagnostics()
+	if len(diagnostics) != 3 {
+		t.Fatalf("got %d diagnostics: %v", len(diagnostics), diagnostics)
+	}
+	if d := diagnostics[0]; d.Line != 4 || d.Record != "BatchControl" || d.Field != "reserved" || !strings.Contains(d.Message, `"ABCDEF"`) {
+		t.Errorf("unexpected diagnostic: %v", d)
+	}
+	if d := diagnostics[1]; d.Line != 5 || d.Record != "FileControl" || !strings.Contains(d.Message, "DATA") {
+		t.Errorf("unexpected diagnostic: %v", d)
+	}
+	if d := diagnostics[2]; d.Line != 6 || d.Field != "" || !strings.Contains(d.Message, "block padding") {
+		t.Errorf("unexpected diagnostic: %v", d)
+	}
+
+	// a valid file has no diagnostics
+	r = NewReader(bytes.NewReader(bs))
+	if _, err := r.Read(); err != nil {
+		t.Fatal(err)
+	}
+	if d := r.Diagnostics(); len(d) != 0 {
+		t.Errorf("unexpected diagnostics: %v", d)
+	}
+}
+
+func TestReader__IATDiagnostics(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "20180716-IAT-A17-A18.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(bs), "\n")
+	for i := range lines {
+		if strings.HasPrefix(lines[i], "710") {
+			lines[i] = lines[i][:81] + "RESERV" + lines[i][87:]
+			break
+		}
+	}
+
+	r := NewReader(strings.NewReader(strings.Join(lines, "\n")))
+	if _, err := r.Read(); err != nil {
+		t.Fatal(err)
+	}
+	diagnostics := r.Diagnostics()
+	if len(diagnostics) != 1 {
+		t.Fatalf("got %d diagnostics: %v", len(diagnostics), diagnostics)
+	}
+	if d := diagnostics[0]; d.Record != "Addenda10" || !strings.Contains(d.String(), "columns 82-87") {
+		t.Errorf("unexpected diagnostic: %v", d)
+	}
+}
+
+func TestReader__lineEndings(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(bs)), "\n")
+
+	cases := map[string]string{
+		"LF":                 strings.Join(lines, "\n"),
+		"CRLF":               strings.Join(lines, "\r\n") + "\r\n",
+		"CR":                 strings.Join(lines, "\r") + "\r",
+		"no block padding":   strings.Join(lines[:5], "\r\n"),
+		"mixed line endings": strings.Join(lines[:3], "\r") + "\n" + strings.Join(lines[3:], "\r\n"),
+	}
+	for name, contents := range cases {
+		f, err := NewReader(strings.NewReader(contents)).Read()
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if err := f.Validate(); err != nil || len(f.Batches) != 1 {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func BenchmarkReader(b *testing.B) {
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(mockFileBatches(b, 1000)); err != nil {
+		b.Fatal(err)
+	}
+	bench := func(b *testing.B, contents []byte) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(contents)))
+		for i := 0; i < b.N; i++ {
+			if _, err := NewReader(bytes.NewReader(contents)).Read(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	b.Run("lines", func(b *testing.B) {
+		bench(b, buf.Bytes())
+	})
+	b.Run("fixed width", func(b *testing.B) {
+		// files without line endings are read as one line, which bufio.Scanner limits to 64KB
+		var small bytes.Buffer
+		if err := NewWriter(&small).Write(mockFileBatches(b, 50)); err != nil {
+			b.Fatal(err)
+		}
+		bench(b, bytes.ReplaceAll(small.Bytes(), []byte("\n"), nil))
+	})
+}
@@ -0,0 +1,280 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build ignore
+// +build ignore
+
+// Generates records.go.
+//
+// Records whose fields are tagged with their position and format, for example
+//
+//	BatchCount int `json:"batchCount" ach:"2-7,numeric"`
+//
+// have their parseFields and String methods written here instead of by hand.
+// Positions are the 1-based, inclusive columns from the NACHA rules and the
+// formats are:
+//
+//	alpha    left-justified and space filled, trimmed when parsed
+//	numeric  an int which is right-justified and zero filled
+//	string   a string of digits which is zero filled, trimmed when parsed
+//	blank    reserved, always written and parsed as spaces
+//
+// Columns not covered by a field are written as spaces.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const recordLength = 94
+
+var outputFilename = flag.String("o", "records.go", "file to write the generated code to")
+
+type recordField struct {
+	name   string
+	format string
+	start  int // 0-based offset of the field
+	end    int // 0-based offset after the field
+}
+
+type record struct {
+	name     string
+	receiver string
+	fields   []recordField
+}
+
+func main() {
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != "records.go"
+	}, 0)
+	if err != nil {
+		log.Fatalf("error parsing package: %v", err)
+	}
+	pkg, ok := pkgs["ach"]
+	if !ok {
+		log.Fatal("package ach not found, run from the root of the repository")
+	}
+
+	records := make(map[string]*record)
+	receivers := make(map[string]string)
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					if r := readRecord(ts.Name.Name, st); r != nil {
+						records[r.name] = r
+					}
+				}
+			case *ast.FuncDecl:
+				// name receivers as the record's Parse method does
+				if decl.Name.Name != "Parse" || decl.Recv == nil || len(decl.Recv.List) != 1 || len(decl.Recv.List[0].Names) != 1 {
+					continue
+				}
+				if star, ok := decl.Recv.List[0].Type.(*ast.StarExpr); ok {
+					if ident, ok := star.X.(*ast.Ident); ok {
+						receivers[ident.Name] = decl.Recv.List[0].Names[0].Name
+					}
+				}
+			}
+		}
+	}
+
+	var names []string
+	for name, r := range records {
+		r.receiver = receivers[name]
+		if r.receiver == "" {
+			r.receiver = strings.ToLower(name[:1])
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	fmt.Fprint(&buf, `// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Code generated by records_gen.go; DO NOT EDIT.
+
+package ach
+
+import "strings"
+`)
+	for _, name := range names {
+		writeRecord(&buf, records[name])
+	}
+
+	// format source code and write file
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Println(buf.String())
+		log.Fatalf("error formatting output code, err=%v", err)
+	}
+	if err := ioutil.WriteFile(*outputFilename, out, 0644); err != nil {
+		log.Fatalf("error writing file, err=%v", err)
+	}
+}
+
+// readRecord returns the fields of st tagged with their position, or nil if none are
+func readRecord(name string, st *ast.StructType) *record {
+	r := &record{name: name}
+	for _, field := range st.Fields.List {
+		if field.Tag == nil || len(field.Names) != 1 {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			log.Fatalf("%s.%s: invalid tag: %v", name, field.Names[0].Name, err)
+		}
+		value, ok := reflect.StructTag(tag).Lookup("ach")
+		if !ok {
+			continue
+		}
+		f, err := parseTag(value)
+		if err != nil {
+			log.Fatalf("%s.%s: %v", name, field.Names[0].Name, err)
+		}
+		f.name = field.Names[0].Name
+
+		want := "string"
+		if f.format == "numeric" {
+			want = "int"
+		}
+		if typ, _ := field.Type.(*ast.Ident); typ == nil || typ.Name != want {
+			log.Fatalf("%s.%s: %s fields must be of type %s", name, f.name, f.format, want)
+		}
+		if n := len(r.fields); n > 0 && r.fields[n-1].end > f.start {
+			log.Fatalf("%s.%s: overlaps %s", name, f.name, r.fields[n-1].name)
+		}
+		r.fields = append(r.fields, f)
+	}
+	if len(r.fields) == 0 {
+		return nil
+	}
+	return r
+}
+
+// parseTag reads an ach struct tag of "start-end,format"
+func parseTag(tag string) (recordField, error) {
+	var f recordField
+	parts := strings.Split(tag, ",")
+	if len(parts) != 2 {
+		return f, fmt.Errorf("invalid tag %q", tag)
+	}
+	switch parts[1] {
+	case "alpha", "numeric", "string", "blank":
+		f.format = parts[1]
+	default:
+		return f, fmt.Errorf("unknown format %q", parts[1])
+	}
+	if _, err := fmt.Sscanf(parts[0], "%d-%d", &f.start, &f.end); err != nil {
+		return f, fmt.Errorf("invalid position %q: %v", parts[0], err)
+	}
+	if f.start < 1 || f.end < f.start || f.end > recordLength {
+		return f, fmt.Errorf("invalid position %q", parts[0])
+	}
+	f.start--
+	return f, nil
+}
+
+func writeRecord(buf *bytes.Buffer, r *record) {
+	// blank fields aren't read, so records may end before them
+	last := 0
+	for _, f := range r.fields {
+		if f.format != "blank" {
+			last = f.end
+		}
+	}
+
+	fmt.Fprintf(buf, "\n// parseFields sets the fields of %s from record, which must have at least %d characters\n", r.name, last)
+	fmt.Fprintf(buf, "func (%s *%s) parseFields(record string) {\n", r.receiver, r.name)
+	for _, f := range r.fields {
+		fmt.Fprintf(buf, "\t// %d-%d\n", f.start+1, f.end)
+		switch f.format {
+		case "numeric":
+			fmt.Fprintf(buf, "\t%[1]s.%[2]s = %[1]s.parseNumField(record[%[3]d:%[4]d])\n", r.receiver, f.name, f.start, f.end)
+		case "blank":
+			fmt.Fprintf(buf, "\t%s.%s = spaces[:%d]\n", r.receiver, f.name, f.end-f.start)
+		default:
+			fmt.Fprintf(buf, "\t%[1]s.%[2]s = %[1]s.parseStringField(record[%[3]d:%[4]d])\n", r.receiver, f.name, f.start, f.end)
+		}
+	}
+	fmt.Fprint(buf, "}\n")
+
+	fmt.Fprintf(buf, "\n// String writes the %s struct to a %d character string.\n", r.name, recordLength)
+	fmt.Fprintf(buf, "func (%s *%s) String() string {\n", r.receiver, r.name)
+	fmt.Fprintf(buf, "\tvar buf strings.Builder\n\tbuf.Grow(%d)\n", recordLength)
+	pos := 0
+	for _, f := range r.fields {
+		if f.start > pos {
+			fmt.Fprintf(buf, "\tbuf.WriteString(spaces[:%d])\n", f.start-pos)
+		}
+		switch f.format {
+		case "alpha":
+			fmt.Fprintf(buf, "\tbuf.WriteString(%[1]s.alphaField(%[1]s.%[2]s, %[3]d))\n", r.receiver, f.name, f.end-f.start)
+		case "numeric":
+			fmt.Fprintf(buf, "\tbuf.WriteString(%[1]s.numericField(%[1]s.%[2]s, %[3]d))\n", r.receiver, f.name, f.end-f.start)
+		case "string":
+			fmt.Fprintf(buf, "\tbuf.WriteString(%[1]s.stringField(%[1]s.%[2]s, %[3]d))\n", r.receiver, f.name, f.end-f.start)
+		case "blank":
+			fmt.Fprintf(buf, "\tbuf.WriteString(spaces[:%d])\n", f.end-f.start)
+		}
+		pos = f.end
+	}
+	if pos < recordLength {
+		fmt.Fprintf(buf, "\tbuf.WriteString(spaces[:%d])\n", recordLength-pos)
+	}
+	fmt.Fprint(buf, "\treturn buf.String()\n}\n")
+}
@@ -0,0 +1,66 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecords__generated(t *testing.T) {
+	if testing.Short() {
+		t.Skip("-short flag enabled")
+	}
+	dir, err := ioutil.TempDir("", "ach-records")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "records.go")
+	if out, err := exec.Command("go", "run", "records_gen.go", "-o", path).CombinedOutput(); err != nil {
+		t.Fatalf("%v: %s", err, out)
+	}
+	generated, _ := ioutil.ReadFile(path)
+	existing, _ := ioutil.ReadFile("records.go")
+	if !bytes.Equal(generated, existing) {
+		t.Error("records.go is out of date, run: go run records_gen.go")
+	}
+}
+
+func TestRecords__String(t *testing.T) {
+	// reserved columns are written as spaces, even on records which weren't created by their constructor
+	addenda98 := &Addenda98{recordType: "7", TypeCode: "98", ChangeCode: "C01", OriginalTrace: "121042880000001", OriginalDFI: "12104288", CorrectedData: "1918171614", TraceNumber: "91012980000088"}
+	line := addenda98.String()
+	if len(line) != RecordLength || line[21:27] != "      " || line[64:79] != strings.Repeat(" ", 15) {
+		t.Errorf("unexpected line: %q", line)
+	}
+	parsed := NewAddenda98()
+	parsed.Parse(line)
+	if parsed.String() != line || parsed.TraceNumber != "091012980000088" {
+		t.Errorf("unexpected Addenda98: %#v", parsed)
+	}
+
+	for _, s := range []interface{ String() string }{&FileControl{}, &ADVFileControl{}, &BatchControl{}, &ADVBatchControl{}, &Addenda05{}} {
+		if n := len(s.String()); n != RecordLength {
+			t.Errorf("%T: %d characters", s, n)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+)
+
+var (
+	// ErrZeroDollarRemittanceAmount is the error given when a zero dollar remittance entry has a non-zero Amount
+	ErrZeroDollarRemittanceAmount = errors.New("zero dollar remittance entries must have a zero amount")
+	// ErrZeroDollarRemittanceAddenda is the error given when a zero dollar remittance entry has no Addenda05 records
+	ErrZeroDollarRemittanceAddenda = errors.New("zero dollar remittance entries require remittance addenda")
+	// ErrZeroDollarRemittanceTransactionCode is the error given when an entry's TransactionCode has no zero dollar remittance code
+	ErrZeroDollarRemittanceTransactionCode = errors.New("transaction code has no zero dollar remittance code")
+)
+
+// zeroDollarRemittanceTransactionCodes maps the TransactionCode of live entries to that of a zero dollar
+// entry carrying remittance data for the same account
+var zeroDollarRemittanceTransactionCodes = map[int]int{
+	CheckingCredit: CheckingZeroDollarRemittanceCredit,
+	CheckingDebit:  CheckingZeroDollarRemittanceDebit,
+	SavingsCredit:  SavingsZeroDollarRemittanceCredit,
+	SavingsDebit:   SavingsZeroDollarRemittanceDebit,
+	GLCredit:       GLZeroDollarRemittanceCredit,
+	GLDebit:        GLZeroDollarRemittanceDebit,
+	LoanCredit:     LoanZeroDollarRemittanceCredit,
+}
+
+// isZeroDollarRemittanceTransactionCode returns true if code is the TransactionCode of a zero dollar remittance entry
+func isZeroDollarRemittanceTransactionCode(code int) bool {
+	switch code {
+	case CheckingZeroDollarRemittanceCredit, CheckingZeroDollarRemittanceDebit, SavingsZeroDollarRemittanceCredit,
+		SavingsZeroDollarRemittanceDebit, GLZeroDollarRemittanceCredit, GLZeroDollarRemittanceDebit,
+		LoanZeroDollarRemittanceCredit:
+		return true
+	}
+	return false
+}
+
+// ZeroDollarRemittanceTransactionCode returns the TransactionCode of a zero dollar remittance entry for an
+// entry with code, e.g. 24 for 22. Zero dollar remittance codes are returned as is and
+// ErrZeroDollarRemittanceTransactionCode is returned for codes without one.
+func ZeroDollarRemittanceTransactionCode(code int) (int, error) {
+	if isZeroDollarRemittanceTransactionCode(code) {
+		return code, nil
+	}
+	if remittance, ok := zeroDollarRemittanceTransactionCodes[code]; ok {
+		return remittance, nil
+	}
+	return 0, ErrZeroDollarRemittanceTransactionCode
+}
+
+// IsZeroDollarRemittance returns true if the entry only carries remittance data in its addenda, without moving
+// any funds. CCD and CTX batches require these entries to have a zero Amount and at least one Addenda05.
+func (ed *EntryDetail) IsZeroDollarRemittance() bool {
+	return isZeroDollarRemittanceTransactionCode(ed.TransactionCode)
+}
+
+// isZeroDollarRemittance checks the zero dollar remittance entries of a CCD or CTX batch have a zero
+// Amount and remittance data in an Addenda05
+func (batch *Batch) isZeroDollarRemittance(entry *EntryDetail) error {
+	if !entry.IsZeroDollarRemittance() || (entry.Category != "" && entry.Category != CategoryForward) {
+		return nil
+	}
+	if entry.Amount != 0 {
+		return batch.Error("Amount", ErrZeroDollarRemittanceAmount, entry.Amount)
+	}
+	if len(entry.Addenda05) == 0 {
+		return batch.Error("Addenda05", ErrZeroDollarRemittanceAddenda, entry.TransactionCode)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/moov-io/base"
+)
+
+func TestZeroDollarRemittanceTransactionCode(t *testing.T) {
+	cases := map[int]int{
+		CheckingCredit:                     CheckingZeroDollarRemittanceCredit,
+		CheckingDebit:                      CheckingZeroDollarRemittanceDebit,
+		SavingsCredit:                      SavingsZeroDollarRemittanceCredit,
+		SavingsDebit:                       SavingsZeroDollarRemittanceDebit,
+		GLCredit:                           GLZeroDollarRemittanceCredit,
+		GLDebit:                            GLZeroDollarRemittanceDebit,
+		LoanCredit:                         LoanZeroDollarRemittanceCredit,
+		CheckingZeroDollarRemittanceCredit: CheckingZeroDollarRemittanceCredit,
+	}
+	for code, expected := range cases {
+		if remittance, err := ZeroDollarRemittanceTransactionCode(code); err != nil || remittance != expected {
+			t.Errorf("%d: got %d (%v) expected %d", code, remittance, err, expected)
+		}
+	}
+	for _, code := range []int{CheckingPrenoteCredit, LoanDebit} {
+		if _, err := ZeroDollarRemittanceTransactionCode(code); err != ErrZeroDollarRemittanceTransactionCode {
+			t.Errorf("%d: unexpected error: %v", code, err)
+		}
+	}
+}
+
+func TestBatchCCD__zeroDollarRemittance(t *testing.T) {
+	batch := NewBatchCCD(mockBatchCCDHeader())
+	entry := mockCCDEntryDetail()
+	entry.TransactionCode = CheckingZeroDollarRemittanceDebit
+	entry.Amount = 0
+	entry.AddAddenda05(mockAddenda05())
+	entry.AddendaRecordIndicator = 1
+	batch.AddEntry(entry)
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if !entry.IsZeroDollarRemittance() || batch.Control.TotalDebitEntryDollarAmount != 0 {
+		t.Errorf("unexpected batch: %#v", batch.Control)
+	}
+
+	// the file can be written and read back
+	file := NewFile()
+	file.SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewReader(&buf).Read(); err != nil {
+		t.Fatal(err)
+	}
+
+	entry.Amount = 100
+	if err := batch.Create(); !base.Match(err, ErrZeroDollarRemittanceAmount) {
+		t.Errorf("%T: %v", err, err)
+	}
+
+	entry.Amount = 0
+	entry.Addenda05 = nil
+	entry.AddendaRecordIndicator = 0
+	if err := batch.Create(); !base.Match(err, ErrZeroDollarRemittanceAddenda) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
+
+func TestBatchCTX__zeroDollarRemittance(t *testing.T) {
+	batch := NewBatchCTX(mockBatchCTXHeader())
+	entry := mockCTXEntryDetail()
+	entry.TransactionCode = SavingsZeroDollarRemittanceCredit
+	entry.Amount = 0
+	entry.AddAddenda05(mockAddenda05())
+	entry.AddendaRecordIndicator = 1
+	batch.AddEntry(entry)
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	entry.Addenda05 = nil
+	entry.AddendaRecordIndicator = 0
+	entry.SetCATXAddendaRecords(0)
+	if err := batch.Create(); !base.Match(err, ErrZeroDollarRemittanceAddenda) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
@@ -0,0 +1,210 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package routing
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrNotParticipant is given when a routing number isn't in the FedACH participant directory
+	ErrNotParticipant = errors.New("routing number is not a FedACH participant")
+	// ErrSECCodeNotReceived is given when a participant doesn't receive entries of a Standard Entry Class Code
+	ErrSECCodeNotReceived = errors.New("participant does not receive SEC code")
+)
+
+// Participant is an institution listed in the FedACH participant directory
+type Participant struct {
+	RoutingNumber Number
+	// OfficeCode is O for a main office or B for a branch
+	OfficeCode string
+	// ServicingFRBNumber is the routing number of the Federal Reserve Bank servicing the institution
+	ServicingFRBNumber string
+	// RecordTypeCode is 0 for a Federal Reserve Bank, 1 when entries are sent to RoutingNumber and
+	// 2 when entries are sent to NewRoutingNumber
+	RecordTypeCode string
+	// NewRoutingNumber replaces RoutingNumber when RecordTypeCode is 2
+	NewRoutingNumber string
+	CustomerName     string
+	City             string
+	State            string
+	// SECCodes are the Standard Entry Class Codes the participant receives, or empty when it receives all of them.
+	// The FedACH directory doesn't list them, so they're only read from CSV files.
+	SECCodes []string
+}
+
+// Receives returns nil if p receives entries of the Standard Entry Class Code sec, or ErrSECCodeNotReceived
+func (p *Participant) Receives(sec string) error {
+	if len(p.SECCodes) == 0 {
+		return nil
+	}
+	for _, code := range p.SECCodes {
+		if strings.EqualFold(code, sec) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %s: %s", ErrSECCodeNotReceived, sec, p.RoutingNumber)
+}
+
+// Directory looks up routing numbers in the FedACH participant directory, e.g. with a StaticDirectory
+// or a client of a service which keeps a copy of it.
+type Directory interface {
+	// Lookup returns the Participant of routingNumber or ErrNotParticipant
+	Lookup(ctx context.Context, routingNumber Number) (*Participant, error)
+}
+
+// Verify parses routingNumber and checks it's a FedACH participant in dir
+func Verify(ctx context.Context, dir Directory, routingNumber string) (*Participant, error) {
+	n, err := Parse(routingNumber)
+	if err != nil {
+		return nil, err
+	}
+	return dir.Lookup(ctx, n)
+}
+
+// StaticDirectory is a Directory of participants by their routing number
+type StaticDirectory map[Number]Participant
+
+// Lookup returns the Participant of routingNumber or ErrNotParticipant
+func (d StaticDirectory) Lookup(_ context.Context, routingNumber Number) (*Participant, error) {
+	p, ok := d[routingNumber]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotParticipant, routingNumber)
+	}
+	return &p, nil
+}
+
+// fedACHRecordLength is the length of each line of a FedACH directory file
+const fedACHRecordLength = 155
+
+// ReadFedACHDirectory reads the fixed width FedACH directory file (FedACHdir.txt) the Federal Reserve publishes
+func ReadFedACHDirectory(r io.Reader) (StaticDirectory, error) {
+	dir := make(StaticDirectory)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		record := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+		if len(record) < fedACHRecordLength {
+			// some copies have their trailing spaces trimmed
+			record += strings.Repeat(" ", fedACHRecordLength-len(record))
+		}
+		if len(record) != fedACHRecordLength {
+			return nil, fmt.Errorf("line %d: invalid FedACH directory record length of %d", line, len(record))
+		}
+		n, err := Parse(record[0:9])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		dir[n] = Participant{
+			RoutingNumber:      n,
+			OfficeCode:         record[9:10],
+			ServicingFRBNumber: record[10:19],
+			RecordTypeCode:     record[19:20],
+			NewRoutingNumber:   strings.TrimSpace(record[26:35]),
+			CustomerName:       strings.TrimSpace(record[35:71]),
+			City:               strings.TrimSpace(record[107:127]),
+			State:              record[127:129],
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return dir, nil
+}
+
+// ReadParticipantsCSV reads participants from a CSV file whose first row names its columns. The routingNumber
+// column is required and customerName, city, state, newRoutingNumber, officeCode, servicingFRBNumber,
+// recordTypeCode and secCodes (separated by spaces, semicolons or pipes) are read when present. Column names
+// ignore case, spaces, dashes and underscores, so "Routing Number" and "routing_number" also work.
+func ReadParticipantsCSV(r io.Reader) (StaticDirectory, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %v", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.NewReplacer(" ", "", "-", "", "_", "").Replace(name)
+		columns[name] = i
+	}
+	if _, ok := columns["routingnumber"]; !ok {
+		return nil, errors.New("CSV is missing a routingNumber column")
+	}
+
+	dir := make(StaticDirectory)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		n, err := Parse(field("routingnumber"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		dir[n] = Participant{
+			RoutingNumber:      n,
+			OfficeCode:         field("officecode"),
+			ServicingFRBNumber: field("servicingfrbnumber"),
+			RecordTypeCode:     field("recordtypecode"),
+			NewRoutingNumber:   field("newroutingnumber"),
+			CustomerName:       field("customername"),
+			City:               field("city"),
+			State:              field("state"),
+			SECCodes: strings.FieldsFunc(strings.ToUpper(field("seccodes")), func(r rune) bool {
+				return r == ' ' || r == ';' || r == '|'
+			}),
+		}
+	}
+	return dir, nil
+}
+
+// ReadDirectoryFile reads the participants in path, a CSV file (see ReadParticipantsCSV) when it ends
+// in .csv and otherwise a FedACH directory file (see ReadFedACHDirectory).
+func ReadDirectoryFile(path string) (StaticDirectory, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return ReadParticipantsCSV(fd)
+	}
+	return ReadFedACHDirectory(fd)
+}
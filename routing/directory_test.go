@@ -0,0 +1,129 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package routing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadFedACHDirectory(t *testing.T) {
+	fd, err := os.Open(filepath.Join("testdata", "FedACHdir.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	dir, err := ReadFedACHDirectory(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dir) != 3 {
+		t.Fatalf("got %d participants", len(dir))
+	}
+
+	p, err := Verify(context.Background(), dir, "121042882")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.CustomerName != "WELLS FARGO BANK NA" || p.City != "MINNEAPOLIS" || p.State != "MN" {
+		t.Errorf("unexpected participant: %#v", p)
+	}
+	if p.OfficeCode != "O" || p.ServicingFRBNumber != "121000374" || p.RecordTypeCode != "2" || p.NewRoutingNumber != "121000248" {
+		t.Errorf("unexpected participant: %#v", p)
+	}
+
+	// the second record has its trailing spaces trimmed
+	if p, err := Verify(context.Background(), dir, "231380104"); err != nil || p.State != "PA" {
+		t.Errorf("unexpected participant %#v: %v", p, err)
+	}
+
+	if _, err := Verify(context.Background(), dir, "000000518"); !errors.Is(err, ErrNotParticipant) {
+		t.Errorf("expected ErrNotParticipant, got %v", err)
+	}
+	if _, err := Verify(context.Background(), dir, "021000022"); err == nil || errors.Is(err, ErrNotParticipant) {
+		t.Errorf("expected checksum error, got %v", err)
+	}
+}
+
+func TestReadFedACHDirectory__invalid(t *testing.T) {
+	if _, err := ReadFedACHDirectory(strings.NewReader("021000022O0210012081")); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("expected error, got %v", err)
+	}
+	if _, err := ReadFedACHDirectory(strings.NewReader("\n" + strings.Repeat("0", 156))); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected error, got %v", err)
+	}
+}
+
+func TestReadParticipantsCSV(t *testing.T) {
+	dir, err := ReadDirectoryFile(filepath.Join("testdata", "participants.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dir) != 2 {
+		t.Fatalf("got %d participants", len(dir))
+	}
+
+	p, err := Verify(context.Background(), dir, "231380104")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.CustomerName != "CITADEL FEDERAL CREDIT UNION" || p.State != "PA" || len(p.SECCodes) != 2 {
+		t.Errorf("unexpected participant: %#v", p)
+	}
+	if err := p.Receives("web"); err != nil {
+		t.Error(err)
+	}
+	if err := p.Receives("CCD"); !errors.Is(err, ErrSECCodeNotReceived) {
+		t.Errorf("expected ErrSECCodeNotReceived, got %v", err)
+	}
+
+	// participants without SEC codes receive all of them
+	p, err = Verify(context.Background(), dir, "021000021")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Receives("IAT"); err != nil {
+		t.Error(err)
+	}
+
+	// other files are read as FedACH directories
+	dir, err = ReadDirectoryFile(filepath.Join("testdata", "FedACHdir.txt"))
+	if err != nil || len(dir) != 3 {
+		t.Errorf("got %d participants: %v", len(dir), err)
+	}
+}
+
+func TestReadParticipantsCSV__invalid(t *testing.T) {
+	cases := map[string]string{
+		"":                                  "reading CSV header",
+		"name,city\nBANK,NYC\n":             "missing a routingNumber column",
+		"routing_number\n021000021\n1234\n": "line 3",
+		"routingNumber\n\"021000021\n":      "",
+	}
+	for in, want := range cases {
+		_, err := ReadParticipantsCSV(strings.NewReader(in))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: expected %q, got %v", in, want, err)
+		}
+	}
+}
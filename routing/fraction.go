@@ -0,0 +1,87 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidFraction is given when a routing number in fraction form can't be read
+var ErrInvalidFraction = errors.New("invalid routing number fraction")
+
+// Fraction returns n in the fraction form printed on checks, e.g. "1-2/210" for 021000021. The fraction
+// starts with the ABA prefix of the institution's city or state, which isn't part of the routing number.
+func (n Number) Fraction(abaPrefix int) string {
+	institution, _ := strconv.Atoi(n.InstitutionIdentifier())
+	symbol, _ := strconv.Atoi(n.RoutingSymbol())
+	return fmt.Sprintf("%d-%d/%d", abaPrefix, institution, symbol)
+}
+
+// ParseFraction reads a routing number in fraction form, e.g. "1-2/210", returning it along with its ABA prefix
+func ParseFraction(fraction string) (Number, int, error) {
+	fail := func() (Number, int, error) {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidFraction, fraction)
+	}
+	parts := strings.Split(strings.Replace(fraction, " ", "", -1), "/")
+	if len(parts) != 2 {
+		return fail()
+	}
+	numerator := strings.Split(parts[0], "-")
+	if len(numerator) != 2 {
+		return fail()
+	}
+	prefix, err := fractionField(numerator[0], 2)
+	if err != nil {
+		return fail()
+	}
+	institution, err := fractionField(numerator[1], 4)
+	if err != nil {
+		return fail()
+	}
+	symbol, err := fractionField(parts[1], 4)
+	if err != nil {
+		return fail()
+	}
+
+	digits := fmt.Sprintf("%04d%04d", symbol, institution)
+	check, err := CheckDigit(digits)
+	if err != nil {
+		return fail()
+	}
+	n, err := Parse(digits + strconv.Itoa(check))
+	if err != nil {
+		return "", 0, err
+	}
+	return n, prefix, nil
+}
+
+// fractionField reads s as a number of at most max digits
+func fractionField(s string, max int) (int, error) {
+	if s == "" || len(s) > max {
+		return 0, ErrInvalidFraction
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, ErrInvalidFraction
+		}
+	}
+	return strconv.Atoi(s)
+}
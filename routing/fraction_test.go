@@ -0,0 +1,61 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package routing
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFraction(t *testing.T) {
+	n, err := Parse("021000021")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := n.Fraction(1); f != "1-2/210" {
+		t.Errorf("got %s", f)
+	}
+
+	got, prefix, err := ParseFraction(" 1-2 / 210 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != n || prefix != 1 {
+		t.Errorf("got %s with prefix %d", got, prefix)
+	}
+
+	got, prefix, err = ParseFraction("90-4288/1210")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "121042882" || prefix != 90 {
+		t.Errorf("got %s with prefix %d", got, prefix)
+	}
+}
+
+func TestFraction__invalid(t *testing.T) {
+	for _, in := range []string{"", "1-2", "1/210", "1-2/210/3", "123-2/210", "1-23456/210", "1-2/12345", "a-2/210", "1-2/"} {
+		if _, _, err := ParseFraction(in); !errors.Is(err, ErrInvalidFraction) {
+			t.Errorf("%q: expected ErrInvalidFraction, got %v", in, err)
+		}
+	}
+	// routing symbols starting 13 to 20 aren't assigned
+	if _, _, err := ParseFraction("1-2/1310"); !errors.Is(err, ErrInvalidPrefix) {
+		t.Errorf("expected ErrInvalidPrefix, got %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package routing reads ABA routing transit numbers, which identify the financial institutions
+// taking part in ACH, checks and wire transfers.
+//
+// A routing number is nine digits: a four digit Federal Reserve routing symbol, a four digit
+// institution identifier and a check digit. The first two digits of the routing symbol give the
+// kind of institution and its Federal Reserve district.
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrEmpty is given when no routing number is provided
+	ErrEmpty = errors.New("no routing number provided")
+	// ErrInvalidPrefix is given when the first two digits of a routing number aren't assigned by the ABA
+	ErrInvalidPrefix = errors.New("invalid routing number prefix")
+)
+
+// CheckDigit returns the check digit of the first eight digits of a routing number. The digits are
+// multiplied by the weights 3, 7, 1, 3, 7, 1, 3 and 7 and the check digit brings their sum up to the
+// next multiple of ten.
+func CheckDigit(routingNumber string) (int, error) {
+	if n := utf8.RuneCountInString(routingNumber); n != 8 && n != 9 {
+		return 0, fmt.Errorf("invalid routing number length of %d", n)
+	}
+	weights := [8]int{3, 7, 1, 3, 7, 1, 3, 7}
+	sum := 0
+	for i, w := range weights {
+		c := routingNumber[i]
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("invalid routing number %q: only digits are allowed", routingNumber)
+		}
+		sum += int(c-'0') * w
+	}
+	return (10 - sum%10) % 10, nil
+}
+
+// Validate checks routingNumber is nine digits with a correct check digit
+func Validate(routingNumber string) error {
+	if routingNumber == "" {
+		return ErrEmpty
+	}
+	if n := utf8.RuneCountInString(routingNumber); n != 9 {
+		return fmt.Errorf("invalid routing number length of %d", n)
+	}
+	check, err := CheckDigit(routingNumber)
+	if err != nil {
+		return err
+	}
+	if last := routingNumber[8:]; fmt.Sprintf("%d", check) != last {
+		return fmt.Errorf("routing number checksum mismatch: expected %d but got %s", check, last)
+	}
+	return nil
+}
+
+// Number is a valid routing number, see Parse
+type Number string
+
+// Parse returns routingNumber, ignoring surrounding spaces, after checking its length, check digit
+// and that its prefix is one the ABA assigns.
+func Parse(routingNumber string) (Number, error) {
+	routingNumber = strings.TrimSpace(routingNumber)
+	if err := Validate(routingNumber); err != nil {
+		return "", err
+	}
+	n := Number(routingNumber)
+	if n.InstitutionType() == Unknown {
+		return "", fmt.Errorf("%w %s", ErrInvalidPrefix, routingNumber[:2])
+	}
+	return n, nil
+}
+
+// String returns the nine digits of n
+func (n Number) String() string {
+	return string(n)
+}
+
+// RoutingSymbol returns the first four digits of n, its Federal Reserve routing symbol
+func (n Number) RoutingSymbol() string {
+	return string(n[:4])
+}
+
+// InstitutionIdentifier returns the fifth to eighth digits of n, which identify the institution
+// within its Federal Reserve routing symbol
+func (n Number) InstitutionIdentifier() string {
+	return string(n[4:8])
+}
+
+// CheckDigit returns the last digit of n
+func (n Number) CheckDigit() int {
+	return int(n[8] - '0')
+}
+
+// prefix returns the first two digits of n as a number
+func (n Number) prefix() int {
+	return int(n[0]-'0')*10 + int(n[1]-'0')
+}
+
+// InstitutionType returns the kind of institution n was assigned to
+func (n Number) InstitutionType() InstitutionType {
+	switch p := n.prefix(); {
+	case p == 0:
+		return Government
+	case p >= 1 && p <= 12:
+		return Bank
+	case p >= 21 && p <= 32:
+		return Thrift
+	case p >= 61 && p <= 72:
+		return Electronic
+	case p == 80:
+		return TravelersCheques
+	}
+	return Unknown
+}
+
+// District returns the Federal Reserve district n is in, or NoDistrict for government and
+// travelers cheque routing numbers
+func (n Number) District() District {
+	switch n.InstitutionType() {
+	case Bank:
+		return District(n.prefix())
+	case Thrift:
+		return District(n.prefix() - 20)
+	case Electronic:
+		return District(n.prefix() - 60)
+	}
+	return NoDistrict
+}
+
+// InstitutionType is the kind of institution given by the first two digits of a routing number
+type InstitutionType int
+
+const (
+	// Unknown is the InstitutionType of prefixes the ABA doesn't assign
+	Unknown InstitutionType = iota
+	// Government is the InstitutionType of the United States government (prefix 00)
+	Government
+	// Bank is the InstitutionType of banks, the primary institutions of a district (prefixes 01 to 12)
+	Bank
+	// Thrift is the InstitutionType of thrift institutions such as credit unions and savings banks (prefixes 21 to 32)
+	Thrift
+	// Electronic is the InstitutionType of routing numbers only used for electronic payments (prefixes 61 to 72)
+	Electronic
+	// TravelersCheques is the InstitutionType of travelers cheques (prefix 80)
+	TravelersCheques
+)
+
+func (t InstitutionType) String() string {
+	switch t {
+	case Government:
+		return "government"
+	case Bank:
+		return "bank"
+	case Thrift:
+		return "thrift"
+	case Electronic:
+		return "electronic"
+	case TravelersCheques:
+		return "travelers cheques"
+	}
+	return "unknown"
+}
+
+// District is a Federal Reserve district, numbered 1 (Boston) to 12 (San Francisco)
+type District int
+
+// NoDistrict is the District of routing numbers outside of the Federal Reserve districts
+const NoDistrict District = 0
+
+var districtNames = [...]string{
+	"", "Boston", "New York", "Philadelphia", "Cleveland", "Richmond", "Atlanta",
+	"Chicago", "St. Louis", "Minneapolis", "Kansas City", "Dallas", "San Francisco",
+}
+
+// String returns the city of the district's Federal Reserve Bank
+func (d District) String() string {
+	if d <= NoDistrict || int(d) >= len(districtNames) {
+		return "none"
+	}
+	return districtNames[d]
+}
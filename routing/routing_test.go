@@ -0,0 +1,120 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package routing
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckDigit(t *testing.T) {
+	cases := map[string]int{
+		"02100002":  1,
+		"231380104": 4,
+		"12104288":  2,
+		"00000051":  8,
+	}
+	for in, want := range cases {
+		got, err := CheckDigit(in)
+		if err != nil {
+			t.Errorf("%s: %v", in, err)
+		}
+		if got != want {
+			t.Errorf("%s: got %d, want %d", in, got, want)
+		}
+	}
+	for _, in := range []string{"", "1234567", "1234567890", "0210000a"} {
+		if _, err := CheckDigit(in); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate("021000021"); err != nil {
+		t.Error(err)
+	}
+	cases := map[string]string{
+		"":           "no routing number provided",
+		"02100002":   "invalid routing number length of 8",
+		"021000022":  "routing number checksum mismatch: expected 1 but got 2",
+		"02100002a":  "routing number checksum mismatch",
+		"0210a0021":  "only digits are allowed",
+		"0210000211": "invalid routing number length of 10",
+	}
+	for in, want := range cases {
+		err := Validate(in)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: expected %q, got %v", in, want, err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		routingNumber string
+		kind          InstitutionType
+		district      District
+	}{
+		{"000000518", Government, NoDistrict},
+		{" 021000021 ", Bank, 2},
+		{"121042882", Bank, 12},
+		{"231380104", Thrift, 3},
+		{"611000017", Electronic, 1},
+		{"800000006", TravelersCheques, NoDistrict},
+	}
+	for _, tc := range cases {
+		n, err := Parse(tc.routingNumber)
+		if err != nil {
+			t.Errorf("%s: %v", tc.routingNumber, err)
+			continue
+		}
+		if n.InstitutionType() != tc.kind || n.District() != tc.district {
+			t.Errorf("%s: got %s in %s, want %s in %s", n, n.InstitutionType(), n.District(), tc.kind, tc.district)
+		}
+	}
+
+	n, err := Parse("021000021")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.RoutingSymbol() != "0210" || n.InstitutionIdentifier() != "0002" || n.CheckDigit() != 1 {
+		t.Errorf("RoutingSymbol=%s InstitutionIdentifier=%s CheckDigit=%d", n.RoutingSymbol(), n.InstitutionIdentifier(), n.CheckDigit())
+	}
+	if n.District().String() != "New York" || n.InstitutionType().String() != "bank" {
+		t.Errorf("District=%s InstitutionType=%s", n.District(), n.InstitutionType())
+	}
+
+	// 13 to 20 aren't assigned
+	if _, err := Parse("131000018"); !errors.Is(err, ErrInvalidPrefix) {
+		t.Errorf("expected ErrInvalidPrefix, got %v", err)
+	}
+	if _, err := Parse("021000022"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestDistrict__String(t *testing.T) {
+	if s := District(12).String(); s != "San Francisco" {
+		t.Errorf("got %s", s)
+	}
+	if s := District(13).String(); s != "none" {
+		t.Errorf("got %s", s)
+	}
+}
@@ -0,0 +1,167 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/moov-io/base"
+)
+
+// RulesVersion names a set of NACHA Operating Rules by the year it came into force. The rules which
+// change what an ACH file may contain are kept, such as the Same Day ACH per entry limit and the
+// account validation of WEB debits.
+type RulesVersion string
+
+const (
+	// Rules2019 limits Same Day entries to $25,000.00
+	Rules2019 RulesVersion = "2019"
+	// Rules2020 raises the Same Day entry limit to $100,000.00 from March 20, 2020
+	Rules2020 RulesVersion = "2020"
+	// Rules2021 requires the Receiver's account of WEB debits to be validated from March 19, 2021
+	Rules2021 RulesVersion = "2021"
+	// Rules2022 raises the Same Day entry limit to $1,000,000.00 from March 18, 2022
+	Rules2022 RulesVersion = "2022"
+
+	// RulesCurrent is the latest RulesVersion, used when none is set
+	RulesCurrent = Rules2022
+	// RulesEffectiveDate checks each batch against the rules in force on its EffectiveEntryDate
+	RulesEffectiveDate RulesVersion = "effective"
+)
+
+var (
+	// ErrRulesVersion is the error given for a RulesVersion which isn't known
+	ErrRulesVersion = errors.New("unknown NACHA rules version")
+	// ErrWEBDebitAccountNotValidated is the error given for a WEB debit to an account which hasn't been validated
+	ErrWEBDebitAccountNotValidated = errors.New("WEB debit account has not been validated")
+)
+
+// Rules are the limits and requirements of a RulesVersion
+type Rules struct {
+	Version RulesVersion `json:"version"`
+	// Effective is the first day the rules are in force
+	Effective Date `json:"effective"`
+	// SameDayEntryLimit is the maximum Amount (in cents) of an entry eligible for Same Day ACH
+	SameDayEntryLimit int `json:"sameDayEntryLimit"`
+	// WEBDebitAccountValidation is true when the Receiver's account must be validated before its first WEB debit
+	WEBDebitAccountValidation bool `json:"webDebitAccountValidation"`
+}
+
+// nachaRules are the known Rules, oldest first
+var nachaRules = []Rules{
+	{Version: Rules2019, SameDayEntryLimit: 2500000},
+	{Version: Rules2020, Effective: Date{Year: 2020, Month: time.March, Day: 20}, SameDayEntryLimit: 10000000},
+	{Version: Rules2021, Effective: Date{Year: 2021, Month: time.March, Day: 19}, SameDayEntryLimit: 10000000, WEBDebitAccountValidation: true},
+	{Version: Rules2022, Effective: Date{Year: 2022, Month: time.March, Day: 18}, SameDayEntryLimit: SameDayEntryLimit, WEBDebitAccountValidation: true},
+}
+
+// RulesFor returns the Rules of version, RulesCurrent when empty. ErrRulesVersion is returned for
+// unknown versions and RulesEffectiveDate, whose rules depend on the batch.
+func RulesFor(version RulesVersion) (Rules, error) {
+	if version == "" {
+		version = RulesCurrent
+	}
+	for _, r := range nachaRules {
+		if r.Version == version {
+			return r, nil
+		}
+	}
+	return Rules{}, ErrRulesVersion
+}
+
+// RulesInForce returns the Rules in force on day
+func RulesInForce(day Date) Rules {
+	rules := nachaRules[0]
+	for _, r := range nachaRules[1:] {
+		if day.Before(r.Effective) {
+			break
+		}
+		rules = r
+	}
+	return rules
+}
+
+// validate returns ErrRulesVersion for unknown versions
+func (v RulesVersion) validate() error {
+	if v == RulesEffectiveDate {
+		return nil
+	}
+	_, err := RulesFor(v)
+	return err
+}
+
+// batchRules returns the Rules of version for a batch with effectiveEntryDate (YYMMDD). Batches without
+// a valid date and unknown versions get RulesCurrent.
+func (v RulesVersion) batchRules(effectiveEntryDate string) Rules {
+	if v == RulesEffectiveDate {
+		if day, err := ParseDate(strings.TrimSpace(effectiveEntryDate)); err == nil {
+			return RulesInForce(day)
+		}
+		v = RulesCurrent
+	}
+	rules, err := RulesFor(v)
+	if err != nil {
+		rules, _ = RulesFor(RulesCurrent)
+	}
+	return rules
+}
+
+// AccountValidator reports if a Receiver's account has been validated, as required for WEB debits by the
+// NACHA rules since Rules2021. The account is validated with a prenote, a micro-entry or a third party.
+type AccountValidator interface {
+	// AccountValidated returns true if the account at the RDFI (routing number with check digit) has been validated
+	AccountValidated(ctx context.Context, rdfi, account string) (bool, error)
+}
+
+// ValidateWEBDebitAccounts checks the accounts of WEB debits in f have been validated according to v, when the
+// Rules of version require it. Every rejected entry is returned as a BatchError wrapping ErrWEBDebitAccountNotValidated.
+// Prenotes aren't checked as they validate the account. Errors from v are returned as soon as they happen.
+//
+// Set ValidateOpts.AccountValidator to have ValidateWith call ValidateWEBDebitAccounts with ValidateOpts.RulesVersion.
+func (f *File) ValidateWEBDebitAccounts(ctx context.Context, v AccountValidator, version RulesVersion) error {
+	var errs base.ErrorList
+	err := f.Visit(FileVisitor{
+		Entry: func(b Batcher, e *EntryDetail) error {
+			bh := b.GetHeader()
+			if bh.StandardEntryClassCode != WEB || e.CreditOrDebit() != "D" || e.IsPrenote() {
+				return nil
+			}
+			if !version.batchRules(bh.EffectiveEntryDate).WEBDebitAccountValidation {
+				return nil
+			}
+			validated, err := v.AccountValidated(ctx, e.RDFIIdentificationField()+e.CheckDigit, strings.TrimSpace(e.DFIAccountNumber))
+			if err != nil {
+				return err
+			}
+			if !validated {
+				errs.Add(b.Error("DFIAccountNumber", ErrWEBDebitAccountNotValidated, e.TraceNumber))
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if errs.Empty() {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,141 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base"
+)
+
+func TestRulesInForce(t *testing.T) {
+	cases := map[Date]RulesVersion{
+		{Year: 2019, Month: time.June, Day: 25}:    Rules2019,
+		{Year: 2020, Month: time.March, Day: 19}:   Rules2019,
+		{Year: 2020, Month: time.March, Day: 20}:   Rules2020,
+		{Year: 2021, Month: time.March, Day: 19}:   Rules2021,
+		{Year: 2022, Month: time.March, Day: 17}:   Rules2021,
+		{Year: 2026, Month: time.October, Day: 16}: Rules2022,
+	}
+	for day, expected := range cases {
+		if r := RulesInForce(day); r.Version != expected {
+			t.Errorf("%s: got %s expected %s", day, r.Version, expected)
+		}
+	}
+
+	if r, err := RulesFor(""); err != nil || r.Version != RulesCurrent || r.SameDayEntryLimit != SameDayEntryLimit {
+		t.Errorf("unexpected rules: %#v (%v)", r, err)
+	}
+	for _, v := range []RulesVersion{"2018", RulesEffectiveDate} {
+		if _, err := RulesFor(v); err != ErrRulesVersion {
+			t.Errorf("%s: unexpected error: %v", v, err)
+		}
+	}
+}
+
+func TestFile__IsEligibleSameDayRulesVersion(t *testing.T) {
+	file := mockFileSameDay()
+	file.Batches[0].GetEntries()[0].Amount = 5000000 // $50,000.00
+
+	// the current limit applies unless another version is selected
+	if err := file.isEligibleSameDay(sameDayTime(10, 30)); err != nil {
+		t.Error(err)
+	}
+	for _, v := range []RulesVersion{Rules2019, RulesEffectiveDate} {
+		file.SetValidation(&ValidateOpts{RulesVersion: v})
+		if err := file.isEligibleSameDay(sameDayTime(10, 30)); !base.Match(firstError(err), ErrSameDayEntryLimit) {
+			t.Errorf("%s: %T: %v", v, err, err)
+		}
+	}
+	file.SetValidation(&ValidateOpts{RulesVersion: Rules2020})
+	if err := file.isEligibleSameDay(sameDayTime(10, 30)); err != nil {
+		t.Error(err)
+	}
+}
+
+// validatedAccounts is an AccountValidator of the accounts it has
+type validatedAccounts map[string]bool
+
+func (v validatedAccounts) AccountValidated(_ context.Context, rdfi, account string) (bool, error) {
+	if rdfi == "" {
+		return false, errors.New("missing RDFI")
+	}
+	return v[rdfi+" "+account], nil
+}
+
+func TestFile__ValidateWEBDebitAccounts(t *testing.T) {
+	ctx := context.Background()
+
+	web := mockBatchWEB()
+	web.GetHeader().EffectiveEntryDate = "210319"
+	entry := web.GetEntries()[0]
+	entry.TransactionCode = CheckingDebit
+	web.GetHeader().ServiceClassCode = DebitsOnly
+	if err := web.Create(); err != nil {
+		t.Fatal(err)
+	}
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(web)
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+	accounts := validatedAccounts{}
+	account := entry.RDFIIdentificationField() + entry.CheckDigit + " " + strings.TrimSpace(entry.DFIAccountNumber)
+
+	err := file.ValidateWithContext(ctx, &ValidateOpts{AccountValidator: accounts})
+	var batchErr *BatchError
+	if !errors.As(firstError(err), &batchErr) || batchErr.FieldName != "DFIAccountNumber" || !base.Match(batchErr, ErrWEBDebitAccountNotValidated) {
+		t.Errorf("%T: %v", err, err)
+	}
+
+	// the 2019 and 2020 rules don't require validation, nor do the rules in force the day before
+	for _, v := range []RulesVersion{Rules2019, Rules2020} {
+		if err := file.ValidateWEBDebitAccounts(ctx, accounts, v); err != nil {
+			t.Errorf("%s: %v", v, err)
+		}
+	}
+	web.GetHeader().EffectiveEntryDate = "210318"
+	if err := file.ValidateWEBDebitAccounts(ctx, accounts, RulesEffectiveDate); err != nil {
+		t.Error(err)
+	}
+	web.GetHeader().EffectiveEntryDate = "210319"
+	if err := file.ValidateWEBDebitAccounts(ctx, accounts, RulesEffectiveDate); !base.Match(firstError(err), ErrWEBDebitAccountNotValidated) {
+		t.Errorf("%T: %v", err, err)
+	}
+
+	// validated accounts and prenotes are accepted
+	accounts[account] = true
+	if err := file.ValidateWithContext(ctx, &ValidateOpts{AccountValidator: accounts}); err != nil {
+		t.Error(err)
+	}
+	delete(accounts, account)
+	entry.TransactionCode = CheckingPrenoteDebit
+	entry.Amount = 0
+	if err := file.ValidateWEBDebitAccounts(ctx, accounts, ""); err != nil {
+		t.Error(err)
+	}
+
+	// unknown versions are rejected
+	if err := file.ValidateWith(&ValidateOpts{RulesVersion: "2018"}); !base.Match(err, ErrRulesVersion) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
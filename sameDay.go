@@ -0,0 +1,142 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moov-io/base"
+)
+
+const (
+	// SameDayEntryLimit is the maximum Amount (in cents) of an entry which is eligible for
+	// Same Day ACH settlement. NACHA currently limits Same Day entries to $1,000,000.00
+	//
+	// Use ValidateOpts.RulesVersion or ValidateOpts.SameDayEntryLimit to check a File against a different limit.
+	SameDayEntryLimit = 100000000
+
+	// SameDaySubmissionDeadline is the time after midnight Eastern (ET) of the last
+	// Same Day ACH submission window offered by the ACH operators.
+	SameDaySubmissionDeadline = 16*time.Hour + 45*time.Minute
+)
+
+var (
+	// ErrSameDayEntryLimit is the error given when an entry exceeds SameDayEntryLimit
+	ErrSameDayEntryLimit = errors.New("amount exceeds the Same Day ACH per entry limit")
+	// ErrSameDaySECCode is the error given when a batch's SEC code can not be settled on the same day
+	ErrSameDaySECCode = errors.New("SEC code is not eligible for Same Day ACH")
+	// ErrSameDayEffectiveEntryDate is the error given when a batch's EffectiveEntryDate is after the current banking day
+	ErrSameDayEffectiveEntryDate = errors.New("effective entry date is not the current banking day")
+	// ErrSameDayWindow is the error given when the current time is outside of every Same Day ACH submission window
+	ErrSameDayWindow = errors.New("outside of the Same Day ACH submission windows")
+)
+
+// SameDayError describes why a batch or entry of a File is not eligible for Same Day ACH settlement.
+// TraceNumber is only set for entry level errors.
+type SameDayError struct {
+	BatchNumber int
+	TraceNumber string
+	Err         error
+}
+
+func (e *SameDayError) Error() string {
+	if e.TraceNumber == "" {
+		return fmt.Sprintf("batch #%d %v", e.BatchNumber, e.Err)
+	}
+	return fmt.Sprintf("batch #%d entry %s %v", e.BatchNumber, e.TraceNumber, e.Err)
+}
+
+// Unwrap implements the base.UnwrappableError interface for SameDayError
+func (e *SameDayError) Unwrap() error {
+	return e.Err
+}
+
+// SameDayEligible returns an error if the EntryDetail can not be settled with Same Day ACH.
+func (ed *EntryDetail) SameDayEligible() error {
+	return ed.sameDayEligible(SameDayEntryLimit)
+}
+
+func (ed *EntryDetail) sameDayEligible(limit int) error {
+	if ed.Amount > limit {
+		return fieldError("Amount", ErrSameDayEntryLimit, ed.Amount)
+	}
+	return nil
+}
+
+// IsEligibleSameDay returns an error if the File can not be submitted for Same Day ACH settlement at the
+// current time. Every ineligible batch and entry is reported in the returned base.ErrorList as a *SameDayError.
+//
+// A File is eligible when it's submitted on a banking day before SameDaySubmissionDeadline, every
+// EffectiveEntryDate is on or before the current banking day, there are no IAT batches and every entry
+// is within the Same Day entry limit of the File's ValidateOpts.RulesVersion, or its ValidateOpts.SameDayEntryLimit
+// when set.
+func (f *File) IsEligibleSameDay() error {
+	return f.isEligibleSameDay(time.Now())
+}
+
+func (f *File) isEligibleSameDay(now time.Time) error {
+	now = now.In(easternLocation())
+
+	var errs base.ErrorList
+	if !IsBankingDay(now) || now.Sub(startOfDay(now)) >= SameDaySubmissionDeadline {
+		errs.Add(ErrSameDayWindow)
+	}
+	var version RulesVersion
+	var limit int
+	if f.validateOpts != nil {
+		version, limit = f.validateOpts.RulesVersion, f.validateOpts.SameDayEntryLimit
+	}
+	today := now.Format("060102") // YYMMDD
+	for _, batch := range f.Batches {
+		bh := batch.GetHeader()
+		if bh.EffectiveEntryDate > today {
+			errs.Add(&SameDayError{BatchNumber: bh.BatchNumber, Err: ErrSameDayEffectiveEntryDate})
+		}
+		limit := limit
+		if limit <= 0 {
+			limit = version.batchRules(bh.EffectiveEntryDate).SameDayEntryLimit
+		}
+		for _, entry := range batch.GetEntries() {
+			if err := entry.sameDayEligible(limit); err != nil {
+				errs.Add(&SameDayError{BatchNumber: bh.BatchNumber, TraceNumber: entry.TraceNumber, Err: err})
+			}
+		}
+	}
+	for _, iatBatch := range f.IATBatches {
+		errs.Add(&SameDayError{BatchNumber: iatBatch.GetHeader().BatchNumber, Err: ErrSameDaySECCode})
+	}
+	if errs.Empty() {
+		return nil
+	}
+	return errs
+}
+
+// easternLocation returns the time zone the Federal Reserve operates ACH submission windows in.
+func easternLocation() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.FixedZone("EST", -5*60*60)
+	}
+	return loc
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
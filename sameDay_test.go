@@ -0,0 +1,138 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base"
+)
+
+// sameDayTime returns a Tuesday (banking day) at the given hour and minute Eastern
+func sameDayTime(hour, min int) time.Time {
+	return time.Date(2019, time.June, 25, hour, min, 0, 0, easternLocation())
+}
+
+// firstError returns the first error of a base.ErrorList
+func firstError(err error) error {
+	if errs, ok := err.(base.ErrorList); ok {
+		return errs.Err()
+	}
+	return err
+}
+
+func mockFileSameDay() *File {
+	file := mockFilePPD()
+	file.Batches[0].GetHeader().EffectiveEntryDate = "190625"
+	return file
+}
+
+func TestEntryDetail__SameDayEligible(t *testing.T) {
+	ed := mockEntryDetail()
+	ed.Amount = SameDayEntryLimit
+	if err := ed.SameDayEligible(); err != nil {
+		t.Fatal(err)
+	}
+	ed.Amount = SameDayEntryLimit + 1
+	if err := ed.SameDayEligible(); !base.Match(err, ErrSameDayEntryLimit) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+func TestFile__IsEligibleSameDay(t *testing.T) {
+	file := mockFileSameDay()
+	if err := file.isEligibleSameDay(sameDayTime(10, 30)); err != nil {
+		t.Fatal(err)
+	}
+	// a past EffectiveEntryDate is settled on the next available window
+	file.Batches[0].GetHeader().EffectiveEntryDate = "190624"
+	if err := file.isEligibleSameDay(sameDayTime(10, 30)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFile__IsEligibleSameDayWindow(t *testing.T) {
+	file := mockFileSameDay()
+	if err := firstError(file.isEligibleSameDay(sameDayTime(16, 45))); !base.Match(err, ErrSameDayWindow) {
+		t.Errorf("%T: %s", err, err)
+	}
+	// Independence Day
+	when := time.Date(2019, time.July, 4, 10, 0, 0, 0, easternLocation())
+	if err := firstError(file.isEligibleSameDay(when)); !base.Match(err, ErrSameDayWindow) {
+		t.Errorf("%T: %s", err, err)
+	}
+	// 20:00 UTC is 16:00 Eastern
+	when = time.Date(2019, time.June, 25, 20, 0, 0, 0, time.UTC)
+	if err := file.isEligibleSameDay(when); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFile__IsEligibleSameDayErrors(t *testing.T) {
+	file := mockFileSameDay()
+	file.Batches[0].GetHeader().EffectiveEntryDate = "190626"
+	file.Batches[0].GetEntries()[0].Amount = SameDayEntryLimit + 1
+
+	err := file.isEligibleSameDay(sameDayTime(10, 30))
+	errs, ok := err.(base.ErrorList)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("%T: %v", err, err)
+	}
+	var sdErr *SameDayError
+	if !errors.As(errs[0], &sdErr) || sdErr.TraceNumber != "" || !base.Match(sdErr, ErrSameDayEffectiveEntryDate) {
+		t.Errorf("%T: %s", errs[0], errs[0])
+	}
+	if !errors.As(errs[1], &sdErr) || sdErr.TraceNumber != file.Batches[0].GetEntries()[0].TraceNumber {
+		t.Errorf("%T: %s", errs[1], errs[1])
+	}
+	if !base.Match(sdErr, ErrSameDayEntryLimit) {
+		t.Errorf("%T: %s", sdErr, sdErr)
+	}
+}
+
+func TestFile__IsEligibleSameDayLimit(t *testing.T) {
+	file := mockFileSameDay()
+	file.Batches[0].GetEntries()[0].Amount = 50000
+
+	file.SetValidation(&ValidateOpts{SameDayEntryLimit: 10000})
+	err := file.isEligibleSameDay(sameDayTime(10, 30))
+	errs, ok := err.(base.ErrorList)
+	if !ok || len(errs) != 1 || !base.Match(errs[0], ErrSameDayEntryLimit) {
+		t.Errorf("%T: %v", err, err)
+	}
+
+	// the default limit is kept for other files
+	other := mockFileSameDay()
+	other.Batches[0].GetEntries()[0].Amount = 50000
+	if err := other.isEligibleSameDay(sameDayTime(10, 30)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFile__IsEligibleSameDayIAT(t *testing.T) {
+	file := mockFileSameDay()
+	iatBatch := mockIATBatch(t)
+	file.AddIATBatch(iatBatch)
+
+	err := firstError(file.isEligibleSameDay(sameDayTime(10, 30)))
+	if !base.Match(err, ErrSameDaySECCode) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
@@ -0,0 +1,98 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package screening
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxResponseSize limits how much of a screening service's response is read
+const maxResponseSize = 1 << 20
+
+// HTTPScreener is a Screener which calls a screening service. Parties are POSTed to URL as
+// {"parties": [...]} and the service responds 200 OK with the parties it blocked as {"matches": [...]},
+// see Party and Match.
+type HTTPScreener struct {
+	URL    string
+	Client *http.Client
+	// Header is added to each request, e.g. with an Authorization header
+	Header http.Header
+}
+
+// NewHTTPScreener returns an HTTPScreener for url which waits up to 10 seconds for a response
+func NewHTTPScreener(url string) *HTTPScreener {
+	return &HTTPScreener{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type httpRequest struct {
+	Parties []Party `json:"parties"`
+}
+
+type httpResponse struct {
+	Matches []Match `json:"matches"`
+}
+
+// Screen sends parties to the screening service and returns a MatchError with the parties it blocked
+func (s *HTTPScreener) Screen(ctx context.Context, parties []Party) error {
+	if len(parties) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(httpRequest{Parties: parties})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range s.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("screening request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("screening request: unexpected status %s", resp.Status)
+	}
+	var result httpResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&result); err != nil {
+		return fmt.Errorf("reading screening response: %v", err)
+	}
+	if len(result.Matches) > 0 {
+		return &MatchError{Matches: result.Matches}
+	}
+	return nil
+}
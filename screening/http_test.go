@@ -0,0 +1,98 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package screening
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPScreener(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req httpRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var resp httpResponse
+		for _, p := range req.Parties {
+			if p.Name == "BLOCKED BANK" {
+				resp.Matches = append(resp.Matches, Match{Party: p, Reason: "SDN"})
+			}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	s := NewHTTPScreener(srv.URL)
+	s.Header = http.Header{"Authorization": []string{"Bearer token"}}
+	ctx := context.Background()
+
+	if err := s.Screen(ctx, []Party{{Role: Receiver, Name: "JOHN DOE"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Screen(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	err := s.Screen(ctx, []Party{
+		{Role: Receiver, Name: "JOHN DOE"},
+		{Role: ForeignCorrespondentBank, Name: "BLOCKED BANK", CountryCode: "ZZ", TraceNumber: "121042880000001"},
+	})
+	var me *MatchError
+	if !errors.As(err, &me) || len(me.Matches) != 1 {
+		t.Fatalf("%T: %v", err, err)
+	}
+	if p := me.Matches[0].Party; p.Role != ForeignCorrespondentBank || p.CountryCode != "ZZ" || p.TraceNumber != "121042880000001" {
+		t.Errorf("unexpected party: %#v", p)
+	}
+
+	// screening failures aren't matches
+	s.Header = nil
+	err = s.Screen(ctx, []Party{{Role: Receiver, Name: "JOHN DOE"}})
+	if err == nil || errors.Is(err, ErrMatch) || !strings.Contains(err.Error(), "401") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestHTTPScreener__errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not json"))
+	}))
+	defer srv.Close()
+
+	parties := []Party{{Role: Receiver, Name: "JOHN DOE"}}
+	if err := NewHTTPScreener(srv.URL).Screen(context.Background(), parties); err == nil || !strings.Contains(err.Error(), "reading screening response") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := NewHTTPScreener(srv.URL).Screen(ctx, parties); err == nil {
+		t.Error("expected error")
+	}
+}
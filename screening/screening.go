@@ -0,0 +1,105 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package screening checks the parties of a payment, such as the receiver of an IAT entry and the foreign
+// correspondent banks it passes through, against sanctions lists like OFAC's before it's sent.
+//
+// The ach package calls a Screener with the parties of each IAT entry when ach.ValidateOpts.IATScreener
+// is set. HTTPScreener calls out to a screening service and NopScreener accepts every party.
+package screening
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrMatch is given when a party matches a sanctions list
+var ErrMatch = errors.New("blocked by sanctions screening")
+
+// Role is the part a Party plays in a payment
+type Role string
+
+const (
+	// Receiver is the person or company receiving a payment
+	Receiver Role = "receiver"
+	// Originator is the person or company sending a payment
+	Originator Role = "originator"
+	// ODFI is the financial institution of the originator
+	ODFI Role = "odfi"
+	// RDFI is the financial institution of the receiver
+	RDFI Role = "rdfi"
+	// ForeignCorrespondentBank is a financial institution an international payment passes through
+	ForeignCorrespondentBank Role = "foreignCorrespondentBank"
+)
+
+// Party is a person, company or financial institution taking part in a payment
+type Party struct {
+	Role Role   `json:"role"`
+	Name string `json:"name"`
+	// IDNumber is the party's identification number, e.g. a bank's routing number or SWIFT BIC
+	IDNumber string `json:"idNumber,omitempty"`
+	// CountryCode is the ISO 3166-1-alpha-2 code of the party's country
+	CountryCode string `json:"countryCode,omitempty"`
+	// TraceNumber identifies the entry the party is taking part in
+	TraceNumber string `json:"traceNumber,omitempty"`
+}
+
+// Screener checks the parties of a payment against sanctions lists
+type Screener interface {
+	// Screen returns a MatchError when any of parties are blocked, or another error if they
+	// couldn't be screened
+	Screen(ctx context.Context, parties []Party) error
+}
+
+// NopScreener is a Screener which accepts every party
+type NopScreener struct{}
+
+// Screen returns nil
+func (NopScreener) Screen(_ context.Context, _ []Party) error {
+	return nil
+}
+
+// Match is a party which matched a sanctions list
+type Match struct {
+	Party Party `json:"party"`
+	// Reason describes what the party matched, e.g. the sanctions list and entry
+	Reason string `json:"reason,omitempty"`
+}
+
+// MatchError is returned by a Screener with the parties it blocked, it wraps ErrMatch
+type MatchError struct {
+	Matches []Match
+}
+
+func (e *MatchError) Error() string {
+	var names []string
+	for _, m := range e.Matches {
+		name := fmt.Sprintf("%s %s", m.Party.Role, m.Party.Name)
+		if m.Reason != "" {
+			name += " (" + m.Reason + ")"
+		}
+		names = append(names, name)
+	}
+	return fmt.Sprintf("%v: %s", ErrMatch, strings.Join(names, ", "))
+}
+
+// Unwrap returns ErrMatch
+func (e *MatchError) Unwrap() error {
+	return ErrMatch
+}
@@ -0,0 +1,45 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package screening
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNopScreener(t *testing.T) {
+	var s Screener = NopScreener{}
+	if err := s.Screen(context.Background(), []Party{{Role: Receiver, Name: "JOHN DOE"}}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMatchError(t *testing.T) {
+	err := error(&MatchError{Matches: []Match{
+		{Party: Party{Role: Receiver, Name: "JOHN DOE"}, Reason: "SDN 1234"},
+		{Party: Party{Role: ForeignCorrespondentBank, Name: "BANK ONE"}},
+	}})
+	if !errors.Is(err, ErrMatch) {
+		t.Errorf("expected ErrMatch: %v", err)
+	}
+	want := "blocked by sanctions screening: receiver JOHN DOE (SDN 1234), foreignCorrespondentBank BANK ONE"
+	if err.Error() != want {
+		t.Errorf("got %q", err.Error())
+	}
+}
@@ -0,0 +1,158 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/moov-io/ach"
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+)
+
+// acknowledgedSECCodes are the StandardEntryClassCodes of the entries acknowledged by ACK and ATX entries
+var acknowledgedSECCodes = map[string]string{
+	ach.ACK: ach.CCD,
+	ach.ATX: ach.CTX,
+}
+
+// Acknowledgment pairs an originated CCD or CTX credit with the ACK or ATX entries acknowledging it
+type Acknowledgment struct {
+	Original        *EntryMatch   `json:"original"`
+	Acknowledgments []*EntryMatch `json:"acknowledgments"`
+}
+
+// AcknowledgmentReport is the result of MatchAcknowledgments
+type AcknowledgmentReport struct {
+	// Matched are the originated entries which were acknowledged
+	Matched []*Acknowledgment `json:"matched"`
+	// Unmatched are the originated entries which haven't been acknowledged yet
+	Unmatched []*EntryMatch `json:"unmatched"`
+	// Unknown are the acknowledgments whose original entry isn't stored
+	Unknown []*EntryMatch `json:"unknown"`
+}
+
+type acknowledgedKey struct {
+	secCode     string
+	traceNumber string
+}
+
+// MatchAcknowledgments pairs the ACK and ATX entries of stored files with the CCD and CTX credits they acknowledge
+// by the OriginalTraceNumber of the acknowledgment. Prenotes aren't acknowledged and are left out.
+func (s *service) MatchAcknowledgments(ctx context.Context) *AcknowledgmentReport {
+	files := s.store.FindAllFiles(ctx)
+	sort.Slice(files, func(i, j int) bool { return files[i].ID < files[j].ID })
+
+	var originals []*Acknowledgment
+	var acks []*EntryMatch
+	var acked []acknowledgedKey
+	index := make(map[acknowledgedKey][]*Acknowledgment)
+	for _, f := range files {
+		f.Visit(ach.FileVisitor{
+			Entry: func(batch ach.Batcher, entry *ach.EntryDetail) error {
+				bh := batch.GetHeader()
+				match := &EntryMatch{
+					FileID:      f.ID,
+					BatchID:     batch.ID(),
+					BatchNumber: bh.BatchNumber,
+					Entry:       entry,
+				}
+				switch bh.StandardEntryClassCode {
+				case ach.ACK, ach.ATX:
+					acks = append(acks, match)
+					acked = append(acked, acknowledgedKey{
+						secCode:     acknowledgedSECCodes[bh.StandardEntryClassCode],
+						traceNumber: strings.TrimSpace(entry.OriginalTraceNumberField()),
+					})
+				case ach.CCD, ach.CTX:
+					if entry.CreditOrDebit() != "C" || entry.IsPrenote() {
+						return nil
+					}
+					original := &Acknowledgment{Original: match}
+					originals = append(originals, original)
+					key := acknowledgedKey{secCode: bh.StandardEntryClassCode, traceNumber: strings.TrimSpace(entry.TraceNumber)}
+					index[key] = append(index[key], original)
+				}
+				return nil
+			},
+		})
+	}
+
+	report := &AcknowledgmentReport{}
+	for i, ack := range acks {
+		found := index[acked[i]]
+		if len(found) == 0 {
+			report.Unknown = append(report.Unknown, ack)
+		}
+		// trace numbers are reused after a while, so every original with the trace number is acknowledged
+		for _, original := range found {
+			original.Acknowledgments = append(original.Acknowledgments, ack)
+		}
+	}
+	for _, original := range originals {
+		if len(original.Acknowledgments) > 0 {
+			report.Matched = append(report.Matched, original)
+		} else {
+			report.Unmatched = append(report.Unmatched, original.Original)
+		}
+	}
+	return report
+}
+
+type matchAcknowledgmentsRequest struct {
+	requestID string
+}
+
+type matchAcknowledgmentsResponse struct {
+	*AcknowledgmentReport
+	Err error `json:"error"`
+}
+
+func (r matchAcknowledgmentsResponse) error() error { return r.Err }
+
+func matchAcknowledgmentsEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(matchAcknowledgmentsRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return matchAcknowledgmentsResponse{
+				Err: err,
+			}, err
+		}
+
+		report := s.MatchAcknowledgments(ctx)
+
+		logEvent(logger, "files", "matchAcknowledgments", nil, "requestID", req.requestID, "matched", len(report.Matched), "unmatched", len(report.Unmatched), "unknown", len(report.Unknown))
+
+		return matchAcknowledgmentsResponse{
+			AcknowledgmentReport: report,
+		}, nil
+	}
+}
+
+func decodeMatchAcknowledgmentsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return matchAcknowledgmentsRequest{
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
@@ -0,0 +1,131 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+)
+
+func mockAcknowledgmentBatch(t *testing.T, secCode string, entries ...*ach.EntryDetail) ach.Batcher {
+	t.Helper()
+
+	bh := ach.NewBatchHeader()
+	bh.ServiceClassCode = ach.CreditsOnly
+	bh.StandardEntryClassCode = secCode
+	bh.CompanyName = "Your Company, inc"
+	bh.CompanyIdentification = "121042882"
+	bh.CompanyEntryDescription = "Vndr Pay"
+	bh.ODFIIdentification = "12104288"
+	batch, err := ach.NewBatch(bh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		batch.AddEntry(entry)
+	}
+	return batch
+}
+
+func mockAcknowledgedEntry(transactionCode int, traceNumber string) *ach.EntryDetail {
+	entry := ach.NewEntryDetail()
+	entry.TransactionCode = transactionCode
+	entry.SetRDFI("231380104")
+	entry.DFIAccountNumber = "744-5678-99"
+	entry.Amount = 5000000
+	entry.SetReceivingCompany("Best Co. #23")
+	entry.TraceNumber = traceNumber
+	return entry
+}
+
+func mockAcknowledgmentEntry(originalTraceNumber string) *ach.EntryDetail {
+	entry := mockAcknowledgedEntry(ach.CheckingZeroDollarRemittanceCredit, "231380100000001")
+	entry.Amount = 0
+	entry.SetOriginalTraceNumber(originalTraceNumber)
+	return entry
+}
+
+func TestMatchAcknowledgments(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+
+	originals := ach.NewFile()
+	originals.ID = "originals"
+	originals.SetHeader(*mockFileHeader())
+	originals.AddBatch(mockAcknowledgmentBatch(t, ach.CCD,
+		mockAcknowledgedEntry(ach.CheckingCredit, "121042880000001"),
+		mockAcknowledgedEntry(ach.CheckingCredit, "121042880000002"),
+		mockAcknowledgedEntry(ach.CheckingDebit, "121042880000003"),
+		mockAcknowledgedEntry(ach.CheckingPrenoteCredit, "121042880000004"),
+	))
+	originals.AddBatch(mockAcknowledgmentBatch(t, ach.CTX, mockAcknowledgedEntry(ach.CheckingCredit, "121042880000005")))
+	if err := repo.StoreFile(ctx, originals); err != nil {
+		t.Fatal(err)
+	}
+
+	acks := ach.NewFile()
+	acks.ID = "acks"
+	acks.SetHeader(*mockFileHeader())
+	acks.AddBatch(mockAcknowledgmentBatch(t, ach.ACK,
+		mockAcknowledgmentEntry("121042880000001"),
+		mockAcknowledgmentEntry("121042880000009"),
+		mockAcknowledgmentEntry("121042880000005"), // a CTX entry is acknowledged with ATX
+	))
+	acks.AddBatch(mockAcknowledgmentBatch(t, ach.ATX, mockAcknowledgmentEntry("121042880000005")))
+	if err := repo.StoreFile(ctx, acks); err != nil {
+		t.Fatal(err)
+	}
+
+	report := svc.MatchAcknowledgments(ctx)
+	if len(report.Matched) != 2 {
+		t.Fatalf("unexpected matches: %#v", report.Matched)
+	}
+	for i, trace := range []string{"121042880000001", "121042880000005"} {
+		m := report.Matched[i]
+		if m.Original.FileID != "originals" || m.Original.Entry.TraceNumber != trace || len(m.Acknowledgments) != 1 || m.Acknowledgments[0].FileID != "acks" {
+			t.Errorf("#%d unexpected match: %#v", i, m)
+		}
+	}
+	if len(report.Unmatched) != 1 || report.Unmatched[0].Entry.TraceNumber != "121042880000002" {
+		t.Errorf("unexpected unmatched: %#v", report.Unmatched)
+	}
+	if len(report.Unknown) != 2 || report.Unknown[0].Entry.OriginalTraceNumberField() != "121042880000009" {
+		t.Errorf("unexpected unknown: %#v", report.Unknown)
+	}
+
+	router := MakeHTTPHandler(svc, repo, logger)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/files/acknowledgments", nil))
+	w.Flush()
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var resp AcknowledgmentReport
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || len(resp.Matched) != 2 || len(resp.Unmatched) != 1 || len(resp.Unknown) != 2 {
+		t.Errorf("unexpected response: %v: %#v", err, resp)
+	}
+}
@@ -0,0 +1,253 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/moov-io/ach"
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+)
+
+var (
+	errInvalidEntrySequence = invalid(errors.New("invalid entry sequence number"))
+)
+
+// decodeEntryPath reads the fileID, batchID and seq path variables shared by the addenda routes
+func decodeEntryPath(r *http.Request) (fileID string, batchID string, seq int, err error) {
+	vars := mux.Vars(r)
+	fileID, ok := vars["fileID"]
+	if !ok {
+		return "", "", 0, ErrBadRouting
+	}
+	batchID, ok = vars["batchID"]
+	if !ok {
+		return "", "", 0, ErrBadRouting
+	}
+	v, ok := vars["seq"]
+	if !ok {
+		return "", "", 0, ErrBadRouting
+	}
+	seq, err = strconv.Atoi(v)
+	if err != nil {
+		return "", "", 0, errInvalidEntrySequence
+	}
+	return fileID, batchID, seq, nil
+}
+
+type createAddendaRequest struct {
+	fileID  string
+	batchID string
+	seq     int
+
+	addenda05 *ach.Addenda05
+	opts      []ChangeOption
+
+	requestID string
+}
+
+type createAddendaResponse struct {
+	ID  string `json:"id"`
+	Err error  `json:"error"`
+}
+
+func (r createAddendaResponse) error() error { return r.Err }
+
+func createAddendaEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(createAddendaRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return createAddendaResponse{
+				Err: err,
+			}, err
+		}
+
+		id, err := s.CreateAddenda05(ctx, req.fileID, req.batchID, req.seq, req.addenda05, req.opts...)
+
+		logEvent(logger, "addenda", "createAddenda", err, "requestID", req.requestID, "fileID", req.fileID, "batchID", req.batchID)
+
+		return createAddendaResponse{
+			ID:  id,
+			Err: err,
+		}, nil
+	}
+}
+
+func decodeCreateAddendaRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req createAddendaRequest
+	req.requestID = moovhttp.GetRequestID(r)
+
+	fileID, batchID, seq, err := decodeEntryPath(r)
+	if err != nil {
+		return nil, err
+	}
+	req.fileID, req.batchID, req.seq = fileID, batchID, seq
+	if req.opts, err = parseIfMatch(r); err != nil {
+		return nil, err
+	}
+
+	req.addenda05 = ach.NewAddenda05()
+	if err := json.NewDecoder(r.Body).Decode(req.addenda05); err != nil {
+		return nil, invalid(err)
+	}
+	return req, nil
+}
+
+type getAddendasRequest struct {
+	fileID  string
+	batchID string
+	seq     int
+	masked  bool
+
+	requestID string
+}
+
+type getAddendasResponse struct {
+	Addenda02 *ach.Addenda02   `json:"addenda02,omitempty"`
+	Addenda05 []*ach.Addenda05 `json:"addenda05"`
+	Addenda98 *ach.Addenda98   `json:"addenda98,omitempty"`
+	Addenda99 *ach.Addenda99   `json:"addenda99,omitempty"`
+
+	Addenda99Dishonored *ach.Addenda99Dishonored `json:"addenda99Dishonored,omitempty"`
+	Addenda99Contested  *ach.Addenda99Contested  `json:"addenda99Contested,omitempty"`
+
+	Err error `json:"error"`
+}
+
+func (r getAddendasResponse) count() int { return len(r.Addenda05) }
+
+func (r getAddendasResponse) error() error { return r.Err }
+
+func getAddendasEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(getAddendasRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return getAddendasResponse{
+				Err: err,
+			}, err
+		}
+
+		var entry *ach.EntryDetail
+		var err error
+		if req.masked {
+			var batch ach.Batcher
+			if batch, err = getMaskedBatch(ctx, s, req.fileID, req.batchID); err == nil {
+				_, entry, err = batchEntry(batch, req.seq)
+			}
+		} else {
+			entry, err = s.GetEntry(ctx, req.fileID, req.batchID, req.seq)
+		}
+
+		logEvent(logger, "addenda", "getAddendas", err, "requestID", req.requestID, "fileID", req.fileID, "batchID", req.batchID)
+		if err != nil {
+			return getAddendasResponse{Err: err}, nil
+		}
+
+		return getAddendasResponse{
+			Addenda02: entry.Addenda02,
+			Addenda05: entry.Addenda05,
+			Addenda98: entry.Addenda98,
+			Addenda99: entry.Addenda99,
+
+			Addenda99Dishonored: entry.Addenda99Dishonored,
+			Addenda99Contested:  entry.Addenda99Contested,
+		}, nil
+	}
+}
+
+func decodeGetAddendasRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req getAddendasRequest
+	req.requestID = moovhttp.GetRequestID(r)
+
+	fileID, batchID, seq, err := decodeEntryPath(r)
+	if err != nil {
+		return nil, err
+	}
+	req.fileID, req.batchID, req.seq = fileID, batchID, seq
+	if req.masked, err = parseMasked(r); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+type deleteAddendaRequest struct {
+	fileID    string
+	batchID   string
+	seq       int
+	addendaID string
+	opts      []ChangeOption
+
+	requestID string
+}
+
+type deleteAddendaResponse struct {
+	Err error `json:"error"`
+}
+
+func (r deleteAddendaResponse) error() error { return r.Err }
+
+func deleteAddendaEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(deleteAddendaRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return deleteAddendaResponse{
+				Err: err,
+			}, err
+		}
+
+		err := s.DeleteAddenda05(ctx, req.fileID, req.batchID, req.seq, req.addendaID, req.opts...)
+
+		logEvent(logger, "addenda", "deleteAddenda", err, "requestID", req.requestID, "fileID", req.fileID, "batchID", req.batchID)
+
+		return deleteAddendaResponse{
+			Err: err,
+		}, nil
+	}
+}
+
+func decodeDeleteAddendaRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req deleteAddendaRequest
+	req.requestID = moovhttp.GetRequestID(r)
+
+	fileID, batchID, seq, err := decodeEntryPath(r)
+	if err != nil {
+		return nil, err
+	}
+	req.fileID, req.batchID, req.seq = fileID, batchID, seq
+	if req.opts, err = parseIfMatch(r); err != nil {
+		return nil, err
+	}
+
+	addendaID, ok := mux.Vars(r)["addendaID"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	req.addendaID = addendaID
+	return req, nil
+}
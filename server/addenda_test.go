@@ -0,0 +1,190 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+)
+
+func storeAddendaTestFile(t *testing.T, repo Repository) *ach.File {
+	t.Helper()
+	ctx := context.Background()
+
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	bs, _ := ioutil.ReadAll(fd)
+	file, err := ach.FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Batches[0].SetID("batch-01")
+	if err := repo.StoreFile(ctx, file); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestAddenda__createAndDelete(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	file := storeAddendaTestFile(t, repo)
+	storedEntry := func() *ach.EntryDetail {
+		f, err := repo.FindFile(ctx, file.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f.Batches[0].GetEntries()[0]
+	}
+	path := fmt.Sprintf("/files/%s/batches/%s/entries/1/addenda", file.ID, file.Batches[0].ID())
+
+	// add an Addenda05
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", path, strings.NewReader(`{"paymentRelatedInformation": "Invoice #1234"}`))
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var created createAddendaResponse
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil || created.ID == "" {
+		t.Fatalf("created=%#v error=%v", created, err)
+	}
+	entry := storedEntry()
+	if len(entry.Addenda05) != 1 || entry.AddendaRecordIndicator != 1 {
+		t.Fatalf("Addenda05=%d AddendaRecordIndicator=%d", len(entry.Addenda05), entry.AddendaRecordIndicator)
+	}
+	if a := entry.Addenda05[0]; a.SequenceNumber != 1 || a.EntryDetailSequenceNumber != 1 || a.TypeCode != "05" {
+		t.Errorf("unexpected Addenda05: %#v", a)
+	}
+	if versions, _ := repo.FindVersions(ctx, file.ID); len(versions) != 1 {
+		t.Errorf("expected a version to be saved: %d", len(versions))
+	}
+
+	// PPD entries only allow one Addenda05
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", path, strings.NewReader(`{"paymentRelatedInformation": "Invoice #5678"}`))
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if entry := storedEntry(); len(entry.Addenda05) != 1 {
+		t.Errorf("rejected Addenda05 was kept: %d", len(entry.Addenda05))
+	}
+	if versions, _ := repo.FindVersions(ctx, file.ID); len(versions) != 1 {
+		t.Errorf("expected no version for a rejected Addenda05: %d", len(versions))
+	}
+
+	// list addenda
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", path, nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var listed struct {
+		Addenda05 []*ach.Addenda05 `json:"addenda05"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&listed); err != nil {
+		t.Fatal(err)
+	}
+	if len(listed.Addenda05) != 1 || listed.Addenda05[0].ID != created.ID {
+		t.Errorf("unexpected addenda: %#v", listed.Addenda05)
+	}
+
+	// delete the Addenda05
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", path+"/"+created.ID, nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if entry := storedEntry(); len(entry.Addenda05) != 0 || entry.AddendaRecordIndicator != 0 {
+		t.Errorf("Addenda05=%d AddendaRecordIndicator=%d", len(entry.Addenda05), entry.AddendaRecordIndicator)
+	}
+
+	// delete again
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAddenda__notFound(t *testing.T) {
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	file := storeAddendaTestFile(t, repo)
+
+	paths := []string{
+		"/files/missing/batches/foo/entries/1/addenda",
+		fmt.Sprintf("/files/%s/batches/missing/entries/1/addenda", file.ID),
+		fmt.Sprintf("/files/%s/batches/%s/entries/2/addenda", file.ID, file.Batches[0].ID()),
+	}
+	for i := range paths {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", paths[i], nil)
+		router.ServeHTTP(w, req)
+		w.Flush()
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: bogus HTTP status: %d", paths[i], w.Code)
+		}
+	}
+
+	// invalid sequence number
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", fmt.Sprintf("/files/%s/batches/%s/entries/abc/addenda", file.ID, file.Batches[0].ID()), nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code == http.StatusOK {
+		t.Errorf("bogus HTTP status: %d", w.Code)
+	}
+}
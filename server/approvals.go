@@ -0,0 +1,416 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+)
+
+// ApprovalState is where a file is in the dual-approval workflow, see WithApprovals
+type ApprovalState string
+
+const (
+	// ApprovalDraft files are being prepared, including those which were rejected or changed after being submitted
+	ApprovalDraft ApprovalState = "draft"
+	// ApprovalPending files are waiting for someone other than who submitted them to approve or reject them
+	ApprovalPending ApprovalState = "pending_approval"
+	// ApprovalApproved files can be read as plaintext contents and released
+	ApprovalApproved ApprovalState = "approved"
+	// ApprovalReleased files were sent on to the ODFI
+	ApprovalReleased ApprovalState = "released"
+)
+
+// Approval is the state of a file in the dual-approval workflow and who moved it there
+type Approval struct {
+	FileID string        `json:"fileID"`
+	State  ApprovalState `json:"state"`
+	// Digest is the hex encoded SHA-256 of the contents submitted. A file whose contents change after
+	// it's submitted, including by building it, is a draft again.
+	Digest string `json:"digest,omitempty"`
+
+	SubmittedBy string     `json:"submittedBy,omitempty"`
+	Submitted   *time.Time `json:"submitted,omitempty"`
+	// ReviewedBy approved or rejected the file, and is never who submitted it
+	ReviewedBy string     `json:"reviewedBy,omitempty"`
+	Reviewed   *time.Time `json:"reviewed,omitempty"`
+	// Reason is why the file was rejected
+	Reason     string     `json:"reason,omitempty"`
+	ReleasedBy string     `json:"releasedBy,omitempty"`
+	Released   *time.Time `json:"released,omitempty"`
+}
+
+// ApprovalRepository stores the Approvals of a Service, see WithApprovals
+type ApprovalRepository interface {
+	// StoreApproval stores approval over any earlier Approval of its file
+	StoreApproval(ctx context.Context, approval *Approval) error
+	// FindApproval returns ErrNotFound for files which were never submitted
+	FindApproval(ctx context.Context, fileID string) (*Approval, error)
+	DeleteApproval(ctx context.Context, fileID string) error
+}
+
+type approvalRepositoryInMemory struct {
+	mtx       sync.RWMutex
+	approvals map[string]*Approval
+}
+
+// NewApprovalRepositoryInMemory is an in memory storage repository for approvals
+func NewApprovalRepositoryInMemory() ApprovalRepository {
+	return &approvalRepositoryInMemory{
+		approvals: make(map[string]*Approval),
+	}
+}
+
+func (r *approvalRepositoryInMemory) StoreApproval(ctx context.Context, approval *Approval) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err // the change was canceled while waiting for the lock
+	}
+	copied := *approval
+	r.approvals[approval.FileID] = &copied
+	return nil
+}
+
+func (r *approvalRepositoryInMemory) FindApproval(ctx context.Context, fileID string) (*Approval, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	approval, ok := r.approvals[fileID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *approval
+	return &copied, nil
+}
+
+func (r *approvalRepositoryInMemory) DeleteApproval(ctx context.Context, fileID string) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	delete(r.approvals, fileID)
+	return nil
+}
+
+// approvals are the Approvals of a Service, see WithApprovals
+type approvals struct {
+	repo ApprovalRepository
+
+	// mu is held while a file moves between states so two callers can't both move it
+	mu sync.Mutex
+}
+
+// WithApprovals has the Service require two people to release each file: one submits it and another approves
+// or rejects it. The plaintext contents of files which aren't approved can't be read, so they can't be sent on.
+// Who is acting is the principal callers authenticated as, see WithAuth and WithPrincipal. Files can't be submitted,
+// approved, rejected or released by callers who aren't authenticated.
+func WithApprovals(repo ApprovalRepository) ServiceOption {
+	return func(s *service) {
+		s.approvals = &approvals{repo: repo}
+	}
+}
+
+// errApprovalsDisabled is returned by the approval methods of a Service created without WithApprovals
+var errApprovalsDisabled = fmt.Errorf("approvals aren't enabled: %w", ErrNotFound)
+
+// contentsDigest returns the hex encoded SHA-256 of contents, which are left to be read again
+func contentsDigest(contents *contentsReader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, contents); err != nil {
+		return "", err
+	}
+	if _, err := contents.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// fileDigest renders a file and returns the contentsDigest of it
+func (s *service) fileDigest(ctx context.Context, fileID string) (string, error) {
+	r, err := s.renderContents(ctx, fileID)
+	if err != nil {
+		return "", err
+	}
+	contents, err := readContents(r)
+	if err != nil {
+		return "", err
+	}
+	return contentsDigest(contents)
+}
+
+// currentApproval returns the Approval of a file, which is a draft when it was never submitted or has
+// changed since it was
+func (s *service) currentApproval(ctx context.Context, fileID string) (*Approval, error) {
+	if _, err := s.store.FindFile(ctx, fileID); err != nil {
+		return nil, err
+	}
+	approval, err := s.approvals.repo.FindApproval(ctx, fileID)
+	if errors.Is(err, ErrNotFound) {
+		return &Approval{FileID: fileID, State: ApprovalDraft}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if approval.State != ApprovalDraft {
+		if digest, err := s.fileDigest(ctx, fileID); err != nil || digest != approval.Digest {
+			return &Approval{FileID: fileID, State: ApprovalDraft}, nil
+		}
+	}
+	return approval, nil
+}
+
+// checkApproved returns a forbidden error unless contents are those of an approved or released file
+func (s *service) checkApproved(ctx context.Context, fileID string, contents *contentsReader) error {
+	digest, err := contentsDigest(contents)
+	if err != nil {
+		return err
+	}
+	approval, err := s.approvals.repo.FindApproval(ctx, fileID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if approval != nil && approval.Digest == digest {
+		switch approval.State {
+		case ApprovalApproved, ApprovalReleased:
+			return nil
+		}
+	}
+	return forbidden(fmt.Errorf("file %s isn't approved", fileID))
+}
+
+// changeApproval moves a file from one of the states in from with change, which is given the
+// current Approval and the principal acting
+func (s *service) changeApproval(ctx context.Context, fileID string, change func(a *Approval, actor string, now time.Time) error, from ...ApprovalState) (*Approval, error) {
+	if s.approvals == nil {
+		return nil, errApprovalsDisabled
+	}
+	actor := principalFrom(ctx)
+	if actor == "" {
+		return nil, unauthorized(errors.New("approvals require an authenticated caller"))
+	}
+
+	s.approvals.mu.Lock()
+	defer s.approvals.mu.Unlock()
+
+	approval, err := s.currentApproval(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	ok := false
+	for i := range from {
+		ok = ok || approval.State == from[i]
+	}
+	if !ok {
+		return nil, conflict(fmt.Errorf("file %s is %s", fileID, approval.State))
+	}
+	if err := change(approval, actor, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.approvals.repo.StoreApproval(ctx, approval); err != nil {
+		return nil, err
+	}
+	return approval, nil
+}
+
+// review checks actor didn't submit the file they're approving or rejecting
+func review(approval *Approval, actor string, now time.Time) error {
+	if actor == approval.SubmittedBy {
+		return forbidden(fmt.Errorf("file %s was submitted by %s, who can't also review it", approval.FileID, actor))
+	}
+	approval.ReviewedBy = actor
+	approval.Reviewed = &now
+	return nil
+}
+
+// GetApproval returns where a file is in the dual-approval workflow
+func (s *service) GetApproval(ctx context.Context, fileID string) (_ *Approval, err error) {
+	ctx, span := startSpan(ctx, "Service.GetApproval")
+	span.SetAttribute("file.id", fileID)
+	defer endSpan(span, &err)
+
+	if s.approvals == nil {
+		return nil, errApprovalsDisabled
+	}
+	return s.currentApproval(ctx, fileID)
+}
+
+// SubmitFile asks for a draft file to be approved
+func (s *service) SubmitFile(ctx context.Context, fileID string) (_ *Approval, err error) {
+	ctx, span := startSpan(ctx, "Service.SubmitFile")
+	span.SetAttribute("file.id", fileID)
+	defer endSpan(span, &err)
+
+	return s.changeApproval(ctx, fileID, func(a *Approval, actor string, now time.Time) error {
+		digest, err := s.fileDigest(ctx, fileID)
+		if err != nil {
+			return invalid(err)
+		}
+		*a = Approval{
+			FileID:      fileID,
+			State:       ApprovalPending,
+			Digest:      digest,
+			SubmittedBy: actor,
+			Submitted:   &now,
+		}
+		return nil
+	}, ApprovalDraft)
+}
+
+// ApproveFile approves a file submitted by someone else
+func (s *service) ApproveFile(ctx context.Context, fileID string) (_ *Approval, err error) {
+	ctx, span := startSpan(ctx, "Service.ApproveFile")
+	span.SetAttribute("file.id", fileID)
+	defer endSpan(span, &err)
+
+	return s.changeApproval(ctx, fileID, func(a *Approval, actor string, now time.Time) error {
+		if err := review(a, actor, now); err != nil {
+			return err
+		}
+		a.State = ApprovalApproved
+		return nil
+	}, ApprovalPending)
+}
+
+// RejectFile returns a file submitted by someone else, or approved, to draft
+func (s *service) RejectFile(ctx context.Context, fileID string, reason string) (_ *Approval, err error) {
+	ctx, span := startSpan(ctx, "Service.RejectFile")
+	span.SetAttribute("file.id", fileID)
+	defer endSpan(span, &err)
+
+	return s.changeApproval(ctx, fileID, func(a *Approval, actor string, now time.Time) error {
+		if err := review(a, actor, now); err != nil {
+			return err
+		}
+		a.State = ApprovalDraft
+		a.Reason = reason
+		return nil
+	}, ApprovalPending, ApprovalApproved)
+}
+
+// ReleaseFile records an approved file was sent on to the ODFI
+func (s *service) ReleaseFile(ctx context.Context, fileID string) (_ *Approval, err error) {
+	ctx, span := startSpan(ctx, "Service.ReleaseFile")
+	span.SetAttribute("file.id", fileID)
+	defer endSpan(span, &err)
+
+	return s.changeApproval(ctx, fileID, func(a *Approval, actor string, now time.Time) error {
+		a.State = ApprovalReleased
+		a.ReleasedBy = actor
+		a.Released = &now
+		return nil
+	}, ApprovalApproved)
+}
+
+type approvalRequest struct {
+	ID        string
+	reason    string
+	requestID string
+}
+
+type approvalResponse struct {
+	*Approval
+	Err error `json:"error"`
+}
+
+func (r approvalResponse) error() error { return r.Err }
+
+// approvalEndpoint serves one of the approval methods of a Service, logged as op
+func approvalEndpoint(logger log.Logger, op string, call func(ctx context.Context, req approvalRequest) (*Approval, error)) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(approvalRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return approvalResponse{
+				Err: err,
+			}, err
+		}
+
+		approval, err := call(ctx, req)
+
+		logEvent(logger, "files", op, err, "requestID", req.requestID, "fileID", req.ID)
+
+		return approvalResponse{
+			Approval: approval,
+			Err:      err,
+		}, nil
+	}
+}
+
+func getApprovalEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return approvalEndpoint(logger, "getApproval", func(ctx context.Context, req approvalRequest) (*Approval, error) {
+		return s.GetApproval(ctx, req.ID)
+	})
+}
+
+func submitFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return approvalEndpoint(logger, "submitFile", func(ctx context.Context, req approvalRequest) (*Approval, error) {
+		return s.SubmitFile(ctx, req.ID)
+	})
+}
+
+func approveFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return approvalEndpoint(logger, "approveFile", func(ctx context.Context, req approvalRequest) (*Approval, error) {
+		return s.ApproveFile(ctx, req.ID)
+	})
+}
+
+func rejectFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return approvalEndpoint(logger, "rejectFile", func(ctx context.Context, req approvalRequest) (*Approval, error) {
+		return s.RejectFile(ctx, req.ID, req.reason)
+	})
+}
+
+func releaseFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return approvalEndpoint(logger, "releaseFile", func(ctx context.Context, req approvalRequest) (*Approval, error) {
+		return s.ReleaseFile(ctx, req.ID)
+	})
+}
+
+// decodeApprovalRequest reads the optional JSON body {"reason": "..."} of rejections
+func decodeApprovalRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+			return nil, invalid(fmt.Errorf("problem reading request: %v", err))
+		}
+	}
+	return approvalRequest{
+		ID:        id,
+		reason:    body.Reason,
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
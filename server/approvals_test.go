@@ -0,0 +1,228 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestApprovals(t *testing.T) {
+	ctx := context.Background()
+	alice, bob := WithPrincipal(ctx, "alice"), WithPrincipal(ctx, "bob")
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo, WithApprovals(NewApprovalRepositoryInMemory()))
+
+	f := readPPDValidFile(t)
+	if err := repo.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+	if a, err := svc.GetApproval(ctx, f.ID); err != nil || a.State != ApprovalDraft {
+		t.Fatalf("approval=%#v error=%v", a, err)
+	}
+	if _, err := svc.GetFileContents(ctx, f.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// someone other than who submitted a file approves it
+	if _, err := svc.SubmitFile(ctx, f.ID); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected error without a principal: %v", err)
+	}
+	if a, err := svc.SubmitFile(alice, f.ID); err != nil || a.State != ApprovalPending || a.SubmittedBy != "alice" || a.Digest == "" {
+		t.Fatalf("approval=%#v error=%v", a, err)
+	}
+	if _, err := svc.ApproveFile(alice, f.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := svc.ReleaseFile(bob, f.ID); !errors.Is(err, ErrConflict) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if a, err := svc.ApproveFile(bob, f.ID); err != nil || a.State != ApprovalApproved || a.ReviewedBy != "bob" {
+		t.Fatalf("approval=%#v error=%v", a, err)
+	}
+	if _, err := svc.GetFileContents(ctx, f.ID); err != nil {
+		t.Error(err)
+	}
+
+	// changing an approved file makes it a draft
+	if _, err := repo.UpdateFile(ctx, f.ID, func(f *ach.File) error {
+		f.Header.ImmediateOriginName = "Other Bank"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if a, _ := svc.GetApproval(ctx, f.ID); a.State != ApprovalDraft {
+		t.Errorf("State=%s", a.State)
+	}
+	if _, err := svc.GetFileContents(ctx, f.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// rejected files are drafts
+	if _, err := svc.SubmitFile(alice, f.ID); err != nil {
+		t.Fatal(err)
+	}
+	if a, err := svc.RejectFile(bob, f.ID, "wrong origin"); err != nil || a.State != ApprovalDraft || a.Reason != "wrong origin" {
+		t.Fatalf("approval=%#v error=%v", a, err)
+	}
+
+	if _, err := svc.SubmitFile(bob, f.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ApproveFile(alice, f.ID); err != nil {
+		t.Fatal(err)
+	}
+	if a, err := svc.ReleaseFile(alice, f.ID); err != nil || a.State != ApprovalReleased || a.ReleasedBy != "alice" {
+		t.Fatalf("approval=%#v error=%v", a, err)
+	}
+	if _, err := svc.ReleaseFile(alice, f.ID); !errors.Is(err, ErrConflict) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := svc.GetFileContents(ctx, f.ID); err != nil {
+		t.Error(err)
+	}
+
+	// restored files need to be approved again
+	if err := svc.DeleteFile(ctx, f.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.GetApproval(ctx, f.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := svc.RestoreFile(ctx, f.ID); err != nil {
+		t.Fatal(err)
+	}
+	if a, _ := svc.GetApproval(ctx, f.ID); a.State != ApprovalDraft {
+		t.Errorf("State=%s", a.State)
+	}
+}
+
+func TestApprovals__disabled(t *testing.T) {
+	ctx := WithPrincipal(context.Background(), "alice")
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo)
+
+	f := readPPDValidFile(t)
+	if err := repo.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.SubmitFile(ctx, f.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := svc.GetApproval(ctx, f.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := svc.GetFileContents(ctx, f.ID); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestApprovals__endpoints(t *testing.T) {
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo, WithApprovals(NewApprovalRepositoryInMemory()))
+	router := MakeHTTPHandler(svc, repo, logger, WithAuth(&AuthConfig{
+		APIKeys: map[string][]Scope{
+			"alice": AllScopes,
+			"bob":   AllScopes,
+		},
+	}))
+
+	f := readPPDValidFile(t)
+	if err := repo.StoreFile(context.Background(), f); err != nil {
+		t.Fatal(err)
+	}
+
+	// users are identified by their API key, the X-User-ID header is never trusted
+	serve := func(method, path, key string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("X-API-Key", key)
+		req.Header.Set("X-User-ID", "mallory")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		w.Flush()
+		return w
+	}
+	if w := serve("GET", "/files/"+f.ID+"/contents", "alice", nil); w.Code != http.StatusForbidden {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("POST", "/files/"+f.ID+"/submit", "", nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("POST", "/files/"+f.ID+"/submit", "alice", nil); w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("POST", "/files/"+f.ID+"/approve", "alice", nil); w.Code != http.StatusForbidden {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("POST", "/files/"+f.ID+"/reject", "bob", []byte(`{"reason": "wrong amount"}`)); w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("POST", "/files/"+f.ID+"/submit", "alice", nil); w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("POST", "/files/"+f.ID+"/approve", "bob", nil); w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("GET", "/files/"+f.ID+"/contents", "alice", nil); w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("POST", "/files/"+f.ID+"/release", "bob", nil); w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	w := serve("GET", "/files/"+f.ID+"/approval", "alice", nil)
+	var approval Approval
+	if err := json.NewDecoder(w.Body).Decode(&approval); err != nil || approval.State != ApprovalReleased || approval.SubmittedBy != APIKeyPrincipal("alice") || approval.ReviewedBy != APIKeyPrincipal("bob") || approval.Reason != "" {
+		t.Errorf("unexpected approval: %v: %#v", err, approval)
+	}
+	if w := serve("POST", "/files/"+f.ID+"/reject", "bob", []byte("{")); w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestApprovals__endpointsWithoutAuth(t *testing.T) {
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	router := MakeHTTPHandler(NewService(repo, WithApprovals(NewApprovalRepositoryInMemory())), repo, logger)
+
+	f := readPPDValidFile(t)
+	if err := repo.StoreFile(context.Background(), f); err != nil {
+		t.Fatal(err)
+	}
+
+	// the X-User-ID header can be set by anyone, so it doesn't identify who submits a file
+	req := httptest.NewRequest("POST", "/files/"+f.ID+"/submit", nil)
+	req.Header.Set("X-User-ID", "alice")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
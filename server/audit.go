@@ -0,0 +1,394 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/base"
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+)
+
+// AuditAction is the kind of change an AuditEntry records
+type AuditAction string
+
+const (
+	// AuditCreate is recorded when a file is stored
+	AuditCreate AuditAction = "create"
+	// AuditUpdate is recorded when a file or one of its batches is changed, replaced or rolled back
+	AuditUpdate AuditAction = "update"
+	// AuditDelete is recorded when a file is deleted
+	AuditDelete AuditAction = "delete"
+	// AuditBuild is recorded when a file is built, see Service.BuildFile
+	AuditBuild AuditAction = "build"
+	// AuditRestore is recorded when a deleted file is restored, see Repository.RestoreFile
+	AuditRestore AuditAction = "restore"
+)
+
+// AuditEntry records who changed a stored file, when and how
+type AuditEntry struct {
+	ID     string      `json:"id"`
+	FileID string      `json:"fileID"`
+	Action AuditAction `json:"action"`
+	// BatchID is the batch which was stored or deleted when only one batch was changed
+	BatchID string `json:"batchID,omitempty"`
+	// Actor is the principal who made the change, the APIKeyPrincipal or JWT subject an HTTP request authenticated
+	// with, see WithAuth and WithPrincipal. It's empty when the server doesn't require authentication.
+	Actor string `json:"actor,omitempty"`
+	// ClaimedUser is the X-User-ID header of the HTTP request which made the change. It's sent by the caller
+	// and never checked, so it doesn't show who made the change.
+	ClaimedUser string `json:"claimedUser,omitempty"`
+	RequestID   string `json:"requestID,omitempty"`
+	// Operation is the method and route of the HTTP request which made the change, e.g. "POST /files/{id}/build"
+	Operation string    `json:"operation,omitempty"`
+	Created   time.Time `json:"created"`
+}
+
+// AuditLog is an append-only record of the changes made to stored files. NewAuditLogInMemory and
+// NewAuditLogFile are built in, other sinks such as a database can be supported by implementing this interface.
+type AuditLog interface {
+	// Append records entry, entries are never changed or removed once appended
+	Append(ctx context.Context, entry *AuditEntry) error
+	// FileEntries returns the entries of a file, oldest first
+	FileEntries(ctx context.Context, fileID string) ([]*AuditEntry, error)
+}
+
+// auditInfo is how a change was made, saved in the context of each HTTP request
+type auditInfo struct {
+	claimedUser string
+	requestID   string
+	operation   string
+	action      AuditAction
+}
+
+type auditInfoKey struct{}
+
+func auditInfoFrom(ctx context.Context) auditInfo {
+	info, _ := ctx.Value(auditInfoKey{}).(auditInfo)
+	return info
+}
+
+// withAuditAction has changes made with ctx recorded as action, rather than the action of the Repository method
+func withAuditAction(ctx context.Context, action AuditAction) context.Context {
+	info := auditInfoFrom(ctx)
+	info.action = action
+	return context.WithValue(ctx, auditInfoKey{}, info)
+}
+
+// saveAuditInfoIntoContext saves the user a request claims to be made by and its route into the go-kit context
+//
+// This is designed to be added as a ServerOption in our main http handler.
+func saveAuditInfoIntoContext() httptransport.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		info := auditInfo{
+			claimedUser: moovhttp.GetUserID(r),
+			requestID:   moovhttp.GetRequestID(r),
+			operation:   r.Method + " " + r.URL.Path,
+		}
+		if route := mux.CurrentRoute(r); route != nil {
+			if tpl, err := route.GetPathTemplate(); err == nil {
+				info.operation = r.Method + " " + tpl
+			}
+		}
+		return context.WithValue(ctx, auditInfoKey{}, info)
+	}
+}
+
+type auditRepository struct {
+	Repository
+	audit  AuditLog
+	logger log.Logger
+}
+
+// NewAuditRepository wraps r so every file it stores, changes or deletes is recorded in audit. A change
+// isn't undone when it can't be recorded, the error is logged instead.
+func NewAuditRepository(r Repository, audit AuditLog, logger log.Logger) Repository {
+	return &auditRepository{Repository: r, audit: audit, logger: logger}
+}
+
+// record appends an entry for a change made with ctx, when err is nil
+func (r *auditRepository) record(ctx context.Context, err error, action AuditAction, fileID, batchID string) {
+	if err != nil {
+		return
+	}
+	info := auditInfoFrom(ctx)
+	if info.action != "" {
+		action = info.action
+	}
+	entry := &AuditEntry{
+		ID:          base.ID(),
+		FileID:      fileID,
+		Action:      action,
+		BatchID:     batchID,
+		Actor:       principalFrom(ctx),
+		ClaimedUser: info.claimedUser,
+		RequestID:   info.requestID,
+		Operation:   info.operation,
+		Created:     time.Now(),
+	}
+	if err := r.audit.Append(ctx, entry); err != nil {
+		logEvent(r.logger, "audit", "append", err, "requestID", info.requestID, "fileID", fileID, "action", action)
+	}
+}
+
+func (r *auditRepository) StoreFile(ctx context.Context, f *ach.File) error {
+	err := r.Repository.StoreFile(ctx, f)
+	r.record(ctx, err, AuditCreate, f.ID, "")
+	return err
+}
+
+func (r *auditRepository) ReplaceFile(ctx context.Context, f *ach.File) error {
+	err := r.Repository.ReplaceFile(ctx, f)
+	r.record(ctx, err, AuditUpdate, f.ID, "")
+	return err
+}
+
+func (r *auditRepository) DeleteFile(ctx context.Context, id string) error {
+	err := r.Repository.DeleteFile(ctx, id)
+	r.record(ctx, err, AuditDelete, id, "")
+	return err
+}
+
+func (r *auditRepository) DeleteFileAtRevision(ctx context.Context, id string, revision int) error {
+	err := r.Repository.DeleteFileAtRevision(ctx, id, revision)
+	r.record(ctx, err, AuditDelete, id, "")
+	return err
+}
+
+func (r *auditRepository) RestoreFile(ctx context.Context, fileID string) (*ach.File, error) {
+	f, err := r.Repository.RestoreFile(ctx, fileID)
+	r.record(ctx, err, AuditRestore, fileID, "")
+	return f, err
+}
+
+func (r *auditRepository) StoreBatch(ctx context.Context, fileID string, batch ach.Batcher) error {
+	err := r.Repository.StoreBatch(ctx, fileID, batch)
+	r.record(ctx, err, AuditUpdate, fileID, batch.ID())
+	return err
+}
+
+func (r *auditRepository) DeleteBatch(ctx context.Context, fileID string, batchID string) error {
+	err := r.Repository.DeleteBatch(ctx, fileID, batchID)
+	r.record(ctx, err, AuditUpdate, fileID, batchID)
+	return err
+}
+
+func (r *auditRepository) RollbackFile(ctx context.Context, fileID string, version int) (*ach.File, error) {
+	f, err := r.Repository.RollbackFile(ctx, fileID, version)
+	r.record(ctx, err, AuditUpdate, fileID, "")
+	return f, err
+}
+
+func (r *auditRepository) UpdateFile(ctx context.Context, fileID string, update func(*ach.File) error) (*ach.File, error) {
+	f, err := r.Repository.UpdateFile(ctx, fileID, update)
+	r.record(ctx, err, AuditUpdate, fileID, "")
+	return f, err
+}
+
+func (r *auditRepository) UpdateFileAtRevision(ctx context.Context, fileID string, revision int, update func(*ach.File) error) (*ach.File, error) {
+	f, err := r.Repository.UpdateFileAtRevision(ctx, fileID, revision, update)
+	r.record(ctx, err, AuditUpdate, fileID, "")
+	return f, err
+}
+
+type auditLogInMemory struct {
+	mu      sync.RWMutex
+	entries map[string][]AuditEntry
+}
+
+// NewAuditLogInMemory returns an AuditLog which keeps entries in memory, they're lost when the process exits
+func NewAuditLogInMemory() AuditLog {
+	return &auditLogInMemory{entries: make(map[string][]AuditEntry)}
+}
+
+func (l *auditLogInMemory) Append(ctx context.Context, entry *AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries[entry.FileID] = append(l.entries[entry.FileID], *entry)
+	return nil
+}
+
+func (l *auditLogInMemory) FileEntries(ctx context.Context, fileID string) ([]*AuditEntry, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	entries := l.entries[fileID]
+	out := make([]*AuditEntry, len(entries))
+	for i := range entries {
+		entry := entries[i]
+		out[i] = &entry
+	}
+	return out, nil
+}
+
+type auditLogFile struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewAuditLogFile returns an AuditLog which appends entries as lines of JSON to the file at path,
+// creating it if needed. Finding the entries of a file reads every line.
+func NewAuditLogFile(path string) (AuditLog, error) {
+	fd, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := fd.Close(); err != nil {
+		return nil, err
+	}
+	return &auditLogFile{path: path}, nil
+}
+
+func (l *auditLogFile) Append(ctx context.Context, entry *AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fd, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := fd.Write(append(line, '\n')); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Sync(); err != nil {
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
+
+func (l *auditLogFile) FileEntries(ctx context.Context, fileID string) ([]*AuditEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fd, err := os.Open(l.path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	var out []*AuditEntry
+	scanner := bufio.NewScanner(fd)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, err
+		}
+		if entry.FileID == fileID {
+			out = append(out, &entry)
+		}
+	}
+	return out, scanner.Err()
+}
+
+// WithAuditLog has the Service return the entries of audit for GetFileAudit. Wrap the Repository with
+// NewAuditRepository so changes are recorded in it.
+func WithAuditLog(audit AuditLog) ServiceOption {
+	return func(s *service) {
+		s.audit = audit
+	}
+}
+
+// errAuditDisabled is returned by GetFileAudit of a Service created without WithAuditLog
+var errAuditDisabled = fmt.Errorf("audit log isn't enabled: %w", ErrNotFound)
+
+// GetFileAudit returns the audit entries of a file, oldest first. Entries are kept after the file is deleted.
+func (s *service) GetFileAudit(ctx context.Context, id string) (_ []*AuditEntry, err error) {
+	ctx, span := startSpan(ctx, "Service.GetFileAudit")
+	span.SetAttribute("file.id", id)
+	defer endSpan(span, &err)
+
+	if s.audit == nil {
+		return nil, errAuditDisabled
+	}
+	entries, err := s.audit.FileEntries(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		if _, err := s.store.FindFile(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+type getFileAuditRequest struct {
+	ID        string
+	requestID string
+}
+
+type getFileAuditResponse struct {
+	Entries []*AuditEntry `json:"entries"`
+	Err     error         `json:"error"`
+}
+
+func (r getFileAuditResponse) count() int { return len(r.Entries) }
+
+func (r getFileAuditResponse) error() error { return r.Err }
+
+func getFileAuditEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(getFileAuditRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return getFileAuditResponse{
+				Err: err,
+			}, err
+		}
+
+		entries, err := s.GetFileAudit(ctx, req.ID)
+
+		logEvent(logger, "files", "getFileAudit", err, "requestID", req.requestID, "fileID", req.ID)
+
+		return getFileAuditResponse{
+			Entries: entries,
+			Err:     err,
+		}, nil
+	}
+}
+
+func decodeGetFileAuditRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return getFileAuditRequest{
+		ID:        id,
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
@@ -0,0 +1,176 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func auditActions(entries []*AuditEntry) string {
+	var buf bytes.Buffer
+	for i, entry := range entries {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(string(entry.Action))
+	}
+	return buf.String()
+}
+
+func TestAuditRepository(t *testing.T) {
+	ctx := WithPrincipal(context.Background(), "alice")
+	audit := NewAuditLogInMemory()
+	repo := NewAuditRepository(NewRepositoryInMemory(testTTLDuration, nil), audit, nil)
+	svc := NewService(repo, WithAuditLog(audit))
+
+	f := readPPDValidFile(t)
+	if err := repo.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.BuildFile(ctx, f.ID); err != nil {
+		t.Fatal(err)
+	}
+	batchID := f.Batches[0].ID()
+	if err := svc.DeleteBatch(ctx, f.ID, batchID); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.DeleteFile(WithPrincipal(ctx, "bob"), f.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// entries are kept after the file is deleted
+	entries, err := svc.GetFileAudit(ctx, f.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := auditActions(entries); v != "create,build,update,delete" {
+		t.Fatalf("unexpected actions: %s", v)
+	}
+	if entries[0].Actor != "alice" || entries[3].Actor != "bob" || entries[2].BatchID != batchID || entries[0].Created.IsZero() {
+		t.Errorf("unexpected entries: %#v", entries)
+	}
+
+	// failed changes aren't recorded
+	if _, err := svc.BuildFile(ctx, f.ID); err == nil {
+		t.Error("expected error")
+	}
+	if entries, _ := svc.GetFileAudit(ctx, f.ID); len(entries) != 4 {
+		t.Errorf("got %d entries", len(entries))
+	}
+	if _, err := svc.GetFileAudit(ctx, "other"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := NewService(repo).GetFileAudit(ctx, f.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAuditLogFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ach-audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	ctx := context.Background()
+	audit, err := NewAuditLogFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range []*AuditEntry{{FileID: "a", Action: AuditCreate}, {FileID: "b", Action: AuditCreate}, {FileID: "a", Action: AuditDelete}} {
+		if err := audit.Append(ctx, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// entries are read from the file after a restart
+	if audit, err = NewAuditLogFile(path); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := audit.FileEntries(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := auditActions(entries); v != "create,delete" {
+		t.Errorf("unexpected actions: %s", v)
+	}
+
+	if _, err := NewAuditLogFile(filepath.Join(dir, "missing", "audit.log")); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestFiles__getFileAuditEndpoint(t *testing.T) {
+	logger := log.NewNopLogger()
+	audit := NewAuditLogInMemory()
+	repo := NewAuditRepository(NewRepositoryInMemory(testTTLDuration, logger), audit, logger)
+	router := MakeHTTPHandler(NewService(repo, WithAuditLog(audit)), repo, logger, WithAuth(&AuthConfig{
+		APIKeys: map[string][]Scope{"key": AllScopes},
+	}))
+
+	serve := func(method, path string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-API-Key", "key")
+		req.Header.Set("X-User-ID", "alice")
+		req.Header.Set("X-Request-ID", "req")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		w.Flush()
+		return w
+	}
+
+	f := readPPDValidFile(t)
+	bs, _ := json.Marshal(f)
+	if w := serve("POST", "/files/create", bs); w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("POST", "/files/"+f.ID+"/build", nil); w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	w := serve("GET", "/files/"+f.ID+"/audit", nil)
+	if w.Code != http.StatusOK || w.Header().Get("X-Total-Count") != "2" {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var resp getFileAuditResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	entry := resp.Entries[1]
+	// the actor is who authenticated, X-User-ID is only kept as who they claimed to be
+	if entry.Action != AuditBuild || entry.Actor != APIKeyPrincipal("key") || entry.ClaimedUser != "alice" || entry.RequestID != "req" || entry.Operation != "POST /files/{id}/build" {
+		t.Errorf("unexpected entry: %#v", entry)
+	}
+
+	if w := serve("GET", "/files/missing/audit", nil); w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,633 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Scope is a permission required by routes of the HTTP server
+type Scope string
+
+const (
+	// ScopeRead allows GET requests
+	ScopeRead Scope = "read"
+	// ScopeWrite allows requests which create or change files, such as POST and PATCH
+	ScopeWrite Scope = "write"
+	// ScopeDelete allows DELETE requests
+	ScopeDelete Scope = "delete"
+)
+
+// AllScopes are granted to API keys configured without scopes
+var AllScopes = []Scope{ScopeRead, ScopeWrite, ScopeDelete}
+
+// Role is the job of a caller, which limits the operations they can perform, see RolesConfig
+type Role string
+
+const (
+	// RolePreparer creates and changes files
+	RolePreparer Role = "preparer"
+	// RoleApprover builds and releases files
+	RoleApprover Role = "approver"
+	// RoleAdmin can perform every operation, and is the only role which can delete and restore files
+	RoleAdmin Role = "admin"
+)
+
+// AllRoles are allowed to read files
+var AllRoles = []Role{RolePreparer, RoleApprover, RoleAdmin}
+
+// AuthConfig requires callers of the HTTP server to authenticate with an API key or a JWT bearer token.
+// At least one of APIKeys or JWT must be set. GET /ping, /live, /ready and CORS pre-flight requests are always allowed.
+type AuthConfig struct {
+	// APIKeys maps each accepted key to the scopes it's granted. Keys are sent in the X-API-Key
+	// header or as an "Authorization: Bearer" token. Callers with a key are identified by its
+	// APIKeyPrincipal.
+	APIKeys map[string][]Scope
+
+	// JWT validates bearer tokens, such as OAuth2 access tokens, and grants the scopes they carry
+	JWT *JWTConfig
+
+	// RouteScopes overrides the scope a route requires, keyed by method and path template
+	// (e.g. "POST /files/{id}/build"). Other routes require ScopeRead for GET, ScopeDelete
+	// for DELETE and ScopeWrite for everything else.
+	RouteScopes map[string]Scope
+
+	// Roles, when set, also requires callers to have one of the roles allowed on each route
+	Roles *RolesConfig
+}
+
+// RolesConfig limits routes to callers with certain roles, on top of the scope each route requires.
+// By default GET requests are allowed for AllRoles, building, approving, rejecting and releasing files for
+// RoleApprover, deleting and restoring files for RoleAdmin, and everything else for RolePreparer. RoleAdmin
+// is allowed everywhere.
+type RolesConfig struct {
+	// APIKeys grants roles to the keys of AuthConfig.APIKeys, keys without roles are forbidden on every route
+	APIKeys map[string][]Role
+
+	// Claim is the JWT claim a caller's roles are read from, a string of space separated roles or
+	// an array of strings. It's "roles" when empty.
+	Claim string
+
+	// Routes overrides the roles allowed on a route, keyed by method and path template like RouteScopes
+	Routes map[string][]Role
+}
+
+// adminRoutes and approverRoutes are the routes RolesConfig doesn't allow RolePreparer on by default
+var (
+	adminRoutes = map[string]bool{
+		"POST /files/{id}/restore": true,
+	}
+	approverRoutes = map[string]bool{
+		"POST /files/{id}/build":   true,
+		"POST /files/{id}/approve": true,
+		"POST /files/{id}/reject":  true,
+		"POST /files/{id}/release": true,
+	}
+)
+
+// allowedRoles returns the roles allowed on the route with method and path template tpl
+func (cfg *RolesConfig) allowedRoles(method, tpl string) []Role {
+	if roles, ok := cfg.Routes[method+" "+tpl]; ok {
+		return roles
+	}
+	switch {
+	case method == "GET" || method == "HEAD":
+		return AllRoles
+	case method == "DELETE" || adminRoutes[method+" "+tpl]:
+		return []Role{RoleAdmin}
+	case approverRoutes[method+" "+tpl]:
+		return []Role{RoleApprover, RoleAdmin}
+	}
+	return []Role{RolePreparer, RoleAdmin}
+}
+
+// credentials are who an authenticated caller is and what they're granted
+type credentials struct {
+	// principal identifies the caller, the APIKeyPrincipal of their key or the "sub" claim of their JWT
+	principal string
+	scopes    []Scope
+	roles     []Role
+}
+
+// APIKeyPrincipal returns the principal of callers authenticated with key: "apikey:" followed by
+// the start of the hex encoded SHA-256 of key, so the key itself isn't recorded
+func APIKeyPrincipal(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return "apikey:" + hex.EncodeToString(sum[:])[:16]
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx for requests made by principal. The HTTP server sets the principal
+// of requests authenticated with WithAuth, callers of a Service set it to act as someone.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// principalFrom returns the principal set with WithPrincipal, or "" when the caller wasn't authenticated
+func principalFrom(ctx context.Context) string {
+	principal, _ := ctx.Value(principalKey{}).(string)
+	return principal
+}
+
+// JWTConfig validates JWT bearer tokens signed with HS256 or RS256. Scopes are read from the
+// space separated "scope" claim or the "scp" claim used by some OAuth2 providers.
+type JWTConfig struct {
+	// Secret verifies HS256 signatures
+	Secret []byte
+
+	// PublicKeys verify RS256 signatures. Keys are matched to a token's "kid" header, the
+	// key under "" verifies tokens of any kid.
+	PublicKeys map[string]*rsa.PublicKey
+
+	// JWKSURL is fetched for RS256 keys that aren't in PublicKeys, for example
+	// an OAuth2 provider's https://example.com/.well-known/jwks.json
+	JWKSURL string
+
+	// Issuer, when set, must match the "iss" claim
+	Issuer string
+	// Audience, when set, must be one of the "aud" claims
+	Audience string
+
+	// AllowMissingExpiry accepts tokens without an "exp" claim, which never expire.
+	// They're rejected by default.
+	AllowMissingExpiry bool
+}
+
+// WithAuth requires requests to the HTTP server be authenticated as described by cfg
+func WithAuth(cfg *AuthConfig) HandlerOption {
+	return func(h *handlerConfig) {
+		a := &authenticator{cfg: cfg}
+		if cfg.JWT != nil && cfg.JWT.JWKSURL != "" {
+			a.jwks = &jwksCache{url: cfg.JWT.JWKSURL, client: &http.Client{Timeout: 10 * time.Second}}
+		}
+		h.auth = a
+	}
+}
+
+type authenticator struct {
+	cfg  *AuthConfig
+	jwks *jwksCache
+}
+
+func (a *authenticator) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "OPTIONS" || isProbe(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		creds, err := a.authenticate(r)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="ach"`)
+			encodeError(r.Context(), err, w)
+			return
+		}
+		if err := a.authorize(r, creds); err != nil {
+			encodeError(r.Context(), err, w)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), creds.principal)))
+	})
+}
+
+// authorize returns a forbidden error unless creds have the scope, and any role, the route r matched requires
+func (a *authenticator) authorize(r *http.Request, creds *credentials) error {
+	var tpl string
+	if route := mux.CurrentRoute(r); route != nil {
+		tpl, _ = route.GetPathTemplate()
+	}
+	required := a.requiredScope(r.Method, tpl)
+	if !hasScope(creds.scopes, required) {
+		return forbidden(fmt.Errorf("%s scope required", required))
+	}
+	if a.cfg.Roles != nil {
+		allowed := a.cfg.Roles.allowedRoles(r.Method, tpl)
+		if !hasRole(creds.roles, allowed) {
+			return forbidden(fmt.Errorf("one of the roles %v required", allowed))
+		}
+	}
+	return nil
+}
+
+func hasScope(scopes []Scope, required Scope) bool {
+	for i := range scopes {
+		if scopes[i] == required {
+			return true
+		}
+	}
+	return false
+}
+
+func hasRole(roles []Role, allowed []Role) bool {
+	for i := range roles {
+		for j := range allowed {
+			if roles[i] == allowed[j] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requiredScope returns the Scope of the route with method and path template tpl
+func (a *authenticator) requiredScope(method, tpl string) Scope {
+	if scope, ok := a.cfg.RouteScopes[method+" "+tpl]; ok {
+		return scope
+	}
+	switch method {
+	case "GET", "HEAD":
+		return ScopeRead
+	case "DELETE":
+		return ScopeDelete
+	}
+	return ScopeWrite
+}
+
+func (a *authenticator) authenticate(r *http.Request) (*credentials, error) {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		if creds, ok := a.apiKey(key); ok {
+			return creds, nil
+		}
+		return nil, unauthorized(errors.New("invalid API key"))
+	}
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return nil, unauthorized(errors.New("missing API key or bearer token"))
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	if creds, ok := a.apiKey(token); ok {
+		return creds, nil
+	}
+	if a.cfg.JWT == nil {
+		return nil, unauthorized(errors.New("invalid API key"))
+	}
+	creds, err := a.verifyJWT(token, time.Now())
+	if err != nil {
+		return nil, unauthorized(err)
+	}
+	return creds, nil
+}
+
+// apiKey looks up key in constant time per configured key
+func (a *authenticator) apiKey(key string) (*credentials, bool) {
+	var found *credentials
+	for k, scopes := range a.cfg.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			found = &credentials{principal: APIKeyPrincipal(k), scopes: scopes}
+			if a.cfg.Roles != nil {
+				found.roles = a.cfg.Roles.APIKeys[k]
+			}
+		}
+	}
+	return found, found != nil
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *float64        `json:"exp"`
+	NotBefore *float64        `json:"nbf"`
+	Scope     string          `json:"scope"`
+	Scp       json.RawMessage `json:"scp"`
+}
+
+func (a *authenticator) verifyJWT(token string, now time.Time) (*credentials, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed bearer token")
+	}
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed JWT signature")
+	}
+	signed := []byte(parts[0] + "." + parts[1])
+
+	cfg := a.cfg.JWT
+	switch header.Algorithm {
+	case "HS256":
+		if len(cfg.Secret) == 0 {
+			return nil, errors.New("HS256 tokens aren't accepted")
+		}
+		mac := hmac.New(sha256.New, cfg.Secret)
+		mac.Write(signed)
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return nil, errors.New("invalid JWT signature")
+		}
+	case "RS256":
+		key := a.rsaKey(header.KeyID)
+		if key == nil {
+			return nil, fmt.Errorf("no RS256 key for kid %q", header.KeyID)
+		}
+		digest := sha256.Sum256(signed)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+			return nil, errors.New("invalid JWT signature")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported JWT alg %q", header.Algorithm)
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if claims.ExpiresAt == nil && !cfg.AllowMissingExpiry {
+		return nil, errors.New("JWT has no expiry")
+	}
+	if claims.ExpiresAt != nil && now.Unix() >= int64(*claims.ExpiresAt) {
+		return nil, errors.New("JWT has expired")
+	}
+	if claims.NotBefore != nil && now.Unix() < int64(*claims.NotBefore) {
+		return nil, errors.New("JWT isn't valid yet")
+	}
+	if cfg.Issuer != "" && claims.Issuer != cfg.Issuer {
+		return nil, fmt.Errorf("unexpected JWT issuer %q", claims.Issuer)
+	}
+	if cfg.Audience != "" && !containsClaim(claims.Audience, cfg.Audience) {
+		return nil, errors.New("JWT isn't for this audience")
+	}
+
+	creds := &credentials{principal: claims.Subject}
+	for _, s := range strings.Fields(claims.Scope) {
+		creds.scopes = append(creds.scopes, Scope(s))
+	}
+	for _, s := range claimValues(claims.Scp) {
+		creds.scopes = append(creds.scopes, Scope(s))
+	}
+	if a.cfg.Roles != nil {
+		name := a.cfg.Roles.Claim
+		if name == "" {
+			name = "roles"
+		}
+		var all map[string]json.RawMessage
+		if err := decodeJWTPart(parts[1], &all); err != nil {
+			return nil, err
+		}
+		for _, r := range claimValues(all[name]) {
+			creds.roles = append(creds.roles, Role(r))
+		}
+	}
+	return creds, nil
+}
+
+func (a *authenticator) rsaKey(kid string) *rsa.PublicKey {
+	if key, ok := a.cfg.JWT.PublicKeys[kid]; ok {
+		return key
+	}
+	if a.jwks != nil {
+		if key := a.jwks.key(kid); key != nil {
+			return key
+		}
+	}
+	return a.cfg.JWT.PublicKeys[""]
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	bs, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return errors.New("malformed JWT")
+	}
+	if err := json.Unmarshal(bs, v); err != nil {
+		return errors.New("malformed JWT")
+	}
+	return nil
+}
+
+// claimValues reads a claim which is either a string or an array of strings
+func claimValues(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(raw, &many); err == nil {
+		return many
+	}
+	var one string
+	if err := json.Unmarshal(raw, &one); err == nil {
+		return strings.Fields(one)
+	}
+	return nil
+}
+
+func containsClaim(raw json.RawMessage, value string) bool {
+	for _, v := range claimValues(raw) {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// jwksCache holds the RSA keys of a JSON Web Key Set, fetched again when an unknown kid is seen
+type jwksCache struct {
+	url    string
+	client *http.Client
+
+	mu      sync.Mutex
+	keys    map[string]*rsa.PublicKey
+	fetched time.Time
+}
+
+// jwksRefreshInterval limits how often unknown kids cause the key set to be fetched
+const jwksRefreshInterval = time.Minute
+
+func (c *jwksCache) key(kid string) *rsa.PublicKey {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key, ok := c.keys[kid]; ok {
+		return key
+	}
+	if time.Since(c.fetched) < jwksRefreshInterval {
+		return nil
+	}
+	c.fetched = time.Now()
+	keys, err := c.fetch()
+	if err != nil {
+		return nil
+	}
+	c.keys = keys
+	return c.keys[kid]
+}
+
+func (c *jwksCache) fetch() (map[string]*rsa.PublicKey, error) {
+	resp, err := c.client.Get(c.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected JWKS response: %s", resp.Status)
+	}
+	var set struct {
+		Keys []struct {
+			KeyType string `json:"kty"`
+			KeyID   string `json:"kid"`
+			N       string `json:"n"`
+			E       string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.KeyType != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			continue
+		}
+		keys[k.KeyID] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
+
+// ParseRSAPublicKey reads a PEM encoded RSA public key or certificate for JWTConfig.PublicKeys
+func ParseRSAPublicKey(bs []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(bs)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	var pub interface{}
+	var err error
+	switch block.Type {
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+			pub = cert.PublicKey
+		}
+	case "RSA PUBLIC KEY":
+		pub, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		pub, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	key, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%T is not an RSA public key", pub)
+	}
+	return key, nil
+}
+
+// ParseAPIKeyRoles reads the roles of API keys for RolesConfig.APIKeys, separated by semicolons with each key
+// followed by a colon and its comma separated roles, e.g. "key1:preparer;key2:approver,admin".
+func ParseAPIKeyRoles(v string) (map[string][]Role, error) {
+	keys := make(map[string][]Role)
+	for _, entry := range strings.Split(v, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		idx := strings.Index(entry, ":")
+		if idx < 0 {
+			return nil, errors.New("API key without roles")
+		}
+		key := strings.TrimSpace(entry[:idx])
+		if key == "" {
+			return nil, errors.New("empty API key")
+		}
+		var roles []Role
+		for _, r := range strings.Split(entry[idx+1:], ",") {
+			switch role := Role(strings.TrimSpace(r)); role {
+			case RolePreparer, RoleApprover, RoleAdmin:
+				roles = append(roles, role)
+			default:
+				return nil, fmt.Errorf("unknown role %q for API key", r)
+			}
+		}
+		keys[key] = roles
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no API key roles")
+	}
+	return keys, nil
+}
+
+// ParseAPIKeys reads API keys separated by semicolons, each optionally followed by a colon and
+// its comma separated scopes, e.g. "key1:read;key2:read,write,delete". Keys without scopes get AllScopes.
+func ParseAPIKeys(v string) (map[string][]Scope, error) {
+	keys := make(map[string][]Scope)
+	for _, entry := range strings.Split(v, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		idx := strings.Index(entry, ":")
+		if idx < 0 {
+			keys[entry] = AllScopes
+			continue
+		}
+		key := strings.TrimSpace(entry[:idx])
+		if key == "" {
+			return nil, errors.New("empty API key")
+		}
+		var scopes []Scope
+		for _, s := range strings.Split(entry[idx+1:], ",") {
+			switch scope := Scope(strings.TrimSpace(s)); scope {
+			case ScopeRead, ScopeWrite, ScopeDelete:
+				scopes = append(scopes, scope)
+			default:
+				return nil, fmt.Errorf("unknown scope %q for API key", s)
+			}
+		}
+		keys[key] = scopes
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no API keys")
+	}
+	return keys, nil
+}
@@ -0,0 +1,342 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+// signTestJWT signs a token with claims, which expires in a minute unless claims has an "exp".
+// A nil "exp" leaves it out.
+func signTestJWT(t *testing.T, header, claims map[string]interface{}, sign func([]byte) []byte) string {
+	t.Helper()
+	withExpiry := map[string]interface{}{"exp": time.Now().Unix() + 60}
+	for k, v := range claims {
+		withExpiry[k] = v
+	}
+	if withExpiry["exp"] == nil {
+		delete(withExpiry, "exp")
+	}
+	h, _ := json.Marshal(header)
+	c, _ := json.Marshal(withExpiry)
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signed)))
+}
+
+func hs256(secret []byte) func([]byte) []byte {
+	return func(bs []byte) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(bs)
+		return mac.Sum(nil)
+	}
+}
+
+func authTestHandler(cfg *AuthConfig) http.Handler {
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	return MakeHTTPHandler(NewService(repo), repo, log.NewNopLogger(), WithAuth(cfg))
+}
+
+func authTestRequest(handler http.Handler, method, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	w.Flush()
+	return w
+}
+
+func TestAuth__APIKeys(t *testing.T) {
+	handler := authTestHandler(&AuthConfig{
+		APIKeys: map[string][]Scope{
+			"reader": {ScopeRead},
+			"admin":  AllScopes,
+		},
+		RouteScopes: map[string]Scope{
+			"POST /files/{id}/build": ScopeDelete,
+		},
+	})
+
+	cases := []struct {
+		method, path string
+		headers      map[string]string
+		code         int
+	}{
+		{"GET", "/ping", nil, http.StatusOK},
+		{"GET", "/live", nil, http.StatusOK},
+		{"GET", "/ready", nil, http.StatusOK},
+		{"GET", "/files", nil, http.StatusUnauthorized},
+		{"GET", "/files", map[string]string{"X-API-Key": "wrong"}, http.StatusUnauthorized},
+		{"GET", "/files", map[string]string{"X-API-Key": "reader"}, http.StatusOK},
+		{"GET", "/files", map[string]string{"Authorization": "Bearer reader"}, http.StatusOK},
+		{"DELETE", "/files/foo", map[string]string{"X-API-Key": "reader"}, http.StatusForbidden},
+		{"DELETE", "/files/foo", map[string]string{"X-API-Key": "admin"}, http.StatusOK},
+		{"POST", "/files/foo/build", map[string]string{"X-API-Key": "admin"}, http.StatusNotFound},
+		{"POST", "/files/foo/build", map[string]string{"X-API-Key": "reader"}, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		w := authTestRequest(handler, tc.method, tc.path, tc.headers)
+		if w.Code != tc.code {
+			t.Errorf("%s %s %v: got %d: %s", tc.method, tc.path, tc.headers, w.Code, w.Body.String())
+		}
+		if w.Code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("%s %s: missing WWW-Authenticate", tc.method, tc.path)
+		}
+	}
+}
+
+func TestAuth__JWTHS256(t *testing.T) {
+	secret := []byte("secret")
+	handler := authTestHandler(&AuthConfig{
+		JWT: &JWTConfig{Secret: secret, Issuer: "https://issuer", Audience: "ach"},
+	})
+	header := map[string]interface{}{"alg": "HS256", "typ": "JWT"}
+	now := time.Now().Unix()
+
+	cases := []struct {
+		claims map[string]interface{}
+		sign   func([]byte) []byte
+		code   int
+	}{
+		{map[string]interface{}{"iss": "https://issuer", "aud": "ach", "exp": now + 60, "scope": "read write"}, hs256(secret), http.StatusOK},
+		{map[string]interface{}{"iss": "https://issuer", "aud": []string{"other", "ach"}, "scp": []string{"read"}}, hs256(secret), http.StatusOK},
+		{map[string]interface{}{"iss": "https://issuer", "aud": "ach", "scope": "write"}, hs256(secret), http.StatusForbidden},
+		{map[string]interface{}{"iss": "https://issuer", "aud": "ach", "exp": now - 60, "scope": "read"}, hs256(secret), http.StatusUnauthorized},
+		{map[string]interface{}{"iss": "https://issuer", "aud": "ach", "nbf": now + 60, "scope": "read"}, hs256(secret), http.StatusUnauthorized},
+		{map[string]interface{}{"iss": "https://other", "aud": "ach", "scope": "read"}, hs256(secret), http.StatusUnauthorized},
+		{map[string]interface{}{"iss": "https://issuer", "aud": "other", "scope": "read"}, hs256(secret), http.StatusUnauthorized},
+		{map[string]interface{}{"iss": "https://issuer", "aud": "ach", "scope": "read"}, hs256([]byte("wrong")), http.StatusUnauthorized},
+	}
+	for i, tc := range cases {
+		token := signTestJWT(t, header, tc.claims, tc.sign)
+		w := authTestRequest(handler, "GET", "/files", map[string]string{"Authorization": "Bearer " + token})
+		if w.Code != tc.code {
+			t.Errorf("#%d: got %d: %s", i, w.Code, w.Body.String())
+		}
+	}
+
+	// tokens without an expiry are only accepted when allowed
+	token := signTestJWT(t, header, map[string]interface{}{"iss": "https://issuer", "aud": "ach", "exp": nil, "scope": "read"}, hs256(secret))
+	if w := authTestRequest(handler, "GET", "/files", map[string]string{"Authorization": "Bearer " + token}); w.Code != http.StatusUnauthorized {
+		t.Errorf("no exp: got %d", w.Code)
+	}
+	allowed := authTestHandler(&AuthConfig{
+		JWT: &JWTConfig{Secret: secret, Issuer: "https://issuer", Audience: "ach", AllowMissingExpiry: true},
+	})
+	if w := authTestRequest(allowed, "GET", "/files", map[string]string{"Authorization": "Bearer " + token}); w.Code != http.StatusOK {
+		t.Errorf("allowed no exp: got %d: %s", w.Code, w.Body.String())
+	}
+
+	// unsigned tokens are never accepted
+	token = signTestJWT(t, map[string]interface{}{"alg": "none"}, map[string]interface{}{"scope": "read"}, func([]byte) []byte { return nil })
+	if w := authTestRequest(handler, "GET", "/files", map[string]string{"Authorization": "Bearer " + token}); w.Code != http.StatusUnauthorized {
+		t.Errorf("alg none: got %d", w.Code)
+	}
+}
+
+func TestAuth__principal(t *testing.T) {
+	secret := []byte("secret")
+	a := &authenticator{cfg: &AuthConfig{
+		APIKeys: map[string][]Scope{"key": AllScopes},
+		JWT:     &JWTConfig{Secret: secret},
+	}}
+	var principal string
+	handler := a.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal = principalFrom(r.Context())
+	}))
+
+	token := signTestJWT(t, map[string]interface{}{"alg": "HS256"}, map[string]interface{}{"sub": "alice", "scope": "read"}, hs256(secret))
+	cases := map[string]string{
+		"X-API-Key":     "key",
+		"Authorization": "Bearer " + token,
+	}
+	expected := map[string]string{
+		"X-API-Key":     APIKeyPrincipal("key"),
+		"Authorization": "alice",
+	}
+	for header, value := range cases {
+		principal = ""
+		authTestRequest(handler, "GET", "/files", map[string]string{header: value, "X-User-ID": "mallory"})
+		if principal != expected[header] {
+			t.Errorf("%s: principal=%q", header, principal)
+		}
+	}
+	if p := APIKeyPrincipal("key"); len(p) != len("apikey:")+16 || p[:len("apikey:")] != "apikey:" {
+		t.Errorf("unexpected APIKeyPrincipal: %q", p)
+	}
+}
+
+func TestAuth__JWTRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs256 := func(bs []byte) []byte {
+		digest := sha256.Sum256(bs)
+		sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key-1",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	defer jwks.Close()
+
+	handler := authTestHandler(&AuthConfig{JWT: &JWTConfig{JWKSURL: jwks.URL}})
+	claims := map[string]interface{}{"scope": "read"}
+
+	token := signTestJWT(t, map[string]interface{}{"alg": "RS256", "kid": "key-1"}, claims, rs256)
+	if w := authTestRequest(handler, "GET", "/files", map[string]string{"Authorization": "Bearer " + token}); w.Code != http.StatusOK {
+		t.Errorf("got %d: %s", w.Code, w.Body.String())
+	}
+	token = signTestJWT(t, map[string]interface{}{"alg": "RS256", "kid": "key-2"}, claims, rs256)
+	if w := authTestRequest(handler, "GET", "/files", map[string]string{"Authorization": "Bearer " + token}); w.Code != http.StatusUnauthorized {
+		t.Errorf("unknown kid: got %d", w.Code)
+	}
+
+	// a PEM public key verifies tokens of any kid
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := ParseRSAPublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler = authTestHandler(&AuthConfig{JWT: &JWTConfig{PublicKeys: map[string]*rsa.PublicKey{"": pub}}})
+	if w := authTestRequest(handler, "GET", "/files", map[string]string{"Authorization": "Bearer " + token}); w.Code != http.StatusOK {
+		t.Errorf("got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestParseAPIKeys(t *testing.T) {
+	keys, err := ParseAPIKeys("reader:read; admin ;writer:read,write")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 || len(keys["reader"]) != 1 || len(keys["admin"]) != 3 || len(keys["writer"]) != 2 {
+		t.Errorf("unexpected keys: %#v", keys)
+	}
+	for _, v := range []string{"", ";", "key:admin", ":read"} {
+		if _, err := ParseAPIKeys(v); err == nil {
+			t.Errorf("%q: expected error", v)
+		}
+	}
+}
+
+func TestAuth__Roles(t *testing.T) {
+	secret := []byte("secret")
+	handler := authTestHandler(&AuthConfig{
+		APIKeys: map[string][]Scope{
+			"preparer": AllScopes,
+			"approver": AllScopes,
+			"admin":    AllScopes,
+			"nobody":   AllScopes,
+		},
+		JWT: &JWTConfig{Secret: secret},
+		Roles: &RolesConfig{
+			APIKeys: map[string][]Role{
+				"preparer": {RolePreparer},
+				"approver": {RoleApprover},
+				"admin":    {RoleAdmin},
+			},
+			Claim: "groups",
+			Routes: map[string][]Role{
+				"POST /files/{id}/rollback/{version}": {RoleApprover},
+			},
+		},
+	})
+
+	cases := []struct {
+		method, path, key string
+		code              int
+	}{
+		{"GET", "/files", "nobody", http.StatusForbidden},
+		{"GET", "/files", "approver", http.StatusOK},
+		{"POST", "/files/foo/batches", "nobody", http.StatusForbidden},
+		{"POST", "/files/foo/batches", "preparer", http.StatusBadRequest},
+		{"POST", "/files/foo/batches", "approver", http.StatusForbidden},
+		{"POST", "/files/foo/build", "preparer", http.StatusForbidden},
+		{"POST", "/files/foo/build", "approver", http.StatusNotFound},
+		{"POST", "/files/foo/build", "admin", http.StatusNotFound},
+		{"DELETE", "/files/foo", "approver", http.StatusForbidden},
+		{"DELETE", "/files/foo", "admin", http.StatusOK},
+		{"POST", "/files/foo/restore", "preparer", http.StatusForbidden},
+		{"POST", "/files/foo/restore", "admin", http.StatusNotFound},
+		{"POST", "/files/foo/rollback/1", "admin", http.StatusForbidden},
+		{"POST", "/files/foo/rollback/1", "approver", http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		w := authTestRequest(handler, tc.method, tc.path, map[string]string{"X-API-Key": tc.key})
+		if w.Code != tc.code {
+			t.Errorf("%s %s as %s: got %d: %s", tc.method, tc.path, tc.key, w.Code, w.Body.String())
+		}
+	}
+
+	// roles are read from the configured claim
+	header := map[string]interface{}{"alg": "HS256", "typ": "JWT"}
+	token := signTestJWT(t, header, map[string]interface{}{"scope": "delete", "groups": []string{"preparer", "admin"}}, hs256(secret))
+	if w := authTestRequest(handler, "DELETE", "/files/foo", map[string]string{"Authorization": "Bearer " + token}); w.Code != http.StatusOK {
+		t.Errorf("got %d: %s", w.Code, w.Body.String())
+	}
+	token = signTestJWT(t, header, map[string]interface{}{"scope": "delete", "roles": "admin"}, hs256(secret))
+	if w := authTestRequest(handler, "DELETE", "/files/foo", map[string]string{"Authorization": "Bearer " + token}); w.Code != http.StatusForbidden {
+		t.Errorf("got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestParseAPIKeyRoles(t *testing.T) {
+	keys, err := ParseAPIKeyRoles("key1:preparer; key2:approver,admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || len(keys["key1"]) != 1 || len(keys["key2"]) != 2 {
+		t.Errorf("unexpected keys: %#v", keys)
+	}
+	for _, v := range []string{"", "key1", "key1:owner", ":admin"} {
+		if _, err := ParseAPIKeyRoles(v); err == nil {
+			t.Errorf("%q: expected error", v)
+		}
+	}
+}
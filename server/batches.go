@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/moov-io/ach"
@@ -34,6 +35,7 @@ import (
 type createBatchRequest struct {
 	FileID string
 	Batch  *ach.Batch
+	opts   []ChangeOption
 
 	requestID string
 }
@@ -46,7 +48,7 @@ type createBatchResponse struct {
 func (r createBatchResponse) error() error { return r.Err }
 
 func createBatchEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
-	return func(_ context.Context, request interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(createBatchRequest)
 		if !ok {
 			err := errors.New("invalid request")
@@ -55,11 +57,9 @@ func createBatchEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 			}, err
 		}
 
-		id, err := s.CreateBatch(req.FileID, req.Batch)
+		id, err := s.CreateBatch(ctx, req.FileID, req.Batch, req.opts...)
 
-		if logger != nil {
-			logger.Log("batches", "createBatch", "file", req.FileID, "requestID", req.requestID, "error", err)
-		}
+		logEvent(logger, "batches", "createBatch", err, "requestID", req.requestID, "fileID", req.FileID)
 
 		return createBatchResponse{
 			ID:  id,
@@ -78,17 +78,23 @@ func decodeCreateBatchRequest(_ context.Context, r *http.Request) (interface{},
 		return nil, ErrBadRouting
 	}
 	req.FileID = id
-	if err := json.NewDecoder(r.Body).Decode(&req.Batch); err != nil {
+	opts, err := parseIfMatch(r)
+	if err != nil {
 		return nil, err
 	}
+	req.opts = opts
+	if err := json.NewDecoder(r.Body).Decode(&req.Batch); err != nil {
+		return nil, invalid(err)
+	}
 	if req.Batch == nil {
-		return nil, errors.New("no Batch provided")
+		return nil, invalid(errors.New("no Batch provided"))
 	}
 	return req, nil
 }
 
 type getBatchesRequest struct {
 	fileID string
+	masked bool
 
 	requestID string
 }
@@ -114,11 +120,16 @@ func decodeGetBatchesRequest(_ context.Context, r *http.Request) (interface{}, e
 		return nil, ErrBadRouting
 	}
 	req.fileID = id
+
+	var err error
+	if req.masked, err = parseMasked(r); err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
 func getBatchesEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
-	return func(_ context.Context, request interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(getBatchesRequest)
 		if !ok {
 			err := errors.New("invalid request")
@@ -127,11 +138,16 @@ func getBatchesEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 			}, err
 		}
 
-		if logger != nil {
-			logger.Log("batches", "getBatches", "file", req.fileID, "requestID", req.requestID)
+		logEvent(logger, "batches", "getBatches", nil, "requestID", req.requestID, "fileID", req.fileID)
+		if req.masked {
+			f, err := getMaskedFile(ctx, s, req.fileID)
+			if err != nil {
+				return getBatchesResponse{Err: err}, nil
+			}
+			return getBatchesResponse{Batches: f.Batches}, nil
 		}
 		return getBatchesResponse{
-			Batches: s.GetBatches(req.fileID),
+			Batches: s.GetBatches(ctx, req.fileID),
 			Err:     nil,
 		}, nil
 	}
@@ -140,6 +156,7 @@ func getBatchesEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 type getBatchRequest struct {
 	fileID  string
 	batchID string
+	masked  bool
 
 	requestID string
 }
@@ -167,11 +184,16 @@ func decodeGetBatchRequest(_ context.Context, r *http.Request) (interface{}, err
 
 	req.fileID = fileID
 	req.batchID = batchID
+
+	var err error
+	if req.masked, err = parseMasked(r); err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
 func getBatchEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
-	return func(_ context.Context, request interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(getBatchRequest)
 		if !ok {
 			err := errors.New("invalid request")
@@ -180,12 +202,16 @@ func getBatchEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 			}, err
 		}
 
-		batch, err := s.GetBatch(req.fileID, req.batchID)
-
-		if logger != nil {
-			logger.Log("batches", "getBatche", "file", req.fileID, "requestID", req.requestID, "error", err)
+		var batch ach.Batcher
+		var err error
+		if req.masked {
+			batch, err = getMaskedBatch(ctx, s, req.fileID, req.batchID)
+		} else {
+			batch, err = s.GetBatch(ctx, req.fileID, req.batchID)
 		}
 
+		logEvent(logger, "batches", "getBatche", err, "requestID", req.requestID, "fileID", req.fileID)
+
 		return getBatchResponse{
 			Batch: batch,
 			Err:   err,
@@ -193,9 +219,96 @@ func getBatchEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 	}
 }
 
+// getMaskedBatch returns a copy of a stored batch with its personal data masked by ach.File.MaskSensitiveData
+func getMaskedBatch(ctx context.Context, s Service, fileID, batchID string) (ach.Batcher, error) {
+	if _, err := s.GetBatch(ctx, fileID, batchID); err != nil {
+		return nil, err
+	}
+	f, err := getMaskedFile(ctx, s, fileID)
+	if err != nil {
+		return nil, err
+	}
+	for _, batch := range f.Batches {
+		if batch.ID() == batchID {
+			return batch, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+type replaceBatchRequest struct {
+	fileID  string
+	batchID string
+	batch   ach.Batcher
+	opts    []ChangeOption
+
+	requestID string
+}
+
+type replaceBatchResponse struct {
+	Batch ach.Batcher `json:"batch"`
+	Err   error       `json:"error"`
+}
+
+func (r replaceBatchResponse) error() error { return r.Err }
+
+func decodeReplaceBatchRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req replaceBatchRequest
+	req.requestID = moovhttp.GetRequestID(r)
+
+	vars := mux.Vars(r)
+	fileID, ok := vars["fileID"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	batchID, ok := vars["batchID"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	req.fileID = fileID
+	req.batchID = batchID
+
+	opts, err := parseIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+	req.opts = opts
+
+	bs, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if req.batch, err = ach.BatchFromJSON(bs); err != nil {
+		return nil, invalid(err)
+	}
+	return req, nil
+}
+
+func replaceBatchEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(replaceBatchRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return replaceBatchResponse{
+				Err: err,
+			}, err
+		}
+
+		batch, err := s.ReplaceBatch(ctx, req.fileID, req.batchID, req.batch, req.opts...)
+
+		logEvent(logger, "batches", "replaceBatch", err, "requestID", req.requestID, "fileID", req.fileID, "batchID", req.batchID)
+
+		return replaceBatchResponse{
+			Batch: batch,
+			Err:   err,
+		}, nil
+	}
+}
+
 type deleteBatchRequest struct {
 	fileID  string
 	batchID string
+	opts    []ChangeOption
 
 	requestID string
 }
@@ -220,13 +333,19 @@ func decodeDeleteBatchRequest(_ context.Context, r *http.Request) (interface{},
 		return nil, ErrBadRouting
 	}
 
+	opts, err := parseIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+
 	req.fileID = fileID
 	req.batchID = batchID
+	req.opts = opts
 	return req, nil
 }
 
 func deleteBatchEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
-	return func(_ context.Context, request interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(deleteBatchRequest)
 		if !ok {
 			err := errors.New("invalid request")
@@ -235,11 +354,9 @@ func deleteBatchEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 			}, err
 		}
 
-		err := s.DeleteBatch(req.fileID, req.batchID)
+		err := s.DeleteBatch(ctx, req.fileID, req.batchID, req.opts...)
 
-		if logger != nil {
-			logger.Log("batches", "deleteBatch", "file", req.fileID, "requestID", req.requestID, "error", err)
-		}
+		logEvent(logger, "batches", "deleteBatch", err, "requestID", req.requestID, "fileID", req.fileID)
 
 		return deleteBatchResponse{
 			Err: err,
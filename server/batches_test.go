@@ -32,13 +32,14 @@ import (
 )
 
 func TestFiles__decodeCreateBatchRequest(t *testing.T) {
+	ctx := context.Background()
 	f := ach.NewFile()
 	f.ID = "foo"
 
 	// Setup our persistence
 	repo := NewRepositoryInMemory(testTTLDuration, log.NewNopLogger())
 	svc := NewService(repo)
-	if err := repo.StoreFile(f); err != nil {
+	if err := repo.StoreFile(ctx, f); err != nil {
 		t.Fatal(err)
 	}
 
@@ -74,12 +75,13 @@ func TestFiles__decodeCreateBatchRequest(t *testing.T) {
 	handler.ServeHTTP(w, req)
 	w.Flush()
 
-	if w.Code != http.StatusInternalServerError {
+	if w.Code != http.StatusBadRequest {
 		t.Errorf("bogus HTTP status code: %d: %s", w.Code, w.Body.String())
 	}
 }
 
 func TestFiles__createBatchEndpoint(t *testing.T) {
+	ctx := context.Background()
 	repo := NewRepositoryInMemory(testTTLDuration, log.NewNopLogger())
 	svc := NewService(repo)
 
@@ -96,7 +98,7 @@ func TestFiles__createBatchEndpoint(t *testing.T) {
 
 	f := ach.NewFile()
 	f.ID = "create-batch"
-	if err := repo.StoreFile(f); err != nil {
+	if err := repo.StoreFile(ctx, f); err != nil {
 		t.Fatal(err)
 	}
 
@@ -115,13 +117,14 @@ func TestFiles__createBatchEndpoint(t *testing.T) {
 }
 
 func TestFiles__decodeGetBatchesRequest(t *testing.T) {
+	ctx := context.Background()
 	f := ach.NewFile()
 	f.ID = "foo"
 
 	// Setup our persistence
 	repo := NewRepositoryInMemory(testTTLDuration, log.NewNopLogger())
 	svc := NewService(repo)
-	if err := repo.StoreFile(f); err != nil {
+	if err := repo.StoreFile(ctx, f); err != nil {
 		t.Fatal(err)
 	}
 
@@ -142,6 +145,7 @@ func TestFiles__decodeGetBatchesRequest(t *testing.T) {
 }
 
 func TestFiles__getBatchesEndpoint(t *testing.T) {
+	ctx := context.Background()
 	repo := NewRepositoryInMemory(testTTLDuration, log.NewNopLogger())
 	svc := NewService(repo)
 
@@ -160,7 +164,7 @@ func TestFiles__getBatchesEndpoint(t *testing.T) {
 	f := ach.NewFile()
 	f.ID = "get-batches"
 	f.AddBatch(mockBatchWEB())
-	if err := repo.StoreFile(f); err != nil {
+	if err := repo.StoreFile(ctx, f); err != nil {
 		t.Fatal(err)
 	}
 	resp, err = getBatchesEndpoint(svc, log.NewNopLogger())(context.TODO(), getBatchesRequest{
@@ -179,6 +183,7 @@ func TestFiles__getBatchesEndpoint(t *testing.T) {
 }
 
 func TestFiles__decodeGetBatchRequest(t *testing.T) {
+	ctx := context.Background()
 	f := ach.NewFile()
 	f.ID = "foo"
 	b := mockBatchWEB()
@@ -188,7 +193,7 @@ func TestFiles__decodeGetBatchRequest(t *testing.T) {
 	// Setup our persistence
 	repo := NewRepositoryInMemory(testTTLDuration, log.NewNopLogger())
 	svc := NewService(repo)
-	if err := repo.StoreFile(f); err != nil {
+	if err := repo.StoreFile(ctx, f); err != nil {
 		t.Fatal(err)
 	}
 
@@ -209,6 +214,7 @@ func TestFiles__decodeGetBatchRequest(t *testing.T) {
 }
 
 func TestFiles__getBatchEndpoint(t *testing.T) {
+	ctx := context.Background()
 	repo := NewRepositoryInMemory(testTTLDuration, log.NewNopLogger())
 	svc := NewService(repo)
 
@@ -228,7 +234,7 @@ func TestFiles__getBatchEndpoint(t *testing.T) {
 	f.ID = "get-batch"
 	b := mockBatchWEB()
 	f.AddBatch(b)
-	if err := repo.StoreFile(f); err != nil {
+	if err := repo.StoreFile(ctx, f); err != nil {
 		t.Fatal(err)
 	}
 	resp, err = getBatchEndpoint(svc, log.NewNopLogger())(context.TODO(), getBatchRequest{
@@ -248,6 +254,7 @@ func TestFiles__getBatchEndpoint(t *testing.T) {
 }
 
 func TestFiles__decodeDeleteBatchRequest(t *testing.T) {
+	ctx := context.Background()
 	f := ach.NewFile()
 	f.ID = "foo"
 	b := mockBatchWEB()
@@ -257,7 +264,7 @@ func TestFiles__decodeDeleteBatchRequest(t *testing.T) {
 	// Setup our persistence
 	repo := NewRepositoryInMemory(testTTLDuration, log.NewNopLogger())
 	svc := NewService(repo)
-	if err := repo.StoreFile(f); err != nil {
+	if err := repo.StoreFile(ctx, f); err != nil {
 		t.Fatal(err)
 	}
 
@@ -278,6 +285,7 @@ func TestFiles__decodeDeleteBatchRequest(t *testing.T) {
 }
 
 func TestFiles__deleteBatchEndpoint(t *testing.T) {
+	ctx := context.Background()
 	repo := NewRepositoryInMemory(testTTLDuration, log.NewNopLogger())
 	svc := NewService(repo)
 
@@ -297,7 +305,7 @@ func TestFiles__deleteBatchEndpoint(t *testing.T) {
 	f.ID = "delete-batch"
 	b := mockBatchWEB()
 	f.AddBatch(b)
-	if err := repo.StoreFile(f); err != nil {
+	if err := repo.StoreFile(ctx, f); err != nil {
 		t.Fatal(err)
 	}
 	resp, err = deleteBatchEndpoint(svc, log.NewNopLogger())(context.TODO(), deleteBatchRequest{
@@ -312,3 +320,86 @@ func TestFiles__deleteBatchEndpoint(t *testing.T) {
 		t.Errorf("%T %#v", resp, resp)
 	}
 }
+
+func TestFiles__replaceBatchEndpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	router := MakeHTTPHandler(NewService(repo), repo, logger)
+
+	f := ach.NewFile()
+	f.ID = "replace-batch"
+	f.SetHeader(*mockFileHeader())
+	first := mockBatchWEB()
+	first.GetHeader().BatchNumber = 1
+	second := mockBatchWEB()
+	second.SetID("batch-02")
+	second.GetHeader().BatchNumber = 2
+	f.AddBatch(first)
+	f.AddBatch(second)
+	if err := repo.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+
+	put := func(batchID string, batch ach.Batcher, ifMatch string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if err := json.NewEncoder(&body).Encode(batch); err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest("PUT", "/files/replace-batch/batches/"+batchID, &body)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		w.Flush()
+		return w
+	}
+
+	// change the amount of one entry
+	replacement := mockBatchWEB()
+	replacement.GetEntries()[0].Amount = 2500
+	w := put("batch-02", replacement, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Batch *ach.Batch `json:"batch"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Batch.GetHeader().ID != "batch-02" || resp.Batch.GetControl().TotalCreditEntryDollarAmount != 2500 {
+		t.Errorf("unexpected batch: %#v", resp.Batch)
+	}
+
+	found, _ := repo.FindFile(ctx, f.ID)
+	if len(found.Batches) != 2 || found.Batches[1].ID() != "batch-02" {
+		t.Fatalf("batches were reordered: %#v", found.Batches)
+	}
+	if n := found.Batches[1].GetHeader().BatchNumber; n != 2 {
+		t.Errorf("BatchNumber=%d", n)
+	}
+	if _, ok := found.Batches[1].(*ach.BatchWEB); !ok {
+		t.Errorf("got %T", found.Batches[1])
+	}
+	if amt := found.Batches[1].GetEntries()[0].Amount; amt != 2500 {
+		t.Errorf("Amount=%d", amt)
+	}
+
+	// invalid batches aren't stored
+	invalid := mockBatchWEB()
+	invalid.GetHeader().CompanyName = ""
+	if w = put("batch-02", invalid, ""); w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = put("missing", mockBatchWEB(), ""); w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = put("batch-02", mockBatchWEB(), `"1"`); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if rev, _ := repo.FileRevision(ctx, f.ID); rev != 2 {
+		t.Errorf("revision=%d", rev)
+	}
+}
@@ -0,0 +1,411 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/ach"
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+)
+
+const (
+	// defaultCalendarWindow is how many days ahead the settlement calendar looks when no end date is requested
+	defaultCalendarWindow = 30
+
+	calendarDateFormat = "2006-01-02"
+)
+
+// SettlementCalendar lists the entries of stored files by the banking day they're expected
+// to settle on. Entries settle on their batch's EffectiveEntryDate, or the next banking day
+// when the EffectiveEntryDate falls on a weekend or holiday.
+type SettlementCalendar struct {
+	From string           `json:"from"`
+	To   string           `json:"to"`
+	Days []*SettlementDay `json:"days"`
+}
+
+// SettlementDay are the entries expected to settle on one banking day
+type SettlementDay struct {
+	Date        string             `json:"date"`
+	TotalDebit  int                `json:"totalDebit"`
+	TotalCredit int                `json:"totalCredit"`
+	Entries     []*SettlementEntry `json:"entries"`
+}
+
+// SettlementEntry is an entry expected to settle along with where it's stored
+type SettlementEntry struct {
+	FileID             string `json:"fileID"`
+	BatchID            string `json:"batchID"`
+	StandardEntryClass string `json:"standardEntryClassCode"`
+	CompanyName        string `json:"companyName,omitempty"`
+	EffectiveEntryDate string `json:"effectiveEntryDate"`
+	TraceNumber        string `json:"traceNumber"`
+	IndividualName     string `json:"individualName,omitempty"`
+	Amount             int    `json:"amount"`
+	// CreditOrDebit is C for credits and D for debits
+	CreditOrDebit string `json:"creditOrDebit"`
+}
+
+func (s *service) SettlementCalendar(ctx context.Context, from, to time.Time) *SettlementCalendar {
+	files := s.store.FindAllFiles(ctx)
+	sort.Slice(files, func(i, j int) bool { return files[i].ID < files[j].ID })
+
+	days := make(map[string]*SettlementDay)
+	add := func(effectiveEntryDate string, entry *SettlementEntry) {
+		date, ok := settlementDate(effectiveEntryDate)
+		if !ok || date.Before(from) || date.After(to) || entry.Amount == 0 {
+			return
+		}
+		if entry.CreditOrDebit != "C" && entry.CreditOrDebit != "D" {
+			return
+		}
+		key := date.Format(calendarDateFormat)
+		day, exists := days[key]
+		if !exists {
+			day = &SettlementDay{Date: key}
+			days[key] = day
+		}
+		if entry.CreditOrDebit == "C" {
+			day.TotalCredit += entry.Amount
+		} else {
+			day.TotalDebit += entry.Amount
+		}
+		entry.EffectiveEntryDate = effectiveEntryDate
+		day.Entries = append(day.Entries, entry)
+	}
+
+	for _, f := range files {
+		f.Visit(ach.FileVisitor{
+			Entry: func(batch ach.Batcher, entry *ach.EntryDetail) error {
+				bh := batch.GetHeader()
+				add(bh.EffectiveEntryDate, &SettlementEntry{
+					FileID:             f.ID,
+					BatchID:            batch.ID(),
+					StandardEntryClass: bh.StandardEntryClassCode,
+					CompanyName:        strings.TrimSpace(bh.CompanyName),
+					TraceNumber:        entry.TraceNumber,
+					IndividualName:     strings.TrimSpace(entry.IndividualName),
+					Amount:             entry.Amount,
+					CreditOrDebit:      entry.CreditOrDebit(),
+				})
+				return nil
+			},
+			IATEntry: func(iatBatch *ach.IATBatch, entry *ach.IATEntryDetail) error {
+				var name string
+				if entry.Addenda10 != nil {
+					name = strings.TrimSpace(entry.Addenda10.Name)
+				}
+				bh := iatBatch.GetHeader()
+				tran := ach.EntryDetail{TransactionCode: entry.TransactionCode}
+				add(bh.EffectiveEntryDate, &SettlementEntry{
+					FileID:             f.ID,
+					BatchID:            iatBatch.ID,
+					StandardEntryClass: bh.StandardEntryClassCode,
+					TraceNumber:        entry.TraceNumber,
+					IndividualName:     name,
+					Amount:             entry.Amount,
+					CreditOrDebit:      tran.CreditOrDebit(),
+				})
+				return nil
+			},
+		})
+	}
+
+	cal := &SettlementCalendar{
+		From: from.Format(calendarDateFormat),
+		To:   to.Format(calendarDateFormat),
+		Days: make([]*SettlementDay, 0, len(days)),
+	}
+	for _, day := range days {
+		cal.Days = append(cal.Days, day)
+	}
+	sort.Slice(cal.Days, func(i, j int) bool { return cal.Days[i].Date < cal.Days[j].Date })
+	return cal
+}
+
+// settlementDate returns the banking day an EffectiveEntryDate (YYMMDD) settles on
+func settlementDate(effectiveEntryDate string) (time.Time, bool) {
+	date, err := time.Parse("060102", effectiveEntryDate)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return ach.NextBankingDay(date, true), true
+}
+
+// writeICal writes the calendar as an iCalendar (RFC 5545) document with an all day event
+// for each SettlementDay. stamp is used as the DTSTAMP of every event.
+func (cal *SettlementCalendar) writeICal(w io.Writer, stamp time.Time) error {
+	var buf bytes.Buffer
+	line := func(format string, args ...interface{}) {
+		buf.WriteString(foldICalLine(fmt.Sprintf(format, args...)))
+		buf.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//moov-io//ach//EN")
+	line("CALSCALE:GREGORIAN")
+	for _, day := range cal.Days {
+		date := strings.Replace(day.Date, "-", "", -1)
+
+		var desc []string
+		for _, entry := range day.Entries {
+			desc = append(desc, fmt.Sprintf("%s %s %s %s %s", entry.TraceNumber, entry.StandardEntryClass, entry.CreditOrDebit, formatCents(entry.Amount), entry.IndividualName))
+		}
+
+		line("BEGIN:VEVENT")
+		line("UID:settlement-%s@ach.moov.io", date)
+		line("DTSTAMP:%s", stamp.UTC().Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE:%s", date)
+		line("SUMMARY:%s", escapeICalText(fmt.Sprintf("ACH settlement: debits %s credits %s", formatCents(day.TotalDebit), formatCents(day.TotalCredit))))
+		line("DESCRIPTION:%s", escapeICalText(strings.Join(desc, "\n")))
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// formatCents returns an amount in cents as dollars (e.g. 100000 is 1000.00)
+func formatCents(amount int) string {
+	return fmt.Sprintf("%d.%02d", amount/100, amount%100)
+}
+
+// escapeICalText escapes a TEXT property value according to RFC 5545 section 3.3.11
+func escapeICalText(v string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	return r.Replace(v)
+}
+
+// foldICalLine splits content lines longer than 75 octets, continuing them on lines
+// which start with a space according to RFC 5545 section 3.1
+func foldICalLine(v string) string {
+	const limit = 75
+
+	var out strings.Builder
+	n := 0
+	for _, r := range v {
+		size := len(string(r))
+		if n+size > limit {
+			out.WriteString("\r\n ")
+			n = 1
+		}
+		out.WriteRune(r)
+		n += size
+	}
+	return out.String()
+}
+
+// parseCalendarDate reads a YYYY-MM-DD query parameter, returning def when v is empty
+func parseCalendarDate(v string, def time.Time) (time.Time, error) {
+	if v == "" {
+		return def, nil
+	}
+	t, err := time.Parse(calendarDateFormat, v)
+	if err != nil {
+		return time.Time{}, invalid(fmt.Errorf("invalid date %q", v))
+	}
+	return t, nil
+}
+
+type settlementCalendarRequest struct {
+	from, to time.Time
+	ical     bool
+
+	requestID string
+}
+
+type settlementCalendarResponse struct {
+	*SettlementCalendar
+	Err error `json:"error"`
+
+	ical bool
+}
+
+func (r settlementCalendarResponse) error() error { return r.Err }
+
+func settlementCalendarEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(settlementCalendarRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return settlementCalendarResponse{
+				Err: err,
+			}, err
+		}
+
+		cal := s.SettlementCalendar(ctx, req.from, req.to)
+
+		logEvent(logger, "files", "settlementCalendar", nil, "requestID", req.requestID, "from", cal.From, "to", cal.To, "days", len(cal.Days))
+
+		return settlementCalendarResponse{
+			SettlementCalendar: cal,
+			ical:               req.ical,
+		}, nil
+	}
+}
+
+func decodeSettlementCalendarRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	q := r.URL.Query()
+	from, err := parseCalendarDate(q.Get("from"), today)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseCalendarDate(q.Get("to"), from.AddDate(0, 0, defaultCalendarWindow))
+	if err != nil {
+		return nil, err
+	}
+	if to.Before(from) {
+		return nil, invalid(fmt.Errorf("to %s is before from %s", to.Format(calendarDateFormat), from.Format(calendarDateFormat)))
+	}
+
+	req := settlementCalendarRequest{
+		from:      from,
+		to:        to,
+		requestID: moovhttp.GetRequestID(r),
+	}
+	switch format := strings.ToLower(q.Get("format")); format {
+	case "", "json":
+	case "ical", "ics":
+		req.ical = true
+	default:
+		return nil, invalid(fmt.Errorf("unknown format %q", format))
+	}
+	return req, nil
+}
+
+// encodeSettlementCalendarResponse writes an iCalendar document when requested, otherwise JSON
+func encodeSettlementCalendarResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp, ok := response.(settlementCalendarResponse)
+	if !ok || !resp.ical || resp.Err != nil {
+		return encodeResponse(ctx, w, response)
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	return resp.writeICal(w, time.Now())
+}
+
+// ExpectedSettlement is when an entry settles and its funds are available, see ach.ExpectedSettlement
+type ExpectedSettlement struct {
+	Date           string     `json:"date"`
+	Settles        time.Time  `json:"settles"`
+	SameDay        bool       `json:"sameDay"`
+	FundsAvailable *time.Time `json:"fundsAvailable,omitempty"`
+}
+
+type expectedSettlementRequest struct {
+	opts ach.SettlementOptions
+
+	requestID string
+}
+
+type expectedSettlementResponse struct {
+	*ExpectedSettlement
+	Err error `json:"error"`
+}
+
+func (r expectedSettlementResponse) error() error { return r.Err }
+
+func expectedSettlementEndpoint(logger log.Logger) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(expectedSettlementRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return expectedSettlementResponse{
+				Err: err,
+			}, err
+		}
+
+		settlement := ach.ExpectedSettlement(req.opts)
+		out := &ExpectedSettlement{
+			Date:    settlement.Date.String(),
+			Settles: settlement.Settles,
+			SameDay: settlement.SameDay,
+		}
+		if !settlement.FundsAvailable.IsZero() {
+			out.FundsAvailable = &settlement.FundsAvailable
+		}
+
+		logEvent(logger, "calendar", "expectedSettlement", nil, "requestID", req.requestID, "secCode", req.opts.SECCode, "date", out.Date, "sameDay", out.SameDay)
+
+		return expectedSettlementResponse{
+			ExpectedSettlement: out,
+		}, nil
+	}
+}
+
+func decodeExpectedSettlementRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	req := expectedSettlementRequest{
+		opts: ach.SettlementOptions{
+			SECCode: strings.ToUpper(strings.TrimSpace(q.Get("secCode"))),
+		},
+		requestID: moovhttp.GetRequestID(r),
+	}
+	if req.opts.SECCode == "" {
+		return nil, invalid(errors.New("missing secCode"))
+	}
+	switch v := strings.ToUpper(q.Get("creditOrDebit")); v {
+	case "C":
+		req.opts.Credit = true
+	case "D":
+	default:
+		return nil, invalid(fmt.Errorf("invalid creditOrDebit %q", v))
+	}
+
+	var err error
+	if v := q.Get("amount"); v != "" {
+		if req.opts.Amount, err = strconv.Atoi(v); err != nil || req.opts.Amount < 0 {
+			return nil, invalid(fmt.Errorf("invalid amount %q", v))
+		}
+	}
+	if v := q.Get("sameDay"); v != "" {
+		if req.opts.SameDay, err = strconv.ParseBool(v); err != nil {
+			return nil, invalid(fmt.Errorf("invalid sameDay %q", v))
+		}
+	}
+	if v := q.Get("effectiveEntryDate"); v != "" {
+		if req.opts.EffectiveEntryDate, err = ach.ParseDate(v); err != nil {
+			return nil, invalid(err)
+		}
+	}
+	if v := q.Get("submitted"); v != "" {
+		if req.opts.Submitted, err = time.Parse(time.RFC3339, v); err != nil {
+			return nil, invalid(fmt.Errorf("invalid submitted %q: %v", v, err))
+		}
+	}
+	return req, nil
+}
@@ -0,0 +1,248 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+)
+
+func calendarTestService(t *testing.T) Service {
+	t.Helper()
+	ctx := context.Background()
+
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+
+	bs, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, date := range map[string]string{
+		"friday":   "261016",
+		"saturday": "261017", // settles Monday
+		"columbus": "261012", // Columbus Day, settles Tuesday
+		"later":    "261201",
+	} {
+		file, err := ach.FileFromJSON(bs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.ID = id
+		file.Batches[0].GetHeader().EffectiveEntryDate = date
+		repo.StoreFile(ctx, file)
+	}
+	return NewService(repo)
+}
+
+func TestCalendar__SettlementCalendar(t *testing.T) {
+	ctx := context.Background()
+	svc := calendarTestService(t)
+
+	from := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.October, 31, 0, 0, 0, 0, time.UTC)
+	cal := svc.SettlementCalendar(ctx, from, to)
+
+	if cal.From != "2026-10-01" || cal.To != "2026-10-31" {
+		t.Errorf("from=%s to=%s", cal.From, cal.To)
+	}
+	if len(cal.Days) != 3 {
+		t.Fatalf("got %d days", len(cal.Days))
+	}
+	expected := []struct {
+		date   string
+		fileID string
+	}{
+		{"2026-10-13", "columbus"},
+		{"2026-10-16", "friday"},
+		{"2026-10-19", "saturday"},
+	}
+	for i, exp := range expected {
+		day := cal.Days[i]
+		if day.Date != exp.date || len(day.Entries) != 1 || day.Entries[0].FileID != exp.fileID {
+			t.Errorf("day %d: %#v", i, day)
+			continue
+		}
+		if day.TotalCredit != 100000 || day.TotalDebit != 0 {
+			t.Errorf("%s: credit=%d debit=%d", day.Date, day.TotalCredit, day.TotalDebit)
+		}
+		if entry := day.Entries[0]; entry.CreditOrDebit != "C" || entry.StandardEntryClass != ach.PPD || entry.TraceNumber == "" {
+			t.Errorf("%s: unexpected entry %#v", day.Date, entry)
+		}
+	}
+
+	// the window is inclusive
+	cal = svc.SettlementCalendar(ctx, to.AddDate(0, 1, 0), to.AddDate(0, 1, 1))
+	if len(cal.Days) != 1 || cal.Days[0].Date != "2026-12-01" {
+		t.Errorf("unexpected days: %#v", cal.Days)
+	}
+}
+
+func TestCalendar__writeICal(t *testing.T) {
+	cal := &SettlementCalendar{
+		Days: []*SettlementDay{
+			{
+				Date:        "2026-10-19",
+				TotalCredit: 100050,
+				Entries: []*SettlementEntry{
+					{TraceNumber: "121042880000001", StandardEntryClass: ach.PPD, CreditOrDebit: "C", Amount: 100050, IndividualName: "Smith, Jane; Acme Corporation Payroll Department Account"},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := cal.writeICal(&buf, time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, expected := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:settlement-20261019@ach.moov.io\r\n",
+		"DTSTAMP:20261016T120000Z\r\n",
+		"DTSTART;VALUE=DATE:20261019\r\n",
+		"SUMMARY:ACH settlement: debits 0.00 credits 1000.50\r\n",
+		`Smith\, Jane\; Acme`,
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("missing %q in:\n%s", expected, out)
+		}
+	}
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line is not folded: %q", line)
+		}
+	}
+}
+
+func TestCalendar__settlementCalendarEndpoint(t *testing.T) {
+	svc := calendarTestService(t)
+
+	router := mux.NewRouter()
+	router.Methods("GET").Path("/files/calendar").Handler(
+		httptransport.NewServer(settlementCalendarEndpoint(svc, log.NewNopLogger()), decodeSettlementCalendarRequest, encodeSettlementCalendarResponse,
+			httptransport.ServerErrorEncoder(encodeError)),
+	)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/files/calendar?from=2026-10-14&to=2026-10-31", nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var response SettlementCalendar
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Days) != 2 || response.Days[0].Date != "2026-10-16" {
+		t.Errorf("unexpected days: %#v", response.Days)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/files/calendar?from=2026-10-14&to=2026-10-31&format=ical", nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if v := w.Header().Get("Content-Type"); !strings.HasPrefix(v, "text/calendar") {
+		t.Errorf("Content-Type: %s", v)
+	}
+	if n := strings.Count(w.Body.String(), "BEGIN:VEVENT"); n != 2 {
+		t.Errorf("got %d events", n)
+	}
+
+	for _, query := range []string{"from=10/14/2026", "from=2026-10-14&to=2026-10-01", "format=csv"} {
+		w = httptest.NewRecorder()
+		req = httptest.NewRequest("GET", "/files/calendar?"+query, nil)
+		router.ServeHTTP(w, req)
+		w.Flush()
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: bogus HTTP status: %d: %s", query, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestCalendar__expectedSettlementEndpoint(t *testing.T) {
+	router := mux.NewRouter()
+	router.Methods("GET").Path("/settlement").Handler(
+		httptransport.NewServer(expectedSettlementEndpoint(log.NewNopLogger()), decodeExpectedSettlementRequest, encodeResponse,
+			httptransport.ServerErrorEncoder(encodeError)),
+	)
+
+	// Friday afternoon Eastern, after the last Same Day window
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/settlement?secCode=ppd&creditOrDebit=C&sameDay=true&submitted=2026-10-16T21:00:00Z", nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var response ExpectedSettlement
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Date != "2026-10-19" || response.SameDay || response.FundsAvailable == nil || response.FundsAvailable.UTC().Hour() != 13 {
+		t.Errorf("unexpected settlement: %#v", response)
+	}
+
+	// debits aren't made available
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/settlement?secCode=CCD&creditOrDebit=D&effectiveEntryDate=2026-10-21&submitted=2026-10-16T21:00:00Z", nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	var debit ExpectedSettlement
+	if err := json.NewDecoder(w.Body).Decode(&debit); err != nil {
+		t.Fatal(err)
+	}
+	if debit.Date != "2026-10-21" || debit.FundsAvailable != nil {
+		t.Errorf("unexpected settlement: %#v", debit)
+	}
+
+	for _, query := range []string{"creditOrDebit=C", "secCode=PPD", "secCode=PPD&creditOrDebit=C&amount=-1", "secCode=PPD&creditOrDebit=C&effectiveEntryDate=10/21/2026", "secCode=PPD&creditOrDebit=C&submitted=today"} {
+		w = httptest.NewRecorder()
+		req = httptest.NewRequest("GET", "/settlement?"+query, nil)
+		router.ServeHTTP(w, req)
+		w.Flush()
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: bogus HTTP status: %d: %s", query, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/ach"
+)
+
+// maxCachedContents is how many files have their rendered contents cached. An arbitrary
+// entry is dropped to make room for another once it's reached.
+const maxCachedContents = 1000
+
+// contentsReader is the plaintext contents of a file along with what's needed to answer
+// conditional requests for it.
+type contentsReader struct {
+	*bytes.Reader
+
+	etag     string    // hash of the contents, replaced by the fileETag of their revision when it's known
+	modified time.Time // zero when unknown
+
+	notModified bool
+}
+
+func newContentsReader(body []byte, modified time.Time) *contentsReader {
+	sum := sha256.Sum256(body)
+	return &contentsReader{
+		Reader:   bytes.NewReader(body),
+		etag:     `"` + hex.EncodeToString(sum[:16]) + `"`,
+		modified: modified,
+	}
+}
+
+// readContents returns r as a contentsReader, reading it into memory if needed
+func readContents(r io.Reader) (*contentsReader, error) {
+	if c, ok := r.(*contentsReader); ok {
+		return c, nil
+	}
+	bs, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return newContentsReader(bs, time.Time{}), nil
+}
+
+// notModifiedSince reports if a client sending ifNoneMatch and ifModifiedSince already has these contents.
+// If-None-Match takes precedence over If-Modified-Since as described in RFC 7232.
+func (c *contentsReader) notModifiedSince(ifNoneMatch, ifModifiedSince string) bool {
+	if ifNoneMatch != "" {
+		for _, tag := range strings.Split(ifNoneMatch, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == c.etag {
+				return true
+			}
+		}
+		return false
+	}
+	if ifModifiedSince == "" || c.modified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !c.modified.Truncate(time.Second).After(since)
+}
+
+func (c *contentsReader) setHeaders(w http.ResponseWriter) {
+	w.Header().Set("ETag", c.etag)
+	if !c.modified.IsZero() {
+		w.Header().Set("Last-Modified", c.modified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// contentsCache is implemented by repositories which cache rendered file contents, see NewContentsCacheRepository
+type contentsCache interface {
+	// contents returns the cached contents of a file, or loads and renders it. Loading happens on
+	// every call so files which expired or were removed aren't served from the cache.
+	contents(fileID string, load func() (*ach.File, error), render func(*ach.File) (io.Reader, error)) (*contentsReader, error)
+}
+
+type cachedContents struct {
+	body     []byte
+	modified time.Time
+}
+
+// contentsCacheRepository caches the rendered contents of files and drops them whenever the
+// file is changed through it
+type contentsCacheRepository struct {
+	Repository
+
+	mu sync.Mutex
+	// generation increases with every change so contents rendered while a file was being
+	// changed aren't cached
+	generation uint64
+	entries    map[string]*cachedContents
+}
+
+// NewContentsCacheRepository wraps r so the plaintext contents of each file are rendered once and
+// reused until the file is changed. It needs to be the outermost Repository given to NewService and
+// MakeHTTPHandler so every change to a file goes through it.
+//
+// Cached contents are kept in plaintext, so don't wrap a repository from NewRepositoryEncrypted.
+func NewContentsCacheRepository(r Repository) Repository {
+	return &contentsCacheRepository{
+		Repository: r,
+		entries:    make(map[string]*cachedContents),
+	}
+}
+
+func (r *contentsCacheRepository) contents(fileID string, load func() (*ach.File, error), render func(*ach.File) (io.Reader, error)) (*contentsReader, error) {
+	r.mu.Lock()
+	generation := r.generation
+	entry := r.entries[fileID]
+	r.mu.Unlock()
+
+	f, err := load()
+	if err != nil {
+		r.invalidate(fileID)
+		return nil, err
+	}
+	if entry != nil {
+		return newContentsReader(entry.body, entry.modified), nil
+	}
+
+	rendered, err := render(f)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(rendered)
+	if err != nil {
+		return nil, err
+	}
+	entry = &cachedContents{body: body, modified: time.Now()}
+
+	r.mu.Lock()
+	if generation == r.generation {
+		if len(r.entries) >= maxCachedContents {
+			for id := range r.entries {
+				delete(r.entries, id)
+				break
+			}
+		}
+		r.entries[fileID] = entry
+	}
+	r.mu.Unlock()
+
+	return newContentsReader(entry.body, entry.modified), nil
+}
+
+func (r *contentsCacheRepository) invalidate(fileID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	delete(r.entries, fileID)
+}
+
+func (r *contentsCacheRepository) StoreFile(ctx context.Context, f *ach.File) error {
+	defer r.invalidate(f.ID)
+	return r.Repository.StoreFile(ctx, f)
+}
+
+func (r *contentsCacheRepository) ReplaceFile(ctx context.Context, f *ach.File) error {
+	defer r.invalidate(f.ID)
+	return r.Repository.ReplaceFile(ctx, f)
+}
+
+func (r *contentsCacheRepository) DeleteFile(ctx context.Context, id string) error {
+	defer r.invalidate(id)
+	return r.Repository.DeleteFile(ctx, id)
+}
+
+func (r *contentsCacheRepository) RestoreFile(ctx context.Context, fileID string) (*ach.File, error) {
+	defer r.invalidate(fileID)
+	return r.Repository.RestoreFile(ctx, fileID)
+}
+
+func (r *contentsCacheRepository) StoreBatch(ctx context.Context, fileID string, batch ach.Batcher) error {
+	defer r.invalidate(fileID)
+	return r.Repository.StoreBatch(ctx, fileID, batch)
+}
+
+func (r *contentsCacheRepository) DeleteBatch(ctx context.Context, fileID string, batchID string) error {
+	defer r.invalidate(fileID)
+	return r.Repository.DeleteBatch(ctx, fileID, batchID)
+}
+
+func (r *contentsCacheRepository) RollbackFile(ctx context.Context, fileID string, version int) (*ach.File, error) {
+	defer r.invalidate(fileID)
+	return r.Repository.RollbackFile(ctx, fileID, version)
+}
+
+func (r *contentsCacheRepository) UpdateFile(ctx context.Context, fileID string, update func(*ach.File) error) (*ach.File, error) {
+	defer r.invalidate(fileID)
+	return r.Repository.UpdateFile(ctx, fileID, update)
+}
+
+func (r *contentsCacheRepository) UpdateFileAtRevision(ctx context.Context, fileID string, revision int, update func(*ach.File) error) (*ach.File, error) {
+	defer r.invalidate(fileID)
+	return r.Repository.UpdateFileAtRevision(ctx, fileID, revision, update)
+}
+
+func (r *contentsCacheRepository) DeleteFileAtRevision(ctx context.Context, id string, revision int) error {
+	defer r.invalidate(id)
+	return r.Repository.DeleteFileAtRevision(ctx, id, revision)
+}
@@ -0,0 +1,204 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestContents__conditionalRequests(t *testing.T) {
+	ctx := context.Background()
+	repo := NewContentsCacheRepository(NewRepositoryInMemory(testTTLDuration, nil))
+	router := MakeHTTPHandler(NewService(repo), repo, log.NewNopLogger())
+
+	f := ach.NewFile()
+	f.ID = "foo"
+	f.Header = *mockFileHeader()
+	f.AddBatch(mockBatchWEB())
+	second := mockBatchWEB()
+	second.SetID("batch-02")
+	f.AddBatch(second)
+	if err := repo.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		w.Flush()
+		return w
+	}
+
+	w := get("/files/foo/contents", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	etag, modified := w.Header().Get("ETag"), w.Header().Get("Last-Modified")
+	if etag == "" || modified == "" {
+		t.Fatalf("ETag=%q Last-Modified=%q", etag, modified)
+	}
+	body := w.Body.String()
+
+	// unchanged contents
+	for _, headers := range []map[string]string{
+		{"If-None-Match": etag},
+		{"If-None-Match": `"other", W/` + etag},
+		{"If-Modified-Since": modified},
+	} {
+		w = get("/files/foo/contents", headers)
+		if w.Code != http.StatusNotModified {
+			t.Errorf("%v: bogus HTTP status: %d", headers, w.Code)
+		}
+		if w.Body.Len() != 0 {
+			t.Errorf("%v: unexpected body: %s", headers, w.Body.String())
+		}
+	}
+	w = get("/files/foo/contents", map[string]string{"If-None-Match": `"other"`})
+	if w.Code != http.StatusOK || w.Body.String() != body {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	// changing the file renders new contents
+	if err := repo.DeleteBatch(ctx, "foo", "batch-02"); err != nil {
+		t.Fatal(err)
+	}
+	w = get("/files/foo/contents", map[string]string{"If-None-Match": etag})
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("ETag") == etag || w.Body.String() == body {
+		t.Errorf("expected new contents, ETag=%s", w.Header().Get("ETag"))
+	}
+
+	// masked contents have their own ETag
+	w = get("/files/foo/contents?masked=true", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if v := w.Header().Get("ETag"); v == "" || v == etag {
+		t.Errorf("masked ETag=%q", v)
+	}
+
+	// deleted files aren't served from the cache
+	if err := repo.DeleteFile(ctx, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if w = get("/files/foo/contents", nil); w.Code == http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestContents__cache(t *testing.T) {
+	ctx := context.Background()
+	repo := NewContentsCacheRepository(NewRepositoryInMemory(testTTLDuration, nil))
+	cache := repo.(contentsCache)
+
+	f := ach.NewFile()
+	f.ID = "foo"
+	f.Header = *mockFileHeader()
+	f.AddBatch(mockBatchWEB())
+	if err := repo.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+
+	renders := 0
+	render := func(f *ach.File) (io.Reader, error) {
+		renders++
+		return fileContents(f)
+	}
+	load := func() (*ach.File, error) {
+		return repo.FindFile(ctx, "foo")
+	}
+
+	first, err := cache.contents("foo", load, render)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := cache.contents("foo", load, render)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renders != 1 {
+		t.Errorf("rendered %d times", renders)
+	}
+	if first.etag != second.etag || !first.modified.Equal(second.modified) {
+		t.Errorf("first=%#v second=%#v", first, second)
+	}
+
+	if _, err := repo.UpdateFile(ctx, "foo", func(f *ach.File) error {
+		f.Header.ImmediateOriginName = "Other Bank"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	third, err := cache.contents("foo", load, render)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renders != 2 {
+		t.Errorf("rendered %d times", renders)
+	}
+	if third.etag == first.etag {
+		t.Errorf("expected a new ETag: %s", third.etag)
+	}
+}
+
+func TestContents__notModifiedSince(t *testing.T) {
+	modified := time.Date(2020, time.March, 1, 12, 30, 15, 500, time.UTC)
+	c := newContentsReader([]byte("contents"), modified)
+
+	cases := []struct {
+		ifNoneMatch, ifModifiedSince string
+		expected                     bool
+	}{
+		{"", "", false},
+		{"*", "", true},
+		{c.etag, "", true},
+		{"W/" + c.etag, "", true},
+		{`"a", ` + c.etag, "", true},
+		{`"a"`, modified.Format(http.TimeFormat), false}, // If-None-Match takes precedence
+		{"", modified.Format(http.TimeFormat), true},
+		{"", modified.Add(time.Hour).Format(http.TimeFormat), true},
+		{"", modified.Add(-time.Second).Format(http.TimeFormat), false},
+		{"", "yesterday", false},
+	}
+	for i := range cases {
+		if got := c.notModifiedSince(cases[i].ifNoneMatch, cases[i].ifModifiedSince); got != cases[i].expected {
+			t.Errorf("%d: If-None-Match=%q If-Modified-Since=%q got %v", i, cases[i].ifNoneMatch, cases[i].ifModifiedSince, got)
+		}
+	}
+
+	// contents without a modification time only match on ETag
+	c = newContentsReader([]byte("contents"), time.Time{})
+	if c.notModifiedSince("", modified.Format(http.TimeFormat)) {
+		t.Error("expected modified")
+	}
+}
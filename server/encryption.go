@@ -0,0 +1,640 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+)
+
+// ParseEncryptionKey reads an AES-128, AES-192 or AES-256 key from its base64 or hex encoding
+func ParseEncryptionKey(v string) ([]byte, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return nil, errors.New("empty encryption key")
+	}
+	key, err := hex.DecodeString(v)
+	if err != nil {
+		if key, err = base64.StdEncoding.DecodeString(v); err != nil {
+			return nil, errors.New("encryption key must be base64 or hex encoded")
+		}
+	}
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	}
+	return nil, fmt.Errorf("encryption key is %d bytes, expected 16, 24 or 32", len(key))
+}
+
+// sealedFile is a stored file encrypted with AES-GCM
+type sealedFile struct {
+	data []byte // nonce followed by the sealed protobuf encoding of the file
+
+	// creationDate is the file's FileCreationDate, kept in plaintext for TTL cleanup
+	creationDate string
+
+	// modifierKey and modifier are the routing numbers, date and FileIDModifier of the file's header,
+	// kept in plaintext for NextFileIDModifier
+	modifierKey fileIDModifierKey
+	modifier    string
+}
+
+// sealedVersion is a FileVersion with its JSON encrypted
+type sealedVersion struct {
+	version int
+	created time.Time
+	data    []byte
+}
+
+// deletedSealedFile is a sealedFile removed by DeleteFile, kept until it's restored or purged
+type deletedSealedFile struct {
+	file      *sealedFile
+	versions  []*sealedVersion
+	revision  int
+	lifecycle *FileLifecycle
+	deleted   time.Time
+}
+
+type repositoryEncrypted struct {
+	mtx       sync.RWMutex
+	files     map[string]*sealedFile
+	versions  map[string][]*sealedVersion
+	revisions map[string]int
+	statuses  map[string]*FileLifecycle
+	deleted   map[string]*deletedSealedFile
+
+	// modifiers is the last FileIDModifier reserved for each origin, destination and date
+	modifiers map[fileIDModifierKey]string
+
+	aead cipher.AEAD
+
+	ttl time.Duration
+
+	logger log.Logger
+}
+
+// NewRepositoryEncrypted is an in memory ach storage repository which keeps files and their versions
+// encrypted with AES-GCM under key, so raw ACH data is never held in plaintext. Files are decrypted on
+// every read and the copy returned is re-encrypted when stored again through the Repository.
+//
+// Files are encoded with ach.File.MarshalProto, so Batcher implementations which don't embed
+// ach.Batch can't be stored.
+func NewRepositoryEncrypted(key []byte, ttl time.Duration, logger log.Logger) (Repository, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	repo := &repositoryEncrypted{
+		files:     make(map[string]*sealedFile),
+		versions:  make(map[string][]*sealedVersion),
+		revisions: make(map[string]int),
+		statuses:  make(map[string]*FileLifecycle),
+		deleted:   make(map[string]*deletedSealedFile),
+		modifiers: make(map[fileIDModifierKey]string),
+		aead:      aead,
+		ttl:       ttl,
+		logger:    logger,
+	}
+
+	if ttl <= 0*time.Second {
+		// Don't run the cleanup if we've disabled the TTL
+		return repo, nil
+	}
+	go func() {
+		t := time.NewTicker(1 * time.Minute)
+		for range t.C {
+			repo.cleanupOldFiles()
+		}
+	}()
+	return repo, nil
+}
+
+// newAEAD returns AES-GCM with key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("problem with encryption key: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext under a random nonce, which is prepended to the result
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts data written by seal
+func open(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	n := aead.NonceSize()
+	if len(data) < n {
+		return nil, errors.New("sealed data is too short")
+	}
+	return aead.Open(nil, data[:n], data[n:], additionalData)
+}
+
+// seal encrypts plaintext with the file ID as additional data, so sealed data can't be moved between files
+func (r *repositoryEncrypted) seal(fileID string, plaintext []byte) ([]byte, error) {
+	return seal(r.aead, plaintext, []byte(fileID))
+}
+
+func (r *repositoryEncrypted) open(fileID string, data []byte) ([]byte, error) {
+	plaintext, err := open(r.aead, data, []byte(fileID))
+	if err != nil {
+		return nil, fmt.Errorf("problem decrypting file %s: %v", fileID, err)
+	}
+	return plaintext, nil
+}
+
+func (r *repositoryEncrypted) sealFile(f *ach.File) (*sealedFile, error) {
+	bs, err := f.MarshalProto()
+	if err != nil {
+		return nil, fmt.Errorf("problem encoding file %s: %v", f.ID, err)
+	}
+	data, err := r.seal(f.ID, bs)
+	if err != nil {
+		return nil, err
+	}
+	return &sealedFile{
+		data:         data,
+		creationDate: f.Header.FileCreationDate,
+		modifierKey:  fileHeaderModifierKey(f.Header),
+		modifier:     f.Header.FileIDModifier,
+	}, nil
+}
+
+func (r *repositoryEncrypted) openFile(fileID string, sealed *sealedFile) (*ach.File, error) {
+	bs, err := r.open(fileID, sealed.data)
+	if err != nil {
+		return nil, err
+	}
+	return ach.FileFromProto(bs)
+}
+
+// findFile decrypts a stored file. The caller must hold r.mtx
+func (r *repositoryEncrypted) findFile(fileID string) (*ach.File, error) {
+	sealed, ok := r.files[fileID]
+	if !ok || sealed == nil {
+		return nil, ErrNotFound
+	}
+	return r.openFile(fileID, sealed)
+}
+
+// storeFile encrypts f over any stored file with the same ID and advances its revision. The caller must hold r.mtx
+func (r *repositoryEncrypted) storeFile(f *ach.File) error {
+	sealed, err := r.sealFile(f)
+	if err != nil {
+		return err
+	}
+	r.files[f.ID] = sealed
+	r.revisions[f.ID]++
+	return nil
+}
+
+func (r *repositoryEncrypted) StoreFile(ctx context.Context, f *ach.File) error {
+	if f == nil {
+		return errors.New("nil ACH file provided")
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.files[f.ID]; ok {
+		return ErrAlreadyExists
+	}
+	if err := r.storeFile(f); err != nil {
+		return err
+	}
+	r.statuses[f.ID] = newFileLifecycle(f.ID, time.Now())
+	return nil
+}
+
+func (r *repositoryEncrypted) ReplaceFile(ctx context.Context, f *ach.File) error {
+	if f == nil {
+		return errors.New("nil ACH file provided")
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if existing, err := r.findFile(f.ID); err == nil {
+		if err := r.saveVersion(existing); err != nil {
+			return err
+		}
+	}
+	if err := r.storeFile(f); err != nil {
+		return err
+	}
+	if _, ok := r.statuses[f.ID]; !ok {
+		r.statuses[f.ID] = newFileLifecycle(f.ID, time.Now())
+	}
+	return nil
+}
+
+func (r *repositoryEncrypted) FindFile(ctx context.Context, id string) (*ach.File, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.findFile(id)
+}
+
+func (r *repositoryEncrypted) FindAllFiles(ctx context.Context) []*ach.File {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	files := make([]*ach.File, 0, len(r.files))
+	for id := range r.files {
+		f, err := r.findFile(id)
+		if err != nil {
+			logEvent(r.logger, "repository", "readFile", err, "fileID", id)
+			continue
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+func (r *repositoryEncrypted) DeleteFile(ctx context.Context, id string) error {
+	return r.DeleteFileAtRevision(ctx, id, 0)
+}
+
+func (r *repositoryEncrypted) DeleteFileAtRevision(ctx context.Context, id string, revision int) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if sealed, ok := r.files[id]; ok {
+		if err := checkRevision(id, r.revisions[id], revision); err != nil {
+			return err
+		}
+		r.deleted[id] = &deletedSealedFile{
+			file:      sealed,
+			versions:  r.versions[id],
+			revision:  r.revisions[id],
+			lifecycle: r.statuses[id],
+			deleted:   time.Now(),
+		}
+	} else if revision != 0 {
+		return ErrNotFound
+	}
+	delete(r.files, id)
+	delete(r.versions, id)
+	delete(r.revisions, id)
+	delete(r.statuses, id)
+	return nil
+}
+
+func (r *repositoryEncrypted) RestoreFile(ctx context.Context, fileID string) (*ach.File, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	d, ok := r.deleted[fileID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if _, ok := r.files[fileID]; ok {
+		return nil, fmt.Errorf("%w: file %s was stored again after being deleted", ErrAlreadyExists, fileID)
+	}
+	file, err := r.openFile(fileID, d.file)
+	if err != nil {
+		return nil, err
+	}
+	delete(r.deleted, fileID)
+	r.files[fileID] = d.file
+	r.versions[fileID] = d.versions
+	r.revisions[fileID] = d.revision + 1
+	r.statuses[fileID] = d.lifecycle
+	return file, nil
+}
+
+func (r *repositoryEncrypted) PurgeDeletedFiles(ctx context.Context, before time.Time) (int, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for id, d := range r.deleted {
+		if d.deleted.Before(before) {
+			removed++
+			delete(r.deleted, id)
+		}
+	}
+	return removed, nil
+}
+
+func (r *repositoryEncrypted) FileStatus(ctx context.Context, fileID string) (*FileLifecycle, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	lifecycle, ok := r.statuses[fileID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return lifecycle.copy(), nil
+}
+
+func (r *repositoryEncrypted) SetFileStatus(ctx context.Context, fileID string, status FileStatus, at time.Time) (*FileLifecycle, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	lifecycle, ok := r.statuses[fileID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err // the change was canceled while waiting for the lock
+	}
+	if err := lifecycle.advance(status, at); err != nil {
+		return nil, err
+	}
+	return lifecycle.copy(), nil
+}
+
+func (r *repositoryEncrypted) FileRevision(ctx context.Context, fileID string) (int, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if _, ok := r.files[fileID]; !ok {
+		return 0, ErrNotFound
+	}
+	return r.revisions[fileID], nil
+}
+
+func (r *repositoryEncrypted) NextFileIDModifier(ctx context.Context, origin, destination, date string) (string, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	key := newFileIDModifierKey(origin, destination, date)
+	used := make(map[string]bool)
+	for _, sealed := range r.files {
+		if sealed.modifierKey == key {
+			used[sealed.modifier] = true
+		}
+	}
+	for _, d := range r.deleted {
+		if d.file.modifierKey == key {
+			used[d.file.modifier] = true // so it's still unique when restored
+		}
+	}
+	next, err := nextFileIDModifier(key, r.modifiers[key], used)
+	if err != nil {
+		return "", err
+	}
+	r.modifiers[key] = next
+	return next, nil
+}
+
+func (r *repositoryEncrypted) StoreBatch(ctx context.Context, fileID string, batch ach.Batcher) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	file, err := r.findFile(fileID)
+	if err != nil {
+		return err
+	}
+	for _, val := range file.Batches {
+		if val.ID() == batch.ID() {
+			return ErrAlreadyExists
+		}
+	}
+	if err := r.saveVersion(file); err != nil {
+		return err
+	}
+	file.AddBatch(batch)
+	return r.storeFile(file)
+}
+
+func (r *repositoryEncrypted) FindBatch(ctx context.Context, fileID string, batchID string) (ach.Batcher, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	file, err := r.findFile(fileID)
+	if err != nil {
+		return nil, err
+	}
+	for _, val := range file.Batches {
+		if val.ID() == batchID {
+			return val, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *repositoryEncrypted) FindAllBatches(ctx context.Context, fileID string) []ach.Batcher {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	file, err := r.findFile(fileID)
+	if err != nil {
+		return nil
+	}
+	return file.Batches
+}
+
+func (r *repositoryEncrypted) DeleteBatch(ctx context.Context, fileID string, batchID string) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	file, err := r.findFile(fileID)
+	if err != nil {
+		return fmt.Errorf("%w: no file %s with batch %s found", ErrNotFound, fileID, batchID)
+	}
+	for i := len(file.Batches) - 1; i >= 0; i-- {
+		if file.Batches[i].ID() == batchID {
+			if err := r.saveVersion(file); err != nil {
+				return err
+			}
+			file.Batches = append(file.Batches[:i], file.Batches[i+1:]...)
+			return r.storeFile(file)
+		}
+	}
+	return ErrNotFound
+}
+
+func (r *repositoryEncrypted) SaveVersion(ctx context.Context, fileID string) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	file, err := r.findFile(fileID)
+	if err != nil {
+		return err
+	}
+	return r.saveVersion(file)
+}
+
+// saveVersion records the current state of file encrypted. The caller must hold r.mtx
+func (r *repositoryEncrypted) saveVersion(file *ach.File) error {
+	bs, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("problem saving version of file %s: %v", file.ID, err)
+	}
+	data, err := r.seal(file.ID, bs)
+	if err != nil {
+		return err
+	}
+
+	versions := r.versions[file.ID]
+	next := 1
+	if n := len(versions); n > 0 {
+		next = versions[n-1].version + 1
+	}
+	versions = append(versions, &sealedVersion{
+		version: next,
+		created: time.Now(),
+		data:    data,
+	})
+	if len(versions) > maxFileVersions {
+		versions = versions[len(versions)-maxFileVersions:]
+	}
+	r.versions[file.ID] = versions
+	return nil
+}
+
+func (r *repositoryEncrypted) FindVersions(ctx context.Context, fileID string) ([]*FileVersion, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	if _, ok := r.files[fileID]; !ok {
+		return nil, ErrNotFound
+	}
+	versions := make([]*FileVersion, 0, len(r.versions[fileID]))
+	for _, v := range r.versions[fileID] {
+		bs, err := r.open(fileID, v.data)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, &FileVersion{
+			Version: v.version,
+			Created: v.created,
+			File:    bs,
+		})
+	}
+	return versions, nil
+}
+
+func (r *repositoryEncrypted) RollbackFile(ctx context.Context, fileID string, version int) (*ach.File, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	current, err := r.findFile(fileID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range r.versions[fileID] {
+		if v.version != version {
+			continue
+		}
+		bs, err := r.open(fileID, v.data)
+		if err != nil {
+			return nil, err
+		}
+		file, err := fileFromVersion(fileID, v.version, bs)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.saveVersion(current); err != nil {
+			return nil, err
+		}
+		if err := r.storeFile(file); err != nil {
+			return nil, err
+		}
+		return file, nil
+	}
+	return nil, ErrNotFound
+}
+
+// UpdateFile decrypts a file for update and stores the result encrypted. Changes are discarded when update returns an error.
+func (r *repositoryEncrypted) UpdateFile(ctx context.Context, fileID string, update func(*ach.File) error) (*ach.File, error) {
+	return r.UpdateFileAtRevision(ctx, fileID, 0, update)
+}
+
+func (r *repositoryEncrypted) UpdateFileAtRevision(ctx context.Context, fileID string, revision int, update func(*ach.File) error) (*ach.File, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	file, err := r.findFile(fileID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRevision(fileID, r.revisions[fileID], revision); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err // the change was canceled while waiting for the lock
+	}
+	// file is decrypted for this call, so it can be changed. The version is saved from
+	// another copy once the update succeeds.
+	if err := update(file); err != nil {
+		return nil, err
+	}
+	previous, err := r.findFile(fileID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.saveVersion(previous); err != nil {
+		return nil, err
+	}
+	return file, r.storeFile(file)
+}
+
+// cleanupOldFiles deletes files whose FileCreationDate is older than the TTL
+func (r *repositoryEncrypted) cleanupOldFiles() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	removed := 0
+	tooOld := time.Now().Add(-1 * r.ttl)
+	tooOldStr := tooOld.Format("060102") // YYMMDD
+
+	for id := range r.files {
+		if r.files[id].creationDate < tooOldStr {
+			removed++
+			delete(r.files, id)
+			delete(r.versions, id)
+			delete(r.revisions, id)
+			delete(r.statuses, id)
+		}
+	}
+	for id, d := range r.deleted {
+		if d.file.creationDate < tooOldStr {
+			removed++
+			delete(r.deleted, id)
+		}
+	}
+	for key := range r.modifiers {
+		if key.date < tooOldStr {
+			delete(r.modifiers, key)
+		}
+	}
+
+	logEvent(r.logger, "repository", "cleanupOldFiles", nil, "removed", removed, "olderThan", tooOld.Format(time.RFC3339))
+}
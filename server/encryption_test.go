@@ -0,0 +1,188 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/ach"
+)
+
+var testEncryptionKey = bytes.Repeat([]byte{0x42}, 32)
+
+func TestParseEncryptionKey(t *testing.T) {
+	cases := map[string]int{
+		"000102030405060708090a0b0c0d0e0f":                16,
+		"QkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkI=":    32,
+		" QkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkI=\n": 32,
+	}
+	for in, n := range cases {
+		key, err := ParseEncryptionKey(in)
+		if err != nil || len(key) != n {
+			t.Errorf("%q: key=%x error=%v", in, key, err)
+		}
+	}
+	for _, in := range []string{"", "not a key", "0001020304"} {
+		if _, err := ParseEncryptionKey(in); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+}
+
+func TestRepositoryEncrypted(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewRepositoryEncrypted(testEncryptionKey, testTTLDuration, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := readPPDValidFile(t)
+	file.Batches[0].SetID("batch-01")
+	account := strings.TrimSpace(file.Batches[0].GetEntries()[0].DFIAccountNumber)
+	if err := repo.StoreFile(ctx, file); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.StoreFile(ctx, file); err != ErrAlreadyExists {
+		t.Errorf("expected ErrAlreadyExists: %v", err)
+	}
+
+	// nothing is held in plaintext
+	sealed := repo.(*repositoryEncrypted).files[file.ID]
+	if bytes.Contains(sealed.data, []byte(account)) || bytes.Contains(sealed.data, []byte(file.Header.ImmediateOriginName)) {
+		t.Error("stored file isn't encrypted")
+	}
+
+	found, err := repo.FindFile(ctx, file.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found == file || found.Batches[0].GetEntries()[0].DFIAccountNumber != file.Batches[0].GetEntries()[0].DFIAccountNumber {
+		t.Errorf("unexpected file: %#v", found)
+	}
+	if b, err := repo.FindBatch(ctx, file.ID, "batch-01"); err != nil || b.ID() != "batch-01" {
+		t.Errorf("batch=%v error=%v", b, err)
+	}
+	if files := repo.FindAllFiles(ctx); len(files) != 1 {
+		t.Errorf("found %d files", len(files))
+	}
+
+	// changes are kept through UpdateFile and recorded as versions
+	updated, err := repo.UpdateFile(ctx, file.ID, func(f *ach.File) error {
+		f.Header.ImmediateOriginName = "Other Bank"
+		return nil
+	})
+	if err != nil || updated.Header.ImmediateOriginName != "Other Bank" {
+		t.Fatalf("updated=%#v error=%v", updated, err)
+	}
+	if found, _ := repo.FindFile(ctx, file.ID); found.Header.ImmediateOriginName != "Other Bank" {
+		t.Errorf("update wasn't stored: %q", found.Header.ImmediateOriginName)
+	}
+	versions, err := repo.FindVersions(ctx, file.ID)
+	if err != nil || len(versions) != 1 || !bytes.Contains(versions[0].File, []byte(file.Header.ImmediateOriginName)) {
+		t.Fatalf("versions=%#v error=%v", versions, err)
+	}
+	if bytes.Contains(repo.(*repositoryEncrypted).versions[file.ID][0].data, []byte(account)) {
+		t.Error("stored version isn't encrypted")
+	}
+	rolledBack, err := repo.RollbackFile(ctx, file.ID, 1)
+	if err != nil || rolledBack.Header.ImmediateOriginName != file.Header.ImmediateOriginName {
+		t.Errorf("rolledBack=%#v error=%v", rolledBack, err)
+	}
+
+	if err := repo.StoreBatch(ctx, file.ID, mockBatchWEB()); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(repo.FindAllBatches(ctx, file.ID)); n != 2 {
+		t.Errorf("found %d batches", n)
+	}
+	if err := repo.DeleteBatch(ctx, file.ID, rolledBack.Batches[0].ID()); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(repo.FindAllBatches(ctx, file.ID)); n != 1 {
+		t.Errorf("found %d batches", n)
+	}
+
+	if err := repo.DeleteFile(ctx, file.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.FindFile(ctx, file.ID); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound: %v", err)
+	}
+}
+
+func TestRepositoryEncrypted__wrongKey(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewRepositoryEncrypted(testEncryptionKey, testTTLDuration, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := readPPDValidFile(t)
+	if err := repo.StoreFile(ctx, file); err != nil {
+		t.Fatal(err)
+	}
+
+	other, _ := NewRepositoryEncrypted(bytes.Repeat([]byte{0x24}, 32), testTTLDuration, nil)
+	other.(*repositoryEncrypted).files = repo.(*repositoryEncrypted).files
+	if _, err := other.FindFile(ctx, file.ID); err == nil {
+		t.Error("expected decryption error")
+	}
+
+	if _, err := NewRepositoryEncrypted([]byte("short"), testTTLDuration, nil); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestRepositoryEncrypted__service(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewRepositoryEncrypted(testEncryptionKey, testTTLDuration, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := NewService(repo)
+	file := storeAddendaTestFile(t, repo)
+	seq := entrySequenceNumber(file.Batches[0].GetEntries()[0])
+
+	// the service's changes to files are stored encrypted
+	id, err := svc.CreateAddenda05(ctx, file.ID, "batch-01", seq, mockAddenda05())
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := svc.GetEntry(ctx, file.ID, "batch-01", seq)
+	if err != nil || len(entry.Addenda05) != 1 || entry.Addenda05[0].ID != id {
+		t.Fatalf("entry=%#v error=%v", entry, err)
+	}
+	if err := svc.DeleteAddenda05(ctx, file.ID, "batch-01", seq, id); err != nil {
+		t.Fatal(err)
+	}
+	if entry, _ := svc.GetEntry(ctx, file.ID, "batch-01", seq); len(entry.Addenda05) != 0 {
+		t.Errorf("Addenda05 wasn't removed: %#v", entry.Addenda05)
+	}
+
+	name := "Patched Bank"
+	if _, err := svc.PatchFileHeader(ctx, file.ID, &FileHeaderPatch{ImmediateOriginName: &name}); err != nil {
+		t.Fatal(err)
+	}
+	if f, _ := svc.GetFile(ctx, file.ID); f.Header.ImmediateOriginName != "Patched Bank" {
+		t.Errorf("patch wasn't stored: %q", f.Header.ImmediateOriginName)
+	}
+	if versions, _ := svc.GetFileVersions(ctx, file.ID); len(versions) != 3 {
+		t.Errorf("found %d versions", len(versions))
+	}
+}
@@ -0,0 +1,329 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/moov-io/ach"
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+)
+
+// decodeBatchPath reads the fileID and batchID path variables shared by the entries routes
+func decodeBatchPath(r *http.Request) (fileID string, batchID string, err error) {
+	vars := mux.Vars(r)
+	fileID, ok := vars["fileID"]
+	if !ok {
+		return "", "", ErrBadRouting
+	}
+	batchID, ok = vars["batchID"]
+	if !ok {
+		return "", "", ErrBadRouting
+	}
+	return fileID, batchID, nil
+}
+
+// decodeEntryBody reads an EntryDetail from a request body
+func decodeEntryBody(r *http.Request) (*ach.EntryDetail, error) {
+	bs, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := ach.EntryDetailFromJSON(bs)
+	if err != nil {
+		return nil, invalid(err)
+	}
+	return entry, nil
+}
+
+type getEntriesRequest struct {
+	fileID  string
+	batchID string
+	masked  bool
+
+	requestID string
+}
+
+type getEntriesResponse struct {
+	Entries []*ach.EntryDetail `json:"entries"`
+	Err     error              `json:"error"`
+}
+
+func (r getEntriesResponse) count() int { return len(r.Entries) }
+
+func (r getEntriesResponse) error() error { return r.Err }
+
+func getEntriesEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(getEntriesRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return getEntriesResponse{
+				Err: err,
+			}, err
+		}
+
+		var entries []*ach.EntryDetail
+		var err error
+		if req.masked {
+			var batch ach.Batcher
+			if batch, err = getMaskedBatch(ctx, s, req.fileID, req.batchID); err == nil {
+				entries = batch.GetEntries()
+			}
+		} else {
+			entries, err = s.GetEntries(ctx, req.fileID, req.batchID)
+		}
+
+		logEvent(logger, "entries", "getEntries", err, "requestID", req.requestID, "fileID", req.fileID, "batchID", req.batchID)
+
+		return getEntriesResponse{
+			Entries: entries,
+			Err:     err,
+		}, nil
+	}
+}
+
+func decodeGetEntriesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req getEntriesRequest
+	req.requestID = moovhttp.GetRequestID(r)
+
+	fileID, batchID, err := decodeBatchPath(r)
+	if err != nil {
+		return nil, err
+	}
+	req.fileID, req.batchID = fileID, batchID
+	if req.masked, err = parseMasked(r); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+type getEntryRequest struct {
+	fileID  string
+	batchID string
+	seq     int
+	masked  bool
+
+	requestID string
+}
+
+type entryResponse struct {
+	Entry *ach.EntryDetail `json:"entry"`
+	Err   error            `json:"error"`
+}
+
+func (r entryResponse) error() error { return r.Err }
+
+func getEntryEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(getEntryRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return entryResponse{
+				Err: err,
+			}, err
+		}
+
+		var entry *ach.EntryDetail
+		var err error
+		if req.masked {
+			var batch ach.Batcher
+			if batch, err = getMaskedBatch(ctx, s, req.fileID, req.batchID); err == nil {
+				_, entry, err = batchEntry(batch, req.seq)
+			}
+		} else {
+			entry, err = s.GetEntry(ctx, req.fileID, req.batchID, req.seq)
+		}
+
+		logEvent(logger, "entries", "getEntry", err, "requestID", req.requestID, "fileID", req.fileID, "batchID", req.batchID)
+
+		return entryResponse{
+			Entry: entry,
+			Err:   err,
+		}, nil
+	}
+}
+
+func decodeGetEntryRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req getEntryRequest
+	req.requestID = moovhttp.GetRequestID(r)
+
+	fileID, batchID, seq, err := decodeEntryPath(r)
+	if err != nil {
+		return nil, err
+	}
+	req.fileID, req.batchID, req.seq = fileID, batchID, seq
+	if req.masked, err = parseMasked(r); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+type createEntryRequest struct {
+	fileID  string
+	batchID string
+	entry   *ach.EntryDetail
+	opts    []ChangeOption
+
+	requestID string
+}
+
+func createEntryEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(createEntryRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return entryResponse{
+				Err: err,
+			}, err
+		}
+
+		entry, err := s.CreateEntry(ctx, req.fileID, req.batchID, req.entry, req.opts...)
+
+		logEvent(logger, "entries", "createEntry", err, "requestID", req.requestID, "fileID", req.fileID, "batchID", req.batchID)
+
+		return entryResponse{
+			Entry: entry,
+			Err:   err,
+		}, nil
+	}
+}
+
+func decodeCreateEntryRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req createEntryRequest
+	req.requestID = moovhttp.GetRequestID(r)
+
+	fileID, batchID, err := decodeBatchPath(r)
+	if err != nil {
+		return nil, err
+	}
+	req.fileID, req.batchID = fileID, batchID
+	if req.opts, err = parseIfMatch(r); err != nil {
+		return nil, err
+	}
+	if req.entry, err = decodeEntryBody(r); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+type replaceEntryRequest struct {
+	fileID  string
+	batchID string
+	seq     int
+	entry   *ach.EntryDetail
+	opts    []ChangeOption
+
+	requestID string
+}
+
+func replaceEntryEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(replaceEntryRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return entryResponse{
+				Err: err,
+			}, err
+		}
+
+		entry, err := s.ReplaceEntry(ctx, req.fileID, req.batchID, req.seq, req.entry, req.opts...)
+
+		logEvent(logger, "entries", "replaceEntry", err, "requestID", req.requestID, "fileID", req.fileID, "batchID", req.batchID)
+
+		return entryResponse{
+			Entry: entry,
+			Err:   err,
+		}, nil
+	}
+}
+
+func decodeReplaceEntryRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req replaceEntryRequest
+	req.requestID = moovhttp.GetRequestID(r)
+
+	fileID, batchID, seq, err := decodeEntryPath(r)
+	if err != nil {
+		return nil, err
+	}
+	req.fileID, req.batchID, req.seq = fileID, batchID, seq
+	if req.opts, err = parseIfMatch(r); err != nil {
+		return nil, err
+	}
+	if req.entry, err = decodeEntryBody(r); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+type deleteEntryRequest struct {
+	fileID  string
+	batchID string
+	seq     int
+	opts    []ChangeOption
+
+	requestID string
+}
+
+type deleteEntryResponse struct {
+	Err error `json:"error"`
+}
+
+func (r deleteEntryResponse) error() error { return r.Err }
+
+func deleteEntryEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(deleteEntryRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return deleteEntryResponse{
+				Err: err,
+			}, err
+		}
+
+		err := s.DeleteEntry(ctx, req.fileID, req.batchID, req.seq, req.opts...)
+
+		logEvent(logger, "entries", "deleteEntry", err, "requestID", req.requestID, "fileID", req.fileID, "batchID", req.batchID)
+
+		return deleteEntryResponse{
+			Err: err,
+		}, nil
+	}
+}
+
+func decodeDeleteEntryRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req deleteEntryRequest
+	req.requestID = moovhttp.GetRequestID(r)
+
+	fileID, batchID, seq, err := decodeEntryPath(r)
+	if err != nil {
+		return nil, err
+	}
+	req.fileID, req.batchID, req.seq = fileID, batchID, seq
+	if req.opts, err = parseIfMatch(r); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
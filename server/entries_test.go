@@ -0,0 +1,151 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestFiles__entriesEndpoints(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	router := MakeHTTPHandler(NewService(repo), repo, logger)
+
+	f := ach.NewFile()
+	f.ID = "entries"
+	f.SetHeader(*mockFileHeader())
+	f.AddBatch(mockBatchWEB())
+	if err := repo.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(method, path string, entry *ach.EntryDetail, ifMatch string) *httptest.ResponseRecorder {
+		var body io.Reader
+		if entry != nil {
+			var buf bytes.Buffer
+			if err := json.NewEncoder(&buf).Encode(entry); err != nil {
+				t.Fatal(err)
+			}
+			body = &buf
+		}
+		req := httptest.NewRequest(method, "/files/entries/batches/54321/entries"+path, body)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		w.Flush()
+		return w
+	}
+	control := func() *ach.BatchControl {
+		found, _ := repo.FindFile(ctx, f.ID)
+		return found.Batches[0].GetControl()
+	}
+
+	// add an entry, which is numbered after the existing one
+	entry := mockWEBEntryDetail()
+	entry.TraceNumber = ""
+	entry.Amount = 2500
+	entry.AddendaRecordIndicator = 1
+	w := do("POST", "", entry, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var resp entryResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Entry == nil || resp.Entry.TraceNumber != "121042880000002" {
+		t.Fatalf("unexpected entry: %#v", resp.Entry)
+	}
+	if c := control(); c.EntryAddendaCount != 4 || c.TotalCreditEntryDollarAmount != 100002500 {
+		t.Errorf("unexpected control: %#v", c)
+	}
+
+	// list and get
+	w = do("GET", "", nil, "")
+	if w.Code != http.StatusOK || w.Header().Get("X-Total-Count") != "2" {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = do("GET", "/2", nil, ""); w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = do("GET", "/3", nil, ""); w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	// replace keeps the trace number
+	entry = mockWEBEntryDetail()
+	entry.TraceNumber = ""
+	entry.Amount = 5000
+	entry.AddendaRecordIndicator = 1
+	if w = do("PUT", "/2", entry, ""); w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if c := control(); c.TotalCreditEntryDollarAmount != 100005000 {
+		t.Errorf("unexpected control: %#v", c)
+	}
+	if _, e, err := fileEntry(mustFindFile(t, repo, f.ID), "54321", 2); err != nil || e.Amount != 5000 {
+		t.Errorf("entry=%#v error=%v", e, err)
+	}
+
+	// invalid entries aren't stored
+	entry.TransactionCode = 0
+	if w = do("PUT", "/2", entry, ""); w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = do("DELETE", "/2", nil, `"1"`); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	// delete
+	if w = do("DELETE", "/2", nil, `"3"`); w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if c := control(); c.EntryAddendaCount != 2 || c.TotalCreditEntryDollarAmount != 100000000 {
+		t.Errorf("unexpected control: %#v", c)
+	}
+	// a batch can't lose its last entry
+	if w = do("DELETE", "/1", nil, ""); w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if rev, _ := repo.FileRevision(ctx, f.ID); rev != 4 {
+		t.Errorf("revision=%d", rev)
+	}
+}
+
+func mustFindFile(t *testing.T, repo Repository, id string) *ach.File {
+	t.Helper()
+	ctx := context.Background()
+	f, err := repo.FindFile(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
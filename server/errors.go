@@ -0,0 +1,132 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// The Service groups its errors into a few kinds which codeFrom maps onto HTTP status codes.
+// Check for a kind with errors.Is, for example errors.Is(err, ErrInvalid).
+var (
+	// ErrNotFound is returned when a file, batch, entry or addenda record doesn't exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrInvalid is returned when a request or the ACH file it refers to is malformed.
+	ErrInvalid = errors.New("invalid")
+
+	// ErrConflict is returned when a request conflicts with a file or record already stored.
+	ErrConflict = errors.New("conflict")
+
+	// ErrTooLarge is returned when a request body exceeds the size accepted by the server.
+	ErrTooLarge = errors.New("too large")
+
+	// ErrUnauthorized is returned when the caller isn't allowed to perform the request.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrTooManyRequests is returned when a caller exceeds the request rate allowed by the server.
+	ErrTooManyRequests = errors.New("too many requests")
+
+	// ErrForbidden is returned when an authenticated caller lacks the scope or role a request requires,
+	// or isn't allowed to make a change, such as approving a file they submitted.
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrPreconditionFailed is returned when a file has changed since the revision a request expected.
+	ErrPreconditionFailed = errors.New("precondition failed")
+
+	// ErrAlreadyExists is returned when storing a file or record whose ID is already used.
+	ErrAlreadyExists = conflict(errors.New("already exists"))
+)
+
+// kindError tags err with one of the error kinds above without changing its message.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string { return e.err.Error() }
+
+func (e *kindError) Unwrap() error { return e.err }
+
+func (e *kindError) Is(target error) bool { return target == e.kind }
+
+func invalid(err error) error {
+	return &kindError{kind: ErrInvalid, err: err}
+}
+
+func conflict(err error) error {
+	return &kindError{kind: ErrConflict, err: err}
+}
+
+func tooLarge(err error) error {
+	return &kindError{kind: ErrTooLarge, err: err}
+}
+
+func tooManyRequests(err error) error {
+	return &kindError{kind: ErrTooManyRequests, err: err}
+}
+
+func unauthorized(err error) error {
+	return &kindError{kind: ErrUnauthorized, err: err}
+}
+
+func forbidden(err error) error {
+	return &kindError{kind: ErrForbidden, err: err}
+}
+
+func preconditionFailed(err error) error {
+	return &kindError{kind: ErrPreconditionFailed, err: err}
+}
+
+// statusClientClosedRequest is logged for requests canceled by the client disconnecting,
+// as done by nginx. The client never sees the response.
+const statusClientClosedRequest = 499
+
+// codeFrom returns the HTTP status code for err based on its kind.
+// Errors without a kind are treated as internal server errors.
+func codeFrom(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrTooLarge):
+		// checked before ErrInvalid as decoders wrap errors reading the body with invalid()
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrInvalid):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrTooManyRequests):
+		return http.StatusTooManyRequests
+	case errors.Is(err, ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.Canceled):
+		return statusClientClosedRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,88 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestErrors__codeFrom(t *testing.T) {
+	cases := []struct {
+		err  error
+		code int
+	}{
+		{nil, http.StatusOK},
+		{fmt.Errorf("%w: other", errInvalidFile), http.StatusBadRequest},
+		{errInvalidSearch, http.StatusBadRequest},
+		{invalid(errors.New("bad JSON")), http.StatusBadRequest},
+		{ErrNotFound, http.StatusNotFound},
+		{fmt.Errorf("%w: no file foo with batch bar found", ErrNotFound), http.StatusNotFound},
+		{ErrAlreadyExists, http.StatusConflict},
+		{errFileConflict, http.StatusConflict},
+		{fmt.Errorf("%w: matches other", errDuplicateFile), http.StatusConflict},
+		{tooLarge(errors.New("big")), http.StatusRequestEntityTooLarge},
+		{invalid(tooLarge(errors.New("big"))), http.StatusRequestEntityTooLarge},
+		{tooManyRequests(errors.New("slow down")), http.StatusTooManyRequests},
+		{ErrUnauthorized, http.StatusUnauthorized},
+		{ErrForbidden, http.StatusForbidden},
+		{preconditionFailed(errors.New("changed")), http.StatusPreconditionFailed},
+		{context.DeadlineExceeded, http.StatusServiceUnavailable},
+		{fmt.Errorf("building: %w", context.Canceled), statusClientClosedRequest},
+		{errors.New("other"), http.StatusInternalServerError},
+	}
+	for i := range cases {
+		if v := codeFrom(cases[i].err); v != cases[i].code {
+			t.Errorf("%v: HTTP status: %d", cases[i].err, v)
+		}
+	}
+}
+
+func TestErrors__kindError(t *testing.T) {
+	err := invalid(errors.New("bad JSON"))
+	if err.Error() != "bad JSON" {
+		t.Errorf("unexpected message: %v", err)
+	}
+	if !errors.Is(err, ErrInvalid) || errors.Is(err, ErrConflict) {
+		t.Errorf("unexpected kind: %v", err)
+	}
+	if !errors.Is(fmt.Errorf("problem reading file: %w", ErrAlreadyExists), ErrAlreadyExists) {
+		t.Error("expected ErrAlreadyExists")
+	}
+}
+
+func TestErrors__createFileTooLarge(t *testing.T) {
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	router := MakeHTTPHandler(NewService(repo), repo, log.NewNopLogger(), WithMaxBodySize(10))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/files/create", bytes.NewReader(make([]byte, 11)))
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,550 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"crypto/cipher"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/base"
+
+	"github.com/go-kit/kit/log"
+)
+
+// EventType names a change to a stored file
+type EventType string
+
+const (
+	// FileCreated is published when a file is stored, including when an existing file is overwritten or a deleted file is restored
+	FileCreated EventType = "file.created"
+	// FileValidated is published after a stored file is validated, with any validation error
+	FileValidated EventType = "file.validated"
+	// FileDeleted is published when a file is removed from storage
+	FileDeleted EventType = "file.deleted"
+)
+
+// Event describes a change to a stored file. It's published to the subject "<topic>.<type>",
+// for example "ach.file.created".
+type Event struct {
+	ID      string    `json:"id"`
+	Type    EventType `json:"type"`
+	FileID  string    `json:"fileID"`
+	Created time.Time `json:"created"`
+	// Error is the validation error of a FileValidated event, empty when the file is valid
+	Error string `json:"error,omitempty"`
+	// File is the stored file of a FileCreated event
+	File *ach.File `json:"file,omitempty"`
+}
+
+// EventPublisher delivers file events to a message broker
+type EventPublisher interface {
+	Publish(evt *Event) error
+	Close() error
+}
+
+// EventSender writes one encoded event to a broker. A NATS sender is built in with NewNATSSender;
+// other brokers such as Kafka can be supported by implementing this interface.
+type EventSender interface {
+	Send(subject string, payload []byte) error
+}
+
+// EventConfig controls how events are encoded and delivered by NewEventPublisher
+type EventConfig struct {
+	// Topic is the subject prefix events are published under. Defaults to "ach".
+	Topic string
+	// Format is the payload encoding, "json" (the default) or "proto"
+	Format string
+	// SpoolDir keeps undelivered events on disk so they're sent after a restart.
+	// When empty events are only retried while the process runs.
+	SpoolDir string
+	// SpoolKey encrypts spooled events with AES-GCM, as FileCreated events carry the whole file.
+	// It's normally the storage encryption key given to NewRepositoryEncrypted. Spool files
+	// which can't be decrypted with it are skipped.
+	SpoolKey []byte
+	// RetryInterval is how long to wait after a failed send before trying again. Defaults to 5s.
+	RetryInterval time.Duration
+}
+
+var (
+	// ErrEventFormat is returned for an EventConfig.Format other than "json" or "proto"
+	ErrEventFormat = errors.New("unknown event format")
+)
+
+const spoolSuffix = ".event"
+
+type pendingEvent struct {
+	subject string
+	payload []byte
+	path    string // spool file, empty without a SpoolDir
+}
+
+type eventPublisher struct {
+	sender EventSender
+	cfg    EventConfig
+	logger log.Logger
+
+	spoolAEAD cipher.AEAD // nil when spooled events aren't encrypted
+
+	mu      sync.Mutex
+	pending []*pendingEvent
+	seq     uint64
+
+	notify chan struct{}
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewEventPublisher returns an EventPublisher which sends events with sender in the order
+// they were published. Events are queued and retried until sender accepts them, and with a
+// SpoolDir set they're written to disk first and any left over from a prior run are sent again.
+func NewEventPublisher(sender EventSender, cfg EventConfig, logger log.Logger) (EventPublisher, error) {
+	if sender == nil {
+		return nil, errors.New("nil EventSender")
+	}
+	switch cfg.Format {
+	case "":
+		cfg.Format = "json"
+	case "json", "proto":
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrEventFormat, cfg.Format)
+	}
+	if cfg.Topic == "" {
+		cfg.Topic = "ach"
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = 5 * time.Second
+	}
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+	p := &eventPublisher{
+		sender: sender,
+		cfg:    cfg,
+		logger: logger,
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	if len(cfg.SpoolKey) > 0 {
+		aead, err := newAEAD(cfg.SpoolKey)
+		if err != nil {
+			return nil, err
+		}
+		p.spoolAEAD = aead
+	}
+	if cfg.SpoolDir != "" {
+		if err := p.loadSpool(); err != nil {
+			return nil, err
+		}
+	}
+	p.wg.Add(1)
+	go p.run()
+	return p, nil
+}
+
+func (p *eventPublisher) Publish(evt *Event) error {
+	if evt == nil {
+		return errors.New("nil Event")
+	}
+	if evt.ID == "" {
+		evt.ID = base.ID()
+	}
+	if evt.Created.IsZero() {
+		evt.Created = time.Now()
+	}
+	payload, err := encodeEvent(evt, p.cfg.Format)
+	if err != nil {
+		logEvent(p.logger, "events", "encodeEvent", err, "fileID", evt.FileID, "type", evt.Type)
+		return err
+	}
+	pe := &pendingEvent{
+		subject: p.cfg.Topic + "." + string(evt.Type),
+		payload: payload,
+	}
+
+	p.mu.Lock()
+	p.seq++
+	if p.cfg.SpoolDir != "" {
+		name := fmt.Sprintf("%020d-%06d-%s%s", time.Now().UnixNano(), p.seq%1e6, evt.ID, spoolSuffix)
+		pe.path = filepath.Join(p.cfg.SpoolDir, name)
+		if err := p.writeSpoolFile(pe); err != nil {
+			p.mu.Unlock()
+			logEvent(p.logger, "events", "spoolEvent", err, "fileID", evt.FileID, "type", evt.Type)
+			return err
+		}
+	}
+	p.pending = append(p.pending, pe)
+	p.mu.Unlock()
+
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Close stops delivery. Undelivered events stay in the SpoolDir for the next EventPublisher.
+func (p *eventPublisher) Close() error {
+	select {
+	case <-p.done:
+	default:
+		close(p.done)
+	}
+	p.wg.Wait()
+	if c, ok := p.sender.(interface{ Close() error }); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (p *eventPublisher) run() {
+	defer p.wg.Done()
+	for {
+		p.mu.Lock()
+		var next *pendingEvent
+		if len(p.pending) > 0 {
+			next = p.pending[0]
+		}
+		p.mu.Unlock()
+
+		if next == nil {
+			select {
+			case <-p.notify:
+				continue
+			case <-p.done:
+				return
+			}
+		}
+
+		if err := p.sender.Send(next.subject, next.payload); err != nil {
+			logEvent(p.logger, "events", "sendEvent", err, "subject", next.subject, "retryIn", p.cfg.RetryInterval)
+			select {
+			case <-time.After(p.cfg.RetryInterval):
+				continue
+			case <-p.done:
+				return
+			}
+		}
+		if next.path != "" {
+			if err := os.Remove(next.path); err != nil && !os.IsNotExist(err) {
+				logEvent(p.logger, "events", "removeSpooledEvent", err, "path", next.path)
+			}
+		}
+		p.mu.Lock()
+		p.pending = p.pending[1:]
+		p.mu.Unlock()
+	}
+}
+
+// loadSpool queues the events a prior EventPublisher didn't deliver, oldest first
+func (p *eventPublisher) loadSpool() error {
+	if err := os.MkdirAll(p.cfg.SpoolDir, 0700); err != nil {
+		return err
+	}
+	infos, err := ioutil.ReadDir(p.cfg.SpoolDir)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for i := range infos {
+		if !infos[i].IsDir() && strings.HasSuffix(infos[i].Name(), spoolSuffix) {
+			names = append(names, infos[i].Name())
+		}
+	}
+	sort.Strings(names)
+	for i := range names {
+		path := filepath.Join(p.cfg.SpoolDir, names[i])
+		pe, err := p.readSpoolFile(path)
+		if err != nil {
+			logEvent(p.logger, "events", "readSpooledEvent", err, "path", path)
+			continue
+		}
+		p.pending = append(p.pending, pe)
+	}
+	return nil
+}
+
+// writeSpoolFile stores the subject on the first line followed by the payload, encrypted with
+// the SpoolKey when one is set. The file is renamed into place so a crash never leaves a partial
+// event to be replayed.
+func (p *eventPublisher) writeSpoolFile(pe *pendingEvent) error {
+	tmp := pe.path + ".tmp"
+	data := append([]byte(pe.subject+"\n"), pe.payload...)
+	if p.spoolAEAD != nil {
+		var err error
+		if data, err = seal(p.spoolAEAD, data, []byte(filepath.Base(pe.path))); err != nil {
+			return err
+		}
+	}
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, pe.path)
+}
+
+func (p *eventPublisher) readSpoolFile(path string) (*pendingEvent, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if p.spoolAEAD != nil {
+		if data, err = open(p.spoolAEAD, data, []byte(filepath.Base(path))); err != nil {
+			return nil, fmt.Errorf("problem decrypting spooled event: %v", err)
+		}
+	}
+	idx := strings.IndexByte(string(data), '\n')
+	if idx <= 0 {
+		return nil, errors.New("missing subject")
+	}
+	return &pendingEvent{
+		subject: string(data[:idx]),
+		payload: data[idx+1:],
+		path:    path,
+	}, nil
+}
+
+// encodeEvent renders evt as JSON or as the FileEvent message described in events.proto
+func encodeEvent(evt *Event, format string) ([]byte, error) {
+	if format != "proto" {
+		return json.Marshal(evt)
+	}
+	var buf []byte
+	buf = appendProtoString(buf, 1, evt.ID)
+	buf = appendProtoString(buf, 2, string(evt.Type))
+	buf = appendProtoString(buf, 3, evt.FileID)
+	if !evt.Created.IsZero() {
+		buf = appendProtoString(buf, 4, evt.Created.UTC().Format(time.RFC3339Nano))
+	}
+	buf = appendProtoString(buf, 5, evt.Error)
+	if evt.File != nil {
+		bs, err := evt.File.MarshalProto()
+		if err != nil {
+			return nil, err
+		}
+		buf = appendProtoBytes(buf, 6, bs)
+	}
+	return buf, nil
+}
+
+func appendProtoString(buf []byte, field int, s string) []byte {
+	if s == "" {
+		return buf
+	}
+	return appendProtoBytes(buf, field, []byte(s))
+}
+
+// appendProtoBytes writes a length-delimited (wire type 2) field
+func appendProtoBytes(buf []byte, field int, bs []byte) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], uint64(field)<<3|2)
+	buf = append(buf, tmp[:n]...)
+	n = binary.PutUvarint(tmp[:], uint64(len(bs)))
+	buf = append(buf, tmp[:n]...)
+	return append(buf, bs...)
+}
+
+// eventRepository publishes FileCreated and FileDeleted events for changes made through it
+type eventRepository struct {
+	Repository
+	events EventPublisher
+}
+
+// NewEventRepository wraps r so storing, replacing, deleting and restoring files publishes events with events.
+// Publishing doesn't fail the storage call, EventPublisher implementations log their own errors.
+func NewEventRepository(r Repository, events EventPublisher) Repository {
+	return &eventRepository{Repository: r, events: events}
+}
+
+func (r *eventRepository) StoreFile(ctx context.Context, f *ach.File) error {
+	if err := r.Repository.StoreFile(ctx, f); err != nil {
+		return err
+	}
+	r.events.Publish(&Event{Type: FileCreated, FileID: f.ID, File: f})
+	return nil
+}
+
+func (r *eventRepository) ReplaceFile(ctx context.Context, f *ach.File) error {
+	if err := r.Repository.ReplaceFile(ctx, f); err != nil {
+		return err
+	}
+	r.events.Publish(&Event{Type: FileCreated, FileID: f.ID, File: f})
+	return nil
+}
+
+func (r *eventRepository) DeleteFile(ctx context.Context, id string) error {
+	if err := r.Repository.DeleteFile(ctx, id); err != nil {
+		return err
+	}
+	r.events.Publish(&Event{Type: FileDeleted, FileID: id})
+	return nil
+}
+
+func (r *eventRepository) DeleteFileAtRevision(ctx context.Context, id string, revision int) error {
+	if err := r.Repository.DeleteFileAtRevision(ctx, id, revision); err != nil {
+		return err
+	}
+	r.events.Publish(&Event{Type: FileDeleted, FileID: id})
+	return nil
+}
+
+func (r *eventRepository) RestoreFile(ctx context.Context, fileID string) (*ach.File, error) {
+	f, err := r.Repository.RestoreFile(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	r.events.Publish(&Event{Type: FileCreated, FileID: f.ID, File: f})
+	return f, nil
+}
+
+// natsSender publishes to a NATS server with the core text protocol. Each Send waits for the
+// server to answer a PING so an accepted event is known to have reached it. It's only safe
+// for use by one goroutine, which is how eventPublisher calls it.
+type natsSender struct {
+	addr    string
+	user    *url.Userinfo
+	timeout time.Duration
+	conn    net.Conn
+	reader  *bufio.Reader
+}
+
+// NewNATSSender returns an EventSender for the NATS server at rawurl (e.g. nats://localhost:4222).
+// It connects on the first Send and reconnects after any failure.
+func NewNATSSender(rawurl string) (EventSender, error) {
+	if !strings.Contains(rawurl, "://") {
+		rawurl = "nats://" + rawurl
+	}
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "nats" || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid NATS url: %s", rawurl)
+	}
+	port := u.Port()
+	if port == "" {
+		port = "4222"
+	}
+	return &natsSender{
+		addr:    net.JoinHostPort(u.Hostname(), port),
+		user:    u.User,
+		timeout: 10 * time.Second,
+	}, nil
+}
+
+func (n *natsSender) Send(subject string, payload []byte) error {
+	if n.conn == nil {
+		if err := n.connect(); err != nil {
+			return err
+		}
+	}
+	msg := fmt.Sprintf("PUB %s %d\r\n", subject, len(payload))
+	if err := n.write(append(append([]byte(msg), payload...), []byte("\r\nPING\r\n")...)); err != nil {
+		return err
+	}
+	return n.awaitPong()
+}
+
+func (n *natsSender) Close() error {
+	if n.conn == nil {
+		return nil
+	}
+	err := n.conn.Close()
+	n.conn, n.reader = nil, nil
+	return err
+}
+
+func (n *natsSender) connect() error {
+	conn, err := net.DialTimeout("tcp", n.addr, n.timeout)
+	if err != nil {
+		return err
+	}
+	n.conn, n.reader = conn, bufio.NewReader(conn)
+	n.conn.SetDeadline(time.Now().Add(n.timeout))
+	line, err := n.reader.ReadString('\n')
+	if err != nil {
+		n.Close()
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO") {
+		n.Close()
+		return fmt.Errorf("unexpected NATS greeting: %q", strings.TrimSpace(line))
+	}
+	opts := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "ach",
+		"lang":     "go",
+	}
+	if n.user != nil {
+		opts["user"] = n.user.Username()
+		if pass, ok := n.user.Password(); ok {
+			opts["pass"] = pass
+		}
+	}
+	bs, _ := json.Marshal(opts)
+	if err := n.write(append(append([]byte("CONNECT "), bs...), []byte("\r\nPING\r\n")...)); err != nil {
+		return err
+	}
+	return n.awaitPong()
+}
+
+func (n *natsSender) write(bs []byte) error {
+	n.conn.SetDeadline(time.Now().Add(n.timeout))
+	if _, err := n.conn.Write(bs); err != nil {
+		n.Close()
+		return err
+	}
+	return nil
+}
+
+// awaitPong reads until the server answers our PING, replying to its own PINGs
+func (n *natsSender) awaitPong() error {
+	for {
+		line, err := n.reader.ReadString('\n')
+		if err != nil {
+			n.Close()
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if err := n.write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			n.Close()
+			return fmt.Errorf("NATS error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
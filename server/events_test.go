@@ -0,0 +1,331 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/moov-io/ach"
+)
+
+type mockEventSender struct {
+	mu       sync.Mutex
+	failures int // how many Send calls fail before succeeding
+	sent     []string
+	payloads [][]byte
+}
+
+func (s *mockEventSender) Send(subject string, payload []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("broker unavailable")
+	}
+	s.sent = append(s.sent, subject)
+	s.payloads = append(s.payloads, payload)
+	return nil
+}
+
+// waitFor returns the subjects sent once n have been, failing the test after a few seconds
+func (s *mockEventSender) waitFor(t *testing.T, n int) []string {
+	t.Helper()
+	for i := 0; i < 300; i++ {
+		s.mu.Lock()
+		if len(s.sent) >= n {
+			out := append([]string(nil), s.sent...)
+			s.mu.Unlock()
+			return out
+		}
+		s.mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d events", n)
+	return nil
+}
+
+func TestEventPublisher__retry(t *testing.T) {
+	sender := &mockEventSender{failures: 2}
+	pub, err := NewEventPublisher(sender, EventConfig{Topic: "payments", RetryInterval: time.Millisecond}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pub.Close()
+
+	pub.Publish(&Event{Type: FileCreated, FileID: "a"})
+	pub.Publish(&Event{Type: FileDeleted, FileID: "a"})
+
+	sent := sender.waitFor(t, 2)
+	if sent[0] != "payments.file.created" || sent[1] != "payments.file.deleted" {
+		t.Errorf("unexpected subjects: %v", sent)
+	}
+	var evt Event
+	if err := json.Unmarshal(sender.payloads[1], &evt); err != nil {
+		t.Fatal(err)
+	}
+	if evt.Type != FileDeleted || evt.FileID != "a" || evt.ID == "" || evt.Created.IsZero() {
+		t.Errorf("unexpected event: %#v", evt)
+	}
+}
+
+func TestEventPublisher__spool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ach-events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// broker is down for the life of the first publisher
+	down := &mockEventSender{failures: 1000}
+	pub, err := NewEventPublisher(down, EventConfig{SpoolDir: dir, RetryInterval: time.Hour}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := pub.Publish(&Event{Type: FileValidated, FileID: strconv.Itoa(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pub.Close()
+
+	// a restart delivers what was spooled, in order
+	up := &mockEventSender{}
+	pub, err = NewEventPublisher(up, EventConfig{SpoolDir: dir, RetryInterval: time.Millisecond}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pub.Close()
+	up.waitFor(t, 3)
+	for i := range up.payloads {
+		var evt Event
+		if err := json.Unmarshal(up.payloads[i], &evt); err != nil {
+			t.Fatal(err)
+		}
+		if evt.FileID != strconv.Itoa(i) {
+			t.Errorf("event %d: got file %s", i, evt.FileID)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		infos, _ := ioutil.ReadDir(dir)
+		if len(infos) == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("delivered events weren't removed from the spool")
+}
+
+func TestEventPublisher__spoolEncrypted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ach-events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key := []byte("0123456789abcdef")
+	file := readPPDValidFile(t)
+	name := strings.TrimSpace(file.Batches[0].GetEntries()[0].IndividualName)
+
+	down := &mockEventSender{failures: 1000}
+	pub, err := NewEventPublisher(down, EventConfig{SpoolDir: dir, SpoolKey: key, RetryInterval: time.Hour}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pub.Publish(&Event{Type: FileCreated, FileID: file.ID, File: file}); err != nil {
+		t.Fatal(err)
+	}
+	pub.Close()
+
+	infos, _ := ioutil.ReadDir(dir)
+	if len(infos) != 1 {
+		t.Fatalf("expected one spooled event, found %d", len(infos))
+	}
+	bs, err := ioutil.ReadFile(filepath.Join(dir, infos[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(bs), name) || strings.Contains(string(bs), "file.created") {
+		t.Errorf("spooled event isn't encrypted: %s", bs)
+	}
+
+	// spool files are skipped without the key they were written with
+	other := &mockEventSender{}
+	pub, err = NewEventPublisher(other, EventConfig{SpoolDir: dir, SpoolKey: []byte("fedcba9876543210")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub.Close()
+	if len(other.sent) != 0 {
+		t.Errorf("unexpected events: %v", other.sent)
+	}
+
+	up := &mockEventSender{}
+	pub, err = NewEventPublisher(up, EventConfig{SpoolDir: dir, SpoolKey: key, RetryInterval: time.Millisecond}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pub.Close()
+	if sent := up.waitFor(t, 1); sent[0] != "ach.file.created" {
+		t.Errorf("unexpected subject: %v", sent)
+	}
+	var evt struct {
+		FileID string          `json:"fileID"`
+		File   json.RawMessage `json:"file"`
+	}
+	if err := json.Unmarshal(up.payloads[0], &evt); err != nil {
+		t.Fatal(err)
+	}
+	if evt.FileID != file.ID || !strings.Contains(string(evt.File), name) {
+		t.Errorf("unexpected event: %#v", evt)
+	}
+
+	if _, err := NewEventPublisher(up, EventConfig{SpoolKey: []byte("short")}, nil); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestEventPublisher__format(t *testing.T) {
+	if _, err := NewEventPublisher(&mockEventSender{}, EventConfig{Format: "xml"}, nil); !errors.Is(err, ErrEventFormat) {
+		t.Errorf("expected ErrEventFormat: %v", err)
+	}
+
+	file := readPPDValidFile(t)
+	bs, err := encodeEvent(&Event{ID: "evt", Type: FileCreated, FileID: file.ID, File: file}, "proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileBytes, err := file.MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// field 6 is the last written, so the encoded File ends the message
+	if !strings.HasSuffix(string(bs), string(fileBytes)) {
+		t.Error("expected encoded File in FileEvent")
+	}
+	if !strings.Contains(string(bs), string(FileCreated)) {
+		t.Error("expected event type in FileEvent")
+	}
+}
+
+func TestEventRepository(t *testing.T) {
+	ctx := context.Background()
+	sender := &mockEventSender{}
+	pub, err := NewEventPublisher(sender, EventConfig{RetryInterval: time.Millisecond}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pub.Close()
+
+	repo := NewEventRepository(NewRepositoryInMemory(testTTLDuration, nil), pub)
+	svc := NewService(repo, WithEventPublisher(pub))
+
+	file := readPPDValidFile(t)
+	if err := repo.StoreFile(ctx, file); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.StoreFile(ctx, file); err != ErrAlreadyExists {
+		t.Fatalf("expected ErrAlreadyExists: %v", err)
+	}
+	if err := svc.ValidateFile(ctx, file.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.DeleteFile(ctx, file.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	sent := sender.waitFor(t, 3)
+	expected := []string{"ach.file.created", "ach.file.validated", "ach.file.deleted"}
+	if fmt.Sprintf("%v", sent) != fmt.Sprintf("%v", expected) {
+		t.Errorf("got %v", sent)
+	}
+	var created struct {
+		File json.RawMessage `json:"file"`
+	}
+	if err := json.Unmarshal(sender.payloads[0], &created); err != nil {
+		t.Fatal(err)
+	}
+	if f, err := ach.FileFromJSON(created.File); err != nil || f.ID != file.ID {
+		t.Errorf("expected file in created event: %v", err)
+	}
+}
+
+func TestNATSSender(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	published := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprint(conn, "INFO {\"server_id\":\"test\"}\r\n")
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch fields := strings.Fields(line); fields[0] {
+			case "PING":
+				fmt.Fprint(conn, "PONG\r\n")
+			case "PUB":
+				n, _ := strconv.Atoi(fields[2])
+				payload := make([]byte, n+2)
+				if _, err := io.ReadFull(r, payload); err != nil {
+					return
+				}
+				published <- fields[1] + " " + string(payload[:n])
+			}
+		}
+	}()
+
+	sender, err := NewNATSSender(ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sender.(*natsSender).Close()
+	if err := sender.Send("ach.file.deleted", []byte(`{"fileID":"a"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if msg := <-published; msg != `ach.file.deleted {"fileID":"a"}` {
+		t.Errorf("got %q", msg)
+	}
+
+	if _, err := NewNATSSender("http://localhost:4222"); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,194 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/moov-io/ach"
+)
+
+// ExposureLimit is the most a company may originate within the window given to WithExposureLimits.
+// A zero Debit or Credit doesn't limit that side.
+type ExposureLimit struct {
+	Debit  ach.Amount `json:"debit"`
+	Credit ach.Amount `json:"credit"`
+}
+
+// WithExposureLimits has the Service reject files which would take a company's debits or credits originated
+// within the last window over its limit, keyed by Company Identification (Originator Identification for IAT
+// batches). Files are checked when they're created and each time they're validated. Every other stored file
+// created within window, by its FileCreationDate and FileCreationTime, counts towards the limits. Companies
+// without a limit aren't checked. A rejected file's error is an *ExposureLimitError.
+func WithExposureLimits(window time.Duration, limits map[string]ExposureLimit) ServiceOption {
+	return func(s *service) {
+		if window <= 0 || len(limits) == 0 {
+			return
+		}
+		s.exposure = &exposureLimits{
+			window: window,
+			limits: limits,
+			now:    time.Now,
+		}
+	}
+}
+
+// ExposureViolation is a batch which takes its company over an ExposureLimit
+type ExposureViolation struct {
+	BatchNumber           int    `json:"batchNumber"`
+	BatchID               string `json:"batchID,omitempty"`
+	CompanyIdentification string `json:"companyIdentification"`
+	// Side is "debit" or "credit"
+	Side string `json:"side"`
+	// Amount is the batch's total for Side
+	Amount ach.Amount `json:"amount"`
+	// Exposure is the company's total for Side within the window, including every batch of the file
+	Exposure ach.Amount `json:"exposure"`
+	Limit    ach.Amount `json:"limit"`
+}
+
+// ExposureLimitError is returned when a file breaks the limits of WithExposureLimits.
+type ExposureLimitError struct {
+	Violations []ExposureViolation `json:"violations"`
+}
+
+func (e *ExposureLimitError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, fmt.Sprintf("batch #%d company %s %s of %v takes exposure to %v over limit of %v",
+			v.BatchNumber, v.CompanyIdentification, v.Side, v.Amount, v.Exposure, v.Limit))
+	}
+	return "exposure limit exceeded: " + strings.Join(msgs, "; ")
+}
+
+// exposureLimits are the most each company may originate in the files created within window
+type exposureLimits struct {
+	window time.Duration
+	limits map[string]ExposureLimit
+
+	now func() time.Time
+}
+
+type exposureTotals struct {
+	debit, credit ach.Amount
+}
+
+// exposureBatch is a batch's totals for the company which originated it
+type exposureBatch struct {
+	number  int
+	id      string
+	company string
+	exposureTotals
+}
+
+func exposureBatches(f *ach.File) []exposureBatch {
+	var out []exposureBatch
+	for _, batch := range f.Batches {
+		bh, bc := batch.GetHeader(), batch.GetControl()
+		if bh == nil || bc == nil {
+			continue
+		}
+		out = append(out, exposureBatch{
+			number:         bh.BatchNumber,
+			id:             batch.ID(),
+			company:        strings.TrimSpace(bh.CompanyIdentification),
+			exposureTotals: exposureTotals{debit: bc.TotalDebitAmount(), credit: bc.TotalCreditAmount()},
+		})
+	}
+	for _, iatBatch := range f.IATBatches {
+		bh, bc := iatBatch.GetHeader(), iatBatch.GetControl()
+		if bh == nil || bc == nil {
+			continue
+		}
+		out = append(out, exposureBatch{
+			number:         bh.BatchNumber,
+			id:             iatBatch.ID,
+			company:        strings.TrimSpace(bh.OriginatorIdentification),
+			exposureTotals: exposureTotals{debit: bc.TotalDebitAmount(), credit: bc.TotalCreditAmount()},
+		})
+	}
+	return out
+}
+
+// check returns an *ExposureLimitError if f takes any company over its limit. Each of others created
+// within the window counts towards the limits, f always does.
+func (e *exposureLimits) check(f *ach.File, others []*ach.File) error {
+	batches := exposureBatches(f)
+	exposure := make(map[string]exposureTotals)
+	add := func(batches []exposureBatch) {
+		for _, b := range batches {
+			if _, ok := e.limits[b.company]; !ok {
+				continue
+			}
+			sum := exposure[b.company]
+			sum.debit += b.debit
+			sum.credit += b.credit
+			exposure[b.company] = sum
+		}
+	}
+	add(batches)
+	if len(exposure) == 0 {
+		return nil
+	}
+	since := e.now().Add(-e.window)
+	for _, other := range others {
+		if created, err := fileCreated(other.Header); err != nil || !created.After(since) {
+			continue
+		}
+		add(exposureBatches(other))
+	}
+
+	var violations []ExposureViolation
+	for _, b := range batches {
+		limit, ok := e.limits[b.company]
+		if !ok {
+			continue
+		}
+		sum := exposure[b.company]
+		if limit.Debit > 0 && b.debit > 0 && sum.debit > limit.Debit {
+			violations = append(violations, ExposureViolation{
+				BatchNumber: b.number, BatchID: b.id, CompanyIdentification: b.company,
+				Side: "debit", Amount: b.debit, Exposure: sum.debit, Limit: limit.Debit,
+			})
+		}
+		if limit.Credit > 0 && b.credit > 0 && sum.credit > limit.Credit {
+			violations = append(violations, ExposureViolation{
+				BatchNumber: b.number, BatchID: b.id, CompanyIdentification: b.company,
+				Side: "credit", Amount: b.credit, Exposure: sum.credit, Limit: limit.Credit,
+			})
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].BatchNumber < violations[j].BatchNumber
+	})
+	return &ExposureLimitError{Violations: violations}
+}
+
+func (s *service) CheckExposure(ctx context.Context, f *ach.File) error {
+	if s.exposure == nil || f == nil {
+		return nil
+	}
+	return s.exposure.check(f, s.otherFiles(ctx, f))
+}
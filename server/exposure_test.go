@@ -0,0 +1,222 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/base"
+
+	"github.com/go-kit/kit/log"
+)
+
+// exposureNow is within a day of ppd-valid.json's FileCreationDate
+var exposureNow = time.Date(2018, time.October, 8, 12, 0, 0, 0, time.UTC)
+
+func TestExposure__limits(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo, WithExposureLimits(24*time.Hour, map[string]ExposureLimit{
+		"121042882": {Credit: 150000}, // ppd-valid.json credits $1,000.00
+	})).(*service)
+	now := exposureNow
+	svc.exposure.now = func() time.Time { return now }
+
+	file := readPPDValidFile(t)
+	if err := repo.StoreFile(ctx, file); err != nil {
+		t.Fatal(err)
+	}
+	// validating a file again doesn't count it twice
+	for i := 0; i < 2; i++ {
+		if err := svc.ValidateFile(ctx, file.ID, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	other := readPPDValidFile(t)
+	other.ID = base.ID()
+	if err := repo.StoreFile(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	err := svc.ValidateFile(ctx, other.ID, nil)
+	var exposureErr *ExposureLimitError
+	if !errors.As(err, &exposureErr) {
+		t.Fatalf("%T: %v", err, err)
+	}
+	if n := len(exposureErr.Violations); n != 1 {
+		t.Fatalf("got %d violations: %v", n, err)
+	}
+	v := exposureErr.Violations[0]
+	if v.BatchNumber != 1 || v.CompanyIdentification != "121042882" || v.Side != "credit" {
+		t.Errorf("unexpected violation: %#v", v)
+	}
+	if v.Amount != 100000 || v.Exposure != 200000 || v.Limit != 150000 {
+		t.Errorf("unexpected amounts: %#v", v)
+	}
+
+	// re-validating files, e.g. by the validation sweep, doesn't keep them within the window
+	now = now.Add(24 * time.Hour)
+	svc.SweepFiles(ctx, now)
+	if err := svc.ValidateFile(ctx, other.ID, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExposure__created(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo, WithExposureLimits(time.Hour, map[string]ExposureLimit{
+		"121042882": {Credit: 150000},
+	})).(*service)
+	svc.exposure.now = func() time.Time { return exposureNow }
+
+	// files created before the window don't count
+	old := readPPDValidFile(t)
+	old.Header.FileCreationTime = "1059"
+	if err := repo.StoreFile(ctx, old); err != nil {
+		t.Fatal(err)
+	}
+	file := readPPDValidFile(t)
+	file.ID = base.ID()
+	file.Header.FileCreationTime = "1130"
+	if err := svc.CheckExposure(ctx, file); err != nil {
+		t.Error(err)
+	}
+
+	old.Header.FileCreationTime = "1101"
+	if err := svc.CheckExposure(ctx, file); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestExposure__unlimited(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo, WithExposureLimits(time.Hour, map[string]ExposureLimit{
+		"121042882": {Debit: 1}, // ppd-valid.json has no debits
+		"987654321": {Credit: 1},
+	}))
+
+	for i := 0; i < 3; i++ {
+		file := readPPDValidFile(t)
+		file.ID = base.ID()
+		if err := repo.StoreFile(ctx, file); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.ValidateFile(ctx, file.ID, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// without a window there are no limits
+	svc = NewService(repo, WithExposureLimits(0, map[string]ExposureLimit{"121042882": {Credit: 1}}))
+	if svc.(*service).exposure != nil {
+		t.Error("expected no exposure limits")
+	}
+	if err := svc.CheckExposure(ctx, readPPDValidFile(t)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExposure__createFileEndpoint(t *testing.T) {
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo, WithExposureLimits(24*time.Hour, map[string]ExposureLimit{
+		"121042882": {Credit: 150000},
+	}))
+	svc.(*service).exposure.now = func() time.Time { return exposureNow }
+	router := MakeHTTPHandler(svc, repo, log.NewNopLogger())
+
+	bs, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	create := func(id string) *httptest.ResponseRecorder {
+		body := bytes.Replace(bs, []byte(`"adam-01"`), []byte(fmt.Sprintf("%q", id)), -1)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/files/create", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		w.Flush()
+		return w
+	}
+
+	if w := create("adam-01"); w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	w := create("adam-02")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Violations []ExposureViolation `json:"violations"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Violations) != 1 || resp.Violations[0].Exposure != 200000 {
+		t.Errorf("unexpected response: %#v", resp)
+	}
+	// rejected files aren't stored
+	if _, err := repo.FindFile(context.Background(), "adam-02"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound: %v", err)
+	}
+}
+
+func TestExposure__validateFileEndpoint(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo, WithExposureLimits(time.Hour, map[string]ExposureLimit{
+		"121042882": {Credit: ach.Amount(50000)},
+	}))
+	router := MakeHTTPHandler(svc, repo, log.NewNopLogger())
+
+	file := readPPDValidFile(t)
+	if err := repo.StoreFile(ctx, file); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/files/%s/validate", file.ID), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Error      string              `json:"error"`
+		Violations []ExposureViolation `json:"violations"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Violations) != 1 || resp.Violations[0].Limit != 50000 || resp.Violations[0].Exposure != 100000 {
+		t.Errorf("unexpected response: %#v", resp)
+	}
+}
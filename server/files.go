@@ -18,15 +18,16 @@
 package server
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/moov-io/ach"
 	"github.com/moov-io/base"
@@ -51,21 +52,183 @@ var (
 	}, nil)
 )
 
+// FileConflict is how POST /files/create handles a file whose ID is already stored
+type FileConflict string
+
+const (
+	// FileConflictReject responds with a 409 Conflict and leaves the stored file unchanged
+	FileConflictReject FileConflict = "reject"
+	// FileConflictReplace stores the new file over the existing one, which is kept as a version
+	FileConflictReplace FileConflict = "replace"
+	// FileConflictIgnore leaves the stored file unchanged and responds as if it was created
+	FileConflictIgnore FileConflict = "ignore"
+)
+
+// FileDuplicate is how POST /files/create handles a file with the same Fingerprint as a stored file
+type FileDuplicate string
+
+const (
+	// FileDuplicateFlag stores the file and lists the IDs of stored files it duplicates in the response
+	FileDuplicateFlag FileDuplicate = "flag"
+	// FileDuplicateReject responds with a 409 Conflict and does not store the file
+	FileDuplicateReject FileDuplicate = "reject"
+)
+
+var (
+	errFileConflict  = conflict(errors.New("file already exists"))
+	errDuplicateFile = conflict(errors.New("duplicate file"))
+)
+
+// fileIDModifierAuto is the fileIDModifier query parameter of POST /files/create which assigns the next
+// FileIDModifier for the file's origin, destination and creation date, see Service.AssignFileIDModifier
+const fileIDModifierAuto = "auto"
+
+// parseFileIDModifier returns true when the next FileIDModifier is to be assigned to an uploaded file
+func parseFileIDModifier(v string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "":
+		return false, nil
+	case fileIDModifierAuto:
+		return true, nil
+	}
+	return false, invalid(fmt.Errorf("unknown fileIDModifier value %q", v))
+}
+
+func parseFileConflict(v string) (FileConflict, error) {
+	switch c := FileConflict(strings.ToLower(strings.TrimSpace(v))); c {
+	case "":
+		return FileConflictReject, nil
+	case FileConflictReject, FileConflictReplace, FileConflictIgnore:
+		return c, nil
+	}
+	return "", invalid(fmt.Errorf("unknown onConflict value %q", v))
+}
+
+// parseJSONFormat returns true when the v2 JSON format of files is requested
+func parseJSONFormat(v string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "", "v1":
+		return false, nil
+	case ach.JSONVersion2:
+		return true, nil
+	}
+	return false, invalid(fmt.Errorf("unknown format %q", v))
+}
+
+// parseMasked returns true when ?masked=true asks for personal data in files to be masked
+func parseMasked(r *http.Request) (bool, error) {
+	v := r.URL.Query().Get("masked")
+	if v == "" {
+		return false, nil
+	}
+	masked, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, invalid(fmt.Errorf("invalid masked %q", v))
+	}
+	return masked, nil
+}
+
+// parseIfMatch reads the file revision a change expects from the If-Match header, which clients copy
+// from the ETag of any representation of the file, see fileETag. A missing header or * accepts any revision.
+func parseIfMatch(r *http.Request) ([]ChangeOption, error) {
+	v := strings.TrimSpace(r.Header.Get("If-Match"))
+	if v == "" || v == "*" {
+		return nil, nil
+	}
+	tag := strings.Trim(strings.TrimPrefix(v, "W/"), `"`)
+	if idx := strings.IndexByte(tag, '-'); idx > 0 {
+		tag = tag[:idx]
+	}
+	revision, err := strconv.Atoi(tag)
+	if err != nil || revision <= 0 {
+		return nil, invalid(fmt.Errorf("invalid If-Match %q, expected the ETag of a file such as \"3\"", v))
+	}
+	return []ChangeOption{IfRevision(revision)}, nil
+}
+
+// fileETag is the ETag of a representation of a file at revision. The JSON of a file is tagged with
+// just its revision and other representations add their names, for example "3-v2-masked", so each
+// representation has its own ETag while any of them can be sent as If-Match.
+func fileETag(revision int, representation ...string) string {
+	return `"` + strings.Join(append([]string{strconv.Itoa(revision)}, representation...), "-") + `"`
+}
+
+// fileRepresentation names a representation of a file for fileETag
+func fileRepresentation(base string, masked bool) []string {
+	var out []string
+	if base != "" {
+		out = append(out, base)
+	}
+	if masked {
+		out = append(out, "masked")
+	}
+	return out
+}
+
+// getMaskedFile returns a copy of a stored file with its personal data masked by ach.File.MaskSensitiveData
+func getMaskedFile(ctx context.Context, s Service, id string) (*ach.File, error) {
+	f, err := s.GetFile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return f.MaskSensitiveData(nil)
+}
+
+func parseFileDuplicate(v string) (FileDuplicate, error) {
+	switch d := FileDuplicate(strings.ToLower(strings.TrimSpace(v))); d {
+	case "":
+		return FileDuplicateFlag, nil
+	case FileDuplicateFlag, FileDuplicateReject:
+		return d, nil
+	}
+	return "", invalid(fmt.Errorf("unknown onDuplicate value %q", v))
+}
+
+// findDuplicateFiles returns the IDs of stored files, other than file itself, which share its Fingerprint.
+// Stored files expire after the repository's TTL so only recent submissions are compared.
+func findDuplicateFiles(ctx context.Context, r Repository, file *ach.File) []string {
+	fingerprint := file.Fingerprint()
+
+	var out []string
+	for _, f := range r.FindAllFiles(ctx) {
+		if f == nil || f.ID == file.ID {
+			continue
+		}
+		if f.Fingerprint() == fingerprint {
+			out = append(out, f.ID)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
 type createFileRequest struct {
-	File *ach.File
+	File        *ach.File
+	onConflict  FileConflict
+	onDuplicate FileDuplicate
+	jsonV2      bool
+
+	// assignModifier has the file given the next FileIDModifier of its origin, destination and creation date
+	assignModifier bool
+
+	// diagnostics are non-fatal issues from reading a plaintext file
+	diagnostics []ach.Diagnostic
 
 	requestID string
 }
 
 type createFileResponse struct {
-	ID  string `json:"id"`
-	Err error  `json:"error"`
+	ID          string           `json:"id"`
+	Diagnostics []ach.Diagnostic `json:"diagnostics,omitempty"`
+	// DuplicateOf lists the IDs of stored files with the same Fingerprint
+	DuplicateOf []string `json:"duplicateOf,omitempty"`
+	Err         error    `json:"error"`
 }
 
 func (r createFileResponse) error() error { return r.Err }
 
 func createFileEndpoint(s Service, r Repository, logger log.Logger) endpoint.Endpoint {
-	return func(_ context.Context, request interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(createFileRequest)
 		if !ok {
 			err := errors.New("invalid request")
@@ -74,104 +237,323 @@ func createFileEndpoint(s Service, r Repository, logger log.Logger) endpoint.End
 			}, err
 		}
 
-		// record a metric for files created
-		if req.File != nil && req.File.Header.ImmediateDestination != "" && req.File.Header.ImmediateOrigin != "" {
-			filesCreated.With("destination", req.File.Header.ImmediateDestination, "origin", req.File.Header.ImmediateOrigin).Add(1)
-		}
-
 		// Create a random file ID if none was provided
 		if req.File.ID == "" {
 			req.File.ID = base.ID()
 		}
 
-		err := r.StoreFile(req.File)
-		if logger != nil {
-			logger.Log("files", "createFile", "requestID", req.requestID, "error", err)
+		if req.assignModifier || req.File.Header.FileIDModifier == "" {
+			if err := s.AssignFileIDModifier(ctx, req.File); err != nil {
+				logEvent(logger, "files", "createFile", err, "requestID", req.requestID, "fileID", req.File.ID)
+				return createFileResponse{
+					ID:  req.File.ID,
+					Err: err,
+				}, nil
+			}
+		}
+
+		duplicates := findDuplicateFiles(ctx, r, req.File)
+		if len(duplicates) > 0 && req.onDuplicate == FileDuplicateReject {
+			err := fmt.Errorf("%w: matches %s", errDuplicateFile, strings.Join(duplicates, ", "))
+			logEvent(logger, "files", "createFile", err, "requestID", req.requestID, "fileID", req.File.ID, "onDuplicate", req.onDuplicate)
+			return createFileResponse{
+				ID:          req.File.ID,
+				DuplicateOf: duplicates,
+				Err:         err,
+			}, nil
+		}
+
+		if err := s.CheckExposure(ctx, req.File); err != nil {
+			err = invalidFile(err)
+			logEvent(logger, "files", "createFile", err, "requestID", req.requestID, "fileID", req.File.ID)
+			return createFileResponse{
+				ID:  req.File.ID,
+				Err: err,
+			}, nil
+		}
+
+		_, span := startSpan(ctx, "Repository.StoreFile")
+		span.SetAttribute("onConflict", string(req.onConflict))
+		var err error
+		switch req.onConflict {
+		case FileConflictReplace:
+			err = r.ReplaceFile(ctx, req.File)
+		case FileConflictIgnore:
+			if err = r.StoreFile(ctx, req.File); err == ErrAlreadyExists {
+				err = nil // keep the stored file
+			}
+		default:
+			if err = r.StoreFile(ctx, req.File); err == ErrAlreadyExists {
+				err = errFileConflict
+			}
+		}
+		span.End(err)
+		logEvent(logger, "files", "createFile", err, "requestID", req.requestID, "fileID", req.File.ID, "onConflict", req.onConflict)
+
+		// record a metric for files created
+		if err == nil && req.File.Header.ImmediateDestination != "" && req.File.Header.ImmediateOrigin != "" {
+			filesCreated.With("destination", req.File.Header.ImmediateDestination, "origin", req.File.Header.ImmediateOrigin).Add(1)
 		}
 
 		return createFileResponse{
-			ID:  req.File.ID,
-			Err: err,
+			ID:          req.File.ID,
+			Diagnostics: req.diagnostics,
+			DuplicateOf: duplicates,
+			Err:         err,
 		}, nil
 	}
 }
 
-func decodeCreateFileRequest(_ context.Context, request *http.Request) (interface{}, error) {
-	var r io.Reader
+func decodeCreateFileRequest(ctx context.Context, request *http.Request) (interface{}, error) {
 	var req createFileRequest
 
 	req.requestID = moovhttp.GetRequestID(request)
 
+	onConflict, err := parseFileConflict(request.URL.Query().Get("onConflict"))
+	if err != nil {
+		return nil, err
+	}
+	req.onConflict = onConflict
+
+	onDuplicate, err := parseFileDuplicate(request.URL.Query().Get("onDuplicate"))
+	if err != nil {
+		return nil, err
+	}
+	req.onDuplicate = onDuplicate
+
+	jsonV2, err := parseJSONFormat(request.URL.Query().Get("format"))
+	if err != nil {
+		return nil, err
+	}
+	req.jsonV2 = jsonV2
+
+	assignModifier, err := parseFileIDModifier(request.URL.Query().Get("fileIDModifier"))
+	if err != nil {
+		return nil, err
+	}
+	req.assignModifier = assignModifier
+
 	// Sets default values
 	req.File = ach.NewFile()
-	bs, err := ioutil.ReadAll(request.Body)
+
+	// The body is read as a stream and limited by MakeHTTPHandler, see WithMaxBodySize
+	body := &countingReader{Reader: request.Body}
+	_, span := startSpan(ctx, "ReadFile")
+	err = readCreateFileBody(&req, request, body)
+	span.SetAttribute("bytes", body.n)
+	span.End(err)
 	if err != nil {
 		return nil, err
 	}
+	return req, nil
+}
 
+// readCreateFileBody reads the file uploaded in request as JSON or NACHA, by its Content-Type, into req
+func readCreateFileBody(req *createFileRequest, request *http.Request, body *countingReader) error {
 	h := request.Header.Get("Content-Type")
 	if strings.Contains(h, "application/json") {
 		// Read body as ACH file in JSON
-		f, err := ach.FileFromJSON(bs)
+		var f *ach.File
+		var err error
+		if req.jsonV2 {
+			f, err = ach.FileFromJSONV2Reader(body)
+		} else {
+			f, err = ach.FileFromJSONReader(body)
+		}
 		if err != nil {
-			return nil, err
+			if tooLarge := bodyTooLarge(request.Body); tooLarge != nil {
+				return tooLarge
+			}
+			parseErrors.With("record", "JSON").Add(1)
+			return invalid(err)
 		}
 		req.File = f
+		fileSizes.With("format", "json").Observe(float64(body.n))
 	} else {
 		// Attempt parsing body as an ACH File
-		r = bytes.NewReader(bs)
-		f, err := ach.NewReader(r).Read()
+		reader := ach.NewReader(body)
+		f, err := reader.Read()
 		if err != nil {
-			return nil, err
+			if tooLarge := bodyTooLarge(request.Body); tooLarge != nil {
+				return tooLarge
+			}
+			countParseErrors(err)
+			return invalid(err)
 		}
 		req.File = &f
+		req.diagnostics = reader.Diagnostics()
+		fileSizes.With("format", "nacha").Observe(float64(body.n))
 	}
-	return req, nil
+	return nil
 }
 
 type getFilesRequest struct {
+	filter  FileFilter
+	shallow bool
+	masked  bool
+
 	requestID string
 }
 
 type getFilesResponse struct {
 	Files []*ach.File `json:"files"`
 	Err   error       `json:"error"`
+
+	// total is how many files matched before paging
+	total int
 }
 
-func (r getFilesResponse) count() int { return len(r.Files) }
+func (r getFilesResponse) count() int {
+	if r.total > len(r.Files) {
+		return r.total
+	}
+	return len(r.Files)
+}
 
 func (r getFilesResponse) error() error { return r.Err }
 
+// fileSummary is the ID and FileHeader of a file, returned by GET /files?shallow=true
+type fileSummary struct {
+	ID     string         `json:"id"`
+	Header ach.FileHeader `json:"fileHeader"`
+}
+
+type getShallowFilesResponse struct {
+	Files []fileSummary `json:"files"`
+	Err   error         `json:"error"`
+
+	total int
+}
+
+func (r getShallowFilesResponse) count() int {
+	if r.total > len(r.Files) {
+		return r.total
+	}
+	return len(r.Files)
+}
+
+func (r getShallowFilesResponse) error() error { return r.Err }
+
 func getFilesEndpoint(s Service) endpoint.Endpoint {
-	return func(_ context.Context, _ interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// Other requests list every file
+		req, _ := request.(getFilesRequest)
+
+		files, total := s.FindFiles(ctx, req.filter)
+		if req.shallow {
+			summaries := make([]fileSummary, len(files))
+			for i := range files {
+				summaries[i] = fileSummary{ID: files[i].ID, Header: files[i].Header}
+			}
+			return getShallowFilesResponse{
+				Files: summaries,
+				total: total,
+			}, nil
+		}
+		if req.masked {
+			for i := range files {
+				masked, err := files[i].MaskSensitiveData(nil)
+				if err != nil {
+					return getFilesResponse{Err: err}, nil
+				}
+				files[i] = masked
+			}
+		}
 		return getFilesResponse{
-			Files: s.GetFiles(),
-			Err:   nil,
+			Files: files,
+			total: total,
 		}, nil
 	}
 }
 
 func decodeGetFilesRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	return getFilesRequest{
+	req := getFilesRequest{
 		requestID: moovhttp.GetRequestID(r),
-	}, nil
+	}
+	q := r.URL.Query()
+
+	var err error
+	if v := q.Get("skip"); v != "" {
+		if req.filter.Skip, err = strconv.Atoi(v); err != nil || req.filter.Skip < 0 {
+			return nil, invalid(fmt.Errorf("invalid skip %q", v))
+		}
+	}
+	if v := q.Get("count"); v != "" {
+		if req.filter.Count, err = strconv.Atoi(v); err != nil || req.filter.Count < 0 {
+			return nil, invalid(fmt.Errorf("invalid count %q", v))
+		}
+	}
+	if v := q.Get("createdAfter"); v != "" {
+		if req.filter.CreatedAfter, err = time.Parse(time.RFC3339, v); err != nil {
+			return nil, invalid(fmt.Errorf("invalid createdAfter %q: %v", v, err))
+		}
+	}
+	req.filter.Origin = strings.TrimSpace(q.Get("origin"))
+	req.filter.Destination = strings.TrimSpace(q.Get("destination"))
+	if v := q.Get("status"); v != "" {
+		if req.filter.Status = FileStatus(v); req.filter.Status.order() < 0 {
+			return nil, invalid(fmt.Errorf("invalid status %q", v))
+		}
+	}
+	if v := q.Get("shallow"); v != "" {
+		if req.shallow, err = strconv.ParseBool(v); err != nil {
+			return nil, invalid(fmt.Errorf("invalid shallow %q", v))
+		}
+	}
+	if req.masked, err = parseMasked(r); err != nil {
+		return nil, err
+	}
+	return req, nil
 }
 
 type getFileRequest struct {
 	ID string
 
-	requestID string
+	includeChecksums bool
+	jsonV2           bool
+	masked           bool
+	requestID        string
 }
 
 type getFileResponse struct {
-	File *ach.File `json:"file"`
-	Err  error     `json:"error"`
+	File      *ach.File          `json:"file"`
+	Checksums *ach.FileChecksums `json:"checksums,omitempty"`
+	Err       error              `json:"error"`
+
+	rev    int
+	masked bool
 }
 
 func (r getFileResponse) error() error { return r.Err }
 
+func (r getFileResponse) etag() string {
+	if r.rev <= 0 {
+		return ""
+	}
+	return fileETag(r.rev, fileRepresentation("", r.masked)...)
+}
+
+// getFileV2Response is a getFileResponse with the file in the v2 JSON format
+type getFileV2Response struct {
+	File      *ach.FileV2        `json:"file"`
+	Checksums *ach.FileChecksums `json:"checksums,omitempty"`
+	Err       error              `json:"error"`
+
+	rev    int
+	masked bool
+}
+
+func (r getFileV2Response) error() error { return r.Err }
+
+func (r getFileV2Response) etag() string {
+	if r.rev <= 0 {
+		return ""
+	}
+	return fileETag(r.rev, fileRepresentation("v2", r.masked)...)
+}
+
 func getFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
-	return func(_ context.Context, request interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(getFileRequest)
 		if !ok {
 			err := errors.New("invalid request")
@@ -180,15 +562,45 @@ func getFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 			}, err
 		}
 
-		f, err := s.GetFile(req.ID)
+		// Read the revision first so a change made in between gives an older
+		// revision, which fails If-Match rather than overwriting the change.
+		revision, _ := s.FileRevision(ctx, req.ID)
 
-		if logger != nil {
-			logger.Log("files", "getFile", "requestID", req.requestID, "error", err)
+		var f *ach.File
+		var err error
+		if req.masked {
+			f, err = getMaskedFile(ctx, s, req.ID)
+		} else {
+			f, err = s.GetFile(ctx, req.ID)
+		}
+
+		var checksums *ach.FileChecksums
+		if err == nil && req.includeChecksums {
+			checksums, err = f.Checksums()
+		}
+
+		logEvent(logger, "files", "getFile", err, "requestID", req.requestID, "fileID", req.ID)
+
+		if req.jsonV2 && err == nil {
+			v2, err := f.ToV2()
+			if err != nil {
+				err = invalid(err)
+			}
+			return getFileV2Response{
+				File:      v2,
+				Checksums: checksums,
+				Err:       err,
+				rev:       revision,
+				masked:    req.masked,
+			}, nil
 		}
 
 		return getFileResponse{
-			File: f,
-			Err:  err,
+			File:      f,
+			Checksums: checksums,
+			Err:       err,
+			rev:       revision,
+			masked:    req.masked,
 		}, nil
 	}
 }
@@ -199,14 +611,93 @@ func decodeGetFileRequest(_ context.Context, r *http.Request) (interface{}, erro
 	if !ok {
 		return nil, ErrBadRouting
 	}
+	jsonV2, err := parseJSONFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		return nil, err
+	}
+	masked, err := parseMasked(r)
+	if err != nil {
+		return nil, err
+	}
 	return getFileRequest{
+		ID:               id,
+		includeChecksums: includes(r, "checksums"),
+		jsonV2:           jsonV2,
+		masked:           masked,
+		requestID:        moovhttp.GetRequestID(r),
+	}, nil
+}
+
+type patchFileRequest struct {
+	ID    string
+	patch *FileHeaderPatch
+	opts  []ChangeOption
+
+	requestID string
+}
+
+type patchFileResponse struct {
+	File *ach.File `json:"file"`
+	Err  error     `json:"error"`
+}
+
+func (r patchFileResponse) error() error { return r.Err }
+
+func patchFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(patchFileRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return patchFileResponse{
+				Err: err,
+			}, err
+		}
+
+		f, err := s.PatchFileHeader(ctx, req.ID, req.patch, req.opts...)
+
+		logEvent(logger, "files", "patchFile", err, "requestID", req.requestID, "fileID", req.ID)
+
+		return patchFileResponse{
+			File: f,
+			Err:  err,
+		}, nil
+	}
+}
+
+func decodePatchFileRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	opts, err := parseIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+	req := patchFileRequest{
 		ID:        id,
+		opts:      opts,
 		requestID: moovhttp.GetRequestID(r),
-	}, nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req.patch); err != nil {
+		return nil, invalid(err)
+	}
+	return req, nil
+}
+
+// includes returns true if the comma separated ?include= query parameter contains value
+func includes(r *http.Request, value string) bool {
+	for _, v := range strings.Split(r.URL.Query().Get("include"), ",") {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
 }
 
 type deleteFileRequest struct {
-	ID string
+	ID   string
+	opts []ChangeOption
 
 	requestID string
 }
@@ -218,7 +709,7 @@ type deleteFileResponse struct {
 func (r deleteFileResponse) error() error { return r.Err }
 
 func deleteFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
-	return func(_ context.Context, request interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(deleteFileRequest)
 		if !ok {
 			err := errors.New("invalid request")
@@ -229,11 +720,9 @@ func deleteFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 
 		filesDeleted.Add(1)
 
-		err := s.DeleteFile(req.ID)
+		err := s.DeleteFile(ctx, req.ID, req.opts...)
 
-		if logger != nil {
-			logger.Log("files", "deleteFile", "requestID", req.requestID, "error", err)
-		}
+		logEvent(logger, "files", "deleteFile", err, "requestID", req.requestID, "fileID", req.ID)
 
 		return deleteFileResponse{
 			Err: err,
@@ -247,14 +736,24 @@ func decodeDeleteFileRequest(_ context.Context, r *http.Request) (interface{}, e
 	if !ok {
 		return nil, ErrBadRouting
 	}
+	opts, err := parseIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
 	return deleteFileRequest{
 		ID:        id,
+		opts:      opts,
 		requestID: moovhttp.GetRequestID(r),
 	}, nil
 }
 
 type getFileContentsRequest struct {
-	ID string
+	ID     string
+	masked bool
+
+	// conditional request headers
+	ifNoneMatch     string
+	ifModifiedSince string
 
 	requestID string
 }
@@ -266,7 +765,7 @@ type getFileContentsResponse struct {
 func (v getFileContentsResponse) error() error { return v.Err }
 
 func getFileContentsEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
-	return func(_ context.Context, request interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(getFileContentsRequest)
 		if !ok {
 			err := errors.New("invalid request")
@@ -275,16 +774,35 @@ func getFileContentsEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 			}, err
 		}
 
-		r, err := s.GetFileContents(req.ID)
+		// Read the revision first, like getFileEndpoint, so the ETag is never newer than the contents
+		revision, _ := s.FileRevision(ctx, req.ID)
+
+		var r io.Reader
+		var err error
+		if req.masked {
+			var f *ach.File
+			if f, err = getMaskedFile(ctx, s, req.ID); err == nil {
+				r, err = fileContents(f)
+			}
+		} else {
+			r, err = s.GetFileContents(ctx, req.ID)
+		}
 
-		if logger != nil {
-			logger.Log("files", "getFileContents", "requestID", req.requestID, "error", err)
+		var contents *contentsReader
+		if err == nil {
+			contents, err = readContents(r)
 		}
+
+		logEvent(logger, "files", "getFileContents", err, "requestID", req.requestID, "fileID", req.ID)
 		if err != nil {
 			return getFileContentsResponse{Err: err}, nil
 		}
 
-		return r, nil
+		if revision > 0 {
+			contents.etag = fileETag(revision, fileRepresentation("contents", req.masked)...)
+		}
+		contents.notModified = contents.notModifiedSince(req.ifNoneMatch, req.ifModifiedSince)
+		return contents, nil
 	}
 }
 
@@ -294,9 +812,16 @@ func decodeGetFileContentsRequest(_ context.Context, r *http.Request) (interface
 	if !ok {
 		return nil, ErrBadRouting
 	}
+	masked, err := parseMasked(r)
+	if err != nil {
+		return nil, err
+	}
 	return getFileContentsRequest{
-		ID:        id,
-		requestID: moovhttp.GetRequestID(r),
+		ID:              id,
+		masked:          masked,
+		ifNoneMatch:     r.Header.Get("If-None-Match"),
+		ifModifiedSince: r.Header.Get("If-Modified-Since"),
+		requestID:       moovhttp.GetRequestID(r),
 	}, nil
 }
 
@@ -308,13 +833,15 @@ type validateFileRequest struct {
 }
 
 type validateFileResponse struct {
-	Err error `json:"error"`
+	// Warnings are returned whether or not the file is valid, they don't fail validation
+	Warnings []ach.Warning `json:"warnings"`
+	Err      error         `json:"error"`
 }
 
 func (v validateFileResponse) error() error { return v.Err }
 
 func validateFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
-	return func(_ context.Context, request interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(validateFileRequest)
 		if !ok {
 			err := errors.New("invalid request")
@@ -323,14 +850,19 @@ func validateFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 			}, err
 		}
 
-		err := s.ValidateFile(req.ID, req.opts)
-		if logger != nil {
-			logger.Log("files", "validateFile", "requestID", req.requestID, "error", err)
+		err := s.ValidateFile(ctx, req.ID, req.opts)
+		logEvent(logger, "files", "validateFile", err, "requestID", req.requestID, "fileID", req.ID)
+		if errors.Is(err, ErrNotFound) {
+			return validateFileResponse{Err: err}, nil
+		}
+		warnings, _ := s.FileWarnings(ctx, req.ID)
+		if warnings == nil {
+			warnings = []ach.Warning{}
 		}
 		if err != nil { // wrap err with context
-			err = fmt.Errorf("%v: %v", errInvalidFile, err)
+			err = &warningsError{invalidFile(err), warnings}
 		}
-		return validateFileResponse{err}, nil
+		return validateFileResponse{Warnings: warnings, Err: err}, nil
 	}
 }
 
@@ -354,6 +886,400 @@ func decodeValidateFileRequest(_ context.Context, r *http.Request) (interface{},
 	return req, nil
 }
 
+type lintFileRequest struct {
+	File *ach.File
+	// parseErr is why the uploaded file couldn't be read, which is returned as the lint's errors
+	parseErr    error
+	diagnostics []ach.Diagnostic
+
+	requestID string
+}
+
+// fileError is an error from reading or validating a file, with the location found in it
+type fileError struct {
+	Line        int    `json:"line,omitempty"`
+	Record      string `json:"record,omitempty"`
+	BatchNumber int    `json:"batchNumber,omitempty"`
+	FieldName   string `json:"fieldName,omitempty"`
+	Err         string `json:"error"`
+}
+
+type lintFileResponse struct {
+	Valid       bool             `json:"valid"`
+	Errors      []fileError      `json:"errors"`
+	Warnings    []ach.Warning    `json:"warnings"`
+	Diagnostics []ach.Diagnostic `json:"diagnostics,omitempty"`
+	Err         error            `json:"error"`
+}
+
+func (v lintFileResponse) error() error { return v.Err }
+
+func lintFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(lintFileRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return lintFileResponse{
+				Err: err,
+			}, err
+		}
+
+		err := req.parseErr
+		if err == nil {
+			err = s.LintFile(ctx, req.File)
+			if ctx.Err() != nil {
+				logEvent(logger, "files", "lintFile", err, "requestID", req.requestID)
+				return lintFileResponse{Err: err}, nil
+			}
+		}
+		resp := lintFileResponse{
+			Valid:       err == nil,
+			Errors:      fileErrors(err),
+			Warnings:    []ach.Warning{},
+			Diagnostics: req.diagnostics,
+		}
+		if req.parseErr == nil {
+			resp.Warnings = append(resp.Warnings, req.File.Warnings()...)
+		}
+		logEvent(logger, "files", "lintFile", nil, "requestID", req.requestID, "valid", resp.Valid, "errors", len(resp.Errors))
+		return resp, nil
+	}
+}
+
+// fileErrors flattens the errors from reading or validating a file for our JSON response
+func fileErrors(err error) []fileError {
+	out := []fileError{}
+	if err == nil {
+		return out
+	}
+	errs, ok := err.(base.ErrorList)
+	if !ok {
+		errs = base.ErrorList{err}
+	}
+	for i := range errs {
+		fe := fileError{Err: errs[i].Error()}
+		var parseErr *base.ParseError
+		if errors.As(errs[i], &parseErr) {
+			fe.Line, fe.Record = parseErr.Line, parseErr.Record
+			if parseErr.Err != nil {
+				fe.Err = parseErr.Err.Error()
+			}
+		}
+		var fieldErr *ach.FieldError
+		if errors.As(errs[i], &fieldErr) {
+			fe.FieldName = fieldErr.FieldName
+		}
+		var batchErr *ach.BatchError
+		if errors.As(errs[i], &batchErr) {
+			fe.BatchNumber = batchErr.BatchNumber
+			if fe.FieldName == "" && batchErr.FieldName != "FieldError" { // BatchErrors wrapping a FieldError are named FieldError
+				fe.FieldName = batchErr.FieldName
+			}
+		}
+		var fileErr ach.FileError
+		if errors.As(errs[i], &fileErr) && fe.FieldName == "" {
+			fe.FieldName = fileErr.FieldName
+		}
+		out = append(out, fe)
+	}
+	return out
+}
+
+// decodeLintFileRequest reads the uploaded file like decodeCreateFileRequest, but keeps why a file
+// couldn't be read for the response rather than rejecting the request
+func decodeLintFileRequest(ctx context.Context, request *http.Request) (interface{}, error) {
+	jsonV2, err := parseJSONFormat(request.URL.Query().Get("format"))
+	if err != nil {
+		return nil, err
+	}
+	create := createFileRequest{
+		File:   ach.NewFile(),
+		jsonV2: jsonV2,
+	}
+
+	body := &countingReader{Reader: request.Body}
+	_, span := startSpan(ctx, "ReadFile")
+	err = readCreateFileBody(&create, request, body)
+	span.SetAttribute("bytes", body.n)
+	span.End(err)
+	if errors.Is(err, ErrTooLarge) {
+		return nil, err
+	}
+	req := lintFileRequest{
+		File:        create.File,
+		diagnostics: create.diagnostics,
+		requestID:   moovhttp.GetRequestID(request),
+	}
+	if err != nil {
+		req.parseErr = errors.Unwrap(err) // drop the invalid() kind, it's not an error of the request
+	}
+	return req, nil
+}
+
+type buildFileRequest struct {
+	ID        string
+	opts      []ChangeOption
+	requestID string
+}
+
+type buildFileResponse struct {
+	File *ach.File `json:"file"`
+	Err  error     `json:"error"`
+}
+
+func (r buildFileResponse) error() error { return r.Err }
+
+func buildFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(buildFileRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return buildFileResponse{
+				Err: err,
+			}, err
+		}
+
+		f, err := s.BuildFile(ctx, req.ID, req.opts...)
+
+		logEvent(logger, "files", "buildFile", err, "requestID", req.requestID, "fileID", req.ID)
+
+		return buildFileResponse{
+			File: f,
+			Err:  err,
+		}, nil
+	}
+}
+
+func decodeBuildFileRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	opts, err := parseIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+	return buildFileRequest{
+		ID:        id,
+		opts:      opts,
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
+
+type sameDayFileRequest struct {
+	ID        string
+	requestID string
+}
+
+type sameDayEntry struct {
+	BatchNumber int    `json:"batchNumber"`
+	TraceNumber string `json:"traceNumber,omitempty"`
+	Err         string `json:"error"`
+}
+
+type sameDayFileResponse struct {
+	Eligible bool           `json:"eligible"`
+	Errors   []sameDayEntry `json:"errors"`
+	Err      error          `json:"error"`
+}
+
+func (v sameDayFileResponse) error() error { return v.Err }
+
+func sameDayFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(sameDayFileRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return sameDayFileResponse{
+				Err: err,
+			}, err
+		}
+
+		f, err := s.GetFile(ctx, req.ID)
+		if err != nil {
+			logEvent(logger, "files", "sameDayFile", err, "requestID", req.requestID, "fileID", req.ID)
+			return sameDayFileResponse{Err: err}, nil
+		}
+
+		resp := sameDayFileResponse{Eligible: true}
+		if err := f.IsEligibleSameDay(); err != nil {
+			resp.Eligible = false
+			resp.Errors = sameDayEntries(err)
+		}
+		logEvent(logger, "files", "sameDayFile", nil, "requestID", req.requestID, "fileID", req.ID, "eligible", resp.Eligible)
+		return resp, nil
+	}
+}
+
+// sameDayEntries flattens the errors from ach.File's IsEligibleSameDay for our JSON response
+func sameDayEntries(err error) []sameDayEntry {
+	errs, ok := err.(base.ErrorList)
+	if !ok {
+		errs = base.ErrorList{err}
+	}
+	var out []sameDayEntry
+	for i := range errs {
+		if sdErr, ok := errs[i].(*ach.SameDayError); ok {
+			out = append(out, sameDayEntry{
+				BatchNumber: sdErr.BatchNumber,
+				TraceNumber: sdErr.TraceNumber,
+				Err:         sdErr.Err.Error(),
+			})
+		} else {
+			out = append(out, sameDayEntry{Err: errs[i].Error()})
+		}
+	}
+	return out
+}
+
+func decodeSameDayFileRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return sameDayFileRequest{
+		ID:        id,
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
+
+type getFileVersionsRequest struct {
+	ID        string
+	masked    bool
+	requestID string
+}
+
+type getFileVersionsResponse struct {
+	Versions []*FileVersion `json:"versions"`
+	Err      error          `json:"error"`
+}
+
+func (r getFileVersionsResponse) count() int { return len(r.Versions) }
+
+func (r getFileVersionsResponse) error() error { return r.Err }
+
+func getFileVersionsEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(getFileVersionsRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return getFileVersionsResponse{
+				Err: err,
+			}, err
+		}
+
+		versions, err := s.GetFileVersions(ctx, req.ID)
+		if err == nil && req.masked {
+			versions, err = maskFileVersions(req.ID, versions)
+		}
+
+		logEvent(logger, "files", "getFileVersions", err, "requestID", req.requestID, "fileID", req.ID)
+
+		return getFileVersionsResponse{
+			Versions: versions,
+			Err:      err,
+		}, nil
+	}
+}
+
+func decodeGetFileVersionsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	masked, err := parseMasked(r)
+	if err != nil {
+		return nil, err
+	}
+	return getFileVersionsRequest{
+		ID:        id,
+		masked:    masked,
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
+
+// maskFileVersions returns copies of versions with the personal data of their files masked by ach.File.MaskSensitiveData
+func maskFileVersions(fileID string, versions []*FileVersion) ([]*FileVersion, error) {
+	out := make([]*FileVersion, len(versions))
+	for i, v := range versions {
+		file, err := fileFromVersion(fileID, v.Version, v.File)
+		if err != nil {
+			return nil, err
+		}
+		if file, err = file.MaskSensitiveData(nil); err != nil {
+			return nil, err
+		}
+		bs, err := json.Marshal(file)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = &FileVersion{
+			Version: v.Version,
+			Created: v.Created,
+			File:    bs,
+		}
+	}
+	return out, nil
+}
+
+type rollbackFileRequest struct {
+	ID        string
+	version   int
+	requestID string
+}
+
+type rollbackFileResponse struct {
+	File *ach.File `json:"file"`
+	Err  error     `json:"error"`
+}
+
+func (r rollbackFileResponse) error() error { return r.Err }
+
+func rollbackFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(rollbackFileRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return rollbackFileResponse{
+				Err: err,
+			}, err
+		}
+
+		f, err := s.RollbackFile(ctx, req.ID, req.version)
+
+		logEvent(logger, "files", "rollbackFile", err, "requestID", req.requestID, "fileID", req.ID, "version", req.version)
+
+		return rollbackFileResponse{
+			File: f,
+			Err:  err,
+		}, nil
+	}
+}
+
+func decodeRollbackFileRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	v, ok := vars["version"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	version, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, invalid(fmt.Errorf("invalid file version %q", v))
+	}
+	return rollbackFileRequest{
+		ID:        id,
+		version:   version,
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
+
 type balanceFileRequest struct {
 	fileID    string
 	offset    *ach.Offset
@@ -366,21 +1292,20 @@ type balanceFileResponse struct {
 }
 
 func balanceFileEndpoint(s Service, r Repository, logger log.Logger) endpoint.Endpoint {
-	return func(_ context.Context, request interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(balanceFileRequest)
 		if !ok {
 			err := errors.New("invalid request")
 			return balanceFileResponse{Err: err}, err
 		}
 
-		balancedFile, err := s.BalanceFile(req.fileID, req.offset)
-		if balancedFile != nil && logger != nil {
-			logger.Log("files", fmt.Sprintf("balance file created %s", balancedFile.ID), "requestID", req.requestID, "error", err)
+		balancedFile, err := s.BalanceFile(ctx, req.fileID, req.offset)
+		if balancedFile != nil {
+			logEvent(logger, "files", "balanceFile", err, "requestID", req.requestID, "fileID", balancedFile.ID, "sourceFileID", req.fileID)
+		} else {
+			logEvent(logger, "files", "balanceFile", err, "requestID", req.requestID, "sourceFileID", req.fileID)
 		}
 		if err != nil {
-			if logger != nil {
-				logger.Log("files", fmt.Sprintf("problem balancing %s: %v", req.fileID, err), "requestID", req.requestID, "error", err)
-			}
 			return balanceFileResponse{Err: err}, err
 		}
 		return balanceFileResponse{
@@ -398,10 +1323,10 @@ func decodeBalanceFileRequest(_ context.Context, r *http.Request) (interface{},
 
 	var off ach.Offset
 	if err := json.NewDecoder(r.Body).Decode(&off); err != nil {
-		return nil, err
+		return nil, invalid(err)
 	}
 	if off.RoutingNumber == "" || off.AccountNumber == "" || string(off.AccountType) == "" {
-		return nil, errors.New("missing some offset json fields")
+		return nil, invalid(errors.New("missing some offset json fields"))
 	}
 	return balanceFileRequest{
 		fileID:    fileID,
@@ -424,7 +1349,7 @@ type segmentFileResponse struct {
 }
 
 func segmentFileEndpoint(s Service, r Repository, logger log.Logger) endpoint.Endpoint {
-	return func(_ context.Context, request interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(segmentFileRequest)
 		if !ok {
 			err := errors.New("invalid request")
@@ -433,27 +1358,21 @@ func segmentFileEndpoint(s Service, r Repository, logger log.Logger) endpoint.En
 			}, err
 		}
 
-		creditFile, debitFile, err := s.SegmentFile(req.fileID, req.opts)
+		creditFile, debitFile, err := s.SegmentFile(ctx, req.fileID, req.opts)
 
-		if logger != nil {
-			logger.Log("files", "segmentFile", "requestID", req.requestID, "error", err)
-		}
+		logEvent(logger, "files", "segmentFile", err, "requestID", req.requestID, "fileID", req.fileID)
 		if err != nil {
 			return segmentFileResponse{Err: err}, err
 		}
 
 		if creditFile.ID != "" {
-			err = r.StoreFile(creditFile)
-			if logger != nil {
-				logger.Log("files", "storeCreditFile", "requestID", req.requestID, "error", err)
-			}
+			err = r.StoreFile(ctx, creditFile)
+			logEvent(logger, "files", "storeCreditFile", err, "requestID", req.requestID, "fileID", creditFile.ID, "sourceFileID", req.fileID)
 		}
 
 		if debitFile.ID != "" {
-			err = r.StoreFile(debitFile)
-			if logger != nil {
-				logger.Log("files", "storeDebitFile", "requestID", req.requestID, "error", err)
-			}
+			err = r.StoreFile(ctx, debitFile)
+			logEvent(logger, "files", "storeDebitFile", err, "requestID", req.requestID, "fileID", debitFile.ID, "sourceFileID", req.fileID)
 		}
 		return segmentFileResponse{
 			CreditFileID: creditFile.ID,
@@ -494,7 +1413,7 @@ type flattenBatchesResponse struct {
 }
 
 func flattenBatchesEndpoint(s Service, r Repository, logger log.Logger) endpoint.Endpoint {
-	return func(_ context.Context, request interface{}) (interface{}, error) {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(flattenBatchesRequest)
 		if !ok {
 			err := errors.New("invalid request")
@@ -502,18 +1421,14 @@ func flattenBatchesEndpoint(s Service, r Repository, logger log.Logger) endpoint
 				Err: err,
 			}, err
 		}
-		flattenFile, err := s.FlattenBatches(req.fileID)
-		if logger != nil {
-			logger.Log("files", "FlattenBatches", "requestID", req.requestID, "error", err)
-		}
+		flattenFile, err := s.FlattenBatches(ctx, req.fileID)
+		logEvent(logger, "files", "flattenBatches", err, "requestID", req.requestID, "fileID", req.fileID)
 		if err != nil {
 			return flattenBatchesResponse{Err: err}, err
 		}
 		if flattenFile.ID != "" {
-			err = r.StoreFile(flattenFile)
-			if logger != nil {
-				logger.Log("files", "storeFlattenFile", "requestID", req.requestID, "error", err)
-			}
+			err = r.StoreFile(ctx, flattenFile)
+			logEvent(logger, "files", "storeFlattenFile", err, "requestID", req.requestID, "fileID", flattenFile.ID, "sourceFileID", req.fileID)
 		}
 		return flattenBatchesResponse{
 			ID:  flattenFile.ID,
@@ -533,3 +1448,116 @@ func decodeFlattenBatchesRequest(_ context.Context, r *http.Request) (interface{
 		requestID: moovhttp.GetRequestID(r),
 	}, nil
 }
+
+type splitFileRequest struct {
+	fileID    string
+	requestID string
+}
+
+type splitFileResponse struct {
+	FileIDs []string `json:"fileIDs"`
+	Err     error    `json:"error"`
+}
+
+func splitFileEndpoint(s Service, r Repository, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(splitFileRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return splitFileResponse{
+				Err: err,
+			}, err
+		}
+		files, err := s.SplitFileByEffectiveDate(ctx, req.fileID)
+		logEvent(logger, "files", "splitFile", err, "requestID", req.requestID, "fileID", req.fileID)
+		if err != nil {
+			return splitFileResponse{Err: err}, err
+		}
+		resp := splitFileResponse{FileIDs: make([]string, 0, len(files))}
+		for _, f := range files {
+			// a file with a single effective date isn't split
+			if f.ID != req.fileID {
+				if err := r.StoreFile(ctx, f); err != nil {
+					logEvent(logger, "files", "storeSplitFile", err, "requestID", req.requestID, "fileID", f.ID, "sourceFileID", req.fileID)
+					return splitFileResponse{Err: err}, err
+				}
+				logEvent(logger, "files", "storeSplitFile", nil, "requestID", req.requestID, "fileID", f.ID, "sourceFileID", req.fileID)
+			}
+			resp.FileIDs = append(resp.FileIDs, f.ID)
+		}
+		return resp, nil
+	}
+}
+
+func decodeSplitFileRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	fileID, ok := vars["fileID"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return splitFileRequest{
+		fileID:    fileID,
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
+
+type cloneFileRequest struct {
+	fileID             string
+	effectiveEntryDate ach.Date
+	requestID          string
+}
+
+type cloneFileResponse struct {
+	ID  string `json:"id"`
+	Err error  `json:"error"`
+}
+
+func (r cloneFileResponse) error() error { return r.Err }
+
+func cloneFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(cloneFileRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return cloneFileResponse{
+				Err: err,
+			}, err
+		}
+
+		var resp cloneFileResponse
+		f, err := s.CloneFile(ctx, req.fileID, req.effectiveEntryDate)
+		if f != nil {
+			resp.ID = f.ID
+		}
+		resp.Err = err
+		logEvent(logger, "files", "cloneFile", err, "requestID", req.requestID, "fileID", req.fileID, "cloneID", resp.ID)
+		return resp, nil
+	}
+}
+
+// decodeCloneFileRequest reads the effectiveEntryDate (YYYY-MM-DD) of a clone, the next banking day when it's not set.
+// It's a date rather than a time so clients west of the server aren't moved to the next day, and is in the past
+// once the day is over in Eastern time as entries can't settle before the Federal Reserve's current day.
+func decodeCloneFileRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	fileID, ok := mux.Vars(r)["fileID"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	today := ach.Today()
+	effective := ach.DateOf(ach.NextBankingDay(today.Time(), false))
+	if v := r.URL.Query().Get("effectiveEntryDate"); v != "" {
+		d, err := time.Parse(calendarDateFormat, v)
+		if err != nil {
+			return nil, invalid(fmt.Errorf("invalid date %q", v))
+		}
+		effective = ach.DateOf(d)
+	}
+	if effective.Before(today) {
+		return nil, invalid(fmt.Errorf("effectiveEntryDate %s is in the past", effective))
+	}
+	return cloneFileRequest{
+		fileID:             fileID,
+		effectiveEntryDate: effective,
+		requestID:          moovhttp.GetRequestID(r),
+	}, nil
+}
@@ -25,10 +25,12 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/moov-io/ach"
 	"github.com/moov-io/base"
@@ -70,6 +72,31 @@ func TestFiles__decodeCreateFileRequest(t *testing.T) {
 	}
 }
 
+func BenchmarkFiles__decodeCreateFileRequest(b *testing.B) {
+	bench := func(b *testing.B, path, contentType string) {
+		bs, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", path))
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		b.SetBytes(int64(len(bs)))
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			req := httptest.NewRequest("POST", "/files/create", bytes.NewReader(bs))
+			req.Header.Set("Content-Type", contentType)
+			if _, err := decodeCreateFileRequest(context.TODO(), req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	b.Run("nacha", func(b *testing.B) {
+		bench(b, "ppd-mixedDebitCredit.ach", "text/plain")
+	})
+	b.Run("json", func(b *testing.B) {
+		bench(b, "ppd-mixedDebitCredit-valid.json", "application/json")
+	})
+}
+
 func TestFiles__createFileEndpoint(t *testing.T) {
 	repo := NewRepositoryInMemory(testTTLDuration, nil)
 	svc := NewService(repo)
@@ -86,7 +113,120 @@ func TestFiles__createFileEndpoint(t *testing.T) {
 	}
 }
 
+func TestFiles__createFileOnConflict(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	bs, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	create := func(query string, body []byte) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/files/create"+query, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		w.Flush()
+		return w
+	}
+
+	if w := create("", bs); w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	// retrying is rejected by default
+	if w := create("", bs); w.Code != http.StatusConflict {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w := create("?onConflict=reject", bs); w.Code != http.StatusConflict {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	// a changed file is not stored when ignoring conflicts
+	changed := bytes.Replace(bs, []byte(`"Wells Fargo"`), []byte(`"Other Bank"`), -1)
+	if w := create("?onConflict=ignore", changed); w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if f, _ := repo.FindFile(ctx, "adam-01"); f.Header.ImmediateOriginName != "Wells Fargo" {
+		t.Errorf("ImmediateOriginName=%q", f.Header.ImmediateOriginName)
+	}
+
+	// replace stores the changed file and keeps the prior one as a version
+	if w := create("?onConflict=replace", changed); w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if f, _ := repo.FindFile(ctx, "adam-01"); f.Header.ImmediateOriginName != "Other Bank" {
+		t.Errorf("ImmediateOriginName=%q", f.Header.ImmediateOriginName)
+	}
+	if versions, _ := repo.FindVersions(ctx, "adam-01"); len(versions) != 1 {
+		t.Errorf("versions=%d", len(versions))
+	}
+
+	if w := create("?onConflict=other", bs); w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestFiles__createFileOnDuplicate(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	bs, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	create := func(query string, id string) *httptest.ResponseRecorder {
+		body := bytes.Replace(bs, []byte(`"adam-01"`), []byte(fmt.Sprintf("%q", id)), -1)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/files/create"+query, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		w.Flush()
+		return w
+	}
+
+	if w := create("", "adam-01"); w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	// duplicates are stored and flagged by default
+	w := create("", "adam-02")
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var resp createFileResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.DuplicateOf) != 1 || resp.DuplicateOf[0] != "adam-01" {
+		t.Errorf("DuplicateOf=%v", resp.DuplicateOf)
+	}
+
+	// rejected duplicates are not stored
+	w = create("?onDuplicate=reject", "adam-03")
+	if w.Code != http.StatusConflict {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "adam-01, adam-02") {
+		t.Errorf("unexpected error: %s", w.Body.String())
+	}
+	if _, err := repo.FindFile(ctx, "adam-03"); err != ErrNotFound {
+		t.Errorf("expected adam-03 to not be stored: %v", err)
+	}
+
+	if w := create("?onDuplicate=other", "adam-04"); w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
+
 func TestFiles__getFilesEndpoint(t *testing.T) {
+	ctx := context.Background()
 	repo := NewRepositoryInMemory(testTTLDuration, nil)
 	svc := NewService(repo)
 
@@ -94,7 +234,7 @@ func TestFiles__getFilesEndpoint(t *testing.T) {
 	f.ID = "foo"
 	f.Header = *mockFileHeader()
 	f.AddBatch(mockBatchWEB())
-	if err := repo.StoreFile(f); err != nil {
+	if err := repo.StoreFile(ctx, f); err != nil {
 		t.Fatal(err)
 	}
 
@@ -123,7 +263,65 @@ func TestFiles__getFilesEndpoint(t *testing.T) {
 	}
 }
 
+func TestFiles__getFilesFilters(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo)
+	handler := MakeHTTPHandler(svc, repo, log.NewNopLogger())
+
+	for _, id := range []string{"foo", "bar"} {
+		f := ach.NewFile()
+		f.ID = id
+		f.Header = *mockFileHeader()
+		f.AddBatch(mockBatchWEB())
+		if err := repo.StoreFile(ctx, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/files?count=1&shallow=true&origin=121042882", nil))
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status code: %d: %s", w.Code, w.Body.String())
+	}
+	if v := w.Header().Get("X-Total-Count"); v != "2" {
+		t.Errorf("X-Total-Count: %s", v)
+	}
+	var resp struct {
+		Files []map[string]interface{} `json:"files"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Files) != 1 || resp.Files[0]["id"] != "bar" {
+		t.Fatalf("unexpected files: %#v", resp.Files)
+	}
+	if _, ok := resp.Files[0]["batches"]; ok {
+		t.Errorf("shallow file has batches: %#v", resp.Files[0])
+	}
+
+	// no files match
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/files?destination=121042882", nil))
+	w.Flush()
+	if v := w.Header().Get("X-Total-Count"); v != "0" {
+		t.Errorf("X-Total-Count: %s", v)
+	}
+
+	for _, query := range []string{"skip=-1", "count=x", "createdAfter=yesterday", "shallow=maybe"} {
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/files?"+query, nil))
+		w.Flush()
+		if w.Code == http.StatusOK {
+			t.Errorf("%s: expected error", query)
+		}
+	}
+}
+
 func TestFiles__getFileEndpoint(t *testing.T) {
+	ctx := context.Background()
 	repo := NewRepositoryInMemory(testTTLDuration, nil)
 	svc := NewService(repo)
 
@@ -131,7 +329,7 @@ func TestFiles__getFileEndpoint(t *testing.T) {
 	f.ID = "foo"
 	f.Header = *mockFileHeader()
 	f.AddBatch(mockBatchWEB())
-	if err := repo.StoreFile(f); err != nil {
+	if err := repo.StoreFile(ctx, f); err != nil {
 		t.Fatal(err)
 	}
 
@@ -163,7 +361,92 @@ func TestFiles__getFileEndpoint(t *testing.T) {
 	}
 }
 
+func TestFiles__JSONV2(t *testing.T) {
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo)
+	handler := MakeHTTPHandler(svc, repo, log.NewNopLogger())
+
+	bs, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := ach.FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := f.MarshalJSONV2()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// create a file from v2 JSON
+	req := httptest.NewRequest("POST", "/files/create?format=v2", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status code: %d: %s", w.Code, w.Body.String())
+	}
+	var created createFileResponse
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatal(err)
+	}
+
+	// read it back as v2
+	req = httptest.NewRequest("GET", "/files/"+created.ID+"?format=v2", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status code: %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		File *ach.FileV2 `json:"file"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.File == nil || resp.File.Version != ach.JSONVersion2 || len(resp.File.Batches) != 1 || resp.File.Batches[0].Entries[0].Amount != 100000 {
+		t.Errorf("unexpected file: %#v", resp.File)
+	}
+
+	// the legacy format is still the default
+	req = httptest.NewRequest("GET", "/files/"+created.ID, nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	w.Flush()
+
+	if !strings.Contains(w.Body.String(), `"fileHeader"`) {
+		t.Errorf("unexpected legacy file: %s", w.Body.String())
+	}
+
+	// unknown formats are rejected
+	req = httptest.NewRequest("GET", "/files/"+created.ID+"?format=v9", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status code: %d: %s", w.Code, w.Body.String())
+	}
+
+	// invalid v2 JSON is rejected
+	req = httptest.NewRequest("POST", "/files/create?format=v2", strings.NewReader(`{"version": "v2", "header": 1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status code: %d: %s", w.Code, w.Body.String())
+	}
+}
+
 func TestFiles__getFileContentsEndpoint(t *testing.T) {
+	ctx := context.Background()
 	repo := NewRepositoryInMemory(testTTLDuration, nil)
 	svc := NewService(repo)
 
@@ -171,7 +454,7 @@ func TestFiles__getFileContentsEndpoint(t *testing.T) {
 	f.ID = "foo"
 	f.Header = *mockFileHeader()
 	f.AddBatch(mockBatchWEB())
-	if err := repo.StoreFile(f); err != nil {
+	if err := repo.StoreFile(ctx, f); err != nil {
 		t.Fatal(err)
 	}
 
@@ -206,6 +489,7 @@ func TestFiles__getFileContentsEndpoint(t *testing.T) {
 }
 
 func TestFiles__validateFileEndpoint(t *testing.T) {
+	ctx := context.Background()
 	logger := log.NewNopLogger()
 	repo := NewRepositoryInMemory(testTTLDuration, logger)
 	svc := NewService(repo)
@@ -230,7 +514,7 @@ func TestFiles__validateFileEndpoint(t *testing.T) {
 	bs, _ := ioutil.ReadAll(fd)
 	file, _ := ach.FileFromJSON(bs)
 	file.Header.ImmediateDestination = "" // invalid routing number
-	repo.StoreFile(file)
+	repo.StoreFile(ctx, file)
 
 	// test status code
 	w := httptest.NewRecorder()
@@ -255,6 +539,7 @@ func TestFiles__validateFileEndpoint(t *testing.T) {
 }
 
 func TestFiles__ValidateOpts(t *testing.T) {
+	ctx := context.Background()
 	logger := log.NewLogfmtLogger(ioutil.Discard)
 	repo := NewRepositoryInMemory(testTTLDuration, logger)
 	svc := NewService(repo)
@@ -269,7 +554,7 @@ func TestFiles__ValidateOpts(t *testing.T) {
 	bs, _ := ioutil.ReadAll(fd)
 	file, _ := ach.FileFromJSON(bs)
 	file.Header.ImmediateOrigin = "123456789" // invalid routing number
-	repo.StoreFile(file)
+	repo.StoreFile(ctx, file)
 
 	// validate, expect failure
 	w := httptest.NewRecorder()
@@ -291,7 +576,7 @@ func TestFiles__ValidateOpts(t *testing.T) {
 
 	// correct file
 	file.Header.ImmediateOrigin = "987654320" // routing number
-	repo.StoreFile(file)
+	repo.StoreFile(ctx, file)
 
 	// retry, but with different ValidateOpts
 	w = httptest.NewRecorder()
@@ -320,6 +605,7 @@ func TestFilesErr__balanceFileEndpoint(t *testing.T) {
 }
 
 func TestFiles__balanceFileEndpoint(t *testing.T) {
+	ctx := context.Background()
 	logger := log.NewNopLogger()
 	repo := NewRepositoryInMemory(testTTLDuration, logger)
 	svc := NewService(repo)
@@ -334,7 +620,7 @@ func TestFiles__balanceFileEndpoint(t *testing.T) {
 
 	bs, _ := ioutil.ReadAll(fd)
 	file, _ := ach.FileFromJSON(bs)
-	repo.StoreFile(file)
+	repo.StoreFile(ctx, file)
 
 	w := httptest.NewRecorder()
 	body := strings.NewReader(`{"routingNumber": "987654320", "accountNumber": "216112", "accountType": "checking", "description": "OFFSET"}`)
@@ -363,6 +649,7 @@ func TestFiles__balanceFileEndpoint(t *testing.T) {
 }
 
 func TestFilesErr__balanceInvalidFile(t *testing.T) {
+	ctx := context.Background()
 	logger := log.NewNopLogger()
 	repo := NewRepositoryInMemory(testTTLDuration, logger)
 	svc := NewService(repo)
@@ -370,7 +657,7 @@ func TestFilesErr__balanceInvalidFile(t *testing.T) {
 
 	// write an invalid (partial) file
 	fh := ach.NewFileHeader()
-	fileID, err := svc.CreateFile(&fh)
+	fileID, err := svc.CreateFile(ctx, &fh)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -389,6 +676,7 @@ func TestFilesErr__balanceInvalidFile(t *testing.T) {
 }
 
 func TestFilesErr__balanceFileEndpointJSON(t *testing.T) {
+	ctx := context.Background()
 	logger := log.NewNopLogger()
 	repo := NewRepositoryInMemory(testTTLDuration, logger)
 	svc := NewService(repo)
@@ -403,7 +691,7 @@ func TestFilesErr__balanceFileEndpointJSON(t *testing.T) {
 
 	bs, _ := ioutil.ReadAll(fd)
 	file, _ := ach.FileFromJSON(bs)
-	repo.StoreFile(file)
+	repo.StoreFile(ctx, file)
 
 	w := httptest.NewRecorder()
 
@@ -414,7 +702,7 @@ func TestFilesErr__balanceFileEndpointJSON(t *testing.T) {
 	router.ServeHTTP(w, req)
 	w.Flush()
 
-	if w.Code != http.StatusInternalServerError {
+	if w.Code != http.StatusBadRequest {
 		t.Errorf("bogus HTTP status: %d", w.Code)
 	}
 
@@ -427,7 +715,7 @@ func TestFilesErr__balanceFileEndpointJSON(t *testing.T) {
 	router.ServeHTTP(w, req)
 	w.Flush()
 
-	if w.Code != http.StatusInternalServerError {
+	if w.Code != http.StatusBadRequest {
 		t.Errorf("bogus HTTP status: %d", w.Code)
 	}
 }
@@ -449,6 +737,7 @@ func TestFilesError__segmentFileEndpoint(t *testing.T) {
 
 // TestFiles__segmentFileEndpoint tests segmentFileEndpoints
 func TestFiles__segmentFileEndpoint(t *testing.T) {
+	ctx := context.Background()
 	logger := log.NewNopLogger()
 	repo := NewRepositoryInMemory(testTTLDuration, logger)
 	svc := NewService(repo)
@@ -462,7 +751,7 @@ func TestFiles__segmentFileEndpoint(t *testing.T) {
 	defer fd.Close()
 	bs, _ := ioutil.ReadAll(fd)
 	file, _ := ach.FileFromJSON(bs)
-	repo.StoreFile(file)
+	repo.StoreFile(ctx, file)
 
 	body := strings.NewReader(`{}`)
 
@@ -506,6 +795,7 @@ func TestFiles__decodeSegmentFileRequest(t *testing.T) {
 
 // TestFiles__flattenFileEndpoint tests flattenFileEndpoints
 func TestFiles__flattenFileEndpoint(t *testing.T) {
+	ctx := context.Background()
 	logger := log.NewNopLogger()
 	repo := NewRepositoryInMemory(testTTLDuration, logger)
 	svc := NewService(repo)
@@ -519,7 +809,7 @@ func TestFiles__flattenFileEndpoint(t *testing.T) {
 	defer fd.Close()
 	bs, _ := ioutil.ReadAll(fd)
 	file, _ := ach.FileFromJSON(bs)
-	repo.StoreFile(file)
+	repo.StoreFile(ctx, file)
 
 	// test status code
 	w := httptest.NewRecorder()
@@ -564,40 +854,251 @@ func TestFiles__decodeFlattenFileRequest(t *testing.T) {
 	}
 }
 
-// TestFilesByID__getFileEndpoint tests getFileEndpoint by File ID
-func TestFilesByID__getFileEndpoint(t *testing.T) {
+// TestFiles__splitFileEndpoint tests splitFileEndpoint
+func TestFiles__splitFileEndpoint(t *testing.T) {
+	ctx := context.Background()
 	logger := log.NewNopLogger()
 	repo := NewRepositoryInMemory(testTTLDuration, logger)
-	svc := NewService(repo)
-	router := MakeHTTPHandler(svc, repo, logger)
-
-	// write an ACH file into repository
-	fd, err := os.Open(filepath.Join("..", "test", "testdata", "ppd-mixedDebitCredit-valid.json"))
-	if fd == nil {
-		t.Fatalf("empty ACH file: %v", err)
+	router := MakeHTTPHandler(NewService(repo), repo, logger)
+
+	file := ach.NewFile()
+	file.ID = "split"
+	file.SetHeader(*mockFileHeader())
+	file.AddBatch(riskBatch("b1", "111", 100))
+	later := riskBatch("b2", "222", 200)
+	later.GetHeader().EffectiveEntryDate = "261020"
+	file.AddBatch(later)
+	if err := repo.StoreFile(ctx, file); err != nil {
+		t.Fatal(err)
 	}
-	defer fd.Close()
-	bs, _ := ioutil.ReadAll(fd)
-	file, _ := ach.FileFromJSON(bs)
-	repo.StoreFile(file)
 
-	// test status code
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", fmt.Sprintf("/files/%s", file.ID), nil)
-	req.Header.Set("Origin", "https://moov.io")
-	req.Header.Set("X-Request-Id", "11112")
-
+	req := httptest.NewRequest("POST", fmt.Sprintf("/files/%s/split", file.ID), nil)
+	req.Header.Set("X-Request-Id", "11111")
 	router.ServeHTTP(w, req)
 	w.Flush()
-
 	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		FileIDs []string `json:"fileIDs"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.FileIDs) != 2 {
+		t.Fatalf("unexpected fileIDs: %v", resp.FileIDs)
+	}
+	for _, id := range resp.FileIDs {
+		f, err := repo.FindFile(ctx, id)
+		if err != nil || f == nil || len(f.Batches) != 1 {
+			t.Errorf("split file %s wasn't stored: %v", id, err)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/files/missing/split", nil))
+	w.Flush()
+	if w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestFiles__cloneFileEndpoint tests cloneFileEndpoint
+func TestFiles__cloneFileEndpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	router := MakeHTTPHandler(NewService(repo), repo, logger)
+
+	file := readPPDValidFile(t)
+	file.Batches[0].SetID("batch")
+	entry := file.Batches[0].GetEntries()[0]
+	entry.TraceNumber = entry.TraceNumber[:8] + "0000099"
+	if err := repo.StoreFile(ctx, file); err != nil {
+		t.Fatal(err)
+	}
+
+	clone := func(query string) (*ach.File, int) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", fmt.Sprintf("/files/%s/clone%s", file.ID, query), nil))
+		w.Flush()
+		var resp struct {
+			ID string `json:"id"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || resp.ID == "" {
+			return nil, w.Code
+		}
+		f, err := repo.FindFile(ctx, resp.ID)
+		if err != nil {
+			t.Fatalf("clone %s wasn't stored: %v", resp.ID, err)
+		}
+		return f, w.Code
+	}
+
+	effective := ach.NextBankingDay(time.Now().AddDate(0, 0, 7), true)
+	f, code := clone("?effectiveEntryDate=" + effective.Format("2006-01-02"))
+	if code != http.StatusOK || f == nil {
+		t.Fatalf("bogus HTTP status: %d", code)
+	}
+	if f.ID == file.ID || f.Header.ID != f.ID || f.Header.FileCreationDate != time.Now().Format("060102") || f.Header.FileIDModifier != "A" {
+		t.Errorf("unexpected header: %#v", f.Header)
+	}
+	batch := f.Batches[0]
+	if batch.ID() == "batch" || batch.GetHeader().EffectiveEntryDate != effective.Format("060102") {
+		t.Errorf("unexpected batch: %s %#v", batch.ID(), batch.GetHeader())
+	}
+	if trace := batch.GetEntries()[0].TraceNumber; trace != entry.TraceNumber[:8]+"0000001" {
+		t.Errorf("TraceNumber=%s", trace)
+	}
+	if err := f.Validate(); err != nil {
+		t.Error(err)
+	}
+	if entry.TraceNumber[8:] != "0000099" || file.Batches[0].ID() != "batch" {
+		t.Error("source file was changed")
+	}
+
+	// clones created the same day have the next FileIDModifier
+	if f, _ := clone(""); f == nil || f.Header.FileIDModifier != "B" {
+		t.Errorf("unexpected clone: %#v", f)
+	}
+
+	if _, code := clone("?effectiveEntryDate=2018-10-09"); code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d", code)
+	}
+	if _, code := clone("?effectiveEntryDate=181009"); code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d", code)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/files/missing/clone", nil))
+	w.Flush()
+	if w.Code != http.StatusNotFound {
 		t.Errorf("bogus HTTP status: %d", w.Code)
 	}
+}
+
+// TestFilesByID__getFileEndpoint tests getFileEndpoint by File ID
+func TestFiles__lintFileEndpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	router := MakeHTTPHandler(NewService(repo), repo, logger)
+
+	lint := func(body []byte, contentType string) lintFileResponse {
+		req := httptest.NewRequest("POST", "/files/validate", bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		w.Flush()
+		if w.Code != http.StatusOK {
+			t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+		}
+		var resp lintFileResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	bs, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", "ppd-debit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp := lint(bs, "text/plain"); !resp.Valid || len(resp.Errors) != 0 {
+		t.Errorf("unexpected response: %#v", resp)
+	} else if len(resp.Warnings) == 0 || resp.Warnings[0].FieldName != "EffectiveEntryDate" {
+		t.Errorf("unexpected warnings: %#v", resp.Warnings)
+	}
 
+	// the errors reading a file are listed with their line
+	lines := strings.Split(string(bs), "\n")
+	lines[2] = lines[2][:60]
+	resp := lint([]byte(strings.Join(lines, "\n")), "text/plain")
+	if resp.Valid || len(resp.Errors) == 0 || resp.Errors[0].Line != 3 {
+		t.Errorf("unexpected response: %#v", resp)
+	}
+
+	// and the errors validating it with their batch and field
+	f := readPPDValidFile(t)
+	f.Batches[0].GetEntries()[0].DFIAccountNumber = ""
+	bs, _ = json.Marshal(f)
+	resp = lint(bs, "application/json")
+	if resp.Valid || len(resp.Errors) != 1 || resp.Errors[0].BatchNumber != 1 || resp.Errors[0].FieldName != "DFIAccountNumber" {
+		t.Errorf("unexpected response: %#v", resp)
+	}
+
+	// nothing is stored
+	if files := repo.FindAllFiles(ctx); len(files) != 0 {
+		t.Errorf("stored %d files", len(files))
+	}
 }
 
-// TestFileContentsByID__getFileContentsEndpoint tests getFileContentsEndpoint by File ID
-func TestFileContentsByID__getFileContentsEndpoint(t *testing.T) {
+func TestFiles__validateFileEndpointWarnings(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	router := MakeHTTPHandler(NewService(repo), repo, logger)
+
+	validate := func(id string) (validateFileResponse, int) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/files/"+id+"/validate", nil))
+		w.Flush()
+		var resp validateFileResponse
+		json.NewDecoder(w.Body).Decode(&resp)
+		return resp, w.Code
+	}
+
+	f := readPPDValidFile(t)
+	f.Batches[0].GetHeader().CompanyEntryDescription = "Payroll"
+	f.Batches[0].GetHeader().SetEffectiveEntryDay(ach.Today())
+	if err := repo.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+	resp, code := validate(f.ID)
+	if code != http.StatusOK || len(resp.Warnings) != 1 || resp.Warnings[0].FieldName != "CompanyEntryDescription" {
+		t.Errorf("HTTP %d: unexpected warnings: %#v", code, resp.Warnings)
+	}
+
+	// warnings are listed with the errors of invalid files
+	f.Batches[0].GetEntries()[0].DFIAccountNumber = ""
+	resp, code = validate(f.ID)
+	if code != http.StatusBadRequest || len(resp.Warnings) != 1 {
+		t.Errorf("HTTP %d: unexpected warnings: %#v", code, resp.Warnings)
+	}
+
+	if _, code = validate("missing"); code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d", code)
+	}
+}
+
+func TestFiles__validateFileEndpointRulesVersion(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	router := MakeHTTPHandler(NewService(repo), repo, logger)
+
+	f := readPPDValidFile(t)
+	if err := repo.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+	validate := func(body string) int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/files/"+f.ID+"/validate", strings.NewReader(body)))
+		w.Flush()
+		return w.Code
+	}
+	for _, v := range []string{"2019", "2022", "effective"} {
+		if code := validate(fmt.Sprintf(`{"rulesVersion": %q}`, v)); code != http.StatusOK {
+			t.Errorf("%s: bogus HTTP status: %d", v, code)
+		}
+	}
+	if code := validate(`{"rulesVersion": "2018"}`); code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d", code)
+	}
+}
+
+func TestFilesByID__getFileEndpoint(t *testing.T) {
+	ctx := context.Background()
 	logger := log.NewNopLogger()
 	repo := NewRepositoryInMemory(testTTLDuration, logger)
 	svc := NewService(repo)
@@ -611,11 +1112,11 @@ func TestFileContentsByID__getFileContentsEndpoint(t *testing.T) {
 	defer fd.Close()
 	bs, _ := ioutil.ReadAll(fd)
 	file, _ := ach.FileFromJSON(bs)
-	repo.StoreFile(file)
+	repo.StoreFile(ctx, file)
 
 	// test status code
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", fmt.Sprintf("/files/%s/contents", file.ID), nil)
+	req := httptest.NewRequest("GET", fmt.Sprintf("/files/%s", file.ID), nil)
 	req.Header.Set("Origin", "https://moov.io")
 	req.Header.Set("X-Request-Id", "11112")
 
@@ -628,8 +1129,8 @@ func TestFileContentsByID__getFileContentsEndpoint(t *testing.T) {
 
 }
 
-// TestFilesByID__deleteFileEndpoint tests by File ID
-func TestFilesByID__deleteFileEndpoint(t *testing.T) {
+func TestFilesByID__getFileChecksums(t *testing.T) {
+	ctx := context.Background()
 	logger := log.NewNopLogger()
 	repo := NewRepositoryInMemory(testTTLDuration, logger)
 	svc := NewService(repo)
@@ -643,12 +1144,99 @@ func TestFilesByID__deleteFileEndpoint(t *testing.T) {
 	defer fd.Close()
 	bs, _ := ioutil.ReadAll(fd)
 	file, _ := ach.FileFromJSON(bs)
-	repo.StoreFile(file)
+	repo.StoreFile(ctx, file)
 
-	// test status code
+	var resp struct {
+		Checksums *ach.FileChecksums `json:"checksums"`
+	}
+
+	// checksums are only included when requested
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("DELETE", fmt.Sprintf("/files/%s", file.ID), nil)
-	req.Header.Set("Origin", "https://moov.io")
+	req := httptest.NewRequest("GET", fmt.Sprintf("/files/%s", file.ID), nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Checksums != nil {
+		t.Errorf("unexpected checksums: %#v", resp.Checksums)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", fmt.Sprintf("/files/%s?include=checksums", file.ID), nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d", w.Code)
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Checksums == nil || len(resp.Checksums.Batches) != len(file.Batches) {
+		t.Fatalf("unexpected checksums: %#v", resp.Checksums)
+	}
+	if resp.Checksums.File.EntryHash != file.Control.EntryHash || len(resp.Checksums.File.SHA256) != 64 {
+		t.Errorf("unexpected file checksum: %#v", resp.Checksums.File)
+	}
+}
+
+// TestFileContentsByID__getFileContentsEndpoint tests getFileContentsEndpoint by File ID
+func TestFileContentsByID__getFileContentsEndpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	// write an ACH file into repository
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "ppd-mixedDebitCredit-valid.json"))
+	if fd == nil {
+		t.Fatalf("empty ACH file: %v", err)
+	}
+	defer fd.Close()
+	bs, _ := ioutil.ReadAll(fd)
+	file, _ := ach.FileFromJSON(bs)
+	repo.StoreFile(ctx, file)
+
+	// test status code
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", fmt.Sprintf("/files/%s/contents", file.ID), nil)
+	req.Header.Set("Origin", "https://moov.io")
+	req.Header.Set("X-Request-Id", "11112")
+
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d", w.Code)
+	}
+
+}
+
+// TestFilesByID__deleteFileEndpoint tests by File ID
+func TestFilesByID__deleteFileEndpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	// write an ACH file into repository
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "ppd-mixedDebitCredit-valid.json"))
+	if fd == nil {
+		t.Fatalf("empty ACH file: %v", err)
+	}
+	defer fd.Close()
+	bs, _ := ioutil.ReadAll(fd)
+	file, _ := ach.FileFromJSON(bs)
+	repo.StoreFile(ctx, file)
+
+	// test status code
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", fmt.Sprintf("/files/%s", file.ID), nil)
+	req.Header.Set("Origin", "https://moov.io")
 	req.Header.Set("X-Request-Id", "11113")
 
 	router.ServeHTTP(w, req)
@@ -706,8 +1294,39 @@ func TestFiles__CreateFileEndpoint(t *testing.T) {
 	}
 }
 
+func TestFiles__CreateFileDiagnostics(t *testing.T) {
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	bs, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", "ppd-debit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(bs), "\n")
+	lines[5] = lines[5][:93] + "X" // data in block padding
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/files/create", strings.NewReader(strings.Join(lines, "\n")))
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var resp createFileResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Line != 6 {
+		t.Errorf("unexpected diagnostics: %#v", resp.Diagnostics)
+	}
+}
+
 // TestFiles_segmentFileEndpointError tests segmentFileEndpoints
 func TestFiles__segmentFileEndpointError(t *testing.T) {
+	ctx := context.Background()
 	logger := log.NewNopLogger()
 	repo := NewRepositoryInMemory(testTTLDuration, logger)
 	svc := NewService(repo)
@@ -722,7 +1341,7 @@ func TestFiles__segmentFileEndpointError(t *testing.T) {
 	bs, _ := ioutil.ReadAll(fd)
 	file, _ := ach.FileFromJSON(bs)
 	file.Header.ImmediateDestination = "" // invalid routing number
-	repo.StoreFile(file)
+	repo.StoreFile(ctx, file)
 
 	// test status code
 	w := httptest.NewRecorder()
@@ -740,6 +1359,7 @@ func TestFiles__segmentFileEndpointError(t *testing.T) {
 
 // TestFiles_flattenFileEndpointError tests flattenFileEndpoints
 func TestFiles__flattenFileEndpointError(t *testing.T) {
+	ctx := context.Background()
 	logger := log.NewNopLogger()
 	repo := NewRepositoryInMemory(testTTLDuration, logger)
 	svc := NewService(repo)
@@ -754,7 +1374,7 @@ func TestFiles__flattenFileEndpointError(t *testing.T) {
 	bs, _ := ioutil.ReadAll(fd)
 	file, _ := ach.FileFromJSON(bs)
 	file.Header.ImmediateDestination = "" // invalid routing number
-	repo.StoreFile(file)
+	repo.StoreFile(ctx, file)
 
 	// test status code
 	w := httptest.NewRecorder()
@@ -769,3 +1389,488 @@ func TestFiles__flattenFileEndpointError(t *testing.T) {
 		t.Errorf("bogus HTTP status: %d", w.Code)
 	}
 }
+
+func TestFiles__sameDayFileEndpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+
+	resp, err := sameDayFileEndpoint(svc, logger)(context.TODO(), nil)
+	r, ok := resp.(sameDayFileResponse)
+	if !ok {
+		t.Errorf("got %#v", resp)
+	}
+	if err == nil || r.Err == nil {
+		t.Errorf("expected error: err=%v resp.Err=%v", err, r.Err)
+	}
+
+	// write an ACH file into repository
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if fd == nil {
+		t.Fatalf("empty ACH file: %v", err)
+	}
+	defer fd.Close()
+	bs, _ := ioutil.ReadAll(fd)
+	file, _ := ach.FileFromJSON(bs)
+	entry := file.Batches[0].GetEntries()[0]
+	entry.Amount = ach.SameDayEntryLimit + 1
+	repo.StoreFile(ctx, file)
+
+	router := mux.NewRouter()
+	router.Methods("GET").Path("/files/{id}/same-day").Handler(
+		httptransport.NewServer(sameDayFileEndpoint(svc, logger), decodeSameDayFileRequest, encodeResponse),
+	)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", fmt.Sprintf("/files/%s/same-day", file.ID), nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var response sameDayFileResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Eligible {
+		t.Error("expected ineligible file")
+	}
+	var found bool
+	for i := range response.Errors {
+		if response.Errors[i].TraceNumber == entry.TraceNumber {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("missing entry %s: %#v", entry.TraceNumber, response.Errors)
+	}
+
+	// missing file
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/files/missing/same-day", nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d", w.Code)
+	}
+}
+
+func TestFiles__versionsAndRollback(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if fd == nil {
+		t.Fatalf("empty ACH file: %v", err)
+	}
+	defer fd.Close()
+	bs, _ := ioutil.ReadAll(fd)
+	file, _ := ach.FileFromJSON(bs)
+	repo.StoreFile(ctx, file)
+
+	// save the original and then remove its batch
+	if err := repo.SaveVersion(ctx, file.ID); err != nil {
+		t.Fatal(err)
+	}
+	file.Batches = nil
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", fmt.Sprintf("/files/%s/versions", file.ID), nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var versions getFileVersionsResponse
+	if err := json.NewDecoder(w.Body).Decode(&versions); err != nil {
+		t.Fatal(err)
+	}
+	if len(versions.Versions) != 1 || versions.Versions[0].Version != 1 || w.Header().Get("X-Total-Count") != "1" {
+		t.Fatalf("unexpected versions: %#v", versions.Versions)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", fmt.Sprintf("/files/%s/rollback/1", file.ID), nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if found, _ := svc.GetFile(ctx, file.ID); found == nil || len(found.Batches) != 1 {
+		t.Errorf("expected batch to be restored: %#v", found)
+	}
+
+	// unknown version
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", fmt.Sprintf("/files/%s/rollback/99", file.ID), nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d", w.Code)
+	}
+
+	// missing file
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/files/missing/versions", nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d", w.Code)
+	}
+}
+
+func TestFiles__patchFileEndpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if fd == nil {
+		t.Fatalf("empty ACH file: %v", err)
+	}
+	defer fd.Close()
+	bs, _ := ioutil.ReadAll(fd)
+	file, _ := ach.FileFromJSON(bs)
+	repo.StoreFile(ctx, file)
+	origin := file.Header.ImmediateOrigin
+
+	w := httptest.NewRecorder()
+	body := strings.NewReader(`{"fileIDModifier": "B", "immediateDestinationName": "Other Bank", "fileCreationDate": "2019-06-25T10:30:00Z"}`)
+	req := httptest.NewRequest("PATCH", fmt.Sprintf("/files/%s", file.ID), body)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	found, _ := svc.GetFile(ctx, file.ID)
+	if found.Header.FileIDModifier != "B" || found.Header.ImmediateDestinationName != "Other Bank" || found.Header.FileCreationDate != "190625" {
+		t.Errorf("unexpected FileHeader: %#v", found.Header)
+	}
+	if found.Header.ImmediateOrigin != origin {
+		t.Errorf("ImmediateOrigin=%s", found.Header.ImmediateOrigin)
+	}
+	if versions, _ := repo.FindVersions(ctx, file.ID); len(versions) != 1 {
+		t.Errorf("expected a version to be saved: %d", len(versions))
+	}
+
+	// invalid routing number
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("PATCH", fmt.Sprintf("/files/%s", file.ID), strings.NewReader(`{"immediateDestination": "000000000"}`))
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if found.Header.ImmediateDestination == "000000000" {
+		t.Error("invalid ImmediateDestination was saved")
+	}
+
+	// missing file
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("PATCH", "/files/missing", strings.NewReader(`{"fileIDModifier": "C"}`))
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d", w.Code)
+	}
+}
+
+func TestFiles__ifMatch(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	bs, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, _ := ach.FileFromJSON(bs)
+	file.Batches[0].SetID("batch-01")
+	if err := repo.StoreFile(ctx, file); err != nil {
+		t.Fatal(err)
+	}
+
+	send := func(method, path, ifMatch, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		w.Flush()
+		return w
+	}
+	path := "/files/" + file.ID
+
+	w := send("GET", path, "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	etag := w.Header().Get("ETag")
+	if etag != `"1"` {
+		t.Fatalf("ETag=%s", etag)
+	}
+
+	// each representation has its own ETag, all of them are accepted as If-Match
+	representations := map[string]string{
+		path + "?masked=true":           `"1-masked"`,
+		path + "?format=v2":             `"1-v2"`,
+		path + "?format=v2&masked=true": `"1-v2-masked"`,
+		path + "/contents":              `"1-contents"`,
+		path + "/contents?masked=true":  `"1-contents-masked"`,
+	}
+	for p, expected := range representations {
+		w = send("GET", p, "", "")
+		if v := w.Header().Get("ETag"); w.Code != http.StatusOK || v != expected {
+			t.Errorf("%s: HTTP status %d with ETag=%s", p, w.Code, v)
+		}
+	}
+	for _, tag := range []string{`"1-contents-masked"`, `W/"2-v2"`} {
+		if w = send("PATCH", path, tag, `{"fileIDModifier": "A"}`); w.Code != http.StatusOK {
+			t.Fatalf("If-Match %s: bogus HTTP status: %d: %s", tag, w.Code, w.Body.String())
+		}
+		w = send("GET", path, "", "")
+		etag = w.Header().Get("ETag")
+	}
+	if etag != `"3"` {
+		t.Fatalf("ETag=%s", etag)
+	}
+
+	// the first operator's change is saved
+	if w = send("PATCH", path, etag, `{"fileIDModifier": "B"}`); w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	// while a second operator's changes based on the same revision are rejected
+	if w = send("PATCH", path, etag, `{"fileIDModifier": "C"}`); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = send("POST", path+"/batches", etag, `{"batchHeader": {}}`); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = send("DELETE", path+"/batches/"+file.Batches[0].ID(), etag, ""); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = send("DELETE", path, etag, ""); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if found, _ := svc.GetFile(ctx, file.ID); found.Header.FileIDModifier != "B" || len(found.Batches) != 1 {
+		t.Errorf("FileIDModifier=%s with %d batches", found.Header.FileIDModifier, len(found.Batches))
+	}
+
+	if w = send("DELETE", path, "yesterday", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	w = send("GET", path, "", "")
+	if etag = w.Header().Get("ETag"); etag != `"4"` {
+		t.Fatalf("ETag=%s", etag)
+	}
+	if w = send("DELETE", path, etag, ""); w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = send("DELETE", path, etag, ""); w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestFiles__buildFileTraceNumbers(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo)
+
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	bs, _ := ioutil.ReadAll(fd)
+	file, err := ach.FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// add a copy of the batch and clear every TraceNumber so BuildFile assigns them
+	bh := *file.Batches[0].GetHeader()
+	batch := ach.NewBatchPPD(&bh)
+	for _, entry := range file.Batches[0].GetEntries() {
+		ed := *entry
+		batch.AddEntry(&ed)
+	}
+	file.AddBatch(batch)
+	for _, b := range file.Batches {
+		for _, entry := range b.GetEntries() {
+			entry.TraceNumber = ""
+		}
+	}
+	repo.StoreFile(ctx, file)
+
+	built, err := svc.BuildFile(ctx, file.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := built.ValidateWith(&ach.ValidateOpts{RequireUniqueTraceNumbers: true}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFiles__buildFileEndpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if fd == nil {
+		t.Fatalf("empty ACH file: %v", err)
+	}
+	defer fd.Close()
+	bs, _ := ioutil.ReadAll(fd)
+	file, _ := ach.FileFromJSON(bs)
+	repo.StoreFile(ctx, file)
+
+	// change an amount so the controls are out of date
+	file.Batches[0].GetEntries()[0].Amount = 12345
+	if err := file.Validate(); err == nil {
+		t.Fatal("expected invalid file")
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", fmt.Sprintf("/files/%s/build", file.ID), nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	built, err := repo.FindFile(ctx, file.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if built.Control.TotalCreditEntryDollarAmountInFile != 12345 {
+		t.Errorf("TotalCreditEntryDollarAmountInFile=%d", built.Control.TotalCreditEntryDollarAmountInFile)
+	}
+
+	// invalid file
+	repo.UpdateFile(ctx, file.ID, func(f *ach.File) error {
+		f.Header.ImmediateDestination = ""
+		return nil
+	})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "ImmediateDestination") {
+		t.Errorf("unexpected error: %s", w.Body.String())
+	}
+
+	// missing file
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/files/missing/build", nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d", w.Code)
+	}
+}
+
+func TestFiles__Masked(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo)
+	handler := MakeHTTPHandler(svc, repo, log.NewNopLogger())
+
+	file := readPPDValidFile(t)
+	file.Batches[0].SetID("batch-01")
+	if err := repo.StoreFile(ctx, file); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.ReplaceFile(ctx, file); err != nil { // keep a version
+		t.Fatal(err)
+	}
+	entry := file.Batches[0].GetEntries()[0]
+	account, name := strings.TrimSpace(entry.DFIAccountNumber), strings.TrimSpace(entry.IndividualName)
+	entryPath := fmt.Sprintf("/files/%s/batches/%s/entries/%d", file.ID, file.Batches[0].ID(), entrySequenceNumber(entry))
+
+	paths := []string{
+		"/files?masked=true",
+		"/files/" + file.ID + "?masked=true",
+		"/files/" + file.ID + "/contents?masked=true",
+		"/files/" + file.ID + "/versions?masked=true",
+		"/files/" + file.ID + "/batches?masked=true",
+		"/files/" + file.ID + "/batches/" + file.Batches[0].ID() + "?masked=true",
+		"/files/" + file.ID + "/batches/" + file.Batches[0].ID() + "/entries?masked=true",
+		entryPath + "?masked=true",
+		"/files/search?individualName=" + url.QueryEscape(name) + "&masked=true",
+	}
+	for _, path := range paths {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		w.Flush()
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: bogus HTTP status code: %d: %s", path, w.Code, w.Body.String())
+		}
+		body := w.Body.String()
+		if strings.Contains(body, account) || strings.Contains(body, name) {
+			t.Errorf("%s: unmasked response: %s", path, body)
+		}
+		if !strings.Contains(body, account[len(account)-4:]) {
+			t.Errorf("%s: expected last four digits of account: %s", path, body)
+		}
+	}
+
+	// corrected account numbers of an entry's addenda
+	entry.Addenda98 = ach.NewAddenda98()
+	entry.Addenda98.ChangeCode = "C01"
+	entry.Addenda98.CorrectedData = "1918171614"
+	for query, expected := range map[string]string{"": "1918171614", "?masked=true": `"******1614"`} {
+		req := httptest.NewRequest("GET", entryPath+"/addenda"+query, nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		w.Flush()
+
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), expected) {
+			t.Errorf("addenda%s: HTTP status code %d: %s", query, w.Code, w.Body.String())
+		}
+	}
+
+	// stored files are left unmasked
+	req := httptest.NewRequest("GET", "/files/"+file.ID, nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	w.Flush()
+	if !strings.Contains(w.Body.String(), account) {
+		t.Errorf("expected unmasked file: %s", w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/files/"+file.ID+"?masked=maybe", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	w.Flush()
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status code: %d", w.Code)
+	}
+}
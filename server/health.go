@@ -0,0 +1,124 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+var errDraining = errors.New("shutting down")
+
+// Health tracks whether the server should receive requests. GET /live responds 200 OK while the
+// process is serving, and GET /ready responds 200 OK until Drain is called or a readiness check
+// fails, then 503 Service Unavailable so load balancers (e.g. Kubernetes Services) stop routing
+// new requests to it while in-flight requests finish.
+type Health struct {
+	mu       sync.RWMutex
+	draining bool
+	checks   map[string]func() error
+}
+
+// NewHealth returns a Health which is ready until Drain is called
+func NewHealth() *Health {
+	return &Health{checks: make(map[string]func() error)}
+}
+
+// AddReadinessCheck adds a check GET /ready runs on each request. The server isn't ready
+// while check returns an error.
+func (h *Health) AddReadinessCheck(name string, check func() error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = check
+}
+
+// Drain marks the server as not ready, which is done before shutting down
+func (h *Health) Drain() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.draining = true
+}
+
+// Ready returns nil when the server is ready for requests, otherwise why it isn't
+func (h *Health) Ready() error {
+	for _, err := range h.failures() {
+		return err
+	}
+	return nil
+}
+
+// failures returns the error of each failing readiness check by name, including "shutdown"
+// once the server is draining.
+func (h *Health) failures() map[string]error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	errs := make(map[string]error)
+	if h.draining {
+		errs["shutdown"] = errDraining
+	}
+	for name, check := range h.checks {
+		if err := check(); err != nil {
+			errs[name] = err
+		}
+	}
+	return errs
+}
+
+// WithHealth serves GET /ready from h. Without it the server is always ready.
+func WithHealth(h *Health) HandlerOption {
+	return func(cfg *handlerConfig) {
+		cfg.health = h
+	}
+}
+
+// isProbe returns true for the liveness and readiness requests which are always allowed
+func isProbe(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/ping", "/live", "/ready":
+		return true
+	}
+	return false
+}
+
+func liveHandler(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// readyHandler responds with the failing readiness checks of cfg.health by name
+func readyHandler(cfg *handlerConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if cfg.health == nil {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		errs := cfg.health.failures()
+		if len(errs) == 0 {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		failures := make(map[string]string, len(errs))
+		for name, err := range errs {
+			failures[name] = err.Error()
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(failures)
+	}
+}
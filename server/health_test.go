@@ -0,0 +1,93 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestHealth(t *testing.T) {
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	health := NewHealth()
+	handler := MakeHTTPHandler(NewService(repo), repo, log.NewNopLogger(), WithHealth(health))
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		w.Flush()
+		return w
+	}
+	if w := get("/live"); w.Code != http.StatusOK {
+		t.Errorf("live: bogus HTTP status: %d", w.Code)
+	}
+	if w := get("/ready"); w.Code != http.StatusOK {
+		t.Errorf("ready: bogus HTTP status: %d", w.Code)
+	}
+	if err := health.Ready(); err != nil {
+		t.Fatal(err)
+	}
+
+	// failing checks aren't ready
+	var checkErr error
+	health.AddReadinessCheck("storage", func() error { return checkErr })
+	checkErr = errors.New("unavailable")
+	w := get("/ready")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("bogus HTTP status: %d", w.Code)
+	}
+	var failures map[string]string
+	if err := json.NewDecoder(w.Body).Decode(&failures); err != nil {
+		t.Fatal(err)
+	}
+	if failures["storage"] != "unavailable" {
+		t.Errorf("unexpected failures: %v", failures)
+	}
+	checkErr = nil
+
+	// draining servers are live but not ready
+	health.Drain()
+	if err := health.Ready(); err != errDraining {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if w := get("/ready"); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("ready: bogus HTTP status: %d", w.Code)
+	}
+	if w := get("/live"); w.Code != http.StatusOK {
+		t.Errorf("live: bogus HTTP status: %d", w.Code)
+	}
+}
+
+func TestHealth__withoutHealth(t *testing.T) {
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	handler := MakeHTTPHandler(NewService(repo), repo, log.NewNopLogger(), WithRateLimit(1, 1))
+
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/ready", nil))
+		w.Flush()
+		if w.Code != http.StatusOK {
+			t.Errorf("bogus HTTP status: %d", w.Code)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultMaxBodySize is the largest request body accepted without WithMaxBodySize
+const defaultMaxBodySize int64 = 100 * 1024 * 1024
+
+// HandlerOption configures the http.Handler returned by MakeHTTPHandler
+type HandlerOption func(*handlerConfig)
+
+type handlerConfig struct {
+	auth        *authenticator
+	limiter     *rateLimiter
+	maxBodySize int64
+	tracer      Tracer
+	health      *Health
+}
+
+// WithMaxBodySize limits request bodies, including files uploaded to POST /files/create, to n bytes.
+// Larger requests are rejected with 413 Request Entity Too Large. The default is 100MiB.
+func WithMaxBodySize(n int64) HandlerOption {
+	return func(h *handlerConfig) {
+		if n > 0 {
+			h.maxBodySize = n
+		}
+	}
+}
+
+// WithRateLimit limits each client, by IP address, to perSecond requests per second with bursts of up
+// to burst requests. Excess requests are rejected with 429 Too Many Requests. GET /ping, /live and /ready aren't limited.
+func WithRateLimit(perSecond float64, burst int) HandlerOption {
+	return func(h *handlerConfig) {
+		if perSecond <= 0 {
+			return
+		}
+		if burst < 1 {
+			burst = int(math.Ceil(perSecond))
+		}
+		h.limiter = &rateLimiter{
+			rate:    perSecond,
+			burst:   float64(burst),
+			buckets: make(map[string]*tokenBucket),
+			now:     time.Now,
+		}
+	}
+}
+
+// limitBodySize rejects requests whose body is larger than max bytes
+func limitBodySize(max int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > max {
+				encodeError(r.Context(), tooLarge(fmt.Errorf("request body is larger than %d bytes", max)), w)
+				return
+			}
+			if r.Body != nil {
+				r.Body = &limitedBody{ReadCloser: r.Body, remaining: max, max: max}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// limitedBody returns a tooLarge error once more than max bytes are read, so bodies sent
+// without a Content-Length are never read into memory past the limit.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	max       int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, tooLarge(fmt.Errorf("request body is larger than %d bytes", b.max))
+	}
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n, tooLarge(fmt.Errorf("request body is larger than %d bytes", b.max))
+	}
+	return n, err
+}
+
+// bodyTooLarge returns the tooLarge error for body if it was read past its limit. Decoders reading
+// the body as a stream don't always wrap the error they received, so this recovers it.
+func bodyTooLarge(body io.Reader) error {
+	if b, ok := body.(*limitedBody); ok && b.remaining < 0 {
+		return tooLarge(fmt.Errorf("request body is larger than %d bytes", b.max))
+	}
+	return nil
+}
+
+// rateLimiter keeps a token bucket for each client IP address
+type rateLimiter struct {
+	rate  float64 // tokens added per second
+	burst float64 // most tokens a bucket holds
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+
+	now func() time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// idleBucketTimeout is how long a client's bucket is kept after its last request
+const idleBucketTimeout = 10 * time.Minute
+
+// allow takes a token from the client's bucket. When none are left it returns how long until one is.
+func (l *rateLimiter) allow(client string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) > idleBucketTimeout {
+		for k, b := range l.buckets {
+			if now.Sub(b.last) > idleBucketTimeout {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+func (l *rateLimiter) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isProbe(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		client, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			client = r.RemoteAddr
+		}
+		if ok, wait := l.allow(client); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			encodeError(r.Context(), tooManyRequests(fmt.Errorf("rate limit of %v requests per second exceeded", l.rate)), w)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
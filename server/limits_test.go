@@ -0,0 +1,128 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestLimits__maxBodySize(t *testing.T) {
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	router := MakeHTTPHandler(NewService(repo), repo, log.NewNopLogger(), WithMaxBodySize(100))
+
+	// bodies without a Content-Length are cut off while reading
+	for _, path := range []string{"/files/create", "/files/foo/batches"} {
+		req := httptest.NewRequest("POST", path, ioutil.NopCloser(strings.NewReader(`{"a":"`+strings.Repeat("a", 200)+`"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		req.ContentLength = -1
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		w.Flush()
+
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("%s: bogus HTTP status: %d: %s", path, w.Code, w.Body.String())
+		}
+	}
+
+	// small bodies are read
+	bs, err := ioutil.ReadFile("../test/testdata/ppd-debit.ach")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NACHA files are cut off while they're being parsed
+	req := httptest.NewRequest("POST", "/files/create", ioutil.NopCloser(bytes.NewReader(bs)))
+	req.ContentLength = -1
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	w.Flush()
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	router = MakeHTTPHandler(NewService(repo), repo, log.NewNopLogger(), WithMaxBodySize(int64(len(bs))))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/files/create", bytes.NewReader(bs)))
+	w.Flush()
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestLimits__rateLimit(t *testing.T) {
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	router := MakeHTTPHandler(NewService(repo), repo, log.NewNopLogger(), WithRateLimit(1, 2))
+
+	get := func(path, addr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.RemoteAddr = addr
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		w.Flush()
+		return w
+	}
+	for i := 0; i < 2; i++ {
+		if w := get("/files", "10.0.0.1:1234"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: bogus HTTP status: %d", i, w.Code)
+		}
+	}
+	w := get("/files", "10.0.0.1:5678")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "1" {
+		t.Errorf("bogus HTTP status: %d Retry-After=%q", w.Code, w.Header().Get("Retry-After"))
+	}
+	if w := get("/files", "10.0.0.2:1234"); w.Code != http.StatusOK {
+		t.Errorf("other client: bogus HTTP status: %d", w.Code)
+	}
+	if w := get("/ping", "10.0.0.1:1234"); w.Code != http.StatusOK {
+		t.Errorf("ping: bogus HTTP status: %d", w.Code)
+	}
+}
+
+func TestRateLimiter__refill(t *testing.T) {
+	cfg := &handlerConfig{}
+	WithRateLimit(2, 1)(cfg)
+	l := cfg.limiter
+
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	if ok, _ := l.allow("a"); !ok {
+		t.Fatal("expected first request allowed")
+	}
+	if ok, wait := l.allow("a"); ok || wait != 500*time.Millisecond {
+		t.Fatalf("ok=%v wait=%v", ok, wait)
+	}
+	now = now.Add(500 * time.Millisecond)
+	if ok, _ := l.allow("a"); !ok {
+		t.Error("expected a refilled token")
+	}
+
+	// idle clients are forgotten
+	now = now.Add(2 * idleBucketTimeout)
+	l.allow("b")
+	if _, ok := l.buckets["a"]; ok {
+		t.Error("expected idle bucket to be removed")
+	}
+}
@@ -0,0 +1,90 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"net/http"
+	"time"
+
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/gorilla/mux"
+)
+
+// logEvent writes one leveled log line with the same keys on every line: "component", "msg"
+// and then keyvals, which should name IDs "requestID", "fileID" and "batchID". Lines with a
+// non-nil err are logged at error level with an "error" key and all others at info.
+func logEvent(logger log.Logger, component, msg string, err error, keyvals ...interface{}) {
+	if logger == nil {
+		return
+	}
+	kvs := append([]interface{}{"component", component, "msg", msg}, keyvals...)
+	if err != nil {
+		level.Error(logger).Log(append(kvs, "error", err)...)
+		return
+	}
+	level.Info(logger).Log(kvs...)
+}
+
+// logRequests writes a line for each HTTP request once it's been served with the request's
+// method, route, status, latency, request ID, tenant (the X-User-ID header) and file ID.
+// Server errors are logged at error level, client errors at warn and everything else at info,
+// except for GET /ping, /live and /ready which are logged at debug.
+func logRequests(logger log.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: w, code: http.StatusOK}
+			next.ServeHTTP(sw, r)
+
+			route := r.URL.Path
+			if current := mux.CurrentRoute(r); current != nil {
+				if tpl, err := current.GetPathTemplate(); err == nil {
+					route = tpl
+				}
+			}
+			fileID := mux.Vars(r)["id"]
+			if fileID == "" {
+				fileID = mux.Vars(r)["fileID"]
+			}
+
+			lvl := level.Info
+			switch {
+			case isProbe(r):
+				lvl = level.Debug // probes are frequent and expected to fail while draining
+			case sw.code >= 500:
+				lvl = level.Error
+			case sw.code >= 400:
+				lvl = level.Warn
+			}
+			lvl(logger).Log(
+				"component", "http",
+				"msg", "request",
+				"method", r.Method,
+				"route", route,
+				"status", sw.code,
+				"latencyMs", float64(time.Since(start))/float64(time.Millisecond),
+				"requestID", moovhttp.GetRequestID(r),
+				"tenant", moovhttp.GetUserID(r),
+				"fileID", fileID,
+			)
+		})
+	}
+}
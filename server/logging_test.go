@@ -0,0 +1,103 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+// logLines decodes each JSON log line written to buf
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("unparsable log line %q: %v", line, err)
+		}
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestLogging__logEvent(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewJSONLogger(&buf)
+
+	logEvent(logger, "files", "getFile", nil, "requestID", "req", "fileID", "foo")
+	logEvent(logger, "files", "getFile", errors.New("bad"), "requestID", "req", "fileID", "foo")
+	logEvent(nil, "files", "getFile", nil) // no panic
+
+	lines := logLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines: %s", len(lines), buf.String())
+	}
+	if l := lines[0]; l["level"] != "info" || l["component"] != "files" || l["msg"] != "getFile" || l["fileID"] != "foo" || l["error"] != nil {
+		t.Errorf("unexpected line: %v", l)
+	}
+	if l := lines[1]; l["level"] != "error" || l["requestID"] != "req" || l["error"] != "bad" {
+		t.Errorf("unexpected line: %v", l)
+	}
+}
+
+func TestLogging__requests(t *testing.T) {
+	var buf bytes.Buffer
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	handler := MakeHTTPHandler(NewService(repo), repo, log.NewJSONLogger(&buf))
+
+	req := httptest.NewRequest("GET", "/files/missing", nil)
+	req.Header.Set("X-Request-ID", "req")
+	req.Header.Set("X-User-ID", "tenant")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	w.Flush()
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("bogus HTTP status: %d", w.Code)
+	}
+
+	var found bool
+	for _, l := range logLines(t, &buf) {
+		if l["component"] != "http" {
+			continue
+		}
+		found = true
+		if l["level"] != "warn" || l["method"] != "GET" || l["route"] != "/files/{id}" || l["status"] != float64(http.StatusNotFound) {
+			t.Errorf("unexpected line: %v", l)
+		}
+		if l["requestID"] != "req" || l["tenant"] != "tenant" || l["fileID"] != "missing" {
+			t.Errorf("unexpected IDs: %v", l)
+		}
+		if _, ok := l["latencyMs"].(float64); !ok {
+			t.Errorf("missing latency: %v", l)
+		}
+	}
+	if !found {
+		t.Errorf("no request logged: %s", buf.String())
+	}
+}
@@ -0,0 +1,111 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/moov-io/base"
+
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	validationFailures = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Name: "ach_validation_failures",
+		Help: "The number of ACH files which failed validation by the type of error",
+	}, []string{"type"})
+
+	parseErrors = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Name: "ach_parse_errors",
+		Help: "The number of errors reading uploaded ACH files by the record being read",
+	}, []string{"record"})
+
+	fileSizes = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Name:    "ach_file_size_bytes",
+		Help:    "The size of uploaded ACH files",
+		Buckets: stdprometheus.ExponentialBuckets(1024, 4, 10), // 1KiB to 256MiB
+	}, []string{"format"})
+
+	buildDurations = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Name: "ach_file_build_duration_seconds",
+		Help: "How long building (tabulating and validating) a stored ACH file takes",
+	}, nil)
+)
+
+// countValidationFailure records a failed validation of a file
+func countValidationFailure(err error) {
+	if err != nil {
+		validationFailures.With("type", errorType(err)).Add(1)
+	}
+}
+
+// countParseErrors records each error from reading a NACHA file, which ach.Reader returns as a base.ErrorList
+func countParseErrors(err error) {
+	list, ok := err.(base.ErrorList)
+	if !ok {
+		list = base.ErrorList{err}
+	}
+	for _, err := range list {
+		record := "unknown"
+		var parseErr *base.ParseError
+		if errors.As(err, &parseErr) && parseErr.Record != "" {
+			record = parseErr.Record
+		}
+		parseErrors.With("record", record).Add(1)
+	}
+}
+
+// errorType names the cause of an ach error for metric labels. It's the type of the innermost
+// error declared by the ach package (e.g. ErrBatchAmount, or FieldError when a field wraps a
+// plain error) so labels stay few and don't include values from files.
+func errorType(err error) string {
+	name := "other"
+	for err != nil {
+		t := reflect.TypeOf(err)
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.PkgPath() == "github.com/moov-io/ach" {
+			name = t.Name()
+		}
+		err = errors.Unwrap(err)
+	}
+	return name
+}
+
+// observeBuild records how long a file build which started at start took
+func observeBuild(start time.Time) {
+	buildDurations.Observe(time.Since(start).Seconds())
+}
+
+// countingReader counts the bytes read from an io.Reader
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
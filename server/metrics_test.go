@@ -0,0 +1,68 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/ach"
+
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+func TestMetrics__errorType(t *testing.T) {
+	cases := map[string]error{
+		"ErrBatchAmount":                   &ach.BatchError{FieldName: "Amount", Err: ach.NewErrBatchAmount(100, 50)},
+		"FieldError":                       &ach.FieldError{FieldName: "CompanyName", Err: errors.New("invalid")},
+		"ErrFileCalculatedControlEquality": ach.NewErrFileCalculatedControlEquality("BatchCount", 1, 2),
+		"other":                            errors.New("other"),
+	}
+	for expected, err := range cases {
+		if name := errorType(err); name != expected {
+			t.Errorf("got %s expected %s", name, expected)
+		}
+	}
+}
+
+func TestMetrics__countParseErrors(t *testing.T) {
+	_, err := ach.NewReader(strings.NewReader(strings.Repeat("1", 94))).Read()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	countParseErrors(err)
+
+	families, err := stdprometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() != "ach_parse_errors" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "record" && label.GetValue() == "FileHeader" {
+					return
+				}
+			}
+		}
+	}
+	t.Error("no ach_parse_errors for FileHeader")
+}
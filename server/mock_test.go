@@ -18,15 +18,17 @@
 package server
 
 import (
+	"context"
 	"time"
 
 	"github.com/moov-io/ach"
 )
 
 func mockServiceInMemory() Service {
+	ctx := context.Background()
 	repository := NewRepositoryInMemory(testTTLDuration, nil)
-	repository.StoreFile(&ach.File{ID: "98765"})
-	repository.StoreBatch("98765", mockBatchWEB())
+	repository.StoreFile(ctx, &ach.File{ID: "98765"})
+	repository.StoreBatch(ctx, "98765", mockBatchWEB())
 	return NewService(repository)
 }
 
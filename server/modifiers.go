@@ -0,0 +1,79 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/moov-io/ach"
+)
+
+// fileIDModifierKey identifies the files which must have distinct FileIDModifiers: those from
+// an origin to a destination created on the same date (YYMMDD)
+type fileIDModifierKey struct {
+	origin      string
+	destination string
+	date        string
+}
+
+func newFileIDModifierKey(origin, destination, date string) fileIDModifierKey {
+	return fileIDModifierKey{
+		origin:      strings.TrimSpace(origin),
+		destination: strings.TrimSpace(destination),
+		date:        strings.TrimSpace(date),
+	}
+}
+
+// fileHeaderModifierKey returns the fileIDModifierKey of files with fh
+func fileHeaderModifierKey(fh ach.FileHeader) fileIDModifierKey {
+	return newFileIDModifierKey(fh.ImmediateOrigin, fh.ImmediateDestination, fh.FileCreationDateField())
+}
+
+// nextFileIDModifier returns the FileIDModifier following last which isn't in used, the modifiers of
+// stored files with the same key. ach.ErrFileIDModifierExhausted is returned once 9 has been used.
+func nextFileIDModifier(key fileIDModifierKey, last string, used map[string]bool) (string, error) {
+	next, err := ach.NextFileIDModifier(last)
+	for err == nil && used[next] {
+		next, err = ach.NextFileIDModifier(next)
+	}
+	if err != nil {
+		return "", conflict(fmt.Errorf("no FileIDModifier left for files from %s to %s on %s: %v", key.origin, key.destination, key.date, err))
+	}
+	return next, nil
+}
+
+// AssignFileIDModifier sets the FileIDModifier of f to the next one reserved by the Repository for files
+// from its origin to its destination on its FileCreationDate, so files created the same day aren't
+// rejected as duplicates by the ODFI. A file without a FileCreationDate is given today's date.
+func (s *service) AssignFileIDModifier(ctx context.Context, f *ach.File) (err error) {
+	ctx, span := startSpan(ctx, "Service.AssignFileIDModifier")
+	span.SetAttribute("file.id", f.ID)
+	defer endSpan(span, &err)
+
+	if f.Header.FileCreationDate == "" {
+		f.Header.FileCreationDate = f.Header.FileCreationDateField()
+	}
+	modifier, err := s.store.NextFileIDModifier(ctx, f.Header.ImmediateOrigin, f.Header.ImmediateDestination, f.Header.FileCreationDateField())
+	if err != nil {
+		return err
+	}
+	f.Header.FileIDModifier = modifier
+	return nil
+}
@@ -0,0 +1,167 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestRepository__NextFileIDModifier(t *testing.T) {
+	ctx := context.Background()
+	encrypted, err := NewRepositoryEncrypted(testEncryptionKey, testTTLDuration, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repos := map[string]Repository{
+		"memory":    NewRepositoryInMemory(testTTLDuration, nil),
+		"encrypted": encrypted,
+	}
+	for name, repo := range repos {
+		// a stored file already uses B
+		f := ach.NewFile()
+		f.ID = "stored"
+		f.SetHeader(*mockFileHeader())
+		f.Header.FileIDModifier = "B"
+		if err := repo.StoreFile(ctx, f); err != nil {
+			t.Fatal(err)
+		}
+
+		var got []string
+		for i := 0; i < 3; i++ {
+			m, err := repo.NextFileIDModifier(ctx, " 121042882", " 231380104", f.Header.FileCreationDate)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			got = append(got, m)
+		}
+		if v := strings.Join(got, ""); v != "ACD" {
+			t.Errorf("%s: got %s", name, v)
+		}
+
+		// other destinations and dates have their own sequence
+		if m, _ := repo.NextFileIDModifier(ctx, "121042882", "987654320", f.Header.FileCreationDate); m != "A" {
+			t.Errorf("%s: other destination got %s", name, m)
+		}
+		if m, _ := repo.NextFileIDModifier(ctx, "121042882", "231380104", "991231"); m != "A" {
+			t.Errorf("%s: other date got %s", name, m)
+		}
+
+		// every modifier runs out
+		for i := 0; i <= 36; i++ {
+			if _, err = repo.NextFileIDModifier(ctx, "121042882", "111111118", "201016"); err != nil {
+				break
+			}
+		}
+		if err == nil || !strings.Contains(err.Error(), "no FileIDModifier left") {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := repo.NextFileIDModifier(canceled, "121042882", "231380104", "991231"); err != context.Canceled {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+}
+
+func TestCreateFile__FileIDModifier(t *testing.T) {
+	ctx := context.Background()
+	s := NewService(NewRepositoryInMemory(testTTLDuration, nil))
+
+	var modifiers []string
+	for i := 0; i < 2; i++ {
+		fh := mockFileHeader()
+		fh.ID = fmt.Sprintf("file-%d", i)
+		fh.FileIDModifier = ""
+		id, err := s.CreateFile(ctx, fh)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, _ := s.GetFile(ctx, id)
+		modifiers = append(modifiers, f.Header.FileIDModifier)
+	}
+	if modifiers[0] != "A" || modifiers[1] != "B" {
+		t.Errorf("unexpected modifiers: %v", modifiers)
+	}
+
+	// an explicit modifier is kept
+	fh := mockFileHeader()
+	fh.ID = "explicit"
+	fh.FileIDModifier = "Z"
+	if _, err := s.CreateFile(ctx, fh); err != nil {
+		t.Fatal(err)
+	}
+	if f, _ := s.GetFile(ctx, "explicit"); f.Header.FileIDModifier != "Z" {
+		t.Errorf("FileIDModifier=%s", f.Header.FileIDModifier)
+	}
+}
+
+func TestFiles__createFileEndpointFileIDModifier(t *testing.T) {
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	router := MakeHTTPHandler(NewService(repo), repo, logger)
+
+	create := func(query string) (string, int) {
+		f := readPPDValidFile(t)
+		f.ID = ""
+		bs, err := json.Marshal(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest("POST", "/files/create"+query, strings.NewReader(string(bs)))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		w.Flush()
+
+		var resp struct {
+			ID string `json:"id"`
+		}
+		json.NewDecoder(w.Body).Decode(&resp)
+		if resp.ID == "" {
+			return "", w.Code
+		}
+		found, err := repo.FindFile(context.Background(), resp.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return found.Header.FileIDModifier, w.Code
+	}
+
+	if m, code := create(""); code != http.StatusOK || m != "A" {
+		t.Errorf("got %s (HTTP %d)", m, code)
+	}
+	for _, expected := range []string{"B", "C"} {
+		if m, code := create("?fileIDModifier=auto"); code != http.StatusOK || m != expected {
+			t.Errorf("got %s (HTTP %d), expected %s", m, code, expected)
+		}
+	}
+	if _, code := create("?fileIDModifier=other"); code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d", code)
+	}
+}
@@ -0,0 +1,99 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package oteltracing adapts OpenTelemetry to the server.Tracer interface, so requests to the
+// server and the Service methods serving them are exported as OpenTelemetry spans:
+//
+//	tracer := oteltracing.NewTracer(otel.Tracer("ach"), nil)
+//	handler := server.MakeHTTPHandler(svc, repo, logger, server.WithTracer(tracer))
+package oteltracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/moov-io/ach/server"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewTracer returns a server.Tracer which starts spans with tracer. The trace context of each
+// request is extracted from its headers with propagator, or the global propagator when it's nil.
+func NewTracer(tracer trace.Tracer, propagator propagation.TextMapPropagator) server.Tracer {
+	return &otelTracer{
+		tracer:     tracer,
+		propagator: propagator,
+	}
+}
+
+type otelTracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+func (t *otelTracer) Start(ctx context.Context, name string, header http.Header) (context.Context, server.Span) {
+	kind := trace.SpanKindInternal
+	if header != nil {
+		// the root span of a request continues the client's trace
+		propagator := t.propagator
+		if propagator == nil {
+			propagator = otel.GetTextMapPropagator()
+		}
+		ctx = propagator.Extract(ctx, propagation.HeaderCarrier(header))
+		kind = trace.SpanKindServer
+	}
+	ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(kind))
+	return ctx, &otelSpan{span: span}
+}
+
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s *otelSpan) SetAttribute(key string, value interface{}) {
+	s.span.SetAttributes(attributeOf(key, value))
+}
+
+func (s *otelSpan) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+// attributeOf converts value to the OpenTelemetry attribute of its type, other types are formatted as strings
+func attributeOf(key string, value interface{}) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	}
+	return attribute.String(key, fmt.Sprint(value))
+}
@@ -0,0 +1,183 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package oteltracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/moov-io/ach/server"
+
+	"github.com/go-kit/kit/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordedSpan keeps what's done to a span, other methods are those of a no-op span
+type recordedSpan struct {
+	trace.Span
+
+	name   string
+	kind   trace.SpanKind
+	parent trace.SpanContext
+	sc     trace.SpanContext
+	attrs  map[attribute.Key]attribute.Value
+	status codes.Code
+	errs   []error
+	ended  bool
+}
+
+func (s *recordedSpan) SpanContext() trace.SpanContext { return s.sc }
+
+func (s *recordedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for i := range kv {
+		s.attrs[kv[i].Key] = kv[i].Value
+	}
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+
+func (s *recordedSpan) RecordError(err error, _ ...trace.EventOption) { s.errs = append(s.errs, err) }
+
+func (s *recordedSpan) End(...trace.SpanEndOption) { s.ended = true }
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cfg := trace.NewSpanStartConfig(opts...)
+	parent := trace.SpanContextFromContext(ctx)
+	traceID := parent.TraceID()
+	if !traceID.IsValid() {
+		traceID = trace.TraceID{1}
+	}
+	span := &recordedSpan{
+		Span:   trace.SpanFromContext(context.Background()),
+		name:   name,
+		kind:   cfg.SpanKind(),
+		parent: parent,
+		sc:     trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{byte(len(t.spans) + 1)}}),
+		attrs:  make(map[attribute.Key]attribute.Value),
+	}
+	t.spans = append(t.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+func (t *recordingTracer) span(name string) *recordedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, s := range t.spans {
+		if s.name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func TestTracer(t *testing.T) {
+	repo := server.NewRepositoryInMemory(time.Hour, nil)
+	recorder := &recordingTracer{}
+	tracer := NewTracer(recorder, propagation.TraceContext{})
+	handler := server.MakeHTTPHandler(server.NewService(repo), repo, log.NewNopLogger(), server.WithTracer(tracer))
+
+	fd, err := os.Open(filepath.Join("..", "..", "test", "testdata", "ppd-debit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	req := httptest.NewRequest("POST", "/files/create", fd)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	w.Flush()
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	root := recorder.span("POST /files/create")
+	if root == nil || !root.ended || root.kind != trace.SpanKindServer {
+		t.Fatalf("unexpected root span: %#v", root)
+	}
+	if v := root.parent.TraceID().String(); v != "4bf92f3577b34da6a3ce929d0e0e4736" || !root.parent.IsRemote() {
+		t.Errorf("root span didn't continue the client's trace: %v", root.parent)
+	}
+	if v := root.attrs["http.status_code"]; v.AsInt64() != http.StatusOK {
+		t.Errorf("http.status_code=%v", v.Emit())
+	}
+	store := recorder.span("Repository.StoreFile")
+	if store == nil || store.kind != trace.SpanKindInternal || store.parent.SpanID() != root.sc.SpanID() {
+		t.Errorf("unexpected Repository.StoreFile span: %#v", store)
+	}
+
+	// Service methods are children of their request
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/files/missing/validate", nil))
+	w.Flush()
+	root = recorder.span("GET /files/{id}/validate")
+	validate := recorder.span("Service.ValidateFile")
+	if root == nil || validate == nil || validate.parent.SpanID() != root.sc.SpanID() {
+		t.Fatalf("unexpected spans: %#v %#v", root, validate)
+	}
+	if !validate.ended || validate.status != codes.Error || len(validate.errs) != 1 {
+		t.Errorf("unexpected Service.ValidateFile span: %#v", validate)
+	}
+	if v := validate.attrs["file.id"]; v.AsString() != "missing" {
+		t.Errorf("file.id=%v", v.Emit())
+	}
+	if get := recorder.span("Service.GetFile"); get == nil || get.parent.SpanID() != validate.sc.SpanID() {
+		t.Errorf("unexpected Service.GetFile span: %#v", get)
+	}
+}
+
+func TestTracer__attributes(t *testing.T) {
+	recorder := &recordingTracer{}
+	_, span := NewTracer(recorder, nil).Start(context.Background(), "test", nil)
+	span.SetAttribute("string", "a")
+	span.SetAttribute("int", 1)
+	span.SetAttribute("int64", int64(2))
+	span.SetAttribute("float64", 1.5)
+	span.SetAttribute("bool", true)
+	span.SetAttribute("duration", time.Second)
+	span.End(errors.New("failed"))
+
+	attrs := recorder.spans[0].attrs
+	if attrs["string"].AsString() != "a" || attrs["int"].AsInt64() != 1 || attrs["int64"].AsInt64() != 2 {
+		t.Errorf("unexpected attributes: %v", attrs)
+	}
+	if attrs["float64"].AsFloat64() != 1.5 || !attrs["bool"].AsBool() || attrs["duration"].AsString() != "1s" {
+		t.Errorf("unexpected attributes: %v", attrs)
+	}
+	if s := recorder.spans[0]; !s.ended || s.status != codes.Error || s.kind != trace.SpanKindInternal {
+		t.Errorf("unexpected span: %#v", s)
+	}
+}
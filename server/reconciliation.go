@@ -0,0 +1,298 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/ach"
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+)
+
+// ReconciliationItem types
+const (
+	ReconciledReturn     = "return"
+	ReconciledCorrection = "correction"
+)
+
+// Reconciliation links the returns and Notifications of Change received for originated entries
+type Reconciliation struct {
+	Returns     int `json:"returns"`
+	Corrections int `json:"corrections"`
+	// Unmatched is how many returns and corrections have no original entry
+	Unmatched int                   `json:"unmatched"`
+	Items     []*ReconciliationItem `json:"items"`
+}
+
+// ReconciliationItem is a return or correction along with the entry it was received for
+type ReconciliationItem struct {
+	// Type is ReconciledReturn or ReconciledCorrection
+	Type string `json:"type"`
+	// Code is the ReturnCode of a return or ChangeCode of a correction
+	Code                string `json:"code"`
+	Reason              string `json:"reason,omitempty"`
+	OriginalTraceNumber string `json:"originalTraceNumber"`
+	// CorrectedData is the corrected account or routing information of a correction
+	CorrectedData string `json:"correctedData,omitempty"`
+
+	Received *EntryMatch `json:"received"`
+	// Original is the originated entry, it's nil when none of the originated files have it
+	Original *EntryMatch `json:"original,omitempty"`
+}
+
+// Reconcile links the returns and corrections of the received files to the entries of the originated files
+// by their original trace number. Every stored file is used when no originals or received file IDs are given.
+func (s *service) Reconcile(ctx context.Context, originals, received []string) (_ *Reconciliation, err error) {
+	ctx, span := startSpan(ctx, "Service.Reconcile")
+	defer endSpan(span, &err)
+
+	originalFiles, err := s.reconciledFiles(ctx, originals)
+	if err != nil {
+		return nil, err
+	}
+	receivedFiles, err := s.reconciledFiles(ctx, received)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]*EntryMatch)
+	forward := func(m *EntryMatch, traceNumber string, addenda98 *ach.Addenda98, addenda99 *ach.Addenda99) {
+		traceNumber = strings.TrimSpace(traceNumber)
+		if _, exists := index[traceNumber]; !exists && addenda98 == nil && addenda99 == nil {
+			index[traceNumber] = m
+		}
+	}
+	for _, f := range originalFiles {
+		visitEntryMatches(f, func(m *EntryMatch) {
+			if m.Entry != nil {
+				forward(m, m.Entry.TraceNumber, m.Entry.Addenda98, m.Entry.Addenda99)
+			} else {
+				forward(m, m.IATEntry.TraceNumber, m.IATEntry.Addenda98, m.IATEntry.Addenda99)
+			}
+		})
+	}
+
+	out := &Reconciliation{}
+	add := func(m *EntryMatch, addenda98 *ach.Addenda98, addenda99 *ach.Addenda99) {
+		item := &ReconciliationItem{Received: m}
+		switch {
+		case addenda99 != nil:
+			item.Type = ReconciledReturn
+			item.Code = addenda99.ReturnCode
+			item.OriginalTraceNumber = strings.TrimSpace(addenda99.OriginalTrace)
+			if code := ach.LookupReturnCode(item.Code); code != nil {
+				item.Reason = code.Reason
+			}
+			out.Returns++
+		case addenda98 != nil:
+			item.Type = ReconciledCorrection
+			item.Code = addenda98.ChangeCode
+			item.OriginalTraceNumber = strings.TrimSpace(addenda98.OriginalTrace)
+			item.CorrectedData = strings.TrimSpace(addenda98.CorrectedData)
+			if code := ach.LookupChangeCode(item.Code); code != nil {
+				item.Reason = code.Reason
+			}
+			out.Corrections++
+		default:
+			return
+		}
+		if item.Original = index[item.OriginalTraceNumber]; item.Original == nil {
+			out.Unmatched++
+		}
+		out.Items = append(out.Items, item)
+	}
+	for _, f := range receivedFiles {
+		visitEntryMatches(f, func(m *EntryMatch) {
+			if m.Entry != nil {
+				add(m, m.Entry.Addenda98, m.Entry.Addenda99)
+			} else {
+				add(m, m.IATEntry.Addenda98, m.IATEntry.Addenda99)
+			}
+		})
+	}
+	return out, nil
+}
+
+// reconciledFiles returns the stored files with the given IDs, or every stored file without IDs, ordered by ID
+func (s *service) reconciledFiles(ctx context.Context, ids []string) ([]*ach.File, error) {
+	if len(ids) == 0 {
+		files := s.store.FindAllFiles(ctx)
+		sort.Slice(files, func(i, j int) bool { return files[i].ID < files[j].ID })
+		return files, nil
+	}
+	files := make([]*ach.File, 0, len(ids))
+	for _, id := range ids {
+		f, err := s.store.FindFile(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("problem reading file %s: %w", id, err)
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// visitEntryMatches calls fn with every entry and IAT entry of f
+func visitEntryMatches(f *ach.File, fn func(m *EntryMatch)) {
+	f.Visit(ach.FileVisitor{
+		Entry: func(batch ach.Batcher, entry *ach.EntryDetail) error {
+			fn(&EntryMatch{
+				FileID:      f.ID,
+				BatchID:     batch.ID(),
+				BatchNumber: batch.GetHeader().BatchNumber,
+				Entry:       entry,
+			})
+			return nil
+		},
+		IATEntry: func(iatBatch *ach.IATBatch, entry *ach.IATEntryDetail) error {
+			fn(&EntryMatch{
+				FileID:      f.ID,
+				BatchID:     iatBatch.ID,
+				BatchNumber: iatBatch.GetHeader().BatchNumber,
+				IATEntry:    entry,
+			})
+			return nil
+		},
+	})
+}
+
+var reconciliationCSVHeader = []string{
+	"type", "code", "reason", "originalTraceNumber", "correctedData", "amount",
+	"receivedFileID", "receivedBatchID", "traceNumber", "originalFileID", "originalBatchID",
+}
+
+// writeCSV writes a row for every item of r, leaving the original columns empty for unmatched items
+func (r *Reconciliation) writeCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(reconciliationCSVHeader); err != nil {
+		return err
+	}
+	for _, item := range r.Items {
+		var amount int
+		var traceNumber string
+		if item.Received.Entry != nil {
+			amount, traceNumber = item.Received.Entry.Amount, item.Received.Entry.TraceNumber
+		} else {
+			amount, traceNumber = item.Received.IATEntry.Amount, item.Received.IATEntry.TraceNumber
+		}
+		var originalFileID, originalBatchID string
+		if item.Original != nil {
+			originalFileID, originalBatchID = item.Original.FileID, item.Original.BatchID
+		}
+		err := out.Write([]string{
+			item.Type, item.Code, item.Reason, item.OriginalTraceNumber, item.CorrectedData, strconv.Itoa(amount),
+			item.Received.FileID, item.Received.BatchID, traceNumber, originalFileID, originalBatchID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+type reconcileRequest struct {
+	originals, received []string
+	csv                 bool
+
+	requestID string
+}
+
+type reconcileResponse struct {
+	*Reconciliation
+	Err error `json:"error"`
+
+	csv bool
+}
+
+func (r reconcileResponse) error() error { return r.Err }
+
+func reconcileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(reconcileRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return reconcileResponse{
+				Err: err,
+			}, err
+		}
+
+		rec, err := s.Reconcile(ctx, req.originals, req.received)
+
+		if err != nil {
+			logEvent(logger, "files", "reconcile", err, "requestID", req.requestID)
+		} else {
+			logEvent(logger, "files", "reconcile", nil, "requestID", req.requestID, "returns", rec.Returns, "corrections", rec.Corrections, "unmatched", rec.Unmatched)
+		}
+
+		return reconcileResponse{
+			Reconciliation: rec,
+			Err:            err,
+			csv:            req.csv,
+		}, nil
+	}
+}
+
+// splitFileIDs reads a comma separated list of file IDs
+func splitFileIDs(v string) []string {
+	var ids []string
+	for _, id := range strings.Split(v, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func decodeReconcileRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	req := reconcileRequest{
+		originals: splitFileIDs(q.Get("originals")),
+		received:  splitFileIDs(q.Get("received")),
+		requestID: moovhttp.GetRequestID(r),
+	}
+	switch format := strings.ToLower(q.Get("format")); format {
+	case "", "json":
+	case "csv":
+		req.csv = true
+	default:
+		return nil, invalid(fmt.Errorf("unknown format %q", format))
+	}
+	return req, nil
+}
+
+// encodeReconcileResponse writes CSV when requested, otherwise JSON
+func encodeReconcileResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp, ok := response.(reconcileResponse)
+	if !ok || !resp.csv || resp.Err != nil {
+		return encodeResponse(ctx, w, response)
+	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	return resp.writeCSV(w)
+}
@@ -0,0 +1,138 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+
+	originals := ach.NewFile()
+	originals.ID = "originals"
+	originals.SetHeader(*mockFileHeader())
+	originals.AddBatch(mockAcknowledgmentBatch(t, ach.CCD,
+		mockAcknowledgedEntry(ach.CheckingCredit, "121042880000001"),
+		mockAcknowledgedEntry(ach.CheckingCredit, "121042880000002"),
+	))
+	if err := repo.StoreFile(ctx, originals); err != nil {
+		t.Fatal(err)
+	}
+
+	returned := mockAcknowledgedEntry(ach.CheckingReturnNOCCredit, "231380100000001")
+	returned.Category = ach.CategoryReturn
+	returned.Addenda99 = ach.NewAddenda99()
+	returned.Addenda99.ReturnCode = "R01"
+	returned.Addenda99.OriginalTrace = "121042880000001"
+	corrected := mockAcknowledgedEntry(ach.CheckingReturnNOCCredit, "231380100000002")
+	corrected.Amount = 0
+	corrected.Category = ach.CategoryNOC
+	corrected.Addenda98 = ach.NewAddenda98()
+	corrected.Addenda98.ChangeCode = "C01"
+	corrected.Addenda98.OriginalTrace = "121042880000002"
+	corrected.Addenda98.CorrectedData = "1918171614"
+	unknown := mockAcknowledgedEntry(ach.CheckingReturnNOCCredit, "231380100000003")
+	unknown.Category = ach.CategoryReturn
+	unknown.Addenda99 = ach.NewAddenda99()
+	unknown.Addenda99.ReturnCode = "R03"
+	unknown.Addenda99.OriginalTrace = "121042880000009"
+
+	received := ach.NewFile()
+	received.ID = "received"
+	received.SetHeader(*mockFileHeader())
+	received.AddBatch(mockAcknowledgmentBatch(t, ach.CCD, returned, corrected, unknown))
+	if err := repo.StoreFile(ctx, received); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := svc.Reconcile(ctx, []string{"originals"}, []string{"received"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Returns != 2 || rec.Corrections != 1 || rec.Unmatched != 1 || len(rec.Items) != 3 {
+		t.Fatalf("unexpected reconciliation: %#v", rec)
+	}
+	if item := rec.Items[0]; item.Type != ReconciledReturn || item.Code != "R01" || item.Reason == "" || item.Original == nil || item.Original.Entry.TraceNumber != "121042880000001" {
+		t.Errorf("unexpected return: %#v", item)
+	}
+	if item := rec.Items[1]; item.Type != ReconciledCorrection || item.Code != "C01" || item.CorrectedData != "1918171614" || item.Original == nil || item.Original.FileID != "originals" {
+		t.Errorf("unexpected correction: %#v", item)
+	}
+	if item := rec.Items[2]; item.Original != nil || item.OriginalTraceNumber != "121042880000009" {
+		t.Errorf("unexpected return: %#v", item)
+	}
+
+	// every stored file is used without file IDs
+	if rec, err := svc.Reconcile(ctx, nil, nil); err != nil || rec.Unmatched != 1 || len(rec.Items) != 3 {
+		t.Errorf("unexpected reconciliation: %v: %#v", err, rec)
+	}
+	if _, err := svc.Reconcile(ctx, []string{"missing"}, nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	router := MakeHTTPHandler(svc, repo, logger)
+	serve := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/files/reconciliation"+query, nil))
+		w.Flush()
+		return w
+	}
+
+	w := serve("?originals=originals&received=received")
+	var resp Reconciliation
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || w.Code != http.StatusOK || len(resp.Items) != 3 {
+		t.Errorf("bogus HTTP status: %d: %v", w.Code, err)
+	}
+
+	w = serve("?originals=originals&received=received&format=csv")
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/csv") {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	rows, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil || len(rows) != 4 {
+		t.Fatalf("unexpected CSV: %v: %v", err, rows)
+	}
+	if strings.Join(rows[1], ",") != "return,R01,Insufficient Funds,121042880000001,,5000000,received,,231380100000001,originals," {
+		t.Errorf("unexpected row: %v", rows[1])
+	}
+	if rows[3][9] != "" {
+		t.Errorf("unexpected row: %v", rows[3])
+	}
+
+	if w = serve("?originals=missing"); w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = serve("?format=xml"); w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
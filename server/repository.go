@@ -18,6 +18,8 @@
 package server
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
@@ -28,21 +30,96 @@ import (
 	"github.com/go-kit/kit/log"
 )
 
-// Repository is the Service storage mechanism abstraction
+// Repository is the Service storage mechanism abstraction. Methods take the context of the
+// request being served, so implementations backed by a database or network service can honor
+// its cancellation and deadline. Changes aren't made once the context is done.
 type Repository interface {
-	StoreFile(file *ach.File) error
-	FindFile(id string) (*ach.File, error)
-	FindAllFiles() []*ach.File
-	DeleteFile(id string) error
-	StoreBatch(fileID string, batch ach.Batcher) error
-	FindBatch(fileID string, batchID string) (ach.Batcher, error)
-	FindAllBatches(fileID string) []ach.Batcher
-	DeleteBatch(fileID string, batchID string) error
+	StoreFile(ctx context.Context, file *ach.File) error
+	// ReplaceFile stores a file over any existing file with the same ID, after recording the existing file as a version
+	ReplaceFile(ctx context.Context, file *ach.File) error
+	FindFile(ctx context.Context, id string) (*ach.File, error)
+	FindAllFiles(ctx context.Context) []*ach.File
+	// DeleteFile removes a file, which can be restored with RestoreFile until it's purged
+	DeleteFile(ctx context.Context, id string) error
+	StoreBatch(ctx context.Context, fileID string, batch ach.Batcher) error
+	FindBatch(ctx context.Context, fileID string, batchID string) (ach.Batcher, error)
+	FindAllBatches(ctx context.Context, fileID string) []ach.Batcher
+	DeleteBatch(ctx context.Context, fileID string, batchID string) error
+
+	// SaveVersion records the current state of a file so it can be rolled back to after being modified
+	SaveVersion(ctx context.Context, fileID string) error
+	FindVersions(ctx context.Context, fileID string) ([]*FileVersion, error)
+	// RollbackFile replaces a file with the given version, after recording the current state as a new version
+	RollbackFile(ctx context.Context, fileID string, version int) (*ach.File, error)
+	// UpdateFile changes a copy of a file with update. Once update succeeds the current state of the file
+	// is recorded as a version and the copy replaces it. When update fails the error is returned and the
+	// file, its revision and versions are left as they were.
+	UpdateFile(ctx context.Context, fileID string, update func(*ach.File) error) (*ach.File, error)
+
+	// FileRevision returns the revision of a stored file. Revisions start at 1 when a file is stored
+	// and increase with every change made to it.
+	FileRevision(ctx context.Context, fileID string) (int, error)
+	// UpdateFileAtRevision is UpdateFile, but fails with ErrPreconditionFailed unless the file is at revision
+	UpdateFileAtRevision(ctx context.Context, fileID string, revision int, update func(*ach.File) error) (*ach.File, error)
+	// DeleteFileAtRevision is DeleteFile, but fails with ErrPreconditionFailed unless the file is at revision
+	DeleteFileAtRevision(ctx context.Context, fileID string, revision int) error
+
+	// RestoreFile undoes the deletion of a file, returning it with the versions it had. It fails with
+	// ErrNotFound once the file is purged and ErrAlreadyExists if another file was stored with its ID.
+	RestoreFile(ctx context.Context, fileID string) (*ach.File, error)
+	// PurgeDeletedFiles permanently removes the files deleted before the given time and returns how many were removed
+	PurgeDeletedFiles(ctx context.Context, before time.Time) (int, error)
+
+	// FileStatus returns where a stored file is in its lifecycle, files are StatusCreated once stored
+	FileStatus(ctx context.Context, fileID string) (*FileLifecycle, error)
+	// SetFileStatus moves a stored file forward in its lifecycle to status at the given time. It fails with
+	// ErrConflict when the file already has the status or one after it.
+	SetFileStatus(ctx context.Context, fileID string, status FileStatus, at time.Time) (*FileLifecycle, error)
+
+	// NextFileIDModifier reserves and returns the FileIDModifier for the next file from origin to destination
+	// created on date (YYMMDD). Modifiers go A through Z and then 0 through 9, skipping those reserved
+	// earlier and those of stored files with the same origin, destination and date.
+	NextFileIDModifier(ctx context.Context, origin, destination, date string) (string, error)
+}
+
+// checkRevision returns ErrPreconditionFailed if a file at current isn't at the expected revision.
+// An expected revision of zero matches any revision.
+func checkRevision(fileID string, current, expected int) error {
+	if expected != 0 && current != expected {
+		return preconditionFailed(fmt.Errorf("file %s is at revision %d, not %d", fileID, current, expected))
+	}
+	return nil
+}
+
+// maxFileVersions is how many prior versions of each file are kept. Older versions are dropped.
+const maxFileVersions = 25
+
+// FileVersion is a prior state of a stored file, kept as the JSON it would be rendered as.
+type FileVersion struct {
+	Version int             `json:"version"`
+	Created time.Time       `json:"created"`
+	File    json.RawMessage `json:"file"`
+}
+
+// deletedFile is a file removed by DeleteFile, kept until it's restored or purged
+type deletedFile struct {
+	file      *ach.File
+	versions  []*FileVersion
+	revision  int
+	lifecycle *FileLifecycle
+	deleted   time.Time
 }
 
 type repositoryInMemory struct {
-	mtx   sync.RWMutex
-	files map[string]*ach.File
+	mtx       sync.RWMutex
+	files     map[string]*ach.File
+	versions  map[string][]*FileVersion
+	revisions map[string]int
+	statuses  map[string]*FileLifecycle
+	deleted   map[string]*deletedFile
+
+	// modifiers is the last FileIDModifier reserved for each origin, destination and date
+	modifiers map[fileIDModifierKey]string
 
 	ttl time.Duration
 
@@ -52,9 +129,14 @@ type repositoryInMemory struct {
 // NewRepositoryInMemory is an in memory ach storage repository for files
 func NewRepositoryInMemory(ttl time.Duration, logger log.Logger) Repository {
 	repo := &repositoryInMemory{
-		files:  make(map[string]*ach.File),
-		ttl:    ttl,
-		logger: logger,
+		files:     make(map[string]*ach.File),
+		versions:  make(map[string][]*FileVersion),
+		revisions: make(map[string]int),
+		statuses:  make(map[string]*FileLifecycle),
+		deleted:   make(map[string]*deletedFile),
+		modifiers: make(map[fileIDModifierKey]string),
+		ttl:       ttl,
+		logger:    logger,
 	}
 
 	if ttl <= 0*time.Second {
@@ -73,7 +155,7 @@ func NewRepositoryInMemory(ttl time.Duration, logger log.Logger) Repository {
 	return repo
 }
 
-func (r *repositoryInMemory) StoreFile(f *ach.File) error {
+func (r *repositoryInMemory) StoreFile(ctx context.Context, f *ach.File) error {
 	if f == nil {
 		return errors.New("nil ACH file provided")
 	}
@@ -84,11 +166,33 @@ func (r *repositoryInMemory) StoreFile(f *ach.File) error {
 		return ErrAlreadyExists
 	}
 	r.files[f.ID] = f
+	r.revisions[f.ID] = 1
+	r.statuses[f.ID] = newFileLifecycle(f.ID, time.Now())
+	return nil
+}
+
+func (r *repositoryInMemory) ReplaceFile(ctx context.Context, f *ach.File) error {
+	if f == nil {
+		return errors.New("nil ACH file provided")
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if existing, ok := r.files[f.ID]; ok && existing != nil {
+		if err := r.saveVersion(existing); err != nil {
+			return err
+		}
+	}
+	r.files[f.ID] = f
+	r.revisions[f.ID]++
+	if _, ok := r.statuses[f.ID]; !ok {
+		r.statuses[f.ID] = newFileLifecycle(f.ID, time.Now())
+	}
 	return nil
 }
 
 // FindFile retrieves a ach.File based on the supplied ID
-func (r *repositoryInMemory) FindFile(id string) (*ach.File, error) {
+func (r *repositoryInMemory) FindFile(ctx context.Context, id string) (*ach.File, error) {
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
 	if val, ok := r.files[id]; ok {
@@ -98,7 +202,7 @@ func (r *repositoryInMemory) FindFile(id string) (*ach.File, error) {
 }
 
 // FindAllFiles returns all files that have been saved in memory
-func (r *repositoryInMemory) FindAllFiles() []*ach.File {
+func (r *repositoryInMemory) FindAllFiles(ctx context.Context) []*ach.File {
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
 	files := make([]*ach.File, 0, len(r.files))
@@ -108,15 +212,134 @@ func (r *repositoryInMemory) FindAllFiles() []*ach.File {
 	return files
 }
 
-func (r *repositoryInMemory) DeleteFile(id string) error {
+func (r *repositoryInMemory) DeleteFile(ctx context.Context, id string) error {
+	return r.DeleteFileAtRevision(ctx, id, 0)
+}
+
+func (r *repositoryInMemory) DeleteFileAtRevision(ctx context.Context, id string, revision int) error {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
+	if file, ok := r.files[id]; ok {
+		if err := checkRevision(id, r.revisions[id], revision); err != nil {
+			return err
+		}
+		r.deleted[id] = &deletedFile{
+			file:      file,
+			versions:  r.versions[id],
+			revision:  r.revisions[id],
+			lifecycle: r.statuses[id],
+			deleted:   time.Now(),
+		}
+	} else if revision != 0 {
+		return ErrNotFound
+	}
 	delete(r.files, id)
+	delete(r.versions, id)
+	delete(r.revisions, id)
+	delete(r.statuses, id)
 	return nil
 }
 
+func (r *repositoryInMemory) RestoreFile(ctx context.Context, fileID string) (*ach.File, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	d, ok := r.deleted[fileID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if _, ok := r.files[fileID]; ok {
+		return nil, fmt.Errorf("%w: file %s was stored again after being deleted", ErrAlreadyExists, fileID)
+	}
+	delete(r.deleted, fileID)
+	r.files[fileID] = d.file
+	r.versions[fileID] = d.versions
+	r.revisions[fileID] = d.revision + 1
+	r.statuses[fileID] = d.lifecycle
+	return d.file, nil
+}
+
+func (r *repositoryInMemory) PurgeDeletedFiles(ctx context.Context, before time.Time) (int, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for id, d := range r.deleted {
+		if d.deleted.Before(before) {
+			removed++
+			delete(r.deleted, id)
+		}
+	}
+	return removed, nil
+}
+
+func (r *repositoryInMemory) FileStatus(ctx context.Context, fileID string) (*FileLifecycle, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	lifecycle, ok := r.statuses[fileID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return lifecycle.copy(), nil
+}
+
+func (r *repositoryInMemory) SetFileStatus(ctx context.Context, fileID string, status FileStatus, at time.Time) (*FileLifecycle, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	lifecycle, ok := r.statuses[fileID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err // the change was canceled while waiting for the lock
+	}
+	if err := lifecycle.advance(status, at); err != nil {
+		return nil, err
+	}
+	return lifecycle.copy(), nil
+}
+
+func (r *repositoryInMemory) FileRevision(ctx context.Context, fileID string) (int, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if _, ok := r.files[fileID]; !ok {
+		return 0, ErrNotFound
+	}
+	return r.revisions[fileID], nil
+}
+
+func (r *repositoryInMemory) NextFileIDModifier(ctx context.Context, origin, destination, date string) (string, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	key := newFileIDModifierKey(origin, destination, date)
+	used := make(map[string]bool)
+	for _, f := range r.files {
+		if fileHeaderModifierKey(f.Header) == key {
+			used[f.Header.FileIDModifier] = true
+		}
+	}
+	for _, d := range r.deleted {
+		if fileHeaderModifierKey(d.file.Header) == key {
+			used[d.file.Header.FileIDModifier] = true // so it's still unique when restored
+		}
+	}
+	next, err := nextFileIDModifier(key, r.modifiers[key], used)
+	if err != nil {
+		return "", err
+	}
+	r.modifiers[key] = next
+	return next, nil
+}
+
 // TODO(adam): was copying ach.Batcher causing issues?
-func (r *repositoryInMemory) StoreBatch(fileID string, batch ach.Batcher) error {
+func (r *repositoryInMemory) StoreBatch(ctx context.Context, fileID string, batch ach.Batcher) error {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 
@@ -133,14 +356,19 @@ func (r *repositoryInMemory) StoreBatch(fileID string, batch ach.Batcher) error
 		}
 	}
 
+	if err := r.saveVersion(file); err != nil {
+		return err
+	}
+
 	// Add the batch to the file
 	r.files[fileID].AddBatch(batch)
+	r.revisions[fileID]++
 
 	return nil
 }
 
 // FindBatch retrieves a ach.Batcher based on the supplied ID
-func (r *repositoryInMemory) FindBatch(fileID string, batchID string) (ach.Batcher, error) {
+func (r *repositoryInMemory) FindBatch(ctx context.Context, fileID string, batchID string) (ach.Batcher, error) {
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
 
@@ -159,7 +387,7 @@ func (r *repositoryInMemory) FindBatch(fileID string, batchID string) (ach.Batch
 }
 
 // FindAllBatches
-func (r *repositoryInMemory) FindAllBatches(fileID string) []ach.Batcher {
+func (r *repositoryInMemory) FindAllBatches(ctx context.Context, fileID string) []ach.Batcher {
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
 
@@ -174,18 +402,22 @@ func (r *repositoryInMemory) FindAllBatches(fileID string) []ach.Batcher {
 	return batches
 }
 
-func (r *repositoryInMemory) DeleteBatch(fileID string, batchID string) error {
+func (r *repositoryInMemory) DeleteBatch(ctx context.Context, fileID string, batchID string) error {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 
 	file, ok := r.files[fileID]
 	if !ok || file == nil {
-		return fmt.Errorf("%v: no file %s with batch %s found", ErrNotFound, fileID, batchID)
+		return fmt.Errorf("%w: no file %s with batch %s found", ErrNotFound, fileID, batchID)
 	}
 
 	for i := len(file.Batches) - 1; i >= 0; i-- {
 		if file.Batches[i].ID() == batchID {
+			if err := r.saveVersion(file); err != nil {
+				return err
+			}
 			file.Batches = append(file.Batches[:i], file.Batches[i+1:]...)
+			r.revisions[fileID]++
 			return nil
 		}
 	}
@@ -193,6 +425,142 @@ func (r *repositoryInMemory) DeleteBatch(fileID string, batchID string) error {
 	return ErrNotFound
 }
 
+func (r *repositoryInMemory) SaveVersion(ctx context.Context, fileID string) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	file, ok := r.files[fileID]
+	if !ok || file == nil {
+		return ErrNotFound
+	}
+	return r.saveVersion(file)
+}
+
+// saveVersion records the current state of file. The caller must hold r.mtx
+func (r *repositoryInMemory) saveVersion(file *ach.File) error {
+	bs, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("problem saving version of file %s: %v", file.ID, err)
+	}
+
+	versions := r.versions[file.ID]
+	next := 1
+	if n := len(versions); n > 0 {
+		next = versions[n-1].Version + 1
+	}
+	versions = append(versions, &FileVersion{
+		Version: next,
+		Created: time.Now(),
+		File:    bs,
+	})
+	if len(versions) > maxFileVersions {
+		versions = versions[len(versions)-maxFileVersions:]
+	}
+	r.versions[file.ID] = versions
+	return nil
+}
+
+func (r *repositoryInMemory) FindVersions(ctx context.Context, fileID string) ([]*FileVersion, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	if _, ok := r.files[fileID]; !ok {
+		return nil, ErrNotFound
+	}
+	versions := make([]*FileVersion, 0, len(r.versions[fileID]))
+	versions = append(versions, r.versions[fileID]...)
+	return versions, nil
+}
+
+func (r *repositoryInMemory) RollbackFile(ctx context.Context, fileID string, version int) (*ach.File, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	current, ok := r.files[fileID]
+	if !ok || current == nil {
+		return nil, ErrNotFound
+	}
+	for _, v := range r.versions[fileID] {
+		if v.Version != version {
+			continue
+		}
+		file, err := fileFromVersion(fileID, v.Version, v.File)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.saveVersion(current); err != nil {
+			return nil, err
+		}
+		r.files[fileID] = file
+		r.revisions[fileID]++
+		return file, nil
+	}
+	return nil, ErrNotFound
+}
+
+// fileFromVersion reads the JSON of a FileVersion
+func fileFromVersion(fileID string, version int, bs []byte) (*ach.File, error) {
+	// Files are stored before they're complete, so ignore validation errors
+	file, err := ach.FileFromJSON(bs)
+	if file == nil {
+		return nil, fmt.Errorf("problem reading version %d of file %s: %v", version, fileID, err)
+	}
+	// Batch IDs aren't part of the JSON, but are kept in sync with their BatchHeader
+	for i := range file.Batches {
+		file.Batches[i].SetID(file.Batches[i].GetHeader().ID)
+	}
+	return file, nil
+}
+
+func (r *repositoryInMemory) UpdateFile(ctx context.Context, fileID string, update func(*ach.File) error) (*ach.File, error) {
+	return r.UpdateFileAtRevision(ctx, fileID, 0, update)
+}
+
+func (r *repositoryInMemory) UpdateFileAtRevision(ctx context.Context, fileID string, revision int, update func(*ach.File) error) (*ach.File, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	file, ok := r.files[fileID]
+	if !ok || file == nil {
+		return nil, ErrNotFound
+	}
+	if err := checkRevision(fileID, r.revisions[fileID], revision); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err // the change was canceled while waiting for the lock
+	}
+	// change a copy so a failed update leaves the file, its revision and versions as they were
+	updated, err := copyFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := update(updated); err != nil {
+		return nil, err
+	}
+	if err := r.saveVersion(file); err != nil {
+		return nil, err
+	}
+	r.files[fileID] = updated
+	r.revisions[fileID]++
+	return updated, nil
+}
+
+// copyFile returns a copy of f which can be changed without changing f. It keeps the batch IDs and
+// ValidateOpts of f, and isn't built, so fields f leaves blank stay blank.
+func copyFile(f *ach.File) (*ach.File, error) {
+	out, err := f.MaskSensitiveData(&ach.MaskOpts{
+		KeepAccountNumbers:        true,
+		KeepNames:                 true,
+		KeepIdentificationNumbers: true,
+		KeepAddresses:             true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("problem copying file %s: %v", f.ID, err)
+	}
+	return out, nil
+}
+
 // cleanupOldFiles will iterate through r.files and delete entries which are older than
 // the environmental variable ACH_FILE_TTL (parsed as a time.Duration).
 func (r *repositoryInMemory) cleanupOldFiles() {
@@ -207,10 +575,22 @@ func (r *repositoryInMemory) cleanupOldFiles() {
 		if r.files[i].Header.FileCreationDate < tooOldStr {
 			removed++
 			delete(r.files, i)
+			delete(r.versions, i)
+			delete(r.revisions, i)
+			delete(r.statuses, i)
 		}
 	}
-
-	if r.logger != nil {
-		r.logger.Log("files", fmt.Sprintf("removed %d ACH files older than %v", removed, tooOld.Format(time.RFC3339)))
+	for id, d := range r.deleted {
+		if d.file.Header.FileCreationDate < tooOldStr {
+			removed++
+			delete(r.deleted, id)
+		}
+	}
+	for key := range r.modifiers {
+		if key.date < tooOldStr {
+			delete(r.modifiers, key)
+		}
 	}
+
+	logEvent(r.logger, "repository", "cleanupOldFiles", nil, "removed", removed, "olderThan", tooOld.Format(time.RFC3339))
 }
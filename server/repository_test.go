@@ -18,6 +18,10 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -32,9 +36,10 @@ var (
 )
 
 func TestRepositoryFiles(t *testing.T) {
+	ctx := context.Background()
 	r := NewRepositoryInMemory(testTTLDuration, nil)
 
-	if v := len(r.FindAllFiles()); v != 0 {
+	if v := len(r.FindAllFiles(ctx)); v != 0 {
 		t.Errorf("unexpected length: %d", v)
 	}
 
@@ -43,29 +48,64 @@ func TestRepositoryFiles(t *testing.T) {
 		ID:     base.ID(),
 		Header: *header,
 	}
-	if err := r.StoreFile(f); err != nil {
+	if err := r.StoreFile(ctx, f); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 
-	found, err := r.FindFile(f.ID)
+	found, err := r.FindFile(ctx, f.ID)
 	if err != nil || found == nil {
 		t.Errorf("found=%v, err=%v", found, err)
 	}
 
-	if v := len(r.FindAllFiles()); v != 1 {
+	if v := len(r.FindAllFiles(ctx)); v != 1 {
 		t.Errorf("unexpected length: %d", v)
 	}
 
-	if err := r.DeleteFile(f.ID); err != nil {
+	if err := r.DeleteFile(ctx, f.ID); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
 
+func TestRepository__ReplaceFile(t *testing.T) {
+	ctx := context.Background()
+	r := NewRepositoryInMemory(testTTLDuration, nil)
+
+	f := ach.NewFile()
+	f.ID = base.ID()
+	f.SetHeader(*mockFileHeader())
+	if err := r.ReplaceFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+	if versions, err := r.FindVersions(ctx, f.ID); err != nil || len(versions) != 0 {
+		t.Fatalf("versions=%#v error=%v", versions, err)
+	}
+
+	other := ach.NewFile()
+	other.ID = f.ID
+	other.SetHeader(*mockFileHeader())
+	other.Header.ImmediateOriginName = "Other Bank"
+	if err := r.ReplaceFile(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	found, err := r.FindFile(ctx, f.ID)
+	if err != nil || found.Header.ImmediateOriginName != "Other Bank" {
+		t.Errorf("found=%#v error=%v", found, err)
+	}
+	if versions, err := r.FindVersions(ctx, f.ID); err != nil || len(versions) != 1 {
+		t.Errorf("versions=%#v error=%v", versions, err)
+	}
+
+	if err := r.ReplaceFile(ctx, nil); err == nil {
+		t.Error("expected error")
+	}
+}
+
 func TestRepositoryBatches(t *testing.T) {
+	ctx := context.Background()
 	r := NewRepositoryInMemory(testTTLDuration, nil)
 
 	// make sure our tests are setup
-	if v := len(r.FindAllFiles()); v != 0 {
+	if v := len(r.FindAllFiles(ctx)); v != 0 {
 		t.Errorf("unexpected length: %d", v)
 	}
 
@@ -74,39 +114,114 @@ func TestRepositoryBatches(t *testing.T) {
 		ID:     base.ID(),
 		Header: *header,
 	}
-	if err := r.StoreFile(f); err != nil {
+	if err := r.StoreFile(ctx, f); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 
 	// batch tests
-	if v := len(r.FindAllBatches(f.ID)); v != 0 {
+	if v := len(r.FindAllBatches(ctx, f.ID)); v != 0 {
 		t.Errorf("unexpected length: %d", v)
 	}
 
 	batch := mockBatchWEB()
-	b, err := r.FindBatch(f.ID, batch.ID())
+	b, err := r.FindBatch(ctx, f.ID, batch.ID())
 	if err == nil || b != nil {
 		t.Errorf("b=%v, err=%v", b, err)
 	}
 
-	if err := r.StoreBatch(f.ID, batch); err != nil {
+	if err := r.StoreBatch(ctx, f.ID, batch); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 
-	if v := len(r.FindAllBatches(f.ID)); v != 1 {
+	if v := len(r.FindAllBatches(ctx, f.ID)); v != 1 {
 		t.Errorf("unexpected length: %d", v)
 	}
 
-	if err := r.DeleteBatch(f.ID, batch.ID()); err != nil {
+	if err := r.DeleteBatch(ctx, f.ID, batch.ID()); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 
-	if v := len(r.FindAllBatches(f.ID)); v != 0 {
+	if v := len(r.FindAllBatches(ctx, f.ID)); v != 0 {
 		t.Errorf("unexpected length: %d", v)
 	}
 }
 
+func TestRepository__versions(t *testing.T) {
+	ctx := context.Background()
+	r := NewRepositoryInMemory(testTTLDuration, nil)
+
+	f := ach.NewFile()
+	f.ID = base.ID()
+	f.SetHeader(*mockFileHeader())
+	if err := r.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+	if versions, err := r.FindVersions(ctx, f.ID); err != nil || len(versions) != 0 {
+		t.Fatalf("versions=%#v error=%v", versions, err)
+	}
+
+	// adding and deleting batches saves versions
+	batch := mockBatchWEB()
+	if err := r.StoreBatch(ctx, f.ID, batch); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.DeleteBatch(ctx, f.ID, batch.ID()); err != nil {
+		t.Fatal(err)
+	}
+	versions, err := r.FindVersions(ctx, f.ID)
+	if err != nil || len(versions) != 2 {
+		t.Fatalf("versions=%#v error=%v", versions, err)
+	}
+	if versions[0].Version != 1 || versions[1].Version != 2 {
+		t.Errorf("unexpected versions: %d and %d", versions[0].Version, versions[1].Version)
+	}
+
+	// rollback to when the batch existed
+	file, err := r.RollbackFile(ctx, f.ID, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(file.Batches) != 1 || file.Batches[0].ID() != batch.ID() {
+		t.Errorf("unexpected batches: %#v", file.Batches)
+	}
+	if found, _ := r.FindFile(ctx, f.ID); found != file {
+		t.Error("expected rolled back file to be stored")
+	}
+	if versions, _ := r.FindVersions(ctx, f.ID); len(versions) != 3 {
+		t.Errorf("expected rollback to save a version: %d", len(versions))
+	}
+
+	if _, err := r.RollbackFile(ctx, f.ID, 100); err != ErrNotFound {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := r.RollbackFile(ctx, "missing", 1); err != ErrNotFound {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := r.SaveVersion(ctx, "missing"); err != ErrNotFound {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// only the latest versions are kept
+	for i := 0; i < maxFileVersions; i++ {
+		if err := r.SaveVersion(ctx, f.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	versions, _ = r.FindVersions(ctx, f.ID)
+	if len(versions) != maxFileVersions || versions[0].Version != 4 {
+		t.Errorf("len(versions)=%d oldest=%d", len(versions), versions[0].Version)
+	}
+
+	if err := r.DeleteFile(ctx, f.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.FindVersions(ctx, f.ID); err != ErrNotFound {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
 func TestRepository__cleanupOldFiles(t *testing.T) {
+	ctx := context.Background()
 	r := NewRepositoryInMemory(testTTLDuration, nil)
 	if repo, ok := r.(*repositoryInMemory); !ok {
 		t.Fatalf("unexpected repository: %T %#v", r, r)
@@ -114,12 +229,12 @@ func TestRepository__cleanupOldFiles(t *testing.T) {
 		// write a file and later verify it's cleaned up
 		file := ach.NewFile()
 		file.Header.FileCreationDate = time.Now().Add(-1 * 24 * time.Hour).Format("060102") // YYMMDD of 24hrs ago
-		repo.StoreFile(file)
-		if n := len(repo.FindAllFiles()); n != 1 {
+		repo.StoreFile(ctx, file)
+		if n := len(repo.FindAllFiles(ctx)); n != 1 {
 			t.Errorf("got %d ACH files", n)
 		}
 		repo.cleanupOldFiles() // make sure we don't panic
-		if n := len(repo.FindAllFiles()); n != 0 {
+		if n := len(repo.FindAllFiles(ctx)); n != 0 {
 			t.Errorf("got %d ACH files", n)
 		}
 	}
@@ -132,3 +247,174 @@ func TestRepository__cleanupOldFiles(t *testing.T) {
 		repo.cleanupOldFiles() // make sure we don't panic
 	}
 }
+
+func TestRepository__UpdateFile(t *testing.T) {
+	ctx := context.Background()
+	r := NewRepositoryInMemory(testTTLDuration, nil)
+
+	f := ach.NewFile()
+	f.ID = base.ID()
+	f.SetHeader(*mockFileHeader())
+	if err := r.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := r.UpdateFile(ctx, f.ID, func(file *ach.File) error {
+		file.Header.ImmediateOriginName = "Other Bank"
+		return nil
+	})
+	if err != nil || updated.Header.ImmediateOriginName != "Other Bank" {
+		t.Fatalf("updated=%#v error=%v", updated, err)
+	}
+	if versions, err := r.FindVersions(ctx, f.ID); err != nil || len(versions) != 1 {
+		t.Errorf("versions=%#v error=%v", versions, err)
+	}
+	if _, err := r.UpdateFile(ctx, "missing", func(*ach.File) error { return nil }); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound: %v", err)
+	}
+}
+
+func TestRepository__UpdateFileFailed(t *testing.T) {
+	ctx := context.Background()
+	encrypted, err := NewRepositoryEncrypted(testEncryptionKey, testTTLDuration, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repos := map[string]Repository{
+		"memory":    NewRepositoryInMemory(testTTLDuration, nil),
+		"encrypted": encrypted,
+	}
+	for name, r := range repos {
+		t.Run(name, func(t *testing.T) {
+			f := ach.NewFile()
+			f.ID = base.ID()
+			f.SetHeader(*mockFileHeader())
+			if err := r.StoreFile(ctx, f); err != nil {
+				t.Fatal(err)
+			}
+			name := f.Header.ImmediateOriginName
+
+			failure := errors.New("update failed")
+			updated, err := r.UpdateFile(ctx, f.ID, func(file *ach.File) error {
+				file.Header.ImmediateOriginName = "Other Bank"
+				return failure
+			})
+			if err != failure || updated != nil {
+				t.Fatalf("updated=%#v error=%v", updated, err)
+			}
+			if found, err := r.FindFile(ctx, f.ID); err != nil || found.Header.ImmediateOriginName != name {
+				t.Errorf("ImmediateOriginName=%q error=%v", found.Header.ImmediateOriginName, err)
+			}
+			if rev, err := r.FileRevision(ctx, f.ID); err != nil || rev != 1 {
+				t.Errorf("revision=%d error=%v", rev, err)
+			}
+			if versions, err := r.FindVersions(ctx, f.ID); err != nil || len(versions) != 0 {
+				t.Errorf("versions=%#v error=%v", versions, err)
+			}
+		})
+	}
+}
+
+func TestRepository__UpdateFileKeepsBatches(t *testing.T) {
+	ctx := context.Background()
+	r := NewRepositoryInMemory(testTTLDuration, nil)
+
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "cor-example.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	f, err := ach.NewReader(fd).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.ID = base.ID()
+	f.Batches[0].SetID("batch-01")
+	if err := r.StoreFile(ctx, &f); err != nil {
+		t.Fatal(err)
+	}
+
+	// updates are made to a copy, which keeps the batch IDs and Notifications of Change of the file
+	updated, err := r.UpdateFile(ctx, f.ID, func(file *ach.File) error {
+		if file == &f || file.Batches[0] == f.Batches[0] {
+			t.Error("update was given the stored file")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Batches[0].ID() != "batch-01" || len(updated.NotificationOfChange) != len(f.NotificationOfChange) || len(f.NotificationOfChange) == 0 {
+		t.Errorf("batch ID=%s NotificationOfChange=%d", updated.Batches[0].ID(), len(updated.NotificationOfChange))
+	}
+}
+
+func TestRepository__revisions(t *testing.T) {
+	ctx := context.Background()
+	encrypted, err := NewRepositoryEncrypted(testEncryptionKey, testTTLDuration, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repos := map[string]Repository{
+		"memory":    NewRepositoryInMemory(testTTLDuration, nil),
+		"encrypted": encrypted,
+	}
+	for name, r := range repos {
+		t.Run(name, func(t *testing.T) {
+			f := ach.NewFile()
+			f.ID = base.ID()
+			f.SetHeader(*mockFileHeader())
+			if err := r.StoreFile(ctx, f); err != nil {
+				t.Fatal(err)
+			}
+			expectRevision := func(expected int) {
+				t.Helper()
+				if rev, err := r.FileRevision(ctx, f.ID); err != nil || rev != expected {
+					t.Errorf("revision=%d error=%v, expected %d", rev, err, expected)
+				}
+			}
+			expectRevision(1)
+
+			// every change advances the revision
+			if err := r.StoreBatch(ctx, f.ID, mockBatchWEB()); err != nil {
+				t.Fatal(err)
+			}
+			expectRevision(2)
+			rename := func(file *ach.File) error {
+				file.Header.ImmediateOriginName = "Other Bank"
+				return nil
+			}
+			if _, err := r.UpdateFileAtRevision(ctx, f.ID, 2, rename); err != nil {
+				t.Fatal(err)
+			}
+			expectRevision(3)
+
+			// outdated revisions are rejected without changing the file
+			if _, err := r.UpdateFileAtRevision(ctx, f.ID, 2, rename); !errors.Is(err, ErrPreconditionFailed) {
+				t.Errorf("expected ErrPreconditionFailed: %v", err)
+			}
+			if err := r.DeleteFileAtRevision(ctx, f.ID, 1); !errors.Is(err, ErrPreconditionFailed) {
+				t.Errorf("expected ErrPreconditionFailed: %v", err)
+			}
+			expectRevision(3)
+			if versions, _ := r.FindVersions(ctx, f.ID); len(versions) != 2 {
+				t.Errorf("got %d versions", len(versions))
+			}
+
+			if err := r.DeleteFileAtRevision(ctx, f.ID, 3); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := r.FileRevision(ctx, f.ID); err != ErrNotFound {
+				t.Errorf("expected ErrNotFound: %v", err)
+			}
+			if err := r.DeleteFileAtRevision(ctx, f.ID, 3); err != ErrNotFound {
+				t.Errorf("expected ErrNotFound: %v", err)
+			}
+
+			// stored again the file starts over
+			if err := r.StoreFile(ctx, f); err != nil {
+				t.Fatal(err)
+			}
+			expectRevision(1)
+		})
+	}
+}
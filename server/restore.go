@@ -0,0 +1,112 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/moov-io/ach"
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+)
+
+// RestoreFile undoes the deletion of a file, see Repository.RestoreFile
+func (s *service) RestoreFile(ctx context.Context, id string) (_ *ach.File, err error) {
+	ctx, span := startSpan(ctx, "Service.RestoreFile")
+	span.SetAttribute("file.id", id)
+	defer endSpan(span, &err)
+
+	return s.store.RestoreFile(ctx, id)
+}
+
+// StartPurge permanently removes the files of r deleted longer than after ago, checking every minute until
+// the returned func is called. Deleted files can be restored until they're purged.
+func StartPurge(r Repository, after time.Duration, logger log.Logger) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		t := time.NewTicker(time.Minute)
+		defer t.Stop()
+		for {
+			select {
+			case now := <-t.C:
+				purgeDeletedFiles(ctx, r, now.Add(-after), logger)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return cancel
+}
+
+func purgeDeletedFiles(ctx context.Context, r Repository, before time.Time, logger log.Logger) {
+	removed, err := r.PurgeDeletedFiles(ctx, before)
+	if err != nil || removed > 0 {
+		logEvent(logger, "repository", "purgeDeletedFiles", err, "removed", removed, "deletedBefore", before.Format(time.RFC3339))
+	}
+}
+
+type restoreFileRequest struct {
+	ID        string
+	requestID string
+}
+
+type restoreFileResponse struct {
+	File *ach.File `json:"file"`
+	Err  error     `json:"error"`
+}
+
+func (r restoreFileResponse) error() error { return r.Err }
+
+func restoreFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(restoreFileRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return restoreFileResponse{
+				Err: err,
+			}, err
+		}
+
+		f, err := s.RestoreFile(ctx, req.ID)
+
+		logEvent(logger, "files", "restoreFile", err, "requestID", req.requestID, "fileID", req.ID)
+
+		return restoreFileResponse{
+			File: f,
+			Err:  err,
+		}, nil
+	}
+}
+
+func decodeRestoreFileRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return restoreFileRequest{
+		ID:        id,
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
@@ -0,0 +1,140 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestRepository__RestoreFile(t *testing.T) {
+	ctx := context.Background()
+	encrypted, err := NewRepositoryEncrypted(testEncryptionKey, testTTLDuration, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repos := map[string]Repository{
+		"memory":    NewRepositoryInMemory(testTTLDuration, nil),
+		"encrypted": encrypted,
+	}
+	for name, repo := range repos {
+		f := ach.NewFile()
+		f.ID = "payroll"
+		f.SetHeader(*mockFileHeader())
+		if err := repo.StoreFile(ctx, f); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.SaveVersion(ctx, f.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.DeleteFile(ctx, f.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.FindFile(ctx, f.ID); err != ErrNotFound {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+
+		// the file comes back with its versions and a new revision
+		restored, err := repo.RestoreFile(ctx, f.ID)
+		if err != nil || restored.ID != f.ID || restored.Header.ImmediateOrigin != f.Header.ImmediateOrigin {
+			t.Fatalf("%s: restored=%#v error=%v", name, restored, err)
+		}
+		if versions, _ := repo.FindVersions(ctx, f.ID); len(versions) != 1 {
+			t.Errorf("%s: got %d versions", name, len(versions))
+		}
+		if rev, _ := repo.FileRevision(ctx, f.ID); rev != 2 {
+			t.Errorf("%s: revision=%d", name, rev)
+		}
+		if _, err := repo.RestoreFile(ctx, f.ID); err != ErrNotFound {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+
+		// a file stored with the same ID isn't overwritten
+		if err := repo.DeleteFile(ctx, f.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.StoreFile(ctx, f); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.RestoreFile(ctx, f.ID); !errors.Is(err, ErrAlreadyExists) {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+
+		// purged files are gone
+		if err := repo.DeleteFile(ctx, f.ID); err != nil {
+			t.Fatal(err)
+		}
+		if n, err := repo.PurgeDeletedFiles(ctx, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+			t.Errorf("%s: purged %d: %v", name, n, err)
+		}
+		if n, err := repo.PurgeDeletedFiles(ctx, time.Now().Add(time.Second)); err != nil || n != 1 {
+			t.Errorf("%s: purged %d: %v", name, n, err)
+		}
+		if _, err := repo.RestoreFile(ctx, f.ID); err != ErrNotFound {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := repo.PurgeDeletedFiles(canceled, time.Now()); err != context.Canceled {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+}
+
+func TestFiles__restoreFileEndpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	router := MakeHTTPHandler(NewService(repo), repo, logger)
+
+	f := readPPDValidFile(t)
+	if err := repo.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		w.Flush()
+		return w
+	}
+	if w := serve("DELETE", "/files/"+f.ID); w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("GET", "/files/"+f.ID); w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("POST", "/files/"+f.ID+"/restore"); w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("GET", "/files/"+f.ID); w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve("POST", "/files/"+f.ID+"/restore"); w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
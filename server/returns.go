@@ -0,0 +1,125 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/moov-io/ach"
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+)
+
+type getReturnCodesRequest struct {
+	// secCode limits the codes to those entries of the StandardEntryClassCode can be returned with
+	secCode string
+
+	requestID string
+}
+
+type getReturnCodesResponse struct {
+	Codes []*ach.ReturnCode `json:"codes"`
+	Err   error             `json:"error"`
+}
+
+func (r getReturnCodesResponse) count() int { return len(r.Codes) }
+
+func (r getReturnCodesResponse) error() error { return r.Err }
+
+func getReturnCodesEndpoint(logger log.Logger) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(getReturnCodesRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return getReturnCodesResponse{
+				Err: err,
+			}, err
+		}
+
+		var codes []*ach.ReturnCode
+		if req.secCode != "" {
+			codes = ach.ReturnCodesForSECCode(req.secCode)
+		} else {
+			codes = ach.ReturnCodes()
+		}
+
+		logEvent(logger, "returns", "getReturnCodes", nil, "requestID", req.requestID, "secCode", req.secCode, "codes", len(codes))
+
+		return getReturnCodesResponse{
+			Codes: codes,
+		}, nil
+	}
+}
+
+func decodeGetReturnCodesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return getReturnCodesRequest{
+		secCode:   strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("secCode"))),
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
+
+type getReturnCodeRequest struct {
+	code string
+
+	requestID string
+}
+
+type getReturnCodeResponse struct {
+	Code *ach.ReturnCode `json:"code"`
+	Err  error           `json:"error"`
+}
+
+func (r getReturnCodeResponse) error() error { return r.Err }
+
+func getReturnCodeEndpoint(logger log.Logger) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(getReturnCodeRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return getReturnCodeResponse{
+				Err: err,
+			}, err
+		}
+
+		code := ach.LookupReturnCode(req.code)
+
+		var err error
+		if code == nil {
+			err = ErrNotFound
+		}
+		logEvent(logger, "returns", "getReturnCode", err, "requestID", req.requestID, "code", req.code)
+
+		return getReturnCodeResponse{
+			Code: code,
+			Err:  err,
+		}, nil
+	}
+}
+
+func decodeGetReturnCodeRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return getReturnCodeRequest{
+		code:      mux.Vars(r)["code"],
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
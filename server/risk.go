@@ -0,0 +1,295 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/moov-io/ach"
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+)
+
+// DefaultRiskLargeAmountFactor is how many times larger than its company's average amount in other
+// stored files an entry has to be to be reported, unless RiskOptions.LargeAmountFactor is set
+const DefaultRiskLargeAmountFactor = 3
+
+// Types of RiskSignal
+const (
+	// RiskNewReceiver is an entry to an account which no other stored file has an entry for
+	RiskNewReceiver = "newReceiver"
+	// RiskLargeAmount is an entry far larger than the entries its company has in other stored files
+	RiskLargeAmount = "largeAmount"
+	// RiskDuplicateAccount is an entry to an account which an earlier entry of the file is also for
+	RiskDuplicateAccount = "duplicateAccount"
+)
+
+// RiskOptions tune which entries a RiskReport flags
+type RiskOptions struct {
+	// LargeAmountFactor flags entries over this many times the average amount of their company's
+	// entries in other stored files. DefaultRiskLargeAmountFactor is used when it's zero.
+	LargeAmountFactor float64 `json:"largeAmountFactor,omitempty"`
+}
+
+// RiskReport lists the signals of a file a fraud team may want to review before it's released.
+// Account numbers aren't included, entries are identified by their batch and TraceNumber.
+type RiskReport struct {
+	FileID  string       `json:"fileID"`
+	Signals []RiskSignal `json:"signals"`
+	SameDay RiskSameDay  `json:"sameDay"`
+}
+
+// RiskSignal is an entry of a file flagged by a RiskReport
+type RiskSignal struct {
+	// Type is RiskNewReceiver, RiskLargeAmount or RiskDuplicateAccount
+	Type                  string     `json:"type"`
+	BatchNumber           int        `json:"batchNumber"`
+	BatchID               string     `json:"batchID,omitempty"`
+	CompanyIdentification string     `json:"companyIdentification"`
+	TraceNumber           string     `json:"traceNumber"`
+	Amount                ach.Amount `json:"amount"`
+	// Average is the company's average amount in other stored files for RiskLargeAmount signals
+	Average ach.Amount `json:"average,omitempty"`
+	// DuplicateOf is the TraceNumber of the file's first entry to the same account for RiskDuplicateAccount signals
+	DuplicateOf string `json:"duplicateOf,omitempty"`
+}
+
+// RiskSameDay totals the batches of a file which settle the same day they're sent. A batch is Same Day
+// when its CompanyDescriptiveDate is SD followed by a time (e.g. SD1300) or its EffectiveEntryDate is
+// the current banking day or earlier.
+type RiskSameDay struct {
+	Batches     int        `json:"batches"`
+	Entries     int        `json:"entries"`
+	TotalDebit  ach.Amount `json:"totalDebit"`
+	TotalCredit ach.Amount `json:"totalCredit"`
+}
+
+func (s *service) RiskReport(ctx context.Context, id string, opts RiskOptions) (*RiskReport, error) {
+	f, err := s.GetFile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return riskReport(f, s.otherFiles(ctx, f), opts, time.Now()), nil
+}
+
+// riskEntry is an entry of a file along with the batch and company it's from
+type riskEntry struct {
+	batchNumber int
+	batchID     string
+	company     string
+	sameDay     bool
+
+	receiver      string
+	traceNumber   string
+	amount        ach.Amount
+	creditOrDebit string
+}
+
+// riskEntries returns every entry of f, Same Day batches are those of today (YYMMDD) or earlier
+func riskEntries(f *ach.File, today string) []riskEntry {
+	var out []riskEntry
+	f.Visit(ach.FileVisitor{
+		Entry: func(batch ach.Batcher, entry *ach.EntryDetail) error {
+			bh := batch.GetHeader()
+			out = append(out, riskEntry{
+				batchNumber:   bh.BatchNumber,
+				batchID:       batch.ID(),
+				company:       strings.TrimSpace(bh.CompanyIdentification),
+				sameDay:       sameDayBatch(bh, today),
+				receiver:      entry.RDFIIdentification + entry.CheckDigit + "/" + strings.TrimSpace(entry.DFIAccountNumber),
+				traceNumber:   entry.TraceNumber,
+				amount:        entry.GetAmount(),
+				creditOrDebit: entry.CreditOrDebit(),
+			})
+			return nil
+		},
+		IATEntry: func(iatBatch *ach.IATBatch, entry *ach.IATEntryDetail) error {
+			// IAT entries can't be settled the same day
+			bh := iatBatch.GetHeader()
+			tran := ach.EntryDetail{TransactionCode: entry.TransactionCode}
+			out = append(out, riskEntry{
+				batchNumber:   bh.BatchNumber,
+				batchID:       iatBatch.ID,
+				company:       strings.TrimSpace(bh.OriginatorIdentification),
+				receiver:      entry.RDFIIdentification + entry.CheckDigit + "/" + strings.TrimSpace(entry.DFIAccountNumber),
+				traceNumber:   entry.TraceNumber,
+				amount:        ach.Amount(entry.Amount),
+				creditOrDebit: tran.CreditOrDebit(),
+			})
+			return nil
+		},
+	})
+	return out
+}
+
+// sameDayBatch returns true if bh asks for Same Day settlement or its EffectiveEntryDate is today (YYMMDD) or earlier
+func sameDayBatch(bh *ach.BatchHeader, today string) bool {
+	return strings.HasPrefix(bh.CompanyDescriptiveDate, "SD") || (bh.EffectiveEntryDate != "" && bh.EffectiveEntryDate <= today)
+}
+
+// riskReport flags the entries of f against the entries of others as of now
+func riskReport(f *ach.File, others []*ach.File, opts RiskOptions, now time.Time) *RiskReport {
+	factor := opts.LargeAmountFactor
+	if factor <= 0 {
+		factor = DefaultRiskLargeAmountFactor
+	}
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		loc = time.UTC
+	}
+	today := now.In(loc).Format("060102") // YYMMDD
+
+	// history of the receivers and companies in other files
+	receivers := make(map[string]bool)
+	type companyHistory struct {
+		total   ach.Amount
+		entries int
+	}
+	companies := make(map[string]*companyHistory)
+	for _, other := range others {
+		for _, entry := range riskEntries(other, today) {
+			receivers[entry.receiver] = true
+			history, ok := companies[entry.company]
+			if !ok {
+				history = &companyHistory{}
+				companies[entry.company] = history
+			}
+			history.total += entry.amount
+			history.entries++
+		}
+	}
+
+	report := &RiskReport{
+		FileID:  f.ID,
+		Signals: []RiskSignal{},
+	}
+	first := make(map[string]string) // receiver to the TraceNumber of its first entry in f
+	for _, entry := range riskEntries(f, today) {
+		signal := RiskSignal{
+			BatchNumber:           entry.batchNumber,
+			BatchID:               entry.batchID,
+			CompanyIdentification: entry.company,
+			TraceNumber:           entry.traceNumber,
+			Amount:                entry.amount,
+		}
+		if !receivers[entry.receiver] {
+			signal.Type = RiskNewReceiver
+			report.Signals = append(report.Signals, signal)
+		}
+		if history := companies[entry.company]; history != nil && history.entries > 0 {
+			average := history.total / ach.Amount(history.entries)
+			if float64(entry.amount) > factor*float64(average) {
+				large := signal
+				large.Type = RiskLargeAmount
+				large.Average = average
+				report.Signals = append(report.Signals, large)
+			}
+		}
+		if trace, exists := first[entry.receiver]; exists {
+			dup := signal
+			dup.Type = RiskDuplicateAccount
+			dup.DuplicateOf = trace
+			report.Signals = append(report.Signals, dup)
+		} else {
+			first[entry.receiver] = entry.traceNumber
+		}
+
+		if entry.sameDay {
+			report.SameDay.Entries++
+			switch entry.creditOrDebit {
+			case "C":
+				report.SameDay.TotalCredit += entry.amount
+			case "D":
+				report.SameDay.TotalDebit += entry.amount
+			}
+		}
+	}
+	for _, batch := range f.Batches {
+		if sameDayBatch(batch.GetHeader(), today) {
+			report.SameDay.Batches++
+		}
+	}
+	return report
+}
+
+type riskReportRequest struct {
+	fileID string
+	opts   RiskOptions
+
+	requestID string
+}
+
+type riskReportResponse struct {
+	*RiskReport
+	Err error `json:"error"`
+}
+
+func (r riskReportResponse) error() error { return r.Err }
+
+func riskReportEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(riskReportRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return riskReportResponse{
+				Err: err,
+			}, err
+		}
+
+		report, err := s.RiskReport(ctx, req.fileID, req.opts)
+		if err != nil {
+			logEvent(logger, "files", "riskReport", err, "requestID", req.requestID, "fileID", req.fileID)
+			return riskReportResponse{Err: err}, nil
+		}
+
+		logEvent(logger, "files", "riskReport", nil, "requestID", req.requestID, "fileID", req.fileID, "signals", len(report.Signals))
+
+		return riskReportResponse{
+			RiskReport: report,
+		}, nil
+	}
+}
+
+// decodeRiskReportRequest reads the optional RiskOptions in the request body
+func decodeRiskReportRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	req := riskReportRequest{
+		fileID:    id,
+		requestID: moovhttp.GetRequestID(r),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req.opts); err != nil && err != io.EOF {
+		return nil, invalid(fmt.Errorf("problem reading risk options: %v", err))
+	}
+	if req.opts.LargeAmountFactor < 0 {
+		return nil, invalid(errors.New("largeAmountFactor can't be negative"))
+	}
+	return req, nil
+}
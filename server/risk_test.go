@@ -0,0 +1,141 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+)
+
+func riskBatch(id, account string, amount int) *ach.BatchWEB {
+	batch := mockBatchWEB()
+	batch.SetID(id)
+	batch.GetEntries()[0].DFIAccountNumber = account
+	batch.GetEntries()[0].Amount = amount
+	if err := batch.Create(); err != nil {
+		panic(err)
+	}
+	return batch
+}
+
+func TestRiskReport(t *testing.T) {
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+
+	history := ach.NewFile()
+	history.ID = "history"
+	history.AddBatch(riskBatch("h1", "111", 10000))
+	history.AddBatch(riskBatch("h2", "222", 20000))
+
+	f := ach.NewFile()
+	f.ID = "risky"
+	f.AddBatch(riskBatch("b1", "111", 15000)) // known receiver, normal amount
+	f.AddBatch(riskBatch("b2", "333", 50000)) // new receiver, large amount
+	dup := riskBatch("b3", "333", 100)        // same account as b2
+	dup.GetHeader().EffectiveEntryDate = "261016"
+	f.AddBatch(dup)
+
+	report := riskReport(f, []*ach.File{history}, RiskOptions{}, now)
+	if report.FileID != "risky" {
+		t.Errorf("FileID=%s", report.FileID)
+	}
+	types := make(map[string][]RiskSignal)
+	for _, signal := range report.Signals {
+		types[signal.Type] = append(types[signal.Type], signal)
+	}
+	if signals := types[RiskNewReceiver]; len(signals) != 2 || signals[0].BatchID != "b2" || signals[1].BatchID != "b3" {
+		t.Errorf("unexpected new receivers: %#v", signals)
+	}
+	if signals := types[RiskLargeAmount]; len(signals) != 1 || signals[0].BatchID != "b2" || signals[0].Average != 15000 {
+		t.Errorf("unexpected large amounts: %#v", signals)
+	}
+	if signals := types[RiskDuplicateAccount]; len(signals) != 1 || signals[0].BatchID != "b3" || signals[0].DuplicateOf != f.Batches[1].GetEntries()[0].TraceNumber {
+		t.Errorf("unexpected duplicates: %#v", signals)
+	}
+	if report.SameDay.Batches != 1 || report.SameDay.Entries != 1 || report.SameDay.TotalCredit != 100 || report.SameDay.TotalDebit != 0 {
+		t.Errorf("unexpected same day totals: %#v", report.SameDay)
+	}
+
+	// a higher factor doesn't flag the amount
+	report = riskReport(f, []*ach.File{history}, RiskOptions{LargeAmountFactor: 4}, now)
+	for _, signal := range report.Signals {
+		if signal.Type == RiskLargeAmount {
+			t.Errorf("unexpected signal: %#v", signal)
+		}
+	}
+
+	// without history there are only new receivers
+	report = riskReport(f, nil, RiskOptions{}, now)
+	if len(report.Signals) != 4 {
+		t.Errorf("unexpected signals: %#v", report.Signals)
+	}
+}
+
+func TestRiskReport__endpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	router := MakeHTTPHandler(NewService(repo), repo, logger)
+
+	f := ach.NewFile()
+	f.ID = "risk"
+	f.SetHeader(*mockFileHeader())
+	f.AddBatch(riskBatch("b1", "111", 15000))
+	if err := repo.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/files/risk/risk", nil))
+	w.Flush()
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var response RiskReport
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if response.FileID != "risk" || len(response.Signals) != 1 || response.Signals[0].Type != RiskNewReceiver {
+		t.Errorf("unexpected report: %#v", response)
+	}
+	if strings.Contains(w.Body.String(), "111") {
+		t.Errorf("account number in report: %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/files/risk/risk", strings.NewReader(`{"largeAmountFactor": -1}`)))
+	w.Flush()
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/files/missing/risk", nil))
+	w.Flush()
+	if w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
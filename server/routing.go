@@ -25,12 +25,13 @@ import (
 	"io"
 	"net/http"
 	"strconv"
-	"strings"
 
+	"github.com/moov-io/ach"
 	moovhttp "github.com/moov-io/base/http"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
 	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/mux"
 )
@@ -42,9 +43,24 @@ var (
 	ErrBadRouting = fmt.Errorf("inconsistent mapping between route and handler, %s", bugReportHelp)
 	ErrFoundABug  = fmt.Errorf("snuck into encodeError with err == nil, %s", bugReportHelp)
 
-	errInvalidFile = errors.New("invalid ACH file")
+	errInvalidFile = invalid(errors.New("invalid ACH file"))
 )
 
+// invalidFile wraps err, why a file failed validation, with errInvalidFile's message while keeping
+// err available to errors.As, e.g. for encodeError to find an *ExposureLimitError.
+func invalidFile(err error) error {
+	return invalid(fmt.Errorf("%v: %w", errInvalidFile, err))
+}
+
+// warningsError keeps the ach.Warnings of a file which failed validation so encodeError
+// returns them alongside the error.
+type warningsError struct {
+	error
+	warnings []ach.Warning
+}
+
+func (e *warningsError) Unwrap() error { return e.error }
+
 // contextKey is a unique (and compariable) type we use
 // to store and retrieve additional information in the
 // go-kit context.
@@ -94,12 +110,13 @@ func preflightHandler(options []httptransport.ServerOption) http.Handler {
 	)
 }
 
-func MakeHTTPHandler(s Service, repo Repository, logger log.Logger) http.Handler {
+func MakeHTTPHandler(s Service, repo Repository, logger log.Logger, opts ...HandlerOption) http.Handler {
 	r := mux.NewRouter()
 	options := []httptransport.ServerOption{
-		httptransport.ServerErrorLogger(logger),
+		httptransport.ServerErrorLogger(level.Error(logger)),
 		httptransport.ServerErrorEncoder(encodeError),
 		httptransport.ServerBefore(saveCORSHeadersIntoContext()),
+		httptransport.ServerBefore(saveAuditInfoIntoContext()),
 		httptransport.ServerAfter(respondWithSavedCORSHeaders()),
 	}
 
@@ -110,24 +127,120 @@ func MakeHTTPHandler(s Service, repo Repository, logger log.Logger) http.Handler
 		w.Header().Set("Content-Type", "text/plain")
 		w.Write([]byte("PONG"))
 	})
+	r.Methods("GET").Path("/stats/aggregate").Handler(httptransport.NewServer(
+		aggregateStatsEndpoint(s, logger),
+		decodeAggregateStatsRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/settlement").Handler(httptransport.NewServer(
+		expectedSettlementEndpoint(logger),
+		decodeExpectedSettlementRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/returns/codes").Handler(httptransport.NewServer(
+		getReturnCodesEndpoint(logger),
+		decodeGetReturnCodesRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/returns/codes/{code}").Handler(httptransport.NewServer(
+		getReturnCodeEndpoint(logger),
+		decodeGetReturnCodeRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/schedules").Handler(httptransport.NewServer(
+		createScheduleEndpoint(s, logger),
+		decodeCreateScheduleRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/schedules").Handler(httptransport.NewServer(
+		getSchedulesEndpoint(s, logger),
+		decodeGetSchedulesRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/schedules/{scheduleID}").Handler(httptransport.NewServer(
+		getScheduleEndpoint(s, logger),
+		decodeScheduleRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("DELETE").Path("/schedules/{scheduleID}").Handler(httptransport.NewServer(
+		deleteScheduleEndpoint(s, logger),
+		decodeScheduleRequest,
+		encodeResponse,
+		options...,
+	))
 	r.Methods("GET").Path("/files").Handler(httptransport.NewServer(
 		getFilesEndpoint(s),
 		decodeGetFilesRequest,
 		encodeResponse,
 		options...,
 	))
+	r.Methods("GET").Path("/files/search").Handler(httptransport.NewServer(
+		searchEntriesEndpoint(s, logger),
+		decodeSearchEntriesRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/files/acknowledgments").Handler(httptransport.NewServer(
+		matchAcknowledgmentsEndpoint(s, logger),
+		decodeMatchAcknowledgmentsRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/files/reconciliation").Handler(httptransport.NewServer(
+		reconcileEndpoint(s, logger),
+		decodeReconcileRequest,
+		encodeReconcileResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/files/alerts").Handler(httptransport.NewServer(
+		getValidationAlertsEndpoint(s, logger),
+		decodeGetValidationAlertsRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/files/calendar").Handler(httptransport.NewServer(
+		settlementCalendarEndpoint(s, logger),
+		decodeSettlementCalendarRequest,
+		encodeSettlementCalendarResponse,
+		options...,
+	))
 	r.Methods("POST").Path("/files/create").Handler(httptransport.NewServer(
 		createFileEndpoint(s, repo, logger),
 		decodeCreateFileRequest,
 		encodeResponse,
 		options...,
 	))
+	r.Methods("POST").Path("/files/validate").Handler(httptransport.NewServer(
+		lintFileEndpoint(s, logger),
+		decodeLintFileRequest,
+		encodeResponse,
+		options...,
+	))
 	r.Methods("GET").Path("/files/{id}").Handler(httptransport.NewServer(
 		getFileEndpoint(s, logger),
 		decodeGetFileRequest,
 		encodeResponse,
 		options...,
 	))
+	r.Methods("PATCH").Path("/files/{id}").Handler(httptransport.NewServer(
+		patchFileEndpoint(s, logger),
+		decodePatchFileRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/files/{id}/stats").Handler(httptransport.NewServer(
+		fileStatsEndpoint(s, logger),
+		decodeFileStatsRequest,
+		encodeResponse,
+		options...,
+	))
 	r.Methods("GET").Path("/files/{id}/contents").Handler(httptransport.NewServer(
 		getFileContentsEndpoint(s, logger),
 		decodeGetFileContentsRequest,
@@ -140,12 +253,96 @@ func MakeHTTPHandler(s Service, repo Repository, logger log.Logger) http.Handler
 		encodeResponse,
 		options...,
 	))
+	r.Methods("POST").Path("/files/{id}/build").Handler(httptransport.NewServer(
+		buildFileEndpoint(s, logger),
+		decodeBuildFileRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/files/{id}/same-day").Handler(httptransport.NewServer(
+		sameDayFileEndpoint(s, logger),
+		decodeSameDayFileRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/files/{id}/risk").Handler(httptransport.NewServer(
+		riskReportEndpoint(s, logger),
+		decodeRiskReportRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/files/{id}/versions").Handler(httptransport.NewServer(
+		getFileVersionsEndpoint(s, logger),
+		decodeGetFileVersionsRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/files/{id}/audit").Handler(httptransport.NewServer(
+		getFileAuditEndpoint(s, logger),
+		decodeGetFileAuditRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/files/{id}/rollback/{version}").Handler(httptransport.NewServer(
+		rollbackFileEndpoint(s, logger),
+		decodeRollbackFileRequest,
+		encodeResponse,
+		options...,
+	))
 	r.Methods("DELETE").Path("/files/{id}").Handler(httptransport.NewServer(
 		deleteFileEndpoint(s, logger),
 		decodeDeleteFileRequest,
 		encodeResponse,
 		options...,
 	))
+	r.Methods("POST").Path("/files/{id}/restore").Handler(httptransport.NewServer(
+		restoreFileEndpoint(s, logger),
+		decodeRestoreFileRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/files/{id}/status").Handler(httptransport.NewServer(
+		getFileStatusEndpoint(s, logger),
+		decodeFileStatusRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/files/{id}/status").Handler(httptransport.NewServer(
+		setFileStatusEndpoint(s, logger),
+		decodeFileStatusRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/files/{id}/approval").Handler(httptransport.NewServer(
+		getApprovalEndpoint(s, logger),
+		decodeApprovalRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/files/{id}/submit").Handler(httptransport.NewServer(
+		submitFileEndpoint(s, logger),
+		decodeApprovalRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/files/{id}/approve").Handler(httptransport.NewServer(
+		approveFileEndpoint(s, logger),
+		decodeApprovalRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/files/{id}/reject").Handler(httptransport.NewServer(
+		rejectFileEndpoint(s, logger),
+		decodeApprovalRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/files/{id}/release").Handler(httptransport.NewServer(
+		releaseFileEndpoint(s, logger),
+		decodeApprovalRequest,
+		encodeResponse,
+		options...,
+	))
 	r.Methods("POST").Path("/files/{fileID}/batches").Handler(httptransport.NewServer(
 		createBatchEndpoint(s, logger),
 		decodeCreateBatchRequest,
@@ -164,12 +361,66 @@ func MakeHTTPHandler(s Service, repo Repository, logger log.Logger) http.Handler
 		encodeResponse,
 		options...,
 	))
+	r.Methods("PUT").Path("/files/{fileID}/batches/{batchID}").Handler(httptransport.NewServer(
+		replaceBatchEndpoint(s, logger),
+		decodeReplaceBatchRequest,
+		encodeResponse,
+		options...,
+	))
 	r.Methods("DELETE").Path("/files/{fileID}/batches/{batchID}").Handler(httptransport.NewServer(
 		deleteBatchEndpoint(s, logger),
 		decodeDeleteBatchRequest,
 		encodeResponse,
 		options...,
 	))
+	r.Methods("GET").Path("/files/{fileID}/batches/{batchID}/entries").Handler(httptransport.NewServer(
+		getEntriesEndpoint(s, logger),
+		decodeGetEntriesRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/files/{fileID}/batches/{batchID}/entries").Handler(httptransport.NewServer(
+		createEntryEndpoint(s, logger),
+		decodeCreateEntryRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/files/{fileID}/batches/{batchID}/entries/{seq}").Handler(httptransport.NewServer(
+		getEntryEndpoint(s, logger),
+		decodeGetEntryRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("PUT").Path("/files/{fileID}/batches/{batchID}/entries/{seq}").Handler(httptransport.NewServer(
+		replaceEntryEndpoint(s, logger),
+		decodeReplaceEntryRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("DELETE").Path("/files/{fileID}/batches/{batchID}/entries/{seq}").Handler(httptransport.NewServer(
+		deleteEntryEndpoint(s, logger),
+		decodeDeleteEntryRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/files/{fileID}/batches/{batchID}/entries/{seq}/addenda").Handler(httptransport.NewServer(
+		createAddendaEndpoint(s, logger),
+		decodeCreateAddendaRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/files/{fileID}/batches/{batchID}/entries/{seq}/addenda").Handler(httptransport.NewServer(
+		getAddendasEndpoint(s, logger),
+		decodeGetAddendasRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("DELETE").Path("/files/{fileID}/batches/{batchID}/entries/{seq}/addenda/{addendaID}").Handler(httptransport.NewServer(
+		deleteAddendaEndpoint(s, logger),
+		decodeDeleteAddendaRequest,
+		encodeResponse,
+		options...,
+	))
 	r.Methods("POST").Path("/files/{fileID}/balance").Handler(httptransport.NewServer(
 		balanceFileEndpoint(s, repo, logger),
 		decodeBalanceFileRequest,
@@ -188,6 +439,38 @@ func MakeHTTPHandler(s Service, repo Repository, logger log.Logger) http.Handler
 		encodeResponse,
 		options...,
 	))
+	r.Methods("POST").Path("/files/{fileID}/split").Handler(httptransport.NewServer(
+		splitFileEndpoint(s, repo, logger),
+		decodeSplitFileRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/files/{fileID}/clone").Handler(httptransport.NewServer(
+		cloneFileEndpoint(s, logger),
+		decodeCloneFileRequest,
+		encodeResponse,
+		options...,
+	))
+
+	cfg := &handlerConfig{maxBodySize: defaultMaxBodySize}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	r.Methods("GET").Path("/live").HandlerFunc(liveHandler)
+	r.Methods("GET").Path("/ready").HandlerFunc(readyHandler(cfg))
+	if logger != nil {
+		r.Use(logRequests(logger))
+	}
+	if cfg.tracer != nil {
+		r.Use(tracing(cfg.tracer))
+	}
+	if cfg.limiter != nil {
+		r.Use(cfg.limiter.middleware)
+	}
+	r.Use(limitBodySize(cfg.maxBodySize))
+	if cfg.auth != nil {
+		r.Use(cfg.auth.middleware)
+	}
 	return r
 }
 
@@ -205,6 +488,12 @@ type counter interface {
 	count() int
 }
 
+// etagger is implemented by response types for a file which set an ETag from its revision,
+// see fileETag, for use in If-Match on later changes. An empty ETag isn't sent.
+type etagger interface {
+	etag() string
+}
+
 // encodeResponse is the common method to encode all response types to the
 // client. I chose to do it this way because, since we're using JSON, there's no
 // reason to provide anything more specific. It's certainly possible to
@@ -221,6 +510,9 @@ func encodeResponse(ctx context.Context, w http.ResponseWriter, response interfa
 	if e, ok := response.(counter); ok {
 		w.Header().Set("X-Total-Count", strconv.Itoa(e.count()))
 	}
+	if e, ok := response.(etagger); ok && e.etag() != "" {
+		w.Header().Set("ETag", e.etag())
+	}
 
 	// Don't overwrite a header (i.e. called from encodeTextResponse)
 	if v := w.Header().Get("Content-Type"); v == "" {
@@ -235,6 +527,17 @@ func encodeResponse(ctx context.Context, w http.ResponseWriter, response interfa
 // This method is designed text/plain content-types and expects response
 // to be an io.Reader.
 func encodeTextResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	if c, ok := response.(*contentsReader); ok {
+		c.setHeaders(w)
+		if c.notModified {
+			w.WriteHeader(http.StatusNotModified)
+			return nil
+		}
+	}
 	if r, ok := response.(io.Reader); ok {
 		w.Header().Set("Content-Type", "text/plain")
 		w.WriteHeader(http.StatusOK)
@@ -244,32 +547,24 @@ func encodeTextResponse(ctx context.Context, w http.ResponseWriter, response int
 	return nil
 }
 
-// encodeError JSON encodes the supplied error
+// encodeError JSON encodes the supplied error, along with the violations of an *ExposureLimitError
+// and the warnings of a *warningsError
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	if err == nil {
 		err = ErrFoundABug
 	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(codeFrom(err))
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	body := map[string]interface{}{
 		"error": err.Error(),
-	})
-}
-
-func codeFrom(err error) int {
-	if err == nil {
-		return http.StatusOK
 	}
-	if strings.Contains(err.Error(), errInvalidFile.Error()) {
-		// This branch comes from validateFileEndpoint
-		return http.StatusBadRequest
+	var exposureErr *ExposureLimitError
+	if errors.As(err, &exposureErr) {
+		body["violations"] = exposureErr.Violations
 	}
-	switch err {
-	case ErrNotFound:
-		return http.StatusNotFound
-	case ErrAlreadyExists:
-		return http.StatusBadRequest
-	default:
-		return http.StatusInternalServerError
+	var warningsErr *warningsError
+	if errors.As(err, &warningsErr) {
+		body["warnings"] = warningsErr.warnings
 	}
+	json.NewEncoder(w).Encode(body)
 }
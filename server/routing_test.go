@@ -20,8 +20,6 @@ package server
 import (
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"github.com/moov-io/ach"
 	"net/http"
 	"net/http/httptest"
@@ -33,24 +31,6 @@ import (
 	httptransport "github.com/go-kit/kit/transport/http"
 )
 
-func TestRouting_codeFrom(t *testing.T) {
-	if v := codeFrom(nil); v != http.StatusOK {
-		t.Errorf("HTTP status: %d", v)
-	}
-	if v := codeFrom(fmt.Errorf("%v: other", errInvalidFile)); v != http.StatusBadRequest {
-		t.Errorf("HTTP status: %d", v)
-	}
-	if v := codeFrom(ErrNotFound); v != http.StatusNotFound {
-		t.Errorf("HTTP status: %d", v)
-	}
-	if v := codeFrom(ErrAlreadyExists); v != http.StatusBadRequest {
-		t.Errorf("HTTP status: %d", v)
-	}
-	if v := codeFrom(errors.New("other")); v != http.StatusInternalServerError {
-		t.Errorf("HTTP status: %d", v)
-	}
-}
-
 func TestRouting_ping(t *testing.T) {
 	logger := log.NewNopLogger()
 	r := NewRepositoryInMemory(1*time.Minute, logger)
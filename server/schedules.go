@@ -0,0 +1,609 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/base"
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+)
+
+// Frequencies of a Recurrence
+const (
+	ScheduleWeekly  = "weekly"
+	ScheduleMonthly = "monthly"
+)
+
+// scheduleDateFormat is the format of the dates of a Recurrence
+const scheduleDateFormat = "2006-01-02"
+
+// errSchedulesDisabled is returned by the schedule methods of a Service created without WithSchedules
+var errSchedulesDisabled = fmt.Errorf("schedules aren't enabled: %w", ErrNotFound)
+
+// Recurrence is when the payments of a Schedule are due
+type Recurrence struct {
+	// Frequency is ScheduleWeekly or ScheduleMonthly
+	Frequency string `json:"frequency"`
+	// Interval is how many weeks or months apart payments are, every week or month when zero
+	Interval int `json:"interval,omitempty"`
+	// Start is the date (YYYY-MM-DD) of the first payment. Weekly payments are due on its weekday and
+	// monthly payments on its day of the month, or the last day of shorter months.
+	Start string `json:"start"`
+	// End is the date (YYYY-MM-DD) after which no payments are due, payments continue without one
+	End string `json:"end,omitempty"`
+}
+
+// Schedule is a recurring payment: an entry sent in a batch with the template BatchHeader each time it's due.
+type Schedule struct {
+	ID string `json:"id"`
+	// BatchHeader is the header of each payment's batch, its EffectiveEntryDate is set when the payment is made
+	BatchHeader *ach.BatchHeader `json:"batchHeader"`
+	// EntryDetail is the entry sent for each payment, its TraceNumber is set when the payment is made
+	EntryDetail *ach.EntryDetail `json:"entryDetail"`
+	Recurrence  Recurrence       `json:"recurrence"`
+	// Last is the due date (YYYY-MM-DD) of the latest payment added to a file
+	Last string `json:"last,omitempty"`
+}
+
+// UnmarshalJSON reads a Schedule, filling in the record types of its BatchHeader and EntryDetail
+func (sched *Schedule) UnmarshalJSON(p []byte) error {
+	type Alias Schedule
+	aux := struct {
+		*Alias
+		BatchHeader json.RawMessage `json:"batchHeader"`
+		EntryDetail json.RawMessage `json:"entryDetail"`
+	}{
+		Alias: (*Alias)(sched),
+	}
+	if err := json.Unmarshal(p, &aux); err != nil {
+		return err
+	}
+	if len(aux.BatchHeader) > 0 && string(aux.BatchHeader) != "null" {
+		sched.BatchHeader = ach.NewBatchHeader()
+		if err := json.Unmarshal(aux.BatchHeader, sched.BatchHeader); err != nil {
+			return fmt.Errorf("problem reading BatchHeader: %v", err)
+		}
+	}
+	if len(aux.EntryDetail) > 0 && string(aux.EntryDetail) != "null" {
+		entry, err := ach.EntryDetailFromJSON(aux.EntryDetail)
+		if err != nil {
+			return err
+		}
+		sched.EntryDetail = entry
+	}
+	return nil
+}
+
+// validate checks r and returns its start and end dates, end is zero without one
+func (r Recurrence) validate() (start, end time.Time, err error) {
+	switch r.Frequency {
+	case ScheduleWeekly, ScheduleMonthly:
+	default:
+		return start, end, fmt.Errorf("unknown recurrence frequency %q", r.Frequency)
+	}
+	if r.Interval < 0 {
+		return start, end, errors.New("recurrence interval can't be negative")
+	}
+	if start, err = time.Parse(scheduleDateFormat, r.Start); err != nil {
+		return start, end, fmt.Errorf("invalid recurrence start: %v", err)
+	}
+	if r.End != "" {
+		if end, err = time.Parse(scheduleDateFormat, r.End); err != nil {
+			return start, end, fmt.Errorf("invalid recurrence end: %v", err)
+		}
+		if end.Before(start) {
+			return start, end, errors.New("recurrence ends before it starts")
+		}
+	}
+	return start, end, nil
+}
+
+// occurrences returns the dates payments are due after after and on or before through
+func (r Recurrence) occurrences(after, through time.Time) []time.Time {
+	start, end, err := r.validate()
+	if err != nil {
+		return nil
+	}
+	if !end.IsZero() && end.Before(through) {
+		through = end
+	}
+	interval := r.Interval
+	if interval == 0 {
+		interval = 1
+	}
+
+	var out []time.Time
+	for i := 0; ; i++ {
+		var day time.Time
+		if r.Frequency == ScheduleWeekly {
+			day = start.AddDate(0, 0, 7*interval*i)
+		} else {
+			// stay on the start's day of the month, or the last day of shorter months
+			first := time.Date(start.Year(), start.Month()+time.Month(interval*i), 1, 0, 0, 0, 0, time.UTC)
+			last := first.AddDate(0, 1, -1).Day()
+			dom := start.Day()
+			if dom > last {
+				dom = last
+			}
+			day = first.AddDate(0, 0, dom-1)
+		}
+		if day.After(through) {
+			return out
+		}
+		if day.After(after) {
+			out = append(out, day)
+		}
+	}
+}
+
+// batch returns a batch with a payment of sched, effective on effective and with the TraceNumber sequence seq
+func (sched *Schedule) batch(effective time.Time, seq int) (ach.Batcher, error) {
+	bh := *sched.BatchHeader
+	bh.ID = ""
+	bh.EffectiveEntryDate = effective.Format("060102") // YYMMDD
+	batch, err := ach.NewBatch(&bh)
+	if err != nil {
+		return nil, err
+	}
+	entry := *sched.EntryDetail
+	entry.ID = ""
+	entry.Addenda05 = nil
+	for _, addenda05 := range sched.EntryDetail.Addenda05 {
+		copied := *addenda05
+		entry.AddAddenda05(&copied)
+	}
+	entry.AddendaRecordIndicator = 0
+	if len(entry.Addenda05) > 0 {
+		entry.AddendaRecordIndicator = 1
+	}
+	entry.SetTraceNumber(bh.ODFIIdentification, seq)
+	batch.AddEntry(&entry)
+	if err := batch.Create(); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// ScheduleRepository stores the Schedules of a Service, see WithSchedules
+type ScheduleRepository interface {
+	// StoreSchedule stores a new schedule, ErrAlreadyExists is returned when its ID is taken
+	StoreSchedule(ctx context.Context, sched *Schedule) error
+	// UpdateSchedule replaces a stored schedule
+	UpdateSchedule(ctx context.Context, sched *Schedule) error
+	FindSchedule(ctx context.Context, id string) (*Schedule, error)
+	FindAllSchedules(ctx context.Context) []*Schedule
+	DeleteSchedule(ctx context.Context, id string) error
+}
+
+type scheduleRepositoryInMemory struct {
+	mtx       sync.RWMutex
+	schedules map[string]*Schedule
+}
+
+// NewScheduleRepositoryInMemory is an in memory storage repository for schedules
+func NewScheduleRepositoryInMemory() ScheduleRepository {
+	return &scheduleRepositoryInMemory{
+		schedules: make(map[string]*Schedule),
+	}
+}
+
+func (r *scheduleRepositoryInMemory) StoreSchedule(ctx context.Context, sched *Schedule) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.schedules[sched.ID]; ok {
+		return ErrAlreadyExists
+	}
+	copied := *sched
+	r.schedules[sched.ID] = &copied
+	return nil
+}
+
+func (r *scheduleRepositoryInMemory) UpdateSchedule(ctx context.Context, sched *Schedule) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err // the change was canceled while waiting for the lock
+	}
+	if _, ok := r.schedules[sched.ID]; !ok {
+		return ErrNotFound
+	}
+	copied := *sched
+	r.schedules[sched.ID] = &copied
+	return nil
+}
+
+func (r *scheduleRepositoryInMemory) FindSchedule(ctx context.Context, id string) (*Schedule, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	sched, ok := r.schedules[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *sched
+	return &copied, nil
+}
+
+func (r *scheduleRepositoryInMemory) FindAllSchedules(ctx context.Context) []*Schedule {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	out := make([]*Schedule, 0, len(r.schedules))
+	for _, sched := range r.schedules {
+		copied := *sched
+		out = append(out, &copied)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func (r *scheduleRepositoryInMemory) DeleteSchedule(ctx context.Context, id string) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.schedules[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.schedules, id)
+	return nil
+}
+
+// schedules are the recurring payments of a Service, see WithSchedules
+type schedules struct {
+	repo   ScheduleRepository
+	header ach.FileHeader
+
+	// mu is held while payments are added to a file so they're only made once
+	mu sync.Mutex
+}
+
+// WithSchedules has the Service store recurring payments in repo and add those due to a file for each
+// submission day with header, see MaterializeSchedules. Files are created with the ID
+// "schedules-YYYYMMDD" of their submission day and the next FileIDModifier of their origin and destination.
+func WithSchedules(repo ScheduleRepository, header ach.FileHeader) ServiceOption {
+	return func(s *service) {
+		s.schedules = &schedules{repo: repo, header: header}
+	}
+}
+
+// CreateSchedule stores a schedule after checking its payments can be made
+func (s *service) CreateSchedule(ctx context.Context, sched *Schedule) (_ string, err error) {
+	ctx, span := startSpan(ctx, "Service.CreateSchedule")
+	defer endSpan(span, &err)
+
+	if s.schedules == nil {
+		return "", errSchedulesDisabled
+	}
+	if sched.BatchHeader == nil || sched.EntryDetail == nil {
+		return "", invalid(errors.New("a schedule requires a batchHeader and entryDetail"))
+	}
+	start, _, err := sched.Recurrence.validate()
+	if err != nil {
+		return "", invalid(err)
+	}
+	if _, err := sched.batch(start, 1); err != nil {
+		return "", invalid(err)
+	}
+	if sched.ID == "" {
+		sched.ID = base.ID()
+	}
+	sched.Last = ""
+	span.SetAttribute("schedule.id", sched.ID)
+	if err := s.schedules.repo.StoreSchedule(ctx, sched); err != nil {
+		return "", err
+	}
+	return sched.ID, nil
+}
+
+// GetSchedule returns a stored schedule
+func (s *service) GetSchedule(ctx context.Context, id string) (*Schedule, error) {
+	if s.schedules == nil {
+		return nil, errSchedulesDisabled
+	}
+	return s.schedules.repo.FindSchedule(ctx, id)
+}
+
+// GetSchedules returns every stored schedule
+func (s *service) GetSchedules(ctx context.Context) ([]*Schedule, error) {
+	if s.schedules == nil {
+		return nil, errSchedulesDisabled
+	}
+	return s.schedules.repo.FindAllSchedules(ctx), nil
+}
+
+// DeleteSchedule stops a schedule's payments, those already added to a file are kept
+func (s *service) DeleteSchedule(ctx context.Context, id string) error {
+	if s.schedules == nil {
+		return errSchedulesDisabled
+	}
+	return s.schedules.repo.DeleteSchedule(ctx, id)
+}
+
+// scheduledPayment is a payment of a Schedule due on date
+type scheduledPayment struct {
+	schedule *Schedule
+	date     time.Time
+}
+
+// MaterializeSchedules adds a batch for each payment due by the banking day after the next submission day
+// as of now (see WithCutoffTimes) to the file for that submission day, and returns the file. Payments are
+// effective the banking day after they're submitted, so payments due on weekends and holidays are made the
+// next banking day. Each payment is made once: it's recorded as the schedule's Last payment once its file is
+// stored, so missed payments are caught up and MaterializeSchedules can run any number of times a day.
+// A nil file is returned when no payments are due.
+func (s *service) MaterializeSchedules(ctx context.Context, now time.Time) (_ *ach.File, err error) {
+	ctx, span := startSpan(ctx, "Service.MaterializeSchedules")
+	defer endSpan(span, &err)
+
+	if s.schedules == nil {
+		return nil, errSchedulesDisabled
+	}
+	s.schedules.mu.Lock()
+	defer s.schedules.mu.Unlock()
+
+	submission := s.nextSubmissionDay(now)
+	effective := ach.NextBankingDay(submission, false)
+	through := time.Date(effective.Year(), effective.Month(), effective.Day(), 0, 0, 0, 0, time.UTC)
+
+	var payments []scheduledPayment
+	for _, sched := range s.schedules.repo.FindAllSchedules(ctx) {
+		after := time.Time{}
+		if sched.Last != "" {
+			after, _ = time.Parse(scheduleDateFormat, sched.Last)
+		}
+		for _, date := range sched.Recurrence.occurrences(after, through) {
+			payments = append(payments, scheduledPayment{schedule: sched, date: date})
+		}
+	}
+	if len(payments) == 0 {
+		return nil, nil
+	}
+
+	addPayments := func(f *ach.File) error {
+		for _, payment := range payments {
+			batch, err := payment.schedule.batch(effective, len(f.Batches)+1)
+			if err != nil {
+				return fmt.Errorf("schedule %s: %v", payment.schedule.ID, err)
+			}
+			f.AddBatch(batch)
+		}
+		return f.Create()
+	}
+	fileID := "schedules-" + submission.Format("20060102")
+	span.SetAttribute("file.id", fileID)
+	f, err := s.store.FindFile(ctx, fileID)
+	if err == nil && f != nil {
+		f, err = s.store.UpdateFile(ctx, fileID, addPayments)
+	} else {
+		f = ach.NewFile()
+		f.ID = fileID
+		f.SetHeader(s.schedules.header)
+		f.Header.ID = fileID
+		f.Header.FileCreationDate = submission.Format("060102") // YYMMDD
+		f.Header.FileIDModifier = ""
+		if err = s.AssignFileIDModifier(ctx, f); err == nil {
+			if err = addPayments(f); err == nil {
+				err = s.store.StoreFile(ctx, f)
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// payments are in order of their due date for each schedule
+	updated := make(map[string]*Schedule)
+	for _, payment := range payments {
+		payment.schedule.Last = payment.date.Format(scheduleDateFormat)
+		updated[payment.schedule.ID] = payment.schedule
+	}
+	for _, sched := range updated {
+		// schedules deleted since are left deleted
+		if err := s.schedules.repo.UpdateSchedule(ctx, sched); err != nil && err != ErrNotFound {
+			return f, err
+		}
+	}
+	return f, nil
+}
+
+// StartScheduler runs MaterializeSchedules on svc every interval until the returned func is called, so each
+// submission day's file of scheduled payments is ready before its cutoff.
+func StartScheduler(svc Service, interval time.Duration, logger log.Logger) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case now := <-t.C:
+				f, err := svc.MaterializeSchedules(ctx, now)
+				if err != nil || f != nil {
+					var fileID string
+					if f != nil {
+						fileID = f.ID
+					}
+					logEvent(logger, "schedules", "materializeSchedules", err, "fileID", fileID)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return cancel
+}
+
+type createScheduleRequest struct {
+	schedule  *Schedule
+	requestID string
+}
+
+type createScheduleResponse struct {
+	ID  string `json:"id"`
+	Err error  `json:"error"`
+}
+
+func (r createScheduleResponse) error() error { return r.Err }
+
+func createScheduleEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(createScheduleRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return createScheduleResponse{
+				Err: err,
+			}, err
+		}
+
+		id, err := s.CreateSchedule(ctx, req.schedule)
+		logEvent(logger, "schedules", "createSchedule", err, "requestID", req.requestID, "scheduleID", id)
+
+		return createScheduleResponse{
+			ID:  id,
+			Err: err,
+		}, nil
+	}
+}
+
+func decodeCreateScheduleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := createScheduleRequest{
+		schedule:  &Schedule{},
+		requestID: moovhttp.GetRequestID(r),
+	}
+	if err := json.NewDecoder(r.Body).Decode(req.schedule); err != nil {
+		return nil, invalid(fmt.Errorf("problem reading schedule: %v", err))
+	}
+	return req, nil
+}
+
+type getSchedulesRequest struct {
+	requestID string
+}
+
+type getSchedulesResponse struct {
+	Schedules []*Schedule `json:"schedules"`
+	Err       error       `json:"error"`
+}
+
+func (r getSchedulesResponse) count() int { return len(r.Schedules) }
+
+func (r getSchedulesResponse) error() error { return r.Err }
+
+func getSchedulesEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(getSchedulesRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return getSchedulesResponse{
+				Err: err,
+			}, err
+		}
+
+		schedules, err := s.GetSchedules(ctx)
+		logEvent(logger, "schedules", "getSchedules", err, "requestID", req.requestID, "schedules", len(schedules))
+
+		return getSchedulesResponse{
+			Schedules: schedules,
+			Err:       err,
+		}, nil
+	}
+}
+
+func decodeGetSchedulesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return getSchedulesRequest{
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
+
+type scheduleRequest struct {
+	scheduleID string
+	requestID  string
+}
+
+type getScheduleResponse struct {
+	*Schedule
+	Err error `json:"error"`
+}
+
+func (r getScheduleResponse) error() error { return r.Err }
+
+func getScheduleEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(scheduleRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return getScheduleResponse{
+				Err: err,
+			}, err
+		}
+
+		sched, err := s.GetSchedule(ctx, req.scheduleID)
+		logEvent(logger, "schedules", "getSchedule", err, "requestID", req.requestID, "scheduleID", req.scheduleID)
+
+		return getScheduleResponse{
+			Schedule: sched,
+			Err:      err,
+		}, nil
+	}
+}
+
+type deleteScheduleResponse struct {
+	Err error `json:"error"`
+}
+
+func (r deleteScheduleResponse) error() error { return r.Err }
+
+func deleteScheduleEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(scheduleRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return deleteScheduleResponse{
+				Err: err,
+			}, err
+		}
+
+		err := s.DeleteSchedule(ctx, req.scheduleID)
+		logEvent(logger, "schedules", "deleteSchedule", err, "requestID", req.requestID, "scheduleID", req.scheduleID)
+
+		return deleteScheduleResponse{
+			Err: err,
+		}, nil
+	}
+}
+
+func decodeScheduleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, ok := mux.Vars(r)["scheduleID"]
+	if !ok || strings.TrimSpace(id) == "" {
+		return nil, ErrBadRouting
+	}
+	return scheduleRequest{
+		scheduleID: id,
+		requestID:  moovhttp.GetRequestID(r),
+	}, nil
+}
@@ -0,0 +1,199 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+func mockSchedule(id string, r Recurrence) *Schedule {
+	return &Schedule{
+		ID:          id,
+		BatchHeader: mockBatchHeaderWeb(),
+		EntryDetail: mockWEBEntryDetail(),
+		Recurrence:  r,
+	}
+}
+
+func scheduleDates(days []time.Time) string {
+	var out []string
+	for _, day := range days {
+		out = append(out, day.Format(scheduleDateFormat))
+	}
+	return strings.Join(out, ",")
+}
+
+func TestRecurrence__occurrences(t *testing.T) {
+	day := func(v string) time.Time {
+		d, _ := time.Parse(scheduleDateFormat, v)
+		return d
+	}
+
+	weekly := Recurrence{Frequency: ScheduleWeekly, Interval: 2, Start: "2026-10-02", End: "2026-11-13"}
+	if v := scheduleDates(weekly.occurrences(day("2026-10-02"), day("2026-12-31"))); v != "2026-10-16,2026-10-30,2026-11-13" {
+		t.Errorf("weekly: %s", v)
+	}
+
+	monthly := Recurrence{Frequency: ScheduleMonthly, Start: "2026-01-31"}
+	if v := scheduleDates(monthly.occurrences(time.Time{}, day("2026-04-30"))); v != "2026-01-31,2026-02-28,2026-03-31,2026-04-30" {
+		t.Errorf("monthly: %s", v)
+	}
+
+	cases := []Recurrence{
+		{Frequency: "daily", Start: "2026-01-01"},
+		{Frequency: ScheduleWeekly, Start: "01/01/2026"},
+		{Frequency: ScheduleWeekly, Start: "2026-01-01", Interval: -1},
+		{Frequency: ScheduleWeekly, Start: "2026-01-01", End: "2025-12-31"},
+	}
+	for _, r := range cases {
+		if _, _, err := r.validate(); err == nil {
+			t.Errorf("expected error: %#v", r)
+		}
+	}
+}
+
+func TestMaterializeSchedules(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo, WithSchedules(NewScheduleRepositoryInMemory(), *mockFileHeader()), WithCutoffTimes(time.UTC, 16*time.Hour))
+
+	// weekly from a Saturday and monthly from Monday
+	if _, err := svc.CreateSchedule(ctx, mockSchedule("weekly", Recurrence{Frequency: ScheduleWeekly, Start: "2026-10-10"})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.CreateSchedule(ctx, mockSchedule("monthly", Recurrence{Frequency: ScheduleMonthly, Start: "2026-10-19"})); err != nil {
+		t.Fatal(err)
+	}
+
+	// Friday's file has the payments due through Monday
+	friday := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+	f, err := svc.MaterializeSchedules(ctx, friday)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f == nil || f.ID != "schedules-20261016" || len(f.Batches) != 3 || f.Header.FileIDModifier != "A" {
+		t.Fatalf("unexpected file: %#v", f)
+	}
+	for i, batch := range f.Batches {
+		if date := batch.GetHeader().EffectiveEntryDate; date != "261019" {
+			t.Errorf("batch #%d EffectiveEntryDate=%s", i, date)
+		}
+	}
+	if err := f.Validate(); err != nil {
+		t.Error(err)
+	}
+	if sched, _ := svc.GetSchedule(ctx, "weekly"); sched.Last != "2026-10-17" {
+		t.Errorf("weekly Last=%s", sched.Last)
+	}
+
+	// payments are made once
+	if f, err := svc.MaterializeSchedules(ctx, friday); err != nil || f != nil {
+		t.Errorf("unexpected file: %v", err)
+	}
+
+	// schedules created later are added to the day's file
+	if _, err := svc.CreateSchedule(ctx, mockSchedule("later", Recurrence{Frequency: ScheduleWeekly, Start: "2026-10-19"})); err != nil {
+		t.Fatal(err)
+	}
+	if f, err := svc.MaterializeSchedules(ctx, friday.Add(time.Hour)); err != nil || f == nil || len(f.Batches) != 4 {
+		t.Errorf("unexpected file: %v", err)
+	}
+	if found, _ := repo.FindFile(ctx, "schedules-20261016"); len(found.Batches) != 4 || found.Control.BatchCount != 4 {
+		t.Errorf("stored file wasn't updated")
+	}
+
+	// nothing is due on Monday for Tuesday
+	monday := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	if f, err := svc.MaterializeSchedules(ctx, monday); err != nil || f != nil {
+		t.Errorf("unexpected file: %v", err)
+	}
+}
+
+func TestSchedules__disabled(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewRepositoryInMemory(testTTLDuration, nil))
+	if _, err := svc.CreateSchedule(ctx, mockSchedule("", Recurrence{})); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := svc.MaterializeSchedules(ctx, time.Now()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSchedules__endpoints(t *testing.T) {
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo, WithSchedules(NewScheduleRepositoryInMemory(), *mockFileHeader()))
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	serve := func(method, path string, body []byte) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewReader(body)))
+		w.Flush()
+		return w
+	}
+
+	bs, _ := json.Marshal(mockSchedule("", Recurrence{Frequency: ScheduleMonthly, Start: "2026-11-01"}))
+	w := serve("POST", "/schedules", bs)
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil || created.ID == "" {
+		t.Fatalf("unexpected response: %v", err)
+	}
+
+	w = serve("GET", "/schedules", nil)
+	if w.Code != http.StatusOK || w.Header().Get("X-Total-Count") != "1" {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	w = serve("GET", "/schedules/"+created.ID, nil)
+	var sched Schedule
+	if err := json.NewDecoder(w.Body).Decode(&sched); err != nil || sched.Recurrence.Start != "2026-11-01" || sched.EntryDetail == nil {
+		t.Errorf("unexpected schedule: %v: %#v", err, sched)
+	}
+	if w = serve("DELETE", "/schedules/"+created.ID, nil); w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = serve("GET", "/schedules/"+created.ID, nil); w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	// schedules whose payments can't be made are rejected
+	invalid := mockSchedule("", Recurrence{Frequency: ScheduleWeekly, Start: "2026-11-01"})
+	invalid.EntryDetail.DFIAccountNumber = ""
+	bs, _ = json.Marshal(invalid)
+	if w = serve("POST", "/schedules", bs); w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = serve("POST", "/schedules", []byte("{")); w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
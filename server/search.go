@@ -0,0 +1,233 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/ach"
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+)
+
+var (
+	errInvalidSearch = invalid(errors.New("invalid entry search"))
+)
+
+// EntrySearch holds the fields to match entries on. Empty fields match every entry.
+type EntrySearch struct {
+	// RoutingNumber is the 8 digit RDFIIdentification or 9 digit routing number of the entry
+	RoutingNumber string
+	// Amount of the entry, only used when HasAmount is set
+	Amount    int
+	HasAmount bool
+	// TraceNumber of the entry
+	TraceNumber string
+	// IndividualName matches the case insensitive substring of an entry's IndividualName,
+	// or the Name of an IAT entry's Addenda10
+	IndividualName string
+	// IdentificationNumber of the entry, or the ReceiverIDNumber of an IAT entry's Addenda15
+	IdentificationNumber string
+
+	// Masked returns matching entries with their personal data masked by ach.File.MaskSensitiveData.
+	// Entries are still matched on their unmasked values.
+	Masked bool
+}
+
+func (search EntrySearch) empty() bool {
+	return search.RoutingNumber == "" && !search.HasAmount && search.TraceNumber == "" &&
+		search.IndividualName == "" && search.IdentificationNumber == ""
+}
+
+func (search EntrySearch) matches(rdfi, checkDigit string, amount int, traceNumber, name, identification string) bool {
+	if search.RoutingNumber != "" && search.RoutingNumber != rdfi && search.RoutingNumber != rdfi+checkDigit {
+		return false
+	}
+	if search.HasAmount && search.Amount != amount {
+		return false
+	}
+	if search.TraceNumber != "" && search.TraceNumber != strings.TrimSpace(traceNumber) {
+		return false
+	}
+	if search.IndividualName != "" && !strings.Contains(strings.ToLower(name), strings.ToLower(search.IndividualName)) {
+		return false
+	}
+	if search.IdentificationNumber != "" && search.IdentificationNumber != strings.TrimSpace(identification) {
+		return false
+	}
+	return true
+}
+
+// EntryMatch is an entry found by SearchEntries along with where it's stored
+type EntryMatch struct {
+	FileID      string              `json:"fileID"`
+	BatchID     string              `json:"batchID"`
+	BatchNumber int                 `json:"batchNumber"`
+	Entry       *ach.EntryDetail    `json:"entryDetail,omitempty"`
+	IATEntry    *ach.IATEntryDetail `json:"IATEntryDetail,omitempty"`
+}
+
+func (s *service) SearchEntries(ctx context.Context, search EntrySearch) []*EntryMatch {
+	files := s.store.FindAllFiles(ctx)
+	sort.Slice(files, func(i, j int) bool { return files[i].ID < files[j].ID })
+
+	var matches []*EntryMatch
+	for _, f := range files {
+		var found []*EntryMatch
+		f.Visit(ach.FileVisitor{
+			Entry: func(batch ach.Batcher, entry *ach.EntryDetail) error {
+				if search.matches(entry.RDFIIdentification, entry.CheckDigit, entry.Amount, entry.TraceNumber, entry.IndividualName, entry.IdentificationNumber) {
+					found = append(found, &EntryMatch{
+						FileID:      f.ID,
+						BatchID:     batch.ID(),
+						BatchNumber: batch.GetHeader().BatchNumber,
+						Entry:       entry,
+					})
+				}
+				return nil
+			},
+			IATEntry: func(iatBatch *ach.IATBatch, entry *ach.IATEntryDetail) error {
+				var name, identification string
+				if entry.Addenda10 != nil {
+					name = entry.Addenda10.Name
+				}
+				if entry.Addenda15 != nil {
+					identification = entry.Addenda15.ReceiverIDNumber
+				}
+				if search.matches(entry.RDFIIdentification, entry.CheckDigit, entry.Amount, entry.TraceNumber, name, identification) {
+					found = append(found, &EntryMatch{
+						FileID:      f.ID,
+						BatchID:     iatBatch.ID,
+						BatchNumber: iatBatch.GetHeader().BatchNumber,
+						IATEntry:    entry,
+					})
+				}
+				return nil
+			},
+		})
+		if search.Masked && len(found) > 0 {
+			if err := maskEntryMatches(f, found); err != nil {
+				continue // never return the unmasked entries
+			}
+		}
+		matches = append(matches, found...)
+	}
+	return matches
+}
+
+// maskEntryMatches replaces the entries of matches, which were all found in f, with their masked copies
+func maskEntryMatches(f *ach.File, matches []*EntryMatch) error {
+	masked, err := f.MaskSensitiveData(nil)
+	if err != nil {
+		return err
+	}
+	entries := make(map[*ach.EntryDetail]*ach.EntryDetail)
+	for i := range f.Batches {
+		for j, entry := range f.Batches[i].GetEntries() {
+			entries[entry] = masked.Batches[i].GetEntries()[j]
+		}
+	}
+	iatEntries := make(map[*ach.IATEntryDetail]*ach.IATEntryDetail)
+	for i := range f.IATBatches {
+		for j, entry := range f.IATBatches[i].Entries {
+			iatEntries[entry] = masked.IATBatches[i].Entries[j]
+		}
+	}
+	for _, m := range matches {
+		if m.Entry != nil {
+			m.Entry = entries[m.Entry]
+		}
+		if m.IATEntry != nil {
+			m.IATEntry = iatEntries[m.IATEntry]
+		}
+	}
+	return nil
+}
+
+type searchEntriesRequest struct {
+	search EntrySearch
+
+	requestID string
+}
+
+type searchEntriesResponse struct {
+	Entries []*EntryMatch `json:"entries"`
+	Err     error         `json:"error"`
+}
+
+func (r searchEntriesResponse) count() int { return len(r.Entries) }
+
+func (r searchEntriesResponse) error() error { return r.Err }
+
+func searchEntriesEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(searchEntriesRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return searchEntriesResponse{
+				Err: err,
+			}, err
+		}
+
+		entries := s.SearchEntries(ctx, req.search)
+
+		logEvent(logger, "files", "searchEntries", nil, "requestID", req.requestID, "matches", len(entries))
+
+		return searchEntriesResponse{
+			Entries: entries,
+		}, nil
+	}
+}
+
+func decodeSearchEntriesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	req := searchEntriesRequest{
+		search: EntrySearch{
+			RoutingNumber:        strings.TrimSpace(q.Get("routingNumber")),
+			TraceNumber:          strings.TrimSpace(q.Get("traceNumber")),
+			IndividualName:       strings.TrimSpace(q.Get("individualName")),
+			IdentificationNumber: strings.TrimSpace(q.Get("identificationNumber")),
+		},
+		requestID: moovhttp.GetRequestID(r),
+	}
+	if v := q.Get("amount"); v != "" {
+		amount, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: amount %q is not a number of cents", errInvalidSearch, v)
+		}
+		req.search.Amount = amount
+		req.search.HasAmount = true
+	}
+	if req.search.empty() {
+		return nil, fmt.Errorf("%w: at least one search parameter is required", errInvalidSearch)
+	}
+	masked, err := parseMasked(r)
+	if err != nil {
+		return nil, err
+	}
+	req.search.Masked = masked
+	return req, nil
+}
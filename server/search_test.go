@@ -0,0 +1,125 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestSearch__SearchEntries(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo)
+
+	bs, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, _ := ach.FileFromJSON(bs)
+	repo.StoreFile(ctx, file)
+
+	fd, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", "20180716-IAT-A17-A18.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	iatFile, err := ach.NewReader(bytes.NewReader(fd)).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	iatFile.ID = "iat"
+	repo.StoreFile(ctx, &iatFile)
+
+	cases := []struct {
+		search   EntrySearch
+		expected int
+	}{
+		{EntrySearch{RoutingNumber: "23138010"}, 1},
+		{EntrySearch{RoutingNumber: "231380104"}, 1},
+		{EntrySearch{Amount: 100000, HasAmount: true}, 2},
+		{EntrySearch{Amount: 100001, HasAmount: true}, 0},
+		{EntrySearch{TraceNumber: "121042880000001"}, 1},
+		{EntrySearch{IndividualName: "steven"}, 1},
+		{EntrySearch{IdentificationNumber: "#83738AB#"}, 1},
+		{EntrySearch{IndividualName: "BEK Enterprises"}, 2},
+		{EntrySearch{IdentificationNumber: "987465493213987"}, 2},
+		{EntrySearch{RoutingNumber: "23138010", IndividualName: "other"}, 0},
+	}
+	for i, tc := range cases {
+		if matches := svc.SearchEntries(ctx, tc.search); len(matches) != tc.expected {
+			t.Errorf("case #%d: got %d matches, expected %d", i, len(matches), tc.expected)
+		}
+	}
+
+	matches := svc.SearchEntries(ctx, EntrySearch{TraceNumber: "121042880000001"})
+	if m := matches[0]; m.FileID != file.ID || m.BatchNumber != 1 || m.Entry == nil || m.IATEntry != nil {
+		t.Errorf("unexpected match: %#v", m)
+	}
+}
+
+func TestSearch__searchEntriesEndpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	bs, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, _ := ach.FileFromJSON(bs)
+	repo.StoreFile(ctx, file)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/files/search?routingNumber=231380104&amount=100000", nil))
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if v := w.Header().Get("X-Total-Count"); v != "1" {
+		t.Errorf("X-Total-Count: %s", v)
+	}
+	var resp searchEntriesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Entries) != 1 || resp.Entries[0].FileID != "adam-01" || resp.Entries[0].Entry.TraceNumber != "121042880000001" {
+		t.Errorf("unexpected entries: %#v", resp.Entries)
+	}
+
+	for _, query := range []string{"", "?amount=ten"} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/files/search"+query, nil))
+		w.Flush()
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%q: bogus HTTP status: %d: %s", query, w.Code, w.Body.String())
+		}
+	}
+}
@@ -19,65 +19,341 @@ package server
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/routing"
+	"github.com/moov-io/ach/screening"
 	"github.com/moov-io/base"
 )
 
-var (
-	ErrNotFound      = errors.New("not found")
-	ErrAlreadyExists = errors.New("already exists")
-)
-
 // Service is a REST interface for interacting with ACH file structures
-// TODO: Add ctx to function parameters to pass the client security token
+//
+// Every method takes the context of the request it serves. Validating and building files
+// stop when it's canceled or its deadline passes, returning the context's error.
 type Service interface {
 	// CreateFile creates a new ach file record and returns a resource ID
-	CreateFile(f *ach.FileHeader) (string, error)
+	CreateFile(ctx context.Context, f *ach.FileHeader) (string, error)
+	// AssignFileIDModifier sets a file's FileIDModifier to the next one for its origin, destination and creation date
+	AssignFileIDModifier(ctx context.Context, f *ach.File) error
 	// AddFile retrieves a file based on the File id
-	GetFile(id string) (*ach.File, error)
+	GetFile(ctx context.Context, id string) (*ach.File, error)
 	// GetFiles retrieves all files accessible from the client.
-	GetFiles() []*ach.File
-	// DeleteFile takes a file resource ID and deletes it from the store
-	DeleteFile(id string) error
+	GetFiles(ctx context.Context) []*ach.File
+	// FindFiles returns one page of the files matching filter along with how many files matched in total
+	FindFiles(ctx context.Context, filter FileFilter) ([]*ach.File, int)
+	// FileRevision returns the revision of a file, which increases with every change made to it
+	FileRevision(ctx context.Context, id string) (int, error)
+	// DeleteFile takes a file resource ID and deletes it from the store, it can be restored until it's purged
+	DeleteFile(ctx context.Context, id string, opts ...ChangeOption) error
+	// RestoreFile undoes the deletion of a file until it's purged, see StartPurge
+	RestoreFile(ctx context.Context, id string) (*ach.File, error)
+	// GetFileStatus returns where a file is in its lifecycle and when it reached each status
+	GetFileStatus(ctx context.Context, id string) (*FileLifecycle, error)
+	// SetFileStatus moves a file forward in its lifecycle, files are validated and built by the service
+	SetFileStatus(ctx context.Context, id string, status FileStatus) (*FileLifecycle, error)
+	// PatchFileHeader updates the non-nil fields of patch on a file's FileHeader
+	PatchFileHeader(ctx context.Context, id string, patch *FileHeaderPatch, opts ...ChangeOption) (*ach.File, error)
 	// GetFileContents creates a valid plaintext file in memory assuming it has a FileHeader and at least one Batch record.
-	GetFileContents(id string) (io.Reader, error)
+	// Only approved and released files can be read with WithApprovals.
+	GetFileContents(ctx context.Context, id string) (io.Reader, error)
 	// ValidateFile
-	ValidateFile(id string, opts *ach.ValidateOpts) error
+	ValidateFile(ctx context.Context, id string, opts *ach.ValidateOpts) error
+	// FileWarnings returns the unusual values of a file which don't fail validation, see ach.File.Warnings
+	FileWarnings(ctx context.Context, id string) ([]ach.Warning, error)
+	// BuildFile tabulates the controls, trace numbers and addenda counts of a stored file and its batches with Create() and validates the result
+	BuildFile(ctx context.Context, id string, opts ...ChangeOption) (*ach.File, error)
 	// BalanceFile will apply a given offset record to the file
-	BalanceFile(fileID string, off *ach.Offset) (*ach.File, error)
+	BalanceFile(ctx context.Context, fileID string, off *ach.Offset) (*ach.File, error)
 	// SegmentFile segments an ach file
-	SegmentFile(id string, opts *ach.SegmentFileConfiguration) (*ach.File, *ach.File, error)
+	SegmentFile(ctx context.Context, id string, opts *ach.SegmentFileConfiguration) (*ach.File, *ach.File, error)
 	// FlattenBatches will minimize the ach.Batch objects in a file by consolidating EntryDetails under distinct batch headers
-	FlattenBatches(id string) (*ach.File, error)
+	FlattenBatches(ctx context.Context, id string) (*ach.File, error)
+	// SplitFileByEffectiveDate returns a file for each effective entry date of a file's batches, see ach.File.SplitByEffectiveDate
+	SplitFileByEffectiveDate(ctx context.Context, id string) ([]*ach.File, error)
+	// CloneFile stores a copy of a file with new IDs, creation date and time, FileIDModifier and TraceNumbers, effective on effectiveEntryDate
+	CloneFile(ctx context.Context, id string, effectiveEntryDate ach.Date) (*ach.File, error)
+	// LintFile returns why a file which isn't stored would fail validation or building, without storing it
+	LintFile(ctx context.Context, f *ach.File) error
 	// CreateBatch creates a new batch within and ach file and returns its resource ID
-	CreateBatch(fileID string, bh ach.Batcher) (string, error)
+	CreateBatch(ctx context.Context, fileID string, bh ach.Batcher, opts ...ChangeOption) (string, error)
 	// GetBatch retrieves a batch based oin the file id and batch id
-	GetBatch(fileID string, batchID string) (ach.Batcher, error)
+	GetBatch(ctx context.Context, fileID string, batchID string) (ach.Batcher, error)
 	// GetBatches retrieves all batches associated with the file id.
-	GetBatches(fileID string) []ach.Batcher
+	GetBatches(ctx context.Context, fileID string) []ach.Batcher
+	// ReplaceBatch replaces a batch of a file in place, keeping its position and batch number, and returns the replacement after Create()
+	ReplaceBatch(ctx context.Context, fileID string, batchID string, batch ach.Batcher, opts ...ChangeOption) (ach.Batcher, error)
 	// DeleteBatch takes a fileID and BatchID and removes the batch from the file
-	DeleteBatch(fileID string, batchID string) error
+	DeleteBatch(ctx context.Context, fileID string, batchID string, opts ...ChangeOption) error
+	// GetEntry retrieves an entry by its Entry Detail Sequence Number (last seven digits of TraceNumber) within a batch
+	GetEntry(ctx context.Context, fileID string, batchID string, seq int) (*ach.EntryDetail, error)
+	// GetEntries retrieves the entries of a batch
+	GetEntries(ctx context.Context, fileID string, batchID string) ([]*ach.EntryDetail, error)
+	// CreateEntry adds an entry to a batch, numbering it after the batch's last entry when it has no TraceNumber, and rebuilds the batch control
+	CreateEntry(ctx context.Context, fileID string, batchID string, entry *ach.EntryDetail, opts ...ChangeOption) (*ach.EntryDetail, error)
+	// ReplaceEntry replaces an entry of a batch, keeping its TraceNumber, and rebuilds the batch control
+	ReplaceEntry(ctx context.Context, fileID string, batchID string, seq int, entry *ach.EntryDetail, opts ...ChangeOption) (*ach.EntryDetail, error)
+	// DeleteEntry removes an entry from a batch and rebuilds the batch control
+	DeleteEntry(ctx context.Context, fileID string, batchID string, seq int, opts ...ChangeOption) error
+	// CreateAddenda05 appends an Addenda05 onto an entry and returns its resource ID
+	CreateAddenda05(ctx context.Context, fileID string, batchID string, seq int, addenda05 *ach.Addenda05, opts ...ChangeOption) (string, error)
+	// DeleteAddenda05 removes an Addenda05 from an entry
+	DeleteAddenda05(ctx context.Context, fileID string, batchID string, seq int, addendaID string, opts ...ChangeOption) error
+	// GetFileVersions returns the prior versions of a file, oldest first
+	GetFileVersions(ctx context.Context, fileID string) ([]*FileVersion, error)
+	// RollbackFile replaces a file with one of its prior versions
+	RollbackFile(ctx context.Context, fileID string, version int) (*ach.File, error)
+	// GetFileAudit returns who changed a file, when and how, oldest first, see WithAuditLog
+	GetFileAudit(ctx context.Context, id string) ([]*AuditEntry, error)
+	// GetApproval returns where a file is in the dual-approval workflow, see WithApprovals
+	GetApproval(ctx context.Context, fileID string) (*Approval, error)
+	// SubmitFile asks for a draft file to be approved
+	SubmitFile(ctx context.Context, fileID string) (*Approval, error)
+	// ApproveFile approves a submitted file, which can't be done by who submitted it
+	ApproveFile(ctx context.Context, fileID string) (*Approval, error)
+	// RejectFile returns a submitted or approved file to draft, which can't be done by who submitted it
+	RejectFile(ctx context.Context, fileID string, reason string) (*Approval, error)
+	// ReleaseFile records an approved file was sent on to the ODFI
+	ReleaseFile(ctx context.Context, fileID string) (*Approval, error)
+	// FileStats returns the entry counts, totals and addenda counts of a file
+	FileStats(ctx context.Context, id string) (*FileStats, error)
+	// AggregateStats totals the entries of files created on or after since by SEC code, suppressing SEC codes with too few entries
+	AggregateStats(ctx context.Context, since time.Time) *AggregateStats
+	// SearchEntries finds entries across all stored files which match every non-empty field of search
+	SearchEntries(ctx context.Context, search EntrySearch) []*EntryMatch
+	// MatchAcknowledgments pairs the ACK and ATX entries of stored files with the CCD and CTX credits they acknowledge
+	MatchAcknowledgments(ctx context.Context) *AcknowledgmentReport
+	// Reconcile links the returns and corrections of received files to the entries of originated files
+	Reconcile(ctx context.Context, originals, received []string) (*Reconciliation, error)
+	// SweepFiles re-validates every stored file as of now and returns those expected to be rejected at the next cutoff
+	SweepFiles(ctx context.Context, now time.Time) []*ValidationAlert
+	// ValidationAlerts returns the files flagged by the most recent SweepFiles
+	ValidationAlerts(ctx context.Context) []*ValidationAlert
+	// CheckExposure returns an *ExposureLimitError if f would take a company over its limit of WithExposureLimits
+	CheckExposure(ctx context.Context, f *ach.File) error
+	// RiskReport flags the entries of a file to new receivers, with amounts far larger than their company's
+	// history or to accounts repeated within the file, and totals its Same Day batches
+	RiskReport(ctx context.Context, id string, opts RiskOptions) (*RiskReport, error)
+	// SettlementCalendar groups the entries of stored files by the banking day between from and to (inclusive) they're expected to settle on
+	SettlementCalendar(ctx context.Context, from, to time.Time) *SettlementCalendar
+
+	// CreateSchedule stores a recurring payment and returns its ID, see WithSchedules
+	CreateSchedule(ctx context.Context, sched *Schedule) (string, error)
+	// GetSchedule returns a stored recurring payment
+	GetSchedule(ctx context.Context, id string) (*Schedule, error)
+	// GetSchedules returns every stored recurring payment
+	GetSchedules(ctx context.Context) ([]*Schedule, error)
+	// DeleteSchedule stops a recurring payment
+	DeleteSchedule(ctx context.Context, id string) error
+	// MaterializeSchedules adds the recurring payments due by the next submission day after now to its file and returns the file
+	MaterializeSchedules(ctx context.Context, now time.Time) (*ach.File, error)
 }
 
 // service a concrete implementation of the service.
 type service struct {
 	store Repository
+
+	// strict has every file validated with ach.StrictNACHA() regardless of requested ValidateOpts
+	strict bool
+
+	// events receives FileValidated events, nil when events aren't published
+	events EventPublisher
+
+	// rdfis has every validated file's RDFIs checked against it, nil when they aren't checked
+	rdfis routing.Directory
+
+	// screener checks the parties of every validated file's IAT entries, nil when they aren't screened
+	screener screening.Screener
+
+	// policy is checked for every validated file, nil when there's no policy
+	policy *ach.Policy
+
+	// tokenizer detokenizes the account numbers of files as their contents are rendered, nil when they aren't tokenized
+	tokenizer Tokenizer
+
+	// exposure limits what companies originate in the files created and validated, nil without limits
+	exposure *exposureLimits
+
+	// offsets are appended to the batches of their company, keyed by Company Identification, when files are built
+	offsets map[string]ach.Offset
+
+	// schedules are recurring payments added to a file for each submission day, nil without WithSchedules
+	schedules *schedules
+
+	// audit has the changes made to files, nil without WithAuditLog
+	audit AuditLog
+
+	// approvals holds where each file is in the dual-approval workflow, nil without WithApprovals
+	approvals *approvals
+
+	// statsMinEntries is how many entries an SEC code needs to be included in AggregateStats
+	statsMinEntries int
+
+	// cutoffs are the times of day, sorted and in cutoffLocation, files are submitted to the ODFI
+	cutoffs        []time.Duration
+	cutoffLocation *time.Location
+
+	alertsMu sync.Mutex
+	alerts   map[string]*ValidationAlert
 }
 
 // NewService creates a new concrete service
-func NewService(r Repository) Service {
-	return &service{
-		store: r,
+func NewService(r Repository, opts ...ServiceOption) Service {
+	s := &service{
+		store:           r,
+		statsMinEntries: DefaultStatsMinEntries,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ChangeOption sets a condition on a change the Service makes to a stored file
+type ChangeOption func(*changeOptions)
+
+type changeOptions struct {
+	// revision the file is expected to be at, zero when any revision is accepted
+	revision int
+}
+
+// IfRevision has a change fail with ErrPreconditionFailed unless the file is still at revision, so
+// changes based on an outdated copy of a file don't overwrite changes made since.
+func IfRevision(revision int) ChangeOption {
+	return func(o *changeOptions) {
+		o.revision = revision
+	}
+}
+
+func readChangeOptions(opts []ChangeOption) changeOptions {
+	var o changeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// ServiceOption configures a Service created by NewService
+type ServiceOption func(*service)
+
+// WithStrictNACHA has the Service validate every file with ach.StrictNACHA(), ignoring
+// any ValidateOpts given with a request.
+func WithStrictNACHA() ServiceOption {
+	return func(s *service) {
+		s.strict = true
+	}
+}
+
+// WithEventPublisher has the Service publish a FileValidated event with events each time a
+// file is validated. Wrap the Repository with NewEventRepository for FileCreated and FileDeleted events.
+func WithEventPublisher(events EventPublisher) ServiceOption {
+	return func(s *service) {
+		s.events = events
+	}
+}
+
+// WithRDFIDirectory has the Service reject files with entries for RDFIs which aren't participants of dir
+// or don't receive the SEC code of their batch each time it validates a file. See ach.File.ValidateRDFIs.
+func WithRDFIDirectory(dir routing.Directory) ServiceOption {
+	return func(s *service) {
+		s.rdfis = dir
+	}
+}
+
+// WithIATScreener has the Service reject files with IAT entries whose receiver, originator or financial
+// institutions are blocked by s each time it validates a file. See ach.File.ScreenIATEntries.
+func WithIATScreener(s screening.Screener) ServiceOption {
+	return func(svc *service) {
+		svc.screener = s
+	}
+}
+
+// WithPolicy has the Service reject files which p doesn't allow each time it validates a file. The
+// other stored files count towards the daily limits of p. See ach.Policy.Check.
+func WithPolicy(p *ach.Policy) ServiceOption {
+	return func(s *service) {
+		s.policy = p
 	}
 }
 
-// CreateFile add a file to storage
+// WithOffsets has the Service append an offset entry to each batch of a company in offsets, keyed by
+// Company Identification, when it builds a file. The offset entry balances the batch's debits or credits
+// against the company's settlement account, see ach.Batch.WithOffset, and is replaced each time the file
+// is built. Batches of other companies are built as they are.
+func WithOffsets(offsets map[string]ach.Offset) ServiceOption {
+	return func(s *service) {
+		if len(offsets) > 0 {
+			s.offsets = offsets
+		}
+	}
+}
+
+// validateFile validates f with opts, or with ach.StrictNACHA() when the Service is strict.
+// ctx's error is returned once ctx is done, validation stops between batches and between checks.
+func (s *service) validateFile(ctx context.Context, f *ach.File, opts *ach.ValidateOpts) error {
+	if s.strict {
+		opts = ach.StrictNACHA()
+	}
+	var err error
+	if opts == nil {
+		err = f.ValidateContext(ctx)
+	} else {
+		err = f.ValidateWithContext(ctx, opts)
+	}
+	if err != nil {
+		return err
+	}
+	if s.rdfis != nil {
+		if err := f.ValidateRDFIs(ctx, s.rdfis); err != nil {
+			return err
+		}
+	}
+	if s.screener != nil {
+		if err := f.ScreenIATEntries(ctx, s.screener); err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if s.policy != nil {
+		if err := s.policy.Check(f, s.otherFiles(ctx, f)...); err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.CheckExposure(ctx, f)
+}
+
+// otherFiles returns every stored file other than f
+func (s *service) otherFiles(ctx context.Context, f *ach.File) []*ach.File {
+	var others []*ach.File
+	for _, other := range s.store.FindAllFiles(ctx) {
+		if other.ID != f.ID {
+			others = append(others, other)
+		}
+	}
+	return others
+}
+
+// CreateFile add a file to storage. Files without a FileIDModifier are given the next one for their
+// origin, destination and creation date, see AssignFileIDModifier.
 // TODO(adam): the HTTP endpoint accepts malformed bodies (and missing data)
-func (s *service) CreateFile(fh *ach.FileHeader) (string, error) {
+func (s *service) CreateFile(ctx context.Context, fh *ach.FileHeader) (_ string, err error) {
+	ctx, span := startSpan(ctx, "Service.CreateFile")
+	defer endSpan(span, &err)
+
 	// create a new file
 	f := ach.NewFile()
 	f.SetHeader(*fh)
@@ -91,42 +367,240 @@ func (s *service) CreateFile(fh *ach.FileHeader) (string, error) {
 		f.ID = fh.ID
 		f.Control.ID = fh.ID
 	}
-	if err := s.store.StoreFile(f); err != nil {
+	span.SetAttribute("file.id", f.ID)
+	if f.Header.FileIDModifier == "" {
+		if err := s.AssignFileIDModifier(ctx, f); err != nil {
+			return "", err
+		}
+	}
+	if err := s.store.StoreFile(ctx, f); err != nil {
 		return "", err
 	}
 	return f.ID, nil
 }
 
 // GetFile returns a files based on the supplied id
-func (s *service) GetFile(id string) (*ach.File, error) {
-	f, err := s.store.FindFile(id)
+func (s *service) GetFile(ctx context.Context, id string) (_ *ach.File, err error) {
+	ctx, span := startSpan(ctx, "Service.GetFile")
+	span.SetAttribute("file.id", id)
+	defer endSpan(span, &err)
+
+	f, err := s.store.FindFile(ctx, id)
 	if err != nil {
 		return nil, ErrNotFound
 	}
 	return f, nil
 }
 
-func (s *service) GetFiles() []*ach.File {
-	return s.store.FindAllFiles()
+func (s *service) GetFiles(ctx context.Context) []*ach.File {
+	return s.store.FindAllFiles(ctx)
+}
+
+// FileFilter limits the files returned by FindFiles. Zero values are not used to filter.
+type FileFilter struct {
+	// Skip is how many matching files to leave off the start of the results
+	Skip int
+	// Count is the maximum number of files to return
+	Count int
+	// CreatedAfter matches files whose FileCreationDate and FileCreationTime are after it
+	CreatedAfter time.Time
+	// Origin matches the FileHeader's ImmediateOrigin
+	Origin string
+	// Destination matches the FileHeader's ImmediateDestination
+	Destination string
+	// Status matches files at that point of their lifecycle
+	Status FileStatus
+}
+
+func (filter FileFilter) matches(f *ach.File) bool {
+	if filter.Origin != "" && strings.TrimSpace(f.Header.ImmediateOrigin) != filter.Origin {
+		return false
+	}
+	if filter.Destination != "" && strings.TrimSpace(f.Header.ImmediateDestination) != filter.Destination {
+		return false
+	}
+	if !filter.CreatedAfter.IsZero() {
+		created, err := fileCreated(f.Header)
+		if err != nil || !created.After(filter.CreatedAfter) {
+			return false
+		}
+	}
+	return true
 }
 
-func (s *service) DeleteFile(id string) error {
-	return s.store.DeleteFile(id)
+// fileCreated reads the FileCreationDate and FileCreationTime (YYMMDD and HHmm) of a FileHeader
+func fileCreated(fh ach.FileHeader) (time.Time, error) {
+	hhmm := fh.FileCreationTime
+	if hhmm == "" {
+		hhmm = "0000"
+	}
+	return time.Parse("0601021504", fh.FileCreationDate+hhmm)
 }
 
-func (s *service) GetFileContents(id string) (io.Reader, error) {
-	f, err := s.GetFile(id)
+func (s *service) FindFiles(ctx context.Context, filter FileFilter) ([]*ach.File, int) {
+	var files []*ach.File
+	for _, f := range s.store.FindAllFiles(ctx) {
+		if !filter.matches(f) {
+			continue
+		}
+		if filter.Status != "" {
+			if lifecycle, err := s.store.FileStatus(ctx, f.ID); err != nil || lifecycle.Status != filter.Status {
+				continue
+			}
+		}
+		files = append(files, f)
+	}
+	// Order files by when they were created so pages are stable
+	sort.Slice(files, func(i, j int) bool {
+		hi, hj := files[i].Header, files[j].Header
+		if hi.FileCreationDate != hj.FileCreationDate {
+			return hi.FileCreationDate < hj.FileCreationDate
+		}
+		if hi.FileCreationTime != hj.FileCreationTime {
+			return hi.FileCreationTime < hj.FileCreationTime
+		}
+		return files[i].ID < files[j].ID
+	})
+
+	total := len(files)
+	if filter.Skip > 0 {
+		if filter.Skip >= len(files) {
+			return nil, total
+		}
+		files = files[filter.Skip:]
+	}
+	if filter.Count > 0 && filter.Count < len(files) {
+		files = files[:filter.Count]
+	}
+	return files, total
+}
+
+func (s *service) FileRevision(ctx context.Context, id string) (int, error) {
+	return s.store.FileRevision(ctx, id)
+}
+
+func (s *service) DeleteFile(ctx context.Context, id string, opts ...ChangeOption) error {
+	var err error
+	if o := readChangeOptions(opts); o.revision != 0 {
+		err = s.store.DeleteFileAtRevision(ctx, id, o.revision)
+	} else {
+		err = s.store.DeleteFile(ctx, id)
+	}
+	if err == nil && s.approvals != nil {
+		// a restored file, or another with its ID, needs to be approved again
+		err = s.approvals.repo.DeleteApproval(ctx, id)
+	}
+	return err
+}
+
+// FileHeaderPatch holds FileHeader fields to update on a stored file. Nil fields are left unchanged.
+type FileHeaderPatch struct {
+	ImmediateOrigin          *string `json:"immediateOrigin"`
+	ImmediateOriginName      *string `json:"immediateOriginName"`
+	ImmediateDestination     *string `json:"immediateDestination"`
+	ImmediateDestinationName *string `json:"immediateDestinationName"`
+	FileIDModifier           *string `json:"fileIDModifier"`
+	// FileCreationDate is accepted as YYMMDD or an RFC 3339 timestamp
+	FileCreationDate *string `json:"fileCreationDate"`
+	// FileCreationTime is accepted as HHmm or an RFC 3339 timestamp
+	FileCreationTime *string `json:"fileCreationTime"`
+}
+
+func (p *FileHeaderPatch) apply(fh *ach.FileHeader) {
+	set := func(field *string, v *string) {
+		if v != nil {
+			*field = *v
+		}
+	}
+	set(&fh.ImmediateOrigin, p.ImmediateOrigin)
+	set(&fh.ImmediateOriginName, p.ImmediateOriginName)
+	set(&fh.ImmediateDestination, p.ImmediateDestination)
+	set(&fh.ImmediateDestinationName, p.ImmediateDestinationName)
+	set(&fh.FileIDModifier, p.FileIDModifier)
+	set(&fh.FileCreationDate, p.FileCreationDate)
+	set(&fh.FileCreationTime, p.FileCreationTime)
+
+	if t, err := time.Parse(time.RFC3339, fh.FileCreationDate); err == nil {
+		fh.FileCreationDate = t.Format("060102")
+	}
+	if t, err := time.Parse(time.RFC3339, fh.FileCreationTime); err == nil {
+		fh.FileCreationTime = t.Format("1504")
+	}
+}
+
+func (s *service) PatchFileHeader(ctx context.Context, id string, patch *FileHeaderPatch, opts ...ChangeOption) (*ach.File, error) {
+	if patch == nil {
+		return nil, invalid(errors.New("no FileHeader fields provided"))
+	}
+	f, err := s.GetFile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	header := f.Header
+	patch.apply(&header)
+	if err := header.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidFile, err)
+	}
+	return s.store.UpdateFileAtRevision(ctx, id, readChangeOptions(opts).revision, func(f *ach.File) error {
+		f.Header = header
+		return nil
+	})
+}
+
+func (s *service) GetFileContents(ctx context.Context, id string) (io.Reader, error) {
+	r, err := s.renderContents(ctx, id)
+	if err != nil || s.approvals == nil {
+		return r, err
+	}
+	contents, err := readContents(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkApproved(ctx, id, contents); err != nil {
+		return nil, err
+	}
+	return contents, nil
+}
+
+// renderContents returns the plaintext contents of a file, from the cache of the Repository when it has one
+// and the file isn't tokenized
+func (s *service) renderContents(ctx context.Context, id string) (io.Reader, error) {
+	if cache, ok := s.store.(contentsCache); ok && s.tokenizer == nil {
+		var readErr error
+		r, err := cache.contents(id, func() (*ach.File, error) {
+			f, err := s.GetFile(ctx, id)
+			readErr = err
+			return f, err
+		}, fileContents)
+		if readErr != nil {
+			return nil, fmt.Errorf("problem reading file %s: %w", id, readErr)
+		}
+		return r, err
+	}
+
+	f, err := s.GetFile(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("problem reading file %s: %v", id, err)
+		return nil, fmt.Errorf("problem reading file %s: %w", id, err)
 	}
+	if s.tokenizer != nil {
+		if f, err = s.detokenizedFile(ctx, f); err != nil {
+			return nil, fmt.Errorf("problem detokenizing file %s: %w", id, err)
+		}
+	}
+	return fileContents(f)
+}
+
+// fileContents tabulates f with Create() and returns it as a plaintext file
+func fileContents(f *ach.File) (io.Reader, error) {
 	if err := f.Create(); err != nil {
-		return nil, fmt.Errorf("problem creating file %s: %v", id, err)
+		return nil, fmt.Errorf("problem creating file %s: %v", f.ID, err)
 	}
 
 	var buf bytes.Buffer
 	w := ach.NewWriter(&buf)
 	if err := w.Write(f); err != nil {
-		return nil, fmt.Errorf("problem writing plaintext file %s: %v", id, err)
+		return nil, fmt.Errorf("problem writing plaintext file %s: %v", f.ID, err)
 	}
 	if err := w.Flush(); err != nil {
 		return nil, err
@@ -139,17 +613,127 @@ func (s *service) GetFileContents(id string) (io.Reader, error) {
 	return &buf, nil
 }
 
-func (s *service) ValidateFile(id string, opts *ach.ValidateOpts) error {
-	f, err := s.GetFile(id)
+func (s *service) ValidateFile(ctx context.Context, id string, opts *ach.ValidateOpts) (err error) {
+	ctx, span := startSpan(ctx, "Service.ValidateFile")
+	span.SetAttribute("file.id", id)
+	defer endSpan(span, &err)
+
+	f, err := s.GetFile(ctx, id)
 	if err != nil {
-		return fmt.Errorf("problem reading file %s: %v", id, err)
+		return fmt.Errorf("problem reading file %s: %w", id, err)
+	}
+	err = s.validateFile(ctx, f, opts)
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
-	return f.ValidateWith(opts)
+	countValidationFailure(err)
+	if s.events != nil {
+		evt := &Event{Type: FileValidated, FileID: id}
+		if err != nil {
+			evt.Error = err.Error()
+		}
+		s.events.Publish(evt)
+	}
+	if err == nil {
+		s.recordFileStatus(ctx, id, StatusValidated)
+	}
+	return err
+}
+
+func (s *service) FileWarnings(ctx context.Context, id string) (_ []ach.Warning, err error) {
+	ctx, span := startSpan(ctx, "Service.FileWarnings")
+	span.SetAttribute("file.id", id)
+	defer endSpan(span, &err)
+
+	f, err := s.GetFile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return f.Warnings(), nil
+}
+
+func (s *service) BuildFile(ctx context.Context, id string, opts ...ChangeOption) (_ *ach.File, err error) {
+	defer observeBuild(time.Now())
+	ctx, span := startSpan(ctx, "Service.BuildFile")
+	span.SetAttribute("file.id", id)
+	defer endSpan(span, &err)
+
+	// a failed build leaves the stored file as it was, so remember why it failed
+	var buildErr error
+	f, err := s.store.UpdateFileAtRevision(withAuditAction(ctx, AuditBuild), id, readChangeOptions(opts).revision, func(f *ach.File) error {
+		buildErr = buildFile(ctx, f, s.offsets)
+		return buildErr
+	})
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if buildErr != nil {
+		countValidationFailure(buildErr)
+		return nil, fmt.Errorf("%w: %v", errInvalidFile, buildErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateFile(ctx, f, nil); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		countValidationFailure(err)
+		return f, invalidFile(err)
+	}
+	s.recordFileStatus(ctx, id, StatusBuilt)
+	return f, nil
+}
+
+// traceNumberSetter is implemented by batches which accept an ach.TraceNumberGenerator, such as every
+// batch embedding ach.Batch
+type traceNumberSetter interface {
+	SetTraceNumberGenerator(ach.TraceNumberGenerator)
 }
 
-func (s *service) CreateBatch(fileID string, batch ach.Batcher) (string, error) {
+// setTraceNumberGenerator sets gen on batch when it accepts an ach.TraceNumberGenerator
+func setTraceNumberGenerator(batch ach.Batcher, gen ach.TraceNumberGenerator) {
+	if b, ok := batch.(traceNumberSetter); ok {
+		b.SetTraceNumberGenerator(gen)
+	}
+}
+
+// buildFile tabulates the controls, trace numbers and addenda counts of f and its batches, appending
+// the offset of each batch's company in offsets, stopping between batches once ctx is done
+func buildFile(ctx context.Context, f *ach.File, offsets map[string]ach.Offset) error {
+	// share one generator so entries missing a TraceNumber aren't numbered from 1 in every batch
+	traceNumbers := ach.NewTraceNumberGenerator(f)
+	for i := range f.Batches {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if off, ok := offsets[strings.TrimSpace(f.Batches[i].GetHeader().CompanyIdentification)]; ok {
+			f.Batches[i].WithOffset(&off)
+		}
+		setTraceNumberGenerator(f.Batches[i], traceNumbers)
+		err := f.Batches[i].Create()
+		setTraceNumberGenerator(f.Batches[i], nil)
+		if err != nil {
+			return err
+		}
+	}
+	for i := range f.IATBatches {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		f.IATBatches[i].SetTraceNumberGenerator(traceNumbers)
+		err := f.IATBatches[i].Create()
+		f.IATBatches[i].SetTraceNumberGenerator(nil)
+		if err != nil {
+			return err
+		}
+	}
+	return f.Create()
+}
+
+func (s *service) CreateBatch(ctx context.Context, fileID string, batch ach.Batcher, opts ...ChangeOption) (string, error) {
 	if batch == nil {
-		return "", errors.New("no batch provided")
+		return "", invalid(errors.New("no batch provided"))
 	}
 	if batch.GetHeader().ID == "" {
 		id := base.ID()
@@ -160,30 +744,403 @@ func (s *service) CreateBatch(fileID string, batch ach.Batcher) (string, error)
 		batch.SetID(batch.GetHeader().ID)
 		batch.GetControl().ID = batch.GetHeader().ID
 	}
-	if err := s.store.StoreBatch(fileID, batch); err != nil {
+	if o := readChangeOptions(opts); o.revision != 0 {
+		// Any batch added since the file was at revision fails the update, so checking
+		// for an existing batch beforehand is safe.
+		if _, err := s.store.FindBatch(ctx, fileID, batch.ID()); err == nil {
+			return "", ErrAlreadyExists
+		}
+		_, err := s.store.UpdateFileAtRevision(ctx, fileID, o.revision, func(f *ach.File) error {
+			f.AddBatch(batch)
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		return batch.ID(), nil
+	}
+	if err := s.store.StoreBatch(ctx, fileID, batch); err != nil {
 		return "", err
 	}
 	return batch.ID(), nil
 }
 
-func (s *service) GetBatch(fileID string, batchID string) (ach.Batcher, error) {
-	b, err := s.store.FindBatch(fileID, batchID)
+func (s *service) GetBatch(ctx context.Context, fileID string, batchID string) (ach.Batcher, error) {
+	b, err := s.store.FindBatch(ctx, fileID, batchID)
 	if err != nil {
 		return nil, ErrNotFound
 	}
 	return b, nil
 }
 
-func (s *service) GetBatches(fileID string) []ach.Batcher {
-	return s.store.FindAllBatches(fileID)
+func (s *service) GetBatches(ctx context.Context, fileID string) []ach.Batcher {
+	return s.store.FindAllBatches(ctx, fileID)
 }
 
-func (s *service) DeleteBatch(fileID string, batchID string) error {
-	return s.store.DeleteBatch(fileID, batchID)
+func (s *service) ReplaceBatch(ctx context.Context, fileID string, batchID string, batch ach.Batcher, opts ...ChangeOption) (ach.Batcher, error) {
+	if batch == nil {
+		return nil, invalid(errors.New("no batch provided"))
+	}
+	existing, err := s.GetBatch(ctx, fileID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	batch.SetID(batchID)
+	batch.GetHeader().ID = batchID
+	batch.GetControl().ID = batchID
+	batch.GetHeader().BatchNumber = existing.GetHeader().BatchNumber
+	if err := batch.Create(); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidFile, err)
+	}
+
+	_, err = s.store.UpdateFileAtRevision(ctx, fileID, readChangeOptions(opts).revision, func(f *ach.File) error {
+		for i := range f.Batches {
+			if f.Batches[i].ID() == batchID {
+				replaceBatchAt(f, i, batch)
+				return nil
+			}
+		}
+		return ErrNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// replaceBatchAt swaps the batch at index i of f for batch, keeping the file's
+// NotificationOfChange and ReturnEntries in step
+func replaceBatchAt(f *ach.File, i int, batch ach.Batcher) {
+	batchID := f.Batches[i].ID()
+	f.Batches[i] = batch
+
+	replace := func(batches []ach.Batcher, include bool) []ach.Batcher {
+		out := make([]ach.Batcher, 0, len(batches)+1)
+		for _, b := range batches {
+			if b.ID() != batchID {
+				out = append(out, b)
+			} else if include {
+				out = append(out, batch)
+				include = false
+			}
+		}
+		if include {
+			out = append(out, batch)
+		}
+		return out
+	}
+	f.NotificationOfChange = replace(f.NotificationOfChange, batch.Category() == ach.CategoryNOC)
+	f.ReturnEntries = replace(f.ReturnEntries, batch.Category() == ach.CategoryReturn)
+}
+
+func (s *service) DeleteBatch(ctx context.Context, fileID string, batchID string, opts ...ChangeOption) error {
+	if o := readChangeOptions(opts); o.revision != 0 {
+		if _, err := s.store.FindBatch(ctx, fileID, batchID); err != nil {
+			return err
+		}
+		_, err := s.store.UpdateFileAtRevision(ctx, fileID, o.revision, func(f *ach.File) error {
+			for i := range f.Batches {
+				if f.Batches[i].ID() == batchID {
+					f.Batches = append(f.Batches[:i], f.Batches[i+1:]...)
+					return nil
+				}
+			}
+			return ErrNotFound
+		})
+		return err
+	}
+	return s.store.DeleteBatch(ctx, fileID, batchID)
+}
+
+func (s *service) GetEntry(ctx context.Context, fileID string, batchID string, seq int) (*ach.EntryDetail, error) {
+	_, entry, err := s.findEntry(ctx, fileID, batchID, seq)
+	return entry, err
+}
+
+func (s *service) findEntry(ctx context.Context, fileID string, batchID string, seq int) (ach.Batcher, *ach.EntryDetail, error) {
+	batch, err := s.GetBatch(ctx, fileID, batchID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return batchEntry(batch, seq)
+}
+
+// fileEntry finds an entry by its Entry Detail Sequence Number within a batch of f
+func fileEntry(f *ach.File, batchID string, seq int) (ach.Batcher, *ach.EntryDetail, error) {
+	for _, batch := range f.Batches {
+		if batch.ID() == batchID {
+			return batchEntry(batch, seq)
+		}
+	}
+	return nil, nil, ErrNotFound
+}
+
+func batchEntry(batch ach.Batcher, seq int) (ach.Batcher, *ach.EntryDetail, error) {
+	for _, entry := range batch.GetEntries() {
+		if entrySequenceNumber(entry) == seq {
+			return batch, entry, nil
+		}
+	}
+	return nil, nil, ErrNotFound
+}
+
+// entrySequenceNumber returns the Entry Detail Sequence Number (last seven digits of TraceNumber) of an entry
+func entrySequenceNumber(entry *ach.EntryDetail) int {
+	n, _ := strconv.Atoi(entry.TraceNumberField()[8:])
+	return n
+}
+
+func (s *service) GetEntries(ctx context.Context, fileID string, batchID string) ([]*ach.EntryDetail, error) {
+	batch, err := s.GetBatch(ctx, fileID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	return batch.GetEntries(), nil
+}
+
+func (s *service) CreateEntry(ctx context.Context, fileID string, batchID string, entry *ach.EntryDetail, opts ...ChangeOption) (*ach.EntryDetail, error) {
+	if entry == nil {
+		return nil, invalid(errors.New("no entry provided"))
+	}
+	batch, err := s.changeEntries(ctx, fileID, batchID, opts, func(batch ach.Batcher) ([]*ach.EntryDetail, error) {
+		entries := batch.GetEntries()
+		if entry.TraceNumber == "" {
+			last := 0
+			for _, e := range entries {
+				if n := entrySequenceNumber(e); n > last {
+					last = n
+				}
+			}
+			entry.SetTraceNumber(batch.GetHeader().ODFIIdentification, last+1)
+		}
+		if _, _, err := batchEntry(batch, entrySequenceNumber(entry)); err == nil {
+			return nil, ErrAlreadyExists
+		}
+		return append(append([]*ach.EntryDetail{}, entries...), entry), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, created, err := batchEntry(batch, entrySequenceNumber(entry))
+	return created, err
+}
+
+func (s *service) ReplaceEntry(ctx context.Context, fileID string, batchID string, seq int, entry *ach.EntryDetail, opts ...ChangeOption) (*ach.EntryDetail, error) {
+	if entry == nil {
+		return nil, invalid(errors.New("no entry provided"))
+	}
+	batch, err := s.changeEntries(ctx, fileID, batchID, opts, func(batch ach.Batcher) ([]*ach.EntryDetail, error) {
+		entries := append([]*ach.EntryDetail{}, batch.GetEntries()...)
+		for i := range entries {
+			if entrySequenceNumber(entries[i]) == seq {
+				entry.TraceNumber = entries[i].TraceNumber
+				entries[i] = entry
+				return entries, nil
+			}
+		}
+		return nil, ErrNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, replaced, err := batchEntry(batch, seq)
+	return replaced, err
+}
+
+func (s *service) DeleteEntry(ctx context.Context, fileID string, batchID string, seq int, opts ...ChangeOption) error {
+	_, err := s.changeEntries(ctx, fileID, batchID, opts, func(batch ach.Batcher) ([]*ach.EntryDetail, error) {
+		entries := batch.GetEntries()
+		for i := range entries {
+			if entrySequenceNumber(entries[i]) == seq {
+				return append(append([]*ach.EntryDetail{}, entries[:i]...), entries[i+1:]...), nil
+			}
+		}
+		return nil, ErrNotFound
+	})
+	return err
+}
+
+// changeEntries swaps a batch for a rebuilt copy holding the entries returned by change.
+// The change is tried against the current batch first so that a missing entry or a batch
+// which no longer builds leaves the file, and its revision, untouched.
+func (s *service) changeEntries(ctx context.Context, fileID string, batchID string, opts []ChangeOption, change func(ach.Batcher) ([]*ach.EntryDetail, error)) (ach.Batcher, error) {
+	batch, err := s.GetBatch(ctx, fileID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	rebuild := func(batch ach.Batcher) (ach.Batcher, error) {
+		entries, err := change(batch)
+		if err != nil {
+			return nil, err
+		}
+		return rebuildBatch(batch, entries)
+	}
+	if _, err := rebuild(batch); err != nil {
+		return nil, err
+	}
+
+	var rebuilt ach.Batcher
+	_, err = s.store.UpdateFileAtRevision(ctx, fileID, readChangeOptions(opts).revision, func(f *ach.File) error {
+		for i := range f.Batches {
+			if f.Batches[i].ID() == batchID {
+				b, err := rebuild(f.Batches[i])
+				if err != nil {
+					return err
+				}
+				replaceBatchAt(f, i, b)
+				rebuilt = b
+				return nil
+			}
+		}
+		return ErrNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rebuilt, nil
+}
+
+// rebuildBatch returns a copy of batch holding entries, tabulated with Create(). The copy is
+// made through JSON as Batcher has no way to remove or replace entries.
+func rebuildBatch(batch ach.Batcher, entries []*ach.EntryDetail) (ach.Batcher, error) {
+	if batch.GetHeader().StandardEntryClassCode == ach.ADV {
+		return nil, invalid(errors.New("entries of ADV batches can't be changed"))
+	}
+	bs, err := json.Marshal(batch)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bs, &fields); err != nil {
+		return nil, err
+	}
+	if fields["entryDetails"], err = json.Marshal(entries); err != nil {
+		return nil, err
+	}
+	if bs, err = json.Marshal(fields); err != nil {
+		return nil, err
+	}
+	rebuilt, err := ach.BatchFromJSON(bs)
+	if err != nil {
+		return nil, err
+	}
+	rebuilt.SetID(batch.ID())
+	if err := rebuilt.Create(); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidFile, err)
+	}
+	return rebuilt, nil
+}
+
+func (s *service) CreateAddenda05(ctx context.Context, fileID string, batchID string, seq int, addenda05 *ach.Addenda05, opts ...ChangeOption) (string, error) {
+	if addenda05 == nil {
+		return "", invalid(errors.New("no Addenda05 provided"))
+	}
+	_, entry, err := s.findEntry(ctx, fileID, batchID, seq)
+	if err != nil {
+		return "", err
+	}
+	if addenda05.ID == "" {
+		addenda05.ID = base.ID()
+	}
+	for _, a := range entry.Addenda05 {
+		if a.ID == addenda05.ID {
+			return "", ErrAlreadyExists
+		}
+	}
+	addenda05.SequenceNumber = len(entry.Addenda05) + 1
+	addenda05.EntryDetailSequenceNumber = seq
+	if err := addenda05.Validate(); err != nil {
+		return "", fmt.Errorf("%w: %v", errInvalidFile, err)
+	}
+	_, err = s.store.UpdateFileAtRevision(ctx, fileID, readChangeOptions(opts).revision, func(f *ach.File) error {
+		batch, entry, err := fileEntry(f, batchID, seq)
+		if err != nil {
+			return err
+		}
+		if err := addAddenda05(batch, entry, addenda05); err != nil {
+			return fmt.Errorf("%w: %v", errInvalidFile, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return addenda05.ID, nil
+}
+
+// addenda05Adder is implemented by batches which check the Addenda05 limit of their SEC code
+// as records are added, such as every batch embedding ach.Batch
+type addenda05Adder interface {
+	AddAddenda05(*ach.EntryDetail, *ach.Addenda05) error
+}
+
+// addAddenda05 appends addenda05 to entry of batch, with the batch's limit checked when it has one
+func addAddenda05(batch ach.Batcher, entry *ach.EntryDetail, addenda05 *ach.Addenda05) error {
+	if b, ok := batch.(addenda05Adder); ok {
+		return b.AddAddenda05(entry, addenda05)
+	}
+	entry.AddendaRecordIndicator = 1
+	entry.AddAddenda05(addenda05)
+	return nil
 }
 
-func (s *service) BalanceFile(fileID string, off *ach.Offset) (*ach.File, error) {
-	f, err := s.GetFile(fileID)
+func (s *service) DeleteAddenda05(ctx context.Context, fileID string, batchID string, seq int, addendaID string, opts ...ChangeOption) error {
+	_, entry, err := s.findEntry(ctx, fileID, batchID, seq)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, a := range entry.Addenda05 {
+		if a.ID == addendaID {
+			found = true
+		}
+	}
+	if !found {
+		return ErrNotFound
+	}
+	_, err = s.store.UpdateFileAtRevision(ctx, fileID, readChangeOptions(opts).revision, func(f *ach.File) error {
+		_, entry, err := fileEntry(f, batchID, seq)
+		if err != nil {
+			return err
+		}
+		removeAddenda05(entry, addendaID)
+		return nil
+	})
+	return err
+}
+
+// removeAddenda05 drops the Addenda05 with addendaID from entry and renumbers the rest
+func removeAddenda05(entry *ach.EntryDetail, addendaID string) {
+	for i := range entry.Addenda05 {
+		if entry.Addenda05[i].ID != addendaID {
+			continue
+		}
+		entry.Addenda05 = append(entry.Addenda05[:i], entry.Addenda05[i+1:]...)
+		for j := range entry.Addenda05 {
+			entry.Addenda05[j].SequenceNumber = j + 1
+		}
+		if len(entry.Addenda05) == 0 && entry.Addenda02 == nil && entry.Addenda98 == nil && entry.Addenda99 == nil &&
+			entry.Addenda99Dishonored == nil && entry.Addenda99Contested == nil {
+			entry.AddendaRecordIndicator = 0
+		}
+		return
+	}
+}
+
+func (s *service) GetFileVersions(ctx context.Context, fileID string) ([]*FileVersion, error) {
+	return s.store.FindVersions(ctx, fileID)
+}
+
+func (s *service) RollbackFile(ctx context.Context, fileID string, version int) (*ach.File, error) {
+	return s.store.RollbackFile(ctx, fileID, version)
+}
+
+func (s *service) BalanceFile(ctx context.Context, fileID string, off *ach.Offset) (_ *ach.File, err error) {
+	ctx, span := startSpan(ctx, "Service.BalanceFile")
+	span.SetAttribute("file.id", fileID)
+	defer endSpan(span, &err)
+
+	f, err := s.GetFile(ctx, fileID)
 	if err != nil {
 		return nil, err
 	}
@@ -202,15 +1159,19 @@ func (s *service) BalanceFile(fileID string, off *ach.Offset) (*ach.File, error)
 		return nil, err
 	}
 	// Save our new file
-	if err := s.store.StoreFile(f); err != nil {
+	if err := s.store.StoreFile(ctx, f); err != nil {
 		return nil, err
 	}
 	return f, nil
 }
 
 // SegmentFile takes an ACH File and segments the files into a credit ACH File and debit ACH File and adds to in memory storage.
-func (s *service) SegmentFile(fileID string, opts *ach.SegmentFileConfiguration) (*ach.File, *ach.File, error) {
-	f, err := s.GetFile(fileID)
+func (s *service) SegmentFile(ctx context.Context, fileID string, opts *ach.SegmentFileConfiguration) (_ *ach.File, _ *ach.File, err error) {
+	ctx, span := startSpan(ctx, "Service.SegmentFile")
+	span.SetAttribute("file.id", fileID)
+	defer endSpan(span, &err)
+
+	f, err := s.GetFile(ctx, fileID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -227,8 +1188,12 @@ func (s *service) SegmentFile(fileID string, opts *ach.SegmentFileConfiguration)
 }
 
 // FlattenBatches consolidates batches that have the same BatchHeader
-func (s *service) FlattenBatches(fileID string) (*ach.File, error) {
-	f, err := s.GetFile(fileID)
+func (s *service) FlattenBatches(ctx context.Context, fileID string) (_ *ach.File, err error) {
+	ctx, span := startSpan(ctx, "Service.FlattenBatches")
+	span.SetAttribute("file.id", fileID)
+	defer endSpan(span, &err)
+
+	f, err := s.GetFile(ctx, fileID)
 	if err != nil {
 		return nil, err
 	}
@@ -242,3 +1207,129 @@ func (s *service) FlattenBatches(fileID string) (*ach.File, error) {
 	}
 	return ff, err
 }
+
+// SplitFileByEffectiveDate splits a file into a file for each effective entry date of its batches
+func (s *service) SplitFileByEffectiveDate(ctx context.Context, fileID string) (_ []*ach.File, err error) {
+	ctx, span := startSpan(ctx, "Service.SplitFileByEffectiveDate")
+	span.SetAttribute("file.id", fileID)
+	defer endSpan(span, &err)
+
+	f, err := s.GetFile(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	// File Create in the case a file is malformed.
+	if err := f.Create(); err != nil {
+		return nil, err
+	}
+	return f.SplitByEffectiveDate()
+}
+
+// CloneFile stores a copy of a stored file to send the same payments again, such as the next run of a payroll.
+// The copy has new IDs and is created now with the next FileIDModifier of its origin and destination. Its batches
+// are effective on effectiveEntryDate, or the banking day after, and forward entries are given new TraceNumbers
+// when the copy is built. Return and NOC entries keep their TraceNumbers.
+func (s *service) CloneFile(ctx context.Context, fileID string, effectiveEntryDate ach.Date) (_ *ach.File, err error) {
+	ctx, span := startSpan(ctx, "Service.CloneFile")
+	span.SetAttribute("file.id", fileID)
+	defer endSpan(span, &err)
+
+	f, err := s.GetFile(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	// copy every record of the file
+	bs, err := f.MarshalProto()
+	if err != nil {
+		return nil, err
+	}
+	clone, err := ach.FileFromProto(bs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	id := base.ID()
+	clone.ID = id
+	clone.Header.ID = id
+	clone.Control.ID = id
+	clone.Header.FileCreationDate = now.Format("060102") // YYMMDD
+	clone.Header.FileCreationTime = now.Format("1504")   // HHmm
+	if err := s.AssignFileIDModifier(ctx, clone); err != nil {
+		return nil, err
+	}
+
+	effective := ach.NextBankingDay(effectiveEntryDate.Time(), true).Format("060102") // YYMMDD
+	forward := func(category string) bool {
+		return category == "" || category == ach.CategoryForward
+	}
+	for _, batch := range clone.Batches {
+		batchID := base.ID()
+		batch.SetID(batchID)
+		batch.GetHeader().ID = batchID
+		batch.GetControl().ID = batchID
+		// ENR batches don't have an EffectiveEntryDate
+		if strings.TrimSpace(batch.GetHeader().EffectiveEntryDate) != "" {
+			batch.GetHeader().EffectiveEntryDate = effective
+		}
+		for _, entry := range batch.GetEntries() {
+			if entry.ID != "" {
+				entry.ID = base.ID()
+			}
+			if forward(entry.Category) {
+				entry.TraceNumber = ""
+			}
+		}
+	}
+	for _, iatBatch := range clone.IATBatches {
+		batchID := base.ID()
+		iatBatch.ID = batchID
+		iatBatch.Header.ID = batchID
+		iatBatch.Control.ID = batchID
+		iatBatch.Header.EffectiveEntryDate = effective
+		for _, entry := range iatBatch.Entries {
+			if entry.ID != "" {
+				entry.ID = base.ID()
+			}
+			if forward(entry.Category) {
+				entry.TraceNumber = ""
+			}
+		}
+	}
+	if err := buildFile(ctx, clone, s.offsets); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, invalidFile(err)
+	}
+	span.SetAttribute("clone.id", id)
+	if err := s.store.StoreFile(ctx, clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// LintFile checks an uploaded file as POST /files/create followed by GET /files/{id}/validate and
+// POST /files/{id}/build would, so clients don't have to store and delete a file to check it. A file
+// without a FileIDModifier is checked with A, as one is assigned when it's created.
+func (s *service) LintFile(ctx context.Context, f *ach.File) (err error) {
+	ctx, span := startSpan(ctx, "Service.LintFile")
+	defer endSpan(span, &err)
+
+	if f.Header.FileIDModifier == "" {
+		f.Header.FileIDModifier = "A"
+	}
+	if err := s.validateFile(ctx, f, nil); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	if err := buildFile(ctx, f, s.offsets); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
@@ -18,6 +18,8 @@
 package server
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
@@ -29,14 +31,17 @@ import (
 	"github.com/moov-io/base"
 
 	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/routing"
+	"github.com/moov-io/ach/screening"
 )
 
 // test mocks are in mock_test.go
 
 // CreateFile tests
 func TestCreateFile(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	id, err := s.CreateFile(mockFileHeader())
+	id, err := s.CreateFile(ctx, mockFileHeader())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -45,18 +50,20 @@ func TestCreateFile(t *testing.T) {
 	}
 }
 func TestCreateFileIDExists(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
 	h := ach.FileHeader{ID: "98765"}
-	id, err := s.CreateFile(&h)
+	id, err := s.CreateFile(ctx, &h)
 	if err != ErrAlreadyExists {
 		t.Errorf("expected %s received %s w/ error %s", "ErrAlreadyExists", id, err)
 	}
 }
 
 func TestCreateFileNoID(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
 	h := ach.NewFileHeader()
-	id, err := s.CreateFile(&h)
+	id, err := s.CreateFile(ctx, &h)
 	if len(id) < 3 {
 		t.Errorf("expected %s received %s w/ error %s", "NextID", id, err)
 	}
@@ -68,16 +75,18 @@ func TestCreateFileNoID(t *testing.T) {
 // Service.GetFile tests
 
 func TestGetFile(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	f, err := s.GetFile("98765")
+	f, err := s.GetFile(ctx, "98765")
 	if err != nil {
 		t.Errorf("expected %s received %s w/ error %s", "98765", f.ID, err)
 	}
 }
 
 func TestGetFileNotFound(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	f, err := s.GetFile("12345")
+	f, err := s.GetFile(ctx, "12345")
 	if err != ErrNotFound {
 		t.Errorf("expected %s received %s w/ error %s", "ErrNotFound", f.ID, err)
 	}
@@ -86,22 +95,63 @@ func TestGetFileNotFound(t *testing.T) {
 // Service.GetFiles tests
 
 func TestGetFiles(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	files := s.GetFiles()
+	files := s.GetFiles(ctx)
 	if len(files) != 1 {
 		t.Errorf("expected %s received %v", "1", len(files))
 	}
 }
 
+func TestFindFiles(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	s := NewService(repo)
+
+	for i, date := range []string{"190101", "190102", "190103"} {
+		fh := mockFileHeader()
+		fh.FileCreationDate = date
+		fh.FileCreationTime = "1200"
+		if i == 2 {
+			fh.ImmediateOrigin = "231380104"
+		}
+		repo.StoreFile(ctx, &ach.File{ID: fmt.Sprintf("file-%d", i), Header: *fh})
+	}
+
+	files, total := s.FindFiles(ctx, FileFilter{})
+	if total != 3 || len(files) != 3 || files[0].ID != "file-0" || files[2].ID != "file-2" {
+		t.Errorf("total=%d files=%d", total, len(files))
+	}
+
+	files, total = s.FindFiles(ctx, FileFilter{Skip: 1, Count: 1})
+	if total != 3 || len(files) != 1 || files[0].ID != "file-1" {
+		t.Errorf("total=%d files=%#v", total, files)
+	}
+	if files, total = s.FindFiles(ctx, FileFilter{Skip: 5}); total != 3 || len(files) != 0 {
+		t.Errorf("total=%d files=%d", total, len(files))
+	}
+
+	files, total = s.FindFiles(ctx, FileFilter{CreatedAfter: time.Date(2019, time.January, 2, 0, 0, 0, 0, time.UTC)})
+	if total != 2 || files[0].ID != "file-1" {
+		t.Errorf("total=%d files=%#v", total, files)
+	}
+
+	files, total = s.FindFiles(ctx, FileFilter{Origin: "231380104", Destination: "231380104"})
+	if total != 1 || files[0].ID != "file-2" {
+		t.Errorf("total=%d files=%#v", total, files)
+	}
+}
+
 // Service.DeleteFile tests
 
 func TestDeleteFile(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	err := s.DeleteFile("98765")
+	err := s.DeleteFile(ctx, "98765")
 	if err != nil {
 		t.Errorf("expected %s received %s", "nil", err)
 	}
-	_, err = s.GetFile("98765")
+	_, err = s.GetFile(ctx, "98765")
 	if err != ErrNotFound {
 		t.Errorf("expected %s received %s", "ErrNotFound", err)
 	}
@@ -110,18 +160,19 @@ func TestDeleteFile(t *testing.T) {
 // Service.GetFileContents tests
 
 func TestGetFileContents(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	id, err := s.CreateFile(mockFileHeader())
+	id, err := s.CreateFile(ctx, mockFileHeader())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
 
 	// make the file valid
 	batch := mockBatchWEB()
-	s.CreateBatch(id, batch)
+	s.CreateBatch(ctx, id, batch)
 
 	// build file
-	r, err := s.GetFileContents(id)
+	r, err := s.GetFileContents(ctx, id)
 	if err != nil {
 		if !strings.Contains(err.Error(), "mandatory ") {
 			t.Fatal(err.Error())
@@ -142,12 +193,13 @@ func TestGetFileContents(t *testing.T) {
 // Service.ValidateFile tests
 
 func TestValidateFile(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	id, err := s.CreateFile(mockFileHeader())
+	id, err := s.CreateFile(ctx, mockFileHeader())
 	if err != nil {
 		t.Fatal(err.Error())
 	}
-	if err := s.ValidateFile(id, nil); err != nil {
+	if err := s.ValidateFile(ctx, id, nil); err != nil {
 		if !strings.Contains(err.Error(), "mandatory ") {
 			t.Fatal(err.Error())
 		}
@@ -155,30 +207,108 @@ func TestValidateFile(t *testing.T) {
 }
 
 func TestValidateFileMissing(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	err := s.ValidateFile("missing", nil)
+	err := s.ValidateFile(ctx, "missing", nil)
 	if err == nil {
 		t.Fatal("expected error")
 	}
 }
 
+func TestValidateFileCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := mockServiceInMemory()
+	id, err := s.CreateFile(ctx, mockFileHeader())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if err := s.ValidateFile(ctx, id, nil); err != context.Canceled {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestBuildFileCanceled(t *testing.T) {
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	s := NewService(repo)
+
+	f := ach.NewFile()
+	f.ID = "build"
+	f.SetHeader(*mockFileHeader())
+	f.AddBatch(mockBatchWEB())
+	if err := repo.StoreFile(context.Background(), f); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	if _, err := s.BuildFile(ctx, f.ID); err != context.DeadlineExceeded {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if rev, _ := repo.FileRevision(context.Background(), f.ID); rev != 1 {
+		t.Errorf("canceled build changed the file, revision=%d", rev)
+	}
+}
+
+func TestBuildFileOffsets(t *testing.T) {
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	s := NewService(repo, WithOffsets(map[string]ach.Offset{
+		"121042882": {RoutingNumber: "121042882", AccountNumber: "123456789", AccountType: ach.OffsetChecking, Description: "SETTLEMENT"},
+	}))
+
+	other := mockBatchWEB()
+	other.SetID("other")
+	other.GetHeader().CompanyIdentification = "987654321"
+	f := ach.NewFile()
+	f.ID = "offsets"
+	f.SetHeader(*mockFileHeader())
+	f.AddBatch(mockBatchWEB())
+	f.AddBatch(other)
+	if err := repo.StoreFile(context.Background(), f); err != nil {
+		t.Fatal(err)
+	}
+
+	// building again replaces the offset entry
+	for i := 0; i < 2; i++ {
+		built, err := s.BuildFile(context.Background(), f.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries := built.Batches[0].GetEntries()
+		if len(entries) != 2 {
+			t.Fatalf("unexpected entries: %#v", entries)
+		}
+		if off := entries[1]; off.IndividualName != "OFFSET" || off.TransactionCode != ach.CheckingDebit || off.Amount != entries[0].Amount {
+			t.Errorf("unexpected offset: %#v", off)
+		}
+		if c := built.Batches[0].GetControl(); c.TotalDebitEntryDollarAmount != c.TotalCreditEntryDollarAmount {
+			t.Errorf("unbalanced batch: %#v", c)
+		}
+		if n := len(built.Batches[1].GetEntries()); n != 1 {
+			t.Errorf("other company's batch has %d entries", n)
+		}
+	}
+}
+
 func TestValidateFileBad(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
 
-	fId, _ := s.CreateFile(mockFileHeader())
+	fId, _ := s.CreateFile(ctx, mockFileHeader())
 
 	// setup batch
 	bh := mockBatchHeaderWeb()
 	bh.ID = "11111"
 	b, _ := ach.NewBatch(bh)
-	bId, e1 := s.CreateBatch(fId, b)
-	batch, e2 := s.GetBatch(fId, bId)
+	bId, e1 := s.CreateBatch(ctx, fId, b)
+	batch, e2 := s.GetBatch(ctx, fId, bId)
 	if batch == nil {
 		t.Fatalf("couldn't get batch, e1=%v, e2=%v", e1, e2)
 	}
 
 	// setup file, add batch
-	f, err := s.GetFile(fId)
+	f, err := s.GetFile(ctx, fId)
 	if f == nil {
 		t.Fatalf("couldn't get file: %v", err)
 	}
@@ -187,36 +317,147 @@ func TestValidateFileBad(t *testing.T) {
 	}
 
 	// validate
-	if err := s.ValidateFile(fId, nil); err == nil {
+	if err := s.ValidateFile(ctx, fId, nil); err == nil {
 		t.Fatal("expected error")
 	}
 }
 
 func TestValidateFileOpts(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
 	fh := mockFileHeader()
 	fh.ImmediateOrigin = "00000000"
-	id, err := s.CreateFile(fh)
+	id, err := s.CreateFile(ctx, fh)
 	if err != nil {
 		t.Fatal(err.Error())
 	}
 
-	if err := s.ValidateFile(id, &ach.ValidateOpts{RequireABAOrigin: false}); err != nil {
+	if err := s.ValidateFile(ctx, id, &ach.ValidateOpts{RequireABAOrigin: false}); err != nil {
 		if !strings.Contains(err.Error(), "mandatory ") {
 			t.Fatal(err.Error())
 		}
 	}
 }
 
+func TestValidateFileStrict(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	file := readPPDValidFile(t)
+	file.AddBatch(file.Batches[0]) // duplicate TraceNumbers
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.StoreFile(ctx, file); err != nil {
+		t.Fatal(err)
+	}
+
+	lenient := NewService(repo)
+	if err := lenient.ValidateFile(ctx, file.ID, &ach.ValidateOpts{BypassOriginValidation: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// per-request options are ignored by a strict service
+	strict := NewService(repo, WithStrictNACHA())
+	err := strict.ValidateFile(ctx, file.ID, &ach.ValidateOpts{BypassOriginValidation: true})
+	if !base.Match(err, ach.NewErrFileDuplicateTraceNumber(file.Batches[0].GetEntries()[0].TraceNumber)) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
+
+func TestValidateFileRDFIDirectory(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	file := readPPDValidFile(t)
+	if err := repo.StoreFile(ctx, file); err != nil {
+		t.Fatal(err)
+	}
+	rdfi := file.Batches[0].GetEntries()[0].RDFIIdentificationField() + file.Batches[0].GetEntries()[0].CheckDigit
+
+	svc := NewService(repo, WithRDFIDirectory(routing.StaticDirectory{
+		routing.Number(rdfi): {RoutingNumber: routing.Number(rdfi), SECCodes: []string{ach.PPD}},
+	}))
+	if err := svc.ValidateFile(ctx, file.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	svc = NewService(repo, WithRDFIDirectory(routing.StaticDirectory{}))
+	if err := svc.ValidateFile(ctx, file.ID, nil); !base.Has(err, routing.ErrNotParticipant) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
+
+func TestValidateFilePolicy(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	file := readPPDValidFile(t)
+	if err := repo.StoreFile(ctx, file); err != nil {
+		t.Fatal(err)
+	}
+	bh := file.Batches[0].GetHeader()
+	total := file.Control.TotalDebitAmount() + file.Control.TotalCreditAmount()
+	policy := &ach.Policy{Companies: []ach.CompanyPolicy{{
+		CompanyIdentification: bh.CompanyIdentification,
+		DailyLimit:            total,
+	}}}
+
+	svc := NewService(repo, WithPolicy(policy))
+	if err := svc.ValidateFile(ctx, file.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// another stored file for the same day puts the company over its limit
+	other := readPPDValidFile(t)
+	other.ID = base.ID()
+	if err := repo.StoreFile(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ValidateFile(ctx, file.ID, nil); !base.Has(err, ach.ErrFileDailyLimit) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
+
+type blockingScreener struct{}
+
+func (blockingScreener) Screen(_ context.Context, parties []screening.Party) error {
+	return &screening.MatchError{Matches: []screening.Match{{Party: parties[0]}}}
+}
+
+func TestValidateFileIATScreener(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "iat-mixedDebitCredit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	file, err := ach.NewReader(fd).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.ID = base.ID()
+	if err := repo.StoreFile(ctx, &file); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewService(repo, WithIATScreener(screening.NopScreener{})).ValidateFile(ctx, file.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	err = NewService(repo, WithIATScreener(blockingScreener{})).ValidateFile(ctx, file.ID, nil)
+	if !base.Has(err, screening.ErrMatch) {
+		t.Errorf("%T: %v", err, err)
+	}
+}
+
 // Service.CreateBatch tests
 
 // TestCreateBatch tests creating a new batch when file.ID exists and batch.id does not exist
 func TestCreateBatch(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
 	bh := mockBatchHeaderWeb()
 	bh.ID = "11111"
 	b, _ := ach.NewBatch(bh)
-	id, err := s.CreateBatch("98765", b)
+	id, err := s.CreateBatch(ctx, "98765", b)
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -227,9 +468,10 @@ func TestCreateBatch(t *testing.T) {
 
 // TestCreateBatchIDExists Create a new batch with batch.id already present. Should fail.
 func TestCreateBatchIDExists(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
 	b, _ := ach.NewBatch(mockBatchHeaderWeb())
-	id, err := s.CreateBatch("98765", b)
+	id, err := s.CreateBatch(ctx, "98765", b)
 	if err != ErrAlreadyExists {
 		t.Errorf("expected %s received %s w/ error %v", "ErrAlreadyExists", id, err)
 	}
@@ -237,9 +479,10 @@ func TestCreateBatchIDExists(t *testing.T) {
 
 // TestCreateBatchFileIDExits create a batch when the file.id does not exist. Should fail.
 func TestCreateBatchFileIDExits(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
 	b, _ := ach.NewBatch(mockBatchHeaderWeb())
-	id, err := s.CreateBatch("55555", b)
+	id, err := s.CreateBatch(ctx, "55555", b)
 	if err != ErrNotFound {
 		t.Errorf("expected %s received %s w/ error %v", "ErrNotFound", id, err)
 	}
@@ -247,11 +490,12 @@ func TestCreateBatchFileIDExits(t *testing.T) {
 
 // TestCreateBatchIDBank create a new batch when the batch.id is nil but file.id is valid. Should generate batch.id and save.
 func TestCreateBatchIDBlank(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
 	bh := mockBatchHeaderWeb()
 	bh.ID = ""
 	b, _ := ach.NewBatch(bh)
-	id, err := s.CreateBatch("98765", b)
+	id, err := s.CreateBatch(ctx, "98765", b)
 	if len(id) < 3 {
 		t.Errorf("expected %s received %s w/ error %v", "NextID", id, err)
 	}
@@ -264,8 +508,9 @@ func TestCreateBatchIDBlank(t *testing.T) {
 
 // TestGetBatch return a batch for the existing file.id and batch.id
 func TestGetBatch(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	b, err := s.GetBatch("98765", "54321")
+	b, err := s.GetBatch(ctx, "98765", "54321")
 	if err != nil {
 		t.Errorf("problem getting batch: %v", err)
 	}
@@ -276,8 +521,9 @@ func TestGetBatch(t *testing.T) {
 
 // TestGetBatchNotFound return a failure if the batch.id is not found
 func TestGetBatchNotFound(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	b, err := s.GetBatch("98765", "55555")
+	b, err := s.GetBatch(ctx, "98765", "55555")
 	if err != ErrNotFound {
 		t.Errorf("expected %s received %#v w/ error %v", "ErrNotFound", b, err)
 	}
@@ -287,8 +533,9 @@ func TestGetBatchNotFound(t *testing.T) {
 
 // TestGetBatches return a list of batches for the supplied file.id
 func TestGetBatches(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	batches := s.GetBatches("98765")
+	batches := s.GetBatches(ctx, "98765")
 	if len(batches) != 1 {
 		t.Errorf("expected %s received %v", "1", len(batches))
 	}
@@ -298,14 +545,16 @@ func TestGetBatches(t *testing.T) {
 
 // TestDeleteBatch removes a batch with existing file and batch id.
 func TestDeleteBatch(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	err := s.DeleteBatch("98765", "54321")
+	err := s.DeleteBatch(ctx, "98765", "54321")
 	if err != nil {
 		t.Errorf("expected %s received error %v", "nil", err)
 	}
 }
 
 func TestBalanceFile(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
 
 	// store a file in the Service and balance it
@@ -322,15 +571,15 @@ func TestBalanceFile(t *testing.T) {
 	}
 
 	// save our file
-	fileID, err := s.CreateFile(&file.Header)
+	fileID, err := s.CreateFile(ctx, &file.Header)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if _, err := s.CreateBatch(fileID, file.Batches[0]); err != nil {
+	if _, err := s.CreateBatch(ctx, fileID, file.Batches[0]); err != nil {
 		t.Fatal(err)
 	}
 
-	balancedFile, err := s.BalanceFile(fileID, &ach.Offset{
+	balancedFile, err := s.BalanceFile(ctx, fileID, &ach.Offset{
 		RoutingNumber: "987654320",
 		AccountNumber: "28198241",
 		AccountType:   ach.OffsetChecking,
@@ -353,23 +602,25 @@ func TestBalanceFile(t *testing.T) {
 }
 
 func TestBalanceFileErrors(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	if file, err := s.BalanceFile(base.ID(), &ach.Offset{}); err == nil {
+	if file, err := s.BalanceFile(ctx, base.ID(), &ach.Offset{}); err == nil {
 		t.Errorf("expected error file=%#v", file)
 	}
 
 	fh := ach.NewFileHeader()
-	fileID, err := s.CreateFile(&fh)
+	fileID, err := s.CreateFile(ctx, &fh)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if file, err := s.BalanceFile(fileID, &ach.Offset{}); err == nil {
+	if file, err := s.BalanceFile(ctx, fileID, &ach.Offset{}); err == nil {
 		t.Errorf("expected error file=%#v", file)
 	}
 }
 
 // TestSegmentFile creates a Segmented File from an existing ACH File
 func TestSegmentFile(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
 
 	fh := ach.NewFileHeader()
@@ -430,12 +681,12 @@ func TestSegmentFile(t *testing.T) {
 		log.Fatalf("Unexpected error building file: %s\n", err)
 	}
 
-	fileID, err := s.CreateFile(&fh)
+	fileID, err := s.CreateFile(ctx, &fh)
 	if err != nil {
 		t.Fatal(err.Error())
 	}
 
-	batchID, err := s.CreateBatch("333339", b)
+	batchID, err := s.CreateBatch(ctx, "333339", b)
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -444,7 +695,7 @@ func TestSegmentFile(t *testing.T) {
 		t.Fatal("No Batch ID")
 	}
 
-	creditFile, debitFile, err := s.SegmentFile(fileID, nil)
+	creditFile, debitFile, err := s.SegmentFile(ctx, fileID, nil)
 
 	if err != nil {
 		t.Fatalf("could not segment file w/ error %v", err)
@@ -461,8 +712,9 @@ func TestSegmentFile(t *testing.T) {
 
 // TestSegmentFile_FileValidateError return an error on file Validation
 func TestSegmentFileError(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	_, _, err := s.SegmentFile("98765", nil)
+	_, _, err := s.SegmentFile(ctx, "98765", nil)
 
 	if err != nil {
 		if !base.Match(err, ach.ErrConstructor) {
@@ -473,6 +725,7 @@ func TestSegmentFileError(t *testing.T) {
 
 // TestSegmentFileDebitsOnly creates a Segmented File from an existing ACH File
 func TestSegmentFileDebitsOnly(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
 
 	fh := ach.NewFileHeader()
@@ -515,12 +768,12 @@ func TestSegmentFileDebitsOnly(t *testing.T) {
 		log.Fatalf("Unexpected error building file: %s\n", err)
 	}
 
-	fileID, err := s.CreateFile(&fh)
+	fileID, err := s.CreateFile(ctx, &fh)
 	if err != nil {
 		t.Fatal(err.Error())
 	}
 
-	batchID, err := s.CreateBatch("333339", b)
+	batchID, err := s.CreateBatch(ctx, "333339", b)
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -529,7 +782,7 @@ func TestSegmentFileDebitsOnly(t *testing.T) {
 		t.Fatal("No Batch ID")
 	}
 
-	creditFile, debitFile, err := s.SegmentFile(fileID, nil)
+	creditFile, debitFile, err := s.SegmentFile(ctx, fileID, nil)
 
 	if err != nil {
 		t.Fatalf("could not segment file w/ error %v", err)
@@ -547,6 +800,7 @@ func TestSegmentFileDebitsOnly(t *testing.T) {
 
 // TestSegmentFileDebitsOnlyBatchID creates a Segmented File from an existing ACH File
 func TestSegmentFileDebitsOnlyBatchID(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
 
 	fh := ach.NewFileHeader()
@@ -589,12 +843,12 @@ func TestSegmentFileDebitsOnlyBatchID(t *testing.T) {
 		log.Fatalf("Unexpected error building file: %s\n", err)
 	}
 
-	fileID, err := s.CreateFile(&fh)
+	fileID, err := s.CreateFile(ctx, &fh)
 	if err != nil {
 		t.Fatal(err.Error())
 	}
 
-	batchID, err := s.CreateBatch("333339", b)
+	batchID, err := s.CreateBatch(ctx, "333339", b)
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -603,7 +857,7 @@ func TestSegmentFileDebitsOnlyBatchID(t *testing.T) {
 		t.Fatal("No Batch ID")
 	}
 
-	_, debitFile, err := s.SegmentFile(fileID, nil)
+	_, debitFile, err := s.SegmentFile(ctx, fileID, nil)
 
 	if err != nil {
 		t.Fatalf("could not segment file w/ error %v", err)
@@ -615,6 +869,7 @@ func TestSegmentFileDebitsOnlyBatchID(t *testing.T) {
 }
 
 func TestFlattenBatches(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
 
 	f, err := os.Open(filepath.Join("..", "test", "testdata", "flattenBatchesMultipleBatchHeaders.ach"))
@@ -628,13 +883,13 @@ func TestFlattenBatches(t *testing.T) {
 		t.Fatalf("Issue reading file: %+v \n", err)
 	}
 
-	fileID, err := s.CreateFile(&achFile.Header)
+	fileID, err := s.CreateFile(ctx, &achFile.Header)
 	if err != nil {
 		t.Fatal(err.Error())
 	}
 
 	for _, b := range achFile.Batches {
-		batchID, err := s.CreateBatch(fileID, b)
+		batchID, err := s.CreateBatch(ctx, fileID, b)
 		if err != nil {
 			t.Fatal(err.Error())
 		}
@@ -643,7 +898,7 @@ func TestFlattenBatches(t *testing.T) {
 		}
 	}
 
-	ff, err := s.FlattenBatches(fileID)
+	ff, err := s.FlattenBatches(ctx, fileID)
 
 	if err != nil {
 		t.Fatalf("Could not flatten the file: %+v \n", err)
@@ -655,9 +910,10 @@ func TestFlattenBatches(t *testing.T) {
 }
 
 func TestSegmentFile_NoFileID(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
 	fileID := ""
-	_, err := s.FlattenBatches(fileID)
+	_, err := s.FlattenBatches(ctx, fileID)
 
 	if err != nil {
 		if !strings.Contains(err.Error(), "not found") {
@@ -667,8 +923,9 @@ func TestSegmentFile_NoFileID(t *testing.T) {
 }
 
 func TestFlattenBatches_NoFileID(t *testing.T) {
+	ctx := context.Background()
 	s := mockServiceInMemory()
-	_, _, err := s.SegmentFile("", nil)
+	_, _, err := s.SegmentFile(ctx, "", nil)
 
 	if err != nil {
 		if !strings.Contains(err.Error(), "not found") {
@@ -0,0 +1,361 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/ach"
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+)
+
+// defaultStatsWindow is how far back aggregate stats look when no window is requested
+const defaultStatsWindow = 7 * 24 * time.Hour
+
+// DefaultStatsMinEntries is how many entries an SEC code needs before AggregateStats includes it,
+// unless WithStatsMinEntries is given.
+const DefaultStatsMinEntries = 10
+
+// WithStatsMinEntries sets how many entries an SEC code needs before AggregateStats includes it. SEC codes
+// with fewer entries are left out of every count and total so a single payment's amount can't be read from them.
+func WithStatsMinEntries(n int) ServiceOption {
+	return func(s *service) {
+		if n > 0 {
+			s.statsMinEntries = n
+		}
+	}
+}
+
+// AggregateStats are origination volumes across stored files. Only counts and totals are
+// included so they can be shared without exposing account or payment details. SEC codes
+// with too few entries are suppressed, see WithStatsMinEntries.
+type AggregateStats struct {
+	Since       time.Time                `json:"since"`
+	Files       int                      `json:"files"`
+	Batches     int                      `json:"batches"`
+	Entries     int                      `json:"entries"`
+	TotalDebit  int                      `json:"totalDebit"`
+	TotalCredit int                      `json:"totalCredit"`
+	SECCodes    map[string]*SECCodeStats `json:"secCodes"`
+	// Suppressed is how many entries were left out because their SEC code had too few entries
+	Suppressed int `json:"suppressed"`
+}
+
+// SECCodeStats are origination volumes of batches with one StandardEntryClassCode
+type SECCodeStats struct {
+	Batches     int `json:"batches"`
+	Entries     int `json:"entries"`
+	TotalDebit  int `json:"totalDebit"`
+	TotalCredit int `json:"totalCredit"`
+}
+
+func (s *service) AggregateStats(ctx context.Context, since time.Time) *AggregateStats {
+	stats := &AggregateStats{
+		Since:    since,
+		SECCodes: make(map[string]*SECCodeStats),
+	}
+	sinceStr := since.Format("060102") // YYMMDD
+
+	for _, f := range s.store.FindAllFiles(ctx) {
+		if f.Header.FileCreationDate < sinceStr {
+			continue
+		}
+		stats.Files++
+		for _, batch := range f.Batches {
+			sec := stats.secCode(batch.GetHeader().StandardEntryClassCode)
+			for _, entry := range batch.GetEntries() {
+				sec.add(entry.TransactionCode, entry.Amount)
+			}
+			for _, entry := range batch.GetADVEntries() {
+				sec.addADV(entry.TransactionCode, entry.Amount)
+			}
+		}
+		for _, iatBatch := range f.IATBatches {
+			sec := stats.secCode(iatBatch.GetHeader().StandardEntryClassCode)
+			for _, entry := range iatBatch.GetEntries() {
+				sec.add(entry.TransactionCode, entry.Amount)
+			}
+		}
+	}
+	for code, sec := range stats.SECCodes {
+		if sec.Entries < s.statsMinEntries {
+			delete(stats.SECCodes, code)
+			stats.Suppressed += sec.Entries
+			continue
+		}
+		stats.Batches += sec.Batches
+		stats.Entries += sec.Entries
+		stats.TotalDebit += sec.TotalDebit
+		stats.TotalCredit += sec.TotalCredit
+	}
+	return stats
+}
+
+// secCode returns the SECCodeStats for code and counts a batch towards them
+func (stats *AggregateStats) secCode(code string) *SECCodeStats {
+	sec, ok := stats.SECCodes[code]
+	if !ok {
+		sec = &SECCodeStats{}
+		stats.SECCodes[code] = sec
+	}
+	sec.Batches++
+	return sec
+}
+
+func (sec *SECCodeStats) add(transactionCode int, amount int) {
+	sec.Entries++
+
+	entry := ach.EntryDetail{TransactionCode: transactionCode}
+	switch entry.CreditOrDebit() {
+	case "C":
+		sec.TotalCredit += amount
+	case "D":
+		sec.TotalDebit += amount
+	}
+}
+
+// addADV counts an ADV entry, whose accounting TransactionCodes (81-88) don't follow CreditOrDebit
+func (sec *SECCodeStats) addADV(transactionCode int, amount int) {
+	sec.Entries++
+
+	switch transactionCode {
+	case ach.CreditForDebitsOriginated, ach.CreditForCreditsReceived, ach.CreditForCreditsRejected, ach.CreditSummary:
+		sec.TotalCredit += amount
+	case ach.DebitForCreditsOriginated, ach.DebitForDebitsReceived, ach.DebitForDebitsRejectedBatches, ach.DebitSummary:
+		sec.TotalDebit += amount
+	}
+}
+
+// FileStats are the totals of one stored file, so dashboards can show them without
+// fetching the whole file.
+type FileStats struct {
+	FileID      string `json:"fileID"`
+	Batches     int    `json:"batches"`
+	Entries     int    `json:"entries"`
+	TotalDebit  int    `json:"totalDebit"`
+	TotalCredit int    `json:"totalCredit"`
+
+	// Addenda counts the addenda records of the file's entries by their TypeCode (e.g. 05)
+	Addenda map[string]int `json:"addenda"`
+
+	SECCodes map[string]*SECCodeStats `json:"secCodes"`
+	// EffectiveDates are totals by the EffectiveEntryDate (YYMMDD) of batches
+	EffectiveDates map[string]*SECCodeStats `json:"effectiveDates"`
+}
+
+func (s *service) FileStats(ctx context.Context, id string) (*FileStats, error) {
+	f, err := s.GetFile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	stats := &FileStats{
+		FileID:         id,
+		Addenda:        make(map[string]int),
+		SECCodes:       make(map[string]*SECCodeStats),
+		EffectiveDates: make(map[string]*SECCodeStats),
+	}
+	for _, batch := range f.Batches {
+		sec, date := stats.batch(batch.GetHeader().StandardEntryClassCode, batch.GetHeader().EffectiveEntryDate)
+		for _, entry := range batch.GetEntries() {
+			sec.add(entry.TransactionCode, entry.Amount)
+			date.add(entry.TransactionCode, entry.Amount)
+			stats.addenda("02", entry.Addenda02 != nil)
+			for range entry.Addenda05 {
+				stats.addenda("05", true)
+			}
+			stats.addenda("98", entry.Addenda98 != nil)
+			stats.addenda("99", entry.Addenda99 != nil || entry.Addenda99Dishonored != nil || entry.Addenda99Contested != nil)
+		}
+		for _, entry := range batch.GetADVEntries() {
+			sec.addADV(entry.TransactionCode, entry.Amount)
+			date.addADV(entry.TransactionCode, entry.Amount)
+			stats.addenda("99", entry.Addenda99 != nil)
+		}
+	}
+	for _, iatBatch := range f.IATBatches {
+		sec, date := stats.batch(iatBatch.GetHeader().StandardEntryClassCode, iatBatch.GetHeader().EffectiveEntryDate)
+		for _, entry := range iatBatch.GetEntries() {
+			sec.add(entry.TransactionCode, entry.Amount)
+			date.add(entry.TransactionCode, entry.Amount)
+			stats.addenda("10", entry.Addenda10 != nil)
+			stats.addenda("11", entry.Addenda11 != nil)
+			stats.addenda("12", entry.Addenda12 != nil)
+			stats.addenda("13", entry.Addenda13 != nil)
+			stats.addenda("14", entry.Addenda14 != nil)
+			stats.addenda("15", entry.Addenda15 != nil)
+			stats.addenda("16", entry.Addenda16 != nil)
+			for range entry.Addenda17 {
+				stats.addenda("17", true)
+			}
+			for range entry.Addenda18 {
+				stats.addenda("18", true)
+			}
+			stats.addenda("98", entry.Addenda98 != nil)
+			stats.addenda("99", entry.Addenda99 != nil)
+		}
+	}
+	for _, sec := range stats.SECCodes {
+		stats.Entries += sec.Entries
+		stats.TotalDebit += sec.TotalDebit
+		stats.TotalCredit += sec.TotalCredit
+	}
+	return stats, nil
+}
+
+// batch returns the SECCodeStats for a batch's StandardEntryClassCode and EffectiveEntryDate and
+// counts the batch towards them
+func (stats *FileStats) batch(code string, effectiveDate string) (*SECCodeStats, *SECCodeStats) {
+	sec, ok := stats.SECCodes[code]
+	if !ok {
+		sec = &SECCodeStats{}
+		stats.SECCodes[code] = sec
+	}
+	date, ok := stats.EffectiveDates[effectiveDate]
+	if !ok {
+		date = &SECCodeStats{}
+		stats.EffectiveDates[effectiveDate] = date
+	}
+	sec.Batches++
+	date.Batches++
+	stats.Batches++
+	return sec, date
+}
+
+func (stats *FileStats) addenda(typeCode string, present bool) {
+	if present {
+		stats.Addenda[typeCode]++
+	}
+}
+
+// parseStatsWindow reads durations like 12h along with a number of days (e.g. 7d)
+func parseStatsWindow(v string) (time.Duration, error) {
+	if v == "" {
+		return defaultStatsWindow, nil
+	}
+	if strings.HasSuffix(v, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(v, "d"))
+		if err != nil || days <= 0 {
+			return 0, invalid(fmt.Errorf("invalid window %q", v))
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, invalid(fmt.Errorf("invalid window %q", v))
+	}
+	return d, nil
+}
+
+type aggregateStatsRequest struct {
+	window time.Duration
+
+	requestID string
+}
+
+type aggregateStatsResponse struct {
+	*AggregateStats
+	Err error `json:"error"`
+}
+
+func (r aggregateStatsResponse) error() error { return r.Err }
+
+func aggregateStatsEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(aggregateStatsRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return aggregateStatsResponse{
+				Err: err,
+			}, err
+		}
+
+		stats := s.AggregateStats(ctx, time.Now().Add(-1*req.window))
+
+		logEvent(logger, "stats", "aggregateStats", nil, "requestID", req.requestID, "window", req.window)
+
+		return aggregateStatsResponse{
+			AggregateStats: stats,
+		}, nil
+	}
+}
+
+func decodeAggregateStatsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	window, err := parseStatsWindow(r.URL.Query().Get("window"))
+	if err != nil {
+		return nil, err
+	}
+	return aggregateStatsRequest{
+		window:    window,
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
+
+type fileStatsRequest struct {
+	fileID string
+
+	requestID string
+}
+
+type fileStatsResponse struct {
+	*FileStats
+	Err error `json:"error"`
+}
+
+func (r fileStatsResponse) error() error { return r.Err }
+
+func fileStatsEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(fileStatsRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return fileStatsResponse{
+				Err: err,
+			}, err
+		}
+
+		stats, err := s.FileStats(ctx, req.fileID)
+
+		logEvent(logger, "stats", "fileStats", err, "requestID", req.requestID, "fileID", req.fileID)
+
+		return fileStatsResponse{
+			FileStats: stats,
+			Err:       err,
+		}, nil
+	}
+}
+
+func decodeFileStatsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return fileStatsRequest{
+		fileID:    id,
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
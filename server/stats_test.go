@@ -0,0 +1,248 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+)
+
+func TestStats__parseStatsWindow(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":    defaultStatsWindow,
+		"1d":  24 * time.Hour,
+		"30d": 30 * 24 * time.Hour,
+		"12h": 12 * time.Hour,
+	}
+	for v, expected := range cases {
+		d, err := parseStatsWindow(v)
+		if err != nil {
+			t.Errorf("%q: %v", v, err)
+		}
+		if d != expected {
+			t.Errorf("%q: got %v expected %v", v, d, expected)
+		}
+	}
+	for _, v := range []string{"0d", "-1d", "xd", "week", "-2h"} {
+		if _, err := parseStatsWindow(v); err == nil {
+			t.Errorf("%q: expected error", v)
+		}
+	}
+}
+
+func TestStats__aggregateStatsEndpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo, WithStatsMinEntries(1))
+
+	resp, err := aggregateStatsEndpoint(svc, logger)(context.TODO(), nil)
+	r, ok := resp.(aggregateStatsResponse)
+	if !ok {
+		t.Errorf("got %#v", resp)
+	}
+	if err == nil || r.Err == nil {
+		t.Errorf("expected error: err=%v resp.Err=%v", err, r.Err)
+	}
+
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if fd == nil {
+		t.Fatalf("empty ACH file: %v", err)
+	}
+	defer fd.Close()
+	bs, _ := ioutil.ReadAll(fd)
+
+	// a recent file is counted
+	file, _ := ach.FileFromJSON(bs)
+	file.Header.FileCreationDate = time.Now().Format("060102")
+	repo.StoreFile(ctx, file)
+
+	// an old file is outside the window
+	old, _ := ach.FileFromJSON(bs)
+	old.ID = "old"
+	old.Header.FileCreationDate = time.Now().AddDate(0, 0, -30).Format("060102")
+	repo.StoreFile(ctx, old)
+
+	router := mux.NewRouter()
+	router.Methods("GET").Path("/stats/aggregate").Handler(
+		httptransport.NewServer(aggregateStatsEndpoint(svc, logger), decodeAggregateStatsRequest, encodeResponse),
+	)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/stats/aggregate?window=7d", nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var response AggregateStats
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Files != 1 || response.Batches != 1 || response.Entries != 1 {
+		t.Errorf("files=%d batches=%d entries=%d", response.Files, response.Batches, response.Entries)
+	}
+	if response.TotalCredit != 100000 || response.TotalDebit != 0 {
+		t.Errorf("totalCredit=%d totalDebit=%d", response.TotalCredit, response.TotalDebit)
+	}
+	ppd, ok := response.SECCodes[ach.PPD]
+	if !ok {
+		t.Fatalf("missing PPD stats: %#v", response.SECCodes)
+	}
+	if ppd.Batches != 1 || ppd.Entries != 1 || ppd.TotalCredit != 100000 {
+		t.Errorf("unexpected PPD stats: %#v", ppd)
+	}
+
+	// include older files with a larger window
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/stats/aggregate?window=60d", nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Files != 2 {
+		t.Errorf("files=%d", response.Files)
+	}
+}
+
+func TestStats__aggregateStatsSuppressed(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+
+	bs, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := ach.FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.StoreFile(ctx, file)
+
+	// a lone PPD entry isn't reported, or its amount would be the PPD total
+	stats := NewService(repo).AggregateStats(ctx, time.Time{})
+	if _, ok := stats.SECCodes[ach.PPD]; ok {
+		t.Errorf("expected PPD to be suppressed: %#v", stats.SECCodes)
+	}
+	if stats.Batches != 0 || stats.Entries != 0 || stats.TotalCredit != 0 || stats.Suppressed != 1 {
+		t.Errorf("batches=%d entries=%d totalCredit=%d suppressed=%d", stats.Batches, stats.Entries, stats.TotalCredit, stats.Suppressed)
+	}
+
+	stats = NewService(repo, WithStatsMinEntries(1)).AggregateStats(ctx, time.Time{})
+	if ppd := stats.SECCodes[ach.PPD]; ppd == nil || ppd.Entries != 1 || stats.Suppressed != 0 {
+		t.Errorf("unexpected PPD stats: %#v suppressed=%d", ppd, stats.Suppressed)
+	}
+}
+
+func TestStats__aggregateStatsADV(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+
+	bs, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", "adv-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := ach.FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 82 would be a credit by EntryDetail.CreditOrDebit
+	file.Batches[0].GetADVEntries()[0].TransactionCode = ach.DebitForCreditsOriginated
+	repo.StoreFile(ctx, file)
+
+	stats := NewService(repo, WithStatsMinEntries(1)).AggregateStats(ctx, time.Time{})
+	adv := stats.SECCodes[ach.ADV]
+	if adv == nil || adv.Entries != 1 || adv.TotalDebit != 100000 || adv.TotalCredit != 0 {
+		t.Errorf("unexpected ADV stats: %#v", adv)
+	}
+	if stats.TotalDebit != 100000 || stats.TotalCredit != 0 {
+		t.Errorf("totalDebit=%d totalCredit=%d", stats.TotalDebit, stats.TotalCredit)
+	}
+}
+
+func TestStats__fileStatsEndpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	router := MakeHTTPHandler(NewService(repo), repo, logger)
+
+	f := ach.NewFile()
+	f.ID = "stats"
+	f.SetHeader(*mockFileHeader())
+	first := mockBatchWEB()
+	first.GetHeader().EffectiveEntryDate = "190816"
+	second := mockBatchWEB()
+	second.SetID("batch-02")
+	second.GetHeader().EffectiveEntryDate = "190817"
+	second.GetEntries()[0].TransactionCode = ach.CheckingDebit
+	second.GetEntries()[0].Amount = 2500
+	f.AddBatch(first)
+	f.AddBatch(second)
+	if err := repo.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/files/stats/stats", nil))
+	w.Flush()
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	var response FileStats
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if response.FileID != "stats" || response.Batches != 2 || response.Entries != 2 {
+		t.Errorf("file=%s batches=%d entries=%d", response.FileID, response.Batches, response.Entries)
+	}
+	if response.TotalCredit != 100000000 || response.TotalDebit != 2500 {
+		t.Errorf("totalCredit=%d totalDebit=%d", response.TotalCredit, response.TotalDebit)
+	}
+	if web := response.SECCodes[ach.WEB]; web == nil || web.Batches != 2 || web.Entries != 2 {
+		t.Errorf("unexpected WEB stats: %#v", web)
+	}
+	if date := response.EffectiveDates["190817"]; date == nil || date.Entries != 1 || date.TotalDebit != 2500 {
+		t.Errorf("unexpected effective date stats: %#v", response.EffectiveDates)
+	}
+	if n := response.Addenda["05"]; n != 2 {
+		t.Errorf("addenda05=%d", n)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/files/missing/stats", nil))
+	w.Flush()
+	if w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,197 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+)
+
+// FileStatus is where a stored file is in its lifecycle, files only move forward through the statuses
+type FileStatus string
+
+const (
+	StatusCreated      FileStatus = "created"
+	StatusValidated    FileStatus = "validated"
+	StatusBuilt        FileStatus = "built"
+	StatusUploaded     FileStatus = "uploaded"
+	StatusAcknowledged FileStatus = "acknowledged"
+	StatusReturned     FileStatus = "returned"
+)
+
+// fileStatuses are the statuses of a file in the order they're reached
+var fileStatuses = []FileStatus{StatusCreated, StatusValidated, StatusBuilt, StatusUploaded, StatusAcknowledged, StatusReturned}
+
+func (s FileStatus) order() int {
+	for i := range fileStatuses {
+		if fileStatuses[i] == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// FileLifecycle is the status of a stored file along with when it reached each status
+type FileLifecycle struct {
+	FileID     string                   `json:"fileID"`
+	Status     FileStatus               `json:"status"`
+	Timestamps map[FileStatus]time.Time `json:"timestamps"`
+}
+
+func newFileLifecycle(fileID string, created time.Time) *FileLifecycle {
+	return &FileLifecycle{
+		FileID:     fileID,
+		Status:     StatusCreated,
+		Timestamps: map[FileStatus]time.Time{StatusCreated: created},
+	}
+}
+
+func (l *FileLifecycle) copy() *FileLifecycle {
+	out := *l
+	out.Timestamps = make(map[FileStatus]time.Time, len(l.Timestamps))
+	for k, v := range l.Timestamps {
+		out.Timestamps[k] = v
+	}
+	return &out
+}
+
+// advance moves l to status at the given time, statuses can be skipped but not repeated or undone
+func (l *FileLifecycle) advance(status FileStatus, at time.Time) error {
+	next := status.order()
+	if next < 0 {
+		return invalid(fmt.Errorf("unknown file status %q", status))
+	}
+	if next <= l.Status.order() {
+		return conflict(fmt.Errorf("file %s is already %s", l.FileID, l.Status))
+	}
+	l.Status = status
+	l.Timestamps[status] = at
+	return nil
+}
+
+// GetFileStatus returns where a file is in its lifecycle, see Repository.FileStatus
+func (s *service) GetFileStatus(ctx context.Context, id string) (_ *FileLifecycle, err error) {
+	ctx, span := startSpan(ctx, "Service.GetFileStatus")
+	span.SetAttribute("file.id", id)
+	defer endSpan(span, &err)
+
+	return s.store.FileStatus(ctx, id)
+}
+
+// SetFileStatus moves a file forward in its lifecycle to status, see Repository.SetFileStatus
+func (s *service) SetFileStatus(ctx context.Context, id string, status FileStatus) (_ *FileLifecycle, err error) {
+	ctx, span := startSpan(ctx, "Service.SetFileStatus")
+	span.SetAttribute("file.id", id)
+	defer endSpan(span, &err)
+
+	return s.store.SetFileStatus(ctx, id, status, time.Now())
+}
+
+// recordFileStatus moves a file to status after the service validated or built it. Files are often
+// validated again after they're built or uploaded, so a file that's already further along is left alone.
+func (s *service) recordFileStatus(ctx context.Context, id string, status FileStatus) {
+	s.store.SetFileStatus(ctx, id, status, time.Now())
+}
+
+type fileStatusRequest struct {
+	ID     string     `json:"-"`
+	Status FileStatus `json:"status"`
+
+	requestID string
+}
+
+type fileStatusResponse struct {
+	*FileLifecycle
+	Err error `json:"error"`
+}
+
+func (r fileStatusResponse) error() error { return r.Err }
+
+func getFileStatusEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(fileStatusRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return fileStatusResponse{
+				Err: err,
+			}, err
+		}
+
+		lifecycle, err := s.GetFileStatus(ctx, req.ID)
+
+		logEvent(logger, "files", "getFileStatus", err, "requestID", req.requestID, "fileID", req.ID)
+
+		return fileStatusResponse{
+			FileLifecycle: lifecycle,
+			Err:           err,
+		}, nil
+	}
+}
+
+func setFileStatusEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(fileStatusRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return fileStatusResponse{
+				Err: err,
+			}, err
+		}
+
+		lifecycle, err := s.SetFileStatus(ctx, req.ID, req.Status)
+
+		logEvent(logger, "files", "setFileStatus", err, "requestID", req.requestID, "fileID", req.ID, "status", req.Status)
+
+		return fileStatusResponse{
+			FileLifecycle: lifecycle,
+			Err:           err,
+		}, nil
+	}
+}
+
+func decodeFileStatusRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	req := fileStatusRequest{
+		ID:        id,
+		requestID: moovhttp.GetRequestID(r),
+	}
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			return nil, invalid(err)
+		}
+		if req.Status == "" {
+			return nil, invalid(errors.New("missing status"))
+		}
+	}
+	return req, nil
+}
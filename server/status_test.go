@@ -0,0 +1,162 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestRepository__FileStatus(t *testing.T) {
+	ctx := context.Background()
+	encrypted, err := NewRepositoryEncrypted(testEncryptionKey, testTTLDuration, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repos := map[string]Repository{
+		"memory":    NewRepositoryInMemory(testTTLDuration, nil),
+		"encrypted": encrypted,
+	}
+	for name, repo := range repos {
+		f := ach.NewFile()
+		f.ID = "payroll"
+		f.SetHeader(*mockFileHeader())
+		if err := repo.StoreFile(ctx, f); err != nil {
+			t.Fatal(err)
+		}
+		if lifecycle, err := repo.FileStatus(ctx, f.ID); err != nil || lifecycle.Status != StatusCreated || lifecycle.Timestamps[StatusCreated].IsZero() {
+			t.Fatalf("%s: lifecycle=%#v error=%v", name, lifecycle, err)
+		}
+
+		// statuses can be skipped but not repeated or undone
+		uploaded := time.Date(2026, time.October, 16, 14, 0, 0, 0, time.UTC)
+		lifecycle, err := repo.SetFileStatus(ctx, f.ID, StatusUploaded, uploaded)
+		if err != nil || lifecycle.Status != StatusUploaded || !lifecycle.Timestamps[StatusUploaded].Equal(uploaded) {
+			t.Errorf("%s: lifecycle=%#v error=%v", name, lifecycle, err)
+		}
+		for _, status := range []FileStatus{StatusUploaded, StatusBuilt} {
+			if _, err := repo.SetFileStatus(ctx, f.ID, status, time.Now()); !errors.Is(err, ErrConflict) {
+				t.Errorf("%s: %s: unexpected error: %v", name, status, err)
+			}
+		}
+		if _, err := repo.SetFileStatus(ctx, f.ID, "sent", time.Now()); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+
+		// replacing a file keeps its status
+		if err := repo.ReplaceFile(ctx, f); err != nil {
+			t.Fatal(err)
+		}
+		if lifecycle, _ := repo.FileStatus(ctx, f.ID); lifecycle.Status != StatusUploaded {
+			t.Errorf("%s: status=%s", name, lifecycle.Status)
+		}
+
+		// and so does deleting and restoring it
+		if err := repo.DeleteFile(ctx, f.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.FileStatus(ctx, f.ID); err != ErrNotFound {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		if _, err := repo.SetFileStatus(ctx, f.ID, StatusReturned, time.Now()); err != ErrNotFound {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		if _, err := repo.RestoreFile(ctx, f.ID); err != nil {
+			t.Fatal(err)
+		}
+		if lifecycle, _ := repo.FileStatus(ctx, f.ID); lifecycle.Status != StatusUploaded {
+			t.Errorf("%s: status=%s", name, lifecycle.Status)
+		}
+	}
+}
+
+func TestFiles__fileStatus(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	f := readPPDValidFile(t)
+	if err := repo.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+
+	// the service records the files it validates and builds
+	if err := svc.ValidateFile(ctx, f.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.BuildFile(ctx, f.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ValidateFile(ctx, f.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	serve := func(method, path string, body []byte) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewReader(body)))
+		w.Flush()
+		return w
+	}
+
+	w := serve("GET", "/files/"+f.ID+"/status", nil)
+	var lifecycle FileLifecycle
+	if err := json.NewDecoder(w.Body).Decode(&lifecycle); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %v", w.Code, err)
+	}
+	if lifecycle.FileID != f.ID || lifecycle.Status != StatusBuilt || len(lifecycle.Timestamps) != 3 {
+		t.Errorf("unexpected lifecycle: %#v", lifecycle)
+	}
+
+	if w = serve("POST", "/files/"+f.ID+"/status", []byte(`{"status":"uploaded"}`)); w.Code != http.StatusOK {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = serve("POST", "/files/"+f.ID+"/status", []byte(`{"status":"built"}`)); w.Code != http.StatusConflict {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	for _, body := range []string{`{"status":"sent"}`, `{}`, `{`} {
+		if w = serve("POST", "/files/"+f.ID+"/status", []byte(body)); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: bogus HTTP status: %d: %s", body, w.Code, w.Body.String())
+		}
+	}
+	if w = serve("GET", "/files/missing/status", nil); w.Code != http.StatusNotFound {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	// files can be listed by status
+	if w = serve("GET", "/files?status=uploaded", nil); w.Header().Get("X-Total-Count") != "1" {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = serve("GET", "/files?status=returned", nil); w.Header().Get("X-Total-Count") != "0" {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if w = serve("GET", "/files?status=sent", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,246 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/moov-io/ach"
+	moovhttp "github.com/moov-io/base/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	validationAlerts = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Name: "ach_validation_alerts",
+		Help: "The number of stored ACH files expected to be rejected at the next cutoff",
+	}, nil)
+
+	validationDrift = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Name: "ach_validation_drift",
+		Help: "The number of stored ACH files which stopped passing validation between sweeps",
+	}, nil)
+)
+
+// ValidationAlert describes a stored file which is expected to be rejected at the next cutoff
+type ValidationAlert struct {
+	FileID string   `json:"fileID"`
+	Errors []string `json:"errors"`
+	// Since is the sweep which first found the file failing
+	Since time.Time `json:"since"`
+}
+
+// SweepFiles re-validates every stored file as of now and returns the files which are expected to be
+// rejected at the next cutoff. Files are checked with Validate() and for batches whose EffectiveEntryDate
+// is before the next banking day files can be submitted on, which happens overnight to files left in storage.
+//
+// Stored files have not been uploaded yet, so every file in the repository is swept.
+func (s *service) SweepFiles(ctx context.Context, now time.Time) []*ValidationAlert {
+	files := s.store.FindAllFiles(ctx)
+
+	s.alertsMu.Lock()
+	defer s.alertsMu.Unlock()
+
+	alerts := make(map[string]*ValidationAlert)
+	for _, f := range files {
+		if f == nil {
+			continue
+		}
+		errs := s.sweepFile(ctx, f, now)
+		if ctx.Err() != nil {
+			return s.sortedAlerts() // keep the prior sweep's alerts
+		}
+		if len(errs) == 0 {
+			continue
+		}
+		alert := &ValidationAlert{
+			FileID: f.ID,
+			Errors: errs,
+			Since:  now,
+		}
+		if prior, ok := s.alerts[f.ID]; ok {
+			alert.Since = prior.Since
+		}
+		alerts[f.ID] = alert
+	}
+	s.alerts = alerts
+
+	return s.sortedAlerts()
+}
+
+// ValidationAlerts returns the files flagged by the most recent SweepFiles
+func (s *service) ValidationAlerts(ctx context.Context) []*ValidationAlert {
+	s.alertsMu.Lock()
+	defer s.alertsMu.Unlock()
+
+	return s.sortedAlerts()
+}
+
+func (s *service) sortedAlerts() []*ValidationAlert {
+	out := make([]*ValidationAlert, 0, len(s.alerts))
+	for _, alert := range s.alerts {
+		out = append(out, alert)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].FileID < out[j].FileID })
+	return out
+}
+
+// WithCutoffTimes sets the times of day, as offsets from midnight in loc, files are submitted
+// to the ODFI. Files swept after the day's last cutoff are expected to be submitted the next banking
+// day. Without cutoffs files are expected to be submitted at the end of each banking day.
+func WithCutoffTimes(loc *time.Location, cutoffs ...time.Duration) ServiceOption {
+	return func(s *service) {
+		if loc == nil {
+			loc = time.UTC
+		}
+		s.cutoffs = append([]time.Duration(nil), cutoffs...)
+		sort.Slice(s.cutoffs, func(i, j int) bool { return s.cutoffs[i] < s.cutoffs[j] })
+		s.cutoffLocation = loc
+	}
+}
+
+// ParseCutoffTime reads a time of day formatted as 15:04 into its offset from midnight for WithCutoffTimes
+func ParseCutoffTime(v string) (time.Duration, error) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cutoff time %q: expected HH:MM", v)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// nextSubmissionDay returns the banking day files left in storage at now are submitted on, which is
+// today when now is a banking day before its last cutoff.
+func (s *service) nextSubmissionDay(now time.Time) time.Time {
+	if len(s.cutoffs) == 0 {
+		return ach.NextBankingDay(now, true)
+	}
+	now = now.In(s.cutoffLocation)
+	year, month, day := now.Date()
+	lastCutoff := time.Date(year, month, day, 0, 0, 0, 0, s.cutoffLocation).Add(s.cutoffs[len(s.cutoffs)-1])
+	return ach.NextBankingDay(now, now.Before(lastCutoff))
+}
+
+// sweepFile returns why f would be rejected if it was submitted at the next cutoff after now
+func (s *service) sweepFile(ctx context.Context, f *ach.File, now time.Time) []string {
+	var errs []string
+	if err := s.validateFile(ctx, f, nil); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	// YYMMDD of the banking day of the next cutoff
+	submission := s.nextSubmissionDay(now).Format("060102")
+	stale := func(batchNumber int, date string) {
+		// ENR batches and files without dates are left to Validate()
+		if date = strings.TrimSpace(date); len(date) == 6 && date < submission {
+			errs = append(errs, fmt.Sprintf("batch #%d EffectiveEntryDate %s is before %s", batchNumber, date, submission))
+		}
+	}
+	for _, batch := range f.Batches {
+		bh := batch.GetHeader()
+		stale(bh.BatchNumber, bh.EffectiveEntryDate)
+	}
+	for _, iatBatch := range f.IATBatches {
+		bh := iatBatch.GetHeader()
+		stale(bh.BatchNumber, bh.EffectiveEntryDate)
+	}
+	return errs
+}
+
+// StartValidationSweep runs SweepFiles on svc every interval until the returned func is called,
+// which also cancels a sweep in progress. Files which stop passing validation between sweeps are logged as drift.
+func StartValidationSweep(svc Service, interval time.Duration, logger log.Logger) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case now := <-t.C:
+				sweepFiles(ctx, svc, now, logger)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return cancel
+}
+
+func sweepFiles(ctx context.Context, svc Service, now time.Time, logger log.Logger) {
+	alerts := svc.SweepFiles(ctx, now)
+	validationAlerts.Set(float64(len(alerts)))
+
+	for _, alert := range alerts {
+		if !alert.Since.Equal(now) {
+			continue // already alerted on
+		}
+		validationDrift.Add(1)
+		if logger != nil {
+			level.Warn(logger).Log("component", "sweep", "msg", "validationDrift", "fileID", alert.FileID, "errors", len(alert.Errors), "error", alert.Errors[0])
+		}
+	}
+}
+
+type getValidationAlertsRequest struct {
+	requestID string
+}
+
+type getValidationAlertsResponse struct {
+	Alerts []*ValidationAlert `json:"alerts"`
+	Err    error              `json:"error"`
+}
+
+func (r getValidationAlertsResponse) count() int { return len(r.Alerts) }
+
+func (r getValidationAlertsResponse) error() error { return r.Err }
+
+func getValidationAlertsEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(getValidationAlertsRequest)
+		if !ok {
+			err := errors.New("invalid request")
+			return getValidationAlertsResponse{
+				Err: err,
+			}, err
+		}
+
+		alerts := s.ValidationAlerts(ctx)
+
+		logEvent(logger, "files", "getValidationAlerts", nil, "requestID", req.requestID, "alerts", len(alerts))
+
+		return getValidationAlertsResponse{
+			Alerts: alerts,
+		}, nil
+	}
+}
+
+func decodeGetValidationAlertsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return getValidationAlertsRequest{
+		requestID: moovhttp.GetRequestID(r),
+	}, nil
+}
@@ -0,0 +1,201 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moov-io/ach"
+
+	"github.com/go-kit/kit/log"
+)
+
+func readPPDValidFile(t *testing.T) *ach.File {
+	t.Helper()
+
+	bs, err := ioutil.ReadFile(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := ach.FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestSweepFiles(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo)
+
+	file := readPPDValidFile(t) // EffectiveEntryDate of 181009
+	if err := repo.StoreFile(ctx, file); err != nil {
+		t.Fatal(err)
+	}
+
+	// Monday 2018-10-08
+	if alerts := svc.SweepFiles(ctx, time.Date(2018, time.October, 8, 10, 0, 0, 0, time.UTC)); len(alerts) != 0 {
+		t.Fatalf("unexpected alerts: %#v", alerts[0])
+	}
+
+	// overnight the EffectiveEntryDate becomes stale
+	first := time.Date(2018, time.October, 10, 1, 0, 0, 0, time.UTC)
+	alerts := svc.SweepFiles(ctx, first)
+	if len(alerts) != 1 {
+		t.Fatalf("got %d alerts", len(alerts))
+	}
+	if alerts[0].FileID != file.ID || !alerts[0].Since.Equal(first) {
+		t.Errorf("unexpected alert: %#v", alerts[0])
+	}
+	if len(alerts[0].Errors) != 1 || !strings.Contains(alerts[0].Errors[0], "EffectiveEntryDate 181009 is before 181010") {
+		t.Errorf("unexpected errors: %v", alerts[0].Errors)
+	}
+
+	// later sweeps keep when the file was first flagged and pick up validation errors
+	file.Batches[0].GetEntries()[0].Amount = 12345
+	alerts = svc.SweepFiles(ctx, first.Add(time.Hour))
+	if len(alerts) != 1 || !alerts[0].Since.Equal(first) || len(alerts[0].Errors) != 2 {
+		t.Errorf("unexpected alerts: %#v", alerts)
+	}
+	if v := svc.ValidationAlerts(ctx); len(v) != 1 {
+		t.Errorf("got %d alerts", len(v))
+	}
+
+	// fixed files are no longer flagged
+	file.Batches[0].GetHeader().EffectiveEntryDate = "181012"
+	if err := file.Batches[0].Create(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if alerts := svc.SweepFiles(ctx, first.Add(2*time.Hour)); len(alerts) != 0 {
+		t.Errorf("unexpected alerts: %#v", alerts[0])
+	}
+}
+
+func TestSweepFiles__bankingCalendar(t *testing.T) {
+	ctx := context.Background()
+	file := readPPDValidFile(t)
+	file.Batches[0].GetHeader().EffectiveEntryDate = "181012" // Friday
+
+	svc := &service{}
+
+	// files sit in storage over the weekend, the next banking day is Monday
+	if errs := svc.sweepFile(ctx, file, time.Date(2018, time.October, 12, 20, 0, 0, 0, time.UTC)); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if errs := svc.sweepFile(ctx, file, time.Date(2018, time.October, 13, 9, 0, 0, 0, time.UTC)); len(errs) != 1 {
+		t.Errorf("expected a stale EffectiveEntryDate: %v", errs)
+	}
+}
+
+func TestSweepFiles__cutoffTimes(t *testing.T) {
+	ctx := context.Background()
+	file := readPPDValidFile(t)
+	file.Batches[0].GetHeader().EffectiveEntryDate = "181010" // Wednesday
+
+	eastern, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	first, _ := ParseCutoffTime("10:30")
+	last, _ := ParseCutoffTime("16:00")
+	svc := NewService(nil, WithCutoffTimes(eastern, last, first)).(*service)
+
+	// before the last cutoff files are submitted today
+	if errs := svc.sweepFile(ctx, file, time.Date(2018, time.October, 10, 15, 59, 0, 0, eastern)); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	// after it they're submitted tomorrow, 20:30 UTC is 16:30 in New York
+	errs := svc.sweepFile(ctx, file, time.Date(2018, time.October, 10, 20, 30, 0, 0, time.UTC))
+	if len(errs) != 1 || !strings.Contains(errs[0], "is before 181011") {
+		t.Errorf("expected a stale EffectiveEntryDate: %v", errs)
+	}
+}
+
+func TestParseCutoffTime(t *testing.T) {
+	if d, err := ParseCutoffTime("16:15"); err != nil || d != 16*time.Hour+15*time.Minute {
+		t.Errorf("d=%v error=%v", d, err)
+	}
+	for _, v := range []string{"", "4pm", "25:00", "16:15:00"} {
+		if _, err := ParseCutoffTime(v); err == nil {
+			t.Errorf("expected error for %q", v)
+		}
+	}
+}
+
+func TestStartValidationSweep(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	svc := NewService(repo)
+	if err := repo.StoreFile(ctx, readPPDValidFile(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	stop := StartValidationSweep(svc, 10*time.Millisecond, log.NewNopLogger())
+	defer stop()
+
+	for i := 0; i < 100; i++ {
+		if len(svc.ValidationAlerts(ctx)) > 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("expected the sweep to flag the stored file")
+}
+
+func TestFiles__getValidationAlertsEndpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, logger)
+
+	if err := repo.StoreFile(ctx, readPPDValidFile(t)); err != nil {
+		t.Fatal(err)
+	}
+	svc.SweepFiles(ctx, time.Now())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/files/alerts", nil))
+	w.Flush()
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+	if v := w.Header().Get("X-Total-Count"); v != "1" {
+		t.Errorf("X-Total-Count: %q", v)
+	}
+	var resp getValidationAlertsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Alerts) != 1 || resp.Alerts[0].FileID != "adam-01" {
+		t.Errorf("unexpected alerts: %#v", resp.Alerts)
+	}
+}
@@ -0,0 +1,181 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/moov-io/ach"
+)
+
+// Tokenizer replaces DFI account numbers with tokens from a vault, and tokens with the account numbers
+// they stand for. Tokens need to be valid DFIAccountNumbers (at most 17 alphanumeric characters) so stored
+// files still validate.
+//
+// Files are tokenized again each time they change, so Tokenize must return tokens it's given unchanged.
+type Tokenizer interface {
+	Tokenize(ctx context.Context, accountNumber string) (string, error)
+	Detokenize(ctx context.Context, token string) (string, error)
+}
+
+// tokenizedRepository replaces the account numbers of files with tokens before they're stored
+type tokenizedRepository struct {
+	Repository
+	tokenizer Tokenizer
+}
+
+// NewTokenizedRepository wraps r so the DFIAccountNumber of every entry stored or changed through it is replaced
+// with a token from t, leaving only tokens at rest. Files given to StoreFile and ReplaceFile are copied before they're
+// tokenized, so callers keep their account numbers, while batches given to StoreBatch are tokenized in place. Use
+// WithTokenizer to have the Service detokenize files as it renders their contents.
+func NewTokenizedRepository(r Repository, t Tokenizer) Repository {
+	return &tokenizedRepository{Repository: r, tokenizer: t}
+}
+
+func (r *tokenizedRepository) tokenize(ctx context.Context) func(string) (string, error) {
+	return func(accountNumber string) (string, error) {
+		return r.tokenizer.Tokenize(ctx, accountNumber)
+	}
+}
+
+// tokenizedCopy returns a copy of f with tokens in place of its account numbers, leaving f as it was
+func (r *tokenizedRepository) tokenizedCopy(ctx context.Context, f *ach.File) (*ach.File, error) {
+	if f == nil {
+		return nil, nil
+	}
+	out, err := copyFile(f)
+	if err != nil {
+		return nil, err
+	}
+	if err := replaceAccountNumbers(out, r.tokenize(ctx)); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *tokenizedRepository) StoreFile(ctx context.Context, f *ach.File) error {
+	tokenized, err := r.tokenizedCopy(ctx, f)
+	if err != nil {
+		return err
+	}
+	return r.Repository.StoreFile(ctx, tokenized)
+}
+
+func (r *tokenizedRepository) ReplaceFile(ctx context.Context, f *ach.File) error {
+	tokenized, err := r.tokenizedCopy(ctx, f)
+	if err != nil {
+		return err
+	}
+	return r.Repository.ReplaceFile(ctx, tokenized)
+}
+
+func (r *tokenizedRepository) StoreBatch(ctx context.Context, fileID string, batch ach.Batcher) error {
+	if batch != nil {
+		if err := replaceBatchAccountNumbers(batch, r.tokenize(ctx)); err != nil {
+			return err
+		}
+	}
+	return r.Repository.StoreBatch(ctx, fileID, batch)
+}
+
+func (r *tokenizedRepository) UpdateFile(ctx context.Context, fileID string, update func(*ach.File) error) (*ach.File, error) {
+	return r.UpdateFileAtRevision(ctx, fileID, 0, update)
+}
+
+func (r *tokenizedRepository) UpdateFileAtRevision(ctx context.Context, fileID string, revision int, update func(*ach.File) error) (*ach.File, error) {
+	return r.Repository.UpdateFileAtRevision(ctx, fileID, revision, func(f *ach.File) error {
+		if err := update(f); err != nil {
+			return err
+		}
+		return replaceAccountNumbers(f, r.tokenize(ctx))
+	})
+}
+
+// replaceAccountNumbers sets the DFIAccountNumber of every entry in f to replace's result. Blank account
+// numbers are left alone.
+func replaceAccountNumbers(f *ach.File, replace func(string) (string, error)) error {
+	for _, batch := range f.Batches {
+		if err := replaceBatchAccountNumbers(batch, replace); err != nil {
+			return err
+		}
+	}
+	for i := range f.IATBatches {
+		for _, entry := range f.IATBatches[i].Entries {
+			if err := replaceAccountNumber(&entry.DFIAccountNumber, replace); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func replaceBatchAccountNumbers(batch ach.Batcher, replace func(string) (string, error)) error {
+	for _, entry := range batch.GetEntries() {
+		if err := replaceAccountNumber(&entry.DFIAccountNumber, replace); err != nil {
+			return err
+		}
+	}
+	for _, entry := range batch.GetADVEntries() {
+		if err := replaceAccountNumber(&entry.DFIAccountNumber, replace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func replaceAccountNumber(accountNumber *string, replace func(string) (string, error)) error {
+	v := strings.TrimSpace(*accountNumber)
+	if v == "" {
+		return nil
+	}
+	v, err := replace(v)
+	if err != nil {
+		return fmt.Errorf("problem replacing account number: %w", err)
+	}
+	*accountNumber = v
+	return nil
+}
+
+// WithTokenizer has the Service detokenize the account numbers of files, stored with NewTokenizedRepository,
+// as it renders their contents. Files are otherwise returned with tokens. Contents aren't cached as they're
+// rendered with account numbers.
+func WithTokenizer(t Tokenizer) ServiceOption {
+	return func(s *service) {
+		s.tokenizer = t
+	}
+}
+
+// detokenizedFile returns a copy of f with the account numbers of its entries in place of their tokens
+func (s *service) detokenizedFile(ctx context.Context, f *ach.File) (*ach.File, error) {
+	// an unmasked copy keeps the file's ValidateOpts, which are needed to render it
+	out, err := f.MaskSensitiveData(&ach.MaskOpts{
+		KeepAccountNumbers:        true,
+		KeepNames:                 true,
+		KeepIdentificationNumbers: true,
+		KeepAddresses:             true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = replaceAccountNumbers(out, func(token string) (string, error) {
+		return s.tokenizer.Detokenize(ctx, token)
+	})
+	return out, err
+}
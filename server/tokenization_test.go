@@ -0,0 +1,149 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/moov-io/ach"
+)
+
+// mockTokenizer is an in-memory vault of account numbers
+type mockTokenizer struct {
+	mu       sync.Mutex
+	tokens   map[string]string // account number to token
+	accounts map[string]string // token to account number
+	err      error
+}
+
+func newMockTokenizer() *mockTokenizer {
+	return &mockTokenizer{
+		tokens:   make(map[string]string),
+		accounts: make(map[string]string),
+	}
+}
+
+func (t *mockTokenizer) Tokenize(_ context.Context, accountNumber string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err != nil {
+		return "", t.err
+	}
+	if _, ok := t.accounts[accountNumber]; ok {
+		return accountNumber, nil // already a token
+	}
+	token, ok := t.tokens[accountNumber]
+	if !ok {
+		token = fmt.Sprintf("TOK%d", len(t.tokens)+1)
+		t.tokens[accountNumber] = token
+		t.accounts[token] = accountNumber
+	}
+	return token, nil
+}
+
+func (t *mockTokenizer) Detokenize(_ context.Context, token string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if accountNumber, ok := t.accounts[token]; ok {
+		return accountNumber, nil
+	}
+	return "", errors.New("unknown token")
+}
+
+func TestTokenizedRepository(t *testing.T) {
+	ctx := context.Background()
+	tokenizer := newMockTokenizer()
+	repo := NewTokenizedRepository(NewRepositoryInMemory(testTTLDuration, nil), tokenizer)
+	svc := NewService(repo, WithTokenizer(tokenizer))
+
+	f := readPPDValidFile(t)
+	var accounts []string
+	for _, entry := range f.Batches[0].GetEntries() {
+		accounts = append(accounts, strings.TrimSpace(entry.DFIAccountNumber))
+	}
+	if err := repo.StoreFile(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+
+	// the caller's file keeps its account numbers, as it's returned to clients
+	for i, entry := range f.Batches[0].GetEntries() {
+		if v := strings.TrimSpace(entry.DFIAccountNumber); v != accounts[i] {
+			t.Errorf("stored file was tokenized: entry #%d DFIAccountNumber=%s", i, v)
+		}
+	}
+
+	// only tokens are stored
+	found, _ := svc.GetFile(ctx, f.ID)
+	for i, entry := range found.Batches[0].GetEntries() {
+		if entry.DFIAccountNumber != tokenizer.tokens[accounts[i]] {
+			t.Errorf("entry #%d DFIAccountNumber=%s", i, entry.DFIAccountNumber)
+		}
+	}
+
+	// batches added later are tokenized as well
+	batchID, err := svc.CreateBatch(ctx, f.ID, mockBatchWEB())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := svc.GetBatch(ctx, f.ID, batchID); b.GetEntries()[0].DFIAccountNumber != tokenizer.tokens["123456789"] {
+		t.Errorf("unexpected batch entry: %#v", b.GetEntries()[0])
+	}
+	updated, err := repo.UpdateFile(ctx, f.ID, func(f *ach.File) error {
+		f.Batches[0].GetEntries()[0].DFIAccountNumber = "987654321"
+		return nil
+	})
+	if err != nil || updated.Batches[0].GetEntries()[0].DFIAccountNumber != tokenizer.tokens["987654321"] {
+		t.Errorf("unexpected update: %v", err)
+	}
+	accounts[0] = "987654321"
+
+	// contents have the account numbers
+	r, err := svc.GetFileContents(ctx, f.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, _ := ioutil.ReadAll(r)
+	for _, account := range append(accounts, "123456789") {
+		if !strings.Contains(string(bs), account) {
+			t.Errorf("contents are missing account %s", account)
+		}
+	}
+	if strings.Contains(string(bs), "TOK") {
+		t.Errorf("contents have tokens:\n%s", string(bs))
+	}
+	if found, _ := svc.GetFile(ctx, f.ID); !strings.HasPrefix(found.Batches[0].GetEntries()[0].DFIAccountNumber, "TOK") {
+		t.Error("stored file was detokenized")
+	}
+
+	// files aren't stored without tokens
+	tokenizer.err = errors.New("vault unavailable")
+	other := readPPDValidFile(t)
+	other.ID = "other"
+	if err := repo.StoreFile(ctx, other); err == nil || !errors.Is(err, tokenizer.err) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := repo.FindFile(ctx, other.ID); err == nil {
+		t.Error("file was stored")
+	}
+}
@@ -0,0 +1,112 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Tracer starts spans for HTTP requests to the server and the work done serving them. It's
+// kept small so the server doesn't depend on a tracing library, see the oteltracing package
+// for an OpenTelemetry Tracer.
+type Tracer interface {
+	// Start starts a span named name as a child of any span in ctx. header holds the headers
+	// of the request when starting its root span, so trace context propagated by the client
+	// (e.g. W3C traceparent) can be continued, and is nil for spans within a request.
+	Start(ctx context.Context, name string, header http.Header) (context.Context, Span)
+}
+
+// Span is an operation started by a Tracer
+type Span interface {
+	// SetAttribute annotates the span with key and value
+	SetAttribute(key string, value interface{})
+	// End finishes the span, marking it failed when err is non-nil
+	End(err error)
+}
+
+// WithTracer starts a span with t for each request, named by its method and route
+// (e.g. "POST /files/create"). Reading and storing uploaded files, and Service methods
+// such as "Service.ValidateFile", are traced as child spans.
+func WithTracer(t Tracer) HandlerOption {
+	return func(h *handlerConfig) {
+		h.tracer = t
+	}
+}
+
+type tracerKey struct{}
+
+// startSpan starts a span within the request traced in ctx. Requests aren't traced
+// without WithTracer and the returned Span does nothing.
+func startSpan(ctx context.Context, name string) (context.Context, Span) {
+	if t, ok := ctx.Value(tracerKey{}).(Tracer); ok {
+		return t.Start(ctx, name, nil)
+	}
+	return ctx, nopSpan{}
+}
+
+// endSpan ends span with the error err points to, so it can be deferred by functions with a named error result
+func endSpan(span Span, err *error) {
+	span.End(*err)
+}
+
+type nopSpan struct{}
+
+func (nopSpan) SetAttribute(string, interface{}) {}
+func (nopSpan) End(error)                        {}
+
+// tracing starts the root span of each request
+func tracing(t Tracer) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			name := r.Method
+			var tpl string
+			if route := mux.CurrentRoute(r); route != nil {
+				tpl, _ = route.GetPathTemplate()
+				name += " " + tpl
+			}
+			ctx, span := t.Start(context.WithValue(r.Context(), tracerKey{}, t), name, r.Header)
+			span.SetAttribute("http.method", r.Method)
+			span.SetAttribute("http.route", tpl)
+
+			sw := &statusWriter{ResponseWriter: w, code: http.StatusOK}
+			next.ServeHTTP(sw, r.WithContext(ctx))
+
+			span.SetAttribute("http.status_code", sw.code)
+			var err error
+			if sw.code >= 500 {
+				err = errors.New(http.StatusText(sw.code))
+			}
+			span.End(err)
+		})
+	}
+}
+
+// statusWriter keeps the status code written to an http.ResponseWriter
+type statusWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	w.code = code
+	w.ResponseWriter.WriteHeader(code)
+}
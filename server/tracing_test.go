@@ -0,0 +1,122 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+type testSpan struct {
+	name   string
+	parent string
+	header http.Header
+	attrs  map[string]interface{}
+	ended  bool
+	err    error
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *testSpan) End(err error) {
+	s.ended = true
+	s.err = err
+}
+
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+type testSpanKey struct{}
+
+func (t *testTracer) Start(ctx context.Context, name string, header http.Header) (context.Context, Span) {
+	span := &testSpan{name: name, header: header, attrs: make(map[string]interface{})}
+	if parent, ok := ctx.Value(testSpanKey{}).(*testSpan); ok {
+		span.parent = parent.name
+	}
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return context.WithValue(ctx, testSpanKey{}, span), span
+}
+
+func (t *testTracer) span(name string) *testSpan {
+	for _, s := range t.spans {
+		if s.name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func TestTracing(t *testing.T) {
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	tracer := &testTracer{}
+	handler := MakeHTTPHandler(NewService(repo), repo, log.NewNopLogger(), WithTracer(tracer))
+
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "ppd-mixedDebitCredit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	req := httptest.NewRequest("POST", "/files/create", fd)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	w.Flush()
+	if w.Code != http.StatusOK {
+		t.Fatalf("bogus HTTP status: %d: %s", w.Code, w.Body.String())
+	}
+
+	root := tracer.span("POST /files/create")
+	if root == nil || !root.ended || root.err != nil {
+		t.Fatalf("unexpected root span: %#v", root)
+	}
+	if root.header.Get("traceparent") == "" {
+		t.Error("missing request headers on the root span")
+	}
+	if code := root.attrs["http.status_code"]; code != http.StatusOK {
+		t.Errorf("http.status_code=%v", code)
+	}
+	for _, name := range []string{"ReadFile", "Repository.StoreFile"} {
+		span := tracer.span(name)
+		if span == nil || !span.ended || span.parent != "POST /files/create" || span.header != nil {
+			t.Errorf("unexpected %s span: %#v", name, span)
+		}
+	}
+	if n, _ := tracer.span("ReadFile").attrs["bytes"].(int64); n == 0 {
+		t.Error("ReadFile span has no bytes")
+	}
+
+	// missing files are traced as well
+	tracer.spans = nil
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/files/missing", nil))
+	w.Flush()
+	if span := tracer.span("GET /files/{id}"); span == nil || span.attrs["http.status_code"] != http.StatusNotFound {
+		t.Errorf("unexpected span: %#v", span)
+	}
+}
@@ -0,0 +1,136 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"time"
+)
+
+// sameDayWindows are the Same Day ACH submission deadlines of the Federal Reserve along with when entries
+// submitted by each deadline settle and when RDFIs must make the funds of credits available, after midnight Eastern
+var sameDayWindows = []struct {
+	deadline, settles, available time.Duration
+}{
+	{10*time.Hour + 30*time.Minute, 13 * time.Hour, 13*time.Hour + 30*time.Minute},
+	{14*time.Hour + 45*time.Minute, 17 * time.Hour, 17 * time.Hour},
+	{SameDaySubmissionDeadline, 18 * time.Hour, 24 * time.Hour}, // by the end of the RDFI's processing day
+}
+
+const (
+	// nextDaySettlement is when the Federal Reserve settles entries which aren't Same Day, after midnight Eastern
+	nextDaySettlement = 8*time.Hour + 30*time.Minute
+
+	// ppdCreditAvailability and creditAvailability are when the funds of credits which aren't Same Day
+	// must be available on their settlement date, after midnight Eastern
+	ppdCreditAvailability = 9 * time.Hour
+	creditAvailability    = 17 * time.Hour
+)
+
+// SettlementOptions describes an entry whose settlement ExpectedSettlement computes
+type SettlementOptions struct {
+	// SECCode is the StandardEntryClassCode of the entry's batch
+	SECCode string
+	// Credit is true for credit entries and false for debits
+	Credit bool
+	// Amount of the entry in cents, entries over SameDayEntryLimit can't settle Same Day
+	Amount int
+	// SameDay requests Same Day ACH settlement when the entry is eligible for it
+	SameDay bool
+	// EffectiveEntryDate is the day the entry should settle on, the day it's submitted when zero
+	EffectiveEntryDate Date
+	// Submitted is when the entry is sent to the ACH Operator, now when zero
+	Submitted time.Time
+}
+
+// Settlement is when an entry is expected to settle and the funds of a credit are available to the Receiver
+type Settlement struct {
+	// Date is the banking day the entry settles on
+	Date Date
+	// Settles is when the Federal Reserve settles the entry
+	Settles time.Time
+	// SameDay is true when the entry settles with Same Day ACH
+	SameDay bool
+	// FundsAvailable is when the RDFI must make the funds of a credit available, in Eastern time as the
+	// RDFI's local time isn't known. It's zero for debits.
+	FundsAvailable time.Time
+}
+
+// ExpectedSettlement returns when an entry settles following the Federal Reserve's schedule and when the
+// funds of a credit are available under the NACHA rules.
+//
+// Entries settle on their EffectiveEntryDate, or the next banking day when it's a weekend or holiday, but no
+// sooner than the banking day after they're submitted. Same Day entries settle the day they're submitted when
+// it's a banking day before SameDaySubmissionDeadline, their EffectiveEntryDate isn't after it and they're
+// eligible: IAT entries and those over SameDayEntryLimit aren't. The funds of PPD credits are available by
+// 9:00 a.m. on the settlement date and other credits by 5:00 p.m., or shortly after Same Day credits settle.
+func ExpectedSettlement(opts SettlementOptions) Settlement {
+	loc := easternLocation()
+	submitted := opts.Submitted
+	if submitted.IsZero() {
+		submitted = time.Now()
+	}
+	submitted = submitted.In(loc)
+	submittedDay := startOfDay(submitted)
+
+	effective := submittedDay
+	if !opts.EffectiveEntryDate.IsZero() {
+		d := opts.EffectiveEntryDate
+		effective = time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+	}
+
+	if opts.SameDay && opts.SECCode != IAT && opts.Amount <= SameDayEntryLimit && IsBankingDay(submittedDay) && !effective.After(submittedDay) {
+		elapsed := time.Duration(submitted.Hour())*time.Hour + time.Duration(submitted.Minute())*time.Minute + time.Duration(submitted.Second())*time.Second
+		for _, window := range sameDayWindows {
+			if elapsed >= window.deadline {
+				continue
+			}
+			out := Settlement{
+				Date:    DateOf(submittedDay),
+				Settles: wallClock(submittedDay, window.settles),
+				SameDay: true,
+			}
+			if opts.Credit {
+				out.FundsAvailable = wallClock(submittedDay, window.available)
+			}
+			return out
+		}
+	}
+
+	day := NextBankingDay(effective, true)
+	if earliest := NextBankingDay(submittedDay, false); day.Before(earliest) {
+		day = earliest
+	}
+	out := Settlement{
+		Date:    DateOf(day),
+		Settles: wallClock(day, nextDaySettlement),
+	}
+	if opts.Credit {
+		if opts.SECCode == PPD {
+			out.FundsAvailable = wallClock(day, ppdCreditAvailability)
+		} else {
+			out.FundsAvailable = wallClock(day, creditAvailability)
+		}
+	}
+	return out
+}
+
+// wallClock returns the time d after midnight of day on the clock, which differs from day.Add(d) when
+// daylight saving time starts or ends on day
+func wallClock(day time.Time, d time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, int(d), day.Location())
+}
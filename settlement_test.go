@@ -0,0 +1,89 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpectedSettlement(t *testing.T) {
+	loc := easternLocation()
+	at := func(day, clock string) time.Time {
+		when, err := time.ParseInLocation("2006-01-02 15:04", day+" "+clock, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return when
+	}
+
+	// Tuesday morning
+	submitted := at("2019-06-25", "09:00")
+	cases := []struct {
+		opts                     SettlementOptions
+		date, settles, available string
+		sameDay                  bool
+	}{
+		// next day PPD credit
+		{SettlementOptions{SECCode: PPD, Credit: true}, "2019-06-26", "2019-06-26 08:30", "2019-06-26 09:00", false},
+		// other credits are available later in the day and debits aren't made available
+		{SettlementOptions{SECCode: CCD, Credit: true}, "2019-06-26", "2019-06-26 08:30", "2019-06-26 17:00", false},
+		{SettlementOptions{SECCode: PPD}, "2019-06-26", "2019-06-26 08:30", "", false},
+		// a future EffectiveEntryDate on a weekend settles Monday
+		{SettlementOptions{SECCode: PPD, Credit: true, EffectiveEntryDate: Date{Year: 2019, Month: time.June, Day: 29}}, "2019-07-01", "2019-07-01 08:30", "2019-07-01 09:00", false},
+		// Same Day in the first window
+		{SettlementOptions{SECCode: PPD, Credit: true, SameDay: true}, "2019-06-25", "2019-06-25 13:00", "2019-06-25 13:30", true},
+		// IAT and large entries aren't eligible for Same Day
+		{SettlementOptions{SECCode: IAT, Credit: true, SameDay: true}, "2019-06-26", "2019-06-26 08:30", "2019-06-26 17:00", false},
+		{SettlementOptions{SECCode: PPD, SameDay: true, Amount: SameDayEntryLimit + 1}, "2019-06-26", "2019-06-26 08:30", "", false},
+		// a future EffectiveEntryDate isn't settled Same Day
+		{SettlementOptions{SECCode: PPD, SameDay: true, EffectiveEntryDate: Date{Year: 2019, Month: time.June, Day: 27}}, "2019-06-27", "2019-06-27 08:30", "", false},
+		// later Same Day windows
+		{SettlementOptions{SECCode: CCD, Credit: true, SameDay: true, Submitted: at("2019-06-25", "14:00")}, "2019-06-25", "2019-06-25 17:00", "2019-06-25 17:00", true},
+		{SettlementOptions{SECCode: CCD, Credit: true, SameDay: true, Submitted: at("2019-06-25", "16:00")}, "2019-06-25", "2019-06-25 18:00", "2019-06-26 00:00", true},
+		// after the last window entries settle the next banking day, skipping Independence Day
+		{SettlementOptions{SECCode: PPD, Credit: true, SameDay: true, Submitted: at("2019-07-03", "17:00")}, "2019-07-05", "2019-07-05 08:30", "2019-07-05 09:00", false},
+		// a stale EffectiveEntryDate settles the next banking day
+		{SettlementOptions{SECCode: PPD, EffectiveEntryDate: Date{Year: 2019, Month: time.June, Day: 20}}, "2019-06-26", "2019-06-26 08:30", "", false},
+	}
+	for i, c := range cases {
+		if c.opts.Submitted.IsZero() {
+			c.opts.Submitted = submitted
+		}
+		got := ExpectedSettlement(c.opts)
+		if got.Date.String() != c.date || got.SameDay != c.sameDay {
+			t.Errorf("#%d: date=%s sameDay=%v", i, got.Date, got.SameDay)
+		}
+		if v := got.Settles.Format("2006-01-02 15:04"); v != c.settles {
+			t.Errorf("#%d: settles=%s", i, v)
+		}
+		var available string
+		if !got.FundsAvailable.IsZero() {
+			available = got.FundsAvailable.In(loc).Format("2006-01-02 15:04")
+		}
+		if available != c.available {
+			t.Errorf("#%d: fundsAvailable=%s", i, available)
+		}
+	}
+
+	// times are read in Eastern
+	utc := time.Date(2019, time.June, 25, 18, 30, 0, 0, time.UTC) // 14:30 Eastern
+	if got := ExpectedSettlement(SettlementOptions{SECCode: PPD, SameDay: true, Submitted: utc}); !got.SameDay || got.Settles.Hour() != 17 {
+		t.Errorf("unexpected settlement: %#v", got)
+	}
+}
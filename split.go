@@ -0,0 +1,125 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"sort"
+
+	"github.com/moov-io/base"
+)
+
+// SplitByEffectiveDate returns a file for each EffectiveEntryDate of f's batches, in order of their dates,
+// for ODFIs which require a separate file per settlement date. Each file has f's header, with a new ID and
+// the FileIDModifiers following f's (e.g. A, B, C) so files created the same day aren't duplicates, and has
+// been built with Create() and validated. f is left unchanged and is returned by itself when all its batches
+// have the same date.
+func (f *File) SplitByEffectiveDate() ([]*File, error) {
+	dates := make(map[string]*File)
+	var order []string
+	file := func(date string) *File {
+		out, ok := dates[date]
+		if !ok {
+			out = NewFile()
+			out.Header = f.Header
+			out.SetValidation(f.validateOpts)
+			dates[date] = out
+			order = append(order, date)
+		}
+		return out
+	}
+	for _, b := range f.Batches {
+		copied, err := copyBatch(b)
+		if err != nil {
+			return nil, err
+		}
+		file(b.GetHeader().EffectiveEntryDate).AddBatch(copied)
+	}
+	for _, b := range f.IATBatches {
+		file(b.Header.EffectiveEntryDate).AddIATBatch(copyIATBatch(b))
+	}
+	if len(order) <= 1 {
+		return []*File{f}, nil
+	}
+
+	sort.Strings(order) // YYMMDD
+	modifier := f.Header.FileIDModifier
+	out := make([]*File, 0, len(order))
+	for i, date := range order {
+		file := dates[date]
+		file.ID = base.ID()
+		file.Header.ID = ""
+		if i > 0 {
+			var err error
+			if modifier, err = NextFileIDModifier(modifier); err != nil {
+				return nil, err
+			}
+		}
+		file.Header.FileIDModifier = modifier
+		if err := file.Create(); err != nil {
+			return nil, err
+		}
+		if err := file.Validate(); err != nil {
+			return nil, err
+		}
+		out = append(out, file)
+	}
+	return out, nil
+}
+
+// copyBatch returns a batch with copies of b's header and controls and its entries and validation options,
+// so files it's added to don't renumber b
+func copyBatch(b Batcher) (Batcher, error) {
+	bh := *b.GetHeader()
+	out, err := NewBatch(&bh)
+	if err != nil {
+		return nil, err
+	}
+	out.SetID(b.ID())
+	if v, ok := b.(interface{ validation() *ValidateOpts }); ok {
+		out.SetValidation(v.validation())
+	}
+	for _, entry := range b.GetEntries() {
+		out.AddEntry(entry)
+	}
+	for _, entry := range b.GetADVEntries() {
+		out.AddADVEntry(entry)
+	}
+	if c := b.GetControl(); c != nil {
+		control := *c
+		out.SetControl(&control)
+	}
+	if c := b.GetADVControl(); c != nil {
+		control := *c
+		out.SetADVControl(&control)
+	}
+	return out, nil
+}
+
+// copyIATBatch returns b with copies of its header and control
+func copyIATBatch(b IATBatch) IATBatch {
+	if b.Header != nil {
+		bh := *b.Header
+		b.Header = &bh
+	}
+	if b.Control != nil {
+		control := *b.Control
+		b.Control = &control
+	}
+	b.Entries = append([]*IATEntryDetail(nil), b.Entries...)
+	return b
+}
@@ -0,0 +1,80 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"testing"
+)
+
+func TestFile__SplitByEffectiveDate(t *testing.T) {
+	f := NewFile()
+	f.SetHeader(mockFileHeader())
+	for i, date := range []string{"261020", "261019", "261020"} {
+		bh := mockBatchPPDHeader()
+		bh.EffectiveEntryDate = date
+		batch := NewBatchPPD(bh)
+		entry := mockPPDEntryDetail()
+		entry.SetTraceNumber(bh.ODFIIdentification, i+1)
+		batch.AddEntry(entry)
+		if err := batch.Create(); err != nil {
+			t.Fatal(err)
+		}
+		f.AddBatch(batch)
+	}
+	iatBatch := mockIATBatch(t)
+	iatBatch.Header.EffectiveEntryDate = "261019"
+	f.AddIATBatch(iatBatch)
+	if err := f.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := f.SplitByEffectiveDate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("got %d files", len(files))
+	}
+	first, second := files[0], files[1]
+	if len(first.Batches) != 1 || len(first.IATBatches) != 1 || first.Batches[0].GetHeader().EffectiveEntryDate != "261019" {
+		t.Errorf("unexpected first file: %#v", first)
+	}
+	if len(second.Batches) != 2 || second.Batches[1].GetHeader().EffectiveEntryDate != "261020" {
+		t.Errorf("unexpected second file: %#v", second)
+	}
+	if first.Header.FileIDModifier != "A" || second.Header.FileIDModifier != "B" || first.ID == "" || first.ID == second.ID {
+		t.Errorf("unexpected headers: %#v %#v", first.Header, second.Header)
+	}
+	if second.Batches[1].GetHeader().BatchNumber != 2 || first.IATBatches[0].Header.BatchNumber != 2 {
+		t.Errorf("batches weren't numbered within their file")
+	}
+	if second.Control.BatchCount != 2 || second.Control.EntryAddendaCount != 2 {
+		t.Errorf("unexpected control: %#v", second.Control)
+	}
+
+	// f is left alone
+	if f.Batches[2].GetHeader().BatchNumber != 3 || f.IATBatches[0].Header.BatchNumber != 4 || f.Control.BatchCount != 4 {
+		t.Errorf("source file was changed")
+	}
+
+	// a file with a single date isn't split
+	files, err = first.SplitByEffectiveDate()
+	if err != nil || len(files) != 1 || files[0] != first {
+		t.Errorf("unexpected files: %v", err)
+	}
+}
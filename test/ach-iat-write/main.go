@@ -193,7 +193,7 @@ func main() {
 	addenda17Two.PaymentRelatedInformation = "This is an international payment"
 	addenda17Two.SequenceNumber = 1
 	addenda17Two.EntryDetailSequenceNumber = 0000002
-	entry.AddAddenda17(addenda17)
+	entryTwo.AddAddenda17(addenda17Two)
 
 	addenda18Two := ach.NewAddenda18()
 	addenda18Two.ForeignCorrespondentBankName = "Bank of France"
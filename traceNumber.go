@@ -0,0 +1,135 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// maxTraceSequence is the largest sequence number which fits into the last 7 digits of a TraceNumber
+const maxTraceSequence = 9999999
+
+var (
+	// ErrTraceNumberSequence is given when a TraceNumberGenerator has used every sequence number for an ODFI
+	ErrTraceNumberSequence = errors.New("no more TraceNumber sequence numbers are available")
+)
+
+// TraceNumberGenerator assigns TraceNumbers to entries when their batch is created. A generator
+// can be shared between batches so TraceNumbers are not repeated across a file.
+type TraceNumberGenerator interface {
+	// Next returns the next 15 digit TraceNumber for the 8 digit ODFI identification
+	Next(ODFIIdentification string) (string, error)
+}
+
+// traceNumberSetter is implemented by batches which accept a TraceNumberGenerator, such as every
+// batch embedding Batch. It isn't part of Batcher so other implementations don't need it.
+type traceNumberSetter interface {
+	SetTraceNumberGenerator(TraceNumberGenerator)
+}
+
+// setTraceNumberGenerator sets gen on batch when it accepts a TraceNumberGenerator
+func setTraceNumberGenerator(batch Batcher, gen TraceNumberGenerator) {
+	if b, ok := batch.(traceNumberSetter); ok {
+		b.SetTraceNumberGenerator(gen)
+	}
+}
+
+// NewTraceNumberGenerator returns a TraceNumberGenerator which prefixes the ODFI identification
+// onto a sequence number which increases with every call. When file is not nil the sequence
+// for each ODFI starts after the highest TraceNumber already used in file.
+func NewTraceNumberGenerator(file *File) TraceNumberGenerator {
+	gen := &sequentialTraceNumbers{
+		last: make(map[string]int),
+	}
+	if file == nil {
+		return gen
+	}
+	for _, batch := range file.Batches {
+		for _, entry := range batch.GetEntries() {
+			gen.observe(entry.TraceNumberField())
+		}
+	}
+	for _, iatBatch := range file.IATBatches {
+		for _, entry := range iatBatch.GetEntries() {
+			gen.observe(entry.TraceNumberField())
+		}
+	}
+	return gen
+}
+
+type sequentialTraceNumbers struct {
+	mu   sync.Mutex
+	last map[string]int
+}
+
+func (gen *sequentialTraceNumbers) Next(ODFIIdentification string) (string, error) {
+	var c converters
+	odfi := c.stringField(ODFIIdentification, 8)
+
+	gen.mu.Lock()
+	defer gen.mu.Unlock()
+
+	seq := gen.last[odfi] + 1
+	if seq > maxTraceSequence {
+		return "", fmt.Errorf("ODFI %s: %w", odfi, ErrTraceNumberSequence)
+	}
+	gen.last[odfi] = seq
+	return odfi + c.numericField(seq, 7), nil
+}
+
+// observe moves the sequence of a TraceNumber's ODFI past it
+func (gen *sequentialTraceNumbers) observe(traceNumber string) {
+	seq, err := strconv.Atoi(traceNumber[8:])
+	if err != nil {
+		return
+	}
+	if odfi := traceNumber[:8]; seq > gen.last[odfi] {
+		gen.last[odfi] = seq
+	}
+}
+
+// isTraceNumberUnique checks that no two entries in the file, across all of its batches,
+// share a TraceNumber
+func (f *File) isTraceNumberUnique() error {
+	seen := make(map[string]bool)
+	check := func(traceNumber string) error {
+		if seen[traceNumber] {
+			return NewErrFileDuplicateTraceNumber(traceNumber)
+		}
+		seen[traceNumber] = true
+		return nil
+	}
+	for _, batch := range f.Batches {
+		for _, entry := range batch.GetEntries() {
+			if err := check(entry.TraceNumberField()); err != nil {
+				return err
+			}
+		}
+	}
+	for _, iatBatch := range f.IATBatches {
+		for _, entry := range iatBatch.GetEntries() {
+			if err := check(entry.TraceNumberField()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/moov-io/base"
+)
+
+func TestTraceNumberGenerator(t *testing.T) {
+	gen := NewTraceNumberGenerator(nil)
+	for _, want := range []string{"121042880000001", "121042880000002"} {
+		traceNumber, err := gen.Next("12104288")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if traceNumber != want {
+			t.Errorf("got %s, expected %s", traceNumber, want)
+		}
+	}
+	// each ODFI has its own sequence
+	if traceNumber, _ := gen.Next("23138010"); traceNumber != "231380100000001" {
+		t.Errorf("unexpected TraceNumber: %s", traceNumber)
+	}
+}
+
+func TestTraceNumberGenerator__seeded(t *testing.T) {
+	file := mockFilePPD()
+	file.Batches[0].GetEntries()[0].SetTraceNumber("12104288", 41)
+
+	gen := NewTraceNumberGenerator(file)
+	if traceNumber, _ := gen.Next("12104288"); traceNumber != "121042880000042" {
+		t.Errorf("unexpected TraceNumber: %s", traceNumber)
+	}
+}
+
+func TestTraceNumberGenerator__exhausted(t *testing.T) {
+	gen := NewTraceNumberGenerator(nil)
+	gen.(*sequentialTraceNumbers).last["12104288"] = maxTraceSequence
+
+	if _, err := gen.Next("12104288"); !errors.Is(err, ErrTraceNumberSequence) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+func TestTraceNumberGenerator__acrossBatches(t *testing.T) {
+	gen := NewTraceNumberGenerator(nil)
+
+	var batches []*BatchPPD
+	for i := 0; i < 2; i++ {
+		entry := mockPPDEntryDetail()
+		entry.TraceNumber = ""
+		batch := NewBatchPPD(mockBatchPPDHeader())
+		batch.AddEntry(entry)
+		batch.SetTraceNumberGenerator(gen)
+		if err := batch.Create(); err != nil {
+			t.Fatal(err)
+		}
+		batches = append(batches, batch)
+	}
+
+	first, second := batches[0].GetEntries()[0], batches[1].GetEntries()[0]
+	if first.TraceNumber != "121042880000001" || second.TraceNumber != "121042880000002" {
+		t.Errorf("first=%s second=%s", first.TraceNumber, second.TraceNumber)
+	}
+
+	// without a generator both batches start from 1
+	entry := mockPPDEntryDetail()
+	entry.TraceNumber = ""
+	batch := NewBatchPPD(mockBatchPPDHeader())
+	batch.AddEntry(entry)
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if entry.TraceNumber != "121042880000001" {
+		t.Errorf("unexpected TraceNumber: %s", entry.TraceNumber)
+	}
+}
+
+func TestFile__RequireUniqueTraceNumbers(t *testing.T) {
+	file := mockFilePPD()
+	file.AddBatch(mockBatchPPD())
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := file.ValidateWith(&ValidateOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	err := file.ValidateWith(&ValidateOpts{RequireUniqueTraceNumbers: true})
+	if !base.Match(err, NewErrFileDuplicateTraceNumber("121042880000001")) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	file.Batches[1].GetEntries()[0].SetTraceNumber("12104288", 2)
+	if err := file.ValidateWith(&ValidateOpts{RequireUniqueTraceNumbers: true}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFile__StrictNACHA(t *testing.T) {
+	file := mockFilePPD()
+	file.AddBatch(mockBatchPPD())
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	// duplicate TraceNumbers are only rejected by the strict rules
+	if err := file.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	err := file.ValidateWith(StrictNACHA())
+	if !base.Match(err, NewErrFileDuplicateTraceNumber("121042880000001")) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	opts := StrictNACHA()
+	if !opts.strict || opts.BypassOriginValidation || opts.CorrectServiceClassCode || !opts.RequireABAOrigin || len(opts.MaxAddendaPerEntry) != 0 {
+		t.Errorf("lenient option enabled: %#v", opts)
+	}
+}
+
+func TestFile__StrictNACHABatchOverrides(t *testing.T) {
+	file := mockFilePPD()
+	batch := file.Batches[0]
+	entry := batch.GetEntries()[0]
+	entry.AddendaRecordIndicator = 1
+	for i := 0; i < 3; i++ {
+		entry.AddAddenda05(mockAddenda05())
+	}
+	// the batch allows three Addenda05 records on PPD entries, NACHA allows one
+	batch.SetValidation(&ValidateOpts{MaxAddendaPerEntry: map[string]int{PPD: 3}})
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	err := file.ValidateWith(StrictNACHA())
+	if !base.Match(err, NewErrBatchAddendaCount(3, 1)) {
+		t.Errorf("%T: %v", err, err)
+	}
+	// the batch keeps its own options
+	if err := batch.Validate(); err != nil {
+		t.Error(err)
+	}
+}
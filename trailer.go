@@ -0,0 +1,71 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"strings"
+)
+
+// TrailerRecordValidator checks a proprietary record found after the FileControl, such as the
+// proof or summary records some processors append. file has been read up to and including
+// its FileControl. Returning an error rejects the record and the file.
+type TrailerRecordValidator func(file *File, record string) error
+
+// AllowTrailerRecords has the Reader keep records found after the FileControl in File.TrailerRecords
+// instead of returning errors for them. Each record is checked by validate when it's not nil.
+//
+// Block padding (lines of 9's) is never kept as a trailer record.
+func (r *Reader) AllowTrailerRecords(validate TrailerRecordValidator) {
+	r.allowTrailerRecords = true
+	r.trailerRecordValidator = validate
+}
+
+// isTrailerRecord returns true if the current line follows the FileControl and should be
+// kept as a trailer record
+func (r *Reader) isTrailerRecord() bool {
+	if !r.allowTrailerRecords {
+		return false
+	}
+	if (FileControl{}) == r.File.Control && (ADVFileControl{}) == r.File.ADVControl {
+		return false
+	}
+	return strings.Trim(r.line, "9") != ""
+}
+
+// parseTrailerRecord validates and keeps the current line as a trailer record
+func (r *Reader) parseTrailerRecord() error {
+	r.recordName = "TrailerRecord"
+	if r.trailerRecordValidator != nil {
+		if err := r.trailerRecordValidator(&r.File, r.line); err != nil {
+			return r.parseError(err)
+		}
+	}
+	r.File.TrailerRecords = append(r.File.TrailerRecords, r.line)
+	return nil
+}
+
+// writeTrailerRecords writes each of the file's trailer records
+func (w *Writer) writeTrailerRecords(file *File) error {
+	for _, record := range file.TrailerRecords {
+		if err := w.writeLine(record); err != nil {
+			return err
+		}
+		w.lineNum++
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base"
+)
+
+// mockProofRecord is a balanced "batch total" proof record as appended by some processors
+func mockProofRecord(debits int) string {
+	return fmt.Sprintf("PROOF%012d%s", debits, strings.Repeat(" ", 77))
+}
+
+var errUnbalancedProof = errors.New("proof record is unbalanced")
+
+func validateProofRecord(file *File, record string) error {
+	debits, err := strconv.Atoi(record[5:17])
+	if err != nil {
+		return err
+	}
+	if debits != file.Control.TotalDebitEntryDollarAmountInFile {
+		return errUnbalancedProof
+	}
+	return nil
+}
+
+// withTrailerRecord returns the lines of ppd-debit.ach with record after its FileControl
+func withTrailerRecord(t *testing.T, record string) string {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(bs), "\n")
+	lines = append(lines[:5], append([]string{record}, lines[5:]...)...)
+	return strings.Join(lines, "\n")
+}
+
+func TestReader__TrailerRecords(t *testing.T) {
+	input := withTrailerRecord(t, mockProofRecord(100000000))
+
+	// trailer records aren't read by default
+	if _, err := NewReader(strings.NewReader(input)).Read(); err == nil {
+		t.Error("expected error")
+	}
+
+	r := NewReader(strings.NewReader(input))
+	r.AllowTrailerRecords(validateProofRecord)
+	file, err := r.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(file.TrailerRecords) != 1 || file.TrailerRecords[0] != mockProofRecord(100000000) {
+		t.Errorf("unexpected TrailerRecords: %q", file.TrailerRecords)
+	}
+
+	// the validator rejects unbalanced records
+	r = NewReader(strings.NewReader(withTrailerRecord(t, mockProofRecord(1))))
+	r.AllowTrailerRecords(validateProofRecord)
+	if _, err := r.Read(); !base.Has(err, errUnbalancedProof) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// without a validator every record is kept
+	r = NewReader(strings.NewReader(withTrailerRecord(t, mockProofRecord(1))))
+	r.AllowTrailerRecords(nil)
+	if file, err := r.Read(); err != nil || len(file.TrailerRecords) != 1 {
+		t.Errorf("TrailerRecords=%q error=%v", file.TrailerRecords, err)
+	}
+}
+
+func TestWriter__TrailerRecords(t *testing.T) {
+	r := NewReader(strings.NewReader(withTrailerRecord(t, mockProofRecord(100000000))))
+	r.AllowTrailerRecords(validateProofRecord)
+	file, err := r.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(&file); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "PROOF") {
+		t.Error("trailer records were written")
+	}
+
+	buf.Reset()
+	w := NewWriter(&buf)
+	w.WriteTrailerRecords = true
+	if err := w.Write(&file); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 10 || lines[5] != mockProofRecord(100000000) {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+
+	// trailer records are kept through JSON
+	bs, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.TrailerRecords) != 1 {
+		t.Errorf("unexpected TrailerRecords: %q", out.TrailerRecords)
+	}
+}
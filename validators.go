@@ -18,19 +18,42 @@
 package ach
 
 import (
-	"errors"
 	"fmt"
 	"math"
 	"regexp"
-	"strconv"
 	"unicode/utf8"
+
+	"github.com/moov-io/ach/routing"
 )
 
 var (
 	upperAlphanumericRegex = regexp.MustCompile(`[^ A-Z0-9!"#$%&'()*+,-.\\/:;<>=?@\[\]^_{}|~]+`)
 	alphanumericRegex      = regexp.MustCompile(`[^ \w!"#$%&'()*+,-.\\/:;<>=?@\[\]^_{}|~]+`)
+
+	// upperAlphanumeric and alphanumeric are the ASCII characters each regex accepts, so fields are
+	// checked a byte at a time rather than with the regexes. Every other byte is rejected.
+	upperAlphanumeric = asciiAccepted(upperAlphanumericRegex)
+	alphanumeric      = asciiAccepted(alphanumericRegex)
 )
 
+// asciiAccepted returns the ASCII characters not matched by invalid, a regex of invalid characters
+func asciiAccepted(invalid *regexp.Regexp) (accepted [utf8.RuneSelf]bool) {
+	for c := range accepted {
+		accepted[c] = !invalid.MatchString(string(rune(c)))
+	}
+	return accepted
+}
+
+// onlyAccepted returns true if every byte of s is accepted
+func onlyAccepted(s string, accepted *[utf8.RuneSelf]bool) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf || !accepted[s[i]] {
+			return false
+		}
+	}
+	return true
+}
+
 // validator is common validation and formatting of golang types to ach type strings
 type validator struct{}
 
@@ -38,22 +61,14 @@ type validator struct{}
 func (v *validator) isCardTransactionType(code string) error {
 	switch code {
 	case
-		// Purchase of goods or services
-		"01",
-		// Cash
-		"02",
-		// Return Reversal
-		"03",
-		// Purchase Reversal
-		"11",
-		// Cash Reversal
-		"12",
-		// Return
-		"13",
-		// Adjustment
-		"21",
-		// Miscellaneous Transaction
-		"99":
+		CardTransactionPurchase,
+		CardTransactionCash,
+		CardTransactionReturnReversal,
+		CardTransactionPurchaseReversal,
+		CardTransactionCashReversal,
+		CardTransactionReturn,
+		CardTransactionAdjustment,
+		CardTransactionMiscellaneous:
 		return nil
 	}
 	return ErrCardTransactionType
@@ -184,6 +199,41 @@ func (v *validator) isForeignExchangeReferenceIndicator(code int) error {
 	return ErrForeignExchangeReferenceIndicator
 }
 
+// isForeignExchangeRate ensures a ForeignExchangeReference carrying an exchange
+// rate is a positive decimal number such as 1.3542
+func (v *validator) isForeignExchangeRate(rate string) error {
+	digits, dots := 0, 0
+	for _, r := range rate {
+		switch {
+		case r >= '0' && r <= '9':
+			if r != '0' {
+				digits++
+			}
+		case r == '.':
+			dots++
+		default:
+			return ErrForeignExchangeRate
+		}
+	}
+	if digits == 0 || dots > 1 {
+		return ErrForeignExchangeRate
+	}
+	return nil
+}
+
+// isOFACScreeningIndicator ensures the OFAC screening indicators of an IATEntryDetail are valid
+// Blank - Not screened, which is expected from originators
+// 0 - No OFAC hit
+// 1 - Potential OFAC hit
+func (v *validator) isOFACScreeningIndicator(s string) error {
+	switch s {
+	case
+		"", " ", "0", "1":
+		return nil
+	}
+	return ErrOFACScreeningIndicator
+}
+
 // isIDNumberQualifier ensures ODFI Identification Number Qualifier is valid
 // For Inbound IATs: The 2-digit code that identifies the numbering scheme used in the
 // Foreign DFI Identification Number field:
@@ -217,13 +267,24 @@ func (v *validator) isOriginatorStatusCode(code int) error {
 
 // isSECCode returns true if a SEC Code of a Batch is found
 func (v *validator) isSECCode(code string) error {
+	if isNACHASECCode(code) {
+		return nil
+	}
+	if _, ok := registeredBatchType(code); ok {
+		return nil
+	}
+	return ErrSECCode
+}
+
+// isNACHASECCode returns true if code is a SEC Code defined by NACHA
+func isNACHASECCode(code string) bool {
 	switch code {
 	case
 		ACK, ADV, ARC, ATX, BOC, CCD, CIE, COR, CTX, DNE, ENR,
 		IAT, MTE, POS, PPD, POP, RCK, SHR, TEL, TRC, TRX, WEB, XCK:
-		return nil
+		return true
 	}
-	return ErrSECCode
+	return false
 }
 
 // iServiceClass returns true if a valid service class code of a batch is found
@@ -412,7 +473,7 @@ func (v *validator) isTransactionTypeCode(s string) error {
 
 // isUpperAlphanumeric checks if string only contains ASCII alphanumeric upper case characters
 func (v *validator) isUpperAlphanumeric(s string) error {
-	if upperAlphanumericRegex.MatchString(s) {
+	if !onlyAccepted(s, &upperAlphanumeric) {
 		return ErrUpperAlpha
 	}
 	return nil
@@ -420,7 +481,7 @@ func (v *validator) isUpperAlphanumeric(s string) error {
 
 // isAlphanumeric checks if a string only contains ASCII alphanumeric characters
 func (v *validator) isAlphanumeric(s string) error {
-	if alphanumericRegex.MatchString(s) {
+	if !onlyAccepted(s, &alphanumeric) {
 		// ^[ A-Za-z0-9_@./#&+-]*$/
 		return ErrNonAlphanumeric
 	}
@@ -439,50 +500,22 @@ func (v *validator) CalculateCheckDigit(routingNumber string) int {
 		return -1
 	}
 
-	var routeIndex [8]string
+	weights := [8]int{3, 7, 1, 3, 7, 1, 3, 7}
+	sum := 0
 	for i := 0; i < 8; i++ {
 		if routingNumber[i] < '0' || routingNumber[i] > '9' {
 			return -1 // only digits are allowed
 		}
-		routeIndex[i] = string(routingNumber[i])
+		sum += int(routingNumber[i]-'0') * weights[i]
 	}
-	n, _ := strconv.Atoi(routeIndex[0])
-	sum := n * 3
-	n, _ = strconv.Atoi(routeIndex[1])
-	sum = sum + (n * 7)
-	n, _ = strconv.Atoi(routeIndex[2])
-	sum = sum + n // multiply by 1
-	n, _ = strconv.Atoi(routeIndex[3])
-	sum = sum + (n * 3)
-	n, _ = strconv.Atoi(routeIndex[4])
-	sum = sum + (n * 7)
-	n, _ = strconv.Atoi(routeIndex[5])
-	sum = sum + n // multiply by 1
-	n, _ = strconv.Atoi(routeIndex[6])
-	sum = sum + (n * 3)
-	n, _ = strconv.Atoi(routeIndex[7])
-	sum = sum + (n * 7)
-
 	return v.roundUp10(sum) - sum
 }
 
 // CheckRoutingNumber returns a nil error if the provided routingNumber is valid according to
-// NACHA rules. See CalculateCheckDigit for details on computing the check digit.
+// NACHA rules. See CalculateCheckDigit for details on computing the check digit, and the routing
+// package for reading the parts of a routing number.
 func CheckRoutingNumber(routingNumber string) error {
-	if routingNumber == "" {
-		return errors.New("no routing number provided")
-	}
-	if n := utf8.RuneCountInString(routingNumber); n != 9 {
-		return fmt.Errorf("invalid routing number length of %d", n)
-	}
-
-	v := new(validator)
-	check := fmt.Sprintf("%d", v.CalculateCheckDigit(routingNumber))
-	last := string(routingNumber[len(routingNumber)-1])
-	if check != last {
-		return fmt.Errorf("routing number checksum mismatch: expected %s but got %s", check, last)
-	}
-	return nil
+	return routing.Validate(routingNumber)
 }
 
 // roundUp10 round number up to the next ten spot.
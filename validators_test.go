@@ -118,3 +118,19 @@ func TestValidators__validateSimpleTime(t *testing.T) {
 		}
 	}
 }
+
+func TestValidators__alphanumericMatchesRegex(t *testing.T) {
+	v := validator{}
+	inputs := []string{"", "ACME Corp. #1", "lower_case", "tick`", "café", "tab\t", "\xff"}
+	for c := 0; c < 256; c++ {
+		inputs = append(inputs, string(rune(c)), string([]byte{byte(c)}))
+	}
+	for _, s := range inputs {
+		if invalid := v.isAlphanumeric(s) != nil; invalid != alphanumericRegex.MatchString(s) {
+			t.Errorf("isAlphanumeric(%q) invalid=%v", s, invalid)
+		}
+		if invalid := v.isUpperAlphanumeric(s) != nil; invalid != upperAlphanumericRegex.MatchString(s) {
+			t.Errorf("isUpperAlphanumeric(%q) invalid=%v", s, invalid)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Warning is an unusual value of a File which passes validation, but may be rejected or mishandled by ODFIs.
+// TraceNumber is only set for entry level warnings.
+type Warning struct {
+	BatchNumber int    `json:"batchNumber,omitempty"`
+	TraceNumber string `json:"traceNumber,omitempty"`
+	FieldName   string `json:"fieldName"`
+	Message     string `json:"message"`
+}
+
+func (w Warning) String() string {
+	if w.TraceNumber == "" {
+		return fmt.Sprintf("batch #%d %s %s", w.BatchNumber, w.FieldName, w.Message)
+	}
+	return fmt.Sprintf("batch #%d entry %s %s %s", w.BatchNumber, w.TraceNumber, w.FieldName, w.Message)
+}
+
+// Warnings returns the unusual values of a File which, unlike the errors of Validate, don't prevent it from
+// being sent. Batches effective before today (in Eastern time) are settled the next banking day, lower case
+// CompanyEntryDescriptions are changed by some ODFIs and WEB and TEL entries should have a payment type of
+// R (recurring) or S (single) as their DiscretionaryData.
+func (f *File) Warnings() []Warning {
+	return f.warnings(Today())
+}
+
+func (f *File) warnings(today Date) []Warning {
+	var out []Warning
+	pastEffectiveEntryDate := func(batchNumber int, effectiveEntryDate string) {
+		t, err := time.Parse("060102", effectiveEntryDate) // YYMMDD
+		if d := DateOf(t); err == nil && d.Before(today) {
+			out = append(out, Warning{
+				BatchNumber: batchNumber,
+				FieldName:   "EffectiveEntryDate",
+				Message:     fmt.Sprintf("%s is in the past, the entries will settle the next banking day", d),
+			})
+		}
+	}
+	for _, batch := range f.Batches {
+		bh := batch.GetHeader()
+		pastEffectiveEntryDate(bh.BatchNumber, bh.EffectiveEntryDate)
+		if strings.IndexFunc(bh.CompanyEntryDescription, unicode.IsLower) >= 0 {
+			out = append(out, Warning{
+				BatchNumber: bh.BatchNumber,
+				FieldName:   "CompanyEntryDescription",
+				Message:     fmt.Sprintf("%q is printed on statements and some ODFIs require it in upper case", bh.CompanyEntryDescription),
+			})
+		}
+		if bh.StandardEntryClassCode != WEB && bh.StandardEntryClassCode != TEL {
+			continue
+		}
+		for _, entry := range batch.GetEntries() {
+			if v := strings.TrimSpace(entry.DiscretionaryData); v != "R" && v != "S" {
+				out = append(out, Warning{
+					BatchNumber: bh.BatchNumber,
+					TraceNumber: entry.TraceNumber,
+					FieldName:   "DiscretionaryData",
+					Message:     fmt.Sprintf("payment type %q of a %s entry is neither R (recurring) nor S (single)", v, bh.StandardEntryClassCode),
+				})
+			}
+		}
+	}
+	for i := range f.IATBatches {
+		pastEffectiveEntryDate(f.IATBatches[i].Header.BatchNumber, f.IATBatches[i].Header.EffectiveEntryDate)
+	}
+	return out
+}
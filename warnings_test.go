@@ -0,0 +1,75 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFile__Warnings(t *testing.T) {
+	today := Date{Year: 2019, Month: time.October, Day: 4}
+
+	f := mockFilePPD()
+	f.Batches[0].GetHeader().CompanyEntryDescription = "PAYROLL"
+	f.Batches[0].GetHeader().EffectiveEntryDate = "191004"
+	if warnings := f.warnings(today); len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+
+	// batches effective yesterday, lower case descriptions and unknown WEB payment types
+	f.Batches[0].GetHeader().EffectiveEntryDate = "191003"
+	web := mockBatchWEB()
+	web.GetHeader().BatchNumber = 2
+	web.GetEntries()[0].DiscretionaryData = "X"
+	f.AddBatch(web)
+	iatBatch := mockIATBatch(t)
+	iatBatch.GetHeader().BatchNumber = 3
+	iatBatch.GetHeader().EffectiveEntryDate = "190930"
+	f.AddIATBatch(iatBatch)
+
+	warnings := f.warnings(today)
+	expected := []Warning{
+		{BatchNumber: 1, FieldName: "EffectiveEntryDate"},
+		{BatchNumber: 2, FieldName: "CompanyEntryDescription"},
+		{BatchNumber: 2, TraceNumber: web.GetEntries()[0].TraceNumber, FieldName: "DiscretionaryData"},
+		{BatchNumber: 3, FieldName: "EffectiveEntryDate"},
+	}
+	if len(warnings) != len(expected) {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+	for i := range expected {
+		w := warnings[i]
+		if w.BatchNumber != expected[i].BatchNumber || w.TraceNumber != expected[i].TraceNumber || w.FieldName != expected[i].FieldName || w.Message == "" {
+			t.Errorf("#%d: %v", i, w)
+		}
+	}
+	if v := warnings[0].String(); v != "batch #1 EffectiveEntryDate 2019-10-03 is in the past, the entries will settle the next banking day" {
+		t.Errorf("got %s", v)
+	}
+
+	// warnings don't fail validation
+	f.Batches = f.Batches[:1]
+	f.IATBatches = nil
+	if err := f.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Validate(); err != nil || len(f.warnings(today)) != 1 {
+		t.Errorf("error=%v warnings=%v", err, f.warnings(today))
+	}
+}
@@ -0,0 +1,92 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+// JSONOpts changes how FileFromJSONWith reads a File.
+type JSONOpts struct {
+	// Draft reads the JSON as a workspace which is hand edited over time. Workspaces may
+	// contain // line and /* block */ comments and partial files, such as batches without
+	// a batchControl or fileControl. Drafts aren't built or validated when read; call
+	// File.Finalize once the workspace is complete.
+	Draft bool `json:"draft"`
+}
+
+// Finalize builds a File read as a draft workspace into a valid ACH file. Each batch is
+// created, which computes its BatchControl and sequence numbers, and then the FileControl
+// is computed and the File validated.
+//
+// Comments on the File and its batches are kept.
+func (f *File) Finalize() error {
+	for i := range f.Batches {
+		if err := f.Batches[i].Create(); err != nil {
+			return err
+		}
+	}
+	for i := range f.IATBatches {
+		if err := f.IATBatches[i].Create(); err != nil {
+			return err
+		}
+	}
+	if err := f.Create(); err != nil {
+		return err
+	}
+	return f.Validate()
+}
+
+// stripJSONComments replaces // line and /* block */ comments outside of JSON strings with
+// spaces so the result can be decoded as JSON. Newlines are kept so decoding errors refer
+// to the same positions as the workspace.
+func stripJSONComments(bs []byte) []byte {
+	out := make([]byte, len(bs))
+	copy(out, bs)
+
+	inString, escaped := false, false
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			out[i], out[i+1] = ' ', ' '
+			for i += 2; i < len(out); i++ {
+				if out[i] == '*' && i+1 < len(out) && out[i+1] == '/' {
+					out[i], out[i+1] = ' ', ' '
+					i++
+					break
+				}
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+			}
+		}
+	}
+	return out
+}
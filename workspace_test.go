@@ -0,0 +1,109 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWorkspace__Draft(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "ppd-workspace.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// comments aren't valid JSON outside of a draft
+	if _, err := FileFromJSON(bs); err == nil {
+		t.Error("expected error")
+	}
+
+	file, err := FileFromJSONWith(bs, &JSONOpts{Draft: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(file.Comments) != 2 || file.Comments[0] != "October payroll" {
+		t.Errorf("file comments: %v", file.Comments)
+	}
+	if len(file.Batches) != 1 {
+		t.Fatalf("batches: %d", len(file.Batches))
+	}
+	batch, ok := file.Batches[0].(*BatchPPD)
+	if !ok {
+		t.Fatalf("unexpected batch: %T", file.Batches[0])
+	}
+	if len(batch.Comments) != 1 || batch.Comments[0] != "weekly payroll" {
+		t.Errorf("batch comments: %v", batch.Comments)
+	}
+	if name := batch.Entries[0].IndividualName; name != "Steven Tander // Jr" {
+		t.Errorf("IndividualName=%q", name)
+	}
+	if batch.Control.TotalCreditEntryDollarAmount != 0 || file.Control.TotalCreditEntryDollarAmountInFile != 0 {
+		t.Error("draft was built")
+	}
+
+	if err := file.Finalize(); err != nil {
+		t.Fatal(err)
+	}
+	if batch.Control.EntryHash != 23138010 || file.Control.TotalCreditEntryDollarAmountInFile != 100000 {
+		t.Errorf("EntryHash=%d TotalCredit=%d", batch.Control.EntryHash, file.Control.TotalCreditEntryDollarAmountInFile)
+	}
+
+	// comments are kept when writing the workspace back out
+	out, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `"comments":["weekly payroll"]`) {
+		t.Errorf("missing batch comments: %s", out)
+	}
+	if _, err := FileFromJSON(out); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWorkspace__FinalizeError(t *testing.T) {
+	bs := []byte(`{"fileHeader": {"immediateOrigin": "121042882"}, "batches": []}`)
+	file, err := FileFromJSONWith(bs, &JSONOpts{Draft: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Finalize(); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestWorkspace__stripJSONComments(t *testing.T) {
+	cases := map[string]string{
+		`{"a": 1} // trailing`:          `{"a": 1}            `,
+		"{\"a\": 1, // note\n\"b\": 2}": "{\"a\": 1,        \n\"b\": 2}",
+		`{"a": /* one */ 1}`:            `{"a":           1}`,
+		"{/* multi\nline */\"a\": 1}":   "{        \n       \"a\": 1}",
+		`{"url": "http://moov.io"}`:     `{"url": "http://moov.io"}`,
+		`{"a": "quote \" // kept"}`:     `{"a": "quote \" // kept"}`,
+		`{"a": 1} /* unterminated`:      `{"a": 1}                `,
+	}
+	for in, expected := range cases {
+		if out := string(stripJSONComments([]byte(in))); out != expected {
+			t.Errorf("%q: got %q", in, out)
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"bufio"
 	"io"
 	"strings"
+	"time"
 )
 
 // A Writer writes an ach.file to a NACHA encoded file.
@@ -29,10 +30,55 @@ import (
 // NACHA formatted files.
 //
 type Writer struct {
+	// WriteTrailerRecords writes a File's TrailerRecords after its FileControl
+	WriteTrailerRecords bool
+
+	// WriterOpts customizes how records are written
+	WriterOpts
+
 	w       *bufio.Writer
 	lineNum int //current line being written
 }
 
+// WriterOpts customizes how a Writer renders files, for institutions with their own requirements
+// or to write the same bytes for a file every time.
+type WriterOpts struct {
+	// LineEnding is written after each record, "\n" when empty. Some institutions require "\r\n".
+	LineEnding string
+
+	// BypassBlockPadding doesn't pad the last block of 10 records with lines of 9's, for
+	// institutions which reject padded files
+	BypassBlockPadding bool
+
+	// Uppercase is which records have their lowercase letters written as uppercase
+	Uppercase UppercasePolicy
+
+	// FileCreation, when set, is written as the FileCreationDate and FileCreationTime instead of
+	// the FileHeader's, so a file which is rebuilt (e.g. by a job which sets them to the current
+	// time) can be written with the same bytes to match its hash or signature.
+	FileCreation time.Time
+}
+
+// UppercasePolicy is which records a Writer converts to uppercase
+type UppercasePolicy int
+
+const (
+	// UppercaseNone writes records as they are. It's the default.
+	UppercaseNone UppercasePolicy = iota
+	// UppercaseExceptAddenda converts every record but addenda, whose payment related information
+	// can be case sensitive (e.g. EDI in CTX addenda).
+	UppercaseExceptAddenda
+	// UppercaseAll converts every record
+	UppercaseAll
+)
+
+// NewWriterWithOpts returns a new Writer that writes to w as customized by opts
+func NewWriterWithOpts(w io.Writer, opts WriterOpts) *Writer {
+	writer := NewWriter(w)
+	writer.WriterOpts = opts
+	return writer
+}
+
 // NewWriter returns a new Writer that writes to w.
 func NewWriter(w io.Writer) *Writer {
 	return &Writer{
@@ -45,13 +91,16 @@ func (w *Writer) Write(file *File) error {
 	if err := file.Validate(); err != nil {
 		return err
 	}
+	return w.write(file)
+}
 
+// write renders file without validating it first
+func (w *Writer) write(file *File) error {
 	w.lineNum = 0
 	// Iterate over all records in the file
-	if _, err := w.w.WriteString(file.Header.String() + "\n"); err != nil {
+	if err := w.writeFileHeader(file.Header); err != nil {
 		return err
 	}
-	w.lineNum++
 
 	if err := w.writeBatch(file); err != nil {
 		return err
@@ -62,168 +111,243 @@ func (w *Writer) Write(file *File) error {
 	}
 
 	if !file.IsADV() {
-		if _, err := w.w.WriteString(file.Control.String() + "\n"); err != nil {
+		if err := w.writeLine(file.Control.String()); err != nil {
 			return err
 		}
 	} else {
-		if _, err := w.w.WriteString(file.ADVControl.String() + "\n"); err != nil {
+		if err := w.writeLine(file.ADVControl.String()); err != nil {
 			return err
 		}
 	}
 	w.lineNum++
 
-	// pad the final block
-	for i := 0; i < (10-(w.lineNum%10)) && w.lineNum%10 != 0; i++ {
-		if _, err := w.w.WriteString(strings.Repeat("9", 94) + "\n"); err != nil {
+	if w.WriteTrailerRecords {
+		if err := w.writeTrailerRecords(file); err != nil {
 			return err
 		}
 	}
 
+	if err := w.writeBlockPadding(); err != nil {
+		return err
+	}
 	return w.w.Flush()
 }
 
+// writeFileHeader writes header with the Writer's FileCreation
+func (w *Writer) writeFileHeader(header FileHeader) error {
+	if !w.FileCreation.IsZero() {
+		header.FileCreationDate = w.FileCreation.Format("060102") // YYMMDD
+		header.FileCreationTime = w.FileCreation.Format("1504")   // HHmm
+	}
+	if err := w.writeLine(header.String()); err != nil {
+		return err
+	}
+	w.lineNum++
+	return nil
+}
+
+// writeBlockPadding pads the final block with lines of 9's, unless BypassBlockPadding is set
+func (w *Writer) writeBlockPadding() error {
+	for i := 0; !w.BypassBlockPadding && i < (10-(w.lineNum%10)) && w.lineNum%10 != 0; i++ {
+		if err := w.writeLine(strings.Repeat("9", 94)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeLine writes record, converted to uppercase by the Writer's UppercasePolicy, and a LineEnding
+func (w *Writer) writeLine(record string) error {
+	switch w.Uppercase {
+	case UppercaseAll:
+		record = strings.ToUpper(record)
+	case UppercaseExceptAddenda:
+		if !strings.HasPrefix(record, entryAddendaPos) {
+			record = strings.ToUpper(record)
+		}
+	}
+	if _, err := w.w.WriteString(record); err != nil {
+		return err
+	}
+	ending := w.LineEnding
+	if ending == "" {
+		ending = "\n"
+	}
+	_, err := w.w.WriteString(ending)
+	return err
+}
+
 // Flush writes any buffered data to the underlying io.Writer.
 func (w *Writer) Flush() error {
 	return w.w.Flush()
 }
 
 func (w *Writer) writeBatch(file *File) error {
+	adv := file.IsADV()
 	for _, batch := range file.Batches {
-		if _, err := w.w.WriteString(batch.GetHeader().String() + "\n"); err != nil {
+		if err := w.writeBatcher(batch, adv); err != nil {
 			return err
 		}
-		w.lineNum++
-		if !file.IsADV() {
-			for _, entry := range batch.GetEntries() {
-				if _, err := w.w.WriteString(entry.String() + "\n"); err != nil {
+	}
+	return nil
+}
+
+// writeBatcher writes batch's records, adv is true for batches of an ADV file
+func (w *Writer) writeBatcher(batch Batcher, adv bool) error {
+	if err := w.writeLine(batch.GetHeader().String()); err != nil {
+		return err
+	}
+	w.lineNum++
+	if !adv {
+		for _, entry := range batch.GetEntries() {
+			if err := w.writeLine(entry.String()); err != nil {
+				return err
+			}
+			w.lineNum++
+
+			if entry.Addenda02 != nil {
+				if err := w.writeLine(entry.Addenda02.String()); err != nil {
 					return err
 				}
 				w.lineNum++
-
-				if entry.Addenda02 != nil {
-					if _, err := w.w.WriteString(entry.Addenda02.String() + "\n"); err != nil {
-						return err
-					}
-					w.lineNum++
-				}
-				for _, addenda05 := range entry.Addenda05 {
-					if _, err := w.w.WriteString(addenda05.String() + "\n"); err != nil {
-						return err
-					}
-					w.lineNum++
+			}
+			for _, addenda05 := range entry.Addenda05 {
+				if err := w.writeLine(addenda05.String()); err != nil {
+					return err
 				}
-				if entry.Addenda98 != nil {
-					if _, err := w.w.WriteString(entry.Addenda98.String() + "\n"); err != nil {
-						return err
-					}
-					w.lineNum++
+				w.lineNum++
+			}
+			if entry.Addenda98 != nil {
+				if err := w.writeLine(entry.Addenda98.String()); err != nil {
+					return err
 				}
-				if entry.Addenda99 != nil {
-					if _, err := w.w.WriteString(entry.Addenda99.String() + "\n"); err != nil {
-						return err
-					}
-					w.lineNum++
+				w.lineNum++
+			}
+			if entry.Addenda99 != nil {
+				if err := w.writeLine(entry.Addenda99.String()); err != nil {
+					return err
 				}
+				w.lineNum++
 			}
-		} else {
-			for _, entry := range batch.GetADVEntries() {
-				if _, err := w.w.WriteString(entry.String() + "\n"); err != nil {
+			if entry.Addenda99Dishonored != nil {
+				if err := w.writeLine(entry.Addenda99Dishonored.String()); err != nil {
 					return err
 				}
 				w.lineNum++
-				if entry.Addenda99 != nil {
-					if _, err := w.w.WriteString(entry.Addenda99.String() + "\n"); err != nil {
-						return err
-					}
-					w.lineNum++
+			}
+			if entry.Addenda99Contested != nil {
+				if err := w.writeLine(entry.Addenda99Contested.String()); err != nil {
+					return err
 				}
+				w.lineNum++
 			}
 		}
-
-		if batch.GetHeader().StandardEntryClassCode != ADV {
-			if _, err := w.w.WriteString(batch.GetControl().String() + "\n"); err != nil {
+	} else {
+		for _, entry := range batch.GetADVEntries() {
+			if err := w.writeLine(entry.String()); err != nil {
 				return err
 			}
-		} else {
-			if _, err := w.w.WriteString(batch.GetADVControl().String() + "\n"); err != nil {
-				return err
+			w.lineNum++
+			if entry.Addenda99 != nil {
+				if err := w.writeLine(entry.Addenda99.String()); err != nil {
+					return err
+				}
+				w.lineNum++
 			}
 		}
-		w.lineNum++
 	}
+
+	if batch.GetHeader().StandardEntryClassCode != ADV {
+		if err := w.writeLine(batch.GetControl().String()); err != nil {
+			return err
+		}
+	} else {
+		if err := w.writeLine(batch.GetADVControl().String()); err != nil {
+			return err
+		}
+	}
+	w.lineNum++
 	return nil
 }
 
 func (w *Writer) writeIATBatch(file *File) error {
-	for _, iatBatch := range file.IATBatches {
-		if _, err := w.w.WriteString(iatBatch.GetHeader().String() + "\n"); err != nil {
+	for i := range file.IATBatches {
+		if err := w.writeIATBatcher(&file.IATBatches[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeIATBatcher writes iatBatch's records
+func (w *Writer) writeIATBatcher(iatBatch *IATBatch) error {
+	if err := w.writeLine(iatBatch.GetHeader().String()); err != nil {
+		return err
+	}
+	w.lineNum++
+	for _, entry := range iatBatch.GetEntries() {
+		if err := w.writeLine(entry.String()); err != nil {
 			return err
 		}
 		w.lineNum++
-		for _, entry := range iatBatch.GetEntries() {
-			if _, err := w.w.WriteString(entry.String() + "\n"); err != nil {
-				return err
-			}
-			w.lineNum++
-			if _, err := w.w.WriteString(entry.Addenda10.String() + "\n"); err != nil {
-				return err
-			}
-			w.lineNum++
-			if _, err := w.w.WriteString(entry.Addenda11.String() + "\n"); err != nil {
-				return err
-			}
-			w.lineNum++
-			if _, err := w.w.WriteString(entry.Addenda12.String() + "\n"); err != nil {
-				return err
-			}
-			w.lineNum++
-			if _, err := w.w.WriteString(entry.Addenda13.String() + "\n"); err != nil {
+		if err := w.writeLine(entry.Addenda10.String()); err != nil {
+			return err
+		}
+		w.lineNum++
+		if err := w.writeLine(entry.Addenda11.String()); err != nil {
+			return err
+		}
+		w.lineNum++
+		if err := w.writeLine(entry.Addenda12.String()); err != nil {
+			return err
+		}
+		w.lineNum++
+		if err := w.writeLine(entry.Addenda13.String()); err != nil {
+			return err
+		}
+		w.lineNum++
+		if err := w.writeLine(entry.Addenda14.String()); err != nil {
+			return err
+		}
+		w.lineNum++
+		if err := w.writeLine(entry.Addenda15.String()); err != nil {
+			return err
+		}
+		w.lineNum++
+		if err := w.writeLine(entry.Addenda16.String()); err != nil {
+			return err
+		}
+		w.lineNum++
+		// IAT Addenda17
+		for _, addenda17 := range entry.Addenda17 {
+			if err := w.writeLine(addenda17.String()); err != nil {
 				return err
 			}
 			w.lineNum++
-			if _, err := w.w.WriteString(entry.Addenda14.String() + "\n"); err != nil {
+		}
+		// IAT Addenda18
+		for _, addenda18 := range entry.Addenda18 {
+			if err := w.writeLine(addenda18.String()); err != nil {
 				return err
 			}
 			w.lineNum++
-			if _, err := w.w.WriteString(entry.Addenda15.String() + "\n"); err != nil {
+		}
+		if entry.Addenda98 != nil {
+			if err := w.writeLine(entry.Addenda98.String()); err != nil {
 				return err
 			}
 			w.lineNum++
-			if _, err := w.w.WriteString(entry.Addenda16.String() + "\n"); err != nil {
+		}
+		if entry.Addenda99 != nil {
+			if err := w.writeLine(entry.Addenda99.String()); err != nil {
 				return err
 			}
 			w.lineNum++
-			// IAT Addenda17
-			for _, addenda17 := range entry.Addenda17 {
-				if _, err := w.w.WriteString(addenda17.String() + "\n"); err != nil {
-					return err
-				}
-				w.lineNum++
-			}
-			// IAT Addenda18
-			for _, addenda18 := range entry.Addenda18 {
-				if _, err := w.w.WriteString(addenda18.String() + "\n"); err != nil {
-					return err
-				}
-				w.lineNum++
-			}
-			if entry.Addenda98 != nil {
-				if _, err := w.w.WriteString(entry.Addenda98.String() + "\n"); err != nil {
-					return err
-				}
-				w.lineNum++
-			}
-			if entry.Addenda99 != nil {
-				if _, err := w.w.WriteString(entry.Addenda99.String() + "\n"); err != nil {
-					return err
-				}
-				w.lineNum++
-			}
-		}
-		if _, err := w.w.WriteString(iatBatch.GetControl().String() + "\n"); err != nil {
-			return err
 		}
-		w.lineNum++
 	}
+	if err := w.writeLine(iatBatch.GetControl().String()); err != nil {
+		return err
+	}
+	w.lineNum++
 	return nil
 }
@@ -19,8 +19,10 @@ package ach
 
 import (
 	"bytes"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/moov-io/base"
 )
@@ -681,3 +683,103 @@ func TestADVReturnWrite(t *testing.T) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
+
+func TestWriter__LineEndingBypassBlockPadding(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.LineEnding = "\r\n"
+	w.BypassBlockPadding = true
+	if err := w.Write(file); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	if len(lines) != 5 || strings.HasPrefix(lines[4], "99") {
+		t.Errorf("unexpected lines: %q", lines)
+	}
+	for i := range lines {
+		if len(lines[i]) != RecordLength {
+			t.Errorf("line %d: %q", i+1, lines[i])
+		}
+	}
+
+	read, err := NewReader(&buf).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := read.Validate(); err != nil {
+		t.Error(err)
+	}
+	if read.Control.BlockCount != file.Control.BlockCount {
+		t.Errorf("BlockCount=%d", read.Control.BlockCount)
+	}
+}
+
+func TestWriter__WriterOpts(t *testing.T) {
+	file := NewFile().SetHeader(mockFileHeader())
+	entry := mockEntryDetail()
+	entry.IndividualName = "receiver name"
+	entry.AddendaRecordIndicator = 1
+	entry.AddAddenda05(mockAddenda05())
+	entry.Addenda05[0].PaymentRelatedInformation = "case*Sensitive~"
+	batch := NewBatchPPD(mockBatchPPDHeader())
+	batch.AddEntry(entry)
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+	file.AddBatch(batch)
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := WriterOpts{
+		Uppercase:    UppercaseExceptAddenda,
+		FileCreation: time.Date(2026, time.October, 16, 9, 30, 0, 0, time.UTC),
+	}
+	write := func(opts WriterOpts) string {
+		var buf bytes.Buffer
+		if err := NewWriterWithOpts(&buf, opts).Write(file); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+	first := write(opts)
+	file.Header.FileCreationDate = time.Now().Format("060102")
+	file.Header.FileCreationTime = time.Now().Format("1504")
+	if second := write(opts); first != second {
+		t.Errorf("files differ:\n%s\n%s", first, second)
+	}
+	lines := strings.Split(first, "\n")
+	if v := lines[0][23:33]; v != "2610160930" {
+		t.Errorf("FileCreationDate and FileCreationTime: %s", v)
+	}
+	if !strings.Contains(lines[2], "RECEIVER NAME") || !strings.Contains(lines[3], "case*Sensitive~") {
+		t.Errorf("unexpected records:\n%s\n%s", lines[2], lines[3])
+	}
+
+	opts.Uppercase = UppercaseAll
+	if lines := strings.Split(write(opts), "\n"); !strings.Contains(lines[3], "CASE*SENSITIVE~") {
+		t.Errorf("unexpected addenda: %s", lines[3])
+	}
+	if lines := strings.Split(write(WriterOpts{}), "\n"); !strings.Contains(lines[2], "receiver name") {
+		t.Errorf("unexpected entry: %s", lines[2])
+	}
+}
+
+func BenchmarkWriter(b *testing.B) {
+	file := mockFileBatches(b, 1000)
+	var buf bytes.Buffer
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := NewWriter(&buf).Write(file); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.SetBytes(int64(buf.Len()))
+}